@@ -4,25 +4,90 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/julimonteiro/cupcake-store/internal/captcha"
 	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/currency"
 	"github.com/julimonteiro/cupcake-store/internal/database"
+	"github.com/julimonteiro/cupcake-store/internal/email"
+	"github.com/julimonteiro/cupcake-store/internal/events"
+	"github.com/julimonteiro/cupcake-store/internal/health"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/lifecycle"
+	"github.com/julimonteiro/cupcake-store/internal/locale"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/oauth"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"github.com/julimonteiro/cupcake-store/internal/printer"
+	"github.com/julimonteiro/cupcake-store/internal/push"
+	"github.com/julimonteiro/cupcake-store/internal/redact"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/repository/inmem"
 	"github.com/julimonteiro/cupcake-store/internal/router"
+	"github.com/julimonteiro/cupcake-store/internal/scheduler"
+	"github.com/julimonteiro/cupcake-store/internal/search"
+	"github.com/julimonteiro/cupcake-store/internal/secrets"
+	"github.com/julimonteiro/cupcake-store/internal/server"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/sms"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	redact.Install()
+
 	if err := godotenv.Load(); err != nil {
 		log.Println(".env file not found, using system environment variables")
 	}
 
 	cfg := config.Load()
 
+	// With a secrets provider, the variables the environment leaves unset
+	// are fetched from it before anything reads the configuration.
+	secretProvider, err := secrets.New(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring secrets provider: %v", err)
+	}
+	var secretStore *secrets.Store
+	if secretProvider != nil {
+		secretStore = secrets.NewStore(secretProvider)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := secretStore.Refresh(ctx)
+		cancel()
+		if err != nil {
+			log.Fatalf("Error loading secrets: %v", err)
+		}
+		cfg = config.Load(secretStore)
+		log.Printf("Loaded configuration secrets from %s", cfg.SecretsProvider)
+	}
+
+	// The keys must be in place before migrating, which encrypts the
+	// customer data still stored in plain text and re-encrypts what was
+	// sealed with a previous key.
+	var previousKeys []string
+	for _, key := range strings.Split(cfg.PIIPreviousKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			previousKeys = append(previousKeys, key)
+		}
+	}
+	if err := pii.Configure(cfg.PIIEncryptionKey, previousKeys...); err != nil {
+		log.Fatalf("Invalid PII_ENCRYPTION_KEY or PII_PREVIOUS_KEYS: %v", err)
+	}
+	if !pii.Enabled() {
+		log.Println("PII_ENCRYPTION_KEY not set, storing customer data unencrypted")
+	}
+
 	db, err := database.Init(cfg)
 	if err != nil {
 		log.Fatalf("Error connecting to database: %v", err)
@@ -32,9 +97,351 @@ func main() {
 	if err != nil {
 		log.Fatalf("Error getting database instance: %v", err)
 	}
-	defer sqlDB.Close()
 
-	r := router.Setup(db)
+	// Components are stopped in reverse order of registration: servers
+	// first, then background work, and the database last.
+	lc := lifecycle.New()
+	lc.Add("database", 5*time.Second, lifecycle.Close(sqlDB.Close))
+
+	// The admin token is swapped in place when rotated; the other secrets
+	// are read once at startup and need a restart.
+	adminToken := cfg.AdminToken
+	var adminTokenFunc func() string
+	if secretStore != nil {
+		refreshInterval, err := time.ParseDuration(cfg.SecretsRefreshInterval)
+		if cfg.SecretsRefreshInterval == "0" {
+			refreshInterval, err = 0, nil
+		}
+		if err != nil || refreshInterval < 0 {
+			log.Fatalf("Invalid SECRETS_REFRESH_INTERVAL %q: must be a duration such as 5m, or 0", cfg.SecretsRefreshInterval)
+		}
+
+		if adminToken != "" && os.Getenv("ADMIN_TOKEN") == "" {
+			var current atomic.Value
+			current.Store(adminToken)
+			adminTokenFunc = func() string { return current.Load().(string) }
+			secretStore.OnRotate("ADMIN_TOKEN", func(value string) {
+				current.Store(value)
+				log.Println("ADMIN_TOKEN rotated")
+			})
+		}
+		for _, key := range []string{"DB_DSN", "DB_READ_DSNS", "RABBITMQ_URL", "DATA_EXPORT_SECRET", "ERASURE_SECRET", "AUTH_TOKEN_SECRET", "GOOGLE_CLIENT_SECRET", "GITHUB_CLIENT_SECRET", "CAPTCHA_SECRET", "TWILIO_AUTH_TOKEN", "SMTP_PASSWORD", "PRINT_WEBHOOK_SECRET", "FCM_CREDENTIALS"} {
+			if os.Getenv(key) == "" {
+				secretStore.OnRotate(key, func(string) {
+					log.Printf("%s rotated; restart to apply it", key)
+				})
+			}
+		}
+		// A new PII key alone cannot read what the old one sealed, so its
+		// change is not a rotation to apply on restart.
+		if os.Getenv("PII_ENCRYPTION_KEY") == "" {
+			secretStore.OnRotate("PII_ENCRYPTION_KEY", func(string) {
+				log.Println("PII_ENCRYPTION_KEY changed; keep the previous key in PII_PREVIOUS_KEYS before restarting, or the data it encrypted cannot be read")
+			})
+		}
+
+		if refreshInterval > 0 {
+			secretsCtx, stopSecrets := context.WithCancel(context.Background())
+			go secretStore.Run(secretsCtx, refreshInterval)
+			lc.Add("secrets refresh", time.Second, func(context.Context) error {
+				stopSecrets()
+				return nil
+			})
+		}
+	}
+
+	if adminToken == "" {
+		log.Println("ADMIN_TOKEN is not set: only admin accounts can use the admin API")
+	}
+
+	httpClientSettings, err := httpclient.FromConfig(cfg)
+	if err != nil {
+		log.Fatalf("Invalid HTTP client settings: %v", err)
+	}
+
+	publisher, err := events.New(cfg)
+	if err != nil {
+		log.Fatalf("Error connecting to events broker: %v", err)
+	}
+	emitter := events.NewEmitter(publisher)
+	lc.Add("event emitter", 10*time.Second, lifecycle.Close(emitter.Close))
+
+	searchIndex, err := search.New(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring search: %v", err)
+	}
+	var cupcakeEvents service.EventPublisher = emitter
+	if searchIndex != nil {
+		indexer := search.NewIndexer(searchIndex)
+		lc.Add("search indexer", 10*time.Second, lifecycle.Close(indexer.Close))
+		cupcakeEvents = service.EventPublishers{emitter, indexer}
+	}
+
+	jobService := service.NewJobService(repository.NewJobRepository(db))
+
+	viewCounter := service.NewViewCounter(repository.NewViewRepository(db))
+	viewsCtx, stopViews := context.WithCancel(context.Background())
+	viewsDone := make(chan struct{})
+	go func() {
+		defer close(viewsDone)
+		viewCounter.Run(viewsCtx, 10*time.Second)
+	}()
+	// Stopping the counter flushes the views still buffered.
+	lc.Add("view counter", 10*time.Second, func(ctx context.Context) error {
+		stopViews()
+		select {
+		case <-viewsDone:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	sched, err := scheduler.New(map[string]string{
+		scheduler.TaskExpireCoupons: cfg.ScheduleExpireCoupons,
+		scheduler.TaskPurgeJobs:     cfg.SchedulePurgeJobs,
+		scheduler.TaskCheckStock:    cfg.ScheduleCheckStock,
+	})
+	if err != nil {
+		log.Fatalf("Error configuring scheduler: %v", err)
+	}
+
+	rates, err := currency.ParseStaticRates(cfg.BaseCurrency, cfg.ExchangeRates)
+	if err != nil {
+		log.Fatalf("Error configuring exchange rates: %v", err)
+	}
+
+	compressionLevel, err := strconv.Atoi(cfg.CompressionLevel)
+	if err != nil || compressionLevel < 0 || compressionLevel > 9 {
+		log.Fatalf("Invalid COMPRESSION_LEVEL %q: must be 0 (off) to 9", cfg.CompressionLevel)
+	}
+	compressionBrotli, err := strconv.ParseBool(cfg.CompressionBrotli)
+	if err != nil {
+		log.Fatalf("Invalid COMPRESSION_BROTLI %q: %v", cfg.CompressionBrotli, err)
+	}
+
+	maxBodyBytes, err := strconv.ParseInt(cfg.MaxBodyBytes, 10, 64)
+	if err != nil || maxBodyBytes <= 0 {
+		log.Fatalf("Invalid MAX_BODY_BYTES %q: must be a positive number of bytes", cfg.MaxBodyBytes)
+	}
+
+	publicRateLimit, err := strconv.Atoi(cfg.PublicRateLimit)
+	if err != nil || publicRateLimit < 0 {
+		log.Fatalf("Invalid PUBLIC_RATE_LIMIT %q: must be 0 (unlimited) or a number of requests per minute", cfg.PublicRateLimit)
+	}
+	adminRateLimit, err := strconv.Atoi(cfg.AdminRateLimit)
+	if err != nil || adminRateLimit < 0 {
+		log.Fatalf("Invalid ADMIN_RATE_LIMIT %q: must be 0 (unlimited) or a number of requests per minute", cfg.AdminRateLimit)
+	}
+	if cfg.AdminPort != "" && cfg.AdminPort == cfg.Port {
+		log.Fatalf("ADMIN_PORT must differ from PORT")
+	}
+	adminNetworks, err := router.ParseNetworks(cfg.AdminAllowedNetworks)
+	if err != nil {
+		log.Fatalf("Invalid ADMIN_ALLOWED_NETWORKS: %v", err)
+	}
+	trustedProxies, err := router.ParseNetworks(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
+	maintenanceMode, err := strconv.ParseBool(cfg.MaintenanceMode)
+	if err != nil {
+		log.Fatalf("Invalid MAINTENANCE_MODE %q: %v", cfg.MaintenanceMode, err)
+	}
+	readOnlyMode, err := strconv.ParseBool(cfg.ReadOnlyMode)
+	if err != nil {
+		log.Fatalf("Invalid READ_ONLY_MODE %q: %v", cfg.ReadOnlyMode, err)
+	}
+	retryAfter, err := time.ParseDuration(cfg.MaintenanceRetryAfter)
+	if err != nil || retryAfter < time.Second {
+		log.Fatalf("Invalid MAINTENANCE_RETRY_AFTER %q: must be a duration of at least 1s", cfg.MaintenanceRetryAfter)
+	}
+
+	var rules models.ValidationRules
+	if rules.NameMinLength, err = strconv.Atoi(cfg.CupcakeNameMinLength); err != nil {
+		log.Fatalf("Invalid CUPCAKE_NAME_MIN_LENGTH %q: %v", cfg.CupcakeNameMinLength, err)
+	}
+	if rules.NameMaxLength, err = strconv.Atoi(cfg.CupcakeNameMaxLength); err != nil {
+		log.Fatalf("Invalid CUPCAKE_NAME_MAX_LENGTH %q: %v", cfg.CupcakeNameMaxLength, err)
+	}
+	if rules.MaxPriceCents, err = strconv.Atoi(cfg.CupcakeMaxPriceCents); err != nil {
+		log.Fatalf("Invalid CUPCAKE_MAX_PRICE_CENTS %q: %v", cfg.CupcakeMaxPriceCents, err)
+	}
+	validation, err := service.NewValidationService(rules)
+	if err != nil {
+		log.Fatalf("Invalid cupcake validation rules: %v", err)
+	}
+
+	passwordParams := password.DefaultParams
+	memory, err := strconv.ParseUint(cfg.PasswordArgon2Memory, 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid PASSWORD_ARGON2_MEMORY %q: %v", cfg.PasswordArgon2Memory, err)
+	}
+	iterations, err := strconv.ParseUint(cfg.PasswordArgon2Iterations, 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid PASSWORD_ARGON2_ITERATIONS %q: %v", cfg.PasswordArgon2Iterations, err)
+	}
+	parallelism, err := strconv.ParseUint(cfg.PasswordArgon2Parallelism, 10, 8)
+	if err != nil {
+		log.Fatalf("Invalid PASSWORD_ARGON2_PARALLELISM %q: %v", cfg.PasswordArgon2Parallelism, err)
+	}
+	passwordParams.Memory = uint32(memory)
+	passwordParams.Iterations = uint32(iterations)
+	passwordParams.Parallelism = uint8(parallelism)
+	if err := passwordParams.Validate(); err != nil {
+		log.Fatalf("Invalid password hashing parameters: %v", err)
+	}
+	authTokenTTL, err := time.ParseDuration(cfg.AuthTokenTTL)
+	if err != nil || authTokenTTL < time.Minute {
+		log.Fatalf("Invalid AUTH_TOKEN_TTL %q: must be a duration of at least 1m", cfg.AuthTokenTTL)
+	}
+	jobRetention, err := time.ParseDuration(cfg.JobRetention)
+	if err != nil || jobRetention < time.Hour {
+		log.Fatalf("Invalid JOB_RETENTION %q: must be a duration of at least 1h", cfg.JobRetention)
+	}
+	sessionTTL, err := time.ParseDuration(cfg.SessionTTL)
+	if err != nil || sessionTTL < time.Minute {
+		log.Fatalf("Invalid SESSION_TTL %q: must be a duration of at least 1m", cfg.SessionTTL)
+	}
+	requireVerifiedEmail, err := strconv.ParseBool(cfg.RequireVerifiedEmail)
+	if err != nil {
+		log.Fatalf("Invalid REQUIRE_VERIFIED_EMAIL %q: %v", cfg.RequireVerifiedEmail, err)
+	}
+
+	oauthProviders, err := oauth.New(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring social login: %v", err)
+	}
+	if len(oauthProviders) > 0 && cfg.PublicURL == "" {
+		log.Fatalf("PUBLIC_URL is required with social login, for the callback URL registered with the providers")
+	}
+	captchaVerifier, err := captcha.New(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring captcha: %v", err)
+	}
+	captchaEndpoints, err := router.ParseCaptchaEndpoints(cfg.CaptchaEndpoints)
+	if err != nil {
+		log.Fatalf("Invalid CAPTCHA_ENDPOINTS: %v", err)
+	}
+	smsSender, err := sms.New(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring SMS: %v", err)
+	}
+	pushSender, err := push.New(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring push notifications: %v", err)
+	}
+	ticketPrinter, err := printer.New(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring printing: %v", err)
+	}
+	emailSender, err := email.New(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring email: %v", err)
+	}
+	if emailSender != nil && cfg.PublicURL == "" {
+		log.Fatalf("PUBLIC_URL is required with EMAIL_PROVIDER, for the links sent by email")
+	}
+
+	defaultLocale, ok := locale.Normalize(cfg.DefaultLocale)
+	if !ok {
+		log.Fatalf("Invalid DEFAULT_LOCALE %q: must be a language tag such as pt-BR", cfg.DefaultLocale)
+	}
+
+	var grpcServer *grpc.Server
+	if cfg.GRPCPort != "" {
+		grpcServer = grpc.NewServer()
+	}
+
+	var cupcakeRepo repository.CupcakeRepositoryInterface
+	if cfg.DBDialect == database.DialectMemory {
+		cupcakeRepo = inmem.NewCupcakeRepository()
+	}
+
+	// Readiness covers the database and, when one is configured, the events
+	// broker; each check gets its own deadline.
+	checker := health.New()
+	checker.Add("database", router.DatabasePingTimeout, router.PingDatabase(db))
+	if pinger, ok := publisher.(events.Pinger); ok {
+		checker.Add("broker", 3*time.Second, pinger.Ping)
+	}
+
+	opts := router.Options{
+		Publisher:    cupcakeEvents,
+		Jobs:         jobService,
+		JobRetention: jobRetention,
+		Views:        viewCounter,
+		Scheduler:    sched,
+		Converter:    currency.NewConverter(cfg.BaseCurrency, rates),
+		GRPCServer:   grpcServer,
+
+		CompressionLevel: compressionLevel,
+		Brotli:           compressionBrotli,
+		MaxBodyBytes:     maxBodyBytes,
+		Maintenance:      service.NewMaintenanceService(maintenanceMode, readOnlyMode, retryAfter),
+		Validation:       validation,
+
+		CupcakeRepository: cupcakeRepo,
+		SearchIndex:       searchIndex,
+		Health:            checker,
+		DefaultLocale:     defaultLocale,
+
+		AdminToken:      adminToken,
+		AdminTokenFunc:  adminTokenFunc,
+		PublicRateLimit: publicRateLimit,
+		AdminRateLimit:  adminRateLimit,
+		AdminNetworks:   adminNetworks,
+		TrustedProxies:  trustedProxies,
+
+		DataExportSecret: cfg.DataExportSecret,
+		ErasureSecret:    cfg.ErasureSecret,
+
+		PasswordParams:       passwordParams,
+		AuthTokenSecret:      cfg.AuthTokenSecret,
+		AuthTokenTTL:         authTokenTTL,
+		SessionTTL:           sessionTTL,
+		RequireVerifiedEmail: requireVerifiedEmail,
+		OAuthProviders:       oauthProviders,
+		Captcha:              captchaVerifier,
+		CaptchaEndpoints:     captchaEndpoints,
+		SMS:                  smsSender,
+		Push:                 pushSender,
+		Email:                emailSender,
+		Printer:              ticketPrinter,
+		PublicURL:            cfg.PublicURL,
+		HTTPClient:           httpClientSettings,
+	}
+
+	// With ADMIN_PORT set the admin API gets a listener of its own, so it
+	// can be kept off the public network.
+	var r, adminHandler http.Handler
+	if cfg.AdminPort != "" {
+		r, adminHandler = router.SetupSplit(db, opts)
+	} else {
+		r = router.Setup(db, opts)
+	}
+	// The worker starts only now: setting up the router registers the job
+	// handlers, and jobs claimed before would fail for want of one.
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		jobService.Run(workerCtx, time.Second)
+	}()
+	// Webhook deliveries run as jobs, so stopping the worker also lets the
+	// in-flight delivery finish.
+	lc.Add("job worker", 30*time.Second, func(ctx context.Context) error {
+		stopWorker()
+		select {
+		case <-workerDone:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	sched.Start()
+	lc.Add("scheduler", 30*time.Second, lifecycle.Wait(sched.Stop))
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.Port),
@@ -44,26 +451,127 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	autocertDomains := server.ParseDomains(cfg.TLSAutocertDomains)
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		log.Fatalf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if cfg.TLSCertFile != "" && len(autocertDomains) > 0 {
+		log.Fatalf("Set either TLS_CERT_FILE/TLS_KEY_FILE or TLS_AUTOCERT_DOMAINS, not both")
+	}
+
+	var redirect http.Handler = server.RedirectHTTPS(cfg.Port)
+	switch {
+	case cfg.TLSCertFile != "":
+		srv.TLSConfig = server.TLSConfig()
+	case len(autocertDomains) > 0:
+		manager, tlsConfig := server.Autocert(autocertDomains, cfg.TLSAutocertCacheDir)
+		srv.TLSConfig = tlsConfig
+		redirect = manager.HTTPHandler(redirect)
+	}
+
+	var adminSrv *http.Server
+	if adminHandler != nil {
+		adminSrv = &http.Server{
+			Addr:         fmt.Sprintf(":%s", cfg.AdminPort),
+			Handler:      adminHandler,
+			TLSConfig:    srv.TLSConfig,
+			ReadTimeout:  srv.ReadTimeout,
+			WriteTimeout: srv.WriteTimeout,
+			IdleTimeout:  srv.IdleTimeout,
+		}
+	}
+
+	var redirectSrv *http.Server
+	if cfg.HTTPRedirectPort != "" {
+		if srv.TLSConfig == nil {
+			log.Fatalf("HTTP_REDIRECT_PORT requires TLS_CERT_FILE/TLS_KEY_FILE or TLS_AUTOCERT_DOMAINS")
+		}
+		redirectSrv = &http.Server{
+			Addr:         fmt.Sprintf(":%s", cfg.HTTPRedirectPort),
+			Handler:      redirect,
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 5 * time.Second,
+		}
+	}
+
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
 	go func() {
-		log.Printf("Server started on port %s", cfg.Port)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if srv.TLSConfig != nil {
+			log.Printf("Server started on port %s (HTTPS)", cfg.Port)
+			err = srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+		} else {
+			log.Printf("Server started on port %s", cfg.Port)
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Error starting server: %v", err)
 		}
 	}()
+	lc.Add("HTTP server", 30*time.Second, srv.Shutdown)
+
+	if adminSrv != nil {
+		go func() {
+			var err error
+			if adminSrv.TLSConfig != nil {
+				log.Printf("Admin server started on port %s (HTTPS)", cfg.AdminPort)
+				err = adminSrv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+			} else {
+				log.Printf("Admin server started on port %s", cfg.AdminPort)
+				err = adminSrv.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Error starting admin server: %v", err)
+			}
+		}()
+		lc.Add("admin HTTP server", 30*time.Second, adminSrv.Shutdown)
+	}
+
+	if redirectSrv != nil {
+		go func() {
+			log.Printf("Redirecting HTTP on port %s to HTTPS", cfg.HTTPRedirectPort)
+			if err := redirectSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Error starting HTTP redirect server: %v", err)
+			}
+		}()
+		lc.Add("HTTP redirect server", 5*time.Second, redirectSrv.Shutdown)
+	}
+
+	if grpcServer != nil {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPCPort))
+		if err != nil {
+			log.Fatalf("Error listening on gRPC port: %v", err)
+		}
+		go func() {
+			log.Printf("gRPC server started on port %s", cfg.GRPCPort)
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("Error starting gRPC server: %v", err)
+			}
+		}()
+		lc.Add("gRPC server", 10*time.Second, func(ctx context.Context) error {
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				grpcServer.Stop()
+				return ctx.Err()
+			}
+		})
+	}
 
 	<-done
 	log.Println("Server shutting down...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Error during server shutdown: %v", err)
+	if err := lc.Shutdown(context.Background()); err != nil {
+		log.Fatalf("Error during shutdown: %v", err)
 	}
 
 	log.Println("Server stopped successfully")
 }
-
@@ -0,0 +1,97 @@
+// Package captcha verifies the tokens of captcha widgets with their
+// provider: Cloudflare Turnstile, hCaptcha or Google reCAPTCHA (v2). All
+// three take the same siteverify request, so they differ only in URL.
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+var verifyURLs = map[string]string{
+	"turnstile": "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+	"hcaptcha":  "https://api.hcaptcha.com/siteverify",
+	"recaptcha": "https://www.google.com/recaptcha/api/siteverify",
+}
+
+// New returns the verifier of CAPTCHA_PROVIDER, or nil when it is none.
+func New(cfg *config.Config) (service.CaptchaVerifier, error) {
+	if cfg.CaptchaProvider == "" || cfg.CaptchaProvider == "none" {
+		return nil, nil
+	}
+	verifyURL, ok := verifyURLs[cfg.CaptchaProvider]
+	if !ok {
+		return nil, fmt.Errorf("unknown CAPTCHA_PROVIDER %q: must be none, turnstile, hcaptcha or recaptcha", cfg.CaptchaProvider)
+	}
+	if cfg.CaptchaSecret == "" {
+		return nil, fmt.Errorf("CAPTCHA_SECRET is required with CAPTCHA_PROVIDER=%s", cfg.CaptchaProvider)
+	}
+	settings, err := httpclient.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{
+		provider:  cfg.CaptchaProvider,
+		verifyURL: verifyURL,
+		secret:    cfg.CaptchaSecret,
+		http:      httpclient.New("captcha", 5*time.Second, settings),
+	}, nil
+}
+
+// Verifier checks tokens with a provider's siteverify endpoint.
+type Verifier struct {
+	provider  string
+	verifyURL string
+	secret    string
+	http      *http.Client
+}
+
+var _ service.CaptchaVerifier = (*Verifier)(nil)
+
+func (v *Verifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.http.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", v.provider, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return false, fmt.Errorf("%s: siteverify: %s: %s", v.provider, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("%s: decoding response: %w", v.provider, err)
+	}
+	// A bad secret fails every token; that is the store's fault, not the
+	// client's.
+	for _, code := range result.ErrorCodes {
+		if code == "invalid-input-secret" || code == "missing-input-secret" {
+			return false, fmt.Errorf("%s: siteverify: %s", v.provider, code)
+		}
+	}
+	return result.Success, nil
+}
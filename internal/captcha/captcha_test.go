@@ -0,0 +1,56 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	verifier, err := New(&config.Config{CaptchaProvider: "none"})
+	require.NoError(t, err)
+	require.Nil(t, verifier)
+
+	verifier, err = New(&config.Config{CaptchaProvider: "turnstile", CaptchaSecret: "secret"})
+	require.NoError(t, err)
+	require.Equal(t, "https://challenges.cloudflare.com/turnstile/v0/siteverify", verifier.(*Verifier).verifyURL)
+
+	_, err = New(&config.Config{CaptchaProvider: "hcaptcha"})
+	require.EqualError(t, err, "CAPTCHA_SECRET is required with CAPTCHA_PROVIDER=hcaptcha")
+	_, err = New(&config.Config{CaptchaProvider: "friendly", CaptchaSecret: "secret"})
+	require.ErrorContains(t, err, `unknown CAPTCHA_PROVIDER "friendly"`)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		switch {
+		case r.PostForm.Get("secret") != "secret":
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-secret"]}`))
+		case r.PostForm.Get("response") == "good" && r.PostForm.Get("remoteip") == "192.0.2.1":
+			w.Write([]byte(`{"success":true}`))
+		default:
+			w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+		}
+	}))
+	defer server.Close()
+	verifier, err := New(&config.Config{CaptchaProvider: "turnstile", CaptchaSecret: "secret"})
+	require.NoError(t, err)
+	verifier.(*Verifier).verifyURL = server.URL
+	ctx := context.Background()
+
+	ok, err := verifier.Verify(ctx, "good", "192.0.2.1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = verifier.Verify(ctx, "forged", "192.0.2.1")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	verifier.(*Verifier).secret = "wrong"
+	_, err = verifier.Verify(ctx, "good", "192.0.2.1")
+	require.EqualError(t, err, "turnstile: siteverify: invalid-input-secret")
+}
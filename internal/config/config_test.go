@@ -93,6 +93,68 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoad_Events(t *testing.T) {
+	tests := []struct {
+		name           string
+		envVars        map[string]string
+		expectedBroker string
+		expectedTopic  string
+	}{
+		{
+			name:           "events disabled by default",
+			envVars:        map[string]string{},
+			expectedBroker: "none",
+			expectedTopic:  "cupcake-store.events",
+		},
+		{
+			name: "kafka broker from environment",
+			envVars: map[string]string{
+				"EVENTS_BROKER": "kafka",
+				"EVENTS_TOPIC":  "catalog",
+			},
+			expectedBroker: "kafka",
+			expectedTopic:  "catalog",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+
+			for key, value := range tt.envVars {
+				os.Setenv(key, value)
+			}
+
+			cfg := Load()
+
+			require.Equal(t, tt.expectedBroker, cfg.EventsBroker)
+			require.Equal(t, tt.expectedTopic, cfg.EventsTopic)
+
+			os.Clearenv()
+		})
+	}
+}
+
+type mapSource map[string]string
+
+func (s mapSource) Lookup(key string) (string, bool) {
+	value, ok := s[key]
+	return value, ok
+}
+
+func TestLoad_Sources(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+	os.Setenv("DB_DSN", "from-env")
+
+	cfg := Load(mapSource{"DB_DSN": "from-secret", "ADMIN_TOKEN": "s3cret"}, mapSource{"ADMIN_TOKEN": "ignored", "PORT": "9090"})
+
+	require.Equal(t, "from-env", cfg.DBDSN)
+	require.Equal(t, "s3cret", cfg.AdminToken)
+	require.Equal(t, "9090", cfg.Port)
+	require.Equal(t, "info", cfg.LogLevel)
+}
+
 func TestGetEnv(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,155 @@
+package currency
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/julimonteiro/cupcake-store/internal/money"
+)
+
+type UnsupportedError struct {
+	Code string
+}
+
+func (e *UnsupportedError) Error() string {
+	return "unsupported currency: " + e.Code
+}
+
+type RateProvider interface {
+	// Rate returns how many units of to one unit of from is worth.
+	Rate(from, to string) (float64, error)
+}
+
+// StaticRates holds fixed rates relative to a base currency, where
+// rates["EUR"] = 0.17 means one unit of base buys 0.17 EUR.
+type StaticRates struct {
+	base  string
+	rates map[string]float64
+}
+
+var _ RateProvider = (*StaticRates)(nil)
+
+// ParseStaticRates reads rates in the form "USD=0.18,EUR=0.17".
+func ParseStaticRates(base, spec string) (*StaticRates, error) {
+	rates := make(map[string]float64)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		code, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid exchange rate: %s", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate: %s", pair)
+		}
+		rates[Normalize(code)] = rate
+	}
+
+	return &StaticRates{base: Normalize(base), rates: rates}, nil
+}
+
+func (p *StaticRates) Rate(from, to string) (float64, error) {
+	fromRate, err := p.rate(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := p.rate(to)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+func (p *StaticRates) rate(code string) (float64, error) {
+	if code == p.base {
+		return 1, nil
+	}
+	rate, ok := p.rates[code]
+	if !ok {
+		return 0, &UnsupportedError{Code: code}
+	}
+	return rate, nil
+}
+
+type Converter struct {
+	base     string
+	provider RateProvider
+
+	mu          sync.RWMutex
+	defaultCode string
+}
+
+func NewConverter(base string, provider RateProvider) *Converter {
+	base = Normalize(base)
+	return &Converter{base: base, provider: provider, defaultCode: base}
+}
+
+func (c *Converter) Base() string {
+	return c.base
+}
+
+// Default is the currency prices are shown in when a request asks for none.
+// It starts as the base currency.
+func (c *Converter) Default() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.defaultCode
+}
+
+// SetDefault changes the currency returned by Default, failing with an
+// UnsupportedError when there is no rate for it.
+func (c *Converter) SetDefault(code string) error {
+	code = Normalize(code)
+	if code != c.base {
+		if _, err := c.provider.Rate(c.base, code); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.defaultCode = code
+	return nil
+}
+
+func (c *Converter) Convert(amountCents int, to string) (int, error) {
+	converted, err := c.ConvertMoney(money.Cents(amountCents), to)
+	if err != nil {
+		return 0, err
+	}
+	return converted.Int()
+}
+
+// ConvertMoney converts m, in the base currency when it has none, to the
+// currency to, rounding to the nearest minor unit.
+func (c *Converter) ConvertMoney(m money.Money, to string) (money.Money, error) {
+	from := m.Currency
+	if from == "" {
+		from = c.base
+	}
+	to = Normalize(to)
+	if to == from {
+		return money.New(m.Amount, to), nil
+	}
+
+	rate, err := c.provider.Rate(from, to)
+	if err != nil {
+		return money.Money{}, err
+	}
+	amount := math.Round(float64(m.Amount) * rate)
+	if amount >= math.MaxInt64 || amount < math.MinInt64 {
+		return money.Money{}, money.ErrOverflow
+	}
+	return money.New(int64(amount), to), nil
+}
+
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,121 @@
+package currency
+
+import (
+	"math"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/money"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStaticRates(t *testing.T) {
+	tests := []struct {
+		name          string
+		spec          string
+		expectedError string
+	}{
+		{name: "empty spec", spec: ""},
+		{name: "multiple rates", spec: "USD=0.2, eur=0.18"},
+		{name: "missing separator", spec: "USD0.2", expectedError: "invalid exchange rate: USD0.2"},
+		{name: "non-numeric rate", spec: "USD=abc", expectedError: "invalid exchange rate: USD=abc"},
+		{name: "non-positive rate", spec: "USD=0", expectedError: "invalid exchange rate: USD=0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rates, err := ParseStaticRates("BRL", tt.spec)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, rates)
+				require.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, rates)
+		})
+	}
+}
+
+func TestConverter_Convert(t *testing.T) {
+	rates, err := ParseStaticRates("brl", "USD=0.2,EUR=0.18")
+	require.NoError(t, err)
+	converter := NewConverter("BRL", rates)
+
+	tests := []struct {
+		name          string
+		amountCents   int
+		to            string
+		expected      int
+		expectedError string
+	}{
+		{name: "base currency is unchanged", amountCents: 1250, to: "BRL", expected: 1250},
+		{name: "converts to configured currency", amountCents: 1250, to: "USD", expected: 250},
+		{name: "currency code is case insensitive", amountCents: 1000, to: " eur ", expected: 180},
+		{name: "rounds to nearest cent", amountCents: 999, to: "EUR", expected: 180},
+		{name: "unknown currency", amountCents: 1000, to: "JPY", expectedError: "unsupported currency: JPY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := converter.Convert(tt.amountCents, tt.to)
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, amount)
+		})
+	}
+}
+
+func TestConverter_SetDefault(t *testing.T) {
+	rates, err := ParseStaticRates("BRL", "USD=0.2")
+	require.NoError(t, err)
+	converter := NewConverter("brl", rates)
+	require.Equal(t, "BRL", converter.Default())
+
+	require.NoError(t, converter.SetDefault(" usd "))
+	require.Equal(t, "USD", converter.Default())
+	require.Equal(t, "BRL", converter.Base())
+
+	require.EqualError(t, converter.SetDefault("JPY"), "unsupported currency: JPY")
+	require.Equal(t, "USD", converter.Default())
+}
+
+func TestConverter_ConvertMoney(t *testing.T) {
+	rates, err := ParseStaticRates("BRL", "USD=0.2,EUR=0.18")
+	require.NoError(t, err)
+	converter := NewConverter("BRL", rates)
+
+	converted, err := converter.ConvertMoney(money.Cents(1250), "usd")
+	require.NoError(t, err)
+	require.Equal(t, money.New(250, "USD"), converted)
+
+	converted, err = converter.ConvertMoney(money.New(250, "USD"), "EUR")
+	require.NoError(t, err)
+	require.Equal(t, money.New(225, "EUR"), converted)
+
+	converted, err = converter.ConvertMoney(money.Cents(1250), "BRL")
+	require.NoError(t, err)
+	require.Equal(t, money.New(1250, "BRL"), converted)
+
+	_, err = converter.ConvertMoney(money.New(math.MaxInt64, "USD"), "BRL")
+	require.ErrorIs(t, err, money.ErrOverflow)
+}
+
+func TestStaticRates_Rate(t *testing.T) {
+	rates, err := ParseStaticRates("BRL", "USD=0.2,EUR=0.18")
+	require.NoError(t, err)
+
+	rate, err := rates.Rate("USD", "BRL")
+	require.NoError(t, err)
+	require.InDelta(t, 5.0, rate, 0.0001)
+
+	rate, err = rates.Rate("USD", "EUR")
+	require.NoError(t, err)
+	require.InDelta(t, 0.9, rate, 0.0001)
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/julimonteiro/cupcake-store/internal/config"
 	"github.com/julimonteiro/cupcake-store/internal/models"
@@ -12,24 +13,48 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// DialectMemory keeps the catalog in process memory (see repository/inmem).
+// The remaining tables still need SQL, so they go to a private in-memory
+// SQLite database and the DSN is ignored; the binary still needs CGO.
+const DialectMemory = "memory"
+
+const memoryDSN = "file:cupcake-store?mode=memory&cache=shared"
+
 func Init(cfg *config.Config) (db *gorm.DB, err error) {
-	gormLogger := logger.Default.LogMode(logger.Info)
+	slowThreshold, err := parseSlowThreshold(cfg.DBSlowQueryThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	level := logger.Info
 	if cfg.LogLevel == "error" {
-		gormLogger = logger.Default.LogMode(logger.Error)
+		level = logger.Error
 	}
+	gormLogger := newLogger(level, slowThreshold)
 
-	if cfg.DBDSN == "" {
+	if cfg.DBDSN == "" && cfg.DBDialect != DialectMemory {
 		return nil, fmt.Errorf("error connecting to database: database DSN cannot be empty")
 	}
 
 	switch cfg.DBDialect {
 	case "postgres":
 		db, err = gorm.Open(postgres.Open(cfg.DBDSN), &gorm.Config{
-			Logger: gormLogger,
+			Logger:         gormLogger,
+			TranslateError: true,
 		})
 	case "sqlite":
-		db, err = gorm.Open(sqlite.Open(cfg.DBDSN), &gorm.Config{
-			Logger: gormLogger,
+		dsn, dsnErr := sqliteDSN(cfg.DBDSN, cfg)
+		if dsnErr != nil {
+			return nil, dsnErr
+		}
+		db, err = gorm.Open(sqlite.Open(dsn), &gorm.Config{
+			Logger:         gormLogger,
+			TranslateError: true,
+		})
+	case DialectMemory:
+		db, err = gorm.Open(sqlite.Open(memoryDSN), &gorm.Config{
+			Logger:         gormLogger,
+			TranslateError: true,
 		})
 	default:
 		return nil, fmt.Errorf("unsupported database dialect: %s", cfg.DBDialect)
@@ -39,16 +64,115 @@ func Init(cfg *config.Config) (db *gorm.DB, err error) {
 		return nil, fmt.Errorf("error connecting to database: %w", err)
 	}
 
-	if err := runMigrations(db); err != nil {
+	if err := db.Use(NewQueryMetrics()); err != nil {
+		return nil, fmt.Errorf("error registering query metrics: %w", err)
+	}
+
+	if err := Migrate(db); err != nil {
 		return nil, fmt.Errorf("error running migrations: %w", err)
 	}
 
+	// Replicas are registered after migrating so schema checks never read
+	// from a replica that has not caught up yet.
+	if replicas := parseReplicaDSNs(cfg.DBReadDSNs); len(replicas) > 0 {
+		if err := useReplicas(db, cfg, replicas); err != nil {
+			return nil, fmt.Errorf("error configuring read replicas: %w", err)
+		}
+		log.Printf("Routing reads to %d replica(s)", len(replicas))
+	}
+
 	log.Printf("Connected to database %s", cfg.DBDialect)
 	return db, nil
 }
 
-func runMigrations(db *gorm.DB) error {
-	return db.AutoMigrate(
+// parseSlowThreshold reads DB_SLOW_QUERY_THRESHOLD; empty or "0" disables
+// slow query logging.
+func parseSlowThreshold(raw string) (time.Duration, error) {
+	if raw == "" || raw == "0" {
+		return 0, nil
+	}
+	threshold, err := time.ParseDuration(raw)
+	if err != nil || threshold < 0 {
+		return 0, fmt.Errorf("invalid slow query threshold %q: must be a duration such as 200ms", raw)
+	}
+	return threshold, nil
+}
+
+// Migrate creates or updates every table the application uses.
+func Migrate(db *gorm.DB) error {
+	err := db.AutoMigrate(
 		&models.Cupcake{},
+		&models.CupcakeVersion{},
+		&models.Coupon{},
+		&models.CouponRedemption{},
+		&models.Promotion{},
+		&models.GiftCard{},
+		&models.GiftCardRedemption{},
+		&models.Subscription{},
+		&models.Webhook{},
+		&models.WebhookDelivery{},
+		&models.Job{},
+		&models.Location{},
+		&models.LocationStock{},
+		&models.StockAlert{},
+		&models.PickupSlot{},
+		&models.PickupReservation{},
+		&models.PickupReservationItem{},
+		&models.CustomOption{},
+		&models.Addon{},
+		&models.Bundle{},
+		&models.BundleItem{},
+		&models.WholesaleAccount{},
+		&models.WholesalePrice{},
+		&models.Supplier{},
+		&models.Ingredient{},
+		&models.PurchaseOrder{},
+		&models.PurchaseOrderLine{},
+		&models.RecipeIngredient{},
+		&models.ProductionBatch{},
+		&models.CupcakeChange{},
+		&models.CupcakeViewCount{},
+		&models.Setting{},
+		&models.Experiment{},
+		&models.ExperimentVariant{},
+		&models.ExperimentExposure{},
+		&models.ExperimentConversion{},
+		&models.DataExport{},
+		&models.Erasure{},
+		&models.Account{},
+		&models.AccountIdentity{},
+		&models.Session{},
+		&models.CupcakeTranslation{},
+		&models.Admin{},
+		&models.AdminBackupCode{},
+		&models.APIKey{},
+		&models.APIKeyUsage{},
+		&models.Ticket{},
+		&models.EmailTemplate{},
+		&models.NotificationPreference{},
+		&models.DeviceToken{},
 	)
+	if err != nil {
+		return err
+	}
+	if err := purgeEmailedLinks(db); err != nil {
+		return err
+	}
+	return EncryptPII(db)
+}
+
+// purgeEmailedLinks deletes what is left of the webhook events that
+// carried signed links now only emailed to customers, data_export.ready,
+// erasure.requested and account.verification_requested: their deliveries
+// and the ones still queued.
+func purgeEmailedLinks(db *gorm.DB) error {
+	for _, event := range []string{"data_export.ready", "erasure.requested", "account.verification_requested"} {
+		if err := db.Where("event = ?", event).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := db.Where("type = ? AND payload LIKE ?", "webhook.deliver", `%"event":"`+event+`"%`).Delete(&models.Job{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
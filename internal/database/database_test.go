@@ -1,10 +1,14 @@
 package database
 
 import (
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/models"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -33,7 +37,7 @@ func TestInit(t *testing.T) {
 			name: "SQLite with file database",
 			config: &config.Config{
 				DBDialect: "sqlite",
-				DBDSN:     "test.db",
+				DBDSN:     filepath.Join(t.TempDir(), "test.db"),
 				LogLevel:  "error",
 			},
 			validateResult: func(t *testing.T, db *gorm.DB) {
@@ -43,6 +47,20 @@ func TestInit(t *testing.T) {
 				require.NoError(t, sqlDB.Close())
 			},
 		},
+		{
+			name: "memory dialect ignores the DSN",
+			config: &config.Config{
+				DBDialect: "memory",
+				LogLevel:  "error",
+			},
+			validateResult: func(t *testing.T, db *gorm.DB) {
+				require.NotNil(t, db)
+				require.True(t, db.Migrator().HasTable("coupons"))
+				sqlDB, err := db.DB()
+				require.NoError(t, err)
+				require.NoError(t, sqlDB.Close())
+			},
+		},
 		{
 			name: "PostgreSQL connection (expected to fail)",
 			config: &config.Config{
@@ -52,6 +70,16 @@ func TestInit(t *testing.T) {
 			},
 			expectedError: "error connecting to database",
 		},
+		{
+			name: "invalid slow query threshold",
+			config: &config.Config{
+				DBDialect:            "sqlite",
+				DBDSN:                ":memory:",
+				LogLevel:             "error",
+				DBSlowQueryThreshold: "fast",
+			},
+			expectedError: "invalid slow query threshold",
+		},
 		{
 			name: "unsupported database dialect",
 			config: &config.Config{
@@ -282,6 +310,33 @@ func TestRunMigrations(t *testing.T) {
 	}
 }
 
+func TestMigrate_PurgesEmailedLinks(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, Migrate(db))
+
+	link := `{"event":"data_export.ready","data":{"download_path":"/api/v1/data-exports/1/download?expires=1&signature=abc"}}`
+	require.NoError(t, db.Create(&models.WebhookDelivery{WebhookID: 1, Event: "data_export.ready", Payload: link, Attempt: 1}).Error)
+	require.NoError(t, db.Create(&models.WebhookDelivery{WebhookID: 1, Event: "cupcake.created", Payload: `{}`, Attempt: 1}).Error)
+	confirm := `{"event":"erasure.requested","data":{"confirm_path":"/api/v1/me?email=ana%40example.com&expires=1&token=abc"}}`
+	require.NoError(t, db.Create(&models.WebhookDelivery{WebhookID: 1, Event: "erasure.requested", Payload: confirm, Attempt: 1}).Error)
+	require.NoError(t, db.Create(&models.Job{Type: "webhook.deliver", Payload: `{"webhook_id":1,"event":"erasure.requested","body":` + confirm + `}`, Status: models.JobPending, MaxAttempts: 5, RunAt: time.Now()}).Error)
+	verify := `{"event":"account.verification_requested","data":{"verify_path":"/api/v1/auth/verify?token=1.1.abc"}}`
+	require.NoError(t, db.Create(&models.WebhookDelivery{WebhookID: 1, Event: "account.verification_requested", Payload: verify, Attempt: 1}).Error)
+	require.NoError(t, db.Create(&models.Job{Type: "webhook.deliver", Payload: `{"webhook_id":1,"event":"data_export.ready","body":` + link + `}`, Status: models.JobPending, MaxAttempts: 5, RunAt: time.Now()}).Error)
+	require.NoError(t, db.Create(&models.Job{Type: "webhook.deliver", Payload: `{"webhook_id":1,"event":"cupcake.created","body":{}}`, Status: models.JobPending, MaxAttempts: 5, RunAt: time.Now()}).Error)
+
+	require.NoError(t, Migrate(db))
+
+	var deliveries []models.WebhookDelivery
+	require.NoError(t, db.Find(&deliveries).Error)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, "cupcake.created", deliveries[0].Event)
+	var jobs int64
+	require.NoError(t, db.Model(&models.Job{}).Count(&jobs).Error)
+	require.Equal(t, int64(1), jobs)
+}
+
 func TestInit_DatabaseTypes(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -304,7 +359,7 @@ func TestInit_DatabaseTypes(t *testing.T) {
 		{
 			name:    "SQLite with file database",
 			dialect: "sqlite",
-			dsn:     "test.db",
+			dsn:     filepath.Join(t.TempDir(), "test.db"),
 			validateResult: func(t *testing.T, db *gorm.DB) {
 				require.NotNil(t, db)
 				sqlDB, err := db.DB()
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+const queryMetricsName = "cupcake-store:query_metrics"
+
+// QueryMetrics is a GORM plugin counting statements and failures per
+// operation (create, query, update, delete, row, raw). Init registers it on
+// every connection it opens.
+type QueryMetrics struct {
+	mu     sync.Mutex
+	total  map[string]uint64
+	failed map[string]uint64
+}
+
+func NewQueryMetrics() *QueryMetrics {
+	return &QueryMetrics{total: map[string]uint64{}, failed: map[string]uint64{}}
+}
+
+func (m *QueryMetrics) Name() string {
+	return queryMetricsName
+}
+
+func (m *QueryMetrics) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().After("gorm:create").Register(queryMetricsName+":create", m.counter("create")),
+		callbacks.Query().After("gorm:query").Register(queryMetricsName+":query", m.counter("query")),
+		callbacks.Update().After("gorm:update").Register(queryMetricsName+":update", m.counter("update")),
+		callbacks.Delete().After("gorm:delete").Register(queryMetricsName+":delete", m.counter("delete")),
+		callbacks.Row().After("gorm:row").Register(queryMetricsName+":row", m.counter("row")),
+		callbacks.Raw().After("gorm:raw").Register(queryMetricsName+":raw", m.counter("raw")),
+	)
+}
+
+func (m *QueryMetrics) counter(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		m.observe(operation, tx.Error)
+	}
+}
+
+func (m *QueryMetrics) observe(operation string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.total[operation]++
+	// A missing row is an answer, not a database problem.
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		m.failed[operation]++
+	}
+}
+
+// Counts returns copies of the per-operation statement and error counters.
+func (m *QueryMetrics) Counts() (total, failed map[string]uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	total = make(map[string]uint64, len(m.total))
+	for operation, n := range m.total {
+		total[operation] = n
+	}
+	failed = make(map[string]uint64, len(m.failed))
+	for operation, n := range m.failed {
+		failed[operation] = n
+	}
+	return total, failed
+}
+
+// WriteMetrics writes the connection pool statistics and, when db was opened
+// by Init, the query counters in the Prometheus text exposition format.
+func WriteMetrics(w io.Writer, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	stats := sqlDB.Stats()
+
+	gauges := []struct {
+		name, help string
+		value      int
+	}{
+		{"cupcake_store_db_max_open_connections", "Maximum number of open connections to the database.", stats.MaxOpenConnections},
+		{"cupcake_store_db_open_connections", "Established connections, in use or idle.", stats.OpenConnections},
+		{"cupcake_store_db_in_use_connections", "Connections currently in use.", stats.InUse},
+		{"cupcake_store_db_idle_connections", "Idle connections.", stats.Idle},
+	}
+	for _, g := range gauges {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %d\n", g.name, g.help, g.name, g.name, g.value)
+	}
+
+	fmt.Fprintf(w, "# HELP cupcake_store_db_wait_count_total Connections waited for because the pool was exhausted.\n")
+	fmt.Fprintf(w, "# TYPE cupcake_store_db_wait_count_total counter\n")
+	fmt.Fprintf(w, "cupcake_store_db_wait_count_total %d\n", stats.WaitCount)
+	fmt.Fprintf(w, "# HELP cupcake_store_db_wait_duration_seconds_total Time spent waiting for a connection.\n")
+	fmt.Fprintf(w, "# TYPE cupcake_store_db_wait_duration_seconds_total counter\n")
+	fmt.Fprintf(w, "cupcake_store_db_wait_duration_seconds_total %g\n", stats.WaitDuration.Seconds())
+
+	plugin, ok := db.Config.Plugins[queryMetricsName].(*QueryMetrics)
+	if !ok {
+		return nil
+	}
+	total, failed := plugin.Counts()
+	writeOperationCounter(w, "cupcake_store_db_queries_total", "Statements executed, by operation.", total)
+	writeOperationCounter(w, "cupcake_store_db_query_errors_total", "Statements that failed, by operation.", failed)
+	return nil
+}
+
+func writeOperationCounter(w io.Writer, name, help string, counts map[string]uint64) {
+	operations := make([]string, 0, len(counts))
+	for operation := range counts {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	for _, operation := range operations {
+		fmt.Fprintf(w, "%s{operation=%q} %d\n", name, operation, counts[operation])
+	}
+}
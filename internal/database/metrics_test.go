@@ -0,0 +1,38 @@
+package database
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryMetrics(t *testing.T) {
+	db, err := Init(&config.Config{DBDialect: "sqlite", DBDSN: ":memory:", LogLevel: "error"})
+	require.NoError(t, err)
+	t.Cleanup(func() { closeDB(t, db) })
+
+	metrics, ok := db.Config.Plugins[queryMetricsName].(*QueryMetrics)
+	require.True(t, ok)
+	before, _ := metrics.Counts()
+
+	require.NoError(t, db.Create(&models.Cupcake{Name: "Chocolate", Flavor: "Cacau", PriceCents: 1000}).Error)
+	var cupcake models.Cupcake
+	require.NoError(t, db.First(&cupcake).Error)
+	require.Error(t, db.First(&cupcake, 999).Error)
+	require.Error(t, db.Exec("SELECT * FROM missing_table").Error)
+
+	total, failed := metrics.Counts()
+	require.Equal(t, before["create"]+1, total["create"])
+	require.Equal(t, before["query"]+2, total["query"])
+	require.Zero(t, failed["query"], "record not found should not count as a failure")
+	require.Equal(t, uint64(1), failed["raw"])
+
+	var out bytes.Buffer
+	require.NoError(t, WriteMetrics(&out, db))
+	require.Contains(t, out.String(), "# TYPE cupcake_store_db_open_connections gauge")
+	require.Contains(t, out.String(), "# TYPE cupcake_store_db_wait_duration_seconds_total counter")
+	require.Contains(t, out.String(), `cupcake_store_db_query_errors_total{operation="raw"} 1`)
+}
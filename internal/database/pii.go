@@ -0,0 +1,57 @@
+package database
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+const piiBatchSize = 100
+
+// EncryptPII rewrites the rows stored before their personal data was
+// encrypted: rows missing the lookup hash, and with a key configured, rows
+// in plain text or sealed with a previous key, whose hashes were made with
+// it too. Once every row is up to date it only runs the queries that find
+// none.
+func EncryptPII(db *gorm.DB) error {
+	if err := encryptTable[models.Subscription](db, "customer_email", "customer_email_hash"); err != nil {
+		return err
+	}
+	if err := encryptTable[models.PickupReservation](db, "customer_email", "customer_email_hash", "customer_name", "customer_phone"); err != nil {
+		return err
+	}
+	if err := encryptTable[models.WholesaleAccount](db, "email", "email_hash"); err != nil {
+		return err
+	}
+	if err := encryptTable[models.DataExport](db, "customer_email", "customer_email_hash", "archive"); err != nil {
+		return err
+	}
+	if err := encryptTable[models.Account](db, "email", "email_hash", "name"); err != nil {
+		return err
+	}
+	if err := encryptTable[models.Ticket](db, "customer_email", "customer_email_hash", "message"); err != nil {
+		return err
+	}
+	return encryptTable[models.Admin](db, "email", "email_hash", "totp_secret", "phone")
+}
+
+// encryptTable saves the outdated rows of T again, which encrypts column
+// and the other columns given and recomputes hashColumn in the model's
+// BeforeSave hook. Saving only those columns leaves updated_at alone.
+func encryptTable[T any](db *gorm.DB, column, hashColumn string, others ...string) error {
+	query := db.Where(hashColumn + " IS NULL")
+	if prefix := pii.CurrentPrefix(); prefix != "" {
+		query = query.Or(column+" NOT LIKE ?", prefix+"%")
+	}
+	columns := append([]string{column, hashColumn}, others...)
+
+	var rows []T
+	return query.FindInBatches(&rows, piiBatchSize, func(tx *gorm.DB, _ int) error {
+		for i := range rows {
+			if err := tx.Model(&rows[i]).Select(columns).Omit("updated_at").Updates(&rows[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
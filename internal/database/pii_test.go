@@ -0,0 +1,82 @@
+package database
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestEncryptPII(t *testing.T) {
+	t.Cleanup(func() { pii.Configure("") })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, Migrate(db))
+
+	// Rows written without a key, one of them from before the hash column
+	// existed.
+	subscription := &models.Subscription{CustomerEmail: "ana@example.com", CupcakeID: 1, Quantity: 1, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: time.Now()}
+	require.NoError(t, db.Create(subscription).Error)
+	reservation := &models.PickupReservation{SlotID: 1, CustomerName: "Ana Lima", CustomerEmail: "ana@example.com", CustomerPhone: "+5511999990000"}
+	require.NoError(t, db.Create(reservation).Error)
+	require.NoError(t, db.Exec("UPDATE pickup_reservations SET customer_email_hash = NULL").Error)
+	ticket := &models.Ticket{OrderType: "order", OrderID: 1, CustomerEmail: "ana@example.com", Subject: "Atraso", Message: "Meu pedido não chegou", Status: models.TicketOpen}
+	require.NoError(t, db.Create(ticket).Error)
+
+	require.NoError(t, pii.Configure(base64.StdEncoding.EncodeToString(make([]byte, pii.KeySize))))
+	require.NoError(t, Migrate(db))
+
+	var stored struct{ CustomerName, CustomerEmail, CustomerEmailHash, CustomerPhone string }
+	require.NoError(t, db.Raw("SELECT customer_name, customer_email, customer_email_hash, customer_phone FROM pickup_reservations").Scan(&stored).Error)
+	require.True(t, strings.HasPrefix(stored.CustomerName, pii.Prefix))
+	require.True(t, strings.HasPrefix(stored.CustomerEmail, pii.Prefix))
+	require.True(t, strings.HasPrefix(stored.CustomerPhone, pii.Prefix))
+	require.Equal(t, pii.Hash("ana@example.com"), stored.CustomerEmailHash)
+
+	var storedTicket struct{ CustomerEmail, Message string }
+	require.NoError(t, db.Raw("SELECT customer_email, message FROM tickets").Scan(&storedTicket).Error)
+	require.True(t, strings.HasPrefix(storedTicket.CustomerEmail, pii.Prefix))
+	require.True(t, strings.HasPrefix(storedTicket.Message, pii.Prefix))
+
+	var updatedAt time.Time
+	require.NoError(t, db.Raw("SELECT updated_at FROM subscriptions").Scan(&updatedAt).Error)
+	require.WithinDuration(t, subscription.UpdatedAt, updatedAt, time.Millisecond)
+
+	var found models.Subscription
+	require.NoError(t, db.Where("customer_email_hash = ?", pii.Hash("Ana@example.com")).First(&found).Error)
+	require.Equal(t, "ana@example.com", found.CustomerEmail)
+}
+
+func TestEncryptPII_Rotation(t *testing.T) {
+	t.Cleanup(func() { pii.Configure("") })
+	oldKey := base64.StdEncoding.EncodeToString(make([]byte, pii.KeySize))
+	newKey := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, pii.Configure(oldKey))
+	require.NoError(t, Migrate(db))
+	require.NoError(t, db.Create(&models.Account{Name: "Ana Lima", Email: "ana@example.com", PasswordHash: "x"}).Error)
+
+	require.NoError(t, pii.Configure(newKey, oldKey))
+	require.NoError(t, Migrate(db))
+
+	var stored struct{ Name, Email, EmailHash string }
+	require.NoError(t, db.Raw("SELECT name, email, email_hash FROM accounts").Scan(&stored).Error)
+	require.True(t, strings.HasPrefix(stored.Name, pii.CurrentPrefix()))
+	require.True(t, strings.HasPrefix(stored.Email, pii.CurrentPrefix()))
+	require.Equal(t, pii.Hash("ana@example.com"), stored.EmailHash)
+
+	// The old key is no longer needed.
+	require.NoError(t, pii.Configure(newKey))
+	var account models.Account
+	require.NoError(t, db.Where("email_hash = ?", pii.Hash("ana@example.com")).First(&account).Error)
+	require.Equal(t, "Ana Lima", account.Name)
+}
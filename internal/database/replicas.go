@@ -0,0 +1,49 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// parseReplicaDSNs splits DB_READ_DSNS on commas, dropping blank entries.
+func parseReplicaDSNs(raw string) []string {
+	var dsns []string
+	for _, dsn := range strings.Split(raw, ",") {
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			dsns = append(dsns, dsn)
+		}
+	}
+	return dsns
+}
+
+// useReplicas registers the read replicas with dbresolver. Queries outside a
+// transaction (lists, searches, lookups) are spread across the replicas at
+// random; writes and everything inside a transaction stay on the primary.
+func useReplicas(db *gorm.DB, cfg *config.Config, dsns []string) error {
+	replicas := make([]gorm.Dialector, 0, len(dsns))
+	for _, dsn := range dsns {
+		switch cfg.DBDialect {
+		case "postgres":
+			replicas = append(replicas, postgres.Open(dsn))
+		case "sqlite":
+			dsn, err := sqliteDSN(dsn, cfg)
+			if err != nil {
+				return err
+			}
+			replicas = append(replicas, sqlite.Open(dsn))
+		default:
+			return fmt.Errorf("read replicas are not supported for dialect %s", cfg.DBDialect)
+		}
+	}
+
+	return db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	}))
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestParseReplicaDSNs(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []string
+	}{
+		{name: "empty", raw: "", expected: nil},
+		{name: "single", raw: "replica.db", expected: []string{"replica.db"}},
+		{
+			name:     "trims and drops blanks",
+			raw:      " host=r1 dbname=store , ,host=r2 dbname=store",
+			expected: []string{"host=r1 dbname=store", "host=r2 dbname=store"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, parseReplicaDSNs(tt.raw))
+		})
+	}
+}
+
+func TestInit_ReadReplicas(t *testing.T) {
+	dir := t.TempDir()
+	replicaDSN := filepath.Join(dir, "replica.db")
+
+	// Seed the replica directly so reads served by it are recognisable.
+	replica, err := Init(&config.Config{DBDialect: "sqlite", DBDSN: replicaDSN, LogLevel: "error"})
+	require.NoError(t, err)
+	require.NoError(t, replica.Create(&models.Cupcake{Name: "Replica", Flavor: "Baunilha", PriceCents: 500}).Error)
+	closeDB(t, replica)
+
+	db, err := Init(&config.Config{
+		DBDialect:  "sqlite",
+		DBDSN:      filepath.Join(dir, "primary.db"),
+		DBReadDSNs: replicaDSN,
+		LogLevel:   "error",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { closeDB(t, db) })
+
+	require.NoError(t, db.Create(&models.Cupcake{Name: "Primary", Flavor: "Chocolate", PriceCents: 700}).Error)
+
+	var reads []models.Cupcake
+	require.NoError(t, db.Find(&reads).Error)
+	require.Len(t, reads, 1)
+	require.Equal(t, "Replica", reads[0].Name)
+
+	var inTx []models.Cupcake
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return tx.Find(&inTx).Error
+	}))
+	require.Len(t, inTx, 1)
+	require.Equal(t, "Primary", inTx[0].Name)
+}
+
+func TestInit_ReadReplicasUnsupportedDialect(t *testing.T) {
+	_, err := Init(&config.Config{DBDialect: "memory", DBReadDSNs: "replica.db", LogLevel: "error"})
+	require.ErrorContains(t, err, "read replicas are not supported for dialect memory")
+}
+
+func closeDB(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/julimonteiro/cupcake-store/internal/redact"
+	"gorm.io/gorm/logger"
+)
+
+// slowQueryLogger reports queries slower than threshold as structured
+// log records and forwards everything to the wrapped GORM logger, whose own
+// slow-SQL warning is disabled to avoid logging the same query twice.
+type slowQueryLogger struct {
+	logger.Interface
+	threshold time.Duration
+}
+
+func newLogger(level logger.LogLevel, threshold time.Duration) logger.Interface {
+	base := logger.New(log.New(redact.NewWriter(os.Stdout), "\r\n", log.LstdFlags), logger.Config{
+		LogLevel: level,
+		Colorful: true,
+	})
+	return slowQueryLogger{Interface: base, threshold: threshold}
+}
+
+func (l slowQueryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return slowQueryLogger{Interface: l.Interface.LogMode(level), threshold: l.threshold}
+}
+
+func (l slowQueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if elapsed := time.Since(begin); l.threshold > 0 && elapsed >= l.threshold {
+		query, rows := fc()
+		slog.WarnContext(ctx, "slow query",
+			"duration_ms", elapsed.Milliseconds(),
+			"rows", rows,
+			"request_id", middleware.GetReqID(ctx),
+			"query", query,
+		)
+	}
+	l.Interface.Trace(ctx, begin, fc, err)
+}
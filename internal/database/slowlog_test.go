@@ -0,0 +1,94 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/logger"
+)
+
+func TestSlowQueryLogger(t *testing.T) {
+	tests := []struct {
+		name        string
+		threshold   time.Duration
+		elapsed     time.Duration
+		expectedLog []string
+	}{
+		{
+			name:      "logs queries over the threshold",
+			threshold: 100 * time.Millisecond,
+			elapsed:   250 * time.Millisecond,
+			expectedLog: []string{
+				`msg="slow query"`,
+				"rows=3",
+				"request_id=req-1",
+				`query="SELECT * FROM cupcakes"`,
+			},
+		},
+		{
+			name:      "ignores fast queries",
+			threshold: 100 * time.Millisecond,
+			elapsed:   10 * time.Millisecond,
+		},
+		{
+			name:      "zero threshold disables logging",
+			threshold: 0,
+			elapsed:   time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			previous := slog.Default()
+			slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+			t.Cleanup(func() { slog.SetDefault(previous) })
+
+			l := newLogger(logger.Silent, tt.threshold)
+			ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-1")
+			l.Trace(ctx, time.Now().Add(-tt.elapsed), func() (string, int64) {
+				return "SELECT * FROM cupcakes", 3
+			}, nil)
+
+			if len(tt.expectedLog) == 0 {
+				require.Empty(t, buf.String())
+				return
+			}
+			for _, expected := range tt.expectedLog {
+				require.Contains(t, buf.String(), expected)
+			}
+		})
+	}
+}
+
+func TestParseSlowThreshold(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		expected      time.Duration
+		expectedError bool
+	}{
+		{name: "empty disables", raw: "", expected: 0},
+		{name: "zero disables", raw: "0", expected: 0},
+		{name: "duration", raw: "250ms", expected: 250 * time.Millisecond},
+		{name: "not a duration", raw: "fast", expectedError: true},
+		{name: "negative", raw: "-1s", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			threshold, err := parseSlowThreshold(tt.raw)
+			if tt.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, threshold)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+)
+
+var journalModes = map[string]bool{
+	"DELETE": true, "TRUNCATE": true, "PERSIST": true,
+	"MEMORY": true, "WAL": true, "OFF": true,
+}
+
+// sqliteDSN adds the journal_mode, busy_timeout and foreign_keys pragmas to
+// the DSN. They go through the DSN rather than a one-off PRAGMA statement so
+// every connection in the pool opened by the driver gets them.
+func sqliteDSN(dsn string, cfg *config.Config) (string, error) {
+	var params []string
+
+	if cfg.SQLiteJournalMode != "" {
+		mode := strings.ToUpper(cfg.SQLiteJournalMode)
+		if !journalModes[mode] {
+			return "", fmt.Errorf("invalid SQLite journal mode %q", cfg.SQLiteJournalMode)
+		}
+		params = append(params, "_journal_mode="+mode)
+	}
+
+	if cfg.SQLiteBusyTimeout != "" {
+		timeout, err := time.ParseDuration(cfg.SQLiteBusyTimeout)
+		if err != nil || timeout < 0 {
+			return "", fmt.Errorf("invalid SQLite busy timeout %q: must be a duration such as 5s", cfg.SQLiteBusyTimeout)
+		}
+		params = append(params, fmt.Sprintf("_busy_timeout=%d", timeout.Milliseconds()))
+	}
+
+	if cfg.SQLiteForeignKeys != "" {
+		enabled, err := strconv.ParseBool(cfg.SQLiteForeignKeys)
+		if err != nil {
+			return "", fmt.Errorf("invalid SQLite foreign keys setting %q", cfg.SQLiteForeignKeys)
+		}
+		params = append(params, "_foreign_keys="+strconv.FormatBool(enabled))
+	}
+
+	if len(params) == 0 {
+		return dsn, nil
+	}
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	return dsn + separator + strings.Join(params, "&"), nil
+}
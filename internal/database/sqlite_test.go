@@ -0,0 +1,99 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteDSN(t *testing.T) {
+	tests := []struct {
+		name          string
+		dsn           string
+		config        *config.Config
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "no options keeps the DSN",
+			dsn:      "cupcake_store.db",
+			config:   &config.Config{},
+			expected: "cupcake_store.db",
+		},
+		{
+			name: "all options",
+			dsn:  "cupcake_store.db",
+			config: &config.Config{
+				SQLiteJournalMode: "wal",
+				SQLiteBusyTimeout: "5s",
+				SQLiteForeignKeys: "true",
+			},
+			expected: "cupcake_store.db?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=true",
+		},
+		{
+			name:     "DSN with existing query",
+			dsn:      "file:cupcake_store.db?cache=shared",
+			config:   &config.Config{SQLiteForeignKeys: "false"},
+			expected: "file:cupcake_store.db?cache=shared&_foreign_keys=false",
+		},
+		{
+			name:          "invalid journal mode",
+			dsn:           "cupcake_store.db",
+			config:        &config.Config{SQLiteJournalMode: "fast"},
+			expectedError: "invalid SQLite journal mode",
+		},
+		{
+			name:          "invalid busy timeout",
+			dsn:           "cupcake_store.db",
+			config:        &config.Config{SQLiteBusyTimeout: "5"},
+			expectedError: "invalid SQLite busy timeout",
+		},
+		{
+			name:          "invalid foreign keys",
+			dsn:           "cupcake_store.db",
+			config:        &config.Config{SQLiteForeignKeys: "sometimes"},
+			expectedError: "invalid SQLite foreign keys setting",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsn, err := sqliteDSN(tt.dsn, tt.config)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, dsn)
+		})
+	}
+}
+
+func TestInit_SQLitePragmas(t *testing.T) {
+	db, err := Init(&config.Config{
+		DBDialect:         "sqlite",
+		DBDSN:             filepath.Join(t.TempDir(), "pragmas.db"),
+		LogLevel:          "error",
+		SQLiteJournalMode: "WAL",
+		SQLiteBusyTimeout: "2s",
+		SQLiteForeignKeys: "true",
+	})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	var journalMode string
+	require.NoError(t, db.Raw("PRAGMA journal_mode").Scan(&journalMode).Error)
+	require.Equal(t, "wal", journalMode)
+
+	var busyTimeout int
+	require.NoError(t, db.Raw("PRAGMA busy_timeout").Scan(&busyTimeout).Error)
+	require.Equal(t, 2000, busyTimeout)
+
+	var foreignKeys int
+	require.NoError(t, db.Raw("PRAGMA foreign_keys").Scan(&foreignKeys).Error)
+	require.Equal(t, 1, foreignKeys)
+}
@@ -0,0 +1,133 @@
+// Package email sends email through a mail server. SMTP is the only
+// transport supported.
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// New returns the sender of EMAIL_PROVIDER, or nil when it is none.
+func New(cfg *config.Config) (service.EmailSender, error) {
+	switch cfg.EmailProvider {
+	case "", "none":
+		return nil, nil
+	case "smtp":
+	default:
+		return nil, fmt.Errorf("unknown EMAIL_PROVIDER %q: must be none or smtp", cfg.EmailProvider)
+	}
+	if cfg.SMTPHost == "" || cfg.EmailFrom == "" {
+		return nil, fmt.Errorf("SMTP_HOST and EMAIL_FROM are required with EMAIL_PROVIDER=smtp")
+	}
+	from, err := mail.ParseAddress(cfg.EmailFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_FROM: %w", err)
+	}
+	return &SMTP{
+		addr:     net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		host:     cfg.SMTPHost,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		from:     from,
+	}, nil
+}
+
+// SMTP sends email through a mail server, upgrading the connection with
+// STARTTLS when the server offers it. Credentials are only sent over TLS,
+// or to a server on localhost.
+type SMTP struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     *mail.Address
+}
+
+var _ service.EmailSender = (*SMTP)(nil)
+
+func (s *SMTP) Send(ctx context.Context, to string, email *models.RenderedEmail) error {
+	recipient, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("smtp: invalid recipient: %w", err)
+	}
+	message, err := buildMessage(s.from, recipient, email)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return fmt.Errorf("smtp: %w", err)
+		}
+	}
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("smtp: %w", err)
+		}
+	}
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if err := client.Rcpt(recipient.Address); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	return client.Quit()
+}
+
+// buildMessage writes an HTML email with its headers. The body is
+// quoted-printable, so long lines and accents survive any server.
+func buildMessage(from, to *mail.Address, email *models.RenderedEmail) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", strings.NewReplacer("\r", "", "\n", " ").Replace(email.Subject)))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	body := quotedprintable.NewWriter(&buf)
+	if _, err := body.Write([]byte(email.HTML)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,104 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	sender, err := New(&config.Config{EmailProvider: "none"})
+	require.NoError(t, err)
+	require.Nil(t, sender)
+
+	sender, err = New(&config.Config{EmailProvider: "smtp", SMTPHost: "mail.example.com", SMTPPort: "587", EmailFrom: "Cupcake Store <loja@example.com>"})
+	require.NoError(t, err)
+	require.Equal(t, "mail.example.com:587", sender.(*SMTP).addr)
+
+	_, err = New(&config.Config{EmailProvider: "smtp", SMTPHost: "mail.example.com"})
+	require.EqualError(t, err, "SMTP_HOST and EMAIL_FROM are required with EMAIL_PROVIDER=smtp")
+	_, err = New(&config.Config{EmailProvider: "smtp", SMTPHost: "mail.example.com", EmailFrom: "loja"})
+	require.ErrorContains(t, err, "invalid EMAIL_FROM")
+	_, err = New(&config.Config{EmailProvider: "pigeon"})
+	require.ErrorContains(t, err, `unknown EMAIL_PROVIDER "pigeon"`)
+}
+
+func TestSMTP_Send(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	type received struct {
+		from, to string
+		data     string
+	}
+	messages := make(chan received, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+		text.PrintfLine("220 localhost ESMTP")
+		var message received
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			switch verb := strings.ToUpper(strings.Fields(line)[0]); verb {
+			case "EHLO", "HELO":
+				text.PrintfLine("250 localhost")
+			case "MAIL":
+				message.from = line
+				text.PrintfLine("250 OK")
+			case "RCPT":
+				message.to = line
+				text.PrintfLine("250 OK")
+			case "DATA":
+				text.PrintfLine("354 Go ahead")
+				data, _ := io.ReadAll(text.DotReader())
+				message.data = string(data)
+				text.PrintfLine("250 OK")
+			case "QUIT":
+				text.PrintfLine("221 Bye")
+				messages <- message
+				return
+			default:
+				text.PrintfLine("502 Not implemented")
+			}
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	sender, err := New(&config.Config{EmailProvider: "smtp", SMTPHost: host, SMTPPort: port, EmailFrom: "Cupcake Store <loja@example.com>"})
+	require.NoError(t, err)
+
+	err = sender.Send(context.Background(), "ana@example.com", &models.RenderedEmail{Subject: "Seus dados estão prontos", HTML: `<p>Baixe <a href="https://loja.example.com/x?a=1&b=2">aqui</a>.</p>`})
+	require.NoError(t, err)
+
+	message := <-messages
+	require.Equal(t, "MAIL FROM:<loja@example.com>", message.from)
+	require.Equal(t, "RCPT TO:<ana@example.com>", message.to)
+	header, err := textproto.NewReader(bufio.NewReader(strings.NewReader(message.data))).ReadMIMEHeader()
+	require.NoError(t, err)
+	require.Equal(t, "<ana@example.com>", header.Get("To"))
+	require.Equal(t, "=?utf-8?q?Seus_dados_est=C3=A3o_prontos?=", header.Get("Subject"))
+	require.Equal(t, "text/html; charset=UTF-8", header.Get("Content-Type"))
+
+	_, body, _ := strings.Cut(message.data, "\n\n")
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	require.NoError(t, err)
+	require.Contains(t, string(decoded), `href="https://loja.example.com/x?a=1&b=2"`)
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+)
+
+const publishTimeout = 5 * time.Second
+
+type Event struct {
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
+
+// Pinger is implemented by publishers that can report whether their broker
+// is reachable, for the readiness check.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+func New(cfg *config.Config) (Publisher, error) {
+	switch cfg.EventsBroker {
+	case "", "none":
+		return NoopPublisher{}, nil
+	case "kafka":
+		return NewKafkaPublisher(cfg.KafkaBrokers, cfg.EventsTopic), nil
+	case "rabbitmq":
+		return NewRabbitMQPublisher(cfg.RabbitMQURL, cfg.EventsTopic)
+	default:
+		return nil, fmt.Errorf("unsupported events broker: %s", cfg.EventsBroker)
+	}
+}
+
+type NoopPublisher struct{}
+
+func (NoopPublisher) Publish(context.Context, Event) error { return nil }
+
+func (NoopPublisher) Close() error { return nil }
+
+// Emitter adapts a Publisher to the fire-and-forget interface used by the
+// services, so a slow or unavailable broker never blocks a request.
+type Emitter struct {
+	publisher Publisher
+	now       func() time.Time
+	wg        sync.WaitGroup
+}
+
+func NewEmitter(publisher Publisher) *Emitter {
+	return &Emitter{publisher: publisher, now: time.Now}
+}
+
+func (e *Emitter) Publish(eventType string, data interface{}) {
+	event := Event{
+		Type:       eventType,
+		OccurredAt: e.now().UTC(),
+		Data:       data,
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+
+		if err := e.publisher.Publish(ctx, event); err != nil {
+			log.Printf("Error publishing event %s: %v", event.Type, err)
+		}
+	}()
+}
+
+func (e *Emitter) Close() error {
+	e.wg.Wait()
+	return e.publisher.Close()
+}
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePublisher struct {
+	mu     sync.Mutex
+	events []Event
+	err    error
+	closed bool
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, event Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return p.err
+}
+
+func (p *fakePublisher) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		broker      string
+		expectError bool
+	}{
+		{name: "none broker", broker: "none"},
+		{name: "empty broker", broker: ""},
+		{name: "kafka broker", broker: "kafka"},
+		{name: "unsupported broker", broker: "sqs", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher, err := New(&config.Config{
+				EventsBroker: tt.broker,
+				EventsTopic:  "cupcake-store.events",
+				KafkaBrokers: "localhost:9092",
+			})
+
+			if tt.expectError {
+				require.Error(t, err)
+				require.Nil(t, publisher)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, publisher)
+			require.NoError(t, publisher.Close())
+		})
+	}
+}
+
+func TestEmitter_Publish(t *testing.T) {
+	tests := []struct {
+		name       string
+		publishErr error
+	}{
+		{name: "successful publish"},
+		{name: "publisher error is not propagated", publishErr: errors.New("broker unavailable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &fakePublisher{err: tt.publishErr}
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			emitter := NewEmitter(publisher)
+			emitter.now = func() time.Time { return now }
+
+			emitter.Publish("cupcake.created", map[string]uint{"id": 1})
+			require.NoError(t, emitter.Close())
+
+			require.True(t, publisher.closed)
+			require.Len(t, publisher.events, 1)
+			require.Equal(t, "cupcake.created", publisher.events[0].Type)
+			require.Equal(t, now, publisher.events[0].OccurredAt)
+			require.Equal(t, map[string]uint{"id": 1}, publisher.events[0].Data)
+		})
+	}
+}
+
+func TestKafkaPublisher_PingUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	publisher := NewKafkaPublisher(addr, "cupcake-store.events")
+	defer publisher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.Error(t, publisher.Ping(ctx))
+}
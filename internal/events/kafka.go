@@ -0,0 +1,58 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type KafkaPublisher struct {
+	writer  *kafka.Writer
+	brokers []string
+}
+
+func NewKafkaPublisher(brokers, topic string) *KafkaPublisher {
+	addrs := strings.Split(brokers, ",")
+	return &KafkaPublisher{
+		brokers: addrs,
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(addrs...),
+			Topic:                  topic,
+			Balancer:               &kafka.Hash{},
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.Type),
+		Value: body,
+	})
+}
+
+// Ping succeeds as soon as one broker accepts a connection; the writer only
+// needs one to discover the rest of the cluster.
+func (p *KafkaPublisher) Ping(ctx context.Context) error {
+	var errs []error
+	for _, addr := range p.brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", addr)
+		if err == nil {
+			return conn.Close()
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type RabbitMQPublisher struct {
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	exchange string
+}
+
+func NewRabbitMQPublisher(url, exchange string) (*RabbitMQPublisher, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to rabbitmq: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error opening rabbitmq channel: %w", err)
+	}
+
+	if err := channel.ExchangeDeclare(exchange, "topic", true, false, false, false, nil); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error declaring rabbitmq exchange: %w", err)
+	}
+
+	return &RabbitMQPublisher{conn: conn, channel: channel, exchange: exchange}, nil
+}
+
+func (p *RabbitMQPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.channel.PublishWithContext(ctx, p.exchange, event.Type, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    event.OccurredAt,
+		Body:         body,
+	})
+}
+
+// Ping reports whether the connection and channel opened at startup are still
+// usable; amqp091 does not reconnect on its own.
+func (p *RabbitMQPublisher) Ping(context.Context) error {
+	if p.conn.IsClosed() {
+		return errors.New("rabbitmq connection is closed")
+	}
+	if p.channel.IsClosed() {
+		return errors.New("rabbitmq channel is closed")
+	}
+	return nil
+}
+
+func (p *RabbitMQPublisher) Close() error {
+	p.channel.Close()
+	return p.conn.Close()
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type AddonHandler struct {
+	service service.AddonServiceInterface
+}
+
+func NewAddonHandler(service service.AddonServiceInterface) *AddonHandler {
+	return &AddonHandler{service: service}
+}
+
+func (h *AddonHandler) CreateAddon(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateAddonRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	addon, err := h.service.CreateAddon(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(addon)
+}
+
+func (h *AddonHandler) GetAddon(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	addon, err := h.service.GetAddon(uint(id))
+	if err != nil {
+		sendAddonError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(addon)
+}
+
+func (h *AddonHandler) GetAllAddons(w http.ResponseWriter, r *http.Request) {
+	addons, err := h.service.GetAllAddons()
+	if err != nil {
+		sendJSONError(w, "Error fetching add-ons", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(addons)
+}
+
+func (h *AddonHandler) GetAvailableAddons(w http.ResponseWriter, r *http.Request) {
+	addons, err := h.service.GetAvailableAddons()
+	if err != nil {
+		sendJSONError(w, "Error fetching add-ons", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(addons)
+}
+
+func (h *AddonHandler) UpdateAddon(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateAddonRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	addon, err := h.service.UpdateAddon(uint(id), &req)
+	if err != nil {
+		sendAddonError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(addon)
+}
+
+func (h *AddonHandler) DeleteAddon(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteAddon(uint(id)); err != nil {
+		sendAddonError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sendAddonError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrAddonNotFound) {
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
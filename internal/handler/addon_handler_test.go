@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newAddonTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	addonHandler := NewAddonHandler(service.NewAddonService(repository.NewAddonRepository(setupTestDB(t))))
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/addons", addonHandler.GetAvailableAddons)
+	r.Post("/api/v1/admin/addons", addonHandler.CreateAddon)
+	r.Get("/api/v1/admin/addons/{id}", addonHandler.GetAddon)
+	r.Put("/api/v1/admin/addons/{id}", addonHandler.UpdateAddon)
+	r.Delete("/api/v1/admin/addons/{id}", addonHandler.DeleteAddon)
+	return r
+}
+
+func TestAddonLifecycle(t *testing.T) {
+	router := newAddonTestRouter(t)
+
+	for _, payload := range []string{`{"name":"Candles","price_cents":150}`, `{"name":"Gift wrapping","price_cents":300}`} {
+		req := httptest.NewRequest("POST", "/api/v1/admin/addons", bytes.NewBufferString(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	req := httptest.NewRequest("PUT", "/api/v1/admin/addons/1", bytes.NewBufferString(`{"is_available":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/addons", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var addons []models.Addon
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&addons))
+	require.Len(t, addons, 1, "withdrawn add-ons are hidden from customers")
+	require.Equal(t, "Gift wrapping", addons[0].Name)
+
+	req = httptest.NewRequest("DELETE", "/api/v1/admin/addons/2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/addons/2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "add-on not found")
+}
+
+func TestCreateAddon_Validation(t *testing.T) {
+	router := newAddonTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/addons", bytes.NewBufferString(`{"name":"Candles","price_cents":-5}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "price cannot be negative")
+}
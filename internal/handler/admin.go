@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+type adminContextKey struct{}
+
+// WithAdmin records who an admin API request comes from: the admin of its
+// access token, or nil when it carries the static admin token. Requests
+// without it are refused by the handlers that check who is calling.
+func WithAdmin(ctx context.Context, admin *models.Admin) context.Context {
+	return context.WithValue(ctx, adminContextKey{}, admin)
+}
+
+// currentAdmin returns the admin account the request was made as,
+// answering 401 for requests made with the static admin token or none.
+func currentAdmin(w http.ResponseWriter, r *http.Request) (*models.Admin, bool) {
+	admin, _ := r.Context().Value(adminContextKey{}).(*models.Admin)
+	if admin == nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+		sendJSONError(w, "Log in as an admin to use this endpoint", http.StatusUnauthorized)
+		return nil, false
+	}
+	return admin, true
+}
+
+// adminID returns the ID of the admin account the request was made as, or
+// nil when it was made with the static admin token.
+func adminID(r *http.Request) *uint {
+	admin, _ := r.Context().Value(adminContextKey{}).(*models.Admin)
+	if admin == nil {
+		return nil
+	}
+	id := admin.ID
+	return &id
+}
+
+// requireSuperAdmin answers 403 unless the request was made as a
+// super-admin or with the static admin token, which is how the first
+// super-admin gets created, and 401 when it was made as no one.
+func requireSuperAdmin(w http.ResponseWriter, r *http.Request) bool {
+	admin, ok := r.Context().Value(adminContextKey{}).(*models.Admin)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+		sendJSONError(w, "Log in as an admin to use this endpoint", http.StatusUnauthorized)
+		return false
+	}
+	if admin != nil && admin.Role != models.AdminRoleSuperAdmin {
+		sendJSONError(w, "Only a super-admin can do this", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+type AdminHandler struct {
+	service service.AdminServiceInterface
+}
+
+func NewAdminHandler(service service.AdminServiceInterface) *AdminHandler {
+	return &AdminHandler{service: service}
+}
+
+func (h *AdminHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req models.AdminLoginRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	token, err := h.service.Login(&req)
+	if err != nil {
+		sendAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(token)
+}
+
+// SendLoginCode texts a two-factor code to an admin who cannot reach
+// their authenticator, to log in with as the code of Login.
+func (h *AdminHandler) SendLoginCode(w http.ResponseWriter, r *http.Request) {
+	var req models.AdminLoginRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.service.SendLoginCode(&req); err != nil {
+		sendAdminError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *AdminHandler) Me(w http.ResponseWriter, r *http.Request) {
+	admin, ok := currentAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(admin)
+}
+
+// SetPhone sets or, with an empty phone, removes the number SMS codes go
+// to.
+func (h *AdminHandler) SetPhone(w http.ResponseWriter, r *http.Request) {
+	admin, ok := currentAdmin(w, r)
+	if !ok {
+		return
+	}
+	var req models.AdminPhoneRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	updated, err := h.service.SetPhone(admin.ID, req.Phone)
+	if err != nil {
+		sendAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (h *AdminHandler) GetAdmins(w http.ResponseWriter, r *http.Request) {
+	admins, err := h.service.GetAdmins()
+	if err != nil {
+		sendJSONError(w, "Error fetching admins", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(admins)
+}
+
+func (h *AdminHandler) CreateAdmin(w http.ResponseWriter, r *http.Request) {
+	if !requireSuperAdmin(w, r) {
+		return
+	}
+	var req models.CreateAdminRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	admin, err := h.service.CreateAdmin(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(admin)
+}
+
+// ResetTOTP turns two-factor authentication off for another admin who
+// lost their authenticator and backup codes.
+func (h *AdminHandler) ResetTOTP(w http.ResponseWriter, r *http.Request) {
+	if !requireSuperAdmin(w, r) {
+		return
+	}
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	admin, err := h.service.ResetTOTP(uint(id))
+	if err != nil {
+		sendAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(admin)
+}
+
+// BeginTOTP starts two-factor enrollment, returning the secret along with
+// a QR code of the provisioning URI to scan with an authenticator app.
+func (h *AdminHandler) BeginTOTP(w http.ResponseWriter, r *http.Request) {
+	admin, ok := currentAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.service.BeginTOTP(admin.ID)
+	if err != nil {
+		sendAdminError(w, r, err)
+		return
+	}
+	png, err := qrcode.Encode(enrollment.ProvisioningURI, qrcode.Medium, defaultQRSize)
+	if err != nil {
+		sendJSONError(w, "Error generating QR code", http.StatusInternalServerError)
+		return
+	}
+	enrollment.QRCode = "data:image/png;base64," + base64.StdEncoding.EncodeToString(png)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(enrollment)
+}
+
+// ConfirmTOTP turns two-factor authentication on with a first code from
+// the authenticator and returns the backup codes, which are not shown
+// again.
+func (h *AdminHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	admin, ok := currentAdmin(w, r)
+	if !ok {
+		return
+	}
+	var req models.TOTPCodeRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	codes, err := h.service.ConfirmTOTP(admin.ID, req.Code)
+	if err != nil {
+		sendAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(codes)
+}
+
+func (h *AdminHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	admin, ok := currentAdmin(w, r)
+	if !ok {
+		return
+	}
+	var req models.TOTPCodeRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.service.DisableTOTP(admin.ID, req.Code); err != nil {
+		sendAdminError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminHandler) RegenerateBackupCodes(w http.ResponseWriter, r *http.Request) {
+	admin, ok := currentAdmin(w, r)
+	if !ok {
+		return
+	}
+	var req models.TOTPCodeRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	codes, err := h.service.RegenerateBackupCodes(admin.ID, req.Code)
+	if err != nil {
+		sendAdminError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(codes)
+}
+
+func sendAdminError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials),
+		errors.Is(err, service.ErrSecondFactorRequired),
+		errors.Is(err, service.ErrSecondFactorInvalid),
+		errors.Is(err, service.ErrAccessTokenInvalid),
+		errors.Is(err, service.ErrAccessTokenExpired):
+		w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+		sendJSONError(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrAdminNotFound):
+		sendJSONError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrTOTPNotEnrolled),
+		errors.Is(err, service.ErrTOTPAlreadyEnabled),
+		errors.Is(err, service.ErrTOTPNotEnabled),
+		errors.Is(err, service.ErrSMSUnavailable):
+		sendJSONError(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrSMSCodeTooSoon):
+		w.Header().Set("Retry-After", "60")
+		sendJSONError(w, err.Error(), http.StatusTooManyRequests)
+	default:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin_TwoFactor(t *testing.T) {
+	db := setupTestDB(t)
+	params := password.Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	svc := service.NewAdminService(repository.NewAdminRepository(db), params, "test-secret", time.Hour)
+	admin, err := svc.CreateAdmin(&models.CreateAdminRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	handler := NewAdminHandler(svc)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/admin/auth/login", handler.Login)
+	r.Group(func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r.WithContext(WithAdmin(r.Context(), admin)))
+			})
+		})
+		r.Post("/api/v1/admin/me/2fa", handler.BeginTOTP)
+		r.Post("/api/v1/admin/me/2fa/confirm", handler.ConfirmTOTP)
+	})
+	post := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("POST", path, strings.NewReader(body)))
+		return w
+	}
+
+	w := post("/api/v1/admin/me/2fa", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var enrollment models.TOTPEnrollment
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&enrollment))
+	require.True(t, strings.HasPrefix(enrollment.QRCode, "data:image/png;base64,"))
+
+	w = post("/api/v1/admin/me/2fa/confirm", `{"code":"000000"}`)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	code, err := totp.Code(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	w = post("/api/v1/admin/me/2fa/confirm", `{"code":"`+code+`"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var backup models.BackupCodes
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&backup))
+	require.Len(t, backup.BackupCodes, service.BackupCodeCount)
+
+	w = post("/api/v1/admin/me/2fa", "")
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	w = post("/api/v1/admin/auth/login", `{"email":"ana@example.com","password":"correct horse"}`)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Contains(t, w.Body.String(), "two-factor code required")
+
+	w = post("/api/v1/admin/auth/login", `{"email":"ana@example.com","password":"correct horse","code":"`+backup.BackupCodes[0]+`"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var token models.AdminAccessToken
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&token))
+	require.NotEmpty(t, token.AccessToken)
+	require.NotContains(t, w.Body.String(), "totp_secret")
+}
+
+func TestAdmin_SuperAdminOnly(t *testing.T) {
+	svc := &mocks.AdminService{
+		ResetTOTPFunc: func(adminID uint) (*models.Admin, error) {
+			if adminID != 2 {
+				return nil, service.ErrAdminNotFound
+			}
+			return &models.Admin{ID: 2, Name: "Bruno"}, nil
+		},
+	}
+	handler := NewAdminHandler(svc)
+
+	reset := func(as *models.Admin, id string) int {
+		r := chi.NewRouter()
+		r.Delete("/api/v1/admin/admins/{id}/2fa", handler.ResetTOTP)
+		req := httptest.NewRequest("DELETE", "/api/v1/admin/admins/"+id+"/2fa", nil)
+		req = req.WithContext(WithAdmin(req.Context(), as))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusForbidden, reset(&models.Admin{ID: 1, Role: models.AdminRoleAdmin}, "2"))
+	require.Equal(t, http.StatusOK, reset(&models.Admin{ID: 1, Role: models.AdminRoleSuperAdmin}, "2"))
+	require.Equal(t, http.StatusNotFound, reset(&models.Admin{ID: 1, Role: models.AdminRoleSuperAdmin}, "3"))
+	// The static admin token acts as a super-admin.
+	require.Equal(t, http.StatusOK, reset(nil, "2"))
+}
+
+func TestAdmin_MeNeedsAdminAccount(t *testing.T) {
+	handler := NewAdminHandler(&mocks.AdminService{})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/v1/admin/me", nil)
+	handler.Me(w, req.WithContext(WithAdmin(req.Context(), nil)))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, `Bearer realm="admin"`, w.Header().Get("WWW-Authenticate"))
+}
+
+func TestAdmin_SMSLoginCode(t *testing.T) {
+	admin := &models.Admin{ID: 1, Email: "ana@example.com"}
+	sent := 0
+	svc := &mocks.AdminService{
+		SendLoginCodeFunc: func(req *models.AdminLoginRequest) error {
+			switch {
+			case req.Password != "correct horse":
+				return service.ErrInvalidCredentials
+			case sent > 0:
+				return service.ErrSMSCodeTooSoon
+			}
+			sent++
+			return nil
+		},
+		SetPhoneFunc: func(adminID uint, phone string) (*models.Admin, error) {
+			require.Equal(t, admin.ID, adminID)
+			return &models.Admin{ID: adminID, Phone: phone}, nil
+		},
+	}
+	handler := NewAdminHandler(svc)
+	r := chi.NewRouter()
+	r.Post("/api/v1/admin/auth/login/sms", handler.SendLoginCode)
+	r.With(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(WithAdmin(r.Context(), admin)))
+		})
+	}).Put("/api/v1/admin/me/phone", handler.SetPhone)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	w := serve("PUT", "/api/v1/admin/me/phone", `{"phone":"+5511912345678"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"phone":"+5511912345678"`)
+
+	w = serve("POST", "/api/v1/admin/auth/login/sms", `{"email":"ana@example.com","password":"wrong horse"}`)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serve("POST", "/api/v1/admin/auth/login/sms", `{"email":"ana@example.com","password":"correct horse"}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	w = serve("POST", "/api/v1/admin/auth/login/sms", `{"email":"ana@example.com","password":"correct horse"}`)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "60", w.Header().Get("Retry-After"))
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// apiKeyHeader carries the key an integration calls the public API with.
+const apiKeyHeader = "X-API-Key"
+
+type APIKeyHandler struct {
+	service service.APIKeyServiceInterface
+	now     func() time.Time
+}
+
+func NewAPIKeyHandler(service service.APIKeyServiceInterface) *APIKeyHandler {
+	return &APIKeyHandler{service: service, now: time.Now}
+}
+
+// Meter counts requests made with an API key against its quotas, telling
+// the client in X-Quota-Limit, X-Quota-Remaining and X-Quota-Reset (Unix
+// seconds) where it stands. Past a quota the answer is 429 with
+// Retry-After, and an unknown or revoked key gets 401. Requests without a
+// key are let through uncounted.
+func (h *APIKeyHandler) Meter(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := r.Header.Get(apiKeyHeader)
+		if secret == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		quota, err := h.service.Use(secret)
+		if quota != nil && quota.Limit > 0 {
+			w.Header().Set("X-Quota-Limit", strconv.Itoa(quota.Limit))
+			w.Header().Set("X-Quota-Remaining", strconv.Itoa(quota.Remaining))
+			w.Header().Set("X-Quota-Reset", strconv.FormatInt(quota.Reset.Unix(), 10))
+		}
+		switch {
+		case err == nil:
+			next.ServeHTTP(w, r)
+		case errors.Is(err, service.ErrQuotaExceeded):
+			retryAfter := math.Ceil(quota.Reset.Sub(h.now()).Seconds())
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(retryAfter), 1)))
+			sendJSONError(w, err.Error(), http.StatusTooManyRequests)
+		case errors.Is(err, service.ErrAPIKeyInvalid):
+			sendJSONError(w, err.Error(), http.StatusUnauthorized)
+		default:
+			sendJSONError(w, "Error checking API key", http.StatusInternalServerError)
+		}
+	})
+}
+
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateAPIKeyRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	key, err := h.service.CreateAPIKey(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(key)
+}
+
+func (h *APIKeyHandler) GetAllAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.service.GetAPIKeys()
+	if err != nil {
+		sendJSONError(w, "Error fetching API keys", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+func (h *APIKeyHandler) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	key, err := h.service.GetAPIKey(uint(id))
+	if err != nil {
+		sendAPIKeyError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(key)
+}
+
+func (h *APIKeyHandler) UpdateAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var req models.UpdateAPIKeyRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	key, err := h.service.UpdateAPIKey(uint(id), &req)
+	if err != nil {
+		sendAPIKeyError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(key)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	key, err := h.service.RevokeAPIKey(uint(id))
+	if err != nil {
+		sendAPIKeyError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(key)
+}
+
+// GetUsage reports the key's requests over ?window= days ("30d" by
+// default), along with today's and this month's against its quotas.
+func (h *APIKeyHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	days := service.DefaultUsageWindow
+	if v := r.URL.Query().Get("window"); v != "" {
+		if days, err = strconv.Atoi(strings.TrimSuffix(v, "d")); err != nil || !strings.HasSuffix(v, "d") {
+			sendJSONError(w, "Invalid window, expected days such as 30d", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := h.service.Usage(uint(id), days)
+	if err != nil {
+		sendAPIKeyError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+func sendAPIKeyError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrAPIKeyNotFound) {
+		sendJSONError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
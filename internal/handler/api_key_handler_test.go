@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPIKeyTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	handler := NewAPIKeyHandler(service.NewAPIKeyService(repository.NewAPIKeyRepository(db)))
+	r := chi.NewRouter()
+
+	r.With(handler.Meter).Get("/api/v1/cupcakes", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Route("/api/v1/admin/api-keys", func(r chi.Router) {
+		r.Get("/", handler.GetAllAPIKeys)
+		r.Post("/", handler.CreateAPIKey)
+		r.Get("/{id}", handler.GetAPIKey)
+		r.Put("/{id}", handler.UpdateAPIKey)
+		r.Delete("/{id}", handler.RevokeAPIKey)
+		r.Get("/{id}/usage", handler.GetUsage)
+	})
+
+	return r
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "valid key returns 201",
+			payload:        `{"name":"POS","daily_quota":1000}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing name returns 400",
+			payload:        `{"daily_quota":1000}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "name is required",
+		},
+		{
+			name:           "negative quota returns 400",
+			payload:        `{"name":"POS","monthly_quota":-5}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "quota cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAPIKeyTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/admin/api-keys", bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestAPIKeyQuota(t *testing.T) {
+	router := newAPIKeyTestRouter(t)
+	serve := func(method, path, body string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("POST", "/api/v1/admin/api-keys", `{"name":"Partner","daily_quota":2}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created models.CreatedAPIKey
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	withKey := http.Header{"X-Api-Key": {created.Key}}
+
+	w = serve("GET", "/api/v1/cupcakes", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("X-Quota-Limit"))
+
+	w = serve("GET", "/api/v1/cupcakes", "", withKey)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "2", w.Header().Get("X-Quota-Limit"))
+	require.Equal(t, "1", w.Header().Get("X-Quota-Remaining"))
+	require.NotEmpty(t, w.Header().Get("X-Quota-Reset"))
+
+	serve("GET", "/api/v1/cupcakes", "", withKey)
+	w = serve("GET", "/api/v1/cupcakes", "", withKey)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "0", w.Header().Get("X-Quota-Remaining"))
+	require.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	usagePath := fmt.Sprintf("/api/v1/admin/api-keys/%d/usage", created.ID)
+	w = serve("GET", usagePath+"?window=7d", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var report models.APIKeyUsageReport
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	require.Equal(t, 2, report.Today)
+	require.Equal(t, 2, report.Total)
+	require.Len(t, report.Days, 1)
+
+	w = serve("GET", usagePath+"?window=7", "", nil)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve("GET", "/api/v1/admin/api-keys/999/usage", "", nil)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve("DELETE", fmt.Sprintf("/api/v1/admin/api-keys/%d", created.ID), "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serve("GET", "/api/v1/cupcakes", "", withKey)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serve("GET", "/api/v1/cupcakes", "", http.Header{"X-Api-Key": {"not-a-key"}})
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type AuthHandler struct {
+	service service.AccountServiceInterface
+}
+
+func NewAuthHandler(service service.AccountServiceInterface) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req models.RegisterRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	account, err := h.service.Register(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(account)
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req models.LoginRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	token, err := h.service.Login(&req)
+	if err != nil {
+		sendAuthError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(token)
+}
+
+// Me returns the account of the bearer token in Authorization.
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	account, err := h.service.Authenticate(token)
+	if err != nil {
+		sendAuthError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+// VerifyEmail handles the link sent on registration.
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		sendJSONError(w, "Missing token", http.StatusBadRequest)
+		return
+	}
+
+	account, err := h.service.VerifyEmail(token)
+	if err != nil {
+		sendAuthError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+// ResendVerification sends the bearer token's account a new verification
+// link, unless its email is already verified.
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.ResendVerification(token); err != nil {
+		sendAuthError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"status": "verification_sent"})
+}
+
+// bearerToken reads the access token from Authorization, answering 401
+// when there is none.
+func bearerToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		sendJSONError(w, "Missing bearer token", http.StatusUnauthorized)
+		return "", false
+	}
+	return token, true
+}
+
+func sendAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials),
+		errors.Is(err, service.ErrAccessTokenInvalid),
+		errors.Is(err, service.ErrAccessTokenExpired):
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		sendJSONError(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrVerificationTokenInvalid):
+		sendJSONError(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrVerificationTokenExpired):
+		sendJSONError(w, err.Error(), http.StatusGone)
+	case errors.Is(err, service.ErrVerificationUnavailable):
+		sendJSONError(w, err.Error(), http.StatusServiceUnavailable)
+	case errors.Is(err, service.ErrVerificationNotSent):
+		sendJSONError(w, err.Error(), http.StatusBadGateway)
+	default:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+	}
+}
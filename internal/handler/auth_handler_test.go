@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth(t *testing.T) {
+	db := setupTestDB(t)
+	params := password.Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	handler := NewAuthHandler(service.NewAccountService(repository.NewAccountRepository(db), params, "test-secret", time.Hour))
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/auth/register", handler.Register)
+	r.Post("/api/v1/auth/login", handler.Login)
+	r.Get("/api/v1/auth/me", handler.Me)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/auth/register", strings.NewReader(`{"name":"Ana","email":"ana@example.com","password":"short"}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "password must have at least 8 characters")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/auth/register", strings.NewReader(`{"name":"Ana","email":"ana@example.com","password":"correct horse"}`)))
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.NotContains(t, w.Body.String(), "password")
+	require.NotContains(t, w.Body.String(), "argon2")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/auth/login", strings.NewReader(`{"email":"ana@example.com","password":"wrong horse"}`)))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Contains(t, w.Body.String(), "invalid email or password")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/auth/login", strings.NewReader(`{"email":"ana@example.com","password":"correct horse"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	var token models.AccessToken
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&token))
+	require.NotEmpty(t, token.AccessToken)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/auth/me", nil))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken+"x")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/auth/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"email":"ana@example.com"`)
+}
+
+func TestAuth_VerifyEmail(t *testing.T) {
+	db := setupTestDB(t)
+	params := password.Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	var verifyPath string
+	sent := 0
+	sender := &mocks.EmailSender{SendFunc: func(_ context.Context, _ string, email *models.RenderedEmail) error {
+		href := regexp.MustCompile(`href="([^"]+)"`).FindStringSubmatch(email.HTML)
+		require.NotNil(t, href)
+		link, err := url.Parse(html.UnescapeString(href[1]))
+		require.NoError(t, err)
+		verifyPath = link.RequestURI()
+		sent++
+		return nil
+	}}
+	accounts := service.NewAccountService(repository.NewAccountRepository(db), params, "test-secret", time.Hour)
+	handler := NewAuthHandler(accounts.WithEmail(sender, "https://loja.example.com"))
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/auth/register", handler.Register)
+	r.Post("/api/v1/auth/login", handler.Login)
+	r.Get("/api/v1/auth/verify", handler.VerifyEmail)
+	r.Post("/api/v1/auth/verify/resend", handler.ResendVerification)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/auth/register", strings.NewReader(`{"name":"Ana","email":"ana@example.com","password":"correct horse"}`)))
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.NotEmpty(t, verifyPath)
+	require.NotContains(t, w.Body.String(), "token")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/auth/login", strings.NewReader(`{"email":"ana@example.com","password":"correct horse"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	var token models.AccessToken
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&token))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/auth/verify/resend", nil))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req := httptest.NewRequest("POST", "/api/v1/auth/verify/resend", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"verification_sent"`)
+	require.Equal(t, 2, sent)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/auth/verify", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/auth/verify?token=1.2.bad", nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", verifyPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"email_verified_at"`)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type BundleHandler struct {
+	service service.BundleServiceInterface
+}
+
+func NewBundleHandler(service service.BundleServiceInterface) *BundleHandler {
+	return &BundleHandler{service: service}
+}
+
+func (h *BundleHandler) CreateBundle(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateBundleRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	bundle, err := h.service.CreateBundle(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(bundle)
+}
+
+func (h *BundleHandler) GetBundle(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	bundle, err := h.service.GetBundle(uint(id))
+	if err != nil {
+		sendBundleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bundle)
+}
+
+func (h *BundleHandler) GetAllBundles(w http.ResponseWriter, r *http.Request) {
+	bundles, err := h.service.GetAllBundles()
+	if err != nil {
+		sendJSONError(w, "Error fetching bundles", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bundles)
+}
+
+func (h *BundleHandler) GetAvailableBundles(w http.ResponseWriter, r *http.Request) {
+	bundles, err := h.service.GetAvailableBundles()
+	if err != nil {
+		sendJSONError(w, "Error fetching bundles", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bundles)
+}
+
+func (h *BundleHandler) UpdateBundle(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateBundleRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	bundle, err := h.service.UpdateBundle(uint(id), &req)
+	if err != nil {
+		sendBundleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bundle)
+}
+
+func (h *BundleHandler) DeleteBundle(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteBundle(uint(id)); err != nil {
+		sendBundleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sendBundleError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrBundleNotFound) {
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func newBundleTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+	bundleHandler := NewBundleHandler(service.NewBundleService(repository.NewBundleRepository(db), cupcakeRepo))
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/bundles", bundleHandler.GetAvailableBundles)
+	r.Post("/api/v1/admin/bundles", bundleHandler.CreateBundle)
+	r.Get("/api/v1/admin/bundles/{id}", bundleHandler.GetBundle)
+	r.Put("/api/v1/admin/bundles/{id}", bundleHandler.UpdateBundle)
+	r.Delete("/api/v1/admin/bundles/{id}", bundleHandler.DeleteBundle)
+	return r
+}
+
+func TestBundleLifecycle(t *testing.T) {
+	router := newBundleTestRouter(t)
+
+	for _, payload := range []string{
+		`{"name":"Box of 6","price_cents":4500,"items":[{"cupcake_id":1,"quantity":3},{"cupcake_id":2,"quantity":3}]}`,
+		`{"name":"Chocolate duo","price_cents":1600,"items":[{"cupcake_id":2,"quantity":2}]}`,
+	} {
+		req := httptest.NewRequest("POST", "/api/v1/admin/bundles", bytes.NewBufferString(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	req := httptest.NewRequest("PUT", "/api/v1/admin/bundles/2", bytes.NewBufferString(`{"is_available":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/bundles", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var bundles []models.Bundle
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&bundles))
+	require.Len(t, bundles, 1, "withdrawn bundles are hidden from customers")
+	require.Equal(t, "Box of 6", bundles[0].Name)
+	require.Len(t, bundles[0].Items, 2)
+
+	req = httptest.NewRequest("DELETE", "/api/v1/admin/bundles/2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/bundles/2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "bundle not found")
+}
+
+func TestCreateBundle_Validation(t *testing.T) {
+	router := newBundleTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/bundles", bytes.NewBufferString(`{"name":"Box of 6","price_cents":4500,"items":[{"cupcake_id":999,"quantity":6}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "cupcake 999 not found")
+}
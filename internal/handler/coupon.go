@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type CouponHandler struct {
+	service service.CouponServiceInterface
+}
+
+func NewCouponHandler(service service.CouponServiceInterface) *CouponHandler {
+	return &CouponHandler{service: service}
+}
+
+func (h *CouponHandler) CreateCoupon(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateCouponRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	coupon, err := h.service.CreateCoupon(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(coupon)
+}
+
+func (h *CouponHandler) GetCoupon(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	coupon, err := h.service.GetCoupon(uint(id))
+	if err != nil {
+		sendJSONError(w, "coupon not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(coupon)
+}
+
+func (h *CouponHandler) GetAllCoupons(w http.ResponseWriter, r *http.Request) {
+	coupons, err := h.service.GetAllCoupons()
+	if err != nil {
+		sendJSONError(w, "Error fetching coupons", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(coupons)
+}
+
+func (h *CouponHandler) UpdateCoupon(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateCouponRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	coupon, err := h.service.UpdateCoupon(uint(id), &req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(coupon)
+}
+
+func (h *CouponHandler) DeleteCoupon(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteCoupon(uint(id)); err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
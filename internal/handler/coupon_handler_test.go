@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newCouponTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	repo := repository.NewCouponRepository(db)
+	handler := NewCouponHandler(service.NewCouponService(repo, repository.NewUnitOfWork(db)))
+	r := chi.NewRouter()
+
+	r.Route("/api/v1/admin/coupons", func(r chi.Router) {
+		r.Post("/", handler.CreateCoupon)
+		r.Get("/", handler.GetAllCoupons)
+		r.Get("/{id}", handler.GetCoupon)
+		r.Put("/{id}", handler.UpdateCoupon)
+		r.Delete("/{id}", handler.DeleteCoupon)
+	})
+
+	return r
+}
+
+func createTestCoupon(t *testing.T, router chi.Router, payload map[string]interface{}) models.Coupon {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/coupons", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var coupon models.Coupon
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &coupon))
+	return coupon
+}
+
+func TestCreateCoupon(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "valid payload returns 201",
+			payload:        `{"code":"sweet10","discount_type":"percentage","discount_value":10}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid discount returns 400",
+			payload:        `{"code":"sweet10","discount_type":"percentage","discount_value":0}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "percentage discount must be between 1 and 100",
+		},
+		{
+			name:           "malformed JSON returns 400",
+			payload:        `{"code":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Error decoding request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newCouponTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/admin/coupons", bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestCouponCRUD(t *testing.T) {
+	router := newCouponTestRouter(t)
+
+	created := createTestCoupon(t, router, map[string]interface{}{
+		"code":           "FIVEOFF",
+		"discount_type":  "fixed",
+		"discount_value": 500,
+	})
+	require.Equal(t, "FIVEOFF", created.Code)
+
+	req := httptest.NewRequest("GET", "/api/v1/admin/coupons", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var coupons []models.Coupon
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &coupons))
+	require.Len(t, coupons, 1)
+
+	req = httptest.NewRequest("PUT", fmt.Sprintf("/api/v1/admin/coupons/%d", created.ID), bytes.NewBufferString(`{"is_active":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var updated models.Coupon
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	require.False(t, updated.IsActive)
+
+	req = httptest.NewRequest("DELETE", fmt.Sprintf("/api/v1/admin/coupons/%d", created.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/v1/admin/coupons/%d", created.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "coupon not found")
+
+	req = httptest.NewRequest("DELETE", fmt.Sprintf("/api/v1/admin/coupons/%d", created.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.NotContains(t, w.Body.String(), "record not found")
+}
+
+func TestGetCoupon_InvalidID(t *testing.T) {
+	tests := []struct {
+		name     string
+		couponID string
+	}{
+		{name: "non-numeric ID", couponID: "abc"},
+		{name: "zero ID", couponID: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newCouponTestRouter(t)
+
+			req := httptest.NewRequest("GET", "/api/v1/admin/coupons/"+tt.couponID, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			require.Contains(t, w.Body.String(), "Invalid ID")
+		})
+	}
+}
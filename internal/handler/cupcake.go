@@ -2,11 +2,19 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/currency"
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
 	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
 	"github.com/julimonteiro/cupcake-store/internal/service"
 )
 
@@ -16,14 +24,71 @@ func sendJSONError(w http.ResponseWriter, message string, statusCode int) {
 	json.NewEncoder(w).Encode(map[string]string{"error": message})
 }
 
+// sendLocalizedError reports err, with service validation messages in
+// the language the request asks for. Invalid fields always get 422 with
+// one entry per failed rule, whatever statusCode is, and repository
+// errors get 404 or 409.
+func sendLocalizedError(w http.ResponseWriter, r *http.Request, err error, statusCode int) {
+	w.Header().Add("Vary", "Accept-Language")
+
+	var invalid *service.ValidationError
+	if errors.As(err, &invalid) {
+		lang := requestedLanguage(r)
+		resp := models.ValidationErrorResponse{
+			Error:  "validation failed",
+			Errors: make([]models.FieldErrorResponse, len(invalid.Fields)),
+		}
+		for i, field := range invalid.Fields {
+			resp.Errors[i] = models.FieldErrorResponse{Field: field.Field, Rule: field.Rule, Message: field.Err.Localize(lang)}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		statusCode = http.StatusNotFound
+	case errors.Is(err, repository.ErrDuplicate), errors.Is(err, repository.ErrConflict):
+		statusCode = http.StatusConflict
+	}
+	sendJSONError(w, i18n.Translate(err, requestedLanguage(r)), statusCode)
+}
+
+// decodeRequest decodes the JSON body into v. Bodies cut off by the size
+// limit get 413; anything else malformed gets 400.
+func decodeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return true
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		sendJSONError(w, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+		return false
+	}
+	sendJSONError(w, "Error decoding request", http.StatusBadRequest)
+	return false
+}
+
 type CupcakeHandler struct {
-	service *service.CupcakeService
+	service     service.CupcakeServiceInterface
+	experiments service.ExperimentServiceInterface
 }
 
-func NewCupcakeHandler(service *service.CupcakeService) *CupcakeHandler {
+func NewCupcakeHandler(service service.CupcakeServiceInterface) *CupcakeHandler {
 	return &CupcakeHandler{service: service}
 }
 
+// WithExperiments has price experiments adjust the prices the handler
+// serves to visitors that send X-Visitor-ID.
+func (h *CupcakeHandler) WithExperiments(experiments service.ExperimentServiceInterface) *CupcakeHandler {
+	h.experiments = experiments
+	return h
+}
+
 func (h *CupcakeHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -35,20 +100,38 @@ func (h *CupcakeHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 
 func (h *CupcakeHandler) CreateCupcake(w http.ResponseWriter, r *http.Request) {
 	var req models.CreateCupcakeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		sendJSONError(w, "Error decoding request", http.StatusBadRequest)
+	if !decodeRequest(w, r, &req) {
 		return
 	}
+	if v := r.URL.Query().Get("force"); v != "" {
+		force, err := strconv.ParseBool(v)
+		if err != nil {
+			sendJSONError(w, "Invalid force", http.StatusBadRequest)
+			return
+		}
+		req.Force = force
+	}
+	req.CreatedBy = adminID(r)
 
 	cupcake, err := h.service.CreateCupcake(&req)
+	var duplicates *service.DuplicateCupcakeError
+	if errors.As(err, &duplicates) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(models.DuplicateCupcakeResponse{
+			Error:      err.Error() + "; retry with ?force=true to create it anyway",
+			Duplicates: models.NewCupcakeResponses(duplicates.Matches),
+		})
+		return
+	}
 	if err != nil {
-		sendJSONError(w, err.Error(), http.StatusBadRequest)
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(cupcake)
+	json.NewEncoder(w).Encode(models.NewAdminCupcakeResponse(cupcake))
 }
 
 func (h *CupcakeHandler) GetCupcake(w http.ResponseWriter, r *http.Request) {
@@ -59,25 +142,317 @@ func (h *CupcakeHandler) GetCupcake(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	enc, ok := negotiateResponse(w, r, itemMediaTypes)
+	if !ok {
+		return
+	}
+	fields, err := parseFields(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
 	cupcake, err := h.service.GetCupcake(uint(id))
 	if err != nil {
 		sendJSONError(w, "cupcake not found", http.StatusNotFound)
 		return
 	}
 
+	cupcakes := []models.Cupcake{*cupcake}
+	if !h.applyExperiments(w, r, cupcakes) {
+		return
+	}
+	if err := h.service.ConvertPrices(cupcakes, requestedCurrency(r)); err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if !h.localize(w, r, cupcakes) {
+		return
+	}
+
+	writeCupcake(w, enc, cupcakes[0], fields)
+}
+
+func (h *CupcakeHandler) GetCupcakeBySKU(w http.ResponseWriter, r *http.Request) {
+	enc, ok := negotiateResponse(w, r, itemMediaTypes)
+	if !ok {
+		return
+	}
+	fields, err := parseFields(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	cupcake, err := h.service.GetCupcakeBySKU(chi.URLParam(r, "sku"))
+	if err != nil {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+
+	cupcakes := []models.Cupcake{*cupcake}
+	if !h.applyExperiments(w, r, cupcakes) {
+		return
+	}
+	if err := h.service.ConvertPrices(cupcakes, requestedCurrency(r)); err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if !h.localize(w, r, cupcakes) {
+		return
+	}
+
+	writeCupcake(w, enc, cupcakes[0], fields)
+}
+
+// GetCupcakeBySlug looks a cupcake up by its slug, for SEO-friendly URLs.
+func (h *CupcakeHandler) GetCupcakeBySlug(w http.ResponseWriter, r *http.Request) {
+	enc, ok := negotiateResponse(w, r, itemMediaTypes)
+	if !ok {
+		return
+	}
+	fields, err := parseFields(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	cupcake, err := h.service.GetCupcakeBySlug(chi.URLParam(r, "slug"))
+	if err != nil {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+
+	cupcakes := []models.Cupcake{*cupcake}
+	if !h.applyExperiments(w, r, cupcakes) {
+		return
+	}
+	if err := h.service.ConvertPrices(cupcakes, requestedCurrency(r)); err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if !h.localize(w, r, cupcakes) {
+		return
+	}
+
+	writeCupcake(w, enc, cupcakes[0], fields)
+}
+
+// GetRelatedCupcakes lists available cupcakes sharing the flavor of the
+// cupcake, at most ?limit= of them.
+func (h *CupcakeHandler) GetRelatedCupcakes(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	limit := service.DefaultRelatedLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			sendJSONError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	cupcakes, err := h.service.GetRelatedCupcakes(uint(id), limit)
+	if errors.Is(err, repository.ErrNotFound) {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if !h.applyExperiments(w, r, cupcakes) {
+		return
+	}
+	if err := h.service.ConvertPrices(cupcakes, requestedCurrency(r)); err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if !h.localize(w, r, cupcakes) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.NewCupcakeResponses(cupcakes))
+}
+
+func (h *CupcakeHandler) BulkUpdatePrices(w http.ResponseWriter, r *http.Request) {
+	var req models.BulkPriceUpdateRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	req.UpdatedBy = adminID(r)
+
+	result, err := h.service.BulkUpdatePrices(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(cupcake)
+	json.NewEncoder(w).Encode(result)
 }
 
 func (h *CupcakeHandler) GetAllCupcakes(w http.ResponseWriter, r *http.Request) {
-	cupcakes, err := h.service.GetAllCupcakes()
+	locationID, err := locationParam(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "ndjson" {
+		if locationID != 0 {
+			sendJSONError(w, "location_id is not supported with format=ndjson", http.StatusBadRequest)
+			return
+		}
+		h.streamCupcakes(w, r)
+		return
+	}
+
+	enc, ok := negotiateResponse(w, r, listMediaTypes)
+	if !ok {
+		return
+	}
+	fields, err := parseFields(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if isHypermedia(enc) {
+		h.listCupcakesPage(w, r, enc, fields, locationID)
+		return
+	}
+
+	var cupcakes []models.Cupcake
+	if locationID != 0 {
+		cupcakes, err = h.service.GetCupcakesAtLocation(locationID)
+	} else {
+		cupcakes, err = h.service.GetAllCupcakes()
+	}
+	if errors.Is(err, service.ErrLocationNotFound) {
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		sendJSONError(w, "Error fetching cupcakes", http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(cupcakes)
+	if !h.applyExperiments(w, r, cupcakes) {
+		return
+	}
+	if err := h.service.ConvertPrices(cupcakes, requestedCurrency(r)); err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if !h.localize(w, r, cupcakes) {
+		return
+	}
+
+	responses := models.NewCupcakeResponses(cupcakes)
+	if fields != nil {
+		writeResponse(w, enc, newFieldSetList(responses, fields))
+		return
+	}
+	writeResponse(w, enc, cupcakeList(responses))
+}
+
+// listCupcakesPage serves the paginated v2 collection envelope.
+func (h *CupcakeHandler) listCupcakesPage(w http.ResponseWriter, r *http.Request, enc responseEncoder, fields []string, locationID uint) {
+	query := r.URL.Query()
+	page, perPage, err := pageParams(query)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	var cupcakes []models.Cupcake
+	var total int64
+	if locationID != 0 {
+		cupcakes, total, err = h.service.ListCupcakesAtLocation(locationID, page, perPage)
+	} else {
+		cupcakes, total, err = h.service.ListCupcakes(page, perPage)
+	}
+	if errors.Is(err, service.ErrLocationNotFound) {
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if !h.applyExperiments(w, r, cupcakes) {
+		return
+	}
+	if err := h.service.ConvertPrices(cupcakes, requestedCurrency(r)); err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if !h.localize(w, r, cupcakes) {
+		return
+	}
+
+	writeResponse(w, enc, newCupcakeCollection(models.NewCupcakeResponses(cupcakes), total, page, perPage, query, fields))
+}
+
+// streamCupcakes writes one JSON object per line as rows are read from the
+// database, flushing periodically so large catalogs never sit in memory.
+func (h *CupcakeHandler) streamCupcakes(w http.ResponseWriter, r *http.Request) {
+	const flushEvery = 100
+
+	fields, err := parseFields(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	written := 0
+
+	err = h.service.StreamCupcakes(requestedCurrency(r), func(cupcake *models.Cupcake) error {
+		if written == 0 {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+		}
+		response := models.NewCupcakeResponse(cupcake)
+		var line interface{} = response
+		if fields != nil {
+			line = selectFields(&response, fields)
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+		written++
+		if flusher != nil && written%flushEvery == 0 {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if written > 0 {
+			// The status line is already out; all we can do is stop.
+			log.Printf("Error streaming cupcakes: %v", err)
+			return
+		}
+		var unsupported *currency.UnsupportedError
+		if errors.As(err, &unsupported) {
+			sendLocalizedError(w, r, err, http.StatusBadRequest)
+			return
+		}
+		sendJSONError(w, "Error fetching cupcakes", http.StatusInternalServerError)
+		return
+	}
+
+	if written == 0 {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
 }
 
 func (h *CupcakeHandler) UpdateCupcake(w http.ResponseWriter, r *http.Request) {
@@ -89,19 +464,108 @@ func (h *CupcakeHandler) UpdateCupcake(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req models.UpdateCupcakeRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		sendJSONError(w, "Error decoding request", http.StatusBadRequest)
+	if !decodeRequest(w, r, &req) {
 		return
 	}
+	req.UpdatedBy = adminID(r)
 
 	cupcake, err := h.service.UpdateCupcake(uint(id), &req)
+	if errors.Is(err, repository.ErrNotFound) {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
 	if err != nil {
-		sendJSONError(w, err.Error(), http.StatusBadRequest)
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(cupcake)
+	json.NewEncoder(w).Encode(models.NewAdminCupcakeResponse(cupcake))
+}
+
+// PatchCupcake applies a JSON Patch document (RFC 6902) to a cupcake, for
+// tools that edit one field at a time.
+func (h *CupcakeHandler) PatchCupcake(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != mediaJSONPatch {
+		w.Header().Set("Accept-Patch", mediaJSONPatch)
+		sendJSONError(w, "Content-Type must be "+mediaJSONPatch, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	var ops []models.PatchOperation
+	if !decodeRequest(w, r, &ops) {
+		return
+	}
+
+	cupcake, err := h.service.PatchCupcake(uint(id), ops, adminID(r))
+	if errors.Is(err, repository.ErrNotFound) {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.NewAdminCupcakeResponse(cupcake))
+}
+
+func (h *CupcakeHandler) GetCupcakeVersions(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	versions, err := h.service.GetCupcakeVersions(uint(id))
+	if err != nil {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(versions)
+}
+
+func (h *CupcakeHandler) RevertCupcake(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil || version < 1 {
+		sendJSONError(w, "Invalid version", http.StatusBadRequest)
+		return
+	}
+
+	cupcake, err := h.service.RevertCupcake(uint(id), version, adminID(r))
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	case errors.Is(err, service.ErrVersionNotFound):
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	case err != nil:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.NewAdminCupcakeResponse(cupcake))
 }
 
 func (h *CupcakeHandler) DeleteCupcake(w http.ResponseWriter, r *http.Request) {
@@ -112,10 +576,91 @@ func (h *CupcakeHandler) DeleteCupcake(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.DeleteCupcake(uint(id)); err != nil {
-		sendJSONError(w, err.Error(), http.StatusBadRequest)
+	err = h.service.DeleteCupcake(uint(id))
+	if errors.Is(err, repository.ErrNotFound) {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// writeCupcake encodes a single cupcake, reduced to the selected fields when
+// any were requested and with its links for the v2 media type.
+func writeCupcake(w http.ResponseWriter, enc responseEncoder, cupcake models.Cupcake, fields []string) {
+	response := models.NewCupcakeResponse(&cupcake)
+	if fields != nil {
+		set := selectFields(&response, fields)
+		if isHypermedia(enc) {
+			set = append(set, field{name: "links", value: cupcakeLinks(response)})
+		}
+		writeResponse(w, enc, set)
+		return
+	}
+	if isHypermedia(enc) {
+		writeResponse(w, enc, newCupcakeResource(response))
+		return
+	}
+	writeResponse(w, enc, response)
+}
+
+// requestedCurrency prefers the currency query parameter over the
+// Accept-Currency header.
+func requestedCurrency(r *http.Request) string {
+	if code := r.URL.Query().Get("currency"); code != "" {
+		return code
+	}
+	return r.Header.Get("Accept-Currency")
+}
+
+// requestedLanguage prefers the lang query parameter over the
+// Accept-Language header.
+func requestedLanguage(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return lang
+	}
+	return r.Header.Get("Accept-Language")
+}
+
+// localize swaps in translated content for the requested language and
+// reports it in Content-Language. It writes the error response itself.
+func (h *CupcakeHandler) localize(w http.ResponseWriter, r *http.Request, cupcakes []models.Cupcake) bool {
+	w.Header().Add("Vary", "Accept-Language")
+	lang, err := h.service.Localize(cupcakes, requestedLanguage(r))
+	if err != nil {
+		sendJSONError(w, "Error fetching translations", http.StatusInternalServerError)
+		return false
+	}
+	if lang != "" {
+		w.Header().Set("Content-Language", lang)
+	}
+	return true
+}
+
+// applyExperiments adjusts prices for the visitor's experiment variants and
+// lists them in X-Experiments as key=variant pairs. It runs before currency
+// conversion, so adjustments apply to base-currency prices. It writes the
+// error response itself.
+func (h *CupcakeHandler) applyExperiments(w http.ResponseWriter, r *http.Request, cupcakes []models.Cupcake) bool {
+	if h.experiments == nil {
+		return true
+	}
+	w.Header().Add("Vary", visitorIDHeader)
+	assignments, err := h.experiments.ApplyPrices(cupcakes, visitorID(r))
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return false
+	}
+	if len(assignments) > 0 {
+		pairs := make([]string, len(assignments))
+		for i, assignment := range assignments {
+			pairs[i] = assignment.Experiment + "=" + assignment.Variant
+		}
+		w.Header().Set("X-Experiments", strings.Join(pairs, ", "))
+	}
+	return true
+}
@@ -9,24 +9,18 @@ import (
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/currency"
 	"github.com/julimonteiro/cupcake-store/internal/models"
 	"github.com/julimonteiro/cupcake-store/internal/repository"
 	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 func setupTestDB(t *testing.T) *gorm.DB {
 	t.Helper()
-
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
-
-	err = db.AutoMigrate(&models.Cupcake{})
-	require.NoError(t, err)
-
-	return db
+	return testutil.NewDB(t)
 }
 
 func newHandler(t *testing.T) *CupcakeHandler {
@@ -34,7 +28,7 @@ func newHandler(t *testing.T) *CupcakeHandler {
 
 	db := setupTestDB(t)
 	repo := repository.NewCupcakeRepository(db)
-	svc := service.NewCupcakeService(repo)
+	svc := service.NewCupcakeService(repo, repository.NewPromotionRepository(db), repository.NewLocationRepository(db), nil, nil, nil, nil)
 	return NewCupcakeHandler(svc)
 }
 
@@ -48,10 +42,20 @@ func newTestRouter(t *testing.T) chi.Router {
 		r.Route("/cupcakes", func(r chi.Router) {
 			r.Post("/", handler.CreateCupcake)
 			r.Get("/", handler.GetAllCupcakes)
+			r.Get("/by-sku/{sku}", handler.GetCupcakeBySKU)
+			r.Get("/slug/{slug}", handler.GetCupcakeBySlug)
 			r.Get("/{id}", handler.GetCupcake)
 			r.Put("/{id}", handler.UpdateCupcake)
+			r.Patch("/{id}", handler.PatchCupcake)
 			r.Delete("/{id}", handler.DeleteCupcake)
+			r.Get("/{id}/qr", handler.GetCupcakeQR)
+			r.Get("/{id}/og", handler.GetCupcakeOG)
+			r.Get("/{id}/og/image", handler.GetCupcakeOGImage)
+			r.Get("/{id}/related", handler.GetRelatedCupcakes)
+			r.Get("/{id}/versions", handler.GetCupcakeVersions)
+			r.Post("/{id}/revert/{version}", handler.RevertCupcake)
 		})
+		r.Post("/admin/cupcakes/price-update", handler.BulkUpdatePrices)
 	})
 
 	return r
@@ -88,7 +92,7 @@ func TestCreateCupcake(t *testing.T) {
 				"flavor":      "X",
 				"price_cents": 1,
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "name must have at least 2 characters",
 		},
 		{
@@ -98,7 +102,7 @@ func TestCreateCupcake(t *testing.T) {
 				"flavor":      "",
 				"price_cents": 1000,
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "flavor is required",
 		},
 		{
@@ -108,7 +112,7 @@ func TestCreateCupcake(t *testing.T) {
 				"flavor":      "Valid Flavor",
 				"price_cents": 0,
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "price must be greater than zero",
 		},
 		{
@@ -118,7 +122,7 @@ func TestCreateCupcake(t *testing.T) {
 				"flavor":      "Valid Flavor",
 				"price_cents": -100,
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "price must be greater than zero",
 		},
 		{
@@ -128,7 +132,7 @@ func TestCreateCupcake(t *testing.T) {
 				"flavor":      "Valid Flavor",
 				"price_cents": 1000,
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "name is required",
 		},
 		{
@@ -136,13 +140,13 @@ func TestCreateCupcake(t *testing.T) {
 			payload: map[string]interface{}{
 				"name": "Valid Name",
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "flavor is required",
 		},
 		{
 			name:           "invalid payload - empty object",
 			payload:        map[string]interface{}{},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "name is required",
 		},
 	}
@@ -177,6 +181,61 @@ func TestCreateCupcake(t *testing.T) {
 	}
 }
 
+func TestCreateCupcake_LocalizedError(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		acceptLanguage string
+		expectedError  string
+	}{
+		{name: "portuguese", url: "/api/v1/cupcakes", acceptLanguage: "pt-BR,pt;q=0.9", expectedError: "o nome é obrigatório"},
+		{name: "base language", url: "/api/v1/cupcakes", acceptLanguage: "pt", expectedError: "o nome é obrigatório"},
+		{name: "lang parameter wins", url: "/api/v1/cupcakes?lang=en", acceptLanguage: "pt-BR", expectedError: "name is required"},
+		{name: "unknown language falls back to english", url: "/api/v1/cupcakes", acceptLanguage: "fr", expectedError: "name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			req := httptest.NewRequest("POST", tt.url, bytes.NewBufferString(`{"flavor":"Chocolate","price_cents":1000}`))
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			require.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+
+			var response models.ValidationErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Len(t, response.Errors, 1)
+			require.Equal(t, tt.expectedError, response.Errors[0].Message)
+		})
+	}
+}
+
+func TestCreateCupcake_ValidationErrors(t *testing.T) {
+	router := newTestRouter(t)
+
+	body := `{"name":"A","flavor":" ","price_cents":0,"sku":"not a sku!"}`
+	req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var response models.ValidationErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, "validation failed", response.Error)
+	require.Equal(t, []models.FieldErrorResponse{
+		{Field: "name", Rule: "min_length", Message: "name must have at least 2 characters"},
+		{Field: "flavor", Rule: "required", Message: "flavor is required"},
+		{Field: "price_cents", Rule: "positive", Message: "price must be greater than zero"},
+		{Field: "sku", Rule: "format", Message: "sku must have 3 to 64 letters, digits or dashes"},
+	}, response.Errors)
+}
+
 func TestCreateCupcake_InvalidJSON(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -396,6 +455,170 @@ func TestGetCupcake(t *testing.T) {
 	}
 }
 
+func TestGetCupcakeBySKU(t *testing.T) {
+	tests := []struct {
+		name           string
+		sku            string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "existing sku returns 200",
+			sku:            "CC-RED-01",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "lookup is case insensitive",
+			sku:            "cc-red-01",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown sku returns 404",
+			sku:            "CC-NONE-01",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "cupcake not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			body := `{"name":"Red Velvet","flavor":"Cocoa","price_cents":1200,"sku":"CC-RED-01"}`
+			req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			req = httptest.NewRequest("GET", "/api/v1/cupcakes/by-sku/"+tt.sku, nil)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var response models.Cupcake
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Equal(t, "Red Velvet", response.Name)
+			require.NotNil(t, response.SKU)
+			require.Equal(t, "CC-RED-01", *response.SKU)
+		})
+	}
+}
+
+func TestGetCupcakeBySlug(t *testing.T) {
+	router := newTestRouter(t)
+
+	create := func(body string) models.Cupcake {
+		req := httptest.NewRequest("POST", "/api/v1/cupcakes?force=true", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var cupcake models.Cupcake
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+		return cupcake
+	}
+
+	first := create(`{"name":"Pão de Mel","flavor":"Honey","price_cents":900,"description":"<p>Soft <b>honey</b> cake</p><script>alert(1)</script>"}`)
+	require.NotNil(t, first.Slug)
+	require.Equal(t, "pao-de-mel", *first.Slug)
+	require.Equal(t, "<p>Soft <b>honey</b> cake</p>", first.Description)
+
+	second := create(`{"name":"Pao de mel","flavor":"Honey","price_cents":900}`)
+	require.Equal(t, "pao-de-mel-2", *second.Slug)
+
+	req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(`{"name":"Carrot","flavor":"Carrot","price_cents":900,"slug":"pao-de-mel"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), "slug already exists")
+
+	req = httptest.NewRequest("GET", "/api/v1/cupcakes/slug/PAO-DE-MEL-2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response models.Cupcake
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, second.ID, response.ID)
+
+	req = httptest.NewRequest("GET", "/api/v1/cupcakes/slug/unknown", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestBulkUpdatePrices(t *testing.T) {
+	tests := []struct {
+		name             string
+		payload          string
+		expectedStatus   int
+		expectedError    string
+		expectedAffected int
+	}{
+		{
+			name:             "percentage update returns changes",
+			payload:          `{"adjustment_type":"percentage","value":10}`,
+			expectedStatus:   http.StatusOK,
+			expectedAffected: 1,
+		},
+		{
+			name:             "flavor filter with no matches",
+			payload:          `{"flavor":"Mint","adjustment_type":"absolute","value":100,"dry_run":true}`,
+			expectedStatus:   http.StatusOK,
+			expectedAffected: 0,
+		},
+		{
+			name:           "validation error returns 400",
+			payload:        `{"adjustment_type":"absolute","value":0}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "absolute adjustment must be non-zero",
+		},
+		{
+			name:           "invalid JSON returns 400",
+			payload:        `{"adjustment_type":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Error decoding request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			body := `{"name":"Red Velvet","flavor":"Cocoa","price_cents":1200}`
+			req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			req = httptest.NewRequest("POST", "/api/v1/admin/cupcakes/price-update", bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var response models.BulkPriceUpdateResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Equal(t, tt.expectedAffected, response.Affected)
+		})
+	}
+}
+
 func TestUpdateCupcake(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -447,11 +670,11 @@ func TestUpdateCupcake(t *testing.T) {
 			},
 		},
 		{
-			name:           "non-existent ID returns 400",
+			name:           "non-existent ID returns 404",
 			cupcakeID:      "9999",
 			updatePayload:  map[string]interface{}{"name": "Updated"},
-			expectedStatus: http.StatusBadRequest,
-			expectedError:  "record not found",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "cupcake not found",
 		},
 		{
 			name:           "invalid ID format returns 400",
@@ -461,7 +684,7 @@ func TestUpdateCupcake(t *testing.T) {
 			expectedError:  "Invalid ID",
 		},
 		{
-			name:      "invalid update data returns 400",
+			name:      "invalid update data returns 422",
 			cupcakeID: "1",
 			setupCupcake: map[string]interface{}{
 				"name":        "Original Name",
@@ -471,7 +694,7 @@ func TestUpdateCupcake(t *testing.T) {
 			updatePayload: map[string]interface{}{
 				"name": "A",
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "name must have at least 2 characters",
 		},
 	}
@@ -554,6 +777,95 @@ func TestUpdateCupcake_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestPatchCupcake(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		contentType    string
+		payload        string
+		acceptLanguage string
+		expectedStatus int
+		expectedError  string
+		expectedPrice  int
+	}{
+		{
+			name:           "replace price",
+			contentType:    "application/json-patch+json",
+			payload:        `[{"op":"replace","path":"/price_cents","value":1750}]`,
+			expectedStatus: http.StatusOK,
+			expectedPrice:  1750,
+		},
+		{
+			name:           "plain JSON is refused",
+			contentType:    "application/json",
+			payload:        `{"price_cents":1750}`,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedError:  "Content-Type must be application/json-patch+json",
+		},
+		{
+			name:           "patch document must be an array",
+			contentType:    "application/json-patch+json",
+			payload:        `{"op":"replace","path":"/price_cents","value":1750}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "validation errors are localized",
+			contentType:    "application/json-patch+json",
+			payload:        `[{"op":"remove","path":"/flavor"}]`,
+			acceptLanguage: "pt-BR",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "/flavor não pode ser removido",
+		},
+		{
+			name:           "non-existent ID returns 404",
+			id:             "9999",
+			contentType:    "application/json-patch+json",
+			payload:        `[{"op":"replace","path":"/price_cents","value":1750}]`,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "cupcake not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(`{"name":"Lemon","flavor":"Lemon","price_cents":1000}`))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			id := tt.id
+			if id == "" {
+				id = "1"
+			}
+			req = httptest.NewRequest("PATCH", "/api/v1/cupcakes/"+id, bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedError != "" {
+				var response map[string]string
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, tt.expectedError, response["error"])
+			}
+			if tt.expectedStatus == http.StatusUnsupportedMediaType {
+				require.Equal(t, "application/json-patch+json", w.Header().Get("Accept-Patch"))
+			}
+			if tt.expectedPrice != 0 {
+				var cupcake models.Cupcake
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+				require.Equal(t, tt.expectedPrice, cupcake.PriceCents)
+				require.Equal(t, "Lemon", cupcake.Name)
+			}
+		})
+	}
+}
+
 func TestDeleteCupcake(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -573,10 +885,10 @@ func TestDeleteCupcake(t *testing.T) {
 			expectedStatus: http.StatusNoContent,
 		},
 		{
-			name:           "non-existent ID returns 400",
+			name:           "non-existent ID returns 404",
 			cupcakeID:      "9999",
-			expectedStatus: http.StatusBadRequest,
-			expectedError:  "record not found",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "cupcake not found",
 		},
 		{
 			name:           "invalid ID format returns 400",
@@ -657,3 +969,360 @@ func TestHealthCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCupcake_Currency(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		header           string
+		expectedStatus   int
+		expectedCurrency string
+		expectedPrice    int
+		expectedError    string
+	}{
+		{
+			name:             "base currency by default",
+			expectedStatus:   http.StatusOK,
+			expectedCurrency: "BRL",
+			expectedPrice:    1500,
+		},
+		{
+			name:             "currency query parameter",
+			query:            "?currency=usd",
+			expectedStatus:   http.StatusOK,
+			expectedCurrency: "USD",
+			expectedPrice:    300,
+		},
+		{
+			name:             "Accept-Currency header",
+			header:           "EUR",
+			expectedStatus:   http.StatusOK,
+			expectedCurrency: "EUR",
+			expectedPrice:    270,
+		},
+		{
+			name:             "query parameter wins over header",
+			query:            "?currency=USD",
+			header:           "EUR",
+			expectedStatus:   http.StatusOK,
+			expectedCurrency: "USD",
+			expectedPrice:    300,
+		},
+		{
+			name:           "unsupported currency returns 400",
+			query:          "?currency=JPY",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "unsupported currency: JPY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			rates, err := currency.ParseStaticRates("BRL", "USD=0.2,EUR=0.18")
+			require.NoError(t, err)
+			svc := service.NewCupcakeService(repository.NewCupcakeRepository(db), repository.NewPromotionRepository(db), nil, nil, currency.NewConverter("BRL", rates), nil, nil)
+			handler := NewCupcakeHandler(svc)
+
+			_, err = svc.CreateCupcake(&models.CreateCupcakeRequest{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1500})
+			require.NoError(t, err)
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/cupcakes/{id}", handler.GetCupcake)
+
+			req := httptest.NewRequest("GET", "/api/v1/cupcakes/1"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("Accept-Currency", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var response models.Cupcake
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Equal(t, tt.expectedCurrency, response.Currency)
+			require.Equal(t, tt.expectedPrice, response.PriceCents)
+		})
+	}
+}
+
+func TestListCupcakes_NDJSON(t *testing.T) {
+	tests := []struct {
+		name           string
+		count          int
+		query          string
+		expectedStatus int
+		expectedLines  int
+		expectedError  string
+	}{
+		{name: "empty catalog", count: 0, query: "?format=ndjson", expectedStatus: http.StatusOK, expectedLines: 0},
+		{name: "one line per cupcake", count: 250, query: "?format=ndjson", expectedStatus: http.StatusOK, expectedLines: 250},
+		{name: "unsupported currency", count: 1, query: "?format=ndjson&currency=JPY", expectedStatus: http.StatusBadRequest, expectedError: "unsupported currency: JPY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			for i := 0; i < tt.count; i++ {
+				body := fmt.Sprintf(`{"name":"Cupcake %d","flavor":"Vanilla","price_cents":%d}`, i+1, 100+i)
+				req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(body))
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				require.Equal(t, http.StatusCreated, w.Code)
+			}
+
+			req := httptest.NewRequest("GET", "/api/v1/cupcakes"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+			require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+			dec := json.NewDecoder(w.Body)
+			lines := 0
+			for dec.More() {
+				var cupcake models.Cupcake
+				require.NoError(t, dec.Decode(&cupcake))
+				lines++
+				require.Equal(t, uint(lines), cupcake.ID)
+			}
+			require.Equal(t, tt.expectedLines, lines)
+		})
+	}
+}
+
+func TestCupcakeVersions(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedError  string
+		validateBody   func(t *testing.T, body []byte)
+	}{
+		{
+			name:           "lists versions newest first",
+			method:         "GET",
+			path:           "/api/v1/cupcakes/1/versions",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var versions []models.CupcakeVersion
+				require.NoError(t, json.Unmarshal(body, &versions))
+				require.Len(t, versions, 2)
+				require.Equal(t, 2, versions[0].Version)
+				require.Equal(t, 1800, versions[0].PriceCents)
+			},
+		},
+		{
+			name:           "versions of unknown cupcake",
+			method:         "GET",
+			path:           "/api/v1/cupcakes/99/versions",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "cupcake not found",
+		},
+		{
+			name:           "revert restores the snapshot",
+			method:         "POST",
+			path:           "/api/v1/cupcakes/1/revert/1",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var cupcake models.Cupcake
+				require.NoError(t, json.Unmarshal(body, &cupcake))
+				require.Equal(t, 1500, cupcake.PriceCents)
+			},
+		},
+		{
+			name:           "revert to unknown version",
+			method:         "POST",
+			path:           "/api/v1/cupcakes/1/revert/7",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "version not found",
+		},
+		{
+			name:           "revert with invalid version",
+			method:         "POST",
+			path:           "/api/v1/cupcakes/1/revert/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(`{"name":"Chocolate","flavor":"Cocoa","price_cents":1500}`))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			req = httptest.NewRequest("PUT", "/api/v1/cupcakes/1", bytes.NewBufferString(`{"price_cents":1800}`))
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			req = httptest.NewRequest(tt.method, tt.path, nil)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestCupcakeAuthorship(t *testing.T) {
+	router := newTestRouter(t)
+	as := func(req *http.Request, admin *models.Admin) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req.WithContext(WithAdmin(req.Context(), admin)))
+		return w
+	}
+	var cupcake models.AdminCupcakeResponse
+
+	w := as(httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(`{"name":"Chocolate","flavor":"Cocoa","price_cents":1500}`)), &models.Admin{ID: 3})
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+	require.Equal(t, uint(3), *cupcake.CreatedBy)
+	require.Equal(t, uint(3), *cupcake.UpdatedBy)
+
+	w = as(httptest.NewRequest("PUT", "/api/v1/cupcakes/1", bytes.NewBufferString(`{"price_cents":1800}`)), &models.Admin{ID: 5})
+	require.Equal(t, http.StatusOK, w.Code)
+	cupcake = models.AdminCupcakeResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+	require.Equal(t, uint(3), *cupcake.CreatedBy)
+	require.Equal(t, uint(5), *cupcake.UpdatedBy)
+
+	req := httptest.NewRequest("PATCH", "/api/v1/cupcakes/1", bytes.NewBufferString(`[{"op":"replace","path":"/price_cents","value":1900}]`))
+	req.Header.Set("Content-Type", mediaJSONPatch)
+	w = as(req, &models.Admin{ID: 7})
+	require.Equal(t, http.StatusOK, w.Code)
+	cupcake = models.AdminCupcakeResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+	require.Equal(t, uint(7), *cupcake.UpdatedBy)
+
+	// A change made with the static admin token is made by no account.
+	w = as(httptest.NewRequest("POST", "/api/v1/cupcakes/1/revert/1", nil), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	cupcake = models.AdminCupcakeResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+	require.Equal(t, uint(3), *cupcake.CreatedBy)
+	require.Nil(t, cupcake.UpdatedBy)
+
+	w = as(httptest.NewRequest("POST", "/api/v1/admin/cupcakes/price-update", bytes.NewBufferString(`{"adjustment_type":"absolute","value":100}`)), &models.Admin{ID: 5})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// The public API does not say who edited a cupcake.
+	w = as(httptest.NewRequest("GET", "/api/v1/cupcakes/1", nil), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "updated_by")
+	require.NotContains(t, w.Body.String(), "created_by")
+}
+
+func TestGetRelatedCupcakes(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedError  string
+		expectedNames  []string
+	}{
+		{name: "related by flavor", path: "/api/v1/cupcakes/1/related", expectedStatus: http.StatusOK, expectedNames: []string{"Vanilla Bean", "Vanilla Berry"}},
+		{name: "with limit", path: "/api/v1/cupcakes/1/related?limit=1", expectedStatus: http.StatusOK, expectedNames: []string{"Vanilla Bean"}},
+		{name: "nothing related", path: "/api/v1/cupcakes/3/related", expectedStatus: http.StatusOK, expectedNames: []string{}},
+		{name: "unknown cupcake", path: "/api/v1/cupcakes/99/related", expectedStatus: http.StatusNotFound, expectedError: "cupcake not found"},
+		{name: "invalid ID", path: "/api/v1/cupcakes/abc/related", expectedStatus: http.StatusBadRequest, expectedError: "Invalid ID"},
+		{name: "invalid limit", path: "/api/v1/cupcakes/1/related?limit=abc", expectedStatus: http.StatusBadRequest, expectedError: "Invalid limit"},
+		{name: "limit out of range", path: "/api/v1/cupcakes/1/related?limit=50", expectedStatus: http.StatusBadRequest, expectedError: "limit must be between 1 and 20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+			for _, body := range []string{
+				`{"name":"Vanilla","flavor":"Vanilla","price_cents":1000}`,
+				`{"name":"Vanilla Bean","flavor":"Vanilla","price_cents":1200}`,
+				`{"name":"Chocolate","flavor":"Cocoa","price_cents":1000}`,
+				`{"name":"Vanilla Berry","flavor":"Vanilla","price_cents":1300}`,
+			} {
+				req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(body))
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				require.Equal(t, http.StatusCreated, w.Code)
+			}
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var related []models.CupcakeResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &related))
+			names := []string{}
+			for _, cupcake := range related {
+				names = append(names, cupcake.Name)
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestCreateCupcake_Duplicate(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "likely duplicate", expectedStatus: http.StatusConflict, expectedError: "retry with ?force=true"},
+		{name: "forced", query: "?force=true", expectedStatus: http.StatusCreated},
+		{name: "invalid force", query: "?force=maybe", expectedStatus: http.StatusBadRequest, expectedError: "Invalid force"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(`{"name":"Red Velvet","flavor":"Cocoa","price_cents":1500}`))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			req = httptest.NewRequest("POST", "/api/v1/cupcakes"+tt.query, bytes.NewBufferString(`{"name":"Red Velvett","flavor":"Cocoa","price_cents":1500}`))
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			if tt.expectedStatus == http.StatusConflict {
+				var resp models.DuplicateCupcakeResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Len(t, resp.Duplicates, 1)
+				require.Equal(t, "Red Velvet", resp.Duplicates[0].Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type CustomCupcakeHandler struct {
+	service service.CustomCupcakeServiceInterface
+}
+
+func NewCustomCupcakeHandler(service service.CustomCupcakeServiceInterface) *CustomCupcakeHandler {
+	return &CustomCupcakeHandler{service: service}
+}
+
+func (h *CustomCupcakeHandler) CreateOption(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateCustomOptionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	option, err := h.service.CreateOption(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(option)
+}
+
+func (h *CustomCupcakeHandler) GetAllOptions(w http.ResponseWriter, r *http.Request) {
+	options, err := h.service.GetAllOptions()
+	if err != nil {
+		sendJSONError(w, "Error fetching custom options", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(options)
+}
+
+func (h *CustomCupcakeHandler) GetAvailableOptions(w http.ResponseWriter, r *http.Request) {
+	options, err := h.service.GetAvailableOptions()
+	if err != nil {
+		sendJSONError(w, "Error fetching custom options", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(options)
+}
+
+func (h *CustomCupcakeHandler) UpdateOption(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateCustomOptionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	option, err := h.service.UpdateOption(uint(id), &req)
+	if err != nil {
+		sendCustomOptionError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(option)
+}
+
+func (h *CustomCupcakeHandler) DeleteOption(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteOption(uint(id)); err != nil {
+		sendCustomOptionError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Quote prices a custom cupcake server-side so the storefront never has to
+// trust a client-computed total.
+func (h *CustomCupcakeHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	var req models.CustomCupcakeQuoteRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	quote, err := h.service.Quote(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quote)
+}
+
+func sendCustomOptionError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrCustomOptionNotFound) {
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
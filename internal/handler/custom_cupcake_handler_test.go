@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+// newCustomCupcakeTestRouter serves the builder routes over a menu with a
+// Vanilla base (800), a Cream cheese frosting (200) and a Sprinkles topping
+// (50).
+func newCustomCupcakeTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	svc := service.NewCustomCupcakeService(repository.NewCustomOptionRepository(setupTestDB(t)))
+	for _, req := range []models.CreateCustomOptionRequest{
+		{Kind: models.CustomOptionBase, Name: "Vanilla", PriceCents: 800},
+		{Kind: models.CustomOptionFrosting, Name: "Cream cheese", PriceCents: 200},
+		{Kind: models.CustomOptionTopping, Name: "Sprinkles", PriceCents: 50},
+	} {
+		_, err := svc.CreateOption(&req)
+		require.NoError(t, err)
+	}
+
+	customCupcakeHandler := NewCustomCupcakeHandler(svc)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/custom-cupcakes/options", customCupcakeHandler.GetAvailableOptions)
+	r.Post("/api/v1/custom-cupcakes/quote", customCupcakeHandler.Quote)
+	r.Post("/api/v1/admin/custom-options", customCupcakeHandler.CreateOption)
+	r.Put("/api/v1/admin/custom-options/{id}", customCupcakeHandler.UpdateOption)
+	return r
+}
+
+func TestQuoteCustomCupcake(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		expectedStatus int
+		expectedPrice  int
+		expectedError  string
+	}{
+		{
+			name:           "success",
+			payload:        `{"base_id":1,"frosting_id":2,"topping_ids":[3]}`,
+			expectedStatus: http.StatusOK,
+			expectedPrice:  1050,
+		},
+		{
+			name:           "invalid combination",
+			payload:        `{"base_id":1,"frosting_id":3}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Sprinkles is not a frosting",
+		},
+		{
+			name:           "client price is ignored",
+			payload:        `{"base_id":1,"frosting_id":2,"price_cents":1}`,
+			expectedStatus: http.StatusOK,
+			expectedPrice:  1000,
+		},
+		{
+			name:           "malformed body",
+			payload:        `{"base_id":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Error decoding request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newCustomCupcakeTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/custom-cupcakes/quote", bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var quote models.CustomCupcakeQuote
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&quote))
+			require.Equal(t, tt.expectedPrice, quote.PriceCents)
+			require.Equal(t, "Vanilla", quote.Base.Name)
+		})
+	}
+}
+
+func TestGetAvailableCustomOptions(t *testing.T) {
+	router := newCustomCupcakeTestRouter(t)
+
+	req := httptest.NewRequest("PUT", "/api/v1/admin/custom-options/3", bytes.NewBufferString(`{"is_available":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/custom-cupcakes/options", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var options []models.CustomOption
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&options))
+	require.Len(t, options, 2, "unavailable options are hidden from customers")
+}
+
+func TestUpdateCustomOption_NotFound(t *testing.T) {
+	router := newCustomCupcakeTestRouter(t)
+
+	req := httptest.NewRequest("PUT", "/api/v1/admin/custom-options/999", bytes.NewBufferString(`{"price_cents":10}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "custom option not found")
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type DataExportHandler struct {
+	service service.DataExportServiceInterface
+}
+
+func NewDataExportHandler(service service.DataExportServiceInterface) *DataExportHandler {
+	return &DataExportHandler{service: service}
+}
+
+// RequestDataExport queues a copy of the customer's data for ?email=. The
+// answer only tells the export's status; the download link is sent to the
+// customer by email once the archive is ready.
+func (h *DataExportHandler) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	export, err := h.service.Request(r.URL.Query().Get("email"))
+	if errors.Is(err, service.ErrDataExportUnavailable) {
+		sendJSONError(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(export)
+}
+
+// DownloadDataExport serves the archive behind a signed link.
+func (h *DataExportHandler) DownloadDataExport(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil {
+		sendJSONError(w, "Invalid expires", http.StatusBadRequest)
+		return
+	}
+
+	export, err := h.service.Download(uint(id), expires, r.URL.Query().Get("signature"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDataExportLinkInvalid):
+			sendJSONError(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, service.ErrDataExportExpired):
+			sendJSONError(w, err.Error(), http.StatusGone)
+		case errors.Is(err, service.ErrDataExportNotFound):
+			sendJSONError(w, err.Error(), http.StatusNotFound)
+		default:
+			sendJSONError(w, "Error fetching data export", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cupcake-store-data-%d.zip"`, export.ID))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Write(export.Archive)
+}
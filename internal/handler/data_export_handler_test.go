@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExport(t *testing.T) {
+	db := setupTestDB(t)
+	jobs := service.NewJobService(repository.NewJobRepository(db))
+	repo := repository.NewDataExportRepository(db)
+	svc := service.NewDataExportService(repo, jobs, "test-secret")
+	handler := NewDataExportHandler(svc)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/me/data-export", handler.RequestDataExport)
+	r.Get("/api/v1/data-exports/{id}/download", handler.DownloadDataExport)
+
+	// The link has no way to the customer without an email sender.
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/me/data-export?email=ana@example.com", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	svc.WithEmail(&mocks.EmailSender{SendFunc: func(context.Context, string, *models.RenderedEmail) error { return nil }}, "https://loja.example.com")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/me/data-export", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "email is required")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/me/data-export?email=ana@example.com", nil))
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"pending"`)
+	require.NotContains(t, w.Body.String(), "download")
+
+	for {
+		found, err := jobs.RunNext()
+		require.NoError(t, err)
+		if !found {
+			break
+		}
+	}
+	export, err := repo.FindByID(1)
+	require.NoError(t, err)
+	link := svc.DownloadPath(export)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", link, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="cupcake-store-data-1.zip"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, export.Archive, w.Body.Bytes())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", strings.Replace(link, "signature=", "signature=0", 1), nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/data-exports/1/download?signature=x", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// DeviceHandler serves the push devices of the account of the bearer
+// token.
+type DeviceHandler struct {
+	service  service.DeviceServiceInterface
+	accounts service.AccountServiceInterface
+}
+
+func NewDeviceHandler(service service.DeviceServiceInterface, accounts service.AccountServiceInterface) *DeviceHandler {
+	return &DeviceHandler{service: service, accounts: accounts}
+}
+
+func (h *DeviceHandler) GetDevices(w http.ResponseWriter, r *http.Request) {
+	account, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	devices, err := h.service.GetDevices(account.ID)
+	if err != nil {
+		sendJSONError(w, "Error fetching devices", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(devices)
+}
+
+func (h *DeviceHandler) RegisterDevice(w http.ResponseWriter, r *http.Request) {
+	account, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	var req models.RegisterDeviceRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	device, err := h.service.RegisterDevice(account.ID, &req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(device)
+}
+
+func (h *DeviceHandler) UnregisterDevice(w http.ResponseWriter, r *http.Request) {
+	account, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.UnregisterDevice(account.ID, chi.URLParam(r, "token")); err != nil {
+		if errors.Is(err, service.ErrDeviceNotFound) {
+			sendJSONError(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		sendJSONError(w, "Error removing device", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *DeviceHandler) authenticate(w http.ResponseWriter, r *http.Request) (*models.Account, bool) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return nil, false
+	}
+	account, err := h.accounts.Authenticate(token)
+	if err != nil {
+		sendAuthError(w, r, err)
+		return nil, false
+	}
+	return account, true
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevices(t *testing.T) {
+	accounts := &mocks.AccountService{AuthenticateFunc: func(token string) (*models.Account, error) {
+		switch token {
+		case "ana-token":
+			return &models.Account{ID: 1, Email: "ana@example.com"}, nil
+		case "bruno-token":
+			return &models.Account{ID: 2, Email: "bruno@example.com"}, nil
+		}
+		return nil, service.ErrAccessTokenInvalid
+	}}
+	handler := NewDeviceHandler(service.NewDeviceService(repository.NewDeviceTokenRepository(setupTestDB(t))), accounts)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/me/devices", handler.GetDevices)
+	r.Post("/api/v1/me/devices", handler.RegisterDevice)
+	r.Delete("/api/v1/me/devices/{token}", handler.UnregisterDevice)
+	serve := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusUnauthorized, serve("POST", "/api/v1/me/devices", `{"token":"fcm-1","platform":"android"}`, "").Code)
+
+	w := serve("POST", "/api/v1/me/devices", `{"token":"fcm-1","platform":"symbian"}`, "ana-token")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "platform must be android, ios or web")
+
+	w = serve("POST", "/api/v1/me/devices", `{"token":"fcm-1","platform":"android"}`, "ana-token")
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serve("GET", "/api/v1/me/devices", "", "ana-token")
+	require.Equal(t, http.StatusOK, w.Code)
+	var devices []models.DeviceToken
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&devices))
+	require.Len(t, devices, 1)
+	require.Equal(t, "fcm-1", devices[0].Token)
+
+	// Only the account the token is registered to can remove it.
+	require.Equal(t, http.StatusNotFound, serve("DELETE", "/api/v1/me/devices/fcm-1", "", "bruno-token").Code)
+	require.Equal(t, http.StatusNoContent, serve("DELETE", "/api/v1/me/devices/fcm-1", "", "ana-token").Code)
+	require.Equal(t, http.StatusNotFound, serve("DELETE", "/api/v1/me/devices/fcm-1", "", "ana-token").Code)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTemplateHandler(t *testing.T) {
+	db := setupTestDB(t)
+	handler := NewEmailTemplateHandler(service.NewEmailTemplateService(repository.NewEmailTemplateRepository(db), repository.NewLocationRepository(db)))
+	router := chi.NewRouter()
+	router.Route("/api/v1/admin/email-templates", func(r chi.Router) {
+		r.Get("/", handler.GetTemplates)
+		r.Get("/{name}", handler.GetTemplate)
+		r.Put("/{name}", handler.SetTemplate)
+		r.Delete("/{name}", handler.ResetTemplate)
+		r.Post("/{name}/preview", handler.PreviewTemplate)
+	})
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("GET", "/api/v1/admin/email-templates", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var templates []models.EmailTemplateResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&templates))
+	require.Len(t, templates, 3)
+
+	w = serve("GET", "/api/v1/admin/email-templates?location_id=abc", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve("GET", "/api/v1/admin/email-templates/order_confirmation?location_id=999", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serve("GET", "/api/v1/admin/email-templates/welcome", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve("PUT", "/api/v1/admin/email-templates/order_confirmation", `{"subject":"Pedido #{{.OrderID}}","body":"<p>{{.Missing}}</p>"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "template is invalid")
+	w = serve("PUT", "/api/v1/admin/email-templates/order_confirmation", `{"subject":"Pedido #{{.OrderID}}","body":"<p>Oi, {{.CustomerName}}</p>"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = serve("POST", "/api/v1/admin/email-templates/order_confirmation/preview", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var email models.RenderedEmail
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&email))
+	require.Equal(t, models.RenderedEmail{Subject: "Pedido #1042", HTML: "<p>Oi, Ana Lima</p>"}, email)
+
+	w = serve("POST", "/api/v1/admin/email-templates/order_confirmation/preview?format=html", `{"body":"<h1>{{.Store.Name}}</h1>"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, "sandbox", w.Header().Get("Content-Security-Policy"))
+	require.Equal(t, "<h1>Cupcake Store</h1>", w.Body.String())
+
+	w = serve("DELETE", "/api/v1/admin/email-templates/order_confirmation", "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = serve("GET", "/api/v1/admin/email-templates/order_confirmation", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"source":"default"`)
+}
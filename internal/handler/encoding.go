@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+const (
+	mediaJSON = "application/json"
+	mediaXML  = "application/xml"
+	mediaCSV  = "text/csv"
+
+	// mediaJSONPatch is the only body PatchCupcake accepts.
+	mediaJSONPatch = "application/json-patch+json"
+
+	// mediaHypermedia selects the v2 JSON representation: collections are
+	// wrapped in a data/meta/links envelope and resources carry links.
+	mediaHypermedia = "application/vnd.cupcake-store.v2+json"
+)
+
+// responseEncoder writes a response body in a single media type.
+type responseEncoder interface {
+	ContentType() string
+	Encode(w io.Writer, v interface{}) error
+}
+
+var encoders = map[string]responseEncoder{
+	mediaJSON:       jsonEncoder{contentType: mediaJSON},
+	mediaHypermedia: jsonEncoder{contentType: mediaHypermedia},
+	mediaXML:        xmlEncoder{},
+	mediaCSV:        csvEncoder{},
+}
+
+// Media types offered by the read endpoints, in order of preference. CSV is
+// only meaningful for collections.
+var (
+	itemMediaTypes = []string{mediaJSON, mediaHypermedia, mediaXML}
+	listMediaTypes = []string{mediaJSON, mediaHypermedia, mediaXML, mediaCSV}
+)
+
+type jsonEncoder struct {
+	contentType string
+}
+
+func (e jsonEncoder) ContentType() string { return e.contentType }
+
+func (jsonEncoder) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+type xmlEncoder struct{}
+
+func (xmlEncoder) ContentType() string { return mediaXML }
+
+func (xmlEncoder) Encode(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(v)
+}
+
+// csvMarshaler is implemented by collections that can be rendered as CSV.
+// The first record is the header.
+type csvMarshaler interface {
+	MarshalCSV() [][]string
+}
+
+type csvEncoder struct{}
+
+func (csvEncoder) ContentType() string { return mediaCSV }
+
+func (csvEncoder) Encode(w io.Writer, v interface{}) error {
+	m, ok := v.(csvMarshaler)
+	if !ok {
+		return fmt.Errorf("csv encoding not supported for %T", v)
+	}
+	return csv.NewWriter(w).WriteAll(m.MarshalCSV())
+}
+
+// negotiate picks the encoder for the best match between the Accept header
+// and the offered media types. An empty header selects the first offer.
+func negotiate(accept string, offers []string) (responseEncoder, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return encoders[offers[0]], true
+	}
+
+	type mediaRange struct {
+		value string
+		q     float64
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if qs, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(qs, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			ranges = append(ranges, mediaRange{value: mediaType, q: q})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	for _, mr := range ranges {
+		for _, offer := range offers {
+			if mediaMatches(mr.value, offer) {
+				return encoders[offer], true
+			}
+		}
+	}
+	return nil, false
+}
+
+func mediaMatches(mediaRange, offer string) bool {
+	if mediaRange == "*/*" || mediaRange == offer {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(offer, prefix+"/")
+	}
+	// text/xml is a common alias for application/xml.
+	return mediaRange == "text/xml" && offer == mediaXML
+}
+
+// negotiateResponse picks the encoder for the request's Accept header, or
+// replies 406 when none of the offers is acceptable.
+func negotiateResponse(w http.ResponseWriter, r *http.Request, offers []string) (responseEncoder, bool) {
+	w.Header().Add("Vary", "Accept")
+
+	enc, ok := negotiate(r.Header.Get("Accept"), offers)
+	if !ok {
+		sendJSONError(w, "not acceptable, supported types: "+strings.Join(offers, ", "), http.StatusNotAcceptable)
+	}
+	return enc, ok
+}
+
+func writeResponse(w http.ResponseWriter, enc responseEncoder, v interface{}) {
+	w.Header().Set("Content-Type", enc.ContentType())
+	enc.Encode(w, v)
+}
+
+func isHypermedia(enc responseEncoder) bool {
+	return enc.ContentType() == mediaHypermedia
+}
+
+// cupcakeList renders as a JSON array, a <cupcakes> XML document or CSV.
+type cupcakeList []models.CupcakeResponse
+
+func (l cupcakeList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "cupcakes"
+	return e.EncodeElement(struct {
+		Cupcakes []models.CupcakeResponse `xml:"cupcake"`
+	}{l}, start)
+}
+
+func (l cupcakeList) MarshalCSV() [][]string {
+	records := [][]string{{
+		"id", "name", "flavor", "sku", "price_cents", "effective_price_cents",
+		"currency", "is_available", "created_at", "updated_at",
+	}}
+	for _, c := range l {
+		var sku, effective string
+		if c.SKU != nil {
+			sku = *c.SKU
+		}
+		if c.EffectivePriceCents != nil {
+			effective = strconv.Itoa(*c.EffectivePriceCents)
+		}
+		records = append(records, []string{
+			strconv.FormatUint(uint64(c.ID), 10),
+			c.Name,
+			c.Flavor,
+			sku,
+			strconv.Itoa(c.PriceCents),
+			effective,
+			c.Currency,
+			strconv.FormatBool(c.IsAvailable),
+			c.CreatedAt.Format(time.RFC3339),
+			c.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return records
+}
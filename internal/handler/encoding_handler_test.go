@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name         string
+		accept       string
+		offers       []string
+		expectedType string
+		expectedOK   bool
+	}{
+		{name: "empty header defaults to first offer", accept: "", offers: listMediaTypes, expectedType: mediaJSON, expectedOK: true},
+		{name: "wildcard", accept: "*/*", offers: listMediaTypes, expectedType: mediaJSON, expectedOK: true},
+		{name: "exact xml", accept: "application/xml", offers: itemMediaTypes, expectedType: mediaXML, expectedOK: true},
+		{name: "text/xml alias", accept: "text/xml", offers: itemMediaTypes, expectedType: mediaXML, expectedOK: true},
+		{name: "csv for collections", accept: "text/csv", offers: listMediaTypes, expectedType: mediaCSV, expectedOK: true},
+		{name: "csv not offered for items", accept: "text/csv", offers: itemMediaTypes, expectedOK: false},
+		{name: "type wildcard", accept: "text/*", offers: listMediaTypes, expectedType: mediaCSV, expectedOK: true},
+		{name: "quality ordering", accept: "application/json;q=0.5, application/xml", offers: itemMediaTypes, expectedType: mediaXML, expectedOK: true},
+		{name: "zero quality is excluded", accept: "application/xml;q=0, */*;q=0.1", offers: itemMediaTypes, expectedType: mediaJSON, expectedOK: true},
+		{name: "browser style header", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", offers: itemMediaTypes, expectedType: mediaXML, expectedOK: true},
+		{name: "versioned media type", accept: mediaHypermedia, offers: listMediaTypes, expectedType: mediaHypermedia, expectedOK: true},
+		{name: "unsupported type", accept: "image/png", offers: listMediaTypes, expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, ok := negotiate(tt.accept, tt.offers)
+			require.Equal(t, tt.expectedOK, ok)
+			if tt.expectedOK {
+				require.Equal(t, tt.expectedType, enc.ContentType())
+			}
+		})
+	}
+}
+
+func TestCupcakeContentNegotiation(t *testing.T) {
+	router := newTestRouter(t)
+
+	body := `{"name":"Red Velvet","flavor":"Red Velvet","price_cents":1200,"sku":"RV-001"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/cupcakes", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	tests := []struct {
+		name                string
+		path                string
+		accept              string
+		expectedStatus      int
+		expectedContentType string
+		validateBody        func(t *testing.T, body []byte)
+	}{
+		{
+			name:                "json by default",
+			path:                "/api/v1/cupcakes/1",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: mediaJSON,
+			validateBody: func(t *testing.T, body []byte) {
+				var cupcake map[string]interface{}
+				require.NoError(t, json.Unmarshal(body, &cupcake))
+				require.Equal(t, "Red Velvet", cupcake["name"])
+			},
+		},
+		{
+			name:                "single cupcake as xml",
+			path:                "/api/v1/cupcakes/1",
+			accept:              "application/xml",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: mediaXML,
+			validateBody: func(t *testing.T, body []byte) {
+				var cupcake struct {
+					XMLName    xml.Name `xml:"cupcake"`
+					ID         uint     `xml:"id"`
+					Name       string   `xml:"name"`
+					SKU        string   `xml:"sku"`
+					PriceCents int      `xml:"price_cents"`
+				}
+				require.True(t, strings.HasPrefix(string(body), "<?xml"))
+				require.NoError(t, xml.Unmarshal(body, &cupcake))
+				require.Equal(t, uint(1), cupcake.ID)
+				require.Equal(t, "Red Velvet", cupcake.Name)
+				require.Equal(t, "RV-001", cupcake.SKU)
+				require.Equal(t, 1200, cupcake.PriceCents)
+			},
+		},
+		{
+			name:                "cupcake by sku as xml",
+			path:                "/api/v1/cupcakes/by-sku/RV-001",
+			accept:              "application/xml",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: mediaXML,
+			validateBody: func(t *testing.T, body []byte) {
+				require.Contains(t, string(body), "<name>Red Velvet</name>")
+			},
+		},
+		{
+			name:                "list as xml",
+			path:                "/api/v1/cupcakes",
+			accept:              "application/xml",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: mediaXML,
+			validateBody: func(t *testing.T, body []byte) {
+				var list struct {
+					XMLName  xml.Name `xml:"cupcakes"`
+					Cupcakes []struct {
+						Name string `xml:"name"`
+					} `xml:"cupcake"`
+				}
+				require.NoError(t, xml.Unmarshal(body, &list))
+				require.Len(t, list.Cupcakes, 1)
+				require.Equal(t, "Red Velvet", list.Cupcakes[0].Name)
+			},
+		},
+		{
+			name:                "list as csv",
+			path:                "/api/v1/cupcakes",
+			accept:              "text/csv",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: mediaCSV,
+			validateBody: func(t *testing.T, body []byte) {
+				records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 2)
+				require.Equal(t, []string{"id", "name", "flavor", "sku", "price_cents"}, records[0][:5])
+				require.Equal(t, []string{"1", "Red Velvet", "Red Velvet", "RV-001", "1200"}, records[1][:5])
+			},
+		},
+		{
+			name:                "csv is not available for a single cupcake",
+			path:                "/api/v1/cupcakes/1",
+			accept:              "text/csv",
+			expectedStatus:      http.StatusNotAcceptable,
+			expectedContentType: mediaJSON,
+		},
+		{
+			name:                "unsupported type",
+			path:                "/api/v1/cupcakes",
+			accept:              "image/png",
+			expectedStatus:      http.StatusNotAcceptable,
+			expectedContentType: mediaJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			require.Equal(t, "Accept", w.Header().Get("Vary"))
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestCupcakeHypermedia(t *testing.T) {
+	router := newTestRouter(t)
+
+	for i := 0; i < 5; i++ {
+		body := fmt.Sprintf(`{"name":"Cupcake %d","flavor":"Vanilla","price_cents":1000}`, i+1)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/cupcakes", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	type envelope struct {
+		Data []struct {
+			ID    uint              `json:"id"`
+			Links map[string]string `json:"links"`
+		} `json:"data"`
+		Meta struct {
+			Total   int64 `json:"total"`
+			Page    int   `json:"page"`
+			PerPage int   `json:"per_page"`
+		} `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		validateBody   func(t *testing.T, body []byte)
+	}{
+		{
+			name:           "single resource carries links",
+			path:           "/api/v1/cupcakes/2",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var resource struct {
+					ID    uint              `json:"id"`
+					Name  string            `json:"name"`
+					Links map[string]string `json:"links"`
+				}
+				require.NoError(t, json.Unmarshal(body, &resource))
+				require.Equal(t, "Cupcake 2", resource.Name)
+				require.Equal(t, "/api/v1/cupcakes/2", resource.Links["self"])
+				require.Equal(t, "/api/v1/admin/cupcakes/2", resource.Links["update"])
+			},
+		},
+		{
+			name:           "first page has next but no prev",
+			path:           "/api/v1/cupcakes?per_page=2",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var env envelope
+				require.NoError(t, json.Unmarshal(body, &env))
+				require.Len(t, env.Data, 2)
+				require.Equal(t, "/api/v1/cupcakes/1", env.Data[0].Links["self"])
+				require.Equal(t, int64(5), env.Meta.Total)
+				require.Equal(t, 1, env.Meta.Page)
+				require.Equal(t, 2, env.Meta.PerPage)
+				require.Equal(t, "/api/v1/cupcakes?page=1&per_page=2", env.Links["self"])
+				require.Equal(t, "/api/v1/cupcakes?page=2&per_page=2", env.Links["next"])
+				require.NotContains(t, env.Links, "prev")
+			},
+		},
+		{
+			name:           "last page has prev but no next",
+			path:           "/api/v1/cupcakes?page=3&per_page=2",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var env envelope
+				require.NoError(t, json.Unmarshal(body, &env))
+				require.Len(t, env.Data, 1)
+				require.Equal(t, uint(5), env.Data[0].ID)
+				require.Equal(t, "/api/v1/cupcakes?page=2&per_page=2", env.Links["prev"])
+				require.NotContains(t, env.Links, "next")
+			},
+		},
+		{
+			name:           "default page size",
+			path:           "/api/v1/cupcakes",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var env envelope
+				require.NoError(t, json.Unmarshal(body, &env))
+				require.Len(t, env.Data, 5)
+				require.Equal(t, 20, env.Meta.PerPage)
+			},
+		},
+		{
+			name:           "invalid page",
+			path:           "/api/v1/cupcakes?page=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "page out of range",
+			path:           "/api/v1/cupcakes?page=0",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Accept", mediaHypermedia)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateBody != nil {
+				require.Equal(t, mediaHypermedia, w.Header().Get("Content-Type"))
+				tt.validateBody(t, w.Body.Bytes())
+			}
+		})
+	}
+}
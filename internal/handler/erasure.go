@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type ErasureHandler struct {
+	service service.ErasureServiceInterface
+}
+
+func NewErasureHandler(service service.ErasureServiceInterface) *ErasureHandler {
+	return &ErasureHandler{service: service}
+}
+
+// EraseMe handles a customer's erasure of ?email=. Without a token it only
+// emails the confirmation link; with the link's expires and token it
+// erases the data.
+func (h *ErasureHandler) EraseMe(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	email := query.Get("email")
+
+	if query.Get("token") == "" {
+		err := h.service.Request(email)
+		var invalid *i18n.Error
+		switch {
+		case errors.Is(err, service.ErrErasureUnavailable):
+			sendJSONError(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		case errors.As(err, &invalid):
+			sendLocalizedError(w, r, err, http.StatusBadRequest)
+			return
+		case err != nil:
+			sendJSONError(w, "Error sending confirmation email", http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{"status": "confirmation_sent"})
+		return
+	}
+
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		sendJSONError(w, "Invalid expires", http.StatusBadRequest)
+		return
+	}
+	erasure, err := h.service.Confirm(email, expires, query.Get("token"))
+	if err != nil {
+		sendErasureError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(erasure)
+}
+
+// EraseCustomer erases the data of ?email= right away, for admins.
+func (h *ErasureHandler) EraseCustomer(w http.ResponseWriter, r *http.Request) {
+	erasure, err := h.service.Erase(r.URL.Query().Get("email"))
+	if err != nil {
+		sendErasureError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(erasure)
+}
+
+func (h *ErasureHandler) GetErasures(w http.ResponseWriter, r *http.Request) {
+	erasures, err := h.service.GetErasures(r.URL.Query().Get("email"))
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(erasures)
+}
+
+func sendErasureError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrErasureTokenInvalid):
+		sendJSONError(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrErasureTokenExpired):
+		sendJSONError(w, err.Error(), http.StatusGone)
+	default:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+	}
+}
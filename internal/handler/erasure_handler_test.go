@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErasure(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(&models.WholesaleAccount{Name: "Ana Café", Email: "ana@example.com"}).Error)
+
+	var confirmPath string
+	var sendErr error
+	sender := &mocks.EmailSender{SendFunc: func(_ context.Context, _ string, email *models.RenderedEmail) error {
+		href := regexp.MustCompile(`href="([^"]+)"`).FindStringSubmatch(email.HTML)
+		require.NotNil(t, href)
+		link, err := url.Parse(html.UnescapeString(href[1]))
+		require.NoError(t, err)
+		confirmPath = "/api/v1/me?" + strings.TrimPrefix(link.Fragment, "erasure?")
+		return sendErr
+	}}
+	erasures := service.NewErasureService(repository.NewErasureRepository(db), "test-secret")
+	handler := NewErasureHandler(erasures)
+
+	r := chi.NewRouter()
+	r.Delete("/api/v1/me", handler.EraseMe)
+	r.Delete("/api/v1/admin/customers", handler.EraseCustomer)
+	r.Get("/api/v1/admin/erasures", handler.GetErasures)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/me?email=ana@example.com", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	erasures.WithEmail(sender, "https://loja.example.com")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/me", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "email is required")
+
+	sendErr = errors.New("smtp: connection refused")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/me?email=ana@example.com", nil))
+	require.Equal(t, http.StatusBadGateway, w.Code)
+	sendErr = nil
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/me?email=ana@example.com", nil))
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"confirmation_sent"`)
+	require.NotContains(t, w.Body.String(), "token")
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", strings.Replace(confirmPath, "token=", "token=0", 1), nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/me?email=ana@example.com&token=x", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", confirmPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"requested_by":"customer"`)
+	require.Contains(t, w.Body.String(), `"wholesale_accounts":1`)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/admin/customers?email=bruno@example.com", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"requested_by":"admin"`)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/erasures?email=ana@example.com", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"requested_by":"customer"`)
+	require.NotContains(t, w.Body.String(), "ana@example.com")
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// visitorIDHeader carries the anonymous visitor ID that experiment variants
+// are assigned by. Clients keep it stable, e.g. in a cookie or local
+// storage.
+const visitorIDHeader = "X-Visitor-ID"
+
+type ExperimentHandler struct {
+	service service.ExperimentServiceInterface
+}
+
+func NewExperimentHandler(service service.ExperimentServiceInterface) *ExperimentHandler {
+	return &ExperimentHandler{service: service}
+}
+
+func (h *ExperimentHandler) CreateExperiment(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateExperimentRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	experiment, err := h.service.CreateExperiment(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(experiment)
+}
+
+func (h *ExperimentHandler) GetAllExperiments(w http.ResponseWriter, r *http.Request) {
+	experiments, err := h.service.GetAllExperiments()
+	if err != nil {
+		sendJSONError(w, "Error fetching experiments", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(experiments)
+}
+
+// GetExperiment returns the experiment with its results per variant.
+func (h *ExperimentHandler) GetExperiment(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	results, err := h.service.GetExperiment(uint(id))
+	if err != nil {
+		sendExperimentError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+func (h *ExperimentHandler) StopExperiment(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	experiment, err := h.service.StopExperiment(uint(id))
+	if err != nil {
+		sendExperimentError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(experiment)
+}
+
+// GetAssignments returns the visitor's variant in every running experiment.
+func (h *ExperimentHandler) GetAssignments(w http.ResponseWriter, r *http.Request) {
+	assignments, err := h.service.Assign(visitorID(r))
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Add("Vary", visitorIDHeader)
+	json.NewEncoder(w).Encode(assignments)
+}
+
+// RecordConversion records that the visitor converted, e.g. placed an
+// order, crediting the variant they were shown.
+func (h *ExperimentHandler) RecordConversion(w http.ResponseWriter, r *http.Request) {
+	var req models.ConversionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.service.RecordConversion(chi.URLParam(r, "key"), visitorID(r), &req); err != nil {
+		sendExperimentError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func visitorID(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(visitorIDHeader))
+}
+
+func sendExperimentError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrExperimentNotFound):
+		sendJSONError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrExperimentStopped):
+		sendJSONError(w, err.Error(), http.StatusConflict)
+	default:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+// newExperimentTestRouter serves a cupcake priced 1000 with a running
+// price-test experiment whose only variant raises prices by 10%.
+func newExperimentTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	cupcake := factory.Cupcake(factory.WithPrice(1000))
+	require.NoError(t, cupcakeRepo.Create(&cupcake))
+
+	experiments := service.NewExperimentService(repository.NewExperimentRepository(db), cupcakeRepo)
+	_, err := experiments.CreateExperiment(&models.CreateExperimentRequest{
+		Key: "price-test",
+		Variants: []models.ExperimentVariantRequest{
+			{Name: "higher", Weight: 1, PriceAdjustmentPercent: 10},
+			{Name: "also-higher", Weight: 1, PriceAdjustmentPercent: 10},
+		},
+	})
+	require.NoError(t, err)
+
+	cupcakes := NewCupcakeHandler(service.NewCupcakeService(cupcakeRepo, repository.NewPromotionRepository(db), repository.NewLocationRepository(db), nil, nil, nil, nil)).
+		WithExperiments(experiments)
+	handler := NewExperimentHandler(experiments)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/cupcakes/{id}", cupcakes.GetCupcake)
+	r.Get("/api/v1/experiments/assignments", handler.GetAssignments)
+	r.Post("/api/v1/experiments/{key}/conversions", handler.RecordConversion)
+	r.Route("/api/v1/admin/experiments", func(r chi.Router) {
+		r.Get("/", handler.GetAllExperiments)
+		r.Post("/", handler.CreateExperiment)
+		r.Get("/{id}", handler.GetExperiment)
+		r.Post("/{id}/stop", handler.StopExperiment)
+	})
+	return r
+}
+
+func TestGetCupcake_PriceExperiment(t *testing.T) {
+	router := newExperimentTestRouter(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/cupcakes/1", nil)
+	req.Header.Set("X-Visitor-ID", "visitor-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Regexp(t, `^price-test=(higher|also-higher)$`, w.Header().Get("X-Experiments"))
+	require.Contains(t, w.Header().Values("Vary"), "X-Visitor-ID")
+	var cupcake models.CupcakeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+	require.Equal(t, 1100, *cupcake.EffectivePriceCents)
+
+	// Without a visitor ID the regular price is served.
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/cupcakes/1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("X-Experiments"))
+	cupcake = models.CupcakeResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+	require.Nil(t, cupcake.EffectivePriceCents)
+}
+
+func TestGetAssignments(t *testing.T) {
+	router := newExperimentTestRouter(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/experiments/assignments", nil)
+	req.Header.Set("X-Visitor-ID", "visitor-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var assignments []models.ExperimentAssignment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &assignments))
+	require.Len(t, assignments, 1)
+	require.Equal(t, "price-test", assignments[0].Experiment)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/experiments/assignments", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "X-Visitor-ID must have 1 to 64 characters")
+}
+
+func TestRecordConversion(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		visitor        string
+		payload        string
+		stopped        bool
+		expectedStatus int
+	}{
+		{name: "conversion returns 204", key: "price-test", visitor: "visitor-1", payload: `{"value_cents":1100}`, expectedStatus: http.StatusNoContent},
+		{name: "missing visitor returns 400", key: "price-test", payload: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "negative value returns 400", key: "price-test", visitor: "visitor-1", payload: `{"value_cents":-1}`, expectedStatus: http.StatusBadRequest},
+		{name: "unknown experiment returns 404", key: "missing", visitor: "visitor-1", payload: `{}`, expectedStatus: http.StatusNotFound},
+		{name: "stopped experiment returns 409", key: "price-test", visitor: "visitor-1", payload: `{}`, stopped: true, expectedStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newExperimentTestRouter(t)
+			if tt.stopped {
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/admin/experiments/1/stop", nil))
+				require.Equal(t, http.StatusOK, w.Code)
+			}
+
+			req := httptest.NewRequest("POST", "/api/v1/experiments/"+tt.key+"/conversions", bytes.NewBufferString(tt.payload))
+			req.Header.Set("X-Visitor-ID", tt.visitor)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestGetExperiment_Results(t *testing.T) {
+	router := newExperimentTestRouter(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/experiments/assignments", nil)
+	req.Header.Set("X-Visitor-ID", "visitor-1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/experiments/1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var results models.ExperimentResults
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+	require.Equal(t, "price-test", results.Key)
+	require.Len(t, results.Results, 2)
+	require.Equal(t, int64(1), results.Results[0].Exposures+results.Results[1].Exposures)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/experiments/99", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+// cupcakeFields is the public shape of a cupcake for sparse fieldsets
+// (?fields=id,name). Only names listed here can be selected, so new columns
+// stay private until they are added on purpose.
+var cupcakeFields = map[string]func(*models.CupcakeResponse) interface{}{
+	"id":                    func(c *models.CupcakeResponse) interface{} { return c.ID },
+	"name":                  func(c *models.CupcakeResponse) interface{} { return c.Name },
+	"flavor":                func(c *models.CupcakeResponse) interface{} { return c.Flavor },
+	"sku":                   func(c *models.CupcakeResponse) interface{} { return c.SKU },
+	"slug":                  func(c *models.CupcakeResponse) interface{} { return c.Slug },
+	"description":           func(c *models.CupcakeResponse) interface{} { return c.Description },
+	"display_order":         func(c *models.CupcakeResponse) interface{} { return c.DisplayOrder },
+	"price_cents":           func(c *models.CupcakeResponse) interface{} { return c.PriceCents },
+	"effective_price_cents": func(c *models.CupcakeResponse) interface{} { return c.EffectivePriceCents },
+	"currency":              func(c *models.CupcakeResponse) interface{} { return c.Currency },
+	"is_available":          func(c *models.CupcakeResponse) interface{} { return c.IsAvailable },
+	"available_from":        func(c *models.CupcakeResponse) interface{} { return c.AvailableFrom },
+	"pre_order":             func(c *models.CupcakeResponse) interface{} { return c.PreOrder },
+	"created_at":            func(c *models.CupcakeResponse) interface{} { return c.CreatedAt },
+	"updated_at":            func(c *models.CupcakeResponse) interface{} { return c.UpdatedAt },
+}
+
+// parseFields reads the ?fields= selection, keeping the requested order.
+// A missing or empty parameter returns nil, meaning every field.
+func parseFields(query url.Values) ([]string, error) {
+	raw := strings.TrimSpace(query.Get("fields"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := cupcakeFields[name]; !ok {
+			return nil, fmt.Errorf("unknown field: %s", name)
+		}
+		seen[name] = true
+		fields = append(fields, name)
+	}
+	return fields, nil
+}
+
+type field struct {
+	name  string
+	value interface{}
+}
+
+// fieldSet is a cupcake reduced to the selected fields. It encodes to JSON
+// and XML in the order the fields were requested.
+type fieldSet []field
+
+func selectFields(cupcake *models.CupcakeResponse, fields []string) fieldSet {
+	set := make(fieldSet, len(fields))
+	for i, name := range fields {
+		set[i] = field{name: name, value: cupcakeFields[name](cupcake)}
+	}
+	return set
+}
+
+func (s fieldSet) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (s fieldSet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "cupcake"
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, f := range s {
+		if err := e.EncodeElement(f.value, xml.StartElement{Name: xml.Name{Local: f.name}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// fieldSetList is a sparse listing; like cupcakeList it renders as JSON,
+// XML or CSV.
+type fieldSetList struct {
+	fields []string
+	items  []fieldSet
+}
+
+func newFieldSetList(cupcakes []models.CupcakeResponse, fields []string) fieldSetList {
+	list := fieldSetList{fields: fields, items: make([]fieldSet, len(cupcakes))}
+	for i := range cupcakes {
+		list.items[i] = selectFields(&cupcakes[i], fields)
+	}
+	return list
+}
+
+func (l fieldSetList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.items)
+}
+
+func (l fieldSetList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "cupcakes"
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, item := range l.items {
+		if err := e.Encode(item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (l fieldSetList) MarshalCSV() [][]string {
+	records := [][]string{l.fields}
+	for _, item := range l.items {
+		record := make([]string, len(item))
+		for i, f := range item {
+			record[i] = csvValue(f.value)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case *int:
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedFields []string
+		expectedError  string
+	}{
+		{name: "no selection", query: "", expectedFields: nil},
+		{name: "empty selection", query: "fields=", expectedFields: nil},
+		{name: "keeps requested order", query: "fields=price_cents,id,name", expectedFields: []string{"price_cents", "id", "name"}},
+		{name: "trims and drops duplicates", query: "fields=id,%20name,id,", expectedFields: []string{"id", "name"}},
+		{name: "unknown field", query: "fields=id,cost_cents", expectedError: "unknown field: cost_cents"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			fields, err := parseFields(query)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedFields, fields)
+		})
+	}
+}
+
+func TestCupcakeSparseFieldsets(t *testing.T) {
+	router := newTestRouter(t)
+
+	for _, body := range []string{
+		`{"name":"Chocolate","flavor":"Cocoa","price_cents":1500}`,
+		`{"name":"Vanilla","flavor":"Vanilla","price_cents":1200}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/cupcakes", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		accept         string
+		expectedStatus int
+		expectedBody   string
+		validateBody   func(t *testing.T, body []byte)
+	}{
+		{
+			name:           "detail with selected fields in order",
+			path:           "/api/v1/cupcakes/1?fields=name,price_cents",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Chocolate","price_cents":1500}` + "\n",
+		},
+		{
+			name:           "list with selected fields",
+			path:           "/api/v1/cupcakes?fields=id,name",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"id":1,"name":"Chocolate"},{"id":2,"name":"Vanilla"}]` + "\n",
+		},
+		{
+			name:           "xml detail",
+			path:           "/api/v1/cupcakes/2?fields=id,flavor",
+			accept:         "application/xml",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				require.Contains(t, string(body), "<cupcake><id>2</id><flavor>Vanilla</flavor></cupcake>")
+			},
+		},
+		{
+			name:           "csv columns follow the selection",
+			path:           "/api/v1/cupcakes?fields=name,price_cents",
+			accept:         "text/csv",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+				require.NoError(t, err)
+				require.Equal(t, [][]string{{"name", "price_cents"}, {"Chocolate", "1500"}, {"Vanilla", "1200"}}, records)
+			},
+		},
+		{
+			name:           "hypermedia items keep their links",
+			path:           "/api/v1/cupcakes?fields=name",
+			accept:         mediaHypermedia,
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var env struct {
+					Data  []map[string]interface{} `json:"data"`
+					Links map[string]string        `json:"links"`
+				}
+				require.NoError(t, json.Unmarshal(body, &env))
+				require.Len(t, env.Data, 2)
+				require.Len(t, env.Data[0], 2)
+				require.Equal(t, "Chocolate", env.Data[0]["name"])
+				require.Contains(t, env.Data[0], "links")
+				require.Contains(t, env.Links["self"], "fields=name")
+			},
+		},
+		{
+			name:           "ndjson stream",
+			path:           "/api/v1/cupcakes?format=ndjson&fields=id",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "{\"id\":1}\n{\"id\":2}\n",
+		},
+		{
+			name:           "unknown field",
+			path:           "/api/v1/cupcakes/1?fields=id,password",
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body []byte) {
+				require.Contains(t, string(body), "unknown field: password")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				require.Equal(t, tt.expectedBody, w.Body.String())
+			}
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type GiftCardHandler struct {
+	service service.GiftCardServiceInterface
+}
+
+func NewGiftCardHandler(service service.GiftCardServiceInterface) *GiftCardHandler {
+	return &GiftCardHandler{service: service}
+}
+
+func (h *GiftCardHandler) IssueGiftCard(w http.ResponseWriter, r *http.Request) {
+	var req models.IssueGiftCardRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	giftCard, err := h.service.IssueGiftCard(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(giftCard)
+}
+
+func (h *GiftCardHandler) GetGiftCard(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	giftCard, err := h.service.GetGiftCard(uint(id))
+	if err != nil {
+		sendJSONError(w, "gift card not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(giftCard)
+}
+
+func (h *GiftCardHandler) GetAllGiftCards(w http.ResponseWriter, r *http.Request) {
+	giftCards, err := h.service.GetAllGiftCards()
+	if err != nil {
+		sendJSONError(w, "Error fetching gift cards", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(giftCards)
+}
+
+func (h *GiftCardHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	balance, err := h.service.GetBalance(chi.URLParam(r, "code"))
+	if err != nil {
+		sendJSONError(w, "gift card not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(balance)
+}
+
+func (h *GiftCardHandler) VoidGiftCard(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	giftCard, err := h.service.VoidGiftCard(uint(id))
+	if err != nil {
+		sendJSONError(w, "gift card not found or already voided", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(giftCard)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newGiftCardTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	handler := NewGiftCardHandler(service.NewGiftCardService(repository.NewGiftCardRepository(db)))
+	r := chi.NewRouter()
+
+	r.Get("/api/v1/gift-cards/{code}", handler.GetBalance)
+	r.Route("/api/v1/admin/gift-cards", func(r chi.Router) {
+		r.Post("/", handler.IssueGiftCard)
+		r.Get("/", handler.GetAllGiftCards)
+		r.Get("/{id}", handler.GetGiftCard)
+		r.Post("/{id}/void", handler.VoidGiftCard)
+	})
+
+	return r
+}
+
+func TestIssueGiftCard(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "valid amount returns 201",
+			payload:        `{"amount_cents":5000}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "zero amount returns 400",
+			payload:        `{"amount_cents":0}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "amount must be greater than zero",
+		},
+		{
+			name:           "malformed JSON returns 400",
+			payload:        `{`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Error decoding request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newGiftCardTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/admin/gift-cards", bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestVoidGiftCard(t *testing.T) {
+	router := newGiftCardTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/gift-cards", bytes.NewBufferString(`{"amount_cents":2500}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var giftCard models.GiftCard
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &giftCard))
+
+	req = httptest.NewRequest("POST", fmt.Sprintf("/api/v1/admin/gift-cards/%d/void", giftCard.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var voided models.GiftCard
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &voided))
+	require.NotNil(t, voided.VoidedAt)
+
+	req = httptest.NewRequest("POST", fmt.Sprintf("/api/v1/admin/gift-cards/%d/void", giftCard.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/gift-cards/"+giftCard.Code, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var balance models.GiftCardBalanceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &balance))
+	require.Equal(t, 2500, balance.BalanceCents)
+	require.True(t, balance.IsVoided)
+}
+
+func TestGetGiftCardBalance_NotFound(t *testing.T) {
+	router := newGiftCardTestRouter(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/gift-cards/NOPE-NOPE-NOPE-NOPE", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "gift card not found")
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/health"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name           string
+		brokerErr      error
+		expectedStatus int
+		expectedReport health.Report
+	}{
+		{
+			name:           "all dependencies up",
+			expectedStatus: http.StatusOK,
+			expectedReport: health.Report{
+				Status: health.StatusOK,
+				Checks: map[string]health.Result{
+					"database": {Status: health.StatusOK},
+					"broker":   {Status: health.StatusOK},
+				},
+			},
+		},
+		{
+			name:           "broker down",
+			brokerErr:      errors.New("connection refused"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: health.Report{
+				Status: health.StatusUnavailable,
+				Checks: map[string]health.Result{
+					"database": {Status: health.StatusOK},
+					"broker":   {Status: health.StatusUnavailable, Error: "connection refused"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := health.New()
+			checker.Add("database", time.Second, func(context.Context) error { return nil })
+			checker.Add("broker", time.Second, func(context.Context) error { return tt.brokerErr })
+			handler := NewHealthHandler(checker)
+
+			req := httptest.NewRequest("GET", "/health/ready", nil)
+			w := httptest.NewRecorder()
+			handler.Ready(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var report health.Report
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+			for name, result := range report.Checks {
+				result.DurationMS = 0
+				report.Checks[name] = result
+			}
+			require.Equal(t, tt.expectedReport, report)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+const (
+	cupcakesPath      = "/api/v1/cupcakes"
+	adminCupcakesPath = "/api/v1/admin/cupcakes"
+	defaultPerPage    = 20
+)
+
+type resourceLinks struct {
+	Self   string `json:"self"`
+	Update string `json:"update,omitempty"`
+}
+
+type collectionLinks struct {
+	Self string `json:"self"`
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+type collectionMeta struct {
+	Total   int64 `json:"total"`
+	Page    int   `json:"page"`
+	PerPage int   `json:"per_page"`
+}
+
+type collectionEnvelope struct {
+	Data  interface{}     `json:"data"`
+	Meta  collectionMeta  `json:"meta"`
+	Links collectionLinks `json:"links"`
+}
+
+type cupcakeResource struct {
+	models.CupcakeResponse
+	Links resourceLinks `json:"links"`
+}
+
+func cupcakeLinks(cupcake models.CupcakeResponse) resourceLinks {
+	return resourceLinks{
+		Self:   fmt.Sprintf("%s/%d", cupcakesPath, cupcake.ID),
+		Update: fmt.Sprintf("%s/%d", adminCupcakesPath, cupcake.ID),
+	}
+}
+
+func newCupcakeResource(cupcake models.CupcakeResponse) cupcakeResource {
+	return cupcakeResource{CupcakeResponse: cupcake, Links: cupcakeLinks(cupcake)}
+}
+
+// newCupcakeCollection wraps one page of cupcakes, linking to the
+// neighbouring pages while keeping the rest of the query string intact.
+func newCupcakeCollection(cupcakes []models.CupcakeResponse, total int64, page, perPage int, query url.Values, fields []string) collectionEnvelope {
+	var data interface{}
+	if fields != nil {
+		sets := make([]fieldSet, len(cupcakes))
+		for i := range cupcakes {
+			sets[i] = append(selectFields(&cupcakes[i], fields), field{name: "links", value: cupcakeLinks(cupcakes[i])})
+		}
+		data = sets
+	} else {
+		resources := make([]cupcakeResource, len(cupcakes))
+		for i := range cupcakes {
+			resources[i] = newCupcakeResource(cupcakes[i])
+		}
+		data = resources
+	}
+
+	pageLink := func(n int) string {
+		q := url.Values{}
+		for key, values := range query {
+			q[key] = values
+		}
+		q.Set("page", strconv.Itoa(n))
+		q.Set("per_page", strconv.Itoa(perPage))
+		return cupcakesPath + "?" + q.Encode()
+	}
+
+	links := collectionLinks{Self: pageLink(page)}
+	if int64(page*perPage) < total {
+		links.Next = pageLink(page + 1)
+	}
+	if page > 1 {
+		links.Prev = pageLink(page - 1)
+	}
+
+	return collectionEnvelope{
+		Data:  data,
+		Meta:  collectionMeta{Total: total, Page: page, PerPage: perPage},
+		Links: links,
+	}
+}
+
+// pageParams reads page and per_page from the query string, falling back to
+// the first page of defaultPerPage items.
+func pageParams(query url.Values) (page, perPage int, err error) {
+	page, perPage = 1, defaultPerPage
+	if v := query.Get("page"); v != "" {
+		if page, err = strconv.Atoi(v); err != nil {
+			return 0, 0, fmt.Errorf("invalid page: %s", v)
+		}
+	}
+	if v := query.Get("per_page"); v != "" {
+		if perPage, err = strconv.Atoi(v); err != nil {
+			return 0, 0, fmt.Errorf("invalid per_page: %s", v)
+		}
+	}
+	return page, perPage, nil
+}
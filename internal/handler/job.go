@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julimonteiro/cupcake-store/internal/scheduler"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type JobHandler struct {
+	service   *service.JobService
+	scheduler *scheduler.Scheduler
+}
+
+func NewJobHandler(service *service.JobService, scheduler *scheduler.Scheduler) *JobHandler {
+	return &JobHandler{service: service, scheduler: scheduler}
+}
+
+func (h *JobHandler) GetScheduledTasks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.scheduler.Statuses())
+}
+
+func (h *JobHandler) GetDeadJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.service.GetDeadJobs()
+	if err != nil {
+		sendJSONError(w, "Error fetching jobs", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(jobs)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/scheduler"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDeadJobs(t *testing.T) {
+	tests := []struct {
+		name          string
+		failingJobs   int
+		expectedCount int
+	}{
+		{
+			name:          "empty dead letter list",
+			expectedCount: 0,
+		},
+		{
+			name:          "lists jobs that exhausted their attempts",
+			failingJobs:   2,
+			expectedCount: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			jobService := service.NewJobService(repository.NewJobRepository(db))
+
+			for i := 0; i < tt.failingJobs; i++ {
+				require.NoError(t, db.Create(&models.Job{
+					Type:        "test.job",
+					Status:      models.JobDead,
+					Attempts:    5,
+					MaxAttempts: 5,
+					LastError:   "permanent failure",
+				}).Error)
+			}
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/admin/jobs/dead", NewJobHandler(jobService, nil).GetDeadJobs)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs/dead", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var jobs []models.Job
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &jobs))
+			require.Len(t, jobs, tt.expectedCount)
+		})
+	}
+}
+
+func TestGetScheduledTasks(t *testing.T) {
+	tests := []struct {
+		name          string
+		specs         map[string]string
+		expectedNames []string
+	}{
+		{
+			name:          "no tasks scheduled",
+			specs:         map[string]string{scheduler.TaskExpireCoupons: scheduler.Disabled},
+			expectedNames: []string{},
+		},
+		{
+			name: "lists scheduled tasks by name",
+			specs: map[string]string{
+				"other-task":                "@every 15m",
+				scheduler.TaskExpireCoupons: "@hourly",
+			},
+			expectedNames: []string{scheduler.TaskExpireCoupons, "other-task"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched, err := scheduler.New(tt.specs)
+			require.NoError(t, err)
+			sched.Register(scheduler.TaskExpireCoupons, func() error { return nil })
+			sched.Register("other-task", func() error { return nil })
+
+			r := chi.NewRouter()
+			r.Get("/api/v1/admin/jobs", NewJobHandler(nil, sched).GetScheduledTasks)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs", nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var statuses []scheduler.TaskStatus
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &statuses))
+
+			names := []string{}
+			for _, status := range statuses {
+				names = append(names, status.Name)
+				require.NotNil(t, status.NextRunAt)
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
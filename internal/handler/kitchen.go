@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// The production plan is read as JSON or exported as a CSV batch sheet.
+var productionPlanMediaTypes = []string{mediaJSON, mediaCSV}
+
+type KitchenHandler struct {
+	service service.KitchenServiceInterface
+}
+
+func NewKitchenHandler(service service.KitchenServiceInterface) *KitchenHandler {
+	return &KitchenHandler{service: service}
+}
+
+// ProductionPlan returns what to bake on ?date=, tomorrow by default.
+func (h *KitchenHandler) ProductionPlan(w http.ResponseWriter, r *http.Request) {
+	enc, ok := negotiateResponse(w, r, productionPlanMediaTypes)
+	if !ok {
+		return
+	}
+
+	day := time.Now().AddDate(0, 0, 1)
+	if r.URL.Query().Get("date") != "" {
+		var err error
+		if day, err = dateParam(r.URL.Query()); err != nil {
+			sendLocalizedError(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+
+	plan, err := h.service.ProductionPlan(day)
+	if err != nil {
+		sendJSONError(w, "Error building production plan", http.StatusInternalServerError)
+		return
+	}
+
+	if enc.ContentType() == mediaCSV {
+		w.Header().Set("Content-Disposition", `attachment; filename="production-`+plan.Date+`.csv"`)
+	}
+	writeResponse(w, enc, productionPlanSheet(*plan))
+}
+
+// Queue lists the pickup orders still to be made on ?date=, today by
+// default, at every location or only at ?location_id=.
+func (h *KitchenHandler) Queue(w http.ResponseWriter, r *http.Request) {
+	day, err := dateParam(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	var locationID uint64
+	if v := r.URL.Query().Get("location_id"); v != "" {
+		if locationID, err = strconv.ParseUint(v, 10, 32); err != nil || locationID == 0 {
+			sendJSONError(w, "Invalid location ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	queue, err := h.service.Queue(day, uint(locationID))
+	if err != nil {
+		sendJSONError(w, "Error loading kitchen queue", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(queue)
+}
+
+// productionPlanSheet renders a production plan as JSON or as a CSV batch
+// sheet with one row per cupcake.
+type productionPlanSheet models.ProductionPlan
+
+func (p productionPlanSheet) MarshalCSV() [][]string {
+	records := [][]string{{"date", "cupcake_id", "name", "subscription_quantity", "pickup_quantity", "quantity"}}
+	for _, line := range p.Lines {
+		records = append(records, []string{
+			p.Date,
+			strconv.FormatUint(uint64(line.CupcakeID), 10),
+			line.Name,
+			strconv.Itoa(line.SubscriptionQuantity),
+			strconv.Itoa(line.PickupQuantity),
+			strconv.Itoa(line.Quantity),
+		})
+	}
+	return records
+}
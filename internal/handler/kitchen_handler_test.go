@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func newKitchenTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	vanilla := factory.Cupcake(factory.WithName("Vanilla"))
+	require.NoError(t, cupcakeRepo.Create(&vanilla))
+
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	require.NoError(t, subscriptionRepo.Create(&models.Subscription{
+		CustomerEmail:  "ana@example.com",
+		CupcakeID:      1,
+		Quantity:       6,
+		Frequency:      models.FrequencyWeekly,
+		Status:         models.SubscriptionActive,
+		NextDeliveryAt: time.Date(2026, 10, 17, 9, 0, 0, 0, time.Local),
+	}))
+
+	kitchenHandler := NewKitchenHandler(service.NewKitchenService(subscriptionRepo, repository.NewPickupRepository(db), cupcakeRepo))
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/kitchen/production-plan", kitchenHandler.ProductionPlan)
+	return r
+}
+
+func TestProductionPlan(t *testing.T) {
+	router := newKitchenTestRouter(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/kitchen/production-plan?date=2026-10-17", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var plan models.ProductionPlan
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&plan))
+	require.Equal(t, 6, plan.TotalQuantity)
+	require.Len(t, plan.Lines, 1)
+
+	req = httptest.NewRequest("GET", "/api/v1/kitchen/production-plan?date=2026-10-17", nil)
+	req.Header.Set("Accept", "text/csv")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="production-2026-10-17.csv"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "date,cupcake_id,name,subscription_quantity,pickup_quantity,quantity\n2026-10-17,1,Vanilla,6,0,6\n", w.Body.String())
+
+	req = httptest.NewRequest("GET", "/api/v1/kitchen/production-plan?date=tomorrow", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/kitchen/production-plan", nil)
+	req.Header.Set("Accept", "application/xml")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotAcceptable, w.Code)
+}
+
+func TestKitchenQueue(t *testing.T) {
+	var gotDay time.Time
+	var gotLocation uint
+	handler := NewKitchenHandler(&mocks.KitchenService{
+		QueueFunc: func(day time.Time, locationID uint) ([]models.KitchenQueueEntry, error) {
+			gotDay, gotLocation = day, locationID
+			return []models.KitchenQueueEntry{{
+				ReservationID: 7,
+				LocationID:    2,
+				CustomerName:  "Eva",
+				Status:        models.KitchenQueueOpen,
+				Items:         []models.KitchenQueueItem{{CupcakeID: 1, Name: "Vanilla", Quantity: 2}},
+			}}, nil
+		},
+	})
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.Queue(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	w := get("/api/v1/admin/kitchen/queue?date=2026-10-17&location_id=2")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local), gotDay)
+	require.Equal(t, uint(2), gotLocation)
+	var queue []models.KitchenQueueEntry
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&queue))
+	require.Len(t, queue, 1)
+	require.Equal(t, "open", queue[0].Status)
+
+	w = get("/api/v1/admin/kitchen/queue")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Zero(t, gotLocation)
+
+	for _, query := range []string{"date=tomorrow", "location_id=0", "location_id=centro"} {
+		w = get("/api/v1/admin/kitchen/queue?" + query)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type LocationHandler struct {
+	service service.LocationServiceInterface
+}
+
+func NewLocationHandler(service service.LocationServiceInterface) *LocationHandler {
+	return &LocationHandler{service: service}
+}
+
+func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateLocationRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	location, err := h.service.CreateLocation(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(location)
+}
+
+func (h *LocationHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	location, err := h.service.GetLocation(uint(id))
+	if err != nil {
+		sendLocationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(location)
+}
+
+func (h *LocationHandler) GetAllLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.service.GetAllLocations()
+	if err != nil {
+		sendJSONError(w, "Error fetching locations", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(locations)
+}
+
+func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateLocationRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	location, err := h.service.UpdateLocation(uint(id), &req)
+	if err != nil {
+		sendLocationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(location)
+}
+
+func (h *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteLocation(uint(id)); err != nil {
+		sendLocationError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *LocationHandler) GetStock(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	stock, err := h.service.GetStock(uint(id))
+	if err != nil {
+		sendLocationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stock)
+}
+
+func (h *LocationHandler) SetStock(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	cupcakeID, err := strconv.ParseUint(chi.URLParam(r, "cupcakeID"), 10, 32)
+	if err != nil || cupcakeID == 0 {
+		sendJSONError(w, "Invalid cupcake ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetStockRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	stock, err := h.service.SetStock(uint(id), uint(cupcakeID), &req)
+	if err != nil {
+		sendLocationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stock)
+}
+
+// CheckPickup lets the storefront confirm a pickup basket can be collected
+// at the chosen location before the customer commits to it.
+func (h *LocationHandler) CheckPickup(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.PickupCheckRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if _, err := h.service.CheckPickup(uint(id), &req); err != nil {
+		sendLocationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"available": true})
+}
+
+// sendLocationError answers 404 for a missing location and 400 for any other
+// service error.
+func sendLocationError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrLocationNotFound) {
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
+
+// locationParam reads the optional location_id catalog filter; zero means
+// no filter.
+func locationParam(query url.Values) (uint, error) {
+	v := query.Get("location_id")
+	if v == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(v, 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid location_id: %s", v)
+	}
+	return uint(id), nil
+}
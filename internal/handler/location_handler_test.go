@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+// newLocationTestRouter serves the catalog and location routes over a
+// database holding one location that stocks Vanilla (3) and Chocolate (0).
+func newLocationTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate")),
+		factory.Cupcake(factory.WithName("Lemon")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+	require.NoError(t, locationRepo.Create(&models.Location{Name: "Centro", Address: "Rua Augusta, 100", Hours: "Seg-Sáb 9h-19h"}))
+	require.NoError(t, locationRepo.SetStock(&models.LocationStock{LocationID: 1, CupcakeID: 1, Quantity: 3}))
+	require.NoError(t, locationRepo.SetStock(&models.LocationStock{LocationID: 1, CupcakeID: 2, Quantity: 0}))
+
+	cupcakeHandler := NewCupcakeHandler(service.NewCupcakeService(cupcakeRepo, repository.NewPromotionRepository(db), locationRepo, nil, nil, nil, nil))
+	locationHandler := NewLocationHandler(service.NewLocationService(locationRepo, cupcakeRepo, repository.NewBundleRepository(db)))
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/cupcakes", cupcakeHandler.GetAllCupcakes)
+	r.Get("/api/v1/locations", locationHandler.GetAllLocations)
+	r.Get("/api/v1/locations/{id}", locationHandler.GetLocation)
+	r.Post("/api/v1/locations/{id}/pickup-check", locationHandler.CheckPickup)
+	r.Post("/api/v1/admin/locations", locationHandler.CreateLocation)
+	r.Put("/api/v1/admin/locations/{id}/stock/{cupcakeID}", locationHandler.SetStock)
+	return r
+}
+
+func TestGetAllLocations(t *testing.T) {
+	router := newLocationTestRouter(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/locations", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var locations []models.Location
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&locations))
+	require.Len(t, locations, 1)
+	require.Equal(t, "Centro", locations[0].Name)
+	require.Equal(t, "Seg-Sáb 9h-19h", locations[0].Hours)
+}
+
+func TestGetLocation_NotFound(t *testing.T) {
+	router := newLocationTestRouter(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/locations/999", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "location not found")
+}
+
+func TestGetAllCupcakes_LocationFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		accept         string
+		expectedStatus int
+		expectedNames  []string
+		expectedError  string
+	}{
+		{
+			name:           "no filter lists the whole catalog",
+			query:          "",
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Vanilla", "Chocolate", "Lemon"},
+		},
+		{
+			name:           "only cupcakes in stock at the location",
+			query:          "?location_id=1",
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Vanilla"},
+		},
+		{
+			name:           "paginated collection",
+			query:          "?location_id=1&per_page=10",
+			accept:         mediaHypermedia,
+			expectedStatus: http.StatusOK,
+			expectedNames:  []string{"Vanilla"},
+		},
+		{
+			name:           "unknown location",
+			query:          "?location_id=999",
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "location not found",
+		},
+		{
+			name:           "invalid location",
+			query:          "?location_id=abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid location_id: abc",
+		},
+		{
+			name:           "not supported when streaming",
+			query:          "?location_id=1&format=ndjson",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "location_id is not supported with format=ndjson",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newLocationTestRouter(t)
+
+			req := httptest.NewRequest("GET", "/api/v1/cupcakes"+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var cupcakes []models.CupcakeResponse
+			if tt.accept == mediaHypermedia {
+				var envelope struct {
+					Data []models.CupcakeResponse `json:"data"`
+					Meta struct {
+						Total int64 `json:"total"`
+					} `json:"meta"`
+				}
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&envelope))
+				require.Equal(t, int64(len(tt.expectedNames)), envelope.Meta.Total)
+				cupcakes = envelope.Data
+			} else {
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&cupcakes))
+			}
+
+			names := make([]string, len(cupcakes))
+			for i, cupcake := range cupcakes {
+				names[i] = cupcake.Name
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestSetStock(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		payload        string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "success",
+			path:           "/api/v1/admin/locations/1/stock/3",
+			payload:        `{"quantity":12}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "negative quantity",
+			path:           "/api/v1/admin/locations/1/stock/3",
+			payload:        `{"quantity":-1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "quantity cannot be negative",
+		},
+		{
+			name:           "unknown location",
+			path:           "/api/v1/admin/locations/999/stock/3",
+			payload:        `{"quantity":1}`,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "location not found",
+		},
+		{
+			name:           "invalid cupcake ID",
+			path:           "/api/v1/admin/locations/1/stock/abc",
+			payload:        `{"quantity":1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid cupcake ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newLocationTestRouter(t)
+
+			req := httptest.NewRequest("PUT", tt.path, bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var stock models.LocationStock
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&stock))
+			require.Equal(t, 12, stock.Quantity)
+		})
+	}
+}
+
+func TestCheckPickup(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		payload        string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "available",
+			path:           "/api/v1/locations/1/pickup-check",
+			payload:        `{"items":[{"cupcake_id":1,"quantity":2}]}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"available":true}`,
+		},
+		{
+			name:           "not enough stock",
+			path:           "/api/v1/locations/1/pickup-check",
+			payload:        `{"items":[{"cupcake_id":1,"quantity":4}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "only 3 of Vanilla available at this location",
+		},
+		{
+			name:           "unknown location",
+			path:           "/api/v1/locations/999/pickup-check",
+			payload:        `{"items":[{"cupcake_id":1,"quantity":1}]}`,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "location not found",
+		},
+		{
+			name:           "malformed body",
+			path:           "/api/v1/locations/1/pickup-check",
+			payload:        `{"items":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "Error decoding request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newLocationTestRouter(t)
+
+			req := httptest.NewRequest("POST", tt.path, bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type MaintenanceHandler struct {
+	service *service.MaintenanceService
+}
+
+func NewMaintenanceHandler(service *service.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{service: service}
+}
+
+func (h *MaintenanceHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.service.Status())
+}
+
+func (h *MaintenanceHandler) SetStatus(w http.ResponseWriter, r *http.Request) {
+	var req models.MaintenanceRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	status, err := h.service.SetStatus(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func (h *MaintenanceHandler) SetReadOnly(w http.ResponseWriter, r *http.Request) {
+	var req models.ReadOnlyRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	status, err := h.service.SetReadOnly(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// Gate answers 503 with Retry-After while maintenance mode is on. It wraps
+// the public API only, so admin routes and health checks keep working.
+func (h *MaintenanceHandler) Gate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := h.service.Status()
+		if !status.Enabled {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Retry-After", strconv.Itoa(status.RetryAfterSeconds))
+		sendJSONError(w, "service under maintenance, please retry later", http.StatusServiceUnavailable)
+	})
+}
+
+// ReadOnlyGate rejects mutating requests with 503 while read-only mode is on.
+// Paths in exempt stay writable so the switches themselves can be flipped
+// back.
+func (h *MaintenanceHandler) ReadOnlyGate(exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := h.service.Status()
+			if !status.ReadOnly || !isMutating(r.Method) || slices.Contains(exempt, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(status.RetryAfterSeconds))
+			sendJSONError(w, "service is in read-only mode, please retry later", http.StatusServiceUnavailable)
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
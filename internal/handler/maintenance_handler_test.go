@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newMaintenanceTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	handler := NewMaintenanceHandler(service.NewMaintenanceService(false, false, time.Minute))
+	r := chi.NewRouter()
+	r.Get("/api/v1/admin/maintenance", handler.GetStatus)
+	r.Post("/api/v1/admin/maintenance", handler.SetStatus)
+	r.With(handler.Gate).Get("/api/v1/cupcakes", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return r
+}
+
+func TestMaintenanceToggle(t *testing.T) {
+	tests := []struct {
+		name               string
+		payload            string
+		expectedStatus     int
+		expectedError      string
+		expectedGateStatus int
+		expectedRetryAfter string
+	}{
+		{
+			name:               "enable blocks public routes",
+			payload:            `{"enabled":true,"retry_after_seconds":300}`,
+			expectedStatus:     http.StatusOK,
+			expectedGateStatus: http.StatusServiceUnavailable,
+			expectedRetryAfter: "300",
+		},
+		{
+			name:               "disable lets requests through",
+			payload:            `{"enabled":false}`,
+			expectedStatus:     http.StatusOK,
+			expectedGateStatus: http.StatusOK,
+		},
+		{
+			name:               "missing enabled flag",
+			payload:            `{}`,
+			expectedStatus:     http.StatusBadRequest,
+			expectedError:      "enabled is required",
+			expectedGateStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newMaintenanceTestRouter(t)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/maintenance", bytes.NewBufferString(tt.payload))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+
+			req = httptest.NewRequest(http.MethodGet, "/api/v1/cupcakes", nil)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedGateStatus, w.Code)
+			require.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
+
+			req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/maintenance", nil)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var status models.MaintenanceStatus
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+			require.Equal(t, tt.expectedGateStatus == http.StatusServiceUnavailable, status.Enabled)
+		})
+	}
+}
+
+func TestReadOnlyGate(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "reads are allowed", method: http.MethodGet, path: "/api/v1/cupcakes", expectedStatus: http.StatusOK},
+		{name: "creates are rejected", method: http.MethodPost, path: "/api/v1/cupcakes", expectedStatus: http.StatusServiceUnavailable},
+		{name: "updates are rejected", method: http.MethodPut, path: "/api/v1/cupcakes/1", expectedStatus: http.StatusServiceUnavailable},
+		{name: "deletes are rejected", method: http.MethodDelete, path: "/api/v1/cupcakes/1", expectedStatus: http.StatusServiceUnavailable},
+		{name: "exempt path stays writable", method: http.MethodPost, path: "/api/v1/admin/read-only", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewMaintenanceHandler(service.NewMaintenanceService(false, true, time.Minute))
+
+			r := chi.NewRouter()
+			r.Use(handler.ReadOnlyGate("/api/v1/admin/read-only"))
+			r.HandleFunc("/*", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				require.Equal(t, "60", w.Header().Get("Retry-After"))
+				require.Contains(t, w.Body.String(), "read-only mode")
+			}
+		})
+	}
+}
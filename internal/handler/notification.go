@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// NotificationHandler serves the notification preferences of the account
+// of the bearer token.
+type NotificationHandler struct {
+	service  service.NotificationServiceInterface
+	accounts service.AccountServiceInterface
+}
+
+func NewNotificationHandler(service service.NotificationServiceInterface, accounts service.AccountServiceInterface) *NotificationHandler {
+	return &NotificationHandler{service: service, accounts: accounts}
+}
+
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	account, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.service.GetPreferences(account.ID)
+	if err != nil {
+		sendJSONError(w, "Error fetching notification preferences", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preferences)
+}
+
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	account, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	var req models.UpdateNotificationPreferencesRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	preferences, err := h.service.UpdatePreferences(account.ID, &req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preferences)
+}
+
+func (h *NotificationHandler) authenticate(w http.ResponseWriter, r *http.Request) (*models.Account, bool) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return nil, false
+	}
+	account, err := h.accounts.Authenticate(token)
+	if err != nil {
+		sendAuthError(w, r, err)
+		return nil, false
+	}
+	return account, true
+}
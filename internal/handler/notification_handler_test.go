@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	db := setupTestDB(t)
+	accounts := &mocks.AccountService{AuthenticateFunc: func(token string) (*models.Account, error) {
+		if token != "ana-token" {
+			return nil, service.ErrAccessTokenInvalid
+		}
+		return &models.Account{ID: 1, Email: "ana@example.com"}, nil
+	}}
+	notifications := service.NewNotificationService(repository.NewNotificationPreferenceRepository(db), repository.NewAccountRepository(db), &mocks.EventPublisher{})
+	handler := NewNotificationHandler(notifications, accounts)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/me/notification-preferences", handler.GetPreferences)
+	r.Put("/api/v1/me/notification-preferences", handler.UpdatePreferences)
+	serve := func(method, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/me/notification-preferences", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("GET", "", "")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	w = serve("GET", "", "other-token")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve("PUT", `{"preferences":[{"event":"cupcake.created","email":false}]}`, "ana-token")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "cupcake.created is not a notification event")
+
+	w = serve("PUT", `{"preferences":[{"event":"ticket.status_changed","email":false,"push":true}]}`, "ana-token")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = serve("GET", "", "ana-token")
+	require.Equal(t, http.StatusOK, w.Code)
+	var preferences []models.NotificationPreferenceResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&preferences))
+	require.Len(t, preferences, 3)
+	require.Equal(t, models.EventTicketStatusChanged, preferences[2].Event)
+	require.False(t, preferences[2].Email)
+	require.False(t, preferences[2].SMS)
+	require.True(t, preferences[2].Push)
+}
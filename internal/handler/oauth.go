@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// oauthNonceCookie keeps the nonce of a social login in the browser that
+// started it, from the redirect to the provider until the callback.
+const oauthNonceCookie = "oauth_nonce"
+
+type OAuthHandler struct {
+	service service.OAuthServiceInterface
+}
+
+func NewOAuthHandler(service service.OAuthServiceInterface) *OAuthHandler {
+	return &OAuthHandler{service: service}
+}
+
+// GetProviders lists the providers customers can log in with.
+func (h *OAuthHandler) GetProviders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{"providers": h.service.Providers()})
+}
+
+// Login redirects to the provider's login page.
+func (h *OAuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+	authorization, err := h.service.Begin(provider, oauthRedirectURI(r, provider))
+	if err != nil {
+		sendOAuthError(w, r, err)
+		return
+	}
+
+	setOAuthNonce(w, r, authorization.Nonce)
+	http.Redirect(w, r, authorization.AuthorizationURL, http.StatusFound)
+}
+
+// Link starts linking the provider to the account of the bearer token. It
+// returns the provider's login page rather than redirecting, since the
+// browser does not send the token on a navigation.
+func (h *OAuthHandler) Link(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	provider := chi.URLParam(r, "provider")
+	authorization, err := h.service.BeginLink(token, provider, oauthRedirectURI(r, provider))
+	if err != nil {
+		sendOAuthError(w, r, err)
+		return
+	}
+
+	setOAuthNonce(w, r, authorization.Nonce)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(authorization)
+}
+
+// Callback is where the provider sends the customer back, for both logins
+// and links. Either way it answers with an access token of the account.
+func (h *OAuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if reason := query.Get("error"); reason != "" {
+		sendJSONError(w, service.ErrOAuthLoginFailed.Error()+": "+reason, http.StatusUnauthorized)
+		return
+	}
+	code, state := query.Get("code"), query.Get("state")
+	if code == "" || state == "" {
+		sendJSONError(w, "Missing code or state", http.StatusBadRequest)
+		return
+	}
+	var nonce string
+	if cookie, err := r.Cookie(oauthNonceCookie); err == nil {
+		nonce = cookie.Value
+	}
+	// The nonce is spent whatever the outcome.
+	setOAuthNonce(w, r, "")
+
+	provider := chi.URLParam(r, "provider")
+	token, err := h.service.Complete(r.Context(), provider, code, state, nonce, oauthRedirectURI(r, provider))
+	if err != nil {
+		sendOAuthError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(token)
+}
+
+// GetIdentities lists the providers linked to the account of the bearer
+// token.
+func (h *OAuthHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	identities, err := h.service.Identities(token)
+	if err != nil {
+		sendOAuthError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(identities)
+}
+
+func (h *OAuthHandler) Unlink(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unlink(token, chi.URLParam(r, "provider")); err != nil {
+		sendOAuthError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// oauthRedirectURI is the callback registered with the provider. It has to
+// be the same when the login begins and when the code is exchanged.
+func oauthRedirectURI(r *http.Request, provider string) string {
+	return storeURL(r) + "/api/v1/auth/oauth/" + provider + "/callback"
+}
+
+// setOAuthNonce keeps nonce for the callback, or clears it when empty. Lax
+// same-site still sends it on the provider's redirect back.
+func setOAuthNonce(w http.ResponseWriter, r *http.Request, nonce string) {
+	cookie := &http.Cookie{
+		Name:     oauthNonceCookie,
+		Value:    nonce,
+		Path:     "/api/v1/auth/oauth",
+		MaxAge:   int(service.OAuthStateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(storeURL(r), "https://"),
+		SameSite: http.SameSiteLaxMode,
+	}
+	if nonce == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+}
+
+func sendOAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrOAuthProviderNotFound),
+		errors.Is(err, service.ErrOAuthIdentityNotFound):
+		sendJSONError(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrOAuthStateInvalid):
+		sendJSONError(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrOAuthLoginFailed):
+		sendJSONError(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrOAuthEmailMissing):
+		sendJSONError(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrOAuthAccountExists),
+		errors.Is(err, service.ErrOAuthIdentityTaken),
+		errors.Is(err, service.ErrOAuthAlreadyLinked),
+		errors.Is(err, service.ErrOAuthLastLoginMethod):
+		sendJSONError(w, err.Error(), http.StatusConflict)
+	default:
+		sendAuthError(w, r, err)
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuth_LoginAndCallback(t *testing.T) {
+	svc := &mocks.OAuthService{
+		BeginFunc: func(provider, redirectURI string) (*models.OAuthAuthorization, error) {
+			if provider != "google" {
+				return nil, service.ErrOAuthProviderNotFound
+			}
+			require.Equal(t, "http://example.com/api/v1/auth/oauth/google/callback", redirectURI)
+			return &models.OAuthAuthorization{AuthorizationURL: "https://accounts.google.com/auth?state=s", Nonce: "n-1"}, nil
+		},
+		CompleteFunc: func(_ context.Context, provider, code, state, nonce, redirectURI string) (*models.AccessToken, error) {
+			if nonce != "n-1" {
+				return nil, service.ErrOAuthStateInvalid
+			}
+			require.Equal(t, "code-1", code)
+			require.Equal(t, "s", state)
+			return &models.AccessToken{AccessToken: "jwt", TokenType: "Bearer"}, nil
+		},
+	}
+	handler := NewOAuthHandler(svc)
+	r := chi.NewRouter()
+	r.Get("/api/v1/auth/oauth/{provider}", handler.Login)
+	r.Get("/api/v1/auth/oauth/{provider}/callback", handler.Callback)
+	get := func(path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/auth/oauth/facebook")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = get("/api/v1/auth/oauth/google")
+	require.Equal(t, http.StatusFound, w.Code)
+	require.Equal(t, "https://accounts.google.com/auth?state=s", w.Header().Get("Location"))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, oauthNonceCookie, cookies[0].Name)
+	require.Equal(t, "n-1", cookies[0].Value)
+	require.True(t, cookies[0].HttpOnly)
+
+	// Without the cookie the callback comes from another browser.
+	w = get("/api/v1/auth/oauth/google/callback?code=code-1&state=s")
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = get("/api/v1/auth/oauth/google/callback?state=s", cookies[0])
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = get("/api/v1/auth/oauth/google/callback?error=access_denied&state=s", cookies[0])
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = get("/api/v1/auth/oauth/google/callback?code=code-1&state=s", cookies[0])
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"access_token":"jwt"`)
+	cleared := w.Result().Cookies()
+	require.Len(t, cleared, 1)
+	require.Equal(t, -1, cleared[0].MaxAge)
+}
+
+func TestOAuth_Unlink(t *testing.T) {
+	svc := &mocks.OAuthService{
+		UnlinkFunc: func(accessToken, provider string) error {
+			require.Equal(t, "jwt", accessToken)
+			if provider == "github" {
+				return service.ErrOAuthLastLoginMethod
+			}
+			return nil
+		},
+	}
+	r := chi.NewRouter()
+	r.Delete("/api/v1/auth/identities/{provider}", NewOAuthHandler(svc).Unlink)
+	unlink := func(provider, token string) int {
+		req := httptest.NewRequest("DELETE", "/api/v1/auth/identities/"+provider, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusUnauthorized, unlink("google", ""))
+	require.Equal(t, http.StatusNoContent, unlink("google", "jwt"))
+	require.Equal(t, http.StatusConflict, unlink("github", "jwt"))
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"html/template"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/microcosm-cc/bluemonday"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	ogSiteName = "Cupcake Store"
+
+	// Social networks crop previews to roughly 1.91:1.
+	ogImageWidth  = 1200
+	ogImageHeight = 630
+	ogQRSize      = 420
+
+	maxOGDescriptionLength = 200
+)
+
+var ogTextPolicy = bluemonday.StrictPolicy()
+
+// openGraph is the link preview metadata for a cupcake.
+type openGraph struct {
+	Type        string `json:"type"`
+	SiteName    string `json:"site_name"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Image       string `json:"image"`
+	ImageWidth  int    `json:"image_width"`
+	ImageHeight int    `json:"image_height"`
+	Locale      string `json:"locale,omitempty"`
+}
+
+var ogPage = template.Must(template.New("og").Parse(`<!DOCTYPE html>
+<html{{with .Locale}} lang="{{.}}"{{end}}>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<meta name="description" content="{{.Description}}">
+<meta property="og:type" content="{{.Type}}">
+<meta property="og:site_name" content="{{.SiteName}}">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.URL}}">
+<meta property="og:image" content="{{.Image}}">
+<meta property="og:image:width" content="{{.ImageWidth}}">
+<meta property="og:image:height" content="{{.ImageHeight}}">
+<meta name="twitter:card" content="summary_large_image">
+<link rel="canonical" href="{{.URL}}">
+<meta http-equiv="refresh" content="0; url={{.URL}}">
+</head>
+<body><a href="{{.URL}}">{{.Title}}</a></body>
+</html>
+`))
+
+// GetCupcakeOG returns the Open Graph metadata used for link previews, as
+// JSON or, with ?format=html, as a share page crawlers can read before
+// sending visitors on to the store.
+func (h *CupcakeHandler) GetCupcakeOG(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "html" {
+		sendJSONError(w, "format must be json or html", http.StatusBadRequest)
+		return
+	}
+
+	cupcake, err := h.service.GetCupcake(uint(id))
+	if err != nil {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+
+	cupcakes := []models.Cupcake{*cupcake}
+	if !h.localize(w, r, cupcakes) {
+		return
+	}
+	og := newOpenGraph(&cupcakes[0], storeURL(r), w.Header().Get("Content-Language"))
+
+	if format == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		ogPage.Execute(w, og)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(og)
+}
+
+// GetCupcakeOGImage renders the share image: a card in a colour picked
+// from the flavor with a QR code linking to the cupcake in the store.
+func (h *CupcakeHandler) GetCupcakeOGImage(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	cupcake, err := h.service.GetCupcake(uint(id))
+	if err != nil {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+
+	img, err := renderShareImage(cupcake, fmt.Sprintf("%s/?cupcake=%d", storeURL(r), id))
+	if err != nil {
+		sendJSONError(w, "Error generating share image", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	png.Encode(w, img)
+}
+
+func newOpenGraph(cupcake *models.Cupcake, base, locale string) openGraph {
+	return openGraph{
+		Type:        "product",
+		SiteName:    ogSiteName,
+		Title:       cupcake.Name,
+		Description: ogDescription(cupcake),
+		URL:         fmt.Sprintf("%s/?cupcake=%d", base, cupcake.ID),
+		Image:       fmt.Sprintf("%s/api/v1/cupcakes/%d/og/image", base, cupcake.ID),
+		ImageWidth:  ogImageWidth,
+		ImageHeight: ogImageHeight,
+		Locale:      locale,
+	}
+}
+
+// ogDescription flattens the cupcake's HTML description to a single line of
+// plain text short enough for a preview, falling back to its flavor.
+func ogDescription(cupcake *models.Cupcake) string {
+	text := html.UnescapeString(ogTextPolicy.Sanitize(cupcake.Description))
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return fmt.Sprintf("%s cupcake", cupcake.Flavor)
+	}
+	if utf8.RuneCountInString(text) <= maxOGDescriptionLength {
+		return text
+	}
+	runes := []rune(text)[:maxOGDescriptionLength-1]
+	return strings.TrimRight(string(runes), " ") + "…"
+}
+
+func renderShareImage(cupcake *models.Cupcake, link string) (image.Image, error) {
+	code, err := qrcode.New(link, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+
+	card := image.NewRGBA(image.Rect(0, 0, ogImageWidth, ogImageHeight))
+	draw.Draw(card, card.Bounds(), &image.Uniform{flavorColor(cupcake.Flavor)}, image.Point{}, draw.Src)
+
+	qr := code.Image(ogQRSize)
+	offset := image.Pt((ogImageWidth-ogQRSize)/2, (ogImageHeight-ogQRSize)/2)
+	draw.Draw(card, qr.Bounds().Add(offset), qr, image.Point{}, draw.Src)
+	return card, nil
+}
+
+// flavorColor picks a stable pastel colour for a flavor so every share card
+// of the same flavor looks alike.
+func flavorColor(flavor string) color.RGBA {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(flavor)))
+	sum := h.Sum32()
+	return color.RGBA{
+		R: 160 + uint8(sum)%96,
+		G: 160 + uint8(sum>>8)%96,
+		B: 160 + uint8(sum>>16)%96,
+		A: 255,
+	}
+}
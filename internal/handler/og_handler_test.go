@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCupcakeOG(t *testing.T) {
+	tests := []struct {
+		name                string
+		path                string
+		createCupcake       bool
+		expectedStatus      int
+		expectedContentType string
+		expectedError       string
+		validateBody        func(t *testing.T, body []byte)
+	}{
+		{
+			name:                "json by default",
+			path:                "/api/v1/cupcakes/1/og",
+			createCupcake:       true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json",
+			validateBody: func(t *testing.T, body []byte) {
+				var og map[string]interface{}
+				require.NoError(t, json.Unmarshal(body, &og))
+				require.Equal(t, "product", og["type"])
+				require.Equal(t, "Red Velvet", og["title"])
+				require.Equal(t, "Rich cocoa & cream cheese frosting", og["description"])
+				require.Equal(t, "http://shop.example.com/?cupcake=1", og["url"])
+				require.Equal(t, "http://shop.example.com/api/v1/cupcakes/1/og/image", og["image"])
+				require.EqualValues(t, 1200, og["image_width"])
+				require.EqualValues(t, 630, og["image_height"])
+			},
+		},
+		{
+			name:                "html share page",
+			path:                "/api/v1/cupcakes/1/og?format=html",
+			createCupcake:       true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/html; charset=utf-8",
+			validateBody: func(t *testing.T, body []byte) {
+				page := string(body)
+				require.Contains(t, page, `<meta property="og:title" content="Red Velvet">`)
+				require.Contains(t, page, `<meta property="og:description" content="Rich cocoa &amp; cream cheese frosting">`)
+				require.Contains(t, page, `<meta property="og:image" content="http://shop.example.com/api/v1/cupcakes/1/og/image">`)
+				require.NotContains(t, page, "<p>")
+			},
+		},
+		{
+			name:                "unsupported format returns 400",
+			path:                "/api/v1/cupcakes/1/og?format=xml",
+			createCupcake:       true,
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+			expectedError:       "format must be json or html",
+		},
+		{
+			name:                "non-existent cupcake returns 404",
+			path:                "/api/v1/cupcakes/9999/og",
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedError:       "cupcake not found",
+		},
+		{
+			name:                "invalid ID returns 400",
+			path:                "/api/v1/cupcakes/abc/og",
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+			expectedError:       "Invalid ID",
+		},
+		{
+			name:                "share image",
+			path:                "/api/v1/cupcakes/1/og/image",
+			createCupcake:       true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/png",
+			validateBody: func(t *testing.T, body []byte) {
+				img, err := png.Decode(bytes.NewReader(body))
+				require.NoError(t, err)
+				require.Equal(t, 1200, img.Bounds().Dx())
+				require.Equal(t, 630, img.Bounds().Dy())
+			},
+		},
+		{
+			name:                "share image for non-existent cupcake returns 404",
+			path:                "/api/v1/cupcakes/9999/og/image",
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedError:       "cupcake not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			if tt.createCupcake {
+				body := `{"name":"Red Velvet","flavor":"Cocoa","price_cents":1200,"description":"<p>Rich cocoa &amp; cream cheese frosting</p>"}`
+				req := httptest.NewRequest("POST", "/api/v1/cupcakes", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				require.Equal(t, http.StatusCreated, w.Code)
+			}
+
+			req := httptest.NewRequest("GET", "http://shop.example.com"+tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestOGDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		cupcake     models.Cupcake
+		expected    string
+		expectedLen int
+	}{
+		{
+			name:     "strips markup and collapses whitespace",
+			cupcake:  models.Cupcake{Description: "<p>Moist   crumb</p>\n<ul><li>vanilla</li></ul>"},
+			expected: "Moist crumb vanilla",
+		},
+		{
+			name:     "falls back to the flavor",
+			cupcake:  models.Cupcake{Flavor: "Lemon"},
+			expected: "Lemon cupcake",
+		},
+		{
+			name:        "truncates long descriptions",
+			cupcake:     models.Cupcake{Description: strings.Repeat("á", 500)},
+			expectedLen: maxOGDescriptionLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ogDescription(&tt.cupcake)
+			if tt.expectedLen > 0 {
+				require.Len(t, []rune(got), tt.expectedLen)
+				require.True(t, strings.HasSuffix(got, "…"))
+				return
+			}
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestFlavorColor(t *testing.T) {
+	require.Equal(t, flavorColor("Cocoa"), flavorColor("cocoa"))
+	require.NotEqual(t, flavorColor("Cocoa"), flavorColor("Lemon"))
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type PickupHandler struct {
+	service service.PickupServiceInterface
+}
+
+func NewPickupHandler(service service.PickupServiceInterface) *PickupHandler {
+	return &PickupHandler{service: service}
+}
+
+func (h *PickupHandler) CreateSlot(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CreatePickupSlotRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	slot, err := h.service.CreateSlot(uint(id), &req)
+	if err != nil {
+		sendPickupError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(slot)
+}
+
+func (h *PickupHandler) GetSlots(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	day, err := dateParam(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	slots, err := h.service.GetSlots(uint(id), day)
+	if err != nil {
+		sendPickupError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(slots)
+}
+
+func (h *PickupHandler) Reserve(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	slotID, err := strconv.ParseUint(chi.URLParam(r, "slotID"), 10, 32)
+	if err != nil || slotID == 0 {
+		sendJSONError(w, "Invalid slot ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ReservePickupRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	reservation, err := h.service.Reserve(uint(id), uint(slotID), &req)
+	if err != nil {
+		sendPickupError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(reservation)
+}
+
+func (h *PickupHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	day, err := dateParam(r.URL.Query())
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	schedule, err := h.service.GetSchedule(uint(id), day)
+	if err != nil {
+		sendPickupError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+// MarkReady tells the customer their pickup order is ready.
+func (h *PickupHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	reservation, err := h.service.MarkReady(uint(id))
+	if err != nil {
+		sendPickupError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// Start tells the kitchen queue the reservation's order is being made.
+func (h *PickupHandler) Start(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	reservation, err := h.service.Start(uint(id))
+	if err != nil {
+		sendPickupError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// Cancel cancels a pickup reservation whose order the kitchen has not
+// started.
+func (h *PickupHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.CancelPickupRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	reservation, err := h.service.Cancel(uint(id), &req)
+	if err != nil {
+		sendPickupError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reservation)
+}
+
+// sendPickupError answers 404 for a missing location, slot or reservation,
+// 409 for a full slot or a reservation already started, ready or
+// cancelled, and 400 for any other service error.
+func sendPickupError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrLocationNotFound), errors.Is(err, service.ErrSlotNotFound), errors.Is(err, service.ErrReservationNotFound):
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, service.ErrSlotFull), errors.Is(err, service.ErrReservationStarted), errors.Is(err, service.ErrReservationReady), errors.Is(err, service.ErrReservationCancelled):
+		sendLocalizedError(w, r, err, http.StatusConflict)
+	default:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+	}
+}
+
+// dateParam reads the optional date=YYYY-MM-DD query parameter in the
+// server's time zone; without it the current day is used.
+func dateParam(query url.Values) (time.Time, error) {
+	v := query.Get("date")
+	if v == "" {
+		return time.Now(), nil
+	}
+	day, err := time.ParseInLocation(time.DateOnly, v, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %s", v)
+	}
+	return day, nil
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+// newPickupTestRouter serves the pickup routes over a database holding one
+// location that stocks Vanilla (3) and has two slots tomorrow: slot 1 with
+// room for two pickups and slot 2 with room for one, already taken.
+func newPickupTestRouter(t *testing.T) (chi.Router, time.Time) {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	cupcake := factory.Cupcake(factory.WithName("Vanilla"))
+	require.NoError(t, cupcakeRepo.Create(&cupcake))
+	require.NoError(t, locationRepo.Create(&models.Location{Name: "Centro", Address: "Rua Augusta, 100"}))
+	require.NoError(t, locationRepo.SetStock(&models.LocationStock{LocationID: 1, CupcakeID: 1, Quantity: 3}))
+
+	svc := service.NewPickupService(repository.NewPickupRepository(db), service.NewLocationService(locationRepo, cupcakeRepo, repository.NewBundleRepository(db)), &mocks.EventPublisher{PublishFunc: func(string, interface{}) {}})
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, time.Local)
+	_, err := svc.CreateSlot(1, &models.CreatePickupSlotRequest{StartsAt: tomorrow, EndsAt: tomorrow.Add(time.Hour), Capacity: 2})
+	require.NoError(t, err)
+	_, err = svc.CreateSlot(1, &models.CreatePickupSlotRequest{StartsAt: tomorrow.Add(time.Hour), EndsAt: tomorrow.Add(2 * time.Hour), Capacity: 1})
+	require.NoError(t, err)
+	_, err = svc.Reserve(1, 2, &models.ReservePickupRequest{CustomerName: "Bia", CustomerEmail: "bia@example.com", Items: []models.PickupItem{{CupcakeID: 1, Quantity: 1}}})
+	require.NoError(t, err)
+
+	pickupHandler := NewPickupHandler(svc)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/locations/{id}/slots", pickupHandler.GetSlots)
+	r.Post("/api/v1/locations/{id}/slots/{slotID}/reservations", pickupHandler.Reserve)
+	r.Post("/api/v1/admin/locations/{id}/slots", pickupHandler.CreateSlot)
+	r.Get("/api/v1/admin/locations/{id}/pickups", pickupHandler.GetSchedule)
+	r.Post("/api/v1/admin/pickups/{id}/start", pickupHandler.Start)
+	r.Post("/api/v1/admin/pickups/{id}/ready", pickupHandler.MarkReady)
+	r.Post("/api/v1/admin/pickups/{id}/cancel", pickupHandler.Cancel)
+	return r, tomorrow
+}
+
+func TestReservePickupSlot(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		payload        string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "success",
+			path:           "/api/v1/locations/1/slots/1/reservations",
+			payload:        `{"customer_name":"Ana","customer_email":"ana@example.com","items":[{"cupcake_id":1,"quantity":2}]}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "slot full",
+			path:           "/api/v1/locations/1/slots/2/reservations",
+			payload:        `{"customer_name":"Ana","customer_email":"ana@example.com","items":[{"cupcake_id":1,"quantity":1}]}`,
+			expectedStatus: http.StatusConflict,
+			expectedError:  "pickup slot is full",
+		},
+		{
+			name:           "unknown slot",
+			path:           "/api/v1/locations/1/slots/999/reservations",
+			payload:        `{"customer_name":"Ana","customer_email":"ana@example.com","items":[{"cupcake_id":1,"quantity":1}]}`,
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "pickup slot not found",
+		},
+		{
+			name:           "not enough stock",
+			path:           "/api/v1/locations/1/slots/1/reservations",
+			payload:        `{"customer_name":"Ana","customer_email":"ana@example.com","items":[{"cupcake_id":1,"quantity":5}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "only 3 of Vanilla available at this location",
+		},
+		{
+			name:           "invalid slot ID",
+			path:           "/api/v1/locations/1/slots/abc/reservations",
+			payload:        `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid slot ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := newPickupTestRouter(t)
+
+			req := httptest.NewRequest("POST", tt.path, bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var reservation models.PickupReservation
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&reservation))
+			require.Equal(t, uint(1), reservation.SlotID)
+			require.Equal(t, "Ana", reservation.CustomerName)
+		})
+	}
+}
+
+func TestGetPickupSchedule(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedSlots  int
+		expectedError  string
+	}{
+		{name: "day with pickups", query: "?date=tomorrow", expectedStatus: http.StatusOK, expectedSlots: 2},
+		{name: "defaults to today", query: "", expectedStatus: http.StatusOK, expectedSlots: 0},
+		{name: "invalid date", query: "?date=16/10/2026", expectedStatus: http.StatusBadRequest, expectedError: "invalid date: 16/10/2026"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, tomorrow := newPickupTestRouter(t)
+
+			query := tt.query
+			if query == "?date=tomorrow" {
+				query = "?date=" + tomorrow.Format(time.DateOnly)
+			}
+			req := httptest.NewRequest("GET", "/api/v1/admin/locations/1/pickups"+query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var schedule []models.PickupSchedule
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&schedule))
+			require.Len(t, schedule, tt.expectedSlots)
+			if tt.expectedSlots > 0 {
+				require.Empty(t, schedule[0].Reservations)
+				require.Len(t, schedule[1].Reservations, 1)
+				require.Equal(t, "Bia", schedule[1].Reservations[0].CustomerName)
+			}
+		})
+	}
+}
+
+func TestCreatePickupSlot_UnknownLocation(t *testing.T) {
+	router, tomorrow := newPickupTestRouter(t)
+
+	payload := `{"starts_at":"` + tomorrow.Format(time.RFC3339) + `","ends_at":"` + tomorrow.Add(time.Hour).Format(time.RFC3339) + `","capacity":3}`
+	req := httptest.NewRequest("POST", "/api/v1/admin/locations/999/slots", bytes.NewBufferString(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "location not found")
+}
+
+func TestMarkPickupReady(t *testing.T) {
+	router, _ := newPickupTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/pickups/1/ready", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var reservation models.PickupReservation
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&reservation))
+	require.NotNil(t, reservation.ReadyAt)
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/pickups/999/ready", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "pickup reservation not found")
+}
+
+func TestCancelPickup(t *testing.T) {
+	router, _ := newPickupTestRouter(t)
+	post := func(path, payload string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, bytes.NewBufferString(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/api/v1/admin/pickups/1/cancel", `{"reason":"out_of_stock"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var reservation models.PickupReservation
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&reservation))
+	require.NotNil(t, reservation.CancelledAt)
+	require.Equal(t, models.CancellationOutOfStock, reservation.CancellationReason)
+
+	// The freed place in slot 2 can be booked again.
+	w = post("/api/v1/locations/1/slots/2/reservations", `{"customer_name":"Caio","customer_email":"caio@example.com","items":[{"cupcake_id":1,"quantity":1}]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = post("/api/v1/admin/pickups/1/ready", "")
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	w = post("/api/v1/admin/pickups/2/ready", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = post("/api/v1/admin/pickups/2/cancel", `{"reason":"no_show"}`)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Contains(t, w.Body.String(), "pickup order is already ready")
+
+	w = post("/api/v1/admin/pickups/1/cancel", `{"reason":"lost"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "reason must be")
+
+	w = post("/api/v1/admin/pickups/999/cancel", `{"reason":"other"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestStartPickup(t *testing.T) {
+	router, _ := newPickupTestRouter(t)
+	post := func(path, payload string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, bytes.NewBufferString(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/api/v1/admin/pickups/1/start", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var reservation models.PickupReservation
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&reservation))
+	require.NotNil(t, reservation.StartedAt)
+
+	w = post("/api/v1/admin/pickups/1/cancel", `{"reason":"out_of_stock"}`)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Contains(t, w.Body.String(), "pickup order is already being made")
+
+	w = post("/api/v1/admin/pickups/1/ready", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = post("/api/v1/admin/pickups/1/start", "")
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	w = post("/api/v1/admin/pickups/999/start", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
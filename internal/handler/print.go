@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type PrintHandler struct {
+	service service.PrintServiceInterface
+}
+
+func NewPrintHandler(service service.PrintServiceInterface) *PrintHandler {
+	return &PrintHandler{service: service}
+}
+
+// PrintTickets prints a pickup reservation's tickets again. The body is
+// optional; without it both tickets are printed.
+func (h *PrintHandler) PrintTickets(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.PrintTicketsRequest
+	if r.ContentLength != 0 && !decodeRequest(w, r, &req) {
+		return
+	}
+
+	tickets, err := h.service.Print(uint(id), req.Tickets)
+	switch {
+	case errors.Is(err, service.ErrReservationNotFound):
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	case errors.Is(err, service.ErrPrintingDisabled):
+		sendLocalizedError(w, r, err, http.StatusConflict)
+		return
+	case err != nil:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(models.PrintTicketsResponse{Tickets: tickets})
+}
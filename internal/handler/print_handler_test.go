@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintTickets(t *testing.T) {
+	var requested []string
+	handler := NewPrintHandler(&mocks.PrintService{
+		PrintFunc: func(reservationID uint, tickets []string) ([]string, error) {
+			switch reservationID {
+			case 2:
+				return nil, service.ErrPrintingDisabled
+			case 999:
+				return nil, service.ErrReservationNotFound
+			}
+			requested = tickets
+			if len(tickets) == 0 {
+				tickets = []string{"kitchen", "receipt"}
+			}
+			return tickets, nil
+		},
+	})
+	r := chi.NewRouter()
+	r.Post("/api/v1/admin/pickups/{id}/print", handler.PrintTickets)
+	post := func(path, payload string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, bytes.NewBufferString(payload))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/api/v1/admin/pickups/1/print", "")
+	require.Equal(t, http.StatusAccepted, w.Code, w.Body.String())
+	require.JSONEq(t, `{"tickets":["kitchen","receipt"]}`, w.Body.String())
+	require.Empty(t, requested)
+
+	w = post("/api/v1/admin/pickups/1/print", `{"tickets":["receipt"]}`)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, []string{"receipt"}, requested)
+
+	w = post("/api/v1/admin/pickups/2/print", "")
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Contains(t, w.Body.String(), "ticket printing is not configured")
+
+	w = post("/api/v1/admin/pickups/999/print", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = post("/api/v1/admin/pickups/1/print", `{"tickets":`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
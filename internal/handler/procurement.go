@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type ProcurementHandler struct {
+	service service.ProcurementServiceInterface
+}
+
+func NewProcurementHandler(service service.ProcurementServiceInterface) *ProcurementHandler {
+	return &ProcurementHandler{service: service}
+}
+
+func (h *ProcurementHandler) CreateSupplier(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateSupplierRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	supplier, err := h.service.CreateSupplier(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(supplier)
+}
+
+func (h *ProcurementHandler) GetSupplier(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	supplier, err := h.service.GetSupplier(uint(id))
+	if err != nil {
+		sendProcurementError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(supplier)
+}
+
+func (h *ProcurementHandler) GetAllSuppliers(w http.ResponseWriter, r *http.Request) {
+	suppliers, err := h.service.GetAllSuppliers()
+	if err != nil {
+		sendJSONError(w, "Error fetching suppliers", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suppliers)
+}
+
+func (h *ProcurementHandler) UpdateSupplier(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateSupplierRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	supplier, err := h.service.UpdateSupplier(uint(id), &req)
+	if err != nil {
+		sendProcurementError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(supplier)
+}
+
+func (h *ProcurementHandler) CreateIngredient(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateIngredientRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	ingredient, err := h.service.CreateIngredient(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ingredient)
+}
+
+func (h *ProcurementHandler) GetIngredient(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	ingredient, err := h.service.GetIngredient(uint(id))
+	if err != nil {
+		sendProcurementError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ingredient)
+}
+
+func (h *ProcurementHandler) GetAllIngredients(w http.ResponseWriter, r *http.Request) {
+	ingredients, err := h.service.GetAllIngredients()
+	if err != nil {
+		sendJSONError(w, "Error fetching ingredients", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ingredients)
+}
+
+func (h *ProcurementHandler) UpdateIngredient(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateIngredientRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	ingredient, err := h.service.UpdateIngredient(uint(id), &req)
+	if err != nil {
+		sendProcurementError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ingredient)
+}
+
+func (h *ProcurementHandler) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	var req models.CreatePurchaseOrderRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	order, err := h.service.CreatePurchaseOrder(&req)
+	if err != nil {
+		sendProcurementError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
+
+func (h *ProcurementHandler) GetPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.service.GetPurchaseOrder(uint(id))
+	if err != nil {
+		sendProcurementError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
+
+func (h *ProcurementHandler) GetPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	orders, err := h.service.GetPurchaseOrders(r.URL.Query().Get("status"))
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(orders)
+}
+
+// ReceivePurchaseOrder records that the supplier delivered an order and
+// moves its ingredients into stock.
+func (h *ProcurementHandler) ReceivePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.service.ReceivePurchaseOrder(uint(id))
+	if err != nil {
+		sendProcurementError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
+
+func (h *ProcurementHandler) CancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.service.CancelPurchaseOrder(uint(id))
+	if err != nil {
+		sendProcurementError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
+
+func sendProcurementError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrSupplierNotFound),
+		errors.Is(err, service.ErrIngredientNotFound),
+		errors.Is(err, service.ErrPurchaseOrderNotFound):
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, service.ErrPurchaseOrderNotOpen):
+		sendLocalizedError(w, r, err, http.StatusConflict)
+	default:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newProcurementTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	procurementHandler := NewProcurementHandler(service.NewProcurementService(repository.NewSupplierRepository(db), repository.NewIngredientRepository(db), repository.NewPurchaseOrderRepository(db)))
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/admin/suppliers", procurementHandler.CreateSupplier)
+	r.Post("/api/v1/admin/ingredients", procurementHandler.CreateIngredient)
+	r.Get("/api/v1/admin/ingredients/{id}", procurementHandler.GetIngredient)
+	r.Get("/api/v1/admin/purchase-orders", procurementHandler.GetPurchaseOrders)
+	r.Post("/api/v1/admin/purchase-orders", procurementHandler.CreatePurchaseOrder)
+	r.Post("/api/v1/admin/purchase-orders/{id}/receive", procurementHandler.ReceivePurchaseOrder)
+	return r
+}
+
+func TestPurchaseOrderReceivingFlow(t *testing.T) {
+	router := newProcurementTestRouter(t)
+
+	for path, payload := range map[string]string{
+		"/api/v1/admin/suppliers":   `{"name":"Moinho Paulista"}`,
+		"/api/v1/admin/ingredients": `{"name":"Flour","unit":"g"}`,
+	} {
+		req := httptest.NewRequest("POST", path, bytes.NewBufferString(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/purchase-orders", bytes.NewBufferString(`{"supplier_id":1,"lines":[{"ingredient_id":1,"quantity":5000,"unit_cost_cents":1}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/purchase-orders/1/receive", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/purchase-orders/1/receive", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/ingredients/1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var flour models.Ingredient
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&flour))
+	require.Equal(t, 5000, flour.StockQuantity)
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/purchase-orders?status=received", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var orders []models.PurchaseOrder
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&orders))
+	require.Len(t, orders, 1)
+}
+
+func TestCreatePurchaseOrder_UnknownSupplier(t *testing.T) {
+	router := newProcurementTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/purchase-orders", bytes.NewBufferString(`{"supplier_id":9,"lines":[{"ingredient_id":1,"quantity":1}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "supplier not found")
+}
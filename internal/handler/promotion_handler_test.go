@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newPromotionTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	cupcakeHandler := NewCupcakeHandler(service.NewCupcakeService(cupcakeRepo, promotionRepo, nil, nil, nil, nil, nil))
+	promotionHandler := NewPromotionHandler(service.NewPromotionService(promotionRepo, cupcakeRepo))
+	r := chi.NewRouter()
+
+	r.Post("/api/v1/cupcakes", cupcakeHandler.CreateCupcake)
+	r.Get("/api/v1/cupcakes/{id}", cupcakeHandler.GetCupcake)
+	r.Route("/api/v1/admin/promotions", func(r chi.Router) {
+		r.Post("/", promotionHandler.CreatePromotion)
+		r.Get("/", promotionHandler.GetAllPromotions)
+		r.Get("/{id}", promotionHandler.GetPromotion)
+		r.Put("/{id}", promotionHandler.UpdatePromotion)
+		r.Delete("/{id}", promotionHandler.DeletePromotion)
+	})
+
+	return r
+}
+
+func TestCreatePromotion(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name             string
+		discountValue    int
+		startsAt         time.Time
+		endsAt           time.Time
+		expectedStatus   int
+		expectedError    string
+		expectedEffPrice *int
+	}{
+		{
+			name:             "active promotion shows effective price",
+			discountValue:    20,
+			startsAt:         now.Add(-time.Hour),
+			endsAt:           now.Add(time.Hour),
+			expectedStatus:   http.StatusCreated,
+			expectedEffPrice: intPtr(1200),
+		},
+		{
+			name:           "scheduled promotion keeps original price",
+			discountValue:  20,
+			startsAt:       now.Add(24 * time.Hour),
+			endsAt:         now.Add(48 * time.Hour),
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid window returns 400",
+			discountValue:  20,
+			startsAt:       now,
+			endsAt:         now.Add(-time.Hour),
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "promotion must end after it starts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newPromotionTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/cupcakes", bytes.NewBufferString(`{"name":"Lemon","flavor":"Citrus","price_cents":1500}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			jsonBody, err := json.Marshal(map[string]interface{}{
+				"cupcake_id":     1,
+				"discount_type":  "percentage",
+				"discount_value": tt.discountValue,
+				"starts_at":      tt.startsAt,
+				"ends_at":        tt.endsAt,
+			})
+			require.NoError(t, err)
+
+			req = httptest.NewRequest("POST", "/api/v1/admin/promotions", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			req = httptest.NewRequest("GET", fmt.Sprintf("/api/v1/cupcakes/%d", 1), nil)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var cupcake models.Cupcake
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &cupcake))
+			require.Equal(t, 1500, cupcake.PriceCents)
+			require.Equal(t, tt.expectedEffPrice, cupcake.EffectivePriceCents)
+		})
+	}
+}
+
+func TestGetPromotion_NotFound(t *testing.T) {
+	router := newPromotionTestRouter(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/admin/promotions/42", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "promotion not found")
+}
+
+func intPtr(i int) *int {
+	return &i
+}
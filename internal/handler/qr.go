@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	defaultQRSize = 256
+	minQRSize     = 64
+	maxQRSize     = 1024
+)
+
+func (h *CupcakeHandler) GetCupcakeQR(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.service.GetCupcake(uint(id)); err != nil {
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+		return
+	}
+
+	size, err := parseQRSize(r.URL.Query().Get("size"))
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	link := fmt.Sprintf("%s/?cupcake=%d", storeURL(r), id)
+	writeQRCode(w, link, r.URL.Query().Get("format"), size)
+}
+
+func writeQRCode(w http.ResponseWriter, content, format string, size int) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		sendJSONError(w, "Error generating QR code", http.StatusInternalServerError)
+		return
+	}
+
+	switch format {
+	case "", "png":
+		png, err := code.PNG(size)
+		if err != nil {
+			sendJSONError(w, "Error generating QR code", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(png)
+	case "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Write([]byte(qrSVG(code.Bitmap(), size)))
+	default:
+		sendJSONError(w, "format must be png or svg", http.StatusBadRequest)
+	}
+}
+
+func parseQRSize(raw string) (int, error) {
+	if raw == "" {
+		return defaultQRSize, nil
+	}
+	size, err := strconv.Atoi(raw)
+	if err != nil || size < minQRSize || size > maxQRSize {
+		return 0, errors.New("size must be between 64 and 1024")
+	}
+	return size, nil
+}
+
+type storeURLContextKey struct{}
+
+// WithStoreURL records the store's public base URL for a request, as the
+// router works it out from PUBLIC_URL or a trusted proxy's headers.
+func WithStoreURL(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, storeURLContextKey{}, url)
+}
+
+// storeURL is the public base URL recorded for the request or, without
+// one, the scheme and host it arrived on. Forwarded headers are not read
+// here: only the router knows which proxies to believe.
+func storeURL(r *http.Request) string {
+	if url, ok := r.Context().Value(storeURLContextKey{}).(string); ok {
+		return url
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func qrSVG(bitmap [][]bool, size int) string {
+	var path strings.Builder
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	return fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+
+			`<rect width="100%%" height="100%%" fill="#fff"/><path fill="#000" d="%s"/></svg>`,
+		size, size, len(bitmap), len(bitmap), path.String(),
+	)
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCupcakeQR(t *testing.T) {
+	tests := []struct {
+		name                string
+		path                string
+		createCupcake       bool
+		expectedStatus      int
+		expectedContentType string
+		expectedError       string
+		validateBody        func(t *testing.T, body []byte)
+	}{
+		{
+			name:                "png by default",
+			path:                "/api/v1/cupcakes/1/qr",
+			createCupcake:       true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/png",
+			validateBody: func(t *testing.T, body []byte) {
+				img, err := png.Decode(bytes.NewReader(body))
+				require.NoError(t, err)
+				require.Equal(t, 256, img.Bounds().Dx())
+			},
+		},
+		{
+			name:                "png with custom size",
+			path:                "/api/v1/cupcakes/1/qr?size=128",
+			createCupcake:       true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/png",
+			validateBody: func(t *testing.T, body []byte) {
+				img, err := png.Decode(bytes.NewReader(body))
+				require.NoError(t, err)
+				require.Equal(t, 128, img.Bounds().Dx())
+			},
+		},
+		{
+			name:                "svg format",
+			path:                "/api/v1/cupcakes/1/qr?format=svg",
+			createCupcake:       true,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/svg+xml",
+			validateBody: func(t *testing.T, body []byte) {
+				require.True(t, strings.HasPrefix(string(body), "<svg"))
+				require.Contains(t, string(body), `width="256"`)
+			},
+		},
+		{
+			name:                "unsupported format returns 400",
+			path:                "/api/v1/cupcakes/1/qr?format=gif",
+			createCupcake:       true,
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+			expectedError:       "format must be png or svg",
+		},
+		{
+			name:                "size out of range returns 400",
+			path:                "/api/v1/cupcakes/1/qr?size=4096",
+			createCupcake:       true,
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+			expectedError:       "size must be between 64 and 1024",
+		},
+		{
+			name:                "non-existent cupcake returns 404",
+			path:                "/api/v1/cupcakes/9999/qr",
+			expectedStatus:      http.StatusNotFound,
+			expectedContentType: "application/json",
+			expectedError:       "cupcake not found",
+		},
+		{
+			name:                "invalid ID returns 400",
+			path:                "/api/v1/cupcakes/abc/qr",
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json",
+			expectedError:       "Invalid ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(t)
+
+			if tt.createCupcake {
+				body := `{"name":"Red Velvet","flavor":"Cocoa","price_cents":1200}`
+				req := httptest.NewRequest("POST", "/api/v1/cupcakes", strings.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				require.Equal(t, http.StatusCreated, w.Code)
+			}
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestStoreURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		recorded       string
+		forwardedProto string
+		expected       string
+	}{
+		{name: "plain http", expected: "http://shop.example.com"},
+		{name: "forwarded proto is left to the router", forwardedProto: "https", expected: "http://shop.example.com"},
+		{name: "recorded URL wins", recorded: "https://loja.example.com", expected: "https://loja.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "http://shop.example.com/api/v1/cupcakes/1/qr", nil)
+			if tt.forwardedProto != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.forwardedProto)
+			}
+			if tt.recorded != "" {
+				req = req.WithContext(WithStoreURL(req.Context(), tt.recorded))
+			}
+
+			require.Equal(t, tt.expected, storeURL(req))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type RecipeHandler struct {
+	service service.RecipeServiceInterface
+}
+
+func NewRecipeHandler(service service.RecipeServiceInterface) *RecipeHandler {
+	return &RecipeHandler{service: service}
+}
+
+func (h *RecipeHandler) GetRecipe(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	recipe, err := h.service.GetRecipe(uint(id))
+	if err != nil {
+		sendRecipeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recipe)
+}
+
+func (h *RecipeHandler) SetRecipe(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetRecipeRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	recipe, err := h.service.SetRecipe(uint(id), &req)
+	if err != nil {
+		sendRecipeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recipe)
+}
+
+// RecordProduction records a baked batch and takes its ingredients out of
+// stock.
+func (h *RecipeHandler) RecordProduction(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.RecordProductionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	batch, err := h.service.RecordProduction(uint(id), &req)
+	if err != nil {
+		sendRecipeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(batch)
+}
+
+func (h *RecipeHandler) GetProductionBatches(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	batches, err := h.service.GetProductionBatches(uint(id))
+	if err != nil {
+		sendRecipeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(batches)
+}
+
+func sendRecipeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, service.ErrRecipeCupcakeNotFound), errors.Is(err, service.ErrRecipeNotFound):
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+	case errors.Is(err, service.ErrInsufficientIngredients):
+		sendLocalizedError(w, r, err, http.StatusConflict)
+	default:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+	}
+}
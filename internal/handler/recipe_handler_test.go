@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipeProductionFlow(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	vanilla := factory.Cupcake(factory.WithName("Vanilla"))
+	require.NoError(t, cupcakeRepo.Create(&vanilla))
+	ingredientRepo := repository.NewIngredientRepository(db)
+	require.NoError(t, ingredientRepo.Create(&models.Ingredient{Name: "Flour", Unit: "g", StockQuantity: 120, UnitCostCents: 1}))
+
+	recipeHandler := NewRecipeHandler(service.NewRecipeService(repository.NewRecipeRepository(db), ingredientRepo, cupcakeRepo))
+	router := chi.NewRouter()
+	router.Get("/api/v1/admin/cupcakes/{id}/recipe", recipeHandler.GetRecipe)
+	router.Put("/api/v1/admin/cupcakes/{id}/recipe", recipeHandler.SetRecipe)
+	router.Post("/api/v1/admin/cupcakes/{id}/production", recipeHandler.RecordProduction)
+
+	req := httptest.NewRequest("GET", "/api/v1/admin/cupcakes/1/recipe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest("PUT", "/api/v1/admin/cupcakes/1/recipe", bytes.NewBufferString(`{"ingredients":[{"ingredient_id":1,"quantity":50}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var recipe models.Recipe
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&recipe))
+	require.Equal(t, 50, recipe.CostCents)
+	require.Equal(t, 2, recipe.MaxProducible)
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/cupcakes/1/production", bytes.NewBufferString(`{"quantity":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/cupcakes/1/production", bytes.NewBufferString(`{"quantity":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Contains(t, w.Body.String(), "at most 0 can be made")
+}
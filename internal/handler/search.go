@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type SearchHandler struct {
+	service service.SearchServiceInterface
+}
+
+func NewSearchHandler(service service.SearchServiceInterface) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search serves ?q= with the facets listed in ?facet= (repeatable) and at
+// most ?limit= hits.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	query := models.SearchQuery{
+		Text:   params.Get("q"),
+		Facets: params["facet"],
+		Limit:  service.DefaultSearchLimit,
+	}
+	if v := params.Get("limit"); v != "" {
+		var err error
+		if query.Limit, err = strconv.Atoi(v); err != nil {
+			sendJSONError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := h.service.Search(query)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.SearchResponse{
+		Query:   query.Text,
+		Backend: result.Backend,
+		Total:   result.Total,
+		Hits:    models.NewCupcakeResponses(result.Hits),
+		Facets:  result.Facets,
+	})
+}
+
+func (h *SearchHandler) Reindex(w http.ResponseWriter, r *http.Request) {
+	indexed, err := h.service.Reindex()
+	if errors.Is(err, service.ErrSearchIndexDisabled) {
+		sendLocalizedError(w, r, err, http.StatusConflict)
+		return
+	}
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"indexed": indexed})
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func newSearchTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Chocolate Fudge"), factory.WithFlavor("Chocolate")),
+		factory.Cupcake(factory.WithName("Vanilla"), factory.WithFlavor("Vanilla")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+
+	searchHandler := NewSearchHandler(service.NewSearchService(nil, cupcakeRepo, repository.NewPromotionRepository(db)))
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/search", searchHandler.Search)
+	r.Post("/api/v1/admin/search/reindex", searchHandler.Reindex)
+	return r
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedError  string
+		validateBody   func(t *testing.T, resp models.SearchResponse)
+	}{
+		{
+			name:           "search with facet",
+			path:           "/api/v1/search?q=choc&facet=flavor",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, resp models.SearchResponse) {
+				require.Equal(t, "choc", resp.Query)
+				require.Equal(t, "sql", resp.Backend)
+				require.Equal(t, 1, resp.Total)
+				require.Len(t, resp.Hits, 1)
+				require.Equal(t, "Chocolate Fudge", resp.Hits[0].Name)
+				require.Equal(t, []models.FacetCount{{Value: "Chocolate", Count: 1}}, resp.Facets["flavor"])
+			},
+		},
+		{
+			name:           "empty query lists the catalog",
+			path:           "/api/v1/search",
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, resp models.SearchResponse) {
+				require.Equal(t, 2, resp.Total)
+				require.Nil(t, resp.Facets)
+			},
+		},
+		{name: "unsupported facet", path: "/api/v1/search?q=choc&facet=price", expectedStatus: http.StatusBadRequest, expectedError: "unsupported facet: price"},
+		{name: "invalid limit", path: "/api/v1/search?limit=abc", expectedStatus: http.StatusBadRequest, expectedError: "Invalid limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newSearchTestRouter(t)
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+			if tt.validateBody != nil {
+				var resp models.SearchResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				tt.validateBody(t, resp)
+			}
+		})
+	}
+}
+
+func TestReindexWithoutIndex(t *testing.T) {
+	router := newSearchTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/search/reindex", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Contains(t, w.Body.String(), "search index is not configured")
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+const (
+	// sessionCookie holds the web app's session token. It has no expiry
+	// of its own: the session ends when the browser closes or when it
+	// expires on the server, whichever comes first.
+	sessionCookie = "session"
+	// csrfHeader carries the session's CSRF token on requests that change
+	// something.
+	csrfHeader = "X-CSRF-Token"
+)
+
+type SessionHandler struct {
+	service service.SessionServiceInterface
+}
+
+func NewSessionHandler(service service.SessionServiceInterface) *SessionHandler {
+	return &SessionHandler{service: service}
+}
+
+// Login checks the credentials and starts a session in the cookie. The
+// body has the account and the CSRF token, not the session token.
+func (h *SessionHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req models.LoginRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	session, err := h.service.Login(&req)
+	if err != nil {
+		sendAuthError(w, r, err)
+		return
+	}
+
+	setSessionCookie(w, r, session.Token)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// Get returns the session of the cookie, for the web app to learn on load
+// whether it is logged in and which CSRF token to send.
+func (h *SessionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil {
+		sendJSONError(w, service.ErrSessionInvalid.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	session, err := h.service.Get(cookie.Value)
+	if errors.Is(err, service.ErrSessionInvalid) {
+		setSessionCookie(w, r, "")
+		sendJSONError(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		sendJSONError(w, "Error loading session", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// Logout ends the session of the cookie and clears it.
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		if err := h.service.Logout(cookie.Value); err != nil {
+			sendJSONError(w, "Error ending session", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	setSessionCookie(w, r, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Authenticate lets requests made with the session cookie reach the
+// endpoints that take bearer tokens, by giving them an access token of the
+// session's account. Those that change something must also carry the
+// session's CSRF token, or get 403. An unknown or expired session is
+// cleared and the request goes on without one; requests with their own
+// Authorization header are left alone.
+func (h *SessionHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(sessionCookie)
+		if err != nil || cookie.Value == "" || r.Header.Get("Authorization") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, err := h.service.AccessToken(cookie.Value)
+		if errors.Is(err, service.ErrSessionInvalid) {
+			setSessionCookie(w, r, "")
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err != nil {
+			sendJSONError(w, "Error loading session", http.StatusInternalServerError)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if !h.service.ValidCSRF(cookie.Value, r.Header.Get(csrfHeader)) {
+				sendJSONError(w, "Missing or invalid CSRF token", http.StatusForbidden)
+				return
+			}
+		}
+
+		r = r.Clone(r.Context())
+		r.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// setSessionCookie stores token in the browser, or clears it when empty.
+func setSessionCookie(w http.ResponseWriter, r *http.Request, token string) {
+	cookie := &http.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(storeURL(r), "https://"),
+		SameSite: http.SameSiteLaxMode,
+	}
+	if token == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+}
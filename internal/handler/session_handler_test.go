@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession_Authenticate(t *testing.T) {
+	svc := &mocks.SessionService{
+		AccessTokenFunc: func(token string) (*models.AccessToken, error) {
+			if token != "live" {
+				return nil, service.ErrSessionInvalid
+			}
+			return &models.AccessToken{AccessToken: "jwt"}, nil
+		},
+		ValidCSRFFunc: func(token, csrfToken string) bool {
+			return csrfToken == "csrf"
+		},
+	}
+	var authorization string
+	handler := NewSessionHandler(svc).Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	serve := func(method, session, header string) *httptest.ResponseRecorder {
+		authorization = ""
+		req := httptest.NewRequest(method, "/api/v1/auth/me", nil)
+		if session != "" {
+			req.AddCookie(&http.Cookie{Name: sessionCookie, Value: session})
+		}
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	serve("GET", "live", "")
+	require.Equal(t, "Bearer jwt", authorization)
+
+	// A bearer token of its own wins over the cookie.
+	serve("POST", "live", "Bearer mine")
+	require.Equal(t, "Bearer mine", authorization)
+
+	// A stale cookie is cleared and the request goes on anonymous.
+	w := serve("POST", "expired", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, authorization)
+	require.Equal(t, -1, w.Result().Cookies()[0].MaxAge)
+
+	w = serve("DELETE", "live", "")
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type SettingsHandler struct {
+	service *service.SettingsService
+}
+
+func NewSettingsHandler(service *service.SettingsService) *SettingsHandler {
+	return &SettingsHandler{service: service}
+}
+
+func (h *SettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.service.List()
+	if err != nil {
+		sendJSONError(w, "Error fetching settings", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// GetPublicSettings serves the values the storefront may read, such as the
+// free delivery threshold and feature flags.
+func (h *SettingsHandler) GetPublicSettings(w http.ResponseWriter, r *http.Request) {
+	values, err := h.service.PublicValues()
+	if err != nil {
+		sendJSONError(w, "Error fetching settings", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(values)
+}
+
+func (h *SettingsHandler) GetSetting(w http.ResponseWriter, r *http.Request) {
+	setting, err := h.service.Get(chi.URLParam(r, "key"))
+	if err != nil {
+		sendSettingError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(setting)
+}
+
+func (h *SettingsHandler) SetSetting(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateSettingRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	setting, err := h.service.Set(chi.URLParam(r, "key"), &req)
+	if err != nil {
+		sendSettingError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(setting)
+}
+
+// ResetSetting brings a setting back to its default.
+func (h *SettingsHandler) ResetSetting(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Reset(chi.URLParam(r, "key")); err != nil {
+		sendSettingError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sendSettingError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrSettingNotFound) {
+		sendJSONError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newSettingsTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	maintenance := service.NewMaintenanceService(false, false, time.Minute)
+	handler := NewSettingsHandler(service.NewSettingsService(repository.NewSettingRepository(setupTestDB(t)), maintenance, nil))
+	r := chi.NewRouter()
+	r.Get("/api/v1/settings", handler.GetPublicSettings)
+	r.Route("/api/v1/admin/settings", func(r chi.Router) {
+		r.Get("/", handler.GetSettings)
+		r.Get("/{key}", handler.GetSetting)
+		r.Put("/{key}", handler.SetSetting)
+		r.Delete("/{key}", handler.ResetSetting)
+	})
+	return r
+}
+
+func TestSetSetting(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		payload        string
+		expectedStatus int
+		expectedError  string
+	}{
+		{name: "valid value returns 200", key: "free_delivery_threshold_cents", payload: `{"value":5000}`, expectedStatus: http.StatusOK},
+		{name: "new feature flag returns 200", key: "feature.gift_wrap", payload: `{"value":true}`, expectedStatus: http.StatusOK},
+		{name: "invalid value returns 400", key: "maintenance_mode", payload: `{"value":"on"}`, expectedStatus: http.StatusBadRequest, expectedError: "maintenance_mode must be true or false"},
+		{name: "missing value returns 400", key: "maintenance_mode", payload: `{}`, expectedStatus: http.StatusBadRequest, expectedError: "value is required"},
+		{name: "unknown key returns 404", key: "colour", payload: `{"value":1}`, expectedStatus: http.StatusNotFound, expectedError: "setting not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newSettingsTestRouter(t)
+
+			req := httptest.NewRequest("PUT", "/api/v1/admin/settings/"+tt.key, bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+				return
+			}
+
+			var setting models.SettingResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &setting))
+			require.Equal(t, tt.key, setting.Key)
+		})
+	}
+}
+
+func TestSettingsLifecycle(t *testing.T) {
+	router := newSettingsTestRouter(t)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, send("PUT", "/api/v1/admin/settings/feature.gift_wrap", `{"value":true}`).Code)
+	require.Equal(t, http.StatusOK, send("PUT", "/api/v1/admin/settings/maintenance_mode", `{"value":true}`).Code)
+
+	w := send("GET", "/api/v1/admin/settings", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var settings []models.SettingResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &settings))
+	require.Len(t, settings, 3)
+
+	w = send("GET", "/api/v1/settings", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"feature.gift_wrap":true,"free_delivery_threshold_cents":0}`, w.Body.String())
+
+	require.Equal(t, http.StatusNoContent, send("DELETE", "/api/v1/admin/settings/maintenance_mode", "").Code)
+	w = send("GET", "/api/v1/admin/settings/maintenance_mode", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var setting models.SettingResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &setting))
+	require.JSONEq(t, "false", string(setting.Value))
+	require.Nil(t, setting.UpdatedAt)
+
+	require.Equal(t, http.StatusNotFound, send("GET", "/api/v1/admin/settings/colour", "").Code)
+}
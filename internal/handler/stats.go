@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type StatsHandler struct {
+	service service.StatsServiceInterface
+}
+
+func NewStatsHandler(service service.StatsServiceInterface) *StatsHandler {
+	return &StatsHandler{service: service}
+}
+
+// TopCupcakes ranks the best sellers from ?from= to ?to=, the last 30 days
+// by default, optionally of a single ?flavor= and at most ?limit= of them.
+func (h *StatsHandler) TopCupcakes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	to := time.Now()
+	if v := query.Get("to"); v != "" {
+		var err error
+		if to, err = time.ParseInLocation(time.DateOnly, v, time.Local); err != nil {
+			sendJSONError(w, "Invalid to date", http.StatusBadRequest)
+			return
+		}
+	}
+	from := to.AddDate(0, 0, 1-service.DefaultTopCupcakesDays)
+	if v := query.Get("from"); v != "" {
+		var err error
+		if from, err = time.ParseInLocation(time.DateOnly, v, time.Local); err != nil {
+			sendJSONError(w, "Invalid from date", http.StatusBadRequest)
+			return
+		}
+	}
+	limit := service.DefaultTopCupcakesLimit
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			sendJSONError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	top, err := h.service.TopCupcakes(from, to, query.Get("flavor"), limit)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(top)
+}
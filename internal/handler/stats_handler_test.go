@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopCupcakes(t *testing.T) {
+	type call struct {
+		from, to time.Time
+		flavor   string
+		limit    int
+	}
+	var got call
+	handler := NewStatsHandler(&mocks.StatsService{
+		TopCupcakesFunc: func(from, to time.Time, flavor string, limit int) (*models.TopCupcakes, error) {
+			got = call{from, to, flavor, limit}
+			return &models.TopCupcakes{
+				From:     from.Format(time.DateOnly),
+				To:       to.Format(time.DateOnly),
+				Cupcakes: []models.CupcakeSales{{CupcakeID: 1, Name: "Vanilla", Flavor: "Vanilla", Quantity: 3, Orders: 2}},
+			}, nil
+		},
+	})
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.TopCupcakes(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	w := get("/api/v1/admin/stats/top-cupcakes?from=2026-10-01&to=2026-10-15&flavor=Vanilla&limit=5")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.JSONEq(t, `{"from":"2026-10-01","to":"2026-10-15","cupcakes":[{"cupcake_id":1,"name":"Vanilla","flavor":"Vanilla","quantity":3,"orders":2}]}`, w.Body.String())
+	require.Equal(t, call{
+		from:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local),
+		to:     time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local),
+		flavor: "Vanilla",
+		limit:  5,
+	}, got)
+
+	w = get("/api/v1/admin/stats/top-cupcakes?to=2026-10-30")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local), got.from)
+	require.Equal(t, service.DefaultTopCupcakesLimit, got.limit)
+
+	for _, query := range []string{"from=yesterday", "to=2026-13-01", "limit=ten"} {
+		w = get("/api/v1/admin/stats/top-cupcakes?" + query)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
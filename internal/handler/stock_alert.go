@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type StockAlertHandler struct {
+	service service.StockAlertServiceInterface
+}
+
+func NewStockAlertHandler(service service.StockAlertServiceInterface) *StockAlertHandler {
+	return &StockAlertHandler{service: service}
+}
+
+// GetAlerts lists the low-stock alerts, optionally those with ?status=.
+func (h *StockAlertHandler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	alerts, err := h.service.GetAlerts(r.URL.Query().Get("status"))
+	if err != nil {
+		sendStockAlertError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(alerts)
+}
+
+func (h *StockAlertHandler) AcknowledgeAlert(w http.ResponseWriter, r *http.Request) {
+	h.update(w, r, h.service.AcknowledgeAlert)
+}
+
+func (h *StockAlertHandler) ResolveAlert(w http.ResponseWriter, r *http.Request) {
+	h.update(w, r, h.service.ResolveAlert)
+}
+
+func (h *StockAlertHandler) update(w http.ResponseWriter, r *http.Request, fn func(id uint) (*models.StockAlert, error)) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	alert, err := fn(uint(id))
+	if err != nil {
+		sendStockAlertError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(alert)
+}
+
+func sendStockAlertError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrStockAlertNotFound) {
+		sendJSONError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
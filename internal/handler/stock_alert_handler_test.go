@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockAlertHandler(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewStockAlertRepository(db)
+	require.NoError(t, repo.Create(&models.StockAlert{LocationID: 1, CupcakeID: 1, Quantity: 2, Threshold: 5, Status: models.StockAlertOpen}))
+
+	handler := NewStockAlertHandler(service.NewStockAlertService(repo, repository.NewLocationRepository(db), repository.NewCupcakeRepository(db), nil))
+	router := chi.NewRouter()
+	router.Route("/api/v1/admin/alerts", func(r chi.Router) {
+		r.Get("/", handler.GetAlerts)
+		r.Post("/{id}/acknowledge", handler.AcknowledgeAlert)
+		r.Post("/{id}/resolve", handler.ResolveAlert)
+	})
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	w := serve("GET", "/api/v1/admin/alerts?status=open")
+	require.Equal(t, http.StatusOK, w.Code)
+	var alerts []models.StockAlert
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&alerts))
+	require.Len(t, alerts, 1)
+	w = serve("GET", "/api/v1/admin/alerts?status=closed")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve("POST", "/api/v1/admin/alerts/1/acknowledge")
+	require.Equal(t, http.StatusOK, w.Code)
+	var alert models.StockAlert
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&alert))
+	require.Equal(t, models.StockAlertAcknowledged, alert.Status)
+
+	w = serve("POST", "/api/v1/admin/alerts/1/resolve")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serve("POST", "/api/v1/admin/alerts/1/acknowledge")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "the alert is already resolved")
+
+	w = serve("POST", "/api/v1/admin/alerts/99/resolve")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serve("POST", "/api/v1/admin/alerts/abc/resolve")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
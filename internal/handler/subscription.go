@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// SubscriptionHandler serves customers' subscriptions. Past Subscribe,
+// every request must prove ownership with the X-Subscription-Token header
+// returned by Subscribe or the bearer token of the subscriber's account.
+type SubscriptionHandler struct {
+	service  service.SubscriptionServiceInterface
+	accounts service.AccountServiceInterface
+}
+
+func NewSubscriptionHandler(service service.SubscriptionServiceInterface, accounts service.AccountServiceInterface) *SubscriptionHandler {
+	return &SubscriptionHandler{service: service, accounts: accounts}
+}
+
+func (h *SubscriptionHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateSubscriptionRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	subscription, err := h.service.Subscribe(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(subscription)
+}
+
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	subscription, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscription)
+}
+
+func (h *SubscriptionHandler) GetAllSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.service.GetAllSubscriptions()
+	if err != nil {
+		sendJSONError(w, "Error fetching subscriptions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscriptions)
+}
+
+func (h *SubscriptionHandler) PauseSubscription(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.Pause)
+}
+
+func (h *SubscriptionHandler) ResumeSubscription(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.Resume)
+}
+
+func (h *SubscriptionHandler) CancelSubscription(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.service.Cancel)
+}
+
+func (h *SubscriptionHandler) changeStatus(w http.ResponseWriter, r *http.Request, change func(id uint) (*models.Subscription, error)) {
+	owned, ok := h.authorize(w, r)
+	if !ok {
+		return
+	}
+
+	subscription, err := change(owned.ID)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscription)
+}
+
+// authorize returns the subscription in the URL when the caller owns it,
+// answering 404 for those they do not so IDs cannot be probed.
+func (h *SubscriptionHandler) authorize(w http.ResponseWriter, r *http.Request) (*models.Subscription, bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	managementToken := r.Header.Get("X-Subscription-Token")
+	var account *models.Account
+	if managementToken == "" {
+		token, ok := bearerToken(w, r)
+		if !ok {
+			return nil, false
+		}
+		if account, err = h.accounts.Authenticate(token); err != nil {
+			sendAuthError(w, r, err)
+			return nil, false
+		}
+	}
+
+	subscription, err := h.service.Authorize(uint(id), managementToken, account)
+	if errors.Is(err, service.ErrSubscriptionNotFound) {
+		sendJSONError(w, err.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	if err != nil {
+		sendJSONError(w, "Error fetching subscription", http.StatusInternalServerError)
+		return nil, false
+	}
+	return subscription, true
+}
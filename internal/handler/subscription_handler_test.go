@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newSubscriptionTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	require.NoError(t, cupcakeRepo.Create(&models.Cupcake{Name: "Box Cupcake", Flavor: "Vanilla", PriceCents: 900}))
+
+	verified := time.Now()
+	accounts := &mocks.AccountService{AuthenticateFunc: func(token string) (*models.Account, error) {
+		switch token {
+		case "ana-token":
+			return &models.Account{ID: 1, Email: "Ana@example.com", EmailVerifiedAt: &verified}, nil
+		case "bia-token":
+			return &models.Account{ID: 2, Email: "bia@example.com", EmailVerifiedAt: &verified}, nil
+		}
+		return nil, service.ErrAccessTokenInvalid
+	}}
+	handler := NewSubscriptionHandler(service.NewSubscriptionService(repository.NewSubscriptionRepository(db), cupcakeRepo), accounts)
+	r := chi.NewRouter()
+
+	r.Route("/api/v1/subscriptions", func(r chi.Router) {
+		r.Post("/", handler.Subscribe)
+		r.Get("/{id}", handler.GetSubscription)
+		r.Post("/{id}/pause", handler.PauseSubscription)
+		r.Post("/{id}/resume", handler.ResumeSubscription)
+		r.Post("/{id}/cancel", handler.CancelSubscription)
+	})
+	r.Get("/api/v1/admin/subscriptions", handler.GetAllSubscriptions)
+
+	return r
+}
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "valid subscription returns 201",
+			payload:        `{"customer_email":"ana@example.com","cupcake_id":1,"quantity":6,"frequency":"weekly"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid frequency returns 400",
+			payload:        `{"customer_email":"ana@example.com","cupcake_id":1,"quantity":6,"frequency":"hourly"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "frequency must be weekly, biweekly or monthly",
+		},
+		{
+			name:           "malformed JSON returns 400",
+			payload:        `{"customer_email":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Error decoding request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newSubscriptionTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/subscriptions", bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestSubscriptionStatusChanges(t *testing.T) {
+	router := newSubscriptionTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/subscriptions", bytes.NewBufferString(`{"customer_email":"ana@example.com","cupcake_id":1,"quantity":6,"frequency":"monthly"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created models.CreatedSubscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotEmpty(t, created.ManagementToken)
+
+	steps := []struct {
+		action         string
+		expectedStatus int
+		expectedState  string
+	}{
+		{action: "pause", expectedStatus: http.StatusOK, expectedState: models.SubscriptionPaused},
+		{action: "pause", expectedStatus: http.StatusBadRequest},
+		{action: "resume", expectedStatus: http.StatusOK, expectedState: models.SubscriptionActive},
+		{action: "cancel", expectedStatus: http.StatusOK, expectedState: models.SubscriptionCancelled},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/subscriptions/%d/%s", created.ID, step.action), nil)
+		req.Header.Set("X-Subscription-Token", created.ManagementToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, step.expectedStatus, w.Code, step.action)
+		if step.expectedState != "" {
+			var subscription models.Subscription
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &subscription))
+			require.Equal(t, step.expectedState, subscription.Status)
+		}
+	}
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/subscriptions", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var subscriptions []models.Subscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &subscriptions))
+	require.Len(t, subscriptions, 1)
+}
+
+func TestSubscriptionOwnership(t *testing.T) {
+	router := newSubscriptionTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/subscriptions", bytes.NewBufferString(`{"customer_email":"ana@example.com","cupcake_id":1,"quantity":6,"frequency":"weekly"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created models.CreatedSubscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+
+	serve := func(method, path string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		for i := 0; i < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	path := fmt.Sprintf("/api/v1/subscriptions/%d", created.ID)
+
+	w = serve("GET", path)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.NotContains(t, w.Body.String(), "ana@example.com")
+	w = serve("POST", path+"/cancel", "X-Subscription-Token", "guess")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serve("POST", path+"/cancel", "Authorization", "Bearer bia-token")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serve("GET", "/api/v1/subscriptions/999", "X-Subscription-Token", created.ManagementToken)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve("GET", path, "X-Subscription-Token", created.ManagementToken)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "management_token")
+
+	// The subscriber's account works too.
+	w = serve("POST", path+"/pause", "Authorization", "Bearer ana-token")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), models.SubscriptionPaused)
+}
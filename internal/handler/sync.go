@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type SyncHandler struct {
+	service service.SyncServiceInterface
+}
+
+func NewSyncHandler(service service.SyncServiceInterface) *SyncHandler {
+	return &SyncHandler{service: service}
+}
+
+// SyncCupcakes serves catalog changes after ?since= (omitted for a full
+// sync), at most ?limit= log entries at a time.
+func (h *SyncHandler) SyncCupcakes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var since uint64
+	if v := query.Get("since"); v != "" {
+		var err error
+		if since, err = strconv.ParseUint(v, 10, 32); err != nil {
+			sendJSONError(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := service.DefaultSyncLimit
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			sendJSONError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	sync, err := h.service.SyncCupcakes(uint(since), limit)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sync)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCupcakes(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	vanilla := factory.Cupcake(factory.WithName("Vanilla"))
+	require.NoError(t, cupcakeRepo.Create(&vanilla))
+
+	syncHandler := NewSyncHandler(service.NewSyncService(cupcakeRepo))
+	r := chi.NewRouter()
+	r.Get("/api/v1/sync/cupcakes", syncHandler.SyncCupcakes)
+
+	sync := func(query string) (int, models.CupcakeSync) {
+		req := httptest.NewRequest("GET", "/api/v1/sync/cupcakes"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var body models.CupcakeSync
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		}
+		return w.Code, body
+	}
+
+	code, full := sync("")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, full.Upserted, 1)
+
+	require.NoError(t, cupcakeRepo.Delete(vanilla.ID))
+	code, delta := sync(fmt.Sprintf("?since=%d", full.Cursor))
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, delta.Upserted)
+	require.Equal(t, []uint{vanilla.ID}, delta.Deleted)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"invalid cursor", "?since=abc"},
+		{"negative cursor", "?since=-1"},
+		{"invalid limit", "?limit=abc"},
+		{"limit out of range", "?since=1&limit=5000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := sync(tt.query)
+			require.Equal(t, http.StatusBadRequest, code)
+		})
+	}
+}
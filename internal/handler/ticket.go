@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type TicketHandler struct {
+	service service.TicketServiceInterface
+}
+
+func NewTicketHandler(service service.TicketServiceInterface) *TicketHandler {
+	return &TicketHandler{service: service}
+}
+
+// CreateTicket opens a ticket about one of the customer's orders.
+func (h *TicketHandler) CreateTicket(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateTicketRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	ticket, err := h.service.CreateTicket(&req)
+	if err != nil {
+		sendTicketError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ticket)
+}
+
+// GetTickets lists the tickets, optionally those with ?status=.
+func (h *TicketHandler) GetTickets(w http.ResponseWriter, r *http.Request) {
+	tickets, err := h.service.GetTickets(r.URL.Query().Get("status"))
+	if err != nil {
+		sendTicketError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tickets)
+}
+
+func (h *TicketHandler) GetTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	ticket, err := h.service.GetTicket(uint(id))
+	if err != nil {
+		sendTicketError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
+}
+
+// UpdateTicket assigns the ticket or moves it to another status.
+func (h *TicketHandler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var req models.UpdateTicketRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	ticket, err := h.service.UpdateTicket(uint(id), &req)
+	if err != nil {
+		sendTicketError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ticket)
+}
+
+func sendTicketError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrTicketNotFound) || errors.Is(err, service.ErrTicketOrderNotFound) {
+		sendJSONError(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
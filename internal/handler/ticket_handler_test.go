@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketHandler(t *testing.T) {
+	db := setupTestDB(t)
+	reservation := &models.PickupReservation{SlotID: 1, CustomerName: "Ana Lima", CustomerEmail: "ana@example.com"}
+	require.NoError(t, db.Create(reservation).Error)
+
+	handler := NewTicketHandler(service.NewTicketService(repository.NewTicketRepository(db), repository.NewPickupRepository(db), repository.NewSubscriptionRepository(db), repository.NewAdminRepository(db), nil))
+	router := chi.NewRouter()
+	router.Post("/api/v1/tickets", handler.CreateTicket)
+	router.Route("/api/v1/admin/tickets", func(r chi.Router) {
+		r.Get("/", handler.GetTickets)
+		r.Get("/{id}", handler.GetTicket)
+		r.Put("/{id}", handler.UpdateTicket)
+	})
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("POST", "/api/v1/tickets", fmt.Sprintf(`{"customer_email":"bruno@example.com","order_type":"pickup_reservation","order_id":%d,"subject":"Late","message":"Not ready."}`, reservation.ID))
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serve("POST", "/api/v1/tickets", fmt.Sprintf(`{"customer_email":"ana@example.com","order_type":"pickup_reservation","order_id":%d,"message":"Not ready."}`, reservation.ID))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "subject is required")
+
+	w = serve("POST", "/api/v1/tickets", fmt.Sprintf(`{"customer_email":"ana@example.com","order_type":"pickup_reservation","order_id":%d,"subject":"Late","message":"Not ready."}`, reservation.ID))
+	require.Equal(t, http.StatusCreated, w.Code)
+	var ticket models.Ticket
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&ticket))
+	require.Equal(t, models.TicketOpen, ticket.Status)
+
+	path := fmt.Sprintf("/api/v1/admin/tickets/%d", ticket.ID)
+	w = serve("PUT", path, `{"status":"investigating"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	w = serve("PUT", path, `{"status":"closed"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve("PUT", "/api/v1/admin/tickets/999", `{"status":"resolved"}`)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	w = serve("GET", "/api/v1/admin/tickets/abc", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve("GET", "/api/v1/admin/tickets?status=investigating", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var tickets []models.Ticket
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&tickets))
+	require.Len(t, tickets, 1)
+	w = serve("GET", "/api/v1/admin/tickets?status=closed", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
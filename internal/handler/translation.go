@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type TranslationHandler struct {
+	service service.TranslationServiceInterface
+}
+
+func NewTranslationHandler(service service.TranslationServiceInterface) *TranslationHandler {
+	return &TranslationHandler{service: service}
+}
+
+func (h *TranslationHandler) GetTranslations(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	translations, err := h.service.GetTranslations(uint(id))
+	if err != nil {
+		sendTranslationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translations)
+}
+
+// SetTranslation creates or replaces the translation of a cupcake in the
+// {locale} of the path.
+func (h *TranslationHandler) SetTranslation(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetTranslationRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	translation, err := h.service.SetTranslation(uint(id), chi.URLParam(r, "locale"), &req)
+	if err != nil {
+		sendTranslationError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(translation)
+}
+
+func (h *TranslationHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteTranslation(uint(id), chi.URLParam(r, "locale")); err != nil {
+		sendTranslationError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sendTranslationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		sendJSONError(w, "cupcake not found", http.StatusNotFound)
+	case errors.Is(err, service.ErrTranslationNotFound):
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+	default:
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslations(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	translations := service.NewTranslationService(repository.NewTranslationRepository(db), cupcakeRepo, "pt-BR")
+	cupcakeService := service.NewCupcakeService(cupcakeRepo, repository.NewPromotionRepository(db), repository.NewLocationRepository(db), nil, nil, translations, nil)
+
+	morango := factory.Cupcake(factory.WithName("Morango"), factory.WithFlavor("Morango"), factory.WithSKU("MOR-001"))
+	require.NoError(t, cupcakeRepo.Create(&morango))
+
+	translationHandler := NewTranslationHandler(translations)
+	cupcakeHandler := NewCupcakeHandler(cupcakeService)
+	r := chi.NewRouter()
+	r.Get("/api/v1/cupcakes", cupcakeHandler.GetAllCupcakes)
+	r.Get("/api/v1/cupcakes/{id}", cupcakeHandler.GetCupcake)
+	r.Get("/api/v1/admin/cupcakes/{id}/translations", translationHandler.GetTranslations)
+	r.Put("/api/v1/admin/cupcakes/{id}/translations/{locale}", translationHandler.SetTranslation)
+	r.Delete("/api/v1/admin/cupcakes/{id}/translations/{locale}", translationHandler.DeleteTranslation)
+
+	do := func(method, path, body string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	adminTests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{"set translation", "PUT", fmt.Sprintf("/api/v1/admin/cupcakes/%d/translations/en", morango.ID), `{"name":"Strawberry","flavor":"Strawberry","description":"<p>Strawberry frosting</p>"}`, http.StatusOK},
+		{"default locale", "PUT", fmt.Sprintf("/api/v1/admin/cupcakes/%d/translations/pt-BR", morango.ID), `{"name":"Morango","flavor":"Morango"}`, http.StatusBadRequest},
+		{"description too long", "PUT", fmt.Sprintf("/api/v1/admin/cupcakes/%d/translations/es", morango.ID), fmt.Sprintf(`{"name":"Fresa","flavor":"Fresa","description":%q}`, strings.Repeat("a", 2001)), http.StatusUnprocessableEntity},
+		{"invalid body", "PUT", fmt.Sprintf("/api/v1/admin/cupcakes/%d/translations/es", morango.ID), `{`, http.StatusBadRequest},
+		{"missing cupcake", "PUT", "/api/v1/admin/cupcakes/999/translations/en", `{"name":"Strawberry","flavor":"Strawberry"}`, http.StatusNotFound},
+		{"invalid ID", "GET", "/api/v1/admin/cupcakes/abc/translations", "", http.StatusBadRequest},
+		{"missing translation", "DELETE", fmt.Sprintf("/api/v1/admin/cupcakes/%d/translations/fr", morango.ID), "", http.StatusNotFound},
+	}
+
+	for _, tt := range adminTests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(tt.method, tt.path, tt.body, nil)
+			require.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+
+	w := do("GET", fmt.Sprintf("/api/v1/admin/cupcakes/%d/translations", morango.ID), "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listed []models.CupcakeTranslation
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	require.Len(t, listed, 1)
+	require.Equal(t, "en", listed[0].Locale)
+	require.Equal(t, "<p>Strawberry frosting</p>", listed[0].Description)
+
+	readTests := []struct {
+		name             string
+		path             string
+		acceptLanguage   string
+		expectedName     string
+		expectedLanguage string
+	}{
+		{"item in English", fmt.Sprintf("/api/v1/cupcakes/%d", morango.ID), "en-GB,en;q=0.9", "Strawberry", "en"},
+		{"item in the default locale", fmt.Sprintf("/api/v1/cupcakes/%d", morango.ID), "", "Morango", "pt-BR"},
+		{"list falls back to the default locale", "/api/v1/cupcakes", "de", "Morango", "pt-BR"},
+		{"lang query parameter wins", "/api/v1/cupcakes?lang=en", "pt-BR", "Strawberry", "en"},
+	}
+
+	for _, tt := range readTests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do("GET", tt.path, "", http.Header{"Accept-Language": {tt.acceptLanguage}})
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, tt.expectedLanguage, w.Header().Get("Content-Language"))
+			require.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+			require.Contains(t, w.Body.String(), fmt.Sprintf(`"name":%q`, tt.expectedName))
+			require.Contains(t, w.Body.String(), fmt.Sprintf(`"locale":%q`, tt.expectedLanguage))
+		})
+	}
+
+	w = do("GET", fmt.Sprintf("/api/v1/cupcakes/%d", morango.ID), "", http.Header{"Accept-Language": {"en"}})
+	var localized models.CupcakeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &localized))
+	require.Equal(t, "<p>Strawberry frosting</p>", localized.Description)
+
+	w = do("DELETE", fmt.Sprintf("/api/v1/admin/cupcakes/%d/translations/en", morango.ID), "", nil)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = do("GET", fmt.Sprintf("/api/v1/cupcakes/%d", morango.ID), "", http.Header{"Accept-Language": {"en"}})
+	require.Contains(t, w.Body.String(), `"name":"Morango"`)
+}
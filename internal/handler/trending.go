@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type TrendingHandler struct {
+	service service.TrendingServiceInterface
+	views   service.ViewRecorder
+}
+
+// NewTrendingHandler builds the handler; with a nil views recorder, Track
+// records nothing.
+func NewTrendingHandler(service service.TrendingServiceInterface, views service.ViewRecorder) *TrendingHandler {
+	return &TrendingHandler{service: service, views: views}
+}
+
+// Track counts a view of the cupcake in the {id} URL parameter whenever the
+// wrapped handler answers 200.
+func (h *TrendingHandler) Track(next http.Handler) http.Handler {
+	if h.views == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		if ww.Status() != http.StatusOK {
+			return
+		}
+		if id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32); err == nil {
+			h.views.RecordView(uint(id))
+		}
+	})
+}
+
+// GetTrending lists the most viewed cupcakes over ?window= days ("7d" by
+// default), at most ?limit= of them.
+func (h *TrendingHandler) GetTrending(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	days := service.DefaultTrendingDays
+	if v := query.Get("window"); v != "" {
+		var err error
+		if days, err = strconv.Atoi(strings.TrimSuffix(v, "d")); err != nil || !strings.HasSuffix(v, "d") {
+			sendJSONError(w, "Invalid window, expected days such as 7d", http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := service.DefaultTrendingLimit
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			sendJSONError(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	trending, err := h.service.GetTrending(days, limit)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	responses := make([]models.TrendingCupcakeResponse, len(trending))
+	for i := range trending {
+		responses[i] = models.TrendingCupcakeResponse{
+			CupcakeResponse: models.NewCupcakeResponse(&trending[i].Cupcake),
+			Views:           trending[i].Views,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(responses)
+}
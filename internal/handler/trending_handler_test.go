@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrending(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+
+	viewRepo := repository.NewViewRepository(db)
+	counter := service.NewViewCounter(viewRepo)
+	cupcakeHandler := NewCupcakeHandler(service.NewCupcakeService(cupcakeRepo, promotionRepo, repository.NewLocationRepository(db), nil, nil, nil, nil))
+	trendingHandler := NewTrendingHandler(service.NewTrendingService(viewRepo, cupcakeRepo, promotionRepo), counter)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/cupcakes/trending", trendingHandler.GetTrending)
+	r.With(trendingHandler.Track).Get("/api/v1/cupcakes/{id}", cupcakeHandler.GetCupcake)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, path := range []string{"/api/v1/cupcakes/2", "/api/v1/cupcakes/2", "/api/v1/cupcakes/1", "/api/v1/cupcakes/99"} {
+		get(path)
+	}
+	require.NoError(t, counter.Flush())
+
+	w := get("/api/v1/cupcakes/trending?window=7d")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var trending []models.TrendingCupcakeResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &trending))
+	require.Len(t, trending, 2, "views of unknown cupcakes are not counted")
+	require.Equal(t, "Chocolate", trending[0].Name)
+	require.Equal(t, 2, trending[0].Views)
+	require.Equal(t, "Vanilla", trending[1].Name)
+	require.Equal(t, 1, trending[1].Views)
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{"window without unit", "?window=7", "Invalid window"},
+		{"window in hours", "?window=24h", "Invalid window"},
+		{"window out of range", "?window=365d", "window must be between 1 and 90 days"},
+		{"invalid limit", "?limit=abc", "Invalid limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := get("/api/v1/cupcakes/trending" + tt.query)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			require.Contains(t, w.Body.String(), tt.expectedError)
+		})
+	}
+}
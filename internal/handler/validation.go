@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type ValidationHandler struct {
+	service *service.ValidationService
+}
+
+func NewValidationHandler(service *service.ValidationService) *ValidationHandler {
+	return &ValidationHandler{service: service}
+}
+
+func (h *ValidationHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.service.Rules())
+}
+
+func (h *ValidationHandler) SetRules(w http.ResponseWriter, r *http.Request) {
+	var req models.UpdateValidationRulesRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	rules, err := h.service.SetRules(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rules)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationRules(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		expectedStatus int
+		expectedError  string
+		expectedRules  models.ValidationRules
+	}{
+		{
+			name:           "update max price",
+			payload:        `{"max_price_cents":5000}`,
+			expectedStatus: http.StatusOK,
+			expectedRules:  models.ValidationRules{NameMinLength: 2, NameMaxLength: 100, MaxPriceCents: 5000},
+		},
+		{
+			name:           "update name lengths",
+			payload:        `{"name_min_length":3,"name_max_length":60}`,
+			expectedStatus: http.StatusOK,
+			expectedRules:  models.ValidationRules{NameMinLength: 3, NameMaxLength: 60},
+		},
+		{
+			name:           "invalid rules are rejected",
+			payload:        `{"name_min_length":0}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "name_min_length must be at least 1",
+			expectedRules:  models.DefaultValidationRules(),
+		},
+		{
+			name:           "malformed JSON",
+			payload:        `{"name_min_length":`,
+			expectedStatus: http.StatusBadRequest,
+			expectedRules:  models.DefaultValidationRules(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewValidationHandler(service.DefaultValidationService())
+			r := chi.NewRouter()
+			r.Get("/api/v1/admin/validation-rules", handler.GetRules)
+			r.Put("/api/v1/admin/validation-rules", handler.SetRules)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/validation-rules", bytes.NewBufferString(tt.payload))
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			}
+
+			req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/validation-rules", nil)
+			w = httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var rules models.ValidationRules
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rules))
+			require.Equal(t, tt.expectedRules, rules)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func newWebhookTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	handler := NewWebhookHandler(service.NewWebhookService(repository.NewWebhookRepository(db), service.NewJobService(repository.NewJobRepository(db))))
+	r := chi.NewRouter()
+
+	r.Route("/api/v1/admin/webhooks", func(r chi.Router) {
+		r.Post("/", handler.CreateWebhook)
+		r.Get("/", handler.GetAllWebhooks)
+		r.Get("/{id}", handler.GetWebhook)
+		r.Put("/{id}", handler.UpdateWebhook)
+		r.Delete("/{id}", handler.DeleteWebhook)
+		r.Get("/{id}/deliveries", handler.GetDeliveries)
+	})
+
+	return r
+}
+
+func TestCreateWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "valid webhook returns 201 without secret",
+			payload:        `{"url":"https://example.com/hooks","events":["cupcake.created"],"secret":"0123456789abcdef"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "unsupported event returns 400",
+			payload:        `{"url":"https://example.com/hooks","events":["order.paid"],"secret":"0123456789abcdef"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "unsupported event: order.paid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newWebhookTestRouter(t)
+
+			req := httptest.NewRequest("POST", "/api/v1/admin/webhooks", bytes.NewBufferString(tt.payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				require.Contains(t, w.Body.String(), tt.expectedError)
+			} else {
+				require.NotContains(t, w.Body.String(), "0123456789abcdef")
+			}
+		})
+	}
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	router := newWebhookTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/webhooks", bytes.NewBufferString(`{"url":"https://example.com/hooks","events":["cupcake.updated"],"secret":"0123456789abcdef"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var webhook models.Webhook
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &webhook))
+
+	req = httptest.NewRequest("GET", fmt.Sprintf("/api/v1/admin/webhooks/%d/deliveries", webhook.ID), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var deliveries []models.WebhookDelivery
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deliveries))
+	require.Len(t, deliveries, 0)
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/webhooks/999/deliveries", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
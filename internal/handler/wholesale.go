@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+type WholesaleHandler struct {
+	service service.WholesaleServiceInterface
+}
+
+func NewWholesaleHandler(service service.WholesaleServiceInterface) *WholesaleHandler {
+	return &WholesaleHandler{service: service}
+}
+
+func (h *WholesaleHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateWholesaleAccountRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	account, err := h.service.CreateAccount(&req)
+	if err != nil {
+		sendLocalizedError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(account)
+}
+
+func (h *WholesaleHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	account, err := h.service.GetAccount(uint(id))
+	if err != nil {
+		sendWholesaleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+func (h *WholesaleHandler) GetAllAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.service.GetAllAccounts()
+	if err != nil {
+		sendJSONError(w, "Error fetching wholesale accounts", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(accounts)
+}
+
+func (h *WholesaleHandler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UpdateWholesaleAccountRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	account, err := h.service.UpdateAccount(uint(id), &req)
+	if err != nil {
+		sendWholesaleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(account)
+}
+
+func (h *WholesaleHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteAccount(uint(id)); err != nil {
+		sendWholesaleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WholesaleHandler) GetPrices(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	prices, err := h.service.GetPrices(uint(id))
+	if err != nil {
+		sendWholesaleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(prices)
+}
+
+func (h *WholesaleHandler) SetPrice(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	cupcakeID, err := strconv.ParseUint(chi.URLParam(r, "cupcakeID"), 10, 32)
+	if err != nil || cupcakeID == 0 {
+		sendJSONError(w, "Invalid cupcake ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetWholesalePriceRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	price, err := h.service.SetPrice(uint(id), uint(cupcakeID), &req)
+	if err != nil {
+		sendWholesaleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(price)
+}
+
+func (h *WholesaleHandler) DeletePrice(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	cupcakeID, err := strconv.ParseUint(chi.URLParam(r, "cupcakeID"), 10, 32)
+	if err != nil || cupcakeID == 0 {
+		sendJSONError(w, "Invalid cupcake ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeletePrice(uint(id), uint(cupcakeID)); err != nil {
+		sendWholesaleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Quote prices a basket at the account's negotiated prices.
+func (h *WholesaleHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		sendJSONError(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.WholesaleQuoteRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	quote, err := h.service.Quote(uint(id), &req)
+	if err != nil {
+		sendWholesaleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quote)
+}
+
+func sendWholesaleError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, service.ErrWholesaleAccountNotFound) || errors.Is(err, service.ErrWholesalePriceNotFound) {
+		sendLocalizedError(w, r, err, http.StatusNotFound)
+		return
+	}
+	sendLocalizedError(w, r, err, http.StatusBadRequest)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func newWholesaleTestRouter(t *testing.T) chi.Router {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla"), factory.WithPrice(500)),
+		factory.Cupcake(factory.WithName("Chocolate"), factory.WithPrice(600)),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+	wholesaleHandler := NewWholesaleHandler(service.NewWholesaleService(repository.NewWholesaleRepository(db), cupcakeRepo))
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/admin/wholesale-accounts", wholesaleHandler.CreateAccount)
+	r.Get("/api/v1/admin/wholesale-accounts/{id}", wholesaleHandler.GetAccount)
+	r.Put("/api/v1/admin/wholesale-accounts/{id}/prices/{cupcakeID}", wholesaleHandler.SetPrice)
+	r.Delete("/api/v1/admin/wholesale-accounts/{id}/prices/{cupcakeID}", wholesaleHandler.DeletePrice)
+	r.Post("/api/v1/admin/wholesale-accounts/{id}/quote", wholesaleHandler.Quote)
+	return r
+}
+
+func TestWholesaleQuoteFlow(t *testing.T) {
+	router := newWholesaleTestRouter(t)
+
+	req := httptest.NewRequest("POST", "/api/v1/admin/wholesale-accounts", bytes.NewBufferString(`{"name":"Café Central","email":"compras@cafecentral.com","min_order_quantity":12}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	req = httptest.NewRequest("PUT", "/api/v1/admin/wholesale-accounts/1/prices/1", bytes.NewBufferString(`{"price_cents":400}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/wholesale-accounts/1/quote", bytes.NewBufferString(`{"items":[{"cupcake_id":1,"quantity":10},{"cupcake_id":2,"quantity":2}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var quote models.WholesaleQuote
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&quote))
+	require.Equal(t, 5200, quote.TotalCents)
+	require.True(t, quote.Lines[0].Negotiated)
+	require.False(t, quote.Lines[1].Negotiated)
+
+	req = httptest.NewRequest("POST", "/api/v1/admin/wholesale-accounts/1/quote", bytes.NewBufferString(`{"items":[{"cupcake_id":1,"quantity":2}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "minimum order is 12 units")
+
+	req = httptest.NewRequest("DELETE", "/api/v1/admin/wholesale-accounts/1/prices/2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/admin/wholesale-accounts/999", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "wholesale account not found")
+}
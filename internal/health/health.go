@@ -0,0 +1,108 @@
+// Package health runs readiness checks against the services the process
+// depends on, giving each its own deadline.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+type check struct {
+	name    string
+	timeout time.Duration
+	ping    func(context.Context) error
+}
+
+// Result is the outcome of a single dependency check.
+type Result struct {
+	Status     string `json:"status"`
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Report is the readiness of the process: unavailable as soon as any
+// dependency is.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Checker holds the registered dependency checks.
+type Checker struct {
+	mu     sync.Mutex
+	checks []check
+}
+
+func New() *Checker {
+	return &Checker{}
+}
+
+// Add registers a dependency. ping receives a context that expires after
+// timeout; a ping that ignores it is reported as timed out once the deadline
+// passes.
+func (c *Checker) Add(name string, timeout time.Duration, ping func(context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, check{name: name, timeout: timeout, ping: ping})
+}
+
+// Check runs every check concurrently, so the slowest dependency bounds the
+// total time rather than the sum of them.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.Lock()
+	checks := make([]check, len(c.checks))
+	copy(checks, c.checks)
+	c.mu.Unlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, ch)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(checks))}
+	for i, ch := range checks {
+		report.Checks[ch.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusUnavailable
+		}
+	}
+	return report
+}
+
+func run(parent context.Context, ch check) Result {
+	ctx, cancel := context.WithTimeout(parent, ch.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- ch.ping(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{Status: StatusOK, DurationMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+	}
+	return result
+}
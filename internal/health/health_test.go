@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Check(t *testing.T) {
+	tests := []struct {
+		name           string
+		register       func(c *Checker)
+		expectedStatus string
+		expectedChecks map[string]string
+		expectedErrors map[string]string
+	}{
+		{
+			name:           "no checks is ready",
+			register:       func(c *Checker) {},
+			expectedStatus: StatusOK,
+			expectedChecks: map[string]string{},
+		},
+		{
+			name: "all dependencies up",
+			register: func(c *Checker) {
+				c.Add("database", time.Second, func(context.Context) error { return nil })
+				c.Add("broker", time.Second, func(context.Context) error { return nil })
+			},
+			expectedStatus: StatusOK,
+			expectedChecks: map[string]string{"database": StatusOK, "broker": StatusOK},
+		},
+		{
+			name: "one failing dependency makes the process unavailable",
+			register: func(c *Checker) {
+				c.Add("database", time.Second, func(context.Context) error { return nil })
+				c.Add("broker", time.Second, func(context.Context) error { return errors.New("connection refused") })
+			},
+			expectedStatus: StatusUnavailable,
+			expectedChecks: map[string]string{"database": StatusOK, "broker": StatusUnavailable},
+			expectedErrors: map[string]string{"broker": "connection refused"},
+		},
+		{
+			name: "a hung dependency times out",
+			register: func(c *Checker) {
+				c.Add("database", time.Second, func(context.Context) error { return nil })
+				c.Add("stuck", 10*time.Millisecond, func(context.Context) error {
+					time.Sleep(time.Second)
+					return nil
+				})
+			},
+			expectedStatus: StatusUnavailable,
+			expectedChecks: map[string]string{"database": StatusOK, "stuck": StatusUnavailable},
+			expectedErrors: map[string]string{"stuck": context.DeadlineExceeded.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New()
+			tt.register(c)
+
+			report := c.Check(context.Background())
+
+			require.Equal(t, tt.expectedStatus, report.Status)
+			require.Len(t, report.Checks, len(tt.expectedChecks))
+			for name, status := range tt.expectedChecks {
+				require.Equal(t, status, report.Checks[name].Status, name)
+				require.Equal(t, tt.expectedErrors[name], report.Checks[name].Error, name)
+			}
+		})
+	}
+}
+
+func TestChecker_ChecksRunConcurrently(t *testing.T) {
+	c := New()
+	for _, name := range []string{"a", "b", "c"} {
+		c.Add(name, time.Second, func(context.Context) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		})
+	}
+
+	start := time.Now()
+	report := c.Check(context.Background())
+
+	require.Equal(t, StatusOK, report.Status)
+	require.Less(t, time.Since(start), 140*time.Millisecond)
+}
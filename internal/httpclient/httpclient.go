@@ -0,0 +1,307 @@
+// Package httpclient builds the HTTP clients of outbound integrations such
+// as webhooks, captcha, SMS, social login and search. Every attempt has its
+// own timeout, failed requests that are safe to repeat are retried with
+// jittered exponential backoff, and a circuit breaker per host fails
+// requests fast while a dependency keeps failing, so one flaky integration
+// cannot tie up request handling. Each client's counters are written by
+// WriteMetrics.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+)
+
+// ErrCircuitOpen is returned, wrapped, for requests to a host whose circuit
+// is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Settings are the retry and circuit breaker settings of a client. The
+// zero value neither retries nor breaks.
+type Settings struct {
+	// MaxRetries is how many times a failed request is tried again. The
+	// wait before retry n is random, up to RetryDelay doubled n-1 times
+	// and capped at MaxRetryDelay.
+	MaxRetries    int
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+
+	// BreakerThreshold consecutive failures of a host open its circuit
+	// for BreakerCooldown; after that one request goes through, and
+	// closes it again if it succeeds. Zero turns the breaker off.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	// RetryPOST retries POST and PATCH requests too, for receivers that
+	// tolerate duplicates. Other methods are retried only when
+	// idempotent.
+	RetryPOST bool
+}
+
+// DefaultSettings are used for the HTTP_CLIENT_* variables left empty.
+var DefaultSettings = Settings{
+	MaxRetries:       2,
+	RetryDelay:       200 * time.Millisecond,
+	MaxRetryDelay:    2 * time.Second,
+	BreakerThreshold: 5,
+	BreakerCooldown:  30 * time.Second,
+}
+
+// FromConfig reads the HTTP_CLIENT_* variables.
+func FromConfig(cfg *config.Config) (Settings, error) {
+	settings := DefaultSettings
+	var err error
+	if cfg.HTTPClientMaxRetries != "" {
+		if settings.MaxRetries, err = strconv.Atoi(cfg.HTTPClientMaxRetries); err != nil || settings.MaxRetries < 0 {
+			return Settings{}, fmt.Errorf("invalid HTTP_CLIENT_MAX_RETRIES %q: must be 0 or more", cfg.HTTPClientMaxRetries)
+		}
+	}
+	if cfg.HTTPClientRetryDelay != "" {
+		if settings.RetryDelay, err = time.ParseDuration(cfg.HTTPClientRetryDelay); err != nil || settings.RetryDelay <= 0 {
+			return Settings{}, fmt.Errorf("invalid HTTP_CLIENT_RETRY_DELAY %q: must be a duration such as 200ms", cfg.HTTPClientRetryDelay)
+		}
+	}
+	if cfg.HTTPClientMaxRetryDelay != "" {
+		if settings.MaxRetryDelay, err = time.ParseDuration(cfg.HTTPClientMaxRetryDelay); err != nil || settings.MaxRetryDelay < settings.RetryDelay {
+			return Settings{}, fmt.Errorf("invalid HTTP_CLIENT_MAX_RETRY_DELAY %q: must be a duration no shorter than HTTP_CLIENT_RETRY_DELAY", cfg.HTTPClientMaxRetryDelay)
+		}
+	}
+	if cfg.HTTPClientBreakerThreshold != "" {
+		if settings.BreakerThreshold, err = strconv.Atoi(cfg.HTTPClientBreakerThreshold); err != nil || settings.BreakerThreshold < 0 {
+			return Settings{}, fmt.Errorf("invalid HTTP_CLIENT_BREAKER_THRESHOLD %q: must be 0 or more", cfg.HTTPClientBreakerThreshold)
+		}
+	}
+	if cfg.HTTPClientBreakerCooldown != "" {
+		if settings.BreakerCooldown, err = time.ParseDuration(cfg.HTTPClientBreakerCooldown); err != nil || settings.BreakerCooldown <= 0 {
+			return Settings{}, fmt.Errorf("invalid HTTP_CLIENT_BREAKER_COOLDOWN %q: must be a duration such as 30s", cfg.HTTPClientBreakerCooldown)
+		}
+	}
+	return settings, nil
+}
+
+// New returns a client for the integration name, which labels its metrics
+// and errors, giving every attempt timeout.
+func New(name string, timeout time.Duration, settings Settings) *http.Client {
+	return &http.Client{Transport: &Transport{
+		name:     name,
+		base:     http.DefaultTransport,
+		timeout:  timeout,
+		settings: settings,
+		stats:    statsFor(name),
+		breakers: map[string]*breaker{},
+		now:      time.Now,
+	}}
+}
+
+// Transport is the http.RoundTripper of the clients made by New.
+type Transport struct {
+	name     string
+	base     http.RoundTripper
+	timeout  time.Duration
+	settings Settings
+	stats    *clientStats
+	now      func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// breaker is the circuit of a host: open until openUntil once failures
+// reach the threshold, then half-open while probing.
+type breaker struct {
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := t.now()
+	resp, err := t.roundTrip(req)
+
+	result := "ok"
+	switch {
+	case errors.Is(err, ErrCircuitOpen):
+		result = "rejected"
+	case err != nil:
+		result = "error"
+	case resp.StatusCode >= 500:
+		result = "server_error"
+	}
+	t.stats.observe(result, t.now().Sub(start))
+	return resp, err
+}
+
+func (t *Transport) roundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	for attempt := 0; ; attempt++ {
+		if !t.allow(host) {
+			return nil, fmt.Errorf("%s: %s: %w", t.name, host, ErrCircuitOpen)
+		}
+
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+		resp, err := t.attempt(attemptReq)
+
+		// A request the caller gave up on says nothing about the host.
+		if req.Context().Err() != nil {
+			t.release(host)
+			return resp, err
+		}
+		t.record(host, err == nil && resp.StatusCode < 500)
+
+		if attempt >= t.settings.MaxRetries || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+		t.stats.retried()
+
+		timer := time.NewTimer(t.backoff(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt sends req once, within the timeout. The deadline lasts until the
+// body is closed, so it also bounds reading the response.
+func (t *Transport) attempt(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// retryable reports whether a failed attempt may be repeated: a transport
+// error or an overloaded or unavailable server, for a request that can be
+// sent again.
+func (t *Transport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	case http.MethodPost, http.MethodPatch:
+		if !t.settings.RetryPOST {
+			return false
+		}
+	default:
+		return false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff is a random wait up to the capped exponential delay of attempt
+// (full jitter), so clients retrying together spread out.
+func (t *Transport) backoff(attempt int) time.Duration {
+	delay := t.settings.RetryDelay << attempt
+	if delay <= 0 || (t.settings.MaxRetryDelay > 0 && delay > t.settings.MaxRetryDelay) {
+		delay = t.settings.MaxRetryDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return rand.N(delay)
+}
+
+// allow reports whether a request to host may go out: always while its
+// circuit is closed, and only as the single probe once an open circuit has
+// cooled down.
+func (t *Transport) allow(host string) bool {
+	if t.settings.BreakerThreshold == 0 {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b := t.breakers[host]
+	if b == nil || b.openUntil.IsZero() {
+		return true
+	}
+	if t.now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record closes the host's circuit after a success and counts a failure
+// otherwise, opening it at the threshold or when the probe failed.
+func (t *Transport) record(host string, ok bool) {
+	if t.settings.BreakerThreshold == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ok {
+		delete(t.breakers, host)
+		return
+	}
+	b := t.breakers[host]
+	if b == nil {
+		b = &breaker{}
+		t.breakers[host] = b
+	}
+	b.failures++
+	if b.probing || b.failures >= t.settings.BreakerThreshold {
+		b.probing = false
+		b.openUntil = t.now().Add(t.settings.BreakerCooldown)
+		t.stats.opened()
+	}
+}
+
+// release lets another request probe the host when the probe was given up.
+func (t *Transport) release(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if b := t.breakers[host]; b != nil {
+		b.probing = false
+	}
+}
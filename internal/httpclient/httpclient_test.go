@@ -0,0 +1,170 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+var fastRetries = Settings{MaxRetries: 2, RetryDelay: time.Millisecond, MaxRetryDelay: 2 * time.Millisecond}
+
+func TestFromConfig(t *testing.T) {
+	settings, err := FromConfig(&config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, DefaultSettings, settings)
+
+	settings, err = FromConfig(&config.Config{HTTPClientMaxRetries: "0", HTTPClientBreakerThreshold: "0"})
+	require.NoError(t, err)
+	require.Zero(t, settings.MaxRetries)
+	require.Zero(t, settings.BreakerThreshold)
+
+	_, err = FromConfig(&config.Config{HTTPClientMaxRetries: "-1"})
+	require.EqualError(t, err, `invalid HTTP_CLIENT_MAX_RETRIES "-1": must be 0 or more`)
+	_, err = FromConfig(&config.Config{HTTPClientRetryDelay: "1s", HTTPClientMaxRetryDelay: "500ms"})
+	require.ErrorContains(t, err, "no shorter than HTTP_CLIENT_RETRY_DELAY")
+	_, err = FromConfig(&config.Config{HTTPClientBreakerCooldown: "soon"})
+	require.ErrorContains(t, err, `invalid HTTP_CLIENT_BREAKER_COOLDOWN "soon"`)
+}
+
+func TestClient_Retries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	// Idempotent requests are retried, with their body.
+	client := New("test_retries", time.Second, fastRetries)
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("cupcake"))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "cupcake", string(body))
+	require.Equal(t, int32(3), calls.Load())
+
+	// POST only when the client allows it.
+	calls.Store(0)
+	resp, err = client.Post(server.URL, "text/plain", strings.NewReader("cupcake"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int32(1), calls.Load())
+
+	calls.Store(0)
+	settings := fastRetries
+	settings.RetryPOST = true
+	resp, err = New("test_retries", time.Second, settings).Post(server.URL, "text/plain", strings.NewReader("cupcake"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Client errors are not retried.
+	calls.Store(10)
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer notFound.Close()
+	resp, err = client.Get(notFound.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, int32(11), calls.Load())
+}
+
+func TestClient_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	start := time.Now()
+	_, err := New("test_timeout", 20*time.Millisecond, fastRetries).Get(server.URL)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	// Three attempts of 20ms, not one hanging on the server.
+	require.Less(t, time.Since(start), time.Second)
+
+	// A cancelled caller is not retried.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = New("test_timeout", time.Second, fastRetries).Do(req)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	// The counters are global; start them over when the test is repeated.
+	registry.Lock()
+	delete(registry.clients, "test_breaker")
+	registry.Unlock()
+	client := New("test_breaker", time.Second, Settings{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+	transport := client.Transport.(*Transport)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	transport.now = func() time.Time { return now }
+	get := func() error {
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	require.NoError(t, get())
+	require.NoError(t, get())
+	require.Equal(t, int32(2), calls.Load())
+
+	// Open: requests fail without reaching the server.
+	err := get()
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.Equal(t, int32(2), calls.Load())
+
+	// A failed probe opens it again.
+	now = now.Add(time.Minute)
+	require.NoError(t, get())
+	require.ErrorIs(t, get(), ErrCircuitOpen)
+
+	// A successful one closes it.
+	now = now.Add(time.Minute)
+	healthy.Store(true)
+	require.NoError(t, get())
+	require.NoError(t, get())
+	require.Equal(t, int32(5), calls.Load())
+
+	var metrics bytes.Buffer
+	WriteMetrics(&metrics)
+	require.Contains(t, metrics.String(), `cupcake_store_http_client_requests_total{client="test_breaker",result="rejected"} 2`)
+	require.Contains(t, metrics.String(), `cupcake_store_http_client_requests_total{client="test_breaker",result="server_error"} 3`)
+	require.Contains(t, metrics.String(), `cupcake_store_http_client_circuit_opens_total{client="test_breaker"} 2`)
+}
@@ -0,0 +1,119 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+var registry = struct {
+	sync.Mutex
+	clients map[string]*clientStats
+}{clients: map[string]*clientStats{}}
+
+// clientStats counts the requests of every client with one name.
+type clientStats struct {
+	mu        sync.Mutex
+	requests  map[string]uint64
+	retries   uint64
+	opens     uint64
+	durations float64
+	count     uint64
+}
+
+func statsFor(name string) *clientStats {
+	registry.Lock()
+	defer registry.Unlock()
+
+	stats, ok := registry.clients[name]
+	if !ok {
+		stats = &clientStats{requests: map[string]uint64{}}
+		registry.clients[name] = stats
+	}
+	return stats
+}
+
+// observe counts a request, retries included, by result: ok (any response
+// below 500), server_error, error or rejected by the circuit breaker.
+func (s *clientStats) observe(result string, took time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[result]++
+	s.durations += took.Seconds()
+	s.count++
+}
+
+func (s *clientStats) retried() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retries++
+}
+
+func (s *clientStats) opened() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.opens++
+}
+
+// WriteMetrics writes the counters of every client in the Prometheus text
+// exposition format.
+func WriteMetrics(w io.Writer) {
+	registry.Lock()
+	names := make([]string, 0, len(registry.clients))
+	clients := make(map[string]*clientStats, len(registry.clients))
+	for name, stats := range registry.clients {
+		names = append(names, name)
+		clients[name] = stats
+	}
+	registry.Unlock()
+	sort.Strings(names)
+
+	type snapshot struct {
+		requests       map[string]uint64
+		retries, opens uint64
+		durations      float64
+		count          uint64
+	}
+	snapshots := make([]snapshot, len(names))
+	for i, name := range names {
+		stats := clients[name]
+		stats.mu.Lock()
+		requests := make(map[string]uint64, len(stats.requests))
+		for result, n := range stats.requests {
+			requests[result] = n
+		}
+		snapshots[i] = snapshot{requests, stats.retries, stats.opens, stats.durations, stats.count}
+		stats.mu.Unlock()
+	}
+
+	fmt.Fprintf(w, "# HELP cupcake_store_http_client_requests_total Outbound requests, by client and result.\n")
+	fmt.Fprintf(w, "# TYPE cupcake_store_http_client_requests_total counter\n")
+	for i, name := range names {
+		results := make([]string, 0, len(snapshots[i].requests))
+		for result := range snapshots[i].requests {
+			results = append(results, result)
+		}
+		sort.Strings(results)
+		for _, result := range results {
+			fmt.Fprintf(w, "cupcake_store_http_client_requests_total{client=%q,result=%q} %d\n", name, result, snapshots[i].requests[result])
+		}
+	}
+	fmt.Fprintf(w, "# HELP cupcake_store_http_client_retries_total Outbound requests retried.\n")
+	fmt.Fprintf(w, "# TYPE cupcake_store_http_client_retries_total counter\n")
+	for i, name := range names {
+		fmt.Fprintf(w, "cupcake_store_http_client_retries_total{client=%q} %d\n", name, snapshots[i].retries)
+	}
+	fmt.Fprintf(w, "# HELP cupcake_store_http_client_circuit_opens_total Times a host's circuit breaker opened.\n")
+	fmt.Fprintf(w, "# TYPE cupcake_store_http_client_circuit_opens_total counter\n")
+	for i, name := range names {
+		fmt.Fprintf(w, "cupcake_store_http_client_circuit_opens_total{client=%q} %d\n", name, snapshots[i].opens)
+	}
+	fmt.Fprintf(w, "# HELP cupcake_store_http_client_request_duration_seconds Time spent on outbound requests, retries included.\n")
+	fmt.Fprintf(w, "# TYPE cupcake_store_http_client_request_duration_seconds summary\n")
+	for i, name := range names {
+		fmt.Fprintf(w, "cupcake_store_http_client_request_duration_seconds_sum{client=%q} %g\n", name, snapshots[i].durations)
+		fmt.Fprintf(w, "cupcake_store_http_client_request_duration_seconds_count{client=%q} %d\n", name, snapshots[i].count)
+	}
+}
@@ -0,0 +1,88 @@
+// Package i18n translates user-facing error messages with the catalogs in
+// locales/, picking the language from an Accept-Language value.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// Message is a translatable message. Other holds the English text, used when
+// no catalog has a translation.
+type Message = goi18n.Message
+
+//go:embed locales/*.json
+var catalogs embed.FS
+
+var bundle = newBundle()
+
+func newBundle() *goi18n.Bundle {
+	b := goi18n.NewBundle(language.English)
+	b.RegisterUnmarshalFunc("json", json.Unmarshal)
+
+	files, err := fs.Glob(catalogs, "locales/*.json")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range files {
+		if _, err := b.LoadMessageFileFS(catalogs, file); err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+// Languages returns the languages with a message catalog, English first.
+func Languages() []string {
+	tags := bundle.LanguageTags()
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.String()
+	}
+	return result
+}
+
+// Error is an error whose text is a translatable message. Error returns it
+// in English.
+type Error struct {
+	Message *Message
+	Data    map[string]any
+}
+
+// NewError returns an error for msg, filling its template with data.
+func NewError(msg *Message, data map[string]any) error {
+	return &Error{Message: msg, Data: data}
+}
+
+func (e *Error) Error() string {
+	return e.Localize("en")
+}
+
+// Localize returns the message in the first of langs with a translation,
+// falling back to English. Each entry may be a tag or a whole Accept-Language
+// header.
+func (e *Error) Localize(langs ...string) string {
+	text, err := goi18n.NewLocalizer(bundle, langs...).Localize(&goi18n.LocalizeConfig{
+		DefaultMessage: e.Message,
+		TemplateData:   e.Data,
+	})
+	// A message missing from every catalog still renders its default text.
+	if text == "" && err != nil {
+		return e.Message.Other
+	}
+	return text
+}
+
+// Translate returns the text of err in the language best matching
+// acceptLanguage. Only an *Error itself is translated: one wrapped with
+// extra context, or any other error, keeps its own text.
+func Translate(err error, acceptLanguage string) string {
+	if e, ok := err.(*Error); ok {
+		return e.Localize(acceptLanguage)
+	}
+	return err.Error()
+}
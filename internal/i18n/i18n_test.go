@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var msgLimit = &Message{ID: "LimitOutOfRange", Other: "limit must be between 1 and {{.Max}}"}
+
+func TestCatalogsHaveSameMessages(t *testing.T) {
+	ids := func(file string) []string {
+		data, err := catalogs.ReadFile("locales/" + file)
+		require.NoError(t, err)
+		var messages map[string]string
+		require.NoError(t, json.Unmarshal(data, &messages))
+
+		result := make([]string, 0, len(messages))
+		for id := range messages {
+			result = append(result, id)
+		}
+		return result
+	}
+
+	require.ElementsMatch(t, ids("en.json"), ids("pt-BR.json"))
+	require.ElementsMatch(t, []string{"en", "pt-BR"}, Languages())
+}
+
+func TestErrorRendersEnglish(t *testing.T) {
+	err := NewError(msgLimit, map[string]any{"Max": 20})
+	require.EqualError(t, err, "limit must be between 1 and 20")
+}
+
+func TestTranslate(t *testing.T) {
+	err := NewError(msgLimit, map[string]any{"Max": 20})
+
+	tests := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{acceptLanguage: "pt-BR", expected: "limit deve estar entre 1 e 20"},
+		{acceptLanguage: "pt", expected: "limit deve estar entre 1 e 20"},
+		{acceptLanguage: "fr, pt-BR;q=0.5", expected: "limit deve estar entre 1 e 20"},
+		{acceptLanguage: "en-US", expected: "limit must be between 1 and 20"},
+		{acceptLanguage: "fr", expected: "limit must be between 1 and 20"},
+		{acceptLanguage: "", expected: "limit must be between 1 and 20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			require.Equal(t, tt.expected, Translate(err, tt.acceptLanguage))
+		})
+	}
+}
+
+func TestTranslateKeepsOtherErrors(t *testing.T) {
+	require.Equal(t, "boom", Translate(errors.New("boom"), "pt-BR"))
+
+	wrapped := fmt.Errorf("context: %w", NewError(msgLimit, map[string]any{"Max": 20}))
+	require.Equal(t, "context: limit must be between 1 and 20", Translate(wrapped, "pt-BR"))
+}
+
+func TestMissingMessageUsesDefault(t *testing.T) {
+	err := NewError(&Message{ID: "NotInCatalog", Other: "only in code"}, nil)
+	require.Equal(t, "only in code", Translate(err, "pt-BR"))
+}
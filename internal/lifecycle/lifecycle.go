@@ -0,0 +1,95 @@
+// Package lifecycle coordinates shutting down the process's long-running
+// components in a fixed order, giving each its own deadline.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type component struct {
+	name    string
+	timeout time.Duration
+	stop    func(context.Context) error
+}
+
+// Manager stops registered components in reverse registration order, so
+// components registered first, such as the database, are stopped last,
+// after everything that depends on them.
+type Manager struct {
+	mu         sync.Mutex
+	components []component
+}
+
+func New() *Manager {
+	return &Manager{}
+}
+
+// Add registers a component. stop receives a context that expires after
+// timeout; a stop that ignores it is abandoned once the deadline passes.
+func (m *Manager) Add(name string, timeout time.Duration, stop func(context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.components = append(m.components, component{name: name, timeout: timeout, stop: stop})
+}
+
+// Shutdown stops every component, even when earlier ones fail or time out,
+// and returns the combined errors. Cancelling ctx shortens every remaining
+// deadline.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	components := make([]component, len(m.components))
+	copy(components, m.components)
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(components) - 1; i >= 0; i-- {
+		c := components[i]
+		start := time.Now()
+		if err := stopComponent(ctx, c); err != nil {
+			log.Printf("Error stopping %s: %v", c.name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+			continue
+		}
+		log.Printf("Stopped %s in %s", c.name, time.Since(start).Round(time.Millisecond))
+	}
+	return errors.Join(errs...)
+}
+
+func stopComponent(parent context.Context, c component) error {
+	ctx, cancel := context.WithTimeout(parent, c.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.stop(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Wait adapts a blocking stop function, e.g. one that waits on a channel or
+// a WaitGroup, to the signature Add expects.
+func Wait(stop func()) func(context.Context) error {
+	return func(context.Context) error {
+		stop()
+		return nil
+	}
+}
+
+// Close adapts an io.Closer-style function to the signature Add expects.
+func Close(close func() error) func(context.Context) error {
+	return func(context.Context) error {
+		return close()
+	}
+}
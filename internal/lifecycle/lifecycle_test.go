@@ -0,0 +1,101 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Shutdown(t *testing.T) {
+	errClose := errors.New("close failed")
+
+	tests := []struct {
+		name          string
+		register      func(m *Manager, record func(string))
+		expectedOrder []string
+		expectedError []error
+	}{
+		{
+			name: "stops in reverse registration order",
+			register: func(m *Manager, record func(string)) {
+				for _, name := range []string{"database", "worker", "http"} {
+					name := name
+					m.Add(name, time.Second, func(context.Context) error {
+						record(name)
+						return nil
+					})
+				}
+			},
+			expectedOrder: []string{"http", "worker", "database"},
+		},
+		{
+			name: "keeps going after an error",
+			register: func(m *Manager, record func(string)) {
+				m.Add("database", time.Second, Wait(func() { record("database") }))
+				m.Add("emitter", time.Second, Close(func() error {
+					record("emitter")
+					return errClose
+				}))
+			},
+			expectedOrder: []string{"emitter", "database"},
+			expectedError: []error{errClose},
+		},
+		{
+			name: "abandons a component past its timeout",
+			register: func(m *Manager, record func(string)) {
+				m.Add("database", time.Second, Wait(func() { record("database") }))
+				m.Add("stuck", 10*time.Millisecond, Wait(func() { time.Sleep(time.Second) }))
+			},
+			expectedOrder: []string{"database"},
+			expectedError: []error{context.DeadlineExceeded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var order []string
+			record := func(name string) {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+			}
+
+			m := New()
+			tt.register(m, record)
+
+			start := time.Now()
+			err := m.Shutdown(context.Background())
+			require.Less(t, time.Since(start), 500*time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			require.Equal(t, tt.expectedOrder, order)
+			if len(tt.expectedError) == 0 {
+				require.NoError(t, err)
+			}
+			for _, expected := range tt.expectedError {
+				require.ErrorIs(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestManager_ShutdownHonorsParentContext(t *testing.T) {
+	m := New()
+	m.Add("worker", time.Minute, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := m.Shutdown(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Contains(t, err.Error(), "worker")
+}
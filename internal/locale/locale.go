@@ -0,0 +1,74 @@
+// Package locale parses and normalizes BCP 47 language tags as used in the
+// Accept-Language header.
+package locale
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var tagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// Normalize returns tag in canonical case ("pt-BR", "zh-Hant") and whether
+// it is a well-formed language tag.
+func Normalize(tag string) (string, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if !tagPattern.MatchString(tag) {
+		return "", false
+	}
+
+	parts := strings.Split(tag, "-")
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "-"), true
+}
+
+// Base returns the language of tag without region or script: "pt" for
+// "pt-BR".
+func Base(tag string) string {
+	base, _, _ := strings.Cut(tag, "-")
+	return base
+}
+
+// ParseAcceptLanguage returns the tags of an Accept-Language header, most
+// preferred first. Malformed tags, the "*" wildcard and tags with q=0 are
+// dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		normalized, ok := Normalize(tag)
+		if !ok || q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: normalized, q: q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
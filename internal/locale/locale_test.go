@@ -0,0 +1,57 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+		valid    bool
+	}{
+		{tag: "pt-br", expected: "pt-BR", valid: true},
+		{tag: " EN ", expected: "en", valid: true},
+		{tag: "es_419", expected: "es-419", valid: true},
+		{tag: "zh-hant-tw", expected: "zh-Hant-TW", valid: true},
+		{tag: "*"},
+		{tag: ""},
+		{tag: "english"},
+		{tag: "pt-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			normalized, valid := Normalize(tt.tag)
+			require.Equal(t, tt.valid, valid)
+			require.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+func TestBase(t *testing.T) {
+	require.Equal(t, "pt", Base("pt-BR"))
+	require.Equal(t, "en", Base("en"))
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []string
+	}{
+		{name: "empty", header: "", expected: []string{}},
+		{name: "single", header: "en-US", expected: []string{"en-US"}},
+		{name: "ordered by quality", header: "en;q=0.5, pt-BR, es;q=0.8", expected: []string{"pt-BR", "es", "en"}},
+		{name: "ties keep header order", header: "fr, de", expected: []string{"fr", "de"}},
+		{name: "drops wildcard, q=0 and malformed", header: "*, en;q=0, pt;q=abc, es", expected: []string{"es"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
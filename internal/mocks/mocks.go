@@ -0,0 +1,11 @@
+// Package mocks holds hand-written mocks of the repository and service
+// interfaces. Each method delegates to its matching Func field; calling a
+// method whose Func is nil panics, so tests only set up what they expect to
+// be called.
+package mocks
+
+import "fmt"
+
+func unexpected(method string) {
+	panic(fmt.Sprintf("mocks: unexpected call to %s", method))
+}
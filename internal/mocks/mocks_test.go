@@ -0,0 +1,65 @@
+package mocks_test
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ service.CupcakeServiceInterface       = (*mocks.CupcakeService)(nil)
+	_ service.CouponServiceInterface        = (*mocks.CouponService)(nil)
+	_ service.PromotionServiceInterface     = (*mocks.PromotionService)(nil)
+	_ service.GiftCardServiceInterface      = (*mocks.GiftCardService)(nil)
+	_ service.SubscriptionServiceInterface  = (*mocks.SubscriptionService)(nil)
+	_ service.LocationServiceInterface      = (*mocks.LocationService)(nil)
+	_ service.PickupServiceInterface        = (*mocks.PickupService)(nil)
+	_ service.CustomCupcakeServiceInterface = (*mocks.CustomCupcakeService)(nil)
+	_ service.AddonServiceInterface         = (*mocks.AddonService)(nil)
+	_ service.BundleServiceInterface        = (*mocks.BundleService)(nil)
+	_ service.WholesaleServiceInterface     = (*mocks.WholesaleService)(nil)
+	_ service.ProcurementServiceInterface   = (*mocks.ProcurementService)(nil)
+	_ service.RecipeServiceInterface        = (*mocks.RecipeService)(nil)
+	_ service.KitchenServiceInterface       = (*mocks.KitchenService)(nil)
+	_ service.StatsServiceInterface         = (*mocks.StatsService)(nil)
+	_ service.PrintServiceInterface         = (*mocks.PrintService)(nil)
+	_ service.SyncServiceInterface          = (*mocks.SyncService)(nil)
+	_ service.TrendingServiceInterface      = (*mocks.TrendingService)(nil)
+	_ service.TranslationServiceInterface   = (*mocks.TranslationService)(nil)
+	_ service.SearchServiceInterface        = (*mocks.SearchService)(nil)
+	_ service.WebhookServiceInterface       = (*mocks.WebhookService)(nil)
+	_ service.ExperimentServiceInterface    = (*mocks.ExperimentService)(nil)
+	_ service.DataExportServiceInterface    = (*mocks.DataExportService)(nil)
+	_ service.ErasureServiceInterface       = (*mocks.ErasureService)(nil)
+	_ service.AccountServiceInterface       = (*mocks.AccountService)(nil)
+	_ service.AdminServiceInterface         = (*mocks.AdminService)(nil)
+	_ service.OAuthServiceInterface         = (*mocks.OAuthService)(nil)
+	_ service.SessionServiceInterface       = (*mocks.SessionService)(nil)
+	_ service.TicketServiceInterface        = (*mocks.TicketService)(nil)
+	_ service.StockAlertServiceInterface    = (*mocks.StockAlertService)(nil)
+	_ service.EmailTemplateServiceInterface = (*mocks.EmailTemplateService)(nil)
+	_ service.NotificationServiceInterface  = (*mocks.NotificationService)(nil)
+	_ service.DeviceServiceInterface        = (*mocks.DeviceService)(nil)
+	_ service.APIKeyServiceInterface        = (*mocks.APIKeyService)(nil)
+	_ service.SearchIndex                   = (*mocks.SearchIndex)(nil)
+	_ service.CaptchaVerifier               = (*mocks.CaptchaVerifier)(nil)
+	_ service.EventPublisher                = (*mocks.EventPublisher)(nil)
+	_ service.EmailSender                   = (*mocks.EmailSender)(nil)
+	_ service.TicketPrinter                 = (*mocks.TicketPrinter)(nil)
+	_ service.PushSender                    = (*mocks.PushSender)(nil)
+)
+
+func TestUnexpectedCallPanics(t *testing.T) {
+	repo := &mocks.CupcakeRepository{}
+	require.PanicsWithValue(t, "mocks: unexpected call to CupcakeRepository.FindByID", func() {
+		repo.FindByID(1)
+	})
+
+	repo.FindByIDFunc = func(id uint) (*models.Cupcake, error) { return &models.Cupcake{ID: id}, nil }
+	cupcake, err := repo.FindByID(7)
+	require.NoError(t, err)
+	require.Equal(t, uint(7), cupcake.ID)
+}
@@ -0,0 +1,1809 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// CupcakeRepository is a mock of repository.CupcakeRepositoryInterface.
+type CupcakeRepository struct {
+	CreateFunc       func(cupcake *models.Cupcake) error
+	FindByIDFunc     func(id uint) (*models.Cupcake, error)
+	FindBySKUFunc    func(sku string) (*models.Cupcake, error)
+	FindBySlugFunc   func(slug string) (*models.Cupcake, error)
+	FindAllFunc      func() ([]models.Cupcake, error)
+	FindPageFunc     func(offset, limit int) ([]models.Cupcake, int64, error)
+	StreamFunc       func(fn func(*models.Cupcake) error) error
+	FindByFlavorFunc func(flavor string) ([]models.Cupcake, error)
+	FindRelatedFunc  func(id uint, limit int) ([]models.Cupcake, error)
+	SearchFunc       func(text string) ([]models.Cupcake, error)
+	UpdateFunc       func(cupcake *models.Cupcake) error
+	UpdatePricesFunc func(cupcakes []models.Cupcake) error
+	FindVersionsFunc func(cupcakeID uint) ([]models.CupcakeVersion, error)
+	FindVersionFunc  func(cupcakeID uint, version int) (*models.CupcakeVersion, error)
+	DeleteFunc       func(id uint) error
+	ExistsFunc       func(id uint) (bool, error)
+	FindChangesFunc  func(since uint, limit int) ([]models.CupcakeChange, error)
+	LastChangeIDFunc func() (uint, error)
+}
+
+var _ repository.CupcakeRepositoryInterface = (*CupcakeRepository)(nil)
+
+func (m *CupcakeRepository) Create(cupcake *models.Cupcake) error {
+	if m.CreateFunc == nil {
+		unexpected("CupcakeRepository.Create")
+	}
+	return m.CreateFunc(cupcake)
+}
+
+func (m *CupcakeRepository) FindByID(id uint) (*models.Cupcake, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("CupcakeRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *CupcakeRepository) FindBySKU(sku string) (*models.Cupcake, error) {
+	if m.FindBySKUFunc == nil {
+		unexpected("CupcakeRepository.FindBySKU")
+	}
+	return m.FindBySKUFunc(sku)
+}
+
+func (m *CupcakeRepository) FindBySlug(slug string) (*models.Cupcake, error) {
+	if m.FindBySlugFunc == nil {
+		unexpected("CupcakeRepository.FindBySlug")
+	}
+	return m.FindBySlugFunc(slug)
+}
+
+func (m *CupcakeRepository) FindAll() ([]models.Cupcake, error) {
+	if m.FindAllFunc == nil {
+		unexpected("CupcakeRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *CupcakeRepository) FindPage(offset, limit int) ([]models.Cupcake, int64, error) {
+	if m.FindPageFunc == nil {
+		unexpected("CupcakeRepository.FindPage")
+	}
+	return m.FindPageFunc(offset, limit)
+}
+
+func (m *CupcakeRepository) Stream(fn func(*models.Cupcake) error) error {
+	if m.StreamFunc == nil {
+		unexpected("CupcakeRepository.Stream")
+	}
+	return m.StreamFunc(fn)
+}
+
+func (m *CupcakeRepository) FindByFlavor(flavor string) ([]models.Cupcake, error) {
+	if m.FindByFlavorFunc == nil {
+		unexpected("CupcakeRepository.FindByFlavor")
+	}
+	return m.FindByFlavorFunc(flavor)
+}
+
+func (m *CupcakeRepository) FindRelated(id uint, limit int) ([]models.Cupcake, error) {
+	if m.FindRelatedFunc == nil {
+		unexpected("CupcakeRepository.FindRelated")
+	}
+	return m.FindRelatedFunc(id, limit)
+}
+
+func (m *CupcakeRepository) Search(text string) ([]models.Cupcake, error) {
+	if m.SearchFunc == nil {
+		unexpected("CupcakeRepository.Search")
+	}
+	return m.SearchFunc(text)
+}
+
+func (m *CupcakeRepository) Update(cupcake *models.Cupcake) error {
+	if m.UpdateFunc == nil {
+		unexpected("CupcakeRepository.Update")
+	}
+	return m.UpdateFunc(cupcake)
+}
+
+func (m *CupcakeRepository) UpdatePrices(cupcakes []models.Cupcake) error {
+	if m.UpdatePricesFunc == nil {
+		unexpected("CupcakeRepository.UpdatePrices")
+	}
+	return m.UpdatePricesFunc(cupcakes)
+}
+
+func (m *CupcakeRepository) FindVersions(cupcakeID uint) ([]models.CupcakeVersion, error) {
+	if m.FindVersionsFunc == nil {
+		unexpected("CupcakeRepository.FindVersions")
+	}
+	return m.FindVersionsFunc(cupcakeID)
+}
+
+func (m *CupcakeRepository) FindVersion(cupcakeID uint, version int) (*models.CupcakeVersion, error) {
+	if m.FindVersionFunc == nil {
+		unexpected("CupcakeRepository.FindVersion")
+	}
+	return m.FindVersionFunc(cupcakeID, version)
+}
+
+func (m *CupcakeRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("CupcakeRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+func (m *CupcakeRepository) Exists(id uint) (bool, error) {
+	if m.ExistsFunc == nil {
+		unexpected("CupcakeRepository.Exists")
+	}
+	return m.ExistsFunc(id)
+}
+
+func (m *CupcakeRepository) FindChanges(since uint, limit int) ([]models.CupcakeChange, error) {
+	if m.FindChangesFunc == nil {
+		unexpected("CupcakeRepository.FindChanges")
+	}
+	return m.FindChangesFunc(since, limit)
+}
+
+func (m *CupcakeRepository) LastChangeID() (uint, error) {
+	if m.LastChangeIDFunc == nil {
+		unexpected("CupcakeRepository.LastChangeID")
+	}
+	return m.LastChangeIDFunc()
+}
+
+// CouponRepository is a mock of repository.CouponRepositoryInterface.
+type CouponRepository struct {
+	CreateFunc            func(coupon *models.Coupon) error
+	FindByIDFunc          func(id uint) (*models.Coupon, error)
+	FindByCodeFunc        func(code string) (*models.Coupon, error)
+	FindAllFunc           func() ([]models.Coupon, error)
+	UpdateFunc            func(coupon *models.Coupon) error
+	DeleteFunc            func(id uint) error
+	RedeemFunc            func(redemption *models.CouponRedemption) error
+	DeactivateExpiredFunc func(at time.Time) (int64, error)
+}
+
+var _ repository.CouponRepositoryInterface = (*CouponRepository)(nil)
+
+func (m *CouponRepository) Create(coupon *models.Coupon) error {
+	if m.CreateFunc == nil {
+		unexpected("CouponRepository.Create")
+	}
+	return m.CreateFunc(coupon)
+}
+
+func (m *CouponRepository) FindByID(id uint) (*models.Coupon, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("CouponRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *CouponRepository) FindByCode(code string) (*models.Coupon, error) {
+	if m.FindByCodeFunc == nil {
+		unexpected("CouponRepository.FindByCode")
+	}
+	return m.FindByCodeFunc(code)
+}
+
+func (m *CouponRepository) FindAll() ([]models.Coupon, error) {
+	if m.FindAllFunc == nil {
+		unexpected("CouponRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *CouponRepository) Update(coupon *models.Coupon) error {
+	if m.UpdateFunc == nil {
+		unexpected("CouponRepository.Update")
+	}
+	return m.UpdateFunc(coupon)
+}
+
+func (m *CouponRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("CouponRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+func (m *CouponRepository) Redeem(redemption *models.CouponRedemption) error {
+	if m.RedeemFunc == nil {
+		unexpected("CouponRepository.Redeem")
+	}
+	return m.RedeemFunc(redemption)
+}
+
+func (m *CouponRepository) DeactivateExpired(at time.Time) (int64, error) {
+	if m.DeactivateExpiredFunc == nil {
+		unexpected("CouponRepository.DeactivateExpired")
+	}
+	return m.DeactivateExpiredFunc(at)
+}
+
+// PromotionRepository is a mock of repository.PromotionRepositoryInterface.
+type PromotionRepository struct {
+	CreateFunc        func(promotion *models.Promotion) error
+	FindByIDFunc      func(id uint) (*models.Promotion, error)
+	FindAllFunc       func() ([]models.Promotion, error)
+	FindActiveFunc    func(cupcakeIDs []uint, at time.Time) ([]models.Promotion, error)
+	FindAllActiveFunc func(at time.Time) ([]models.Promotion, error)
+	UpdateFunc        func(promotion *models.Promotion) error
+	DeleteFunc        func(id uint) error
+}
+
+var _ repository.PromotionRepositoryInterface = (*PromotionRepository)(nil)
+
+func (m *PromotionRepository) Create(promotion *models.Promotion) error {
+	if m.CreateFunc == nil {
+		unexpected("PromotionRepository.Create")
+	}
+	return m.CreateFunc(promotion)
+}
+
+func (m *PromotionRepository) FindByID(id uint) (*models.Promotion, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("PromotionRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *PromotionRepository) FindAll() ([]models.Promotion, error) {
+	if m.FindAllFunc == nil {
+		unexpected("PromotionRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *PromotionRepository) FindActive(cupcakeIDs []uint, at time.Time) ([]models.Promotion, error) {
+	if m.FindActiveFunc == nil {
+		unexpected("PromotionRepository.FindActive")
+	}
+	return m.FindActiveFunc(cupcakeIDs, at)
+}
+
+func (m *PromotionRepository) FindAllActive(at time.Time) ([]models.Promotion, error) {
+	if m.FindAllActiveFunc == nil {
+		unexpected("PromotionRepository.FindAllActive")
+	}
+	return m.FindAllActiveFunc(at)
+}
+
+func (m *PromotionRepository) Update(promotion *models.Promotion) error {
+	if m.UpdateFunc == nil {
+		unexpected("PromotionRepository.Update")
+	}
+	return m.UpdateFunc(promotion)
+}
+
+func (m *PromotionRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("PromotionRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+// GiftCardRepository is a mock of repository.GiftCardRepositoryInterface.
+type GiftCardRepository struct {
+	CreateFunc     func(giftCard *models.GiftCard) error
+	FindByIDFunc   func(id uint) (*models.GiftCard, error)
+	FindByCodeFunc func(code string) (*models.GiftCard, error)
+	FindAllFunc    func() ([]models.GiftCard, error)
+	VoidFunc       func(id uint, at time.Time) error
+	DebitFunc      func(redemption *models.GiftCardRedemption) error
+}
+
+var _ repository.GiftCardRepositoryInterface = (*GiftCardRepository)(nil)
+
+func (m *GiftCardRepository) Create(giftCard *models.GiftCard) error {
+	if m.CreateFunc == nil {
+		unexpected("GiftCardRepository.Create")
+	}
+	return m.CreateFunc(giftCard)
+}
+
+func (m *GiftCardRepository) FindByID(id uint) (*models.GiftCard, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("GiftCardRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *GiftCardRepository) FindByCode(code string) (*models.GiftCard, error) {
+	if m.FindByCodeFunc == nil {
+		unexpected("GiftCardRepository.FindByCode")
+	}
+	return m.FindByCodeFunc(code)
+}
+
+func (m *GiftCardRepository) FindAll() ([]models.GiftCard, error) {
+	if m.FindAllFunc == nil {
+		unexpected("GiftCardRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *GiftCardRepository) Void(id uint, at time.Time) error {
+	if m.VoidFunc == nil {
+		unexpected("GiftCardRepository.Void")
+	}
+	return m.VoidFunc(id, at)
+}
+
+func (m *GiftCardRepository) Debit(redemption *models.GiftCardRedemption) error {
+	if m.DebitFunc == nil {
+		unexpected("GiftCardRepository.Debit")
+	}
+	return m.DebitFunc(redemption)
+}
+
+// SubscriptionRepository is a mock of repository.SubscriptionRepositoryInterface.
+type SubscriptionRepository struct {
+	CreateFunc        func(subscription *models.Subscription) error
+	FindByIDFunc      func(id uint) (*models.Subscription, error)
+	FindAllFunc       func() ([]models.Subscription, error)
+	FindScheduledFunc func(from, to time.Time) ([]models.Subscription, error)
+	UpdateFunc        func(subscription *models.Subscription) error
+}
+
+var _ repository.SubscriptionRepositoryInterface = (*SubscriptionRepository)(nil)
+
+func (m *SubscriptionRepository) Create(subscription *models.Subscription) error {
+	if m.CreateFunc == nil {
+		unexpected("SubscriptionRepository.Create")
+	}
+	return m.CreateFunc(subscription)
+}
+
+func (m *SubscriptionRepository) FindByID(id uint) (*models.Subscription, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("SubscriptionRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *SubscriptionRepository) FindAll() ([]models.Subscription, error) {
+	if m.FindAllFunc == nil {
+		unexpected("SubscriptionRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *SubscriptionRepository) FindScheduled(from, to time.Time) ([]models.Subscription, error) {
+	if m.FindScheduledFunc == nil {
+		unexpected("SubscriptionRepository.FindScheduled")
+	}
+	return m.FindScheduledFunc(from, to)
+}
+
+func (m *SubscriptionRepository) Update(subscription *models.Subscription) error {
+	if m.UpdateFunc == nil {
+		unexpected("SubscriptionRepository.Update")
+	}
+	return m.UpdateFunc(subscription)
+}
+
+// WebhookRepository is a mock of repository.WebhookRepositoryInterface.
+type WebhookRepository struct {
+	CreateFunc         func(webhook *models.Webhook) error
+	FindByIDFunc       func(id uint) (*models.Webhook, error)
+	FindAllFunc        func() ([]models.Webhook, error)
+	FindActiveFunc     func() ([]models.Webhook, error)
+	UpdateFunc         func(webhook *models.Webhook) error
+	DeleteFunc         func(id uint) error
+	CreateDeliveryFunc func(delivery *models.WebhookDelivery) error
+	FindDeliveriesFunc func(webhookID uint) ([]models.WebhookDelivery, error)
+}
+
+var _ repository.WebhookRepositoryInterface = (*WebhookRepository)(nil)
+
+func (m *WebhookRepository) Create(webhook *models.Webhook) error {
+	if m.CreateFunc == nil {
+		unexpected("WebhookRepository.Create")
+	}
+	return m.CreateFunc(webhook)
+}
+
+func (m *WebhookRepository) FindByID(id uint) (*models.Webhook, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("WebhookRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *WebhookRepository) FindAll() ([]models.Webhook, error) {
+	if m.FindAllFunc == nil {
+		unexpected("WebhookRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *WebhookRepository) FindActive() ([]models.Webhook, error) {
+	if m.FindActiveFunc == nil {
+		unexpected("WebhookRepository.FindActive")
+	}
+	return m.FindActiveFunc()
+}
+
+func (m *WebhookRepository) Update(webhook *models.Webhook) error {
+	if m.UpdateFunc == nil {
+		unexpected("WebhookRepository.Update")
+	}
+	return m.UpdateFunc(webhook)
+}
+
+func (m *WebhookRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("WebhookRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+func (m *WebhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	if m.CreateDeliveryFunc == nil {
+		unexpected("WebhookRepository.CreateDelivery")
+	}
+	return m.CreateDeliveryFunc(delivery)
+}
+
+func (m *WebhookRepository) FindDeliveries(webhookID uint) ([]models.WebhookDelivery, error) {
+	if m.FindDeliveriesFunc == nil {
+		unexpected("WebhookRepository.FindDeliveries")
+	}
+	return m.FindDeliveriesFunc(webhookID)
+}
+
+// LocationRepository is a mock of repository.LocationRepositoryInterface.
+type LocationRepository struct {
+	CreateFunc       func(location *models.Location) error
+	FindByIDFunc     func(id uint) (*models.Location, error)
+	FindAllFunc      func() ([]models.Location, error)
+	UpdateFunc       func(location *models.Location) error
+	DeleteFunc       func(id uint) error
+	SetStockFunc     func(stock *models.LocationStock) error
+	FindStockFunc    func(locationID uint) ([]models.LocationStock, error)
+	FindLowStockFunc func() ([]models.LocationStock, error)
+}
+
+var _ repository.LocationRepositoryInterface = (*LocationRepository)(nil)
+
+func (m *LocationRepository) Create(location *models.Location) error {
+	if m.CreateFunc == nil {
+		unexpected("LocationRepository.Create")
+	}
+	return m.CreateFunc(location)
+}
+
+func (m *LocationRepository) FindByID(id uint) (*models.Location, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("LocationRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *LocationRepository) FindAll() ([]models.Location, error) {
+	if m.FindAllFunc == nil {
+		unexpected("LocationRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *LocationRepository) Update(location *models.Location) error {
+	if m.UpdateFunc == nil {
+		unexpected("LocationRepository.Update")
+	}
+	return m.UpdateFunc(location)
+}
+
+func (m *LocationRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("LocationRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+func (m *LocationRepository) SetStock(stock *models.LocationStock) error {
+	if m.SetStockFunc == nil {
+		unexpected("LocationRepository.SetStock")
+	}
+	return m.SetStockFunc(stock)
+}
+
+func (m *LocationRepository) FindStock(locationID uint) ([]models.LocationStock, error) {
+	if m.FindStockFunc == nil {
+		unexpected("LocationRepository.FindStock")
+	}
+	return m.FindStockFunc(locationID)
+}
+
+func (m *LocationRepository) FindLowStock() ([]models.LocationStock, error) {
+	if m.FindLowStockFunc == nil {
+		unexpected("LocationRepository.FindLowStock")
+	}
+	return m.FindLowStockFunc()
+}
+
+// StockAlertRepository is a mock of repository.StockAlertRepositoryInterface.
+type StockAlertRepository struct {
+	CreateFunc         func(alert *models.StockAlert) error
+	FindByIDFunc       func(id uint) (*models.StockAlert, error)
+	FindAllFunc        func(status string) ([]models.StockAlert, error)
+	FindUnresolvedFunc func() ([]models.StockAlert, error)
+	FindLatestFunc     func(locationID, cupcakeID uint) (*models.StockAlert, error)
+	UpdateFunc         func(alert *models.StockAlert) error
+}
+
+var _ repository.StockAlertRepositoryInterface = (*StockAlertRepository)(nil)
+
+func (m *StockAlertRepository) Create(alert *models.StockAlert) error {
+	if m.CreateFunc == nil {
+		unexpected("StockAlertRepository.Create")
+	}
+	return m.CreateFunc(alert)
+}
+
+func (m *StockAlertRepository) FindByID(id uint) (*models.StockAlert, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("StockAlertRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *StockAlertRepository) FindAll(status string) ([]models.StockAlert, error) {
+	if m.FindAllFunc == nil {
+		unexpected("StockAlertRepository.FindAll")
+	}
+	return m.FindAllFunc(status)
+}
+
+func (m *StockAlertRepository) FindUnresolved() ([]models.StockAlert, error) {
+	if m.FindUnresolvedFunc == nil {
+		unexpected("StockAlertRepository.FindUnresolved")
+	}
+	return m.FindUnresolvedFunc()
+}
+
+func (m *StockAlertRepository) FindLatest(locationID, cupcakeID uint) (*models.StockAlert, error) {
+	if m.FindLatestFunc == nil {
+		unexpected("StockAlertRepository.FindLatest")
+	}
+	return m.FindLatestFunc(locationID, cupcakeID)
+}
+
+func (m *StockAlertRepository) Update(alert *models.StockAlert) error {
+	if m.UpdateFunc == nil {
+		unexpected("StockAlertRepository.Update")
+	}
+	return m.UpdateFunc(alert)
+}
+
+// BundleRepository is a mock of repository.BundleRepositoryInterface.
+type BundleRepository struct {
+	CreateFunc     func(bundle *models.Bundle) error
+	FindByIDFunc   func(id uint) (*models.Bundle, error)
+	FindByNameFunc func(name string) (*models.Bundle, error)
+	FindAllFunc    func() ([]models.Bundle, error)
+	UpdateFunc     func(bundle *models.Bundle) error
+	DeleteFunc     func(id uint) error
+}
+
+var _ repository.BundleRepositoryInterface = (*BundleRepository)(nil)
+
+func (m *BundleRepository) Create(bundle *models.Bundle) error {
+	if m.CreateFunc == nil {
+		unexpected("BundleRepository.Create")
+	}
+	return m.CreateFunc(bundle)
+}
+
+func (m *BundleRepository) FindByID(id uint) (*models.Bundle, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("BundleRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *BundleRepository) FindByName(name string) (*models.Bundle, error) {
+	if m.FindByNameFunc == nil {
+		unexpected("BundleRepository.FindByName")
+	}
+	return m.FindByNameFunc(name)
+}
+
+func (m *BundleRepository) FindAll() ([]models.Bundle, error) {
+	if m.FindAllFunc == nil {
+		unexpected("BundleRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *BundleRepository) Update(bundle *models.Bundle) error {
+	if m.UpdateFunc == nil {
+		unexpected("BundleRepository.Update")
+	}
+	return m.UpdateFunc(bundle)
+}
+
+func (m *BundleRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("BundleRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+// WholesaleRepository is a mock of repository.WholesaleRepositoryInterface.
+type WholesaleRepository struct {
+	CreateFunc      func(account *models.WholesaleAccount) error
+	FindByIDFunc    func(id uint) (*models.WholesaleAccount, error)
+	FindByEmailFunc func(email string) (*models.WholesaleAccount, error)
+	FindAllFunc     func() ([]models.WholesaleAccount, error)
+	UpdateFunc      func(account *models.WholesaleAccount) error
+	DeleteFunc      func(id uint) error
+	SetPriceFunc    func(price *models.WholesalePrice) error
+	DeletePriceFunc func(accountID, cupcakeID uint) error
+	FindPricesFunc  func(accountID uint) ([]models.WholesalePrice, error)
+}
+
+var _ repository.WholesaleRepositoryInterface = (*WholesaleRepository)(nil)
+
+func (m *WholesaleRepository) Create(account *models.WholesaleAccount) error {
+	if m.CreateFunc == nil {
+		unexpected("WholesaleRepository.Create")
+	}
+	return m.CreateFunc(account)
+}
+
+func (m *WholesaleRepository) FindByID(id uint) (*models.WholesaleAccount, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("WholesaleRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *WholesaleRepository) FindByEmail(email string) (*models.WholesaleAccount, error) {
+	if m.FindByEmailFunc == nil {
+		unexpected("WholesaleRepository.FindByEmail")
+	}
+	return m.FindByEmailFunc(email)
+}
+
+func (m *WholesaleRepository) FindAll() ([]models.WholesaleAccount, error) {
+	if m.FindAllFunc == nil {
+		unexpected("WholesaleRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *WholesaleRepository) Update(account *models.WholesaleAccount) error {
+	if m.UpdateFunc == nil {
+		unexpected("WholesaleRepository.Update")
+	}
+	return m.UpdateFunc(account)
+}
+
+func (m *WholesaleRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("WholesaleRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+func (m *WholesaleRepository) SetPrice(price *models.WholesalePrice) error {
+	if m.SetPriceFunc == nil {
+		unexpected("WholesaleRepository.SetPrice")
+	}
+	return m.SetPriceFunc(price)
+}
+
+func (m *WholesaleRepository) DeletePrice(accountID, cupcakeID uint) error {
+	if m.DeletePriceFunc == nil {
+		unexpected("WholesaleRepository.DeletePrice")
+	}
+	return m.DeletePriceFunc(accountID, cupcakeID)
+}
+
+func (m *WholesaleRepository) FindPrices(accountID uint) ([]models.WholesalePrice, error) {
+	if m.FindPricesFunc == nil {
+		unexpected("WholesaleRepository.FindPrices")
+	}
+	return m.FindPricesFunc(accountID)
+}
+
+// SupplierRepository is a mock of repository.SupplierRepositoryInterface.
+type SupplierRepository struct {
+	CreateFunc     func(supplier *models.Supplier) error
+	FindByIDFunc   func(id uint) (*models.Supplier, error)
+	FindByNameFunc func(name string) (*models.Supplier, error)
+	FindAllFunc    func() ([]models.Supplier, error)
+	UpdateFunc     func(supplier *models.Supplier) error
+}
+
+var _ repository.SupplierRepositoryInterface = (*SupplierRepository)(nil)
+
+func (m *SupplierRepository) Create(supplier *models.Supplier) error {
+	if m.CreateFunc == nil {
+		unexpected("SupplierRepository.Create")
+	}
+	return m.CreateFunc(supplier)
+}
+
+func (m *SupplierRepository) FindByID(id uint) (*models.Supplier, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("SupplierRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *SupplierRepository) FindByName(name string) (*models.Supplier, error) {
+	if m.FindByNameFunc == nil {
+		unexpected("SupplierRepository.FindByName")
+	}
+	return m.FindByNameFunc(name)
+}
+
+func (m *SupplierRepository) FindAll() ([]models.Supplier, error) {
+	if m.FindAllFunc == nil {
+		unexpected("SupplierRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *SupplierRepository) Update(supplier *models.Supplier) error {
+	if m.UpdateFunc == nil {
+		unexpected("SupplierRepository.Update")
+	}
+	return m.UpdateFunc(supplier)
+}
+
+// IngredientRepository is a mock of repository.IngredientRepositoryInterface.
+type IngredientRepository struct {
+	CreateFunc     func(ingredient *models.Ingredient) error
+	FindByIDFunc   func(id uint) (*models.Ingredient, error)
+	FindByIDsFunc  func(ids []uint) ([]models.Ingredient, error)
+	FindByNameFunc func(name string) (*models.Ingredient, error)
+	FindAllFunc    func() ([]models.Ingredient, error)
+	UpdateFunc     func(ingredient *models.Ingredient) error
+}
+
+var _ repository.IngredientRepositoryInterface = (*IngredientRepository)(nil)
+
+func (m *IngredientRepository) Create(ingredient *models.Ingredient) error {
+	if m.CreateFunc == nil {
+		unexpected("IngredientRepository.Create")
+	}
+	return m.CreateFunc(ingredient)
+}
+
+func (m *IngredientRepository) FindByID(id uint) (*models.Ingredient, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("IngredientRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *IngredientRepository) FindByIDs(ids []uint) ([]models.Ingredient, error) {
+	if m.FindByIDsFunc == nil {
+		unexpected("IngredientRepository.FindByIDs")
+	}
+	return m.FindByIDsFunc(ids)
+}
+
+func (m *IngredientRepository) FindByName(name string) (*models.Ingredient, error) {
+	if m.FindByNameFunc == nil {
+		unexpected("IngredientRepository.FindByName")
+	}
+	return m.FindByNameFunc(name)
+}
+
+func (m *IngredientRepository) FindAll() ([]models.Ingredient, error) {
+	if m.FindAllFunc == nil {
+		unexpected("IngredientRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *IngredientRepository) Update(ingredient *models.Ingredient) error {
+	if m.UpdateFunc == nil {
+		unexpected("IngredientRepository.Update")
+	}
+	return m.UpdateFunc(ingredient)
+}
+
+// PurchaseOrderRepository is a mock of repository.PurchaseOrderRepositoryInterface.
+type PurchaseOrderRepository struct {
+	CreateFunc   func(order *models.PurchaseOrder) error
+	FindByIDFunc func(id uint) (*models.PurchaseOrder, error)
+	FindAllFunc  func(status string) ([]models.PurchaseOrder, error)
+	ReceiveFunc  func(order *models.PurchaseOrder, at time.Time) error
+	CancelFunc   func(id uint) error
+}
+
+var _ repository.PurchaseOrderRepositoryInterface = (*PurchaseOrderRepository)(nil)
+
+func (m *PurchaseOrderRepository) Create(order *models.PurchaseOrder) error {
+	if m.CreateFunc == nil {
+		unexpected("PurchaseOrderRepository.Create")
+	}
+	return m.CreateFunc(order)
+}
+
+func (m *PurchaseOrderRepository) FindByID(id uint) (*models.PurchaseOrder, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("PurchaseOrderRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *PurchaseOrderRepository) FindAll(status string) ([]models.PurchaseOrder, error) {
+	if m.FindAllFunc == nil {
+		unexpected("PurchaseOrderRepository.FindAll")
+	}
+	return m.FindAllFunc(status)
+}
+
+func (m *PurchaseOrderRepository) Receive(order *models.PurchaseOrder, at time.Time) error {
+	if m.ReceiveFunc == nil {
+		unexpected("PurchaseOrderRepository.Receive")
+	}
+	return m.ReceiveFunc(order, at)
+}
+
+func (m *PurchaseOrderRepository) Cancel(id uint) error {
+	if m.CancelFunc == nil {
+		unexpected("PurchaseOrderRepository.Cancel")
+	}
+	return m.CancelFunc(id)
+}
+
+// RecipeRepository is a mock of repository.RecipeRepositoryInterface.
+type RecipeRepository struct {
+	FindRecipeFunc       func(cupcakeID uint) ([]models.RecipeIngredient, error)
+	SetRecipeFunc        func(cupcakeID uint, lines []models.RecipeIngredient) error
+	RecordProductionFunc func(batch *models.ProductionBatch, lines []models.RecipeIngredient) error
+	FindBatchesFunc      func(cupcakeID uint) ([]models.ProductionBatch, error)
+}
+
+var _ repository.RecipeRepositoryInterface = (*RecipeRepository)(nil)
+
+func (m *RecipeRepository) FindRecipe(cupcakeID uint) ([]models.RecipeIngredient, error) {
+	if m.FindRecipeFunc == nil {
+		unexpected("RecipeRepository.FindRecipe")
+	}
+	return m.FindRecipeFunc(cupcakeID)
+}
+
+func (m *RecipeRepository) SetRecipe(cupcakeID uint, lines []models.RecipeIngredient) error {
+	if m.SetRecipeFunc == nil {
+		unexpected("RecipeRepository.SetRecipe")
+	}
+	return m.SetRecipeFunc(cupcakeID, lines)
+}
+
+func (m *RecipeRepository) RecordProduction(batch *models.ProductionBatch, lines []models.RecipeIngredient) error {
+	if m.RecordProductionFunc == nil {
+		unexpected("RecipeRepository.RecordProduction")
+	}
+	return m.RecordProductionFunc(batch, lines)
+}
+
+func (m *RecipeRepository) FindBatches(cupcakeID uint) ([]models.ProductionBatch, error) {
+	if m.FindBatchesFunc == nil {
+		unexpected("RecipeRepository.FindBatches")
+	}
+	return m.FindBatchesFunc(cupcakeID)
+}
+
+// TranslationRepository is a mock of repository.TranslationRepositoryInterface.
+type TranslationRepository struct {
+	SetFunc             func(translation *models.CupcakeTranslation) error
+	DeleteFunc          func(cupcakeID uint, locale string) error
+	FindByCupcakeFunc   func(cupcakeID uint) ([]models.CupcakeTranslation, error)
+	FindForCupcakesFunc func(cupcakeIDs []uint, locales []string) ([]models.CupcakeTranslation, error)
+}
+
+var _ repository.TranslationRepositoryInterface = (*TranslationRepository)(nil)
+
+func (m *TranslationRepository) Set(translation *models.CupcakeTranslation) error {
+	if m.SetFunc == nil {
+		unexpected("TranslationRepository.Set")
+	}
+	return m.SetFunc(translation)
+}
+
+func (m *TranslationRepository) Delete(cupcakeID uint, locale string) error {
+	if m.DeleteFunc == nil {
+		unexpected("TranslationRepository.Delete")
+	}
+	return m.DeleteFunc(cupcakeID, locale)
+}
+
+func (m *TranslationRepository) FindByCupcake(cupcakeID uint) ([]models.CupcakeTranslation, error) {
+	if m.FindByCupcakeFunc == nil {
+		unexpected("TranslationRepository.FindByCupcake")
+	}
+	return m.FindByCupcakeFunc(cupcakeID)
+}
+
+func (m *TranslationRepository) FindForCupcakes(cupcakeIDs []uint, locales []string) ([]models.CupcakeTranslation, error) {
+	if m.FindForCupcakesFunc == nil {
+		unexpected("TranslationRepository.FindForCupcakes")
+	}
+	return m.FindForCupcakesFunc(cupcakeIDs, locales)
+}
+
+// ViewRepository is a mock of repository.ViewRepositoryInterface.
+type ViewRepository struct {
+	AddViewsFunc       func(day string, counts map[uint]int) error
+	FindMostViewedFunc func(since string, limit int) ([]models.CupcakeViewTotal, error)
+}
+
+var _ repository.ViewRepositoryInterface = (*ViewRepository)(nil)
+
+func (m *ViewRepository) AddViews(day string, counts map[uint]int) error {
+	if m.AddViewsFunc == nil {
+		unexpected("ViewRepository.AddViews")
+	}
+	return m.AddViewsFunc(day, counts)
+}
+
+func (m *ViewRepository) FindMostViewed(since string, limit int) ([]models.CupcakeViewTotal, error) {
+	if m.FindMostViewedFunc == nil {
+		unexpected("ViewRepository.FindMostViewed")
+	}
+	return m.FindMostViewedFunc(since, limit)
+}
+
+// AddonRepository is a mock of repository.AddonRepositoryInterface.
+type AddonRepository struct {
+	CreateFunc     func(addon *models.Addon) error
+	FindByIDFunc   func(id uint) (*models.Addon, error)
+	FindByNameFunc func(name string) (*models.Addon, error)
+	FindAllFunc    func() ([]models.Addon, error)
+	UpdateFunc     func(addon *models.Addon) error
+	DeleteFunc     func(id uint) error
+}
+
+var _ repository.AddonRepositoryInterface = (*AddonRepository)(nil)
+
+func (m *AddonRepository) Create(addon *models.Addon) error {
+	if m.CreateFunc == nil {
+		unexpected("AddonRepository.Create")
+	}
+	return m.CreateFunc(addon)
+}
+
+func (m *AddonRepository) FindByID(id uint) (*models.Addon, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("AddonRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *AddonRepository) FindByName(name string) (*models.Addon, error) {
+	if m.FindByNameFunc == nil {
+		unexpected("AddonRepository.FindByName")
+	}
+	return m.FindByNameFunc(name)
+}
+
+func (m *AddonRepository) FindAll() ([]models.Addon, error) {
+	if m.FindAllFunc == nil {
+		unexpected("AddonRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *AddonRepository) Update(addon *models.Addon) error {
+	if m.UpdateFunc == nil {
+		unexpected("AddonRepository.Update")
+	}
+	return m.UpdateFunc(addon)
+}
+
+func (m *AddonRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("AddonRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+// CustomOptionRepository is a mock of repository.CustomOptionRepositoryInterface.
+type CustomOptionRepository struct {
+	CreateFunc    func(option *models.CustomOption) error
+	FindByIDFunc  func(id uint) (*models.CustomOption, error)
+	FindByIDsFunc func(ids []uint) ([]models.CustomOption, error)
+	FindAllFunc   func() ([]models.CustomOption, error)
+	UpdateFunc    func(option *models.CustomOption) error
+	DeleteFunc    func(id uint) error
+}
+
+var _ repository.CustomOptionRepositoryInterface = (*CustomOptionRepository)(nil)
+
+func (m *CustomOptionRepository) Create(option *models.CustomOption) error {
+	if m.CreateFunc == nil {
+		unexpected("CustomOptionRepository.Create")
+	}
+	return m.CreateFunc(option)
+}
+
+func (m *CustomOptionRepository) FindByID(id uint) (*models.CustomOption, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("CustomOptionRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *CustomOptionRepository) FindByIDs(ids []uint) ([]models.CustomOption, error) {
+	if m.FindByIDsFunc == nil {
+		unexpected("CustomOptionRepository.FindByIDs")
+	}
+	return m.FindByIDsFunc(ids)
+}
+
+func (m *CustomOptionRepository) FindAll() ([]models.CustomOption, error) {
+	if m.FindAllFunc == nil {
+		unexpected("CustomOptionRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *CustomOptionRepository) Update(option *models.CustomOption) error {
+	if m.UpdateFunc == nil {
+		unexpected("CustomOptionRepository.Update")
+	}
+	return m.UpdateFunc(option)
+}
+
+func (m *CustomOptionRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("CustomOptionRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+// PickupRepository is a mock of repository.PickupRepositoryInterface.
+type PickupRepository struct {
+	CreateSlotFunc              func(slot *models.PickupSlot) error
+	FindSlotFunc                func(id uint) (*models.PickupSlot, error)
+	FindSlotsFunc               func(locationID uint, from, to time.Time) ([]models.PickupSlot, error)
+	ReserveFunc                 func(reservation *models.PickupReservation) error
+	FindReservationFunc         func(id uint) (*models.PickupReservation, error)
+	FindReservationsFunc        func(slotIDs []uint) ([]models.PickupReservation, error)
+	FindReservationsBetweenFunc func(from, to time.Time) ([]models.PickupReservation, error)
+	FindOpenReservationsFunc    func(from, to time.Time) ([]models.PickupReservation, error)
+	MarkReadyFunc               func(id uint, at time.Time) error
+	MarkStartedFunc             func(id uint, at time.Time) error
+	CancelFunc                  func(id uint, reason string, at time.Time) error
+}
+
+var _ repository.PickupRepositoryInterface = (*PickupRepository)(nil)
+
+func (m *PickupRepository) CreateSlot(slot *models.PickupSlot) error {
+	if m.CreateSlotFunc == nil {
+		unexpected("PickupRepository.CreateSlot")
+	}
+	return m.CreateSlotFunc(slot)
+}
+
+func (m *PickupRepository) FindSlot(id uint) (*models.PickupSlot, error) {
+	if m.FindSlotFunc == nil {
+		unexpected("PickupRepository.FindSlot")
+	}
+	return m.FindSlotFunc(id)
+}
+
+func (m *PickupRepository) FindSlots(locationID uint, from, to time.Time) ([]models.PickupSlot, error) {
+	if m.FindSlotsFunc == nil {
+		unexpected("PickupRepository.FindSlots")
+	}
+	return m.FindSlotsFunc(locationID, from, to)
+}
+
+func (m *PickupRepository) Reserve(reservation *models.PickupReservation) error {
+	if m.ReserveFunc == nil {
+		unexpected("PickupRepository.Reserve")
+	}
+	return m.ReserveFunc(reservation)
+}
+
+func (m *PickupRepository) FindReservation(id uint) (*models.PickupReservation, error) {
+	if m.FindReservationFunc == nil {
+		unexpected("PickupRepository.FindReservation")
+	}
+	return m.FindReservationFunc(id)
+}
+
+func (m *PickupRepository) FindReservations(slotIDs []uint) ([]models.PickupReservation, error) {
+	if m.FindReservationsFunc == nil {
+		unexpected("PickupRepository.FindReservations")
+	}
+	return m.FindReservationsFunc(slotIDs)
+}
+
+func (m *PickupRepository) FindReservationsBetween(from, to time.Time) ([]models.PickupReservation, error) {
+	if m.FindReservationsBetweenFunc == nil {
+		unexpected("PickupRepository.FindReservationsBetween")
+	}
+	return m.FindReservationsBetweenFunc(from, to)
+}
+
+func (m *PickupRepository) FindOpenReservations(from, to time.Time) ([]models.PickupReservation, error) {
+	if m.FindOpenReservationsFunc == nil {
+		unexpected("PickupRepository.FindOpenReservations")
+	}
+	return m.FindOpenReservationsFunc(from, to)
+}
+
+func (m *PickupRepository) MarkReady(id uint, at time.Time) error {
+	if m.MarkReadyFunc == nil {
+		unexpected("PickupRepository.MarkReady")
+	}
+	return m.MarkReadyFunc(id, at)
+}
+
+func (m *PickupRepository) MarkStarted(id uint, at time.Time) error {
+	if m.MarkStartedFunc == nil {
+		unexpected("PickupRepository.MarkStarted")
+	}
+	return m.MarkStartedFunc(id, at)
+}
+
+func (m *PickupRepository) Cancel(id uint, reason string, at time.Time) error {
+	if m.CancelFunc == nil {
+		unexpected("PickupRepository.Cancel")
+	}
+	return m.CancelFunc(id, reason, at)
+}
+
+// JobRepository is a mock of repository.JobRepositoryInterface.
+type JobRepository struct {
+	CreateFunc                func(job *models.Job) error
+	ClaimNextFunc             func(now time.Time, lease time.Duration) (*models.Job, error)
+	UpdateFunc                func(job *models.Job) error
+	FindByStatusFunc          func(status string) ([]models.Job, error)
+	DeleteCompletedBeforeFunc func(before time.Time) (int64, error)
+}
+
+var _ repository.JobRepositoryInterface = (*JobRepository)(nil)
+
+func (m *JobRepository) Create(job *models.Job) error {
+	if m.CreateFunc == nil {
+		unexpected("JobRepository.Create")
+	}
+	return m.CreateFunc(job)
+}
+
+func (m *JobRepository) ClaimNext(now time.Time, lease time.Duration) (*models.Job, error) {
+	if m.ClaimNextFunc == nil {
+		unexpected("JobRepository.ClaimNext")
+	}
+	return m.ClaimNextFunc(now, lease)
+}
+
+func (m *JobRepository) Update(job *models.Job) error {
+	if m.UpdateFunc == nil {
+		unexpected("JobRepository.Update")
+	}
+	return m.UpdateFunc(job)
+}
+
+func (m *JobRepository) FindByStatus(status string) ([]models.Job, error) {
+	if m.FindByStatusFunc == nil {
+		unexpected("JobRepository.FindByStatus")
+	}
+	return m.FindByStatusFunc(status)
+}
+
+func (m *JobRepository) DeleteCompletedBefore(before time.Time) (int64, error) {
+	if m.DeleteCompletedBeforeFunc == nil {
+		unexpected("JobRepository.DeleteCompletedBefore")
+	}
+	return m.DeleteCompletedBeforeFunc(before)
+}
+
+// ExperimentRepository is a mock of repository.ExperimentRepositoryInterface.
+type ExperimentRepository struct {
+	CreateFunc           func(experiment *models.Experiment) error
+	FindByIDFunc         func(id uint) (*models.Experiment, error)
+	FindByKeyFunc        func(key string) (*models.Experiment, error)
+	FindAllFunc          func() ([]models.Experiment, error)
+	FindActiveFunc       func() ([]models.Experiment, error)
+	UpdateFunc           func(experiment *models.Experiment) error
+	RecordExposuresFunc  func(exposures []models.ExperimentExposure) error
+	RecordConversionFunc func(conversion *models.ExperimentConversion) error
+	ResultsFunc          func(experimentID uint) ([]models.VariantResults, error)
+}
+
+var _ repository.ExperimentRepositoryInterface = (*ExperimentRepository)(nil)
+
+func (m *ExperimentRepository) Create(experiment *models.Experiment) error {
+	if m.CreateFunc == nil {
+		unexpected("ExperimentRepository.Create")
+	}
+	return m.CreateFunc(experiment)
+}
+
+func (m *ExperimentRepository) FindByID(id uint) (*models.Experiment, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("ExperimentRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *ExperimentRepository) FindByKey(key string) (*models.Experiment, error) {
+	if m.FindByKeyFunc == nil {
+		unexpected("ExperimentRepository.FindByKey")
+	}
+	return m.FindByKeyFunc(key)
+}
+
+func (m *ExperimentRepository) FindAll() ([]models.Experiment, error) {
+	if m.FindAllFunc == nil {
+		unexpected("ExperimentRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *ExperimentRepository) FindActive() ([]models.Experiment, error) {
+	if m.FindActiveFunc == nil {
+		unexpected("ExperimentRepository.FindActive")
+	}
+	return m.FindActiveFunc()
+}
+
+func (m *ExperimentRepository) Update(experiment *models.Experiment) error {
+	if m.UpdateFunc == nil {
+		unexpected("ExperimentRepository.Update")
+	}
+	return m.UpdateFunc(experiment)
+}
+
+func (m *ExperimentRepository) RecordExposures(exposures []models.ExperimentExposure) error {
+	if m.RecordExposuresFunc == nil {
+		unexpected("ExperimentRepository.RecordExposures")
+	}
+	return m.RecordExposuresFunc(exposures)
+}
+
+func (m *ExperimentRepository) RecordConversion(conversion *models.ExperimentConversion) error {
+	if m.RecordConversionFunc == nil {
+		unexpected("ExperimentRepository.RecordConversion")
+	}
+	return m.RecordConversionFunc(conversion)
+}
+
+func (m *ExperimentRepository) Results(experimentID uint) ([]models.VariantResults, error) {
+	if m.ResultsFunc == nil {
+		unexpected("ExperimentRepository.Results")
+	}
+	return m.ResultsFunc(experimentID)
+}
+
+// DataExportRepository is a mock of repository.DataExportRepositoryInterface.
+type DataExportRepository struct {
+	CreateFunc           func(export *models.DataExport) error
+	FindByIDFunc         func(id uint) (*models.DataExport, error)
+	FindPendingFunc      func(email string) (*models.DataExport, error)
+	UpdateFunc           func(export *models.DataExport) error
+	DeleteExpiredFunc    func(now time.Time) (int64, error)
+	FindCustomerDataFunc func(email string) (*models.CustomerData, error)
+}
+
+var _ repository.DataExportRepositoryInterface = (*DataExportRepository)(nil)
+
+func (m *DataExportRepository) Create(export *models.DataExport) error {
+	if m.CreateFunc == nil {
+		unexpected("DataExportRepository.Create")
+	}
+	return m.CreateFunc(export)
+}
+
+func (m *DataExportRepository) FindByID(id uint) (*models.DataExport, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("DataExportRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *DataExportRepository) FindPending(email string) (*models.DataExport, error) {
+	if m.FindPendingFunc == nil {
+		unexpected("DataExportRepository.FindPending")
+	}
+	return m.FindPendingFunc(email)
+}
+
+func (m *DataExportRepository) Update(export *models.DataExport) error {
+	if m.UpdateFunc == nil {
+		unexpected("DataExportRepository.Update")
+	}
+	return m.UpdateFunc(export)
+}
+
+func (m *DataExportRepository) DeleteExpired(now time.Time) (int64, error) {
+	if m.DeleteExpiredFunc == nil {
+		unexpected("DataExportRepository.DeleteExpired")
+	}
+	return m.DeleteExpiredFunc(now)
+}
+
+func (m *DataExportRepository) FindCustomerData(email string) (*models.CustomerData, error) {
+	if m.FindCustomerDataFunc == nil {
+		unexpected("DataExportRepository.FindCustomerData")
+	}
+	return m.FindCustomerDataFunc(email)
+}
+
+// UnitOfWork is a mock of repository.UnitOfWork.
+type UnitOfWork struct {
+	WithTransactionFunc func(ctx context.Context, fn func(tx repository.Repositories) error) error
+}
+
+var _ repository.UnitOfWork = (*UnitOfWork)(nil)
+
+func (m *UnitOfWork) WithTransaction(ctx context.Context, fn func(tx repository.Repositories) error) error {
+	if m.WithTransactionFunc == nil {
+		unexpected("UnitOfWork.WithTransaction")
+	}
+	return m.WithTransactionFunc(ctx, fn)
+}
+
+// ErasureRepository is a mock of repository.ErasureRepositoryInterface.
+type ErasureRepository struct {
+	EraseFunc           func(email string, erasure *models.Erasure) error
+	FindAllFunc         func() ([]models.Erasure, error)
+	FindByEmailHashFunc func(hash string) ([]models.Erasure, error)
+}
+
+var _ repository.ErasureRepositoryInterface = (*ErasureRepository)(nil)
+
+func (m *ErasureRepository) Erase(email string, erasure *models.Erasure) error {
+	if m.EraseFunc == nil {
+		unexpected("ErasureRepository.Erase")
+	}
+	return m.EraseFunc(email, erasure)
+}
+
+func (m *ErasureRepository) FindAll() ([]models.Erasure, error) {
+	if m.FindAllFunc == nil {
+		unexpected("ErasureRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *ErasureRepository) FindByEmailHash(hash string) ([]models.Erasure, error) {
+	if m.FindByEmailHashFunc == nil {
+		unexpected("ErasureRepository.FindByEmailHash")
+	}
+	return m.FindByEmailHashFunc(hash)
+}
+
+// AccountRepository is a mock of repository.AccountRepositoryInterface.
+type AccountRepository struct {
+	CreateFunc             func(account *models.Account) error
+	FindByIDFunc           func(id uint) (*models.Account, error)
+	FindByEmailFunc        func(email string) (*models.Account, error)
+	UpdatePasswordHashFunc func(id uint, hash string) error
+	MarkEmailVerifiedFunc  func(id uint, at time.Time) error
+	CreateWithIdentityFunc func(account *models.Account, identity *models.AccountIdentity) error
+	FindByIdentityFunc     func(provider, subject string) (*models.Account, error)
+	FindIdentitiesFunc     func(accountID uint) ([]models.AccountIdentity, error)
+	CreateIdentityFunc     func(identity *models.AccountIdentity) error
+	DeleteIdentityFunc     func(accountID uint, provider string) error
+}
+
+var _ repository.AccountRepositoryInterface = (*AccountRepository)(nil)
+
+func (m *AccountRepository) Create(account *models.Account) error {
+	if m.CreateFunc == nil {
+		unexpected("AccountRepository.Create")
+	}
+	return m.CreateFunc(account)
+}
+
+func (m *AccountRepository) FindByID(id uint) (*models.Account, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("AccountRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *AccountRepository) FindByEmail(email string) (*models.Account, error) {
+	if m.FindByEmailFunc == nil {
+		unexpected("AccountRepository.FindByEmail")
+	}
+	return m.FindByEmailFunc(email)
+}
+
+func (m *AccountRepository) UpdatePasswordHash(id uint, hash string) error {
+	if m.UpdatePasswordHashFunc == nil {
+		unexpected("AccountRepository.UpdatePasswordHash")
+	}
+	return m.UpdatePasswordHashFunc(id, hash)
+}
+
+func (m *AccountRepository) MarkEmailVerified(id uint, at time.Time) error {
+	if m.MarkEmailVerifiedFunc == nil {
+		unexpected("AccountRepository.MarkEmailVerified")
+	}
+	return m.MarkEmailVerifiedFunc(id, at)
+}
+
+func (m *AccountRepository) CreateWithIdentity(account *models.Account, identity *models.AccountIdentity) error {
+	if m.CreateWithIdentityFunc == nil {
+		unexpected("AccountRepository.CreateWithIdentity")
+	}
+	return m.CreateWithIdentityFunc(account, identity)
+}
+
+func (m *AccountRepository) FindByIdentity(provider, subject string) (*models.Account, error) {
+	if m.FindByIdentityFunc == nil {
+		unexpected("AccountRepository.FindByIdentity")
+	}
+	return m.FindByIdentityFunc(provider, subject)
+}
+
+func (m *AccountRepository) FindIdentities(accountID uint) ([]models.AccountIdentity, error) {
+	if m.FindIdentitiesFunc == nil {
+		unexpected("AccountRepository.FindIdentities")
+	}
+	return m.FindIdentitiesFunc(accountID)
+}
+
+func (m *AccountRepository) CreateIdentity(identity *models.AccountIdentity) error {
+	if m.CreateIdentityFunc == nil {
+		unexpected("AccountRepository.CreateIdentity")
+	}
+	return m.CreateIdentityFunc(identity)
+}
+
+func (m *AccountRepository) DeleteIdentity(accountID uint, provider string) error {
+	if m.DeleteIdentityFunc == nil {
+		unexpected("AccountRepository.DeleteIdentity")
+	}
+	return m.DeleteIdentityFunc(accountID, provider)
+}
+
+// SessionRepository is a mock of repository.SessionRepositoryInterface.
+type SessionRepository struct {
+	CreateFunc          func(session *models.Session) error
+	FindByTokenHashFunc func(hash string) (*models.Session, error)
+	ExtendFunc          func(id uint, expiresAt time.Time) error
+	DeleteFunc          func(id uint) error
+	DeleteExpiredFunc   func(now time.Time) (int64, error)
+}
+
+var _ repository.SessionRepositoryInterface = (*SessionRepository)(nil)
+
+func (m *SessionRepository) Create(session *models.Session) error {
+	if m.CreateFunc == nil {
+		unexpected("SessionRepository.Create")
+	}
+	return m.CreateFunc(session)
+}
+
+func (m *SessionRepository) FindByTokenHash(hash string) (*models.Session, error) {
+	if m.FindByTokenHashFunc == nil {
+		unexpected("SessionRepository.FindByTokenHash")
+	}
+	return m.FindByTokenHashFunc(hash)
+}
+
+func (m *SessionRepository) Extend(id uint, expiresAt time.Time) error {
+	if m.ExtendFunc == nil {
+		unexpected("SessionRepository.Extend")
+	}
+	return m.ExtendFunc(id, expiresAt)
+}
+
+func (m *SessionRepository) Delete(id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("SessionRepository.Delete")
+	}
+	return m.DeleteFunc(id)
+}
+
+func (m *SessionRepository) DeleteExpired(now time.Time) (int64, error) {
+	if m.DeleteExpiredFunc == nil {
+		unexpected("SessionRepository.DeleteExpired")
+	}
+	return m.DeleteExpiredFunc(now)
+}
+
+// TicketRepository is a mock of repository.TicketRepositoryInterface.
+type TicketRepository struct {
+	CreateFunc   func(ticket *models.Ticket) error
+	FindByIDFunc func(id uint) (*models.Ticket, error)
+	FindAllFunc  func(status string) ([]models.Ticket, error)
+	UpdateFunc   func(ticket *models.Ticket) error
+}
+
+var _ repository.TicketRepositoryInterface = (*TicketRepository)(nil)
+
+func (m *TicketRepository) Create(ticket *models.Ticket) error {
+	if m.CreateFunc == nil {
+		unexpected("TicketRepository.Create")
+	}
+	return m.CreateFunc(ticket)
+}
+
+func (m *TicketRepository) FindByID(id uint) (*models.Ticket, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("TicketRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *TicketRepository) FindAll(status string) ([]models.Ticket, error) {
+	if m.FindAllFunc == nil {
+		unexpected("TicketRepository.FindAll")
+	}
+	return m.FindAllFunc(status)
+}
+
+func (m *TicketRepository) Update(ticket *models.Ticket) error {
+	if m.UpdateFunc == nil {
+		unexpected("TicketRepository.Update")
+	}
+	return m.UpdateFunc(ticket)
+}
+
+// EmailTemplateRepository is a mock of repository.EmailTemplateRepositoryInterface.
+type EmailTemplateRepository struct {
+	FindAllFunc func(locationID uint) ([]models.EmailTemplate, error)
+	SetFunc     func(template *models.EmailTemplate) error
+	DeleteFunc  func(name string, locationID uint) error
+}
+
+var _ repository.EmailTemplateRepositoryInterface = (*EmailTemplateRepository)(nil)
+
+func (m *EmailTemplateRepository) FindAll(locationID uint) ([]models.EmailTemplate, error) {
+	if m.FindAllFunc == nil {
+		unexpected("EmailTemplateRepository.FindAll")
+	}
+	return m.FindAllFunc(locationID)
+}
+
+func (m *EmailTemplateRepository) Set(template *models.EmailTemplate) error {
+	if m.SetFunc == nil {
+		unexpected("EmailTemplateRepository.Set")
+	}
+	return m.SetFunc(template)
+}
+
+func (m *EmailTemplateRepository) Delete(name string, locationID uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("EmailTemplateRepository.Delete")
+	}
+	return m.DeleteFunc(name, locationID)
+}
+
+// NotificationPreferenceRepository is a mock of repository.NotificationPreferenceRepositoryInterface.
+type NotificationPreferenceRepository struct {
+	FindByAccountFunc func(accountID uint) ([]models.NotificationPreference, error)
+	SetFunc           func(preferences []models.NotificationPreference) error
+}
+
+var _ repository.NotificationPreferenceRepositoryInterface = (*NotificationPreferenceRepository)(nil)
+
+func (m *NotificationPreferenceRepository) FindByAccount(accountID uint) ([]models.NotificationPreference, error) {
+	if m.FindByAccountFunc == nil {
+		unexpected("NotificationPreferenceRepository.FindByAccount")
+	}
+	return m.FindByAccountFunc(accountID)
+}
+
+func (m *NotificationPreferenceRepository) Set(preferences []models.NotificationPreference) error {
+	if m.SetFunc == nil {
+		unexpected("NotificationPreferenceRepository.Set")
+	}
+	return m.SetFunc(preferences)
+}
+
+// DeviceTokenRepository is a mock of repository.DeviceTokenRepositoryInterface.
+type DeviceTokenRepository struct {
+	FindByAccountFunc func(accountID uint) ([]models.DeviceToken, error)
+	SaveFunc          func(device *models.DeviceToken) error
+	DeleteFunc        func(accountID uint, token string) error
+	DeleteTokenFunc   func(token string) error
+}
+
+var _ repository.DeviceTokenRepositoryInterface = (*DeviceTokenRepository)(nil)
+
+func (m *DeviceTokenRepository) FindByAccount(accountID uint) ([]models.DeviceToken, error) {
+	if m.FindByAccountFunc == nil {
+		unexpected("DeviceTokenRepository.FindByAccount")
+	}
+	return m.FindByAccountFunc(accountID)
+}
+
+func (m *DeviceTokenRepository) Save(device *models.DeviceToken) error {
+	if m.SaveFunc == nil {
+		unexpected("DeviceTokenRepository.Save")
+	}
+	return m.SaveFunc(device)
+}
+
+func (m *DeviceTokenRepository) Delete(accountID uint, token string) error {
+	if m.DeleteFunc == nil {
+		unexpected("DeviceTokenRepository.Delete")
+	}
+	return m.DeleteFunc(accountID, token)
+}
+
+func (m *DeviceTokenRepository) DeleteToken(token string) error {
+	if m.DeleteTokenFunc == nil {
+		unexpected("DeviceTokenRepository.DeleteToken")
+	}
+	return m.DeleteTokenFunc(token)
+}
+
+// APIKeyRepository is a mock of repository.APIKeyRepositoryInterface.
+type APIKeyRepository struct {
+	CreateFunc        func(key *models.APIKey) error
+	FindByIDFunc      func(id uint) (*models.APIKey, error)
+	FindByKeyHashFunc func(hash string) (*models.APIKey, error)
+	FindAllFunc       func() ([]models.APIKey, error)
+	UpdateFunc        func(key *models.APIKey) error
+	RevokeFunc        func(id uint, at time.Time) error
+	AddUsageFunc      func(apiKeyID uint, day, monthStart string, dailyQuota, monthlyQuota int) error
+	FindUsageFunc     func(apiKeyID uint, since string) ([]models.APIKeyUsage, error)
+}
+
+var _ repository.APIKeyRepositoryInterface = (*APIKeyRepository)(nil)
+
+func (m *APIKeyRepository) Create(key *models.APIKey) error {
+	if m.CreateFunc == nil {
+		unexpected("APIKeyRepository.Create")
+	}
+	return m.CreateFunc(key)
+}
+
+func (m *APIKeyRepository) FindByID(id uint) (*models.APIKey, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("APIKeyRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *APIKeyRepository) FindByKeyHash(hash string) (*models.APIKey, error) {
+	if m.FindByKeyHashFunc == nil {
+		unexpected("APIKeyRepository.FindByKeyHash")
+	}
+	return m.FindByKeyHashFunc(hash)
+}
+
+func (m *APIKeyRepository) FindAll() ([]models.APIKey, error) {
+	if m.FindAllFunc == nil {
+		unexpected("APIKeyRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *APIKeyRepository) Update(key *models.APIKey) error {
+	if m.UpdateFunc == nil {
+		unexpected("APIKeyRepository.Update")
+	}
+	return m.UpdateFunc(key)
+}
+
+func (m *APIKeyRepository) Revoke(id uint, at time.Time) error {
+	if m.RevokeFunc == nil {
+		unexpected("APIKeyRepository.Revoke")
+	}
+	return m.RevokeFunc(id, at)
+}
+
+func (m *APIKeyRepository) AddUsage(apiKeyID uint, day, monthStart string, dailyQuota, monthlyQuota int) error {
+	if m.AddUsageFunc == nil {
+		unexpected("APIKeyRepository.AddUsage")
+	}
+	return m.AddUsageFunc(apiKeyID, day, monthStart, dailyQuota, monthlyQuota)
+}
+
+func (m *APIKeyRepository) FindUsage(apiKeyID uint, since string) ([]models.APIKeyUsage, error) {
+	if m.FindUsageFunc == nil {
+		unexpected("APIKeyRepository.FindUsage")
+	}
+	return m.FindUsageFunc(apiKeyID, since)
+}
+
+// AdminRepository is a mock of repository.AdminRepositoryInterface.
+type AdminRepository struct {
+	CreateFunc             func(admin *models.Admin) error
+	FindByIDFunc           func(id uint) (*models.Admin, error)
+	FindByEmailFunc        func(email string) (*models.Admin, error)
+	FindAllFunc            func() ([]models.Admin, error)
+	UpdateFunc             func(admin *models.Admin) error
+	UpdatePasswordHashFunc func(id uint, hash string) error
+	ClaimTOTPStepFunc      func(id uint, step int64) (bool, error)
+	ReplaceBackupCodesFunc func(adminID uint, hashes []string) error
+	UseBackupCodeFunc      func(adminID uint, hash string, at time.Time) (bool, error)
+	SetSMSCodeFunc         func(id uint, hash string, sentAt time.Time) error
+	ClaimSMSCodeFunc       func(id uint, hash string, since time.Time) (bool, error)
+}
+
+var _ repository.AdminRepositoryInterface = (*AdminRepository)(nil)
+
+func (m *AdminRepository) Create(admin *models.Admin) error {
+	if m.CreateFunc == nil {
+		unexpected("AdminRepository.Create")
+	}
+	return m.CreateFunc(admin)
+}
+
+func (m *AdminRepository) FindByID(id uint) (*models.Admin, error) {
+	if m.FindByIDFunc == nil {
+		unexpected("AdminRepository.FindByID")
+	}
+	return m.FindByIDFunc(id)
+}
+
+func (m *AdminRepository) FindByEmail(email string) (*models.Admin, error) {
+	if m.FindByEmailFunc == nil {
+		unexpected("AdminRepository.FindByEmail")
+	}
+	return m.FindByEmailFunc(email)
+}
+
+func (m *AdminRepository) FindAll() ([]models.Admin, error) {
+	if m.FindAllFunc == nil {
+		unexpected("AdminRepository.FindAll")
+	}
+	return m.FindAllFunc()
+}
+
+func (m *AdminRepository) Update(admin *models.Admin) error {
+	if m.UpdateFunc == nil {
+		unexpected("AdminRepository.Update")
+	}
+	return m.UpdateFunc(admin)
+}
+
+func (m *AdminRepository) UpdatePasswordHash(id uint, hash string) error {
+	if m.UpdatePasswordHashFunc == nil {
+		unexpected("AdminRepository.UpdatePasswordHash")
+	}
+	return m.UpdatePasswordHashFunc(id, hash)
+}
+
+func (m *AdminRepository) ClaimTOTPStep(id uint, step int64) (bool, error) {
+	if m.ClaimTOTPStepFunc == nil {
+		unexpected("AdminRepository.ClaimTOTPStep")
+	}
+	return m.ClaimTOTPStepFunc(id, step)
+}
+
+func (m *AdminRepository) ReplaceBackupCodes(adminID uint, hashes []string) error {
+	if m.ReplaceBackupCodesFunc == nil {
+		unexpected("AdminRepository.ReplaceBackupCodes")
+	}
+	return m.ReplaceBackupCodesFunc(adminID, hashes)
+}
+
+func (m *AdminRepository) UseBackupCode(adminID uint, hash string, at time.Time) (bool, error) {
+	if m.UseBackupCodeFunc == nil {
+		unexpected("AdminRepository.UseBackupCode")
+	}
+	return m.UseBackupCodeFunc(adminID, hash, at)
+}
+
+func (m *AdminRepository) SetSMSCode(id uint, hash string, sentAt time.Time) error {
+	if m.SetSMSCodeFunc == nil {
+		unexpected("AdminRepository.SetSMSCode")
+	}
+	return m.SetSMSCodeFunc(id, hash, sentAt)
+}
+
+func (m *AdminRepository) ClaimSMSCode(id uint, hash string, since time.Time) (bool, error) {
+	if m.ClaimSMSCodeFunc == nil {
+		unexpected("AdminRepository.ClaimSMSCode")
+	}
+	return m.ClaimSMSCodeFunc(id, hash, since)
+}
@@ -0,0 +1,1732 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+// The service mocks cannot assert their interfaces here: service tests import
+// this package, so importing service back would be a cycle. The assertions
+// live in mocks_test.go instead.
+
+// CupcakeService is a mock of service.CupcakeServiceInterface.
+type CupcakeService struct {
+	CreateCupcakeFunc          func(req *models.CreateCupcakeRequest) (*models.Cupcake, error)
+	GetCupcakeFunc             func(id uint) (*models.Cupcake, error)
+	GetCupcakeBySKUFunc        func(sku string) (*models.Cupcake, error)
+	GetCupcakeBySlugFunc       func(slug string) (*models.Cupcake, error)
+	GetRelatedCupcakesFunc     func(id uint, limit int) ([]models.Cupcake, error)
+	GetAllCupcakesFunc         func() ([]models.Cupcake, error)
+	ListCupcakesFunc           func(page, perPage int) ([]models.Cupcake, int64, error)
+	GetCupcakesAtLocationFunc  func(locationID uint) ([]models.Cupcake, error)
+	ListCupcakesAtLocationFunc func(locationID uint, page, perPage int) ([]models.Cupcake, int64, error)
+	StreamCupcakesFunc         func(code string, fn func(*models.Cupcake) error) error
+	ConvertPricesFunc          func(cupcakes []models.Cupcake, code string) error
+	LocalizeFunc               func(cupcakes []models.Cupcake, acceptLanguage string) (string, error)
+	UpdateCupcakeFunc          func(id uint, req *models.UpdateCupcakeRequest) (*models.Cupcake, error)
+	PatchCupcakeFunc           func(id uint, ops []models.PatchOperation, updatedBy *uint) (*models.Cupcake, error)
+	GetCupcakeVersionsFunc     func(id uint) ([]models.CupcakeVersion, error)
+	RevertCupcakeFunc          func(id uint, version int, updatedBy *uint) (*models.Cupcake, error)
+	DeleteCupcakeFunc          func(id uint) error
+	BulkUpdatePricesFunc       func(req *models.BulkPriceUpdateRequest) (*models.BulkPriceUpdateResponse, error)
+}
+
+func (m *CupcakeService) CreateCupcake(req *models.CreateCupcakeRequest) (*models.Cupcake, error) {
+	if m.CreateCupcakeFunc == nil {
+		unexpected("CupcakeService.CreateCupcake")
+	}
+	return m.CreateCupcakeFunc(req)
+}
+
+func (m *CupcakeService) GetCupcake(id uint) (*models.Cupcake, error) {
+	if m.GetCupcakeFunc == nil {
+		unexpected("CupcakeService.GetCupcake")
+	}
+	return m.GetCupcakeFunc(id)
+}
+
+func (m *CupcakeService) GetCupcakeBySKU(sku string) (*models.Cupcake, error) {
+	if m.GetCupcakeBySKUFunc == nil {
+		unexpected("CupcakeService.GetCupcakeBySKU")
+	}
+	return m.GetCupcakeBySKUFunc(sku)
+}
+
+func (m *CupcakeService) GetCupcakeBySlug(slug string) (*models.Cupcake, error) {
+	if m.GetCupcakeBySlugFunc == nil {
+		unexpected("CupcakeService.GetCupcakeBySlug")
+	}
+	return m.GetCupcakeBySlugFunc(slug)
+}
+
+func (m *CupcakeService) GetRelatedCupcakes(id uint, limit int) ([]models.Cupcake, error) {
+	if m.GetRelatedCupcakesFunc == nil {
+		unexpected("CupcakeService.GetRelatedCupcakes")
+	}
+	return m.GetRelatedCupcakesFunc(id, limit)
+}
+
+func (m *CupcakeService) GetAllCupcakes() ([]models.Cupcake, error) {
+	if m.GetAllCupcakesFunc == nil {
+		unexpected("CupcakeService.GetAllCupcakes")
+	}
+	return m.GetAllCupcakesFunc()
+}
+
+func (m *CupcakeService) ListCupcakes(page, perPage int) ([]models.Cupcake, int64, error) {
+	if m.ListCupcakesFunc == nil {
+		unexpected("CupcakeService.ListCupcakes")
+	}
+	return m.ListCupcakesFunc(page, perPage)
+}
+
+func (m *CupcakeService) GetCupcakesAtLocation(locationID uint) ([]models.Cupcake, error) {
+	if m.GetCupcakesAtLocationFunc == nil {
+		unexpected("CupcakeService.GetCupcakesAtLocation")
+	}
+	return m.GetCupcakesAtLocationFunc(locationID)
+}
+
+func (m *CupcakeService) ListCupcakesAtLocation(locationID uint, page, perPage int) ([]models.Cupcake, int64, error) {
+	if m.ListCupcakesAtLocationFunc == nil {
+		unexpected("CupcakeService.ListCupcakesAtLocation")
+	}
+	return m.ListCupcakesAtLocationFunc(locationID, page, perPage)
+}
+
+func (m *CupcakeService) StreamCupcakes(code string, fn func(*models.Cupcake) error) error {
+	if m.StreamCupcakesFunc == nil {
+		unexpected("CupcakeService.StreamCupcakes")
+	}
+	return m.StreamCupcakesFunc(code, fn)
+}
+
+func (m *CupcakeService) ConvertPrices(cupcakes []models.Cupcake, code string) error {
+	if m.ConvertPricesFunc == nil {
+		unexpected("CupcakeService.ConvertPrices")
+	}
+	return m.ConvertPricesFunc(cupcakes, code)
+}
+
+func (m *CupcakeService) Localize(cupcakes []models.Cupcake, acceptLanguage string) (string, error) {
+	if m.LocalizeFunc == nil {
+		unexpected("CupcakeService.Localize")
+	}
+	return m.LocalizeFunc(cupcakes, acceptLanguage)
+}
+
+func (m *CupcakeService) UpdateCupcake(id uint, req *models.UpdateCupcakeRequest) (*models.Cupcake, error) {
+	if m.UpdateCupcakeFunc == nil {
+		unexpected("CupcakeService.UpdateCupcake")
+	}
+	return m.UpdateCupcakeFunc(id, req)
+}
+
+func (m *CupcakeService) PatchCupcake(id uint, ops []models.PatchOperation, updatedBy *uint) (*models.Cupcake, error) {
+	if m.PatchCupcakeFunc == nil {
+		unexpected("CupcakeService.PatchCupcake")
+	}
+	return m.PatchCupcakeFunc(id, ops, updatedBy)
+}
+
+func (m *CupcakeService) GetCupcakeVersions(id uint) ([]models.CupcakeVersion, error) {
+	if m.GetCupcakeVersionsFunc == nil {
+		unexpected("CupcakeService.GetCupcakeVersions")
+	}
+	return m.GetCupcakeVersionsFunc(id)
+}
+
+func (m *CupcakeService) RevertCupcake(id uint, version int, updatedBy *uint) (*models.Cupcake, error) {
+	if m.RevertCupcakeFunc == nil {
+		unexpected("CupcakeService.RevertCupcake")
+	}
+	return m.RevertCupcakeFunc(id, version, updatedBy)
+}
+
+func (m *CupcakeService) DeleteCupcake(id uint) error {
+	if m.DeleteCupcakeFunc == nil {
+		unexpected("CupcakeService.DeleteCupcake")
+	}
+	return m.DeleteCupcakeFunc(id)
+}
+
+func (m *CupcakeService) BulkUpdatePrices(req *models.BulkPriceUpdateRequest) (*models.BulkPriceUpdateResponse, error) {
+	if m.BulkUpdatePricesFunc == nil {
+		unexpected("CupcakeService.BulkUpdatePrices")
+	}
+	return m.BulkUpdatePricesFunc(req)
+}
+
+// CouponService is a mock of service.CouponServiceInterface.
+type CouponService struct {
+	CreateCouponFunc  func(req *models.CreateCouponRequest) (*models.Coupon, error)
+	GetCouponFunc     func(id uint) (*models.Coupon, error)
+	GetAllCouponsFunc func() ([]models.Coupon, error)
+	UpdateCouponFunc  func(id uint, req *models.UpdateCouponRequest) (*models.Coupon, error)
+	DeleteCouponFunc  func(id uint) error
+	ApplyCouponFunc   func(req *models.ApplyCouponRequest) (*models.CouponRedemption, error)
+	ExpireCouponsFunc func() (int64, error)
+}
+
+func (m *CouponService) CreateCoupon(req *models.CreateCouponRequest) (*models.Coupon, error) {
+	if m.CreateCouponFunc == nil {
+		unexpected("CouponService.CreateCoupon")
+	}
+	return m.CreateCouponFunc(req)
+}
+
+func (m *CouponService) GetCoupon(id uint) (*models.Coupon, error) {
+	if m.GetCouponFunc == nil {
+		unexpected("CouponService.GetCoupon")
+	}
+	return m.GetCouponFunc(id)
+}
+
+func (m *CouponService) GetAllCoupons() ([]models.Coupon, error) {
+	if m.GetAllCouponsFunc == nil {
+		unexpected("CouponService.GetAllCoupons")
+	}
+	return m.GetAllCouponsFunc()
+}
+
+func (m *CouponService) UpdateCoupon(id uint, req *models.UpdateCouponRequest) (*models.Coupon, error) {
+	if m.UpdateCouponFunc == nil {
+		unexpected("CouponService.UpdateCoupon")
+	}
+	return m.UpdateCouponFunc(id, req)
+}
+
+func (m *CouponService) DeleteCoupon(id uint) error {
+	if m.DeleteCouponFunc == nil {
+		unexpected("CouponService.DeleteCoupon")
+	}
+	return m.DeleteCouponFunc(id)
+}
+
+func (m *CouponService) ApplyCoupon(req *models.ApplyCouponRequest) (*models.CouponRedemption, error) {
+	if m.ApplyCouponFunc == nil {
+		unexpected("CouponService.ApplyCoupon")
+	}
+	return m.ApplyCouponFunc(req)
+}
+
+func (m *CouponService) ExpireCoupons() (int64, error) {
+	if m.ExpireCouponsFunc == nil {
+		unexpected("CouponService.ExpireCoupons")
+	}
+	return m.ExpireCouponsFunc()
+}
+
+// PromotionService is a mock of service.PromotionServiceInterface.
+type PromotionService struct {
+	CreatePromotionFunc  func(req *models.CreatePromotionRequest) (*models.Promotion, error)
+	GetPromotionFunc     func(id uint) (*models.Promotion, error)
+	GetAllPromotionsFunc func() ([]models.Promotion, error)
+	UpdatePromotionFunc  func(id uint, req *models.UpdatePromotionRequest) (*models.Promotion, error)
+	DeletePromotionFunc  func(id uint) error
+}
+
+func (m *PromotionService) CreatePromotion(req *models.CreatePromotionRequest) (*models.Promotion, error) {
+	if m.CreatePromotionFunc == nil {
+		unexpected("PromotionService.CreatePromotion")
+	}
+	return m.CreatePromotionFunc(req)
+}
+
+func (m *PromotionService) GetPromotion(id uint) (*models.Promotion, error) {
+	if m.GetPromotionFunc == nil {
+		unexpected("PromotionService.GetPromotion")
+	}
+	return m.GetPromotionFunc(id)
+}
+
+func (m *PromotionService) GetAllPromotions() ([]models.Promotion, error) {
+	if m.GetAllPromotionsFunc == nil {
+		unexpected("PromotionService.GetAllPromotions")
+	}
+	return m.GetAllPromotionsFunc()
+}
+
+func (m *PromotionService) UpdatePromotion(id uint, req *models.UpdatePromotionRequest) (*models.Promotion, error) {
+	if m.UpdatePromotionFunc == nil {
+		unexpected("PromotionService.UpdatePromotion")
+	}
+	return m.UpdatePromotionFunc(id, req)
+}
+
+func (m *PromotionService) DeletePromotion(id uint) error {
+	if m.DeletePromotionFunc == nil {
+		unexpected("PromotionService.DeletePromotion")
+	}
+	return m.DeletePromotionFunc(id)
+}
+
+// GiftCardService is a mock of service.GiftCardServiceInterface.
+type GiftCardService struct {
+	IssueGiftCardFunc   func(req *models.IssueGiftCardRequest) (*models.GiftCard, error)
+	GetGiftCardFunc     func(id uint) (*models.GiftCard, error)
+	GetAllGiftCardsFunc func() ([]models.GiftCard, error)
+	GetBalanceFunc      func(code string) (*models.GiftCardBalanceResponse, error)
+	VoidGiftCardFunc    func(id uint) (*models.GiftCard, error)
+	RedeemGiftCardFunc  func(req *models.RedeemGiftCardRequest) (*models.GiftCardRedemption, error)
+}
+
+func (m *GiftCardService) IssueGiftCard(req *models.IssueGiftCardRequest) (*models.GiftCard, error) {
+	if m.IssueGiftCardFunc == nil {
+		unexpected("GiftCardService.IssueGiftCard")
+	}
+	return m.IssueGiftCardFunc(req)
+}
+
+func (m *GiftCardService) GetGiftCard(id uint) (*models.GiftCard, error) {
+	if m.GetGiftCardFunc == nil {
+		unexpected("GiftCardService.GetGiftCard")
+	}
+	return m.GetGiftCardFunc(id)
+}
+
+func (m *GiftCardService) GetAllGiftCards() ([]models.GiftCard, error) {
+	if m.GetAllGiftCardsFunc == nil {
+		unexpected("GiftCardService.GetAllGiftCards")
+	}
+	return m.GetAllGiftCardsFunc()
+}
+
+func (m *GiftCardService) GetBalance(code string) (*models.GiftCardBalanceResponse, error) {
+	if m.GetBalanceFunc == nil {
+		unexpected("GiftCardService.GetBalance")
+	}
+	return m.GetBalanceFunc(code)
+}
+
+func (m *GiftCardService) VoidGiftCard(id uint) (*models.GiftCard, error) {
+	if m.VoidGiftCardFunc == nil {
+		unexpected("GiftCardService.VoidGiftCard")
+	}
+	return m.VoidGiftCardFunc(id)
+}
+
+func (m *GiftCardService) RedeemGiftCard(req *models.RedeemGiftCardRequest) (*models.GiftCardRedemption, error) {
+	if m.RedeemGiftCardFunc == nil {
+		unexpected("GiftCardService.RedeemGiftCard")
+	}
+	return m.RedeemGiftCardFunc(req)
+}
+
+// SubscriptionService is a mock of service.SubscriptionServiceInterface.
+type SubscriptionService struct {
+	SubscribeFunc           func(req *models.CreateSubscriptionRequest) (*models.CreatedSubscription, error)
+	AuthorizeFunc           func(id uint, managementToken string, account *models.Account) (*models.Subscription, error)
+	GetSubscriptionFunc     func(id uint) (*models.Subscription, error)
+	GetAllSubscriptionsFunc func() ([]models.Subscription, error)
+	PauseFunc               func(id uint) (*models.Subscription, error)
+	ResumeFunc              func(id uint) (*models.Subscription, error)
+	CancelFunc              func(id uint) (*models.Subscription, error)
+}
+
+func (m *SubscriptionService) Subscribe(req *models.CreateSubscriptionRequest) (*models.CreatedSubscription, error) {
+	if m.SubscribeFunc == nil {
+		unexpected("SubscriptionService.Subscribe")
+	}
+	return m.SubscribeFunc(req)
+}
+
+func (m *SubscriptionService) Authorize(id uint, managementToken string, account *models.Account) (*models.Subscription, error) {
+	if m.AuthorizeFunc == nil {
+		unexpected("SubscriptionService.Authorize")
+	}
+	return m.AuthorizeFunc(id, managementToken, account)
+}
+
+func (m *SubscriptionService) GetSubscription(id uint) (*models.Subscription, error) {
+	if m.GetSubscriptionFunc == nil {
+		unexpected("SubscriptionService.GetSubscription")
+	}
+	return m.GetSubscriptionFunc(id)
+}
+
+func (m *SubscriptionService) GetAllSubscriptions() ([]models.Subscription, error) {
+	if m.GetAllSubscriptionsFunc == nil {
+		unexpected("SubscriptionService.GetAllSubscriptions")
+	}
+	return m.GetAllSubscriptionsFunc()
+}
+
+func (m *SubscriptionService) Pause(id uint) (*models.Subscription, error) {
+	if m.PauseFunc == nil {
+		unexpected("SubscriptionService.Pause")
+	}
+	return m.PauseFunc(id)
+}
+
+func (m *SubscriptionService) Resume(id uint) (*models.Subscription, error) {
+	if m.ResumeFunc == nil {
+		unexpected("SubscriptionService.Resume")
+	}
+	return m.ResumeFunc(id)
+}
+
+func (m *SubscriptionService) Cancel(id uint) (*models.Subscription, error) {
+	if m.CancelFunc == nil {
+		unexpected("SubscriptionService.Cancel")
+	}
+	return m.CancelFunc(id)
+}
+
+// LocationService is a mock of service.LocationServiceInterface.
+type LocationService struct {
+	CreateLocationFunc  func(req *models.CreateLocationRequest) (*models.Location, error)
+	GetLocationFunc     func(id uint) (*models.Location, error)
+	GetAllLocationsFunc func() ([]models.Location, error)
+	UpdateLocationFunc  func(id uint, req *models.UpdateLocationRequest) (*models.Location, error)
+	DeleteLocationFunc  func(id uint) error
+	GetStockFunc        func(locationID uint) ([]models.LocationStock, error)
+	SetStockFunc        func(locationID, cupcakeID uint, req *models.SetStockRequest) (*models.LocationStock, error)
+	CheckPickupFunc     func(locationID uint, req *models.PickupCheckRequest) ([]models.PickupItem, error)
+}
+
+func (m *LocationService) CreateLocation(req *models.CreateLocationRequest) (*models.Location, error) {
+	if m.CreateLocationFunc == nil {
+		unexpected("LocationService.CreateLocation")
+	}
+	return m.CreateLocationFunc(req)
+}
+
+func (m *LocationService) GetLocation(id uint) (*models.Location, error) {
+	if m.GetLocationFunc == nil {
+		unexpected("LocationService.GetLocation")
+	}
+	return m.GetLocationFunc(id)
+}
+
+func (m *LocationService) GetAllLocations() ([]models.Location, error) {
+	if m.GetAllLocationsFunc == nil {
+		unexpected("LocationService.GetAllLocations")
+	}
+	return m.GetAllLocationsFunc()
+}
+
+func (m *LocationService) UpdateLocation(id uint, req *models.UpdateLocationRequest) (*models.Location, error) {
+	if m.UpdateLocationFunc == nil {
+		unexpected("LocationService.UpdateLocation")
+	}
+	return m.UpdateLocationFunc(id, req)
+}
+
+func (m *LocationService) DeleteLocation(id uint) error {
+	if m.DeleteLocationFunc == nil {
+		unexpected("LocationService.DeleteLocation")
+	}
+	return m.DeleteLocationFunc(id)
+}
+
+func (m *LocationService) GetStock(locationID uint) ([]models.LocationStock, error) {
+	if m.GetStockFunc == nil {
+		unexpected("LocationService.GetStock")
+	}
+	return m.GetStockFunc(locationID)
+}
+
+func (m *LocationService) SetStock(locationID, cupcakeID uint, req *models.SetStockRequest) (*models.LocationStock, error) {
+	if m.SetStockFunc == nil {
+		unexpected("LocationService.SetStock")
+	}
+	return m.SetStockFunc(locationID, cupcakeID, req)
+}
+
+func (m *LocationService) CheckPickup(locationID uint, req *models.PickupCheckRequest) ([]models.PickupItem, error) {
+	if m.CheckPickupFunc == nil {
+		unexpected("LocationService.CheckPickup")
+	}
+	return m.CheckPickupFunc(locationID, req)
+}
+
+// BundleService is a mock of service.BundleServiceInterface.
+type BundleService struct {
+	CreateBundleFunc        func(req *models.CreateBundleRequest) (*models.Bundle, error)
+	GetBundleFunc           func(id uint) (*models.Bundle, error)
+	GetAllBundlesFunc       func() ([]models.Bundle, error)
+	GetAvailableBundlesFunc func() ([]models.Bundle, error)
+	UpdateBundleFunc        func(id uint, req *models.UpdateBundleRequest) (*models.Bundle, error)
+	DeleteBundleFunc        func(id uint) error
+}
+
+func (m *BundleService) CreateBundle(req *models.CreateBundleRequest) (*models.Bundle, error) {
+	if m.CreateBundleFunc == nil {
+		unexpected("BundleService.CreateBundle")
+	}
+	return m.CreateBundleFunc(req)
+}
+
+func (m *BundleService) GetBundle(id uint) (*models.Bundle, error) {
+	if m.GetBundleFunc == nil {
+		unexpected("BundleService.GetBundle")
+	}
+	return m.GetBundleFunc(id)
+}
+
+func (m *BundleService) GetAllBundles() ([]models.Bundle, error) {
+	if m.GetAllBundlesFunc == nil {
+		unexpected("BundleService.GetAllBundles")
+	}
+	return m.GetAllBundlesFunc()
+}
+
+func (m *BundleService) GetAvailableBundles() ([]models.Bundle, error) {
+	if m.GetAvailableBundlesFunc == nil {
+		unexpected("BundleService.GetAvailableBundles")
+	}
+	return m.GetAvailableBundlesFunc()
+}
+
+func (m *BundleService) UpdateBundle(id uint, req *models.UpdateBundleRequest) (*models.Bundle, error) {
+	if m.UpdateBundleFunc == nil {
+		unexpected("BundleService.UpdateBundle")
+	}
+	return m.UpdateBundleFunc(id, req)
+}
+
+func (m *BundleService) DeleteBundle(id uint) error {
+	if m.DeleteBundleFunc == nil {
+		unexpected("BundleService.DeleteBundle")
+	}
+	return m.DeleteBundleFunc(id)
+}
+
+// WholesaleService is a mock of service.WholesaleServiceInterface.
+type WholesaleService struct {
+	CreateAccountFunc  func(req *models.CreateWholesaleAccountRequest) (*models.WholesaleAccount, error)
+	GetAccountFunc     func(id uint) (*models.WholesaleAccount, error)
+	GetAllAccountsFunc func() ([]models.WholesaleAccount, error)
+	UpdateAccountFunc  func(id uint, req *models.UpdateWholesaleAccountRequest) (*models.WholesaleAccount, error)
+	DeleteAccountFunc  func(id uint) error
+	GetPricesFunc      func(accountID uint) ([]models.WholesalePrice, error)
+	SetPriceFunc       func(accountID, cupcakeID uint, req *models.SetWholesalePriceRequest) (*models.WholesalePrice, error)
+	DeletePriceFunc    func(accountID, cupcakeID uint) error
+	QuoteFunc          func(accountID uint, req *models.WholesaleQuoteRequest) (*models.WholesaleQuote, error)
+}
+
+func (m *WholesaleService) CreateAccount(req *models.CreateWholesaleAccountRequest) (*models.WholesaleAccount, error) {
+	if m.CreateAccountFunc == nil {
+		unexpected("WholesaleService.CreateAccount")
+	}
+	return m.CreateAccountFunc(req)
+}
+
+func (m *WholesaleService) GetAccount(id uint) (*models.WholesaleAccount, error) {
+	if m.GetAccountFunc == nil {
+		unexpected("WholesaleService.GetAccount")
+	}
+	return m.GetAccountFunc(id)
+}
+
+func (m *WholesaleService) GetAllAccounts() ([]models.WholesaleAccount, error) {
+	if m.GetAllAccountsFunc == nil {
+		unexpected("WholesaleService.GetAllAccounts")
+	}
+	return m.GetAllAccountsFunc()
+}
+
+func (m *WholesaleService) UpdateAccount(id uint, req *models.UpdateWholesaleAccountRequest) (*models.WholesaleAccount, error) {
+	if m.UpdateAccountFunc == nil {
+		unexpected("WholesaleService.UpdateAccount")
+	}
+	return m.UpdateAccountFunc(id, req)
+}
+
+func (m *WholesaleService) DeleteAccount(id uint) error {
+	if m.DeleteAccountFunc == nil {
+		unexpected("WholesaleService.DeleteAccount")
+	}
+	return m.DeleteAccountFunc(id)
+}
+
+func (m *WholesaleService) GetPrices(accountID uint) ([]models.WholesalePrice, error) {
+	if m.GetPricesFunc == nil {
+		unexpected("WholesaleService.GetPrices")
+	}
+	return m.GetPricesFunc(accountID)
+}
+
+func (m *WholesaleService) SetPrice(accountID, cupcakeID uint, req *models.SetWholesalePriceRequest) (*models.WholesalePrice, error) {
+	if m.SetPriceFunc == nil {
+		unexpected("WholesaleService.SetPrice")
+	}
+	return m.SetPriceFunc(accountID, cupcakeID, req)
+}
+
+func (m *WholesaleService) DeletePrice(accountID, cupcakeID uint) error {
+	if m.DeletePriceFunc == nil {
+		unexpected("WholesaleService.DeletePrice")
+	}
+	return m.DeletePriceFunc(accountID, cupcakeID)
+}
+
+func (m *WholesaleService) Quote(accountID uint, req *models.WholesaleQuoteRequest) (*models.WholesaleQuote, error) {
+	if m.QuoteFunc == nil {
+		unexpected("WholesaleService.Quote")
+	}
+	return m.QuoteFunc(accountID, req)
+}
+
+// ProcurementService is a mock of service.ProcurementServiceInterface.
+type ProcurementService struct {
+	CreateSupplierFunc       func(req *models.CreateSupplierRequest) (*models.Supplier, error)
+	GetSupplierFunc          func(id uint) (*models.Supplier, error)
+	GetAllSuppliersFunc      func() ([]models.Supplier, error)
+	UpdateSupplierFunc       func(id uint, req *models.UpdateSupplierRequest) (*models.Supplier, error)
+	CreateIngredientFunc     func(req *models.CreateIngredientRequest) (*models.Ingredient, error)
+	GetIngredientFunc        func(id uint) (*models.Ingredient, error)
+	GetAllIngredientsFunc    func() ([]models.Ingredient, error)
+	UpdateIngredientFunc     func(id uint, req *models.UpdateIngredientRequest) (*models.Ingredient, error)
+	CreatePurchaseOrderFunc  func(req *models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error)
+	GetPurchaseOrderFunc     func(id uint) (*models.PurchaseOrder, error)
+	GetPurchaseOrdersFunc    func(status string) ([]models.PurchaseOrder, error)
+	ReceivePurchaseOrderFunc func(id uint) (*models.PurchaseOrder, error)
+	CancelPurchaseOrderFunc  func(id uint) (*models.PurchaseOrder, error)
+}
+
+func (m *ProcurementService) CreateSupplier(req *models.CreateSupplierRequest) (*models.Supplier, error) {
+	if m.CreateSupplierFunc == nil {
+		unexpected("ProcurementService.CreateSupplier")
+	}
+	return m.CreateSupplierFunc(req)
+}
+
+func (m *ProcurementService) GetSupplier(id uint) (*models.Supplier, error) {
+	if m.GetSupplierFunc == nil {
+		unexpected("ProcurementService.GetSupplier")
+	}
+	return m.GetSupplierFunc(id)
+}
+
+func (m *ProcurementService) GetAllSuppliers() ([]models.Supplier, error) {
+	if m.GetAllSuppliersFunc == nil {
+		unexpected("ProcurementService.GetAllSuppliers")
+	}
+	return m.GetAllSuppliersFunc()
+}
+
+func (m *ProcurementService) UpdateSupplier(id uint, req *models.UpdateSupplierRequest) (*models.Supplier, error) {
+	if m.UpdateSupplierFunc == nil {
+		unexpected("ProcurementService.UpdateSupplier")
+	}
+	return m.UpdateSupplierFunc(id, req)
+}
+
+func (m *ProcurementService) CreateIngredient(req *models.CreateIngredientRequest) (*models.Ingredient, error) {
+	if m.CreateIngredientFunc == nil {
+		unexpected("ProcurementService.CreateIngredient")
+	}
+	return m.CreateIngredientFunc(req)
+}
+
+func (m *ProcurementService) GetIngredient(id uint) (*models.Ingredient, error) {
+	if m.GetIngredientFunc == nil {
+		unexpected("ProcurementService.GetIngredient")
+	}
+	return m.GetIngredientFunc(id)
+}
+
+func (m *ProcurementService) GetAllIngredients() ([]models.Ingredient, error) {
+	if m.GetAllIngredientsFunc == nil {
+		unexpected("ProcurementService.GetAllIngredients")
+	}
+	return m.GetAllIngredientsFunc()
+}
+
+func (m *ProcurementService) UpdateIngredient(id uint, req *models.UpdateIngredientRequest) (*models.Ingredient, error) {
+	if m.UpdateIngredientFunc == nil {
+		unexpected("ProcurementService.UpdateIngredient")
+	}
+	return m.UpdateIngredientFunc(id, req)
+}
+
+func (m *ProcurementService) CreatePurchaseOrder(req *models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	if m.CreatePurchaseOrderFunc == nil {
+		unexpected("ProcurementService.CreatePurchaseOrder")
+	}
+	return m.CreatePurchaseOrderFunc(req)
+}
+
+func (m *ProcurementService) GetPurchaseOrder(id uint) (*models.PurchaseOrder, error) {
+	if m.GetPurchaseOrderFunc == nil {
+		unexpected("ProcurementService.GetPurchaseOrder")
+	}
+	return m.GetPurchaseOrderFunc(id)
+}
+
+func (m *ProcurementService) GetPurchaseOrders(status string) ([]models.PurchaseOrder, error) {
+	if m.GetPurchaseOrdersFunc == nil {
+		unexpected("ProcurementService.GetPurchaseOrders")
+	}
+	return m.GetPurchaseOrdersFunc(status)
+}
+
+func (m *ProcurementService) ReceivePurchaseOrder(id uint) (*models.PurchaseOrder, error) {
+	if m.ReceivePurchaseOrderFunc == nil {
+		unexpected("ProcurementService.ReceivePurchaseOrder")
+	}
+	return m.ReceivePurchaseOrderFunc(id)
+}
+
+func (m *ProcurementService) CancelPurchaseOrder(id uint) (*models.PurchaseOrder, error) {
+	if m.CancelPurchaseOrderFunc == nil {
+		unexpected("ProcurementService.CancelPurchaseOrder")
+	}
+	return m.CancelPurchaseOrderFunc(id)
+}
+
+// RecipeService is a mock of service.RecipeServiceInterface.
+type RecipeService struct {
+	GetRecipeFunc            func(cupcakeID uint) (*models.Recipe, error)
+	SetRecipeFunc            func(cupcakeID uint, req *models.SetRecipeRequest) (*models.Recipe, error)
+	RecordProductionFunc     func(cupcakeID uint, req *models.RecordProductionRequest) (*models.ProductionBatch, error)
+	GetProductionBatchesFunc func(cupcakeID uint) ([]models.ProductionBatch, error)
+}
+
+func (m *RecipeService) GetRecipe(cupcakeID uint) (*models.Recipe, error) {
+	if m.GetRecipeFunc == nil {
+		unexpected("RecipeService.GetRecipe")
+	}
+	return m.GetRecipeFunc(cupcakeID)
+}
+
+func (m *RecipeService) SetRecipe(cupcakeID uint, req *models.SetRecipeRequest) (*models.Recipe, error) {
+	if m.SetRecipeFunc == nil {
+		unexpected("RecipeService.SetRecipe")
+	}
+	return m.SetRecipeFunc(cupcakeID, req)
+}
+
+func (m *RecipeService) RecordProduction(cupcakeID uint, req *models.RecordProductionRequest) (*models.ProductionBatch, error) {
+	if m.RecordProductionFunc == nil {
+		unexpected("RecipeService.RecordProduction")
+	}
+	return m.RecordProductionFunc(cupcakeID, req)
+}
+
+func (m *RecipeService) GetProductionBatches(cupcakeID uint) ([]models.ProductionBatch, error) {
+	if m.GetProductionBatchesFunc == nil {
+		unexpected("RecipeService.GetProductionBatches")
+	}
+	return m.GetProductionBatchesFunc(cupcakeID)
+}
+
+// KitchenService is a mock of service.KitchenServiceInterface.
+type KitchenService struct {
+	ProductionPlanFunc func(day time.Time) (*models.ProductionPlan, error)
+	QueueFunc          func(day time.Time, locationID uint) ([]models.KitchenQueueEntry, error)
+}
+
+func (m *KitchenService) ProductionPlan(day time.Time) (*models.ProductionPlan, error) {
+	if m.ProductionPlanFunc == nil {
+		unexpected("KitchenService.ProductionPlan")
+	}
+	return m.ProductionPlanFunc(day)
+}
+
+func (m *KitchenService) Queue(day time.Time, locationID uint) ([]models.KitchenQueueEntry, error) {
+	if m.QueueFunc == nil {
+		unexpected("KitchenService.Queue")
+	}
+	return m.QueueFunc(day, locationID)
+}
+
+// PrintService is a mock of service.PrintServiceInterface.
+type PrintService struct {
+	PrintFunc func(reservationID uint, tickets []string) ([]string, error)
+}
+
+func (m *PrintService) Print(reservationID uint, tickets []string) ([]string, error) {
+	if m.PrintFunc == nil {
+		unexpected("PrintService.Print")
+	}
+	return m.PrintFunc(reservationID, tickets)
+}
+
+// StatsService is a mock of service.StatsServiceInterface.
+type StatsService struct {
+	TopCupcakesFunc func(from, to time.Time, flavor string, limit int) (*models.TopCupcakes, error)
+}
+
+func (m *StatsService) TopCupcakes(from, to time.Time, flavor string, limit int) (*models.TopCupcakes, error) {
+	if m.TopCupcakesFunc == nil {
+		unexpected("StatsService.TopCupcakes")
+	}
+	return m.TopCupcakesFunc(from, to, flavor, limit)
+}
+
+// SyncService is a mock of service.SyncServiceInterface.
+type SyncService struct {
+	SyncCupcakesFunc func(since uint, limit int) (*models.CupcakeSync, error)
+}
+
+func (m *SyncService) SyncCupcakes(since uint, limit int) (*models.CupcakeSync, error) {
+	if m.SyncCupcakesFunc == nil {
+		unexpected("SyncService.SyncCupcakes")
+	}
+	return m.SyncCupcakesFunc(since, limit)
+}
+
+// TrendingService is a mock of service.TrendingServiceInterface.
+type TrendingService struct {
+	GetTrendingFunc func(days, limit int) ([]models.TrendingCupcake, error)
+}
+
+func (m *TrendingService) GetTrending(days, limit int) ([]models.TrendingCupcake, error) {
+	if m.GetTrendingFunc == nil {
+		unexpected("TrendingService.GetTrending")
+	}
+	return m.GetTrendingFunc(days, limit)
+}
+
+// TranslationService is a mock of service.TranslationServiceInterface.
+type TranslationService struct {
+	GetTranslationsFunc   func(cupcakeID uint) ([]models.CupcakeTranslation, error)
+	SetTranslationFunc    func(cupcakeID uint, locale string, req *models.SetTranslationRequest) (*models.CupcakeTranslation, error)
+	DeleteTranslationFunc func(cupcakeID uint, locale string) error
+	LocalizeFunc          func(cupcakes []models.Cupcake, acceptLanguage string) (string, error)
+}
+
+func (m *TranslationService) GetTranslations(cupcakeID uint) ([]models.CupcakeTranslation, error) {
+	if m.GetTranslationsFunc == nil {
+		unexpected("TranslationService.GetTranslations")
+	}
+	return m.GetTranslationsFunc(cupcakeID)
+}
+
+func (m *TranslationService) SetTranslation(cupcakeID uint, locale string, req *models.SetTranslationRequest) (*models.CupcakeTranslation, error) {
+	if m.SetTranslationFunc == nil {
+		unexpected("TranslationService.SetTranslation")
+	}
+	return m.SetTranslationFunc(cupcakeID, locale, req)
+}
+
+func (m *TranslationService) DeleteTranslation(cupcakeID uint, locale string) error {
+	if m.DeleteTranslationFunc == nil {
+		unexpected("TranslationService.DeleteTranslation")
+	}
+	return m.DeleteTranslationFunc(cupcakeID, locale)
+}
+
+func (m *TranslationService) Localize(cupcakes []models.Cupcake, acceptLanguage string) (string, error) {
+	if m.LocalizeFunc == nil {
+		unexpected("TranslationService.Localize")
+	}
+	return m.LocalizeFunc(cupcakes, acceptLanguage)
+}
+
+// SearchService is a mock of service.SearchServiceInterface.
+type SearchService struct {
+	SearchFunc  func(query models.SearchQuery) (*models.SearchResult, error)
+	ReindexFunc func() (int, error)
+}
+
+func (m *SearchService) Search(query models.SearchQuery) (*models.SearchResult, error) {
+	if m.SearchFunc == nil {
+		unexpected("SearchService.Search")
+	}
+	return m.SearchFunc(query)
+}
+
+func (m *SearchService) Reindex() (int, error) {
+	if m.ReindexFunc == nil {
+		unexpected("SearchService.Reindex")
+	}
+	return m.ReindexFunc()
+}
+
+// AddonService is a mock of service.AddonServiceInterface.
+type AddonService struct {
+	CreateAddonFunc        func(req *models.CreateAddonRequest) (*models.Addon, error)
+	GetAddonFunc           func(id uint) (*models.Addon, error)
+	GetAllAddonsFunc       func() ([]models.Addon, error)
+	GetAvailableAddonsFunc func() ([]models.Addon, error)
+	UpdateAddonFunc        func(id uint, req *models.UpdateAddonRequest) (*models.Addon, error)
+	DeleteAddonFunc        func(id uint) error
+}
+
+func (m *AddonService) CreateAddon(req *models.CreateAddonRequest) (*models.Addon, error) {
+	if m.CreateAddonFunc == nil {
+		unexpected("AddonService.CreateAddon")
+	}
+	return m.CreateAddonFunc(req)
+}
+
+func (m *AddonService) GetAddon(id uint) (*models.Addon, error) {
+	if m.GetAddonFunc == nil {
+		unexpected("AddonService.GetAddon")
+	}
+	return m.GetAddonFunc(id)
+}
+
+func (m *AddonService) GetAllAddons() ([]models.Addon, error) {
+	if m.GetAllAddonsFunc == nil {
+		unexpected("AddonService.GetAllAddons")
+	}
+	return m.GetAllAddonsFunc()
+}
+
+func (m *AddonService) GetAvailableAddons() ([]models.Addon, error) {
+	if m.GetAvailableAddonsFunc == nil {
+		unexpected("AddonService.GetAvailableAddons")
+	}
+	return m.GetAvailableAddonsFunc()
+}
+
+func (m *AddonService) UpdateAddon(id uint, req *models.UpdateAddonRequest) (*models.Addon, error) {
+	if m.UpdateAddonFunc == nil {
+		unexpected("AddonService.UpdateAddon")
+	}
+	return m.UpdateAddonFunc(id, req)
+}
+
+func (m *AddonService) DeleteAddon(id uint) error {
+	if m.DeleteAddonFunc == nil {
+		unexpected("AddonService.DeleteAddon")
+	}
+	return m.DeleteAddonFunc(id)
+}
+
+// CustomCupcakeService is a mock of service.CustomCupcakeServiceInterface.
+type CustomCupcakeService struct {
+	CreateOptionFunc        func(req *models.CreateCustomOptionRequest) (*models.CustomOption, error)
+	GetAllOptionsFunc       func() ([]models.CustomOption, error)
+	GetAvailableOptionsFunc func() ([]models.CustomOption, error)
+	UpdateOptionFunc        func(id uint, req *models.UpdateCustomOptionRequest) (*models.CustomOption, error)
+	DeleteOptionFunc        func(id uint) error
+	QuoteFunc               func(req *models.CustomCupcakeQuoteRequest) (*models.CustomCupcakeQuote, error)
+}
+
+func (m *CustomCupcakeService) CreateOption(req *models.CreateCustomOptionRequest) (*models.CustomOption, error) {
+	if m.CreateOptionFunc == nil {
+		unexpected("CustomCupcakeService.CreateOption")
+	}
+	return m.CreateOptionFunc(req)
+}
+
+func (m *CustomCupcakeService) GetAllOptions() ([]models.CustomOption, error) {
+	if m.GetAllOptionsFunc == nil {
+		unexpected("CustomCupcakeService.GetAllOptions")
+	}
+	return m.GetAllOptionsFunc()
+}
+
+func (m *CustomCupcakeService) GetAvailableOptions() ([]models.CustomOption, error) {
+	if m.GetAvailableOptionsFunc == nil {
+		unexpected("CustomCupcakeService.GetAvailableOptions")
+	}
+	return m.GetAvailableOptionsFunc()
+}
+
+func (m *CustomCupcakeService) UpdateOption(id uint, req *models.UpdateCustomOptionRequest) (*models.CustomOption, error) {
+	if m.UpdateOptionFunc == nil {
+		unexpected("CustomCupcakeService.UpdateOption")
+	}
+	return m.UpdateOptionFunc(id, req)
+}
+
+func (m *CustomCupcakeService) DeleteOption(id uint) error {
+	if m.DeleteOptionFunc == nil {
+		unexpected("CustomCupcakeService.DeleteOption")
+	}
+	return m.DeleteOptionFunc(id)
+}
+
+func (m *CustomCupcakeService) Quote(req *models.CustomCupcakeQuoteRequest) (*models.CustomCupcakeQuote, error) {
+	if m.QuoteFunc == nil {
+		unexpected("CustomCupcakeService.Quote")
+	}
+	return m.QuoteFunc(req)
+}
+
+// PickupService is a mock of service.PickupServiceInterface.
+type PickupService struct {
+	CreateSlotFunc  func(locationID uint, req *models.CreatePickupSlotRequest) (*models.PickupSlot, error)
+	GetSlotsFunc    func(locationID uint, day time.Time) ([]models.PickupSlot, error)
+	ReserveFunc     func(locationID, slotID uint, req *models.ReservePickupRequest) (*models.PickupReservation, error)
+	GetScheduleFunc func(locationID uint, day time.Time) ([]models.PickupSchedule, error)
+	MarkReadyFunc   func(id uint) (*models.PickupReservation, error)
+	StartFunc       func(id uint) (*models.PickupReservation, error)
+	CancelFunc      func(id uint, req *models.CancelPickupRequest) (*models.PickupReservation, error)
+}
+
+func (m *PickupService) CreateSlot(locationID uint, req *models.CreatePickupSlotRequest) (*models.PickupSlot, error) {
+	if m.CreateSlotFunc == nil {
+		unexpected("PickupService.CreateSlot")
+	}
+	return m.CreateSlotFunc(locationID, req)
+}
+
+func (m *PickupService) GetSlots(locationID uint, day time.Time) ([]models.PickupSlot, error) {
+	if m.GetSlotsFunc == nil {
+		unexpected("PickupService.GetSlots")
+	}
+	return m.GetSlotsFunc(locationID, day)
+}
+
+func (m *PickupService) Reserve(locationID, slotID uint, req *models.ReservePickupRequest) (*models.PickupReservation, error) {
+	if m.ReserveFunc == nil {
+		unexpected("PickupService.Reserve")
+	}
+	return m.ReserveFunc(locationID, slotID, req)
+}
+
+func (m *PickupService) GetSchedule(locationID uint, day time.Time) ([]models.PickupSchedule, error) {
+	if m.GetScheduleFunc == nil {
+		unexpected("PickupService.GetSchedule")
+	}
+	return m.GetScheduleFunc(locationID, day)
+}
+
+func (m *PickupService) MarkReady(id uint) (*models.PickupReservation, error) {
+	if m.MarkReadyFunc == nil {
+		unexpected("PickupService.MarkReady")
+	}
+	return m.MarkReadyFunc(id)
+}
+
+func (m *PickupService) Start(id uint) (*models.PickupReservation, error) {
+	if m.StartFunc == nil {
+		unexpected("PickupService.Start")
+	}
+	return m.StartFunc(id)
+}
+
+func (m *PickupService) Cancel(id uint, req *models.CancelPickupRequest) (*models.PickupReservation, error) {
+	if m.CancelFunc == nil {
+		unexpected("PickupService.Cancel")
+	}
+	return m.CancelFunc(id, req)
+}
+
+// WebhookService is a mock of service.WebhookServiceInterface.
+type WebhookService struct {
+	PublishFunc        func(event string, data interface{})
+	CreateWebhookFunc  func(req *models.CreateWebhookRequest) (*models.Webhook, error)
+	GetWebhookFunc     func(id uint) (*models.Webhook, error)
+	GetAllWebhooksFunc func() ([]models.Webhook, error)
+	UpdateWebhookFunc  func(id uint, req *models.UpdateWebhookRequest) (*models.Webhook, error)
+	DeleteWebhookFunc  func(id uint) error
+	GetDeliveriesFunc  func(id uint) ([]models.WebhookDelivery, error)
+}
+
+func (m *WebhookService) Publish(event string, data interface{}) {
+	if m.PublishFunc == nil {
+		unexpected("WebhookService.Publish")
+	}
+	m.PublishFunc(event, data)
+}
+
+func (m *WebhookService) CreateWebhook(req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	if m.CreateWebhookFunc == nil {
+		unexpected("WebhookService.CreateWebhook")
+	}
+	return m.CreateWebhookFunc(req)
+}
+
+func (m *WebhookService) GetWebhook(id uint) (*models.Webhook, error) {
+	if m.GetWebhookFunc == nil {
+		unexpected("WebhookService.GetWebhook")
+	}
+	return m.GetWebhookFunc(id)
+}
+
+func (m *WebhookService) GetAllWebhooks() ([]models.Webhook, error) {
+	if m.GetAllWebhooksFunc == nil {
+		unexpected("WebhookService.GetAllWebhooks")
+	}
+	return m.GetAllWebhooksFunc()
+}
+
+func (m *WebhookService) UpdateWebhook(id uint, req *models.UpdateWebhookRequest) (*models.Webhook, error) {
+	if m.UpdateWebhookFunc == nil {
+		unexpected("WebhookService.UpdateWebhook")
+	}
+	return m.UpdateWebhookFunc(id, req)
+}
+
+func (m *WebhookService) DeleteWebhook(id uint) error {
+	if m.DeleteWebhookFunc == nil {
+		unexpected("WebhookService.DeleteWebhook")
+	}
+	return m.DeleteWebhookFunc(id)
+}
+
+func (m *WebhookService) GetDeliveries(id uint) ([]models.WebhookDelivery, error) {
+	if m.GetDeliveriesFunc == nil {
+		unexpected("WebhookService.GetDeliveries")
+	}
+	return m.GetDeliveriesFunc(id)
+}
+
+// ExperimentService is a mock of service.ExperimentServiceInterface.
+type ExperimentService struct {
+	CreateExperimentFunc  func(req *models.CreateExperimentRequest) (*models.Experiment, error)
+	GetExperimentFunc     func(id uint) (*models.ExperimentResults, error)
+	GetAllExperimentsFunc func() ([]models.Experiment, error)
+	StopExperimentFunc    func(id uint) (*models.Experiment, error)
+	AssignFunc            func(visitorID string) ([]models.ExperimentAssignment, error)
+	ApplyPricesFunc       func(cupcakes []models.Cupcake, visitorID string) ([]models.ExperimentAssignment, error)
+	RecordConversionFunc  func(key, visitorID string, req *models.ConversionRequest) error
+}
+
+func (m *ExperimentService) CreateExperiment(req *models.CreateExperimentRequest) (*models.Experiment, error) {
+	if m.CreateExperimentFunc == nil {
+		unexpected("ExperimentService.CreateExperiment")
+	}
+	return m.CreateExperimentFunc(req)
+}
+
+func (m *ExperimentService) GetExperiment(id uint) (*models.ExperimentResults, error) {
+	if m.GetExperimentFunc == nil {
+		unexpected("ExperimentService.GetExperiment")
+	}
+	return m.GetExperimentFunc(id)
+}
+
+func (m *ExperimentService) GetAllExperiments() ([]models.Experiment, error) {
+	if m.GetAllExperimentsFunc == nil {
+		unexpected("ExperimentService.GetAllExperiments")
+	}
+	return m.GetAllExperimentsFunc()
+}
+
+func (m *ExperimentService) StopExperiment(id uint) (*models.Experiment, error) {
+	if m.StopExperimentFunc == nil {
+		unexpected("ExperimentService.StopExperiment")
+	}
+	return m.StopExperimentFunc(id)
+}
+
+func (m *ExperimentService) Assign(visitorID string) ([]models.ExperimentAssignment, error) {
+	if m.AssignFunc == nil {
+		unexpected("ExperimentService.Assign")
+	}
+	return m.AssignFunc(visitorID)
+}
+
+func (m *ExperimentService) ApplyPrices(cupcakes []models.Cupcake, visitorID string) ([]models.ExperimentAssignment, error) {
+	if m.ApplyPricesFunc == nil {
+		unexpected("ExperimentService.ApplyPrices")
+	}
+	return m.ApplyPricesFunc(cupcakes, visitorID)
+}
+
+func (m *ExperimentService) RecordConversion(key, visitorID string, req *models.ConversionRequest) error {
+	if m.RecordConversionFunc == nil {
+		unexpected("ExperimentService.RecordConversion")
+	}
+	return m.RecordConversionFunc(key, visitorID, req)
+}
+
+// DataExportService is a mock of service.DataExportServiceInterface.
+type DataExportService struct {
+	RequestFunc  func(email string) (*models.DataExport, error)
+	DownloadFunc func(id uint, expires int64, signature string) (*models.DataExport, error)
+}
+
+func (m *DataExportService) Request(email string) (*models.DataExport, error) {
+	if m.RequestFunc == nil {
+		unexpected("DataExportService.Request")
+	}
+	return m.RequestFunc(email)
+}
+
+func (m *DataExportService) Download(id uint, expires int64, signature string) (*models.DataExport, error) {
+	if m.DownloadFunc == nil {
+		unexpected("DataExportService.Download")
+	}
+	return m.DownloadFunc(id, expires, signature)
+}
+
+// ErasureService is a mock of service.ErasureServiceInterface.
+type ErasureService struct {
+	RequestFunc     func(email string) error
+	ConfirmFunc     func(email string, expires int64, token string) (*models.Erasure, error)
+	EraseFunc       func(email string) (*models.Erasure, error)
+	GetErasuresFunc func(email string) ([]models.Erasure, error)
+}
+
+func (m *ErasureService) Request(email string) error {
+	if m.RequestFunc == nil {
+		unexpected("ErasureService.Request")
+	}
+	return m.RequestFunc(email)
+}
+
+func (m *ErasureService) Confirm(email string, expires int64, token string) (*models.Erasure, error) {
+	if m.ConfirmFunc == nil {
+		unexpected("ErasureService.Confirm")
+	}
+	return m.ConfirmFunc(email, expires, token)
+}
+
+func (m *ErasureService) Erase(email string) (*models.Erasure, error) {
+	if m.EraseFunc == nil {
+		unexpected("ErasureService.Erase")
+	}
+	return m.EraseFunc(email)
+}
+
+func (m *ErasureService) GetErasures(email string) ([]models.Erasure, error) {
+	if m.GetErasuresFunc == nil {
+		unexpected("ErasureService.GetErasures")
+	}
+	return m.GetErasuresFunc(email)
+}
+
+// AccountService is a mock of service.AccountServiceInterface.
+type AccountService struct {
+	RegisterFunc           func(req *models.RegisterRequest) (*models.Account, error)
+	LoginFunc              func(req *models.LoginRequest) (*models.AccessToken, error)
+	AuthenticateFunc       func(token string) (*models.Account, error)
+	VerifyEmailFunc        func(token string) (*models.Account, error)
+	ResendVerificationFunc func(accessToken string) error
+}
+
+func (m *AccountService) Register(req *models.RegisterRequest) (*models.Account, error) {
+	if m.RegisterFunc == nil {
+		unexpected("AccountService.Register")
+	}
+	return m.RegisterFunc(req)
+}
+
+func (m *AccountService) Login(req *models.LoginRequest) (*models.AccessToken, error) {
+	if m.LoginFunc == nil {
+		unexpected("AccountService.Login")
+	}
+	return m.LoginFunc(req)
+}
+
+func (m *AccountService) Authenticate(token string) (*models.Account, error) {
+	if m.AuthenticateFunc == nil {
+		unexpected("AccountService.Authenticate")
+	}
+	return m.AuthenticateFunc(token)
+}
+
+func (m *AccountService) VerifyEmail(token string) (*models.Account, error) {
+	if m.VerifyEmailFunc == nil {
+		unexpected("AccountService.VerifyEmail")
+	}
+	return m.VerifyEmailFunc(token)
+}
+
+func (m *AccountService) ResendVerification(accessToken string) error {
+	if m.ResendVerificationFunc == nil {
+		unexpected("AccountService.ResendVerification")
+	}
+	return m.ResendVerificationFunc(accessToken)
+}
+
+// AdminService is a mock of service.AdminServiceInterface.
+type AdminService struct {
+	CreateAdminFunc           func(req *models.CreateAdminRequest) (*models.Admin, error)
+	GetAdminsFunc             func() ([]models.Admin, error)
+	LoginFunc                 func(req *models.AdminLoginRequest) (*models.AdminAccessToken, error)
+	AuthenticateFunc          func(token string) (*models.Admin, error)
+	BeginTOTPFunc             func(adminID uint) (*models.TOTPEnrollment, error)
+	ConfirmTOTPFunc           func(adminID uint, code string) (*models.BackupCodes, error)
+	DisableTOTPFunc           func(adminID uint, code string) error
+	RegenerateBackupCodesFunc func(adminID uint, code string) (*models.BackupCodes, error)
+	ResetTOTPFunc             func(adminID uint) (*models.Admin, error)
+	SendLoginCodeFunc         func(req *models.AdminLoginRequest) error
+	SetPhoneFunc              func(adminID uint, phone string) (*models.Admin, error)
+}
+
+func (m *AdminService) CreateAdmin(req *models.CreateAdminRequest) (*models.Admin, error) {
+	if m.CreateAdminFunc == nil {
+		unexpected("AdminService.CreateAdmin")
+	}
+	return m.CreateAdminFunc(req)
+}
+
+func (m *AdminService) GetAdmins() ([]models.Admin, error) {
+	if m.GetAdminsFunc == nil {
+		unexpected("AdminService.GetAdmins")
+	}
+	return m.GetAdminsFunc()
+}
+
+func (m *AdminService) Login(req *models.AdminLoginRequest) (*models.AdminAccessToken, error) {
+	if m.LoginFunc == nil {
+		unexpected("AdminService.Login")
+	}
+	return m.LoginFunc(req)
+}
+
+func (m *AdminService) Authenticate(token string) (*models.Admin, error) {
+	if m.AuthenticateFunc == nil {
+		unexpected("AdminService.Authenticate")
+	}
+	return m.AuthenticateFunc(token)
+}
+
+func (m *AdminService) BeginTOTP(adminID uint) (*models.TOTPEnrollment, error) {
+	if m.BeginTOTPFunc == nil {
+		unexpected("AdminService.BeginTOTP")
+	}
+	return m.BeginTOTPFunc(adminID)
+}
+
+func (m *AdminService) ConfirmTOTP(adminID uint, code string) (*models.BackupCodes, error) {
+	if m.ConfirmTOTPFunc == nil {
+		unexpected("AdminService.ConfirmTOTP")
+	}
+	return m.ConfirmTOTPFunc(adminID, code)
+}
+
+func (m *AdminService) DisableTOTP(adminID uint, code string) error {
+	if m.DisableTOTPFunc == nil {
+		unexpected("AdminService.DisableTOTP")
+	}
+	return m.DisableTOTPFunc(adminID, code)
+}
+
+func (m *AdminService) RegenerateBackupCodes(adminID uint, code string) (*models.BackupCodes, error) {
+	if m.RegenerateBackupCodesFunc == nil {
+		unexpected("AdminService.RegenerateBackupCodes")
+	}
+	return m.RegenerateBackupCodesFunc(adminID, code)
+}
+
+func (m *AdminService) ResetTOTP(adminID uint) (*models.Admin, error) {
+	if m.ResetTOTPFunc == nil {
+		unexpected("AdminService.ResetTOTP")
+	}
+	return m.ResetTOTPFunc(adminID)
+}
+
+func (m *AdminService) SendLoginCode(req *models.AdminLoginRequest) error {
+	if m.SendLoginCodeFunc == nil {
+		unexpected("AdminService.SendLoginCode")
+	}
+	return m.SendLoginCodeFunc(req)
+}
+
+func (m *AdminService) SetPhone(adminID uint, phone string) (*models.Admin, error) {
+	if m.SetPhoneFunc == nil {
+		unexpected("AdminService.SetPhone")
+	}
+	return m.SetPhoneFunc(adminID, phone)
+}
+
+// SessionService is a mock of service.SessionServiceInterface.
+type SessionService struct {
+	LoginFunc       func(req *models.LoginRequest) (*models.WebSession, error)
+	GetFunc         func(token string) (*models.WebSession, error)
+	AccessTokenFunc func(token string) (*models.AccessToken, error)
+	ValidCSRFFunc   func(token, csrfToken string) bool
+	LogoutFunc      func(token string) error
+}
+
+func (m *SessionService) Login(req *models.LoginRequest) (*models.WebSession, error) {
+	if m.LoginFunc == nil {
+		unexpected("SessionService.Login")
+	}
+	return m.LoginFunc(req)
+}
+
+func (m *SessionService) Get(token string) (*models.WebSession, error) {
+	if m.GetFunc == nil {
+		unexpected("SessionService.Get")
+	}
+	return m.GetFunc(token)
+}
+
+func (m *SessionService) AccessToken(token string) (*models.AccessToken, error) {
+	if m.AccessTokenFunc == nil {
+		unexpected("SessionService.AccessToken")
+	}
+	return m.AccessTokenFunc(token)
+}
+
+func (m *SessionService) ValidCSRF(token, csrfToken string) bool {
+	if m.ValidCSRFFunc == nil {
+		unexpected("SessionService.ValidCSRF")
+	}
+	return m.ValidCSRFFunc(token, csrfToken)
+}
+
+func (m *SessionService) Logout(token string) error {
+	if m.LogoutFunc == nil {
+		unexpected("SessionService.Logout")
+	}
+	return m.LogoutFunc(token)
+}
+
+// TicketService is a mock of service.TicketServiceInterface.
+type TicketService struct {
+	CreateTicketFunc func(req *models.CreateTicketRequest) (*models.Ticket, error)
+	GetTicketFunc    func(id uint) (*models.Ticket, error)
+	GetTicketsFunc   func(status string) ([]models.Ticket, error)
+	UpdateTicketFunc func(id uint, req *models.UpdateTicketRequest) (*models.Ticket, error)
+}
+
+func (m *TicketService) CreateTicket(req *models.CreateTicketRequest) (*models.Ticket, error) {
+	if m.CreateTicketFunc == nil {
+		unexpected("TicketService.CreateTicket")
+	}
+	return m.CreateTicketFunc(req)
+}
+
+func (m *TicketService) GetTicket(id uint) (*models.Ticket, error) {
+	if m.GetTicketFunc == nil {
+		unexpected("TicketService.GetTicket")
+	}
+	return m.GetTicketFunc(id)
+}
+
+func (m *TicketService) GetTickets(status string) ([]models.Ticket, error) {
+	if m.GetTicketsFunc == nil {
+		unexpected("TicketService.GetTickets")
+	}
+	return m.GetTicketsFunc(status)
+}
+
+func (m *TicketService) UpdateTicket(id uint, req *models.UpdateTicketRequest) (*models.Ticket, error) {
+	if m.UpdateTicketFunc == nil {
+		unexpected("TicketService.UpdateTicket")
+	}
+	return m.UpdateTicketFunc(id, req)
+}
+
+// EmailTemplateService is a mock of service.EmailTemplateServiceInterface.
+type EmailTemplateService struct {
+	GetTemplatesFunc  func(locationID uint) ([]models.EmailTemplateResponse, error)
+	GetTemplateFunc   func(name string, locationID uint) (*models.EmailTemplateResponse, error)
+	SetTemplateFunc   func(name string, req *models.UpdateEmailTemplateRequest) (*models.EmailTemplateResponse, error)
+	ResetTemplateFunc func(name string, locationID uint) error
+	PreviewFunc       func(name string, req *models.PreviewEmailTemplateRequest) (*models.RenderedEmail, error)
+	RenderFunc        func(name string, locationID uint, data any) (*models.RenderedEmail, error)
+}
+
+func (m *EmailTemplateService) GetTemplates(locationID uint) ([]models.EmailTemplateResponse, error) {
+	if m.GetTemplatesFunc == nil {
+		unexpected("EmailTemplateService.GetTemplates")
+	}
+	return m.GetTemplatesFunc(locationID)
+}
+
+func (m *EmailTemplateService) GetTemplate(name string, locationID uint) (*models.EmailTemplateResponse, error) {
+	if m.GetTemplateFunc == nil {
+		unexpected("EmailTemplateService.GetTemplate")
+	}
+	return m.GetTemplateFunc(name, locationID)
+}
+
+func (m *EmailTemplateService) SetTemplate(name string, req *models.UpdateEmailTemplateRequest) (*models.EmailTemplateResponse, error) {
+	if m.SetTemplateFunc == nil {
+		unexpected("EmailTemplateService.SetTemplate")
+	}
+	return m.SetTemplateFunc(name, req)
+}
+
+func (m *EmailTemplateService) ResetTemplate(name string, locationID uint) error {
+	if m.ResetTemplateFunc == nil {
+		unexpected("EmailTemplateService.ResetTemplate")
+	}
+	return m.ResetTemplateFunc(name, locationID)
+}
+
+func (m *EmailTemplateService) Preview(name string, req *models.PreviewEmailTemplateRequest) (*models.RenderedEmail, error) {
+	if m.PreviewFunc == nil {
+		unexpected("EmailTemplateService.Preview")
+	}
+	return m.PreviewFunc(name, req)
+}
+
+func (m *EmailTemplateService) Render(name string, locationID uint, data any) (*models.RenderedEmail, error) {
+	if m.RenderFunc == nil {
+		unexpected("EmailTemplateService.Render")
+	}
+	return m.RenderFunc(name, locationID, data)
+}
+
+// NotificationService is a mock of service.NotificationServiceInterface.
+type NotificationService struct {
+	GetPreferencesFunc    func(accountID uint) ([]models.NotificationPreferenceResponse, error)
+	UpdatePreferencesFunc func(accountID uint, req *models.UpdateNotificationPreferencesRequest) ([]models.NotificationPreferenceResponse, error)
+}
+
+func (m *NotificationService) GetPreferences(accountID uint) ([]models.NotificationPreferenceResponse, error) {
+	if m.GetPreferencesFunc == nil {
+		unexpected("NotificationService.GetPreferences")
+	}
+	return m.GetPreferencesFunc(accountID)
+}
+
+func (m *NotificationService) UpdatePreferences(accountID uint, req *models.UpdateNotificationPreferencesRequest) ([]models.NotificationPreferenceResponse, error) {
+	if m.UpdatePreferencesFunc == nil {
+		unexpected("NotificationService.UpdatePreferences")
+	}
+	return m.UpdatePreferencesFunc(accountID, req)
+}
+
+// DeviceService is a mock of service.DeviceServiceInterface.
+type DeviceService struct {
+	GetDevicesFunc       func(accountID uint) ([]models.DeviceToken, error)
+	RegisterDeviceFunc   func(accountID uint, req *models.RegisterDeviceRequest) (*models.DeviceToken, error)
+	UnregisterDeviceFunc func(accountID uint, token string) error
+}
+
+func (m *DeviceService) GetDevices(accountID uint) ([]models.DeviceToken, error) {
+	if m.GetDevicesFunc == nil {
+		unexpected("DeviceService.GetDevices")
+	}
+	return m.GetDevicesFunc(accountID)
+}
+
+func (m *DeviceService) RegisterDevice(accountID uint, req *models.RegisterDeviceRequest) (*models.DeviceToken, error) {
+	if m.RegisterDeviceFunc == nil {
+		unexpected("DeviceService.RegisterDevice")
+	}
+	return m.RegisterDeviceFunc(accountID, req)
+}
+
+func (m *DeviceService) UnregisterDevice(accountID uint, token string) error {
+	if m.UnregisterDeviceFunc == nil {
+		unexpected("DeviceService.UnregisterDevice")
+	}
+	return m.UnregisterDeviceFunc(accountID, token)
+}
+
+// StockAlertService is a mock of service.StockAlertServiceInterface.
+type StockAlertService struct {
+	CheckStockFunc       func() (int, error)
+	GetAlertsFunc        func(status string) ([]models.StockAlert, error)
+	AcknowledgeAlertFunc func(id uint) (*models.StockAlert, error)
+	ResolveAlertFunc     func(id uint) (*models.StockAlert, error)
+}
+
+func (m *StockAlertService) CheckStock() (int, error) {
+	if m.CheckStockFunc == nil {
+		unexpected("StockAlertService.CheckStock")
+	}
+	return m.CheckStockFunc()
+}
+
+func (m *StockAlertService) GetAlerts(status string) ([]models.StockAlert, error) {
+	if m.GetAlertsFunc == nil {
+		unexpected("StockAlertService.GetAlerts")
+	}
+	return m.GetAlertsFunc(status)
+}
+
+func (m *StockAlertService) AcknowledgeAlert(id uint) (*models.StockAlert, error) {
+	if m.AcknowledgeAlertFunc == nil {
+		unexpected("StockAlertService.AcknowledgeAlert")
+	}
+	return m.AcknowledgeAlertFunc(id)
+}
+
+func (m *StockAlertService) ResolveAlert(id uint) (*models.StockAlert, error) {
+	if m.ResolveAlertFunc == nil {
+		unexpected("StockAlertService.ResolveAlert")
+	}
+	return m.ResolveAlertFunc(id)
+}
+
+// APIKeyService is a mock of service.APIKeyServiceInterface.
+type APIKeyService struct {
+	CreateAPIKeyFunc func(req *models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error)
+	GetAPIKeysFunc   func() ([]models.APIKey, error)
+	GetAPIKeyFunc    func(id uint) (*models.APIKey, error)
+	UpdateAPIKeyFunc func(id uint, req *models.UpdateAPIKeyRequest) (*models.APIKey, error)
+	RevokeAPIKeyFunc func(id uint) (*models.APIKey, error)
+	UseFunc          func(secret string) (*models.APIKeyQuota, error)
+	UsageFunc        func(id uint, window int) (*models.APIKeyUsageReport, error)
+}
+
+func (m *APIKeyService) CreateAPIKey(req *models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	if m.CreateAPIKeyFunc == nil {
+		unexpected("APIKeyService.CreateAPIKey")
+	}
+	return m.CreateAPIKeyFunc(req)
+}
+
+func (m *APIKeyService) GetAPIKeys() ([]models.APIKey, error) {
+	if m.GetAPIKeysFunc == nil {
+		unexpected("APIKeyService.GetAPIKeys")
+	}
+	return m.GetAPIKeysFunc()
+}
+
+func (m *APIKeyService) GetAPIKey(id uint) (*models.APIKey, error) {
+	if m.GetAPIKeyFunc == nil {
+		unexpected("APIKeyService.GetAPIKey")
+	}
+	return m.GetAPIKeyFunc(id)
+}
+
+func (m *APIKeyService) UpdateAPIKey(id uint, req *models.UpdateAPIKeyRequest) (*models.APIKey, error) {
+	if m.UpdateAPIKeyFunc == nil {
+		unexpected("APIKeyService.UpdateAPIKey")
+	}
+	return m.UpdateAPIKeyFunc(id, req)
+}
+
+func (m *APIKeyService) RevokeAPIKey(id uint) (*models.APIKey, error) {
+	if m.RevokeAPIKeyFunc == nil {
+		unexpected("APIKeyService.RevokeAPIKey")
+	}
+	return m.RevokeAPIKeyFunc(id)
+}
+
+func (m *APIKeyService) Use(secret string) (*models.APIKeyQuota, error) {
+	if m.UseFunc == nil {
+		unexpected("APIKeyService.Use")
+	}
+	return m.UseFunc(secret)
+}
+
+func (m *APIKeyService) Usage(id uint, window int) (*models.APIKeyUsageReport, error) {
+	if m.UsageFunc == nil {
+		unexpected("APIKeyService.Usage")
+	}
+	return m.UsageFunc(id, window)
+}
+
+// OAuthService is a mock of service.OAuthServiceInterface.
+type OAuthService struct {
+	ProvidersFunc  func() []string
+	BeginFunc      func(provider, redirectURI string) (*models.OAuthAuthorization, error)
+	BeginLinkFunc  func(accessToken, provider, redirectURI string) (*models.OAuthAuthorization, error)
+	CompleteFunc   func(ctx context.Context, provider, code, state, nonce, redirectURI string) (*models.AccessToken, error)
+	IdentitiesFunc func(accessToken string) ([]models.AccountIdentity, error)
+	UnlinkFunc     func(accessToken, provider string) error
+}
+
+func (m *OAuthService) Providers() []string {
+	if m.ProvidersFunc == nil {
+		unexpected("OAuthService.Providers")
+	}
+	return m.ProvidersFunc()
+}
+
+func (m *OAuthService) Begin(provider, redirectURI string) (*models.OAuthAuthorization, error) {
+	if m.BeginFunc == nil {
+		unexpected("OAuthService.Begin")
+	}
+	return m.BeginFunc(provider, redirectURI)
+}
+
+func (m *OAuthService) BeginLink(accessToken, provider, redirectURI string) (*models.OAuthAuthorization, error) {
+	if m.BeginLinkFunc == nil {
+		unexpected("OAuthService.BeginLink")
+	}
+	return m.BeginLinkFunc(accessToken, provider, redirectURI)
+}
+
+func (m *OAuthService) Complete(ctx context.Context, provider, code, state, nonce, redirectURI string) (*models.AccessToken, error) {
+	if m.CompleteFunc == nil {
+		unexpected("OAuthService.Complete")
+	}
+	return m.CompleteFunc(ctx, provider, code, state, nonce, redirectURI)
+}
+
+func (m *OAuthService) Identities(accessToken string) ([]models.AccountIdentity, error) {
+	if m.IdentitiesFunc == nil {
+		unexpected("OAuthService.Identities")
+	}
+	return m.IdentitiesFunc(accessToken)
+}
+
+func (m *OAuthService) Unlink(accessToken, provider string) error {
+	if m.UnlinkFunc == nil {
+		unexpected("OAuthService.Unlink")
+	}
+	return m.UnlinkFunc(accessToken, provider)
+}
+
+// SearchIndex is a mock of service.SearchIndex.
+type SearchIndex struct {
+	IndexFunc  func(ctx context.Context, doc models.SearchDocument) error
+	DeleteFunc func(ctx context.Context, id uint) error
+	SearchFunc func(ctx context.Context, query models.SearchQuery) (*models.SearchHits, error)
+}
+
+func (m *SearchIndex) Index(ctx context.Context, doc models.SearchDocument) error {
+	if m.IndexFunc == nil {
+		unexpected("SearchIndex.Index")
+	}
+	return m.IndexFunc(ctx, doc)
+}
+
+func (m *SearchIndex) Delete(ctx context.Context, id uint) error {
+	if m.DeleteFunc == nil {
+		unexpected("SearchIndex.Delete")
+	}
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *SearchIndex) Search(ctx context.Context, query models.SearchQuery) (*models.SearchHits, error) {
+	if m.SearchFunc == nil {
+		unexpected("SearchIndex.Search")
+	}
+	return m.SearchFunc(ctx, query)
+}
+
+// CaptchaVerifier is a mock of service.CaptchaVerifier.
+type CaptchaVerifier struct {
+	VerifyFunc func(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+func (m *CaptchaVerifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	if m.VerifyFunc == nil {
+		unexpected("CaptchaVerifier.Verify")
+	}
+	return m.VerifyFunc(ctx, token, remoteIP)
+}
+
+// EventPublisher is a mock of service.EventPublisher.
+type EventPublisher struct {
+	PublishFunc func(event string, data interface{})
+}
+
+func (m *EventPublisher) Publish(event string, data interface{}) {
+	if m.PublishFunc == nil {
+		unexpected("EventPublisher.Publish")
+	}
+	m.PublishFunc(event, data)
+}
+
+// EmailSender is a mock of service.EmailSender.
+type EmailSender struct {
+	SendFunc func(ctx context.Context, to string, email *models.RenderedEmail) error
+}
+
+func (m *EmailSender) Send(ctx context.Context, to string, email *models.RenderedEmail) error {
+	if m.SendFunc == nil {
+		unexpected("EmailSender.Send")
+	}
+	return m.SendFunc(ctx, to, email)
+}
+
+// TicketPrinter is a mock of service.TicketPrinter.
+type TicketPrinter struct {
+	PrintFunc func(ctx context.Context, ticket *models.PrintTicket) error
+}
+
+func (m *TicketPrinter) Print(ctx context.Context, ticket *models.PrintTicket) error {
+	if m.PrintFunc == nil {
+		unexpected("TicketPrinter.Print")
+	}
+	return m.PrintFunc(ctx, ticket)
+}
+
+// PushSender is a mock of service.PushSender.
+type PushSender struct {
+	SendFunc func(ctx context.Context, token string, message *models.PushMessage) error
+}
+
+func (m *PushSender) Send(ctx context.Context, token string, message *models.PushMessage) error {
+	if m.SendFunc == nil {
+		unexpected("PushSender.Send")
+	}
+	return m.SendFunc(ctx, token, message)
+}
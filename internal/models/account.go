@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+// Account is a customer's login. PasswordHash is an argon2id PHC string
+// and never leaves the server. As with the other customer data, the name
+// and email are encrypted at rest and EmailHash keeps the email unique.
+// EmailVerifiedAt is set once the customer follows the verification link.
+type Account struct {
+	ID              uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name            string     `json:"name" gorm:"not null;size:255;serializer:pii"`
+	Email           string     `json:"email" gorm:"not null;size:512;serializer:pii"`
+	EmailHash       string     `json:"-" gorm:"size:64;uniqueIndex"`
+	PasswordHash    string     `json:"-" gorm:"not null;size:255"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Account) TableName() string {
+	return "accounts"
+}
+
+func (a *Account) BeforeSave(*gorm.DB) error {
+	a.EmailHash = pii.Hash(a.Email)
+	return nil
+}
+
+type RegisterRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// AccessToken is what a successful login returns: a bearer token for the
+// account's own endpoints and how long it lasts.
+type AccessToken struct {
+	AccessToken string   `json:"access_token"`
+	TokenType   string   `json:"token_type"`
+	ExpiresIn   int      `json:"expires_in"`
+	Account     *Account `json:"account"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// AccountIdentity links an account to a login at an OAuth provider, by the
+// provider's stable user ID. An account can have one identity per
+// provider, and each provider login belongs to one account.
+type AccountIdentity struct {
+	ID        uint      `json:"-" gorm:"primaryKey;autoIncrement"`
+	AccountID uint      `json:"-" gorm:"not null;uniqueIndex:idx_identity_account"`
+	Provider  string    `json:"provider" gorm:"not null;size:20;uniqueIndex:idx_identity_subject;uniqueIndex:idx_identity_account"`
+	Subject   string    `json:"-" gorm:"not null;size:255;uniqueIndex:idx_identity_subject"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (AccountIdentity) TableName() string {
+	return "account_identities"
+}
+
+// OAuthProfile is what an OAuth provider tells about the user who logged
+// in. Email is only set when the provider has verified it.
+type OAuthProfile struct {
+	Subject string
+	Email   string
+	Name    string
+}
+
+// OAuthAuthorization is where to send the customer to log in at the
+// provider. Nonce is kept in the customer's browser until the provider
+// redirects back.
+type OAuthAuthorization struct {
+	AuthorizationURL string `json:"authorization_url"`
+	Nonce            string `json:"-"`
+}
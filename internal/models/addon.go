@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Addon is an extra sold alongside cupcakes, such as candles or gift
+// wrapping.
+type Addon struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"not null;size:100;uniqueIndex"`
+	PriceCents  int       `json:"price_cents" gorm:"not null"`
+	IsAvailable bool      `json:"is_available"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Addon) TableName() string {
+	return "addons"
+}
+
+type CreateAddonRequest struct {
+	Name       string `json:"name" validate:"required"`
+	PriceCents int    `json:"price_cents" validate:"gte=0"`
+}
+
+type UpdateAddonRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty"`
+	PriceCents  *int    `json:"price_cents,omitempty" validate:"omitempty,gte=0"`
+	IsAvailable *bool   `json:"is_available,omitempty"`
+}
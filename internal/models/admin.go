@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+const (
+	AdminRoleAdmin      = "admin"
+	AdminRoleSuperAdmin = "super_admin"
+)
+
+// Admin is a staff login to the admin API. Two-factor authentication is
+// on once TOTPEnabledAt is set; until then a TOTPSecret is only a pending
+// enrollment. TOTPLastStep is the time step of the last code accepted, so
+// no code is accepted twice. An admin with a Phone can also be sent a
+// one-time code by SMS, kept as SMSCodeHash until used or five minutes
+// after SMSCodeSentAt. The email, phone and TOTP secret are encrypted at
+// rest.
+type Admin struct {
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name          string     `json:"name" gorm:"not null;size:100"`
+	Email         string     `json:"email" gorm:"not null;size:512;serializer:pii"`
+	EmailHash     string     `json:"-" gorm:"size:64;uniqueIndex"`
+	PasswordHash  string     `json:"-" gorm:"not null;size:255"`
+	Role          string     `json:"role" gorm:"not null;size:20;default:admin"`
+	TOTPSecret    string     `json:"-" gorm:"column:totp_secret;size:255;serializer:pii"`
+	TOTPEnabledAt *time.Time `json:"totp_enabled_at,omitempty" gorm:"column:totp_enabled_at"`
+	TOTPLastStep  int64      `json:"-" gorm:"column:totp_last_step;not null;default:0"`
+	Phone         string     `json:"phone,omitempty" gorm:"size:512;serializer:pii"`
+	SMSCodeHash   string     `json:"-" gorm:"column:sms_code_hash;size:64"`
+	SMSCodeSentAt *time.Time `json:"-" gorm:"column:sms_code_sent_at"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Admin) TableName() string {
+	return "admins"
+}
+
+func (a *Admin) BeforeSave(*gorm.DB) error {
+	a.EmailHash = pii.Hash(a.Email)
+	return nil
+}
+
+// AdminBackupCode is a one-time code for logging in without the
+// authenticator. Only a SHA-256 hash of the code is kept.
+type AdminBackupCode struct {
+	ID       uint       `json:"-" gorm:"primaryKey;autoIncrement"`
+	AdminID  uint       `json:"-" gorm:"not null;index"`
+	CodeHash string     `json:"-" gorm:"not null;size:64"`
+	UsedAt   *time.Time `json:"-"`
+}
+
+func (AdminBackupCode) TableName() string {
+	return "admin_backup_codes"
+}
+
+type CreateAdminRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+	Role     string `json:"role,omitempty" validate:"omitempty,oneof=admin super_admin"`
+}
+
+// AdminLoginRequest carries Code, an authenticator code, a code sent by
+// SMS or a backup code, for admins with two-factor authentication on.
+type AdminLoginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code,omitempty"`
+}
+
+type AdminAccessToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Admin       *Admin `json:"admin"`
+}
+
+// TOTPEnrollment is what an authenticator app needs to add the admin:
+// the secret to type in, or the provisioning URI, also as a PNG QR code
+// in a data URI.
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	QRCode          string `json:"qr_code,omitempty"`
+}
+
+// AdminPhoneRequest sets the phone SMS codes are sent to, in E.164 form;
+// an empty one removes it.
+type AdminPhoneRequest struct {
+	Phone string `json:"phone" validate:"omitempty,e164"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// BackupCodes are shown once, when generated.
+type BackupCodes struct {
+	BackupCodes []string `json:"backup_codes"`
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// APIKey lets an integration call the public API under its own quotas.
+// Only a SHA-256 hash of the key is kept; Prefix, the start of the key,
+// tells keys apart in listings. A zero quota is unlimited.
+type APIKey struct {
+	ID           uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name         string     `json:"name" gorm:"not null;size:100"`
+	Prefix       string     `json:"prefix" gorm:"not null;size:16"`
+	KeyHash      string     `json:"-" gorm:"not null;size:64;uniqueIndex"`
+	DailyQuota   int        `json:"daily_quota" gorm:"not null;default:0"`
+	MonthlyQuota int        `json:"monthly_quota" gorm:"not null;default:0"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// APIKeyUsage is the number of requests made with a key on one day
+// (YYYY-MM-DD, local time), as cupcake views are counted.
+type APIKeyUsage struct {
+	APIKeyID uint   `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	Day      string `json:"day" gorm:"primaryKey;size:10"`
+	Requests int    `json:"requests" gorm:"not null"`
+}
+
+func (APIKeyUsage) TableName() string {
+	return "api_key_usage"
+}
+
+type CreateAPIKeyRequest struct {
+	Name         string `json:"name" validate:"required,max=100"`
+	DailyQuota   int    `json:"daily_quota" validate:"gte=0"`
+	MonthlyQuota int    `json:"monthly_quota" validate:"gte=0"`
+}
+
+type UpdateAPIKeyRequest struct {
+	Name         *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	DailyQuota   *int    `json:"daily_quota,omitempty" validate:"omitempty,gte=0"`
+	MonthlyQuota *int    `json:"monthly_quota,omitempty" validate:"omitempty,gte=0"`
+}
+
+// CreatedAPIKey carries the key itself, shown only when it is created.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyQuota is where a key stands against its quotas after a request.
+// Remaining is what is left of the quota that runs out first, and Reset
+// when that quota starts over; Limit is zero when the key is unlimited.
+type APIKeyQuota struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// APIKeyUsageReport sums a key's requests over the last days, listed
+// oldest first with the days without requests left out.
+type APIKeyUsageReport struct {
+	APIKeyID     uint          `json:"api_key_id"`
+	DailyQuota   int           `json:"daily_quota"`
+	MonthlyQuota int           `json:"monthly_quota"`
+	Today        int           `json:"today"`
+	ThisMonth    int           `json:"this_month"`
+	Total        int           `json:"total"`
+	Days         []APIKeyUsage `json:"days"`
+}
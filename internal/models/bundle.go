@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Bundle is a combo pack of several cupcakes sold together for PriceCents.
+type Bundle struct {
+	ID          uint         `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string       `json:"name" gorm:"not null;size:100;uniqueIndex"`
+	PriceCents  int          `json:"price_cents" gorm:"not null"`
+	IsAvailable bool         `json:"is_available"`
+	Items       []BundleItem `json:"items" gorm:"foreignKey:BundleID"`
+	CreatedAt   time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Bundle) TableName() string {
+	return "bundles"
+}
+
+type BundleItem struct {
+	ID        uint `json:"-" gorm:"primaryKey;autoIncrement"`
+	BundleID  uint `json:"-" gorm:"not null;index"`
+	CupcakeID uint `json:"cupcake_id" gorm:"not null"`
+	Quantity  int  `json:"quantity" gorm:"not null"`
+}
+
+func (BundleItem) TableName() string {
+	return "bundle_items"
+}
+
+type CreateBundleRequest struct {
+	Name       string       `json:"name" validate:"required"`
+	PriceCents int          `json:"price_cents" validate:"required,gt=0"`
+	Items      []PickupItem `json:"items" validate:"required,min=1"`
+}
+
+// UpdateBundleRequest replaces the bundle's contents when Items is present.
+type UpdateBundleRequest struct {
+	Name        *string      `json:"name,omitempty" validate:"omitempty"`
+	PriceCents  *int         `json:"price_cents,omitempty" validate:"omitempty,gt=0"`
+	IsAvailable *bool        `json:"is_available,omitempty"`
+	Items       []PickupItem `json:"items,omitempty" validate:"omitempty,min=1"`
+}
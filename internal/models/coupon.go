@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+const (
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixed      = "fixed"
+)
+
+type Coupon struct {
+	ID             uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code           string     `json:"code" gorm:"not null;size:50;uniqueIndex"`
+	DiscountType   string     `json:"discount_type" gorm:"not null;size:20"`
+	DiscountValue  int        `json:"discount_value" gorm:"not null"`
+	MinOrderCents  int        `json:"min_order_cents"`
+	MaxRedemptions int        `json:"max_redemptions"`
+	TimesRedeemed  int        `json:"times_redeemed"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	IsActive       bool       `json:"is_active"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Coupon) TableName() string {
+	return "coupons"
+}
+
+type CouponRedemption struct {
+	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CouponID      uint      `json:"coupon_id" gorm:"not null;index"`
+	OrderCents    int       `json:"order_cents" gorm:"not null"`
+	DiscountCents int       `json:"discount_cents" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (CouponRedemption) TableName() string {
+	return "coupon_redemptions"
+}
+
+type CreateCouponRequest struct {
+	Code           string     `json:"code" validate:"required"`
+	DiscountType   string     `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue  int        `json:"discount_value" validate:"required,gt=0"`
+	MinOrderCents  int        `json:"min_order_cents" validate:"gte=0"`
+	MaxRedemptions int        `json:"max_redemptions" validate:"gte=0"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+type UpdateCouponRequest struct {
+	DiscountType   *string    `json:"discount_type,omitempty" validate:"omitempty,oneof=percentage fixed"`
+	DiscountValue  *int       `json:"discount_value,omitempty" validate:"omitempty,gt=0"`
+	MinOrderCents  *int       `json:"min_order_cents,omitempty" validate:"omitempty,gte=0"`
+	MaxRedemptions *int       `json:"max_redemptions,omitempty" validate:"omitempty,gte=0"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	IsActive       *bool      `json:"is_active,omitempty"`
+}
+
+type ApplyCouponRequest struct {
+	Code       string `json:"code" validate:"required"`
+	OrderCents int    `json:"order_cents" validate:"required,gt=0"`
+}
@@ -1,30 +1,113 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Cupcake struct {
-	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name        string    `json:"name" gorm:"not null;size:100"`
-	Flavor      string    `json:"flavor" gorm:"not null;size:100"`
-	PriceCents  int       `json:"price_cents" gorm:"not null"`
-	IsAvailable bool      `json:"is_available"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID            uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name          string     `json:"name" gorm:"not null;size:100"`
+	Flavor        string     `json:"flavor" gorm:"not null;size:100"`
+	SKU           *string    `json:"sku,omitempty" gorm:"size:64;uniqueIndex"`
+	Slug          *string    `json:"slug,omitempty" gorm:"size:100;uniqueIndex"`
+	Description   string     `json:"description,omitempty" gorm:"type:text"`
+	DisplayOrder  int        `json:"display_order" gorm:"not null;default:0;index"`
+	PriceCents    int        `json:"price_cents" gorm:"not null"`
+	IsAvailable   bool       `json:"is_available"`
+	AvailableFrom *time.Time `json:"available_from,omitempty"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	// CreatedBy and UpdatedBy are the admins who created the cupcake and
+	// last changed it, nil when that was done with the static admin token.
+	// They are only shown to admins, through AdminCupcakeResponse.
+	CreatedBy *uint `json:"-" gorm:"index"`
+	UpdatedBy *uint `json:"-" gorm:"index"`
+
+	EffectivePriceCents *int   `json:"effective_price_cents,omitempty" gorm:"-"`
+	Currency            string `json:"currency,omitempty" gorm:"-"`
+	PreOrder            bool   `json:"pre_order,omitempty" gorm:"-"`
+	Locale              string `json:"locale,omitempty" gorm:"-"`
 }
 
 func (Cupcake) TableName() string {
 	return "cupcakes"
 }
 
+// IsPreOrder reports whether the cupcake is still before its AvailableFrom
+// release date. Pre-order cupcakes can be ordered ahead but are left out of
+// "available now" listings until then.
+func (c *Cupcake) IsPreOrder(at time.Time) bool {
+	return c.AvailableFrom != nil && at.Before(*c.AvailableFrom)
+}
+
 type CreateCupcakeRequest struct {
-	Name       string `json:"name" validate:"required,min=2"`
-	Flavor     string `json:"flavor" validate:"required"`
-	PriceCents int    `json:"price_cents" validate:"required,gt=0"`
+	Name          string     `json:"name" validate:"required,min=2"`
+	Flavor        string     `json:"flavor" validate:"required"`
+	PriceCents    int        `json:"price_cents" validate:"required,gt=0"`
+	SKU           *string    `json:"sku,omitempty" validate:"omitempty,min=3,max=64"`
+	Slug          *string    `json:"slug,omitempty" validate:"omitempty,max=100"`
+	Description   string     `json:"description,omitempty"`
+	DisplayOrder  int        `json:"display_order"`
+	AvailableFrom *time.Time `json:"available_from,omitempty"`
+	// Force skips the check for existing cupcakes with a similar name.
+	Force bool `json:"-"`
+	// CreatedBy is the admin creating the cupcake, set by the handler.
+	CreatedBy *uint `json:"-"`
 }
 
 type UpdateCupcakeRequest struct {
-	Name        *string `json:"name,omitempty" validate:"omitempty,min=2"`
-	Flavor      *string `json:"flavor,omitempty" validate:"omitempty"`
-	PriceCents  *int    `json:"price_cents,omitempty" validate:"omitempty,gt=0"`
-	IsAvailable *bool   `json:"is_available,omitempty"`
+	Name          *string    `json:"name,omitempty" validate:"omitempty,min=2"`
+	Flavor        *string    `json:"flavor,omitempty" validate:"omitempty"`
+	PriceCents    *int       `json:"price_cents,omitempty" validate:"omitempty,gt=0"`
+	IsAvailable   *bool      `json:"is_available,omitempty"`
+	SKU           *string    `json:"sku,omitempty" validate:"omitempty,max=64"`
+	Slug          *string    `json:"slug,omitempty" validate:"omitempty,max=100"`
+	Description   *string    `json:"description,omitempty"`
+	DisplayOrder  *int       `json:"display_order,omitempty"`
+	AvailableFrom *time.Time `json:"available_from,omitempty"`
+	// UpdatedBy is the admin making the change, set by the handler.
+	UpdatedBy *uint `json:"-"`
+}
+
+const (
+	PatchAdd     = "add"
+	PatchReplace = "replace"
+	PatchRemove  = "remove"
+)
+
+// PatchOperation is one step of a JSON Patch (RFC 6902) document. Only
+// add, replace and remove are supported, on the top-level fields of
+// UpdateCupcakeRequest.
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+const (
+	PriceAdjustmentPercentage = "percentage"
+	PriceAdjustmentAbsolute   = "absolute"
+)
+
+type BulkPriceUpdateRequest struct {
+	Flavor         string `json:"flavor,omitempty"`
+	AdjustmentType string `json:"adjustment_type" validate:"required,oneof=percentage absolute"`
+	Value          int    `json:"value" validate:"required"`
+	DryRun         bool   `json:"dry_run"`
+	// UpdatedBy is the admin changing the prices, set by the handler.
+	UpdatedBy *uint `json:"-"`
+}
+
+type PriceChange struct {
+	CupcakeID     uint   `json:"cupcake_id"`
+	Name          string `json:"name"`
+	OldPriceCents int    `json:"old_price_cents"`
+	NewPriceCents int    `json:"new_price_cents"`
+}
+
+type BulkPriceUpdateResponse struct {
+	DryRun   bool          `json:"dry_run"`
+	Affected int           `json:"affected"`
+	Changes  []PriceChange `json:"changes"`
 }
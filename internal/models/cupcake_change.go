@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// CupcakeChange is one entry of the catalog change log, written with every
+// cupcake create, update and delete. Its ID is the sync cursor: a client
+// that has applied the changes up to ID n asks for the ones after n.
+type CupcakeChange struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CupcakeID uint      `json:"cupcake_id" gorm:"not null;index"`
+	Deleted   bool      `json:"deleted"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (CupcakeChange) TableName() string {
+	return "cupcake_changes"
+}
+
+// CupcakeSync is a page of catalog changes for an offline client: the
+// current state of every cupcake created or updated since the requested
+// cursor and tombstones (IDs) for the ones deleted. Cursor is what to pass
+// as since on the next call.
+type CupcakeSync struct {
+	Cursor   uint      `json:"cursor"`
+	HasMore  bool      `json:"has_more"`
+	Upserted []Cupcake `json:"upserted"`
+	Deleted  []uint    `json:"deleted"`
+}
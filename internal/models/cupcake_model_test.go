@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -390,6 +391,66 @@ func TestCreateCupcakeRequest_Fields(t *testing.T) {
 	}
 }
 
+func TestNewCupcakeResponse(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		cupcake      Cupcake
+		expectedKeys []string
+	}{
+		{
+			name: "maps every public field",
+			cupcake: Cupcake{
+				ID:                  1,
+				Name:                "Red Velvet",
+				Flavor:              "Red Velvet",
+				SKU:                 stringPtr("RV-001"),
+				Slug:                stringPtr("red-velvet"),
+				Description:         "<p>Classic</p>",
+				DisplayOrder:        3,
+				PriceCents:          1200,
+				IsAvailable:         true,
+				CreatedAt:           now,
+				UpdatedAt:           now,
+				EffectivePriceCents: intPtr(1000),
+				Currency:            "BRL",
+			},
+			expectedKeys: []string{
+				"id", "name", "flavor", "sku", "slug", "description", "display_order", "price_cents",
+				"is_available", "created_at", "updated_at", "effective_price_cents", "currency",
+			},
+		},
+		{
+			name:    "omits optional fields",
+			cupcake: Cupcake{ID: 2, Name: "Vanilla", Flavor: "Vanilla", PriceCents: 800},
+			expectedKeys: []string{
+				"id", "name", "flavor", "display_order", "price_cents", "is_available", "created_at", "updated_at",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := NewCupcakeResponse(&tt.cupcake)
+			require.Equal(t, tt.cupcake.ID, response.ID)
+			require.Equal(t, tt.cupcake.Name, response.Name)
+			require.Equal(t, tt.cupcake.SKU, response.SKU)
+			require.Equal(t, tt.cupcake.PriceCents, response.PriceCents)
+			require.Equal(t, tt.cupcake.EffectivePriceCents, response.EffectivePriceCents)
+
+			data, err := json.Marshal(response)
+			require.NoError(t, err)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &body))
+			keys := make([]string, 0, len(body))
+			for key := range body {
+				keys = append(keys, key)
+			}
+			require.ElementsMatch(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -401,3 +462,26 @@ func intPtr(i int) *int {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestCupcake_IsPreOrder(t *testing.T) {
+	release := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		availableFrom *time.Time
+		at            time.Time
+		expected      bool
+	}{
+		{name: "no release date", at: release, expected: false},
+		{name: "before release", availableFrom: &release, at: release.Add(-time.Second), expected: true},
+		{name: "on release", availableFrom: &release, at: release, expected: false},
+		{name: "after release", availableFrom: &release, at: release.AddDate(0, 0, 1), expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cupcake := Cupcake{AvailableFrom: tt.availableFrom}
+			require.Equal(t, tt.expected, cupcake.IsPreOrder(tt.at))
+		})
+	}
+}
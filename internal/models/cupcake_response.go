@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// CupcakeResponse is the public representation of a cupcake. Handlers
+// serialize this instead of Cupcake so columns added to the table stay
+// internal until they are mapped here on purpose.
+type CupcakeResponse struct {
+	XMLName             xml.Name   `json:"-" xml:"cupcake"`
+	ID                  uint       `json:"id" xml:"id"`
+	Name                string     `json:"name" xml:"name"`
+	Flavor              string     `json:"flavor" xml:"flavor"`
+	SKU                 *string    `json:"sku,omitempty" xml:"sku,omitempty"`
+	Slug                *string    `json:"slug,omitempty" xml:"slug,omitempty"`
+	Description         string     `json:"description,omitempty" xml:"description,omitempty"`
+	DisplayOrder        int        `json:"display_order" xml:"display_order"`
+	PriceCents          int        `json:"price_cents" xml:"price_cents"`
+	IsAvailable         bool       `json:"is_available" xml:"is_available"`
+	AvailableFrom       *time.Time `json:"available_from,omitempty" xml:"available_from,omitempty"`
+	PreOrder            bool       `json:"pre_order,omitempty" xml:"pre_order,omitempty"`
+	CreatedAt           time.Time  `json:"created_at" xml:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" xml:"updated_at"`
+	EffectivePriceCents *int       `json:"effective_price_cents,omitempty" xml:"effective_price_cents,omitempty"`
+	Currency            string     `json:"currency,omitempty" xml:"currency,omitempty"`
+	Locale              string     `json:"locale,omitempty" xml:"locale,omitempty"`
+}
+
+func NewCupcakeResponse(c *Cupcake) CupcakeResponse {
+	return CupcakeResponse{
+		ID:                  c.ID,
+		Name:                c.Name,
+		Flavor:              c.Flavor,
+		SKU:                 c.SKU,
+		Slug:                c.Slug,
+		Description:         c.Description,
+		DisplayOrder:        c.DisplayOrder,
+		PriceCents:          c.PriceCents,
+		IsAvailable:         c.IsAvailable,
+		AvailableFrom:       c.AvailableFrom,
+		PreOrder:            c.PreOrder,
+		CreatedAt:           c.CreatedAt,
+		UpdatedAt:           c.UpdatedAt,
+		EffectivePriceCents: c.EffectivePriceCents,
+		Currency:            c.Currency,
+		Locale:              c.Locale,
+	}
+}
+
+// AdminCupcakeResponse is a cupcake as the admin API returns it: the
+// public fields plus the admins who created it and last changed it.
+type AdminCupcakeResponse struct {
+	CupcakeResponse
+	CreatedBy *uint `json:"created_by,omitempty"`
+	UpdatedBy *uint `json:"updated_by,omitempty"`
+}
+
+func NewAdminCupcakeResponse(c *Cupcake) AdminCupcakeResponse {
+	return AdminCupcakeResponse{
+		CupcakeResponse: NewCupcakeResponse(c),
+		CreatedBy:       c.CreatedBy,
+		UpdatedBy:       c.UpdatedBy,
+	}
+}
+
+func NewCupcakeResponses(cupcakes []Cupcake) []CupcakeResponse {
+	responses := make([]CupcakeResponse, len(cupcakes))
+	for i := range cupcakes {
+		responses[i] = NewCupcakeResponse(&cupcakes[i])
+	}
+	return responses
+}
+
+// DuplicateCupcakeResponse is the 409 body returned when a new cupcake's name
+// is close to existing ones.
+type DuplicateCupcakeResponse struct {
+	Error      string            `json:"error"`
+	Duplicates []CupcakeResponse `json:"duplicates"`
+}
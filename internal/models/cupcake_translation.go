@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// CupcakeTranslation holds a cupcake's name, flavor and description in one
+// locale other than the catalog's default, which lives on the cupcake
+// itself.
+type CupcakeTranslation struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CupcakeID   uint      `json:"cupcake_id" gorm:"not null;uniqueIndex:idx_cupcake_translation"`
+	Locale      string    `json:"locale" gorm:"not null;size:35;uniqueIndex:idx_cupcake_translation"`
+	Name        string    `json:"name" gorm:"not null;size:100"`
+	Flavor      string    `json:"flavor" gorm:"not null;size:100"`
+	Description string    `json:"description,omitempty" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (CupcakeTranslation) TableName() string {
+	return "cupcake_translations"
+}
+
+type SetTranslationRequest struct {
+	Name        string `json:"name" validate:"required,min=2"`
+	Flavor      string `json:"flavor" validate:"required"`
+	Description string `json:"description"`
+}
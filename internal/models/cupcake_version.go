@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// CupcakeVersion is a snapshot of a cupcake's editable fields, written every
+// time the cupcake is created or changed.
+type CupcakeVersion struct {
+	ID            uint       `json:"-" gorm:"primaryKey;autoIncrement"`
+	CupcakeID     uint       `json:"cupcake_id" gorm:"not null;uniqueIndex:idx_cupcake_version"`
+	Version       int        `json:"version" gorm:"not null;uniqueIndex:idx_cupcake_version"`
+	Name          string     `json:"name" gorm:"not null;size:100"`
+	Flavor        string     `json:"flavor" gorm:"not null;size:100"`
+	SKU           *string    `json:"sku,omitempty" gorm:"size:64"`
+	Slug          *string    `json:"slug,omitempty" gorm:"size:100"`
+	Description   string     `json:"description,omitempty" gorm:"type:text"`
+	DisplayOrder  int        `json:"display_order"`
+	PriceCents    int        `json:"price_cents" gorm:"not null"`
+	IsAvailable   bool       `json:"is_available"`
+	AvailableFrom *time.Time `json:"available_from,omitempty"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (CupcakeVersion) TableName() string {
+	return "cupcake_versions"
+}
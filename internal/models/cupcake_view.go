@@ -0,0 +1,31 @@
+package models
+
+// CupcakeViewCount is the number of times a cupcake's page was viewed on
+// one day (YYYY-MM-DD, local time). Daily buckets keep the table small and
+// let trending windows be summed with a single query.
+type CupcakeViewCount struct {
+	CupcakeID uint   `json:"cupcake_id" gorm:"primaryKey;autoIncrement:false"`
+	Day       string `json:"day" gorm:"primaryKey;size:10"`
+	Count     int    `json:"count" gorm:"not null"`
+}
+
+func (CupcakeViewCount) TableName() string {
+	return "cupcake_view_counts"
+}
+
+// CupcakeViewTotal is the number of views of a cupcake over a window.
+type CupcakeViewTotal struct {
+	CupcakeID uint
+	Views     int
+}
+
+// TrendingCupcake is a cupcake with its views over the requested window.
+type TrendingCupcake struct {
+	Cupcake Cupcake
+	Views   int
+}
+
+type TrendingCupcakeResponse struct {
+	CupcakeResponse
+	Views int `json:"views"`
+}
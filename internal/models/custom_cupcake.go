@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+const (
+	CustomOptionBase     = "base"
+	CustomOptionFrosting = "frosting"
+	CustomOptionTopping  = "topping"
+)
+
+// CustomOption is one ingredient customers can pick when building their own
+// cupcake. Its price is added to the quote when chosen.
+type CustomOption struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Kind        string    `json:"kind" gorm:"not null;size:20;index"`
+	Name        string    `json:"name" gorm:"not null;size:100"`
+	PriceCents  int       `json:"price_cents" gorm:"not null"`
+	IsAvailable bool      `json:"is_available"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (CustomOption) TableName() string {
+	return "custom_options"
+}
+
+type CreateCustomOptionRequest struct {
+	Kind       string `json:"kind" validate:"required,oneof=base frosting topping"`
+	Name       string `json:"name" validate:"required"`
+	PriceCents int    `json:"price_cents" validate:"gte=0"`
+}
+
+type UpdateCustomOptionRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty"`
+	PriceCents  *int    `json:"price_cents,omitempty" validate:"omitempty,gte=0"`
+	IsAvailable *bool   `json:"is_available,omitempty"`
+}
+
+type CustomCupcakeQuoteRequest struct {
+	BaseID     uint   `json:"base_id" validate:"required"`
+	FrostingID uint   `json:"frosting_id" validate:"required"`
+	ToppingIDs []uint `json:"topping_ids" validate:"max=3,unique"`
+}
+
+type CustomCupcakeQuote struct {
+	Base       CustomOption   `json:"base"`
+	Frosting   CustomOption   `json:"frosting"`
+	Toppings   []CustomOption `json:"toppings"`
+	PriceCents int            `json:"price_cents"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+)
+
+// DataExport is a customer's request for a copy of their data. The archive
+// is built by a background job and kept until ExpiresAt. The email and
+// archive are encrypted at rest; lookups go by CustomerEmailHash.
+type DataExport struct {
+	ID                uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	CustomerEmail     string     `json:"customer_email" gorm:"not null;size:512;serializer:pii"`
+	CustomerEmailHash string     `json:"-" gorm:"size:64;index"`
+	Status            string     `json:"status" gorm:"not null;size:20"`
+	Archive           []byte     `json:"-" gorm:"serializer:pii"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty" gorm:"index"`
+}
+
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+func (e *DataExport) BeforeSave(*gorm.DB) error {
+	e.CustomerEmailHash = pii.Hash(e.CustomerEmail)
+	return nil
+}
+
+// CustomerData is everything the store keeps about one customer email.
+// Ordering does not require an account, so the profile is assembled from
+// the names given with the account, subscriptions, pickups and wholesale
+// accounts. Tickets about the customer's orders are included too.
+type CustomerData struct {
+	Profile                 CustomerProfile          `json:"profile"`
+	Account                 *Account                 `json:"account,omitempty"`
+	AccountIdentities       []AccountIdentity        `json:"account_identities,omitempty"`
+	NotificationPreferences []NotificationPreference `json:"notification_preferences,omitempty"`
+	DeviceTokens            []DeviceToken            `json:"device_tokens,omitempty"`
+	Subscriptions           []Subscription           `json:"subscriptions"`
+	PickupReservations      []PickupReservation      `json:"pickup_reservations"`
+	Tickets                 []Ticket                 `json:"tickets"`
+	WholesaleAccount        *WholesaleAccount        `json:"wholesale_account,omitempty"`
+	ExportedAt              time.Time                `json:"exported_at"`
+}
+
+type CustomerProfile struct {
+	Email string   `json:"email"`
+	Names []string `json:"names"`
+}
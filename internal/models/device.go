@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Platforms a device token can come from.
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+	PlatformWeb     = "web"
+)
+
+// DeviceToken is a push token of one of an account's devices, as issued
+// by Firebase Cloud Messaging. A token belongs to the account that
+// registered it last.
+type DeviceToken struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	AccountID uint      `json:"-" gorm:"not null;index"`
+	Token     string    `json:"token" gorm:"not null;size:512;uniqueIndex"`
+	Platform  string    `json:"platform" gorm:"not null;size:20"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (DeviceToken) TableName() string {
+	return "device_tokens"
+}
+
+type RegisterDeviceRequest struct {
+	Token    string `json:"token" validate:"required,max=512"`
+	Platform string `json:"platform" validate:"required,oneof=android ios web"`
+}
+
+// PushMessage is a push notification. Data is passed to the app as is,
+// for it to open the right screen.
+type PushMessage struct {
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+}
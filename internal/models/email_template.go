@@ -0,0 +1,108 @@
+package models
+
+import "time"
+
+// Transactional emails with a built-in template.
+const (
+	EmailOrderConfirmation = "order_confirmation"
+	EmailPasswordReset     = "password_reset"
+	EmailWaitlist          = "waitlist"
+)
+
+// Where an effective email template comes from.
+const (
+	EmailTemplateDefault  = "default"
+	EmailTemplateStore    = "store"
+	EmailTemplateLocation = "location"
+)
+
+// EmailTemplate is an admin's override of a built-in email, for every
+// store (LocationID 0) or for one location. Subject is a text/template and
+// Body an html/template, both run against the email's data.
+type EmailTemplate struct {
+	ID         uint      `json:"-" gorm:"primaryKey;autoIncrement"`
+	Name       string    `json:"name" gorm:"not null;size:50;uniqueIndex:idx_email_template"`
+	LocationID uint      `json:"location_id" gorm:"not null;default:0;uniqueIndex:idx_email_template"`
+	Subject    string    `json:"subject" gorm:"not null;size:200"`
+	Body       string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (EmailTemplate) TableName() string {
+	return "email_templates"
+}
+
+// EmailTemplateResponse is the template an email is sent with, and
+// whether that is the built-in one or an override. UpdatedAt is nil for
+// the built-in.
+type EmailTemplateResponse struct {
+	Name       string     `json:"name"`
+	LocationID uint       `json:"location_id,omitempty"`
+	Subject    string     `json:"subject"`
+	Body       string     `json:"body"`
+	Source     string     `json:"source"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateEmailTemplateRequest overrides a template for every store, or for
+// the location given.
+type UpdateEmailTemplateRequest struct {
+	LocationID uint   `json:"location_id,omitempty"`
+	Subject    string `json:"subject" validate:"required,max=200"`
+	Body       string `json:"body" validate:"required"`
+}
+
+// PreviewEmailTemplateRequest renders a template with sample data. A
+// Subject or Body given is rendered in place of the saved one, so copy
+// can be tried out before saving it.
+type PreviewEmailTemplateRequest struct {
+	LocationID uint    `json:"location_id,omitempty"`
+	Subject    *string `json:"subject,omitempty"`
+	Body       *string `json:"body,omitempty"`
+}
+
+// RenderedEmail is an email ready to send.
+type RenderedEmail struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+}
+
+// EmailStore is the store an email is sent on behalf of.
+type EmailStore struct {
+	Name    string
+	Address string
+	Hours   string
+}
+
+// EmailOrderItem is one line of an order confirmation.
+type EmailOrderItem struct {
+	Name     string
+	Quantity int
+}
+
+// OrderConfirmationEmail is the data of the order_confirmation template.
+type OrderConfirmationEmail struct {
+	Store        EmailStore
+	CustomerName string
+	OrderID      uint
+	PickupAt     time.Time
+	Items        []EmailOrderItem
+}
+
+// PasswordResetEmail is the data of the password_reset template.
+type PasswordResetEmail struct {
+	Store        EmailStore
+	CustomerName string
+	ResetURL     string
+	ExpiresAt    time.Time
+}
+
+// WaitlistEmail is the data of the waitlist template, sent when a cupcake
+// a customer waited for is back.
+type WaitlistEmail struct {
+	Store        EmailStore
+	CustomerName string
+	CupcakeName  string
+	CupcakeURL   string
+}
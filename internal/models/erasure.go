@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	ErasureByCustomer = "customer"
+	ErasureByAdmin    = "admin"
+)
+
+// Erasure records that the personal data stored under one email was
+// erased. Only pii.Hash of the email is kept, enough to answer whether an
+// address was erased without storing it again; being keyed, it cannot be
+// reversed by hashing guessed addresses.
+type Erasure struct {
+	ID                 uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	EmailHash          string    `json:"email_hash" gorm:"not null;size:64;index"`
+	RequestedBy        string    `json:"requested_by" gorm:"not null;size:20"`
+	Subscriptions      int64     `json:"subscriptions"`
+	PickupReservations int64     `json:"pickup_reservations"`
+	Tickets            int64     `json:"tickets"`
+	WholesaleAccounts  int64     `json:"wholesale_accounts"`
+	DataExports        int64     `json:"data_exports"`
+	Accounts           int64     `json:"accounts"`
+	CreatedAt          time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (Erasure) TableName() string {
+	return "erasures"
+}
@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+// Experiment splits visitors between variants. Price experiments adjust
+// the displayed effective price of CupcakeID, or of every cupcake when it
+// is nil, by the variant's PriceAdjustmentPercent.
+type Experiment struct {
+	ID          uint                `json:"id" gorm:"primaryKey;autoIncrement"`
+	Key         string              `json:"key" gorm:"not null;size:64;uniqueIndex"`
+	Description string              `json:"description,omitempty" gorm:"size:255"`
+	CupcakeID   *uint               `json:"cupcake_id,omitempty" gorm:"index"`
+	IsActive    bool                `json:"is_active"`
+	Variants    []ExperimentVariant `json:"variants" gorm:"foreignKey:ExperimentID"`
+	StoppedAt   *time.Time          `json:"stopped_at,omitempty"`
+	CreatedAt   time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time           `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Experiment) TableName() string {
+	return "experiments"
+}
+
+// ExperimentVariant gets Weight out of the experiment's total weight of the
+// visitors.
+type ExperimentVariant struct {
+	ID                     uint   `json:"-" gorm:"primaryKey;autoIncrement"`
+	ExperimentID           uint   `json:"-" gorm:"not null;index"`
+	Name                   string `json:"name" gorm:"not null;size:50"`
+	Weight                 int    `json:"weight" gorm:"not null"`
+	PriceAdjustmentPercent int    `json:"price_adjustment_percent"`
+}
+
+func (ExperimentVariant) TableName() string {
+	return "experiment_variants"
+}
+
+// ExperimentExposure records the first time a visitor was shown a variant.
+type ExperimentExposure struct {
+	ID           uint      `json:"-" gorm:"primaryKey;autoIncrement"`
+	ExperimentID uint      `json:"experiment_id" gorm:"not null;uniqueIndex:idx_experiment_exposure"`
+	VisitorID    string    `json:"visitor_id" gorm:"not null;size:64;uniqueIndex:idx_experiment_exposure"`
+	Variant      string    `json:"variant" gorm:"not null;size:50"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ExperimentExposure) TableName() string {
+	return "experiment_exposures"
+}
+
+type ExperimentConversion struct {
+	ID           uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	ExperimentID uint      `json:"experiment_id" gorm:"not null;index"`
+	VisitorID    string    `json:"visitor_id" gorm:"not null;size:64"`
+	Variant      string    `json:"variant" gorm:"not null;size:50"`
+	ValueCents   int       `json:"value_cents"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ExperimentConversion) TableName() string {
+	return "experiment_conversions"
+}
+
+type CreateExperimentRequest struct {
+	Key         string                     `json:"key" validate:"required"`
+	Description string                     `json:"description,omitempty"`
+	CupcakeID   *uint                      `json:"cupcake_id,omitempty"`
+	Variants    []ExperimentVariantRequest `json:"variants" validate:"required,min=2"`
+}
+
+type ExperimentVariantRequest struct {
+	Name                   string `json:"name" validate:"required"`
+	Weight                 int    `json:"weight" validate:"required,gt=0"`
+	PriceAdjustmentPercent int    `json:"price_adjustment_percent"`
+}
+
+// ExperimentAssignment is the variant a visitor sees in one experiment.
+type ExperimentAssignment struct {
+	Experiment string `json:"experiment"`
+	Variant    string `json:"variant"`
+}
+
+type ConversionRequest struct {
+	ValueCents int `json:"value_cents" validate:"gte=0"`
+}
+
+// VariantResults sums up one variant: distinct visitors exposed and
+// converted, and the value of their conversions.
+type VariantResults struct {
+	Variant        string  `json:"variant"`
+	Exposures      int64   `json:"exposures"`
+	Conversions    int64   `json:"conversions"`
+	ConversionRate float64 `json:"conversion_rate"`
+	RevenueCents   int64   `json:"revenue_cents"`
+}
+
+type ExperimentResults struct {
+	Experiment
+	Results []VariantResults `json:"results"`
+}
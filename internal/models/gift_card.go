@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type GiftCard struct {
+	ID           uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Code         string     `json:"code" gorm:"not null;size:19;uniqueIndex"`
+	InitialCents int        `json:"initial_cents" gorm:"not null"`
+	BalanceCents int        `json:"balance_cents" gorm:"not null"`
+	VoidedAt     *time.Time `json:"voided_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (GiftCard) TableName() string {
+	return "gift_cards"
+}
+
+type GiftCardRedemption struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	GiftCardID  uint      `json:"gift_card_id" gorm:"not null;index"`
+	AmountCents int       `json:"amount_cents" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (GiftCardRedemption) TableName() string {
+	return "gift_card_redemptions"
+}
+
+type IssueGiftCardRequest struct {
+	AmountCents int `json:"amount_cents" validate:"required,gt=0"`
+}
+
+type RedeemGiftCardRequest struct {
+	Code        string `json:"code" validate:"required"`
+	AmountCents int    `json:"amount_cents" validate:"required,gt=0"`
+}
+
+type GiftCardBalanceResponse struct {
+	Code         string `json:"code"`
+	BalanceCents int    `json:"balance_cents"`
+	IsVoided     bool   `json:"is_voided"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+	JobDead      = "dead"
+)
+
+// Job is a unit of background work. A worker holds a running job until
+// LockedUntil; once that passes, the worker is taken to have stopped and
+// another one claims the job again.
+type Job struct {
+	ID          uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Type        string     `json:"type" gorm:"not null;size:100;index"`
+	Payload     string     `json:"payload" gorm:"type:text"`
+	Status      string     `json:"status" gorm:"not null;size:20;index"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"max_attempts" gorm:"not null"`
+	RunAt       time.Time  `json:"run_at" gorm:"not null;index"`
+	LastError   string     `json:"last_error,omitempty" gorm:"size:1000"`
+	LockedUntil *time.Time `json:"locked_until,omitempty" gorm:"index"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Job) TableName() string {
+	return "jobs"
+}
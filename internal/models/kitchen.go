@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ProductionPlan is the kitchen's batch sheet for one day: how many of
+// each cupcake to bake for the subscriptions delivering and the pickups
+// booked that day.
+type ProductionPlan struct {
+	Date          string               `json:"date"`
+	Lines         []ProductionPlanLine `json:"lines"`
+	TotalQuantity int                  `json:"total_quantity"`
+}
+
+type ProductionPlanLine struct {
+	CupcakeID            uint   `json:"cupcake_id"`
+	Name                 string `json:"name"`
+	SubscriptionQuantity int    `json:"subscription_quantity"`
+	PickupQuantity       int    `json:"pickup_quantity"`
+	Quantity             int    `json:"quantity"`
+}
+
+// Statuses of an order in the kitchen queue.
+const (
+	KitchenQueueOpen       = "open"
+	KitchenQueueInProgress = "in_progress"
+)
+
+// KitchenQueueEntry is a pickup order the kitchen still has to make. It is
+// open until the kitchen starts it, then in progress until it is ready.
+type KitchenQueueEntry struct {
+	ReservationID uint               `json:"reservation_id"`
+	LocationID    uint               `json:"location_id"`
+	SlotStartsAt  time.Time          `json:"slot_starts_at"`
+	SlotEndsAt    time.Time          `json:"slot_ends_at"`
+	CustomerName  string             `json:"customer_name"`
+	Status        string             `json:"status"`
+	StartedAt     *time.Time         `json:"started_at,omitempty"`
+	Items         []KitchenQueueItem `json:"items"`
+}
+
+type KitchenQueueItem struct {
+	CupcakeID uint   `json:"cupcake_id"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Location is a physical store where customers can pick up cupcakes.
+type Location struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string    `json:"name" gorm:"not null;size:100"`
+	Address   string    `json:"address" gorm:"not null;size:255"`
+	Hours     string    `json:"hours" gorm:"size:255"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Location) TableName() string {
+	return "locations"
+}
+
+// LocationStock is how many units of a cupcake a location has on hand. A
+// cupcake is available at a location while it is available in the catalog
+// and its quantity there is positive. When the quantity falls to
+// ReorderThreshold a stock alert is raised; zero means never.
+type LocationStock struct {
+	ID               uint      `json:"-" gorm:"primaryKey;autoIncrement"`
+	LocationID       uint      `json:"location_id" gorm:"not null;uniqueIndex:idx_location_stock"`
+	CupcakeID        uint      `json:"cupcake_id" gorm:"not null;uniqueIndex:idx_location_stock"`
+	Quantity         int       `json:"quantity" gorm:"not null;default:0"`
+	ReorderThreshold int       `json:"reorder_threshold" gorm:"not null;default:0"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (LocationStock) TableName() string {
+	return "location_stock"
+}
+
+type CreateLocationRequest struct {
+	Name    string `json:"name" validate:"required"`
+	Address string `json:"address" validate:"required"`
+	Hours   string `json:"hours"`
+}
+
+type UpdateLocationRequest struct {
+	Name    *string `json:"name,omitempty"`
+	Address *string `json:"address,omitempty"`
+	Hours   *string `json:"hours,omitempty"`
+}
+
+// SetStockRequest sets the quantity on hand. The reorder threshold is kept
+// when left out.
+type SetStockRequest struct {
+	Quantity         *int `json:"quantity" validate:"required,gte=0"`
+	ReorderThreshold *int `json:"reorder_threshold,omitempty" validate:"omitempty,gte=0"`
+}
+
+// PickupCheckRequest lists what a customer wants to collect at a location.
+// Bundles are expanded into the cupcakes they contain.
+type PickupCheckRequest struct {
+	Items   []PickupItem   `json:"items" validate:"required_without=Bundles"`
+	Bundles []PickupBundle `json:"bundles,omitempty" validate:"required_without=Items"`
+}
+
+type PickupItem struct {
+	CupcakeID uint `json:"cupcake_id" validate:"required"`
+	Quantity  int  `json:"quantity" validate:"required,gt=0"`
+}
+
+type PickupBundle struct {
+	BundleID uint `json:"bundle_id" validate:"required"`
+	Quantity int  `json:"quantity" validate:"required,gt=0"`
+}
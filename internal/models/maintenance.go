@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type MaintenanceStatus struct {
+	Enabled           bool       `json:"enabled"`
+	RetryAfterSeconds int        `json:"retry_after_seconds"`
+	Since             *time.Time `json:"since,omitempty"`
+	ReadOnly          bool       `json:"read_only"`
+}
+
+type MaintenanceRequest struct {
+	Enabled           *bool `json:"enabled" validate:"required"`
+	RetryAfterSeconds *int  `json:"retry_after_seconds,omitempty" validate:"omitempty,gt=0"`
+}
+
+type ReadOnlyRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Channels a notification can be delivered on.
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelPush  = "push"
+)
+
+// Notification is an event payload meant for one customer, which is
+// delivered on the channels they chose for the event.
+type Notification interface {
+	NotificationRecipient() string
+}
+
+// PhoneNotification is a Notification that can also go out by SMS.
+type PhoneNotification interface {
+	Notification
+	NotificationPhone() string
+}
+
+// NotificationPreference is the channels an account chose for one event.
+// Events without a row use their defaults.
+type NotificationPreference struct {
+	AccountID uint      `json:"-" gorm:"primaryKey"`
+	Event     string    `json:"event" gorm:"primaryKey;size:50"`
+	Email     bool      `json:"email" gorm:"not null"`
+	SMS       bool      `json:"sms" gorm:"column:sms;not null"`
+	Push      bool      `json:"push" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// NotificationPreferenceResponse is an event the customer can be notified
+// about and the channels used for it. UpdatedAt is nil while they are
+// still the defaults.
+type NotificationPreferenceResponse struct {
+	Event       string     `json:"event"`
+	Description string     `json:"description"`
+	Email       bool       `json:"email"`
+	SMS         bool       `json:"sms"`
+	Push        bool       `json:"push"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateNotificationPreferencesRequest changes the channels of the events
+// listed; channels left out keep their current setting.
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceUpdate `json:"preferences" validate:"required,dive"`
+}
+
+type NotificationPreferenceUpdate struct {
+	Event string `json:"event" validate:"required"`
+	Email *bool  `json:"email,omitempty"`
+	SMS   *bool  `json:"sms,omitempty"`
+	Push  *bool  `json:"push,omitempty"`
+}
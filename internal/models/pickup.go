@@ -0,0 +1,148 @@
+package models
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+// PickupSlot is a window in which a location hands over up to Capacity
+// pickup reservations.
+type PickupSlot struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	LocationID uint      `json:"location_id" gorm:"not null;index"`
+	StartsAt   time.Time `json:"starts_at" gorm:"not null;index"`
+	EndsAt     time.Time `json:"ends_at" gorm:"not null"`
+	Capacity   int       `json:"capacity" gorm:"not null"`
+	Booked     int       `json:"booked" gorm:"not null;default:0"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (PickupSlot) TableName() string {
+	return "pickup_slots"
+}
+
+// Reasons an admin gives for cancelling a pickup reservation.
+const (
+	CancellationCustomerRequest = "customer_request"
+	CancellationOutOfStock      = "out_of_stock"
+	CancellationStoreClosed     = "store_closed"
+	CancellationNoShow          = "no_show"
+	CancellationOther           = "other"
+)
+
+// PickupReservation holds a customer's order for a pickup slot. The name,
+// email and phone are encrypted at rest; lookups go by CustomerEmailHash.
+// StartedAt is set when the kitchen starts the order, ReadyAt when it marks
+// the order ready and CancelledAt when an admin cancels it.
+type PickupReservation struct {
+	ID                 uint                    `json:"id" gorm:"primaryKey;autoIncrement"`
+	SlotID             uint                    `json:"slot_id" gorm:"not null;index"`
+	CustomerName       string                  `json:"customer_name" gorm:"not null;size:255;serializer:pii"`
+	CustomerEmail      string                  `json:"customer_email" gorm:"not null;size:512;serializer:pii"`
+	CustomerEmailHash  string                  `json:"-" gorm:"size:64;index"`
+	CustomerPhone      string                  `json:"customer_phone,omitempty" gorm:"size:512;serializer:pii"`
+	Items              []PickupReservationItem `json:"items" gorm:"foreignKey:ReservationID"`
+	StartedAt          *time.Time              `json:"started_at,omitempty"`
+	ReadyAt            *time.Time              `json:"ready_at,omitempty"`
+	CancelledAt        *time.Time              `json:"cancelled_at,omitempty"`
+	CancellationReason string                  `json:"cancellation_reason,omitempty" gorm:"size:20"`
+	CreatedAt          time.Time               `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (PickupReservation) TableName() string {
+	return "pickup_reservations"
+}
+
+func (r *PickupReservation) BeforeSave(*gorm.DB) error {
+	r.CustomerEmailHash = pii.Hash(r.CustomerEmail)
+	return nil
+}
+
+type PickupReservationItem struct {
+	ID            uint `json:"-" gorm:"primaryKey;autoIncrement"`
+	ReservationID uint `json:"-" gorm:"not null;index"`
+	CupcakeID     uint `json:"cupcake_id" gorm:"not null"`
+	Quantity      int  `json:"quantity" gorm:"not null"`
+}
+
+func (PickupReservationItem) TableName() string {
+	return "pickup_reservation_items"
+}
+
+// PickupSchedule is one slot of the admin's daily pickup view.
+type PickupSchedule struct {
+	PickupSlot
+	Reservations []PickupReservation `json:"reservations"`
+}
+
+type CreatePickupSlotRequest struct {
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+	Capacity int       `json:"capacity" validate:"required,gt=0"`
+}
+
+// ReservePickupRequest books a pickup. CustomerPhone, in E.164 form, is
+// optional and only used for the SMS telling the customer the order is
+// ready.
+type ReservePickupRequest struct {
+	CustomerName  string         `json:"customer_name" validate:"required"`
+	CustomerEmail string         `json:"customer_email" validate:"required,email"`
+	CustomerPhone string         `json:"customer_phone,omitempty" validate:"omitempty,e164"`
+	Items         []PickupItem   `json:"items" validate:"required_without=Bundles"`
+	Bundles       []PickupBundle `json:"bundles,omitempty" validate:"required_without=Items"`
+}
+
+// CancelPickupRequest cancels a reservation for one of the Cancellation
+// reasons.
+type CancelPickupRequest struct {
+	Reason string `json:"reason" validate:"required,oneof=customer_request out_of_stock store_closed no_show other"`
+}
+
+// EventPickupReady tells the customer a pickup order is ready.
+const EventPickupReady = "pickup.ready"
+
+// EventPickupCancelled tells the customer a pickup order was cancelled.
+const EventPickupCancelled = "pickup.cancelled"
+
+// PickupReadyEvent is the payload of EventPickupReady.
+type PickupReadyEvent struct {
+	ReservationID uint      `json:"reservation_id"`
+	CustomerName  string    `json:"customer_name"`
+	CustomerEmail string    `json:"customer_email"`
+	CustomerPhone string    `json:"customer_phone,omitempty"`
+	LocationID    uint      `json:"location_id"`
+	LocationName  string    `json:"location_name"`
+	SlotStartsAt  time.Time `json:"slot_starts_at"`
+	SlotEndsAt    time.Time `json:"slot_ends_at"`
+}
+
+func (e PickupReadyEvent) NotificationRecipient() string {
+	return e.CustomerEmail
+}
+
+func (e PickupReadyEvent) NotificationPhone() string {
+	return e.CustomerPhone
+}
+
+// PickupCancelledEvent is the payload of EventPickupCancelled.
+type PickupCancelledEvent struct {
+	ReservationID uint      `json:"reservation_id"`
+	CustomerName  string    `json:"customer_name"`
+	CustomerEmail string    `json:"customer_email"`
+	CustomerPhone string    `json:"customer_phone,omitempty"`
+	LocationID    uint      `json:"location_id"`
+	LocationName  string    `json:"location_name"`
+	SlotStartsAt  time.Time `json:"slot_starts_at"`
+	SlotEndsAt    time.Time `json:"slot_ends_at"`
+	Reason        string    `json:"reason"`
+}
+
+func (e PickupCancelledEvent) NotificationRecipient() string {
+	return e.CustomerEmail
+}
+
+func (e PickupCancelledEvent) NotificationPhone() string {
+	return e.CustomerPhone
+}
@@ -0,0 +1,27 @@
+package models
+
+// Tickets printed for a pickup order.
+const (
+	TicketKitchen = "kitchen"
+	TicketReceipt = "receipt"
+)
+
+// PrintTicket is a ticket ready for a receipt printer: plain text lines,
+// short enough for a 58 mm roll.
+type PrintTicket struct {
+	Ticket        string   `json:"ticket"`
+	ReservationID uint     `json:"reservation_id"`
+	LocationID    uint     `json:"location_id"`
+	Lines         []string `json:"lines"`
+}
+
+// PrintTicketsRequest names the tickets to print again. Without any, both
+// the kitchen ticket and the customer receipt are printed.
+type PrintTicketsRequest struct {
+	Tickets []string `json:"tickets,omitempty" validate:"dive,oneof=kitchen receipt"`
+}
+
+// PrintTicketsResponse lists the tickets queued for printing.
+type PrintTicketsResponse struct {
+	Tickets []string `json:"tickets"`
+}
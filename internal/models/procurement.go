@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+const (
+	PurchaseOrderOpen      = "open"
+	PurchaseOrderReceived  = "received"
+	PurchaseOrderCancelled = "cancelled"
+)
+
+type Supplier struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex;size:100"`
+	Email     string    `json:"email" gorm:"size:255"`
+	Phone     string    `json:"phone" gorm:"size:50"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Supplier) TableName() string {
+	return "suppliers"
+}
+
+// Ingredient is a raw material. StockQuantity is counted in Unit (g, ml,
+// un...), separately from the finished cupcakes held at locations: it
+// grows when purchase orders are received and shrinks when production
+// batches are recorded. UnitCostCents is the unit cost of the latest
+// receipt.
+type Ingredient struct {
+	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name          string    `json:"name" gorm:"not null;uniqueIndex;size:100"`
+	Unit          string    `json:"unit" gorm:"not null;size:20"`
+	StockQuantity int       `json:"stock_quantity" gorm:"not null;default:0"`
+	UnitCostCents int       `json:"unit_cost_cents" gorm:"not null;default:0"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Ingredient) TableName() string {
+	return "ingredients"
+}
+
+// PurchaseOrder is an ingredient order placed with a supplier. It starts
+// open and is either received, adding its lines to ingredient stock, or
+// cancelled.
+type PurchaseOrder struct {
+	ID         uint                `json:"id" gorm:"primaryKey;autoIncrement"`
+	SupplierID uint                `json:"supplier_id" gorm:"not null;index"`
+	Status     string              `json:"status" gorm:"not null;size:20;index"`
+	Lines      []PurchaseOrderLine `json:"lines" gorm:"foreignKey:PurchaseOrderID"`
+	TotalCents int                 `json:"total_cents" gorm:"not null"`
+	ReceivedAt *time.Time          `json:"received_at,omitempty"`
+	CreatedAt  time.Time           `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time           `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+type PurchaseOrderLine struct {
+	ID              uint `json:"-" gorm:"primaryKey;autoIncrement"`
+	PurchaseOrderID uint `json:"-" gorm:"not null;index"`
+	IngredientID    uint `json:"ingredient_id" gorm:"not null"`
+	Quantity        int  `json:"quantity" gorm:"not null"`
+	UnitCostCents   int  `json:"unit_cost_cents" gorm:"not null"`
+}
+
+func (PurchaseOrderLine) TableName() string {
+	return "purchase_order_lines"
+}
+
+type CreateSupplierRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"omitempty,email"`
+	Phone string `json:"phone"`
+}
+
+type UpdateSupplierRequest struct {
+	Name  *string `json:"name,omitempty"`
+	Email *string `json:"email,omitempty" validate:"omitempty,email"`
+	Phone *string `json:"phone,omitempty"`
+}
+
+type CreateIngredientRequest struct {
+	Name string `json:"name" validate:"required"`
+	Unit string `json:"unit" validate:"required"`
+}
+
+type UpdateIngredientRequest struct {
+	Name *string `json:"name,omitempty"`
+	Unit *string `json:"unit,omitempty"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	SupplierID uint                       `json:"supplier_id" validate:"required"`
+	Lines      []PurchaseOrderLineRequest `json:"lines" validate:"required,min=1"`
+}
+
+type PurchaseOrderLineRequest struct {
+	IngredientID  uint `json:"ingredient_id" validate:"required"`
+	Quantity      int  `json:"quantity" validate:"required,gt=0"`
+	UnitCostCents int  `json:"unit_cost_cents" validate:"gte=0"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type Promotion struct {
+	ID            uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CupcakeID     uint      `json:"cupcake_id" gorm:"not null;index"`
+	DiscountType  string    `json:"discount_type" gorm:"not null;size:20"`
+	DiscountValue int       `json:"discount_value" gorm:"not null"`
+	StartsAt      time.Time `json:"starts_at" gorm:"not null;index"`
+	EndsAt        time.Time `json:"ends_at" gorm:"not null;index"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+type CreatePromotionRequest struct {
+	CupcakeID     uint      `json:"cupcake_id" validate:"required"`
+	DiscountType  string    `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue int       `json:"discount_value" validate:"required,gt=0"`
+	StartsAt      time.Time `json:"starts_at" validate:"required"`
+	EndsAt        time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+}
+
+type UpdatePromotionRequest struct {
+	DiscountType  *string    `json:"discount_type,omitempty" validate:"omitempty,oneof=percentage fixed"`
+	DiscountValue *int       `json:"discount_value,omitempty" validate:"omitempty,gt=0"`
+	StartsAt      *time.Time `json:"starts_at,omitempty"`
+	EndsAt        *time.Time `json:"ends_at,omitempty"`
+}
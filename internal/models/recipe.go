@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// RecipeIngredient is how much of an ingredient, in the ingredient's unit,
+// goes into one cupcake.
+type RecipeIngredient struct {
+	ID           uint `json:"-" gorm:"primaryKey;autoIncrement"`
+	CupcakeID    uint `json:"-" gorm:"not null;uniqueIndex:idx_recipe_ingredient"`
+	IngredientID uint `json:"ingredient_id" gorm:"not null;uniqueIndex:idx_recipe_ingredient"`
+	Quantity     int  `json:"quantity" gorm:"not null"`
+}
+
+func (RecipeIngredient) TableName() string {
+	return "recipe_ingredients"
+}
+
+// Recipe is a cupcake's recipe with what it costs to make one at the
+// latest ingredient costs and how many the ingredient stock allows.
+type Recipe struct {
+	CupcakeID     uint               `json:"cupcake_id"`
+	Ingredients   []RecipeIngredient `json:"ingredients"`
+	CostCents     int                `json:"cost_cents"`
+	MaxProducible int                `json:"max_producible"`
+}
+
+// ProductionBatch records cupcakes baked from ingredient stock.
+type ProductionBatch struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CupcakeID uint      `json:"cupcake_id" gorm:"not null;index"`
+	Quantity  int       `json:"quantity" gorm:"not null"`
+	CostCents int       `json:"cost_cents" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (ProductionBatch) TableName() string {
+	return "production_batches"
+}
+
+type SetRecipeRequest struct {
+	Ingredients []RecipeIngredientRequest `json:"ingredients" validate:"required,min=1"`
+}
+
+type RecipeIngredientRequest struct {
+	IngredientID uint `json:"ingredient_id" validate:"required"`
+	Quantity     int  `json:"quantity" validate:"required,gt=0"`
+}
+
+type RecordProductionRequest struct {
+	Quantity int `json:"quantity" validate:"required,gt=0"`
+}
@@ -0,0 +1,56 @@
+package models
+
+// SearchDocument is the indexed form of a cupcake.
+type SearchDocument struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Flavor      string `json:"flavor"`
+	SKU         string `json:"sku,omitempty"`
+	IsAvailable bool   `json:"is_available"`
+}
+
+func NewSearchDocument(c *CupcakeResponse) SearchDocument {
+	doc := SearchDocument{ID: c.ID, Name: c.Name, Flavor: c.Flavor, IsAvailable: c.IsAvailable}
+	if c.SKU != nil {
+		doc.SKU = *c.SKU
+	}
+	return doc
+}
+
+// SearchQuery is a catalog search: free text, the facets to count and the
+// number of hits to return.
+type SearchQuery struct {
+	Text   string
+	Facets []string
+	Limit  int
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SearchHits is what a search index answers: the matching cupcake IDs,
+// best match first, and the facet counts over all matches.
+type SearchHits struct {
+	IDs    []uint
+	Total  int
+	Facets map[string][]FacetCount
+}
+
+// SearchResult is a search answered either by the index or, when it is not
+// configured or unavailable, by the database (Backend "index" or "sql").
+type SearchResult struct {
+	Backend string
+	Total   int
+	Hits    []Cupcake
+	Facets  map[string][]FacetCount
+}
+
+type SearchResponse struct {
+	Query   string                  `json:"query"`
+	Backend string                  `json:"backend"`
+	Total   int                     `json:"total"`
+	Hits    []CupcakeResponse       `json:"hits"`
+	Facets  map[string][]FacetCount `json:"facets,omitempty"`
+}
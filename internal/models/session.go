@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Session is a login of the web app, kept on the server so that logging
+// out ends it at once. The browser holds its token in an HttpOnly cookie
+// and only the token's SHA-256 is stored. ExpiresAt moves forward while
+// the session is in use.
+type Session struct {
+	ID        uint      `json:"-" gorm:"primaryKey;autoIncrement"`
+	TokenHash string    `json:"-" gorm:"not null;size:64;uniqueIndex"`
+	AccountID uint      `json:"-" gorm:"not null;index"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// WebSession is what the web app learns about its session: the account
+// and the CSRF token to send in X-CSRF-Token with every change. Token goes
+// in the cookie and never in the body.
+type WebSession struct {
+	Token     string    `json:"-"`
+	CSRFToken string    `json:"csrf_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Account   *Account  `json:"account"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Setting is a runtime setting changed through the admin API, stored as
+// its JSON-encoded value. Settings never written keep their default and
+// have no row.
+type Setting struct {
+	Key       string    `json:"key" gorm:"primaryKey;size:100"`
+	Value     string    `json:"value" gorm:"type:text;not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Setting) TableName() string {
+	return "settings"
+}
+
+// SettingResponse is a setting with its effective value. UpdatedAt is nil
+// while it still holds its default.
+type SettingResponse struct {
+	Key         string          `json:"key"`
+	Value       json.RawMessage `json:"value"`
+	Default     json.RawMessage `json:"default"`
+	Description string          `json:"description"`
+	Public      bool            `json:"public"`
+	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
+}
+
+type UpdateSettingRequest struct {
+	Value json.RawMessage `json:"value"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// TopCupcakes ranks cupcakes by the units reserved for pickup in slots
+// from From to To, both inclusive.
+type TopCupcakes struct {
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Cupcakes []CupcakeSales `json:"cupcakes"`
+}
+
+// CupcakeSales is how many units of a cupcake were reserved, over how many
+// reservations. Name and Flavor are empty for cupcakes since deleted.
+type CupcakeSales struct {
+	CupcakeID uint   `json:"cupcake_id"`
+	Name      string `json:"name"`
+	Flavor    string `json:"flavor"`
+	Quantity  int    `json:"quantity"`
+	Orders    int    `json:"orders"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Stock alert statuses. An alert is open until an admin acknowledges it and
+// unresolved until the stock is back above the threshold or an admin
+// resolves it.
+const (
+	StockAlertOpen         = "open"
+	StockAlertAcknowledged = "acknowledged"
+	StockAlertResolved     = "resolved"
+)
+
+// StockAlert is raised when a location's stock of a cupcake falls to its
+// reorder threshold. Quantity and Threshold are the values at the time.
+type StockAlert struct {
+	ID             uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	LocationID     uint       `json:"location_id" gorm:"not null;index:idx_stock_alert_stock"`
+	CupcakeID      uint       `json:"cupcake_id" gorm:"not null;index:idx_stock_alert_stock"`
+	Quantity       int        `json:"quantity" gorm:"not null"`
+	Threshold      int        `json:"threshold" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null;size:20;index"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (StockAlert) TableName() string {
+	return "stock_alerts"
+}
+
+// EventStockLow tells the store a location is running out of a cupcake.
+const EventStockLow = "stock.low"
+
+// StockLowEvent is the payload of EventStockLow.
+type StockLowEvent struct {
+	AlertID      uint   `json:"alert_id"`
+	LocationID   uint   `json:"location_id"`
+	LocationName string `json:"location_name"`
+	CupcakeID    uint   `json:"cupcake_id"`
+	CupcakeName  string `json:"cupcake_name"`
+	Quantity     int    `json:"quantity"`
+	Threshold    int    `json:"threshold"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+const (
+	FrequencyWeekly   = "weekly"
+	FrequencyBiweekly = "biweekly"
+	FrequencyMonthly  = "monthly"
+
+	SubscriptionActive    = "active"
+	SubscriptionPaused    = "paused"
+	SubscriptionCancelled = "cancelled"
+)
+
+// Subscription delivers Quantity of a cupcake every Frequency. The email
+// is encrypted at rest; lookups go by CustomerEmailHash. Customers manage
+// it with the token returned when subscribing, of which only the hash is
+// kept.
+type Subscription struct {
+	ID                  uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	CustomerEmail       string    `json:"customer_email" gorm:"not null;size:512;serializer:pii"`
+	CustomerEmailHash   string    `json:"-" gorm:"size:64;index"`
+	ManagementTokenHash string    `json:"-" gorm:"size:64"`
+	CupcakeID           uint      `json:"cupcake_id" gorm:"not null;index"`
+	Quantity            int       `json:"quantity" gorm:"not null"`
+	Frequency           string    `json:"frequency" gorm:"not null;size:20"`
+	Status              string    `json:"status" gorm:"not null;size:20;index"`
+	NextDeliveryAt      time.Time `json:"next_delivery_at" gorm:"not null;index"`
+	CreatedAt           time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
+
+func (s *Subscription) BeforeSave(*gorm.DB) error {
+	s.CustomerEmailHash = pii.Hash(s.CustomerEmail)
+	return nil
+}
+
+type CreateSubscriptionRequest struct {
+	CustomerEmail   string     `json:"customer_email" validate:"required,email"`
+	CupcakeID       uint       `json:"cupcake_id" validate:"required"`
+	Quantity        int        `json:"quantity" validate:"required,gt=0"`
+	Frequency       string     `json:"frequency" validate:"required,oneof=weekly biweekly monthly"`
+	FirstDeliveryAt *time.Time `json:"first_delivery_at,omitempty"`
+}
+
+// CreatedSubscription carries the management token, shown only when the
+// subscription is created.
+type CreatedSubscription struct {
+	Subscription
+	ManagementToken string `json:"management_token"`
+}
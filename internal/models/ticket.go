@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+const (
+	TicketOpen          = "open"
+	TicketInvestigating = "investigating"
+	TicketResolved      = "resolved"
+
+	TicketOrderPickup       = "pickup_reservation"
+	TicketOrderSubscription = "subscription"
+
+	// EventTicketStatusChanged carries a ticket's new status, for the
+	// integration that emails the customer.
+	EventTicketStatusChanged = "ticket.status_changed"
+)
+
+// Ticket is a customer's complaint or return request about one of their
+// orders, a pickup reservation or a subscription. AssigneeID is the admin
+// handling it, if any. The email and message are encrypted at rest;
+// lookups go by CustomerEmailHash.
+type Ticket struct {
+	ID                uint       `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrderType         string     `json:"order_type" gorm:"not null;size:30"`
+	OrderID           uint       `json:"order_id" gorm:"not null"`
+	CustomerEmail     string     `json:"customer_email" gorm:"not null;size:512;serializer:pii"`
+	CustomerEmailHash string     `json:"-" gorm:"size:64;index"`
+	Subject           string     `json:"subject" gorm:"not null;size:200"`
+	Message           string     `json:"message" gorm:"type:text;serializer:pii"`
+	Status            string     `json:"status" gorm:"not null;size:20;index"`
+	AssigneeID        *uint      `json:"assignee_id,omitempty" gorm:"index"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Ticket) TableName() string {
+	return "tickets"
+}
+
+func (t *Ticket) BeforeSave(*gorm.DB) error {
+	t.CustomerEmailHash = pii.Hash(t.CustomerEmail)
+	return nil
+}
+
+type CreateTicketRequest struct {
+	CustomerEmail string `json:"customer_email" validate:"required,email"`
+	OrderType     string `json:"order_type" validate:"required,oneof=pickup_reservation subscription"`
+	OrderID       uint   `json:"order_id" validate:"required"`
+	Subject       string `json:"subject" validate:"required,max=200"`
+	Message       string `json:"message" validate:"required,max=5000"`
+}
+
+// UpdateTicketRequest changes a ticket's status or assignee; an
+// AssigneeID of 0 unassigns it.
+type UpdateTicketRequest struct {
+	Status     *string `json:"status,omitempty" validate:"omitempty,oneof=open investigating resolved"`
+	AssigneeID *uint   `json:"assignee_id,omitempty"`
+}
+
+// TicketStatusEvent is the payload of EventTicketStatusChanged.
+type TicketStatusEvent struct {
+	TicketID       uint   `json:"ticket_id"`
+	CustomerEmail  string `json:"customer_email"`
+	OrderType      string `json:"order_type"`
+	OrderID        uint   `json:"order_id"`
+	Subject        string `json:"subject"`
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status"`
+}
+
+func (e TicketStatusEvent) NotificationRecipient() string {
+	return e.CustomerEmail
+}
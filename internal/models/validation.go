@@ -0,0 +1,35 @@
+package models
+
+// ValidationRules are the business limits checked when cupcakes are created
+// or edited. A MaxPriceCents of zero means prices are not capped.
+type ValidationRules struct {
+	NameMinLength int `json:"name_min_length"`
+	NameMaxLength int `json:"name_max_length"`
+	MaxPriceCents int `json:"max_price_cents"`
+}
+
+// DefaultValidationRules are the limits used when a deployment does not
+// configure its own.
+func DefaultValidationRules() ValidationRules {
+	return ValidationRules{NameMinLength: 2, NameMaxLength: 100}
+}
+
+type UpdateValidationRulesRequest struct {
+	NameMinLength *int `json:"name_min_length,omitempty"`
+	NameMaxLength *int `json:"name_max_length,omitempty"`
+	MaxPriceCents *int `json:"max_price_cents,omitempty"`
+}
+
+// FieldErrorResponse is one failed validation rule in a 422 body.
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationErrorResponse is the 422 body listing every invalid field of a
+// request.
+type ValidationErrorResponse struct {
+	Error  string               `json:"error"`
+	Errors []FieldErrorResponse `json:"errors"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+const (
+	EventCupcakeCreated = "cupcake.created"
+	EventCupcakeUpdated = "cupcake.updated"
+	EventCupcakeDeleted = "cupcake.deleted"
+)
+
+type Webhook struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	URL       string    `json:"url" gorm:"not null;size:2048"`
+	Events    string    `json:"events" gorm:"not null;size:500"`
+	Secret    string    `json:"-" gorm:"not null;size:255"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+type WebhookDelivery struct {
+	ID         uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	WebhookID  uint      `json:"webhook_id" gorm:"not null;index"`
+	Event      string    `json:"event" gorm:"not null;size:100"`
+	Payload    string    `json:"payload" gorm:"type:text"`
+	Attempt    int       `json:"attempt" gorm:"not null"`
+	StatusCode int       `json:"status_code"`
+	Error      string    `json:"error,omitempty" gorm:"size:1000"`
+	Succeeded  bool      `json:"succeeded"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Secret string   `json:"secret" validate:"required,min=16"`
+}
+
+type UpdateWebhookRequest struct {
+	URL      *string   `json:"url,omitempty" validate:"omitempty,url"`
+	Events   *[]string `json:"events,omitempty" validate:"omitempty,min=1"`
+	Secret   *string   `json:"secret,omitempty" validate:"omitempty,min=16"`
+	IsActive *bool     `json:"is_active,omitempty"`
+}
+
+type WebhookEvent struct {
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
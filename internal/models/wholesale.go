@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+// WholesaleAccount is a business customer buying at negotiated prices.
+// Every quote for the account must reach MinOrderQuantity units. The email
+// is encrypted at rest, so EmailHash is what keeps it unique.
+type WholesaleAccount struct {
+	ID               uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name             string    `json:"name" gorm:"not null;size:100"`
+	Email            string    `json:"email" gorm:"not null;size:512;serializer:pii"`
+	EmailHash        string    `json:"-" gorm:"size:64;uniqueIndex"`
+	MinOrderQuantity int       `json:"min_order_quantity" gorm:"not null;default:0"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (WholesaleAccount) TableName() string {
+	return "wholesale_accounts"
+}
+
+func (a *WholesaleAccount) BeforeSave(*gorm.DB) error {
+	a.EmailHash = pii.Hash(a.Email)
+	return nil
+}
+
+// WholesalePrice overrides the catalog price of a cupcake for one account.
+type WholesalePrice struct {
+	ID         uint      `json:"-" gorm:"primaryKey;autoIncrement"`
+	AccountID  uint      `json:"account_id" gorm:"not null;uniqueIndex:idx_wholesale_price"`
+	CupcakeID  uint      `json:"cupcake_id" gorm:"not null;uniqueIndex:idx_wholesale_price"`
+	PriceCents int       `json:"price_cents" gorm:"not null"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (WholesalePrice) TableName() string {
+	return "wholesale_prices"
+}
+
+type CreateWholesaleAccountRequest struct {
+	Name             string `json:"name" validate:"required"`
+	Email            string `json:"email" validate:"required,email"`
+	MinOrderQuantity int    `json:"min_order_quantity" validate:"gte=0"`
+}
+
+type UpdateWholesaleAccountRequest struct {
+	Name             *string `json:"name,omitempty"`
+	Email            *string `json:"email,omitempty" validate:"omitempty,email"`
+	MinOrderQuantity *int    `json:"min_order_quantity,omitempty" validate:"omitempty,gte=0"`
+}
+
+type SetWholesalePriceRequest struct {
+	PriceCents *int `json:"price_cents" validate:"required,gt=0"`
+}
+
+type WholesaleQuoteRequest struct {
+	Items []PickupItem `json:"items" validate:"required,min=1"`
+}
+
+type WholesaleQuoteLine struct {
+	CupcakeID      uint   `json:"cupcake_id"`
+	Name           string `json:"name"`
+	Quantity       int    `json:"quantity"`
+	UnitPriceCents int    `json:"unit_price_cents"`
+	Negotiated     bool   `json:"negotiated"`
+	SubtotalCents  int    `json:"subtotal_cents"`
+}
+
+type WholesaleQuote struct {
+	AccountID  uint                 `json:"account_id"`
+	Lines      []WholesaleQuoteLine `json:"lines"`
+	TotalCents int                  `json:"total_cents"`
+}
@@ -0,0 +1,136 @@
+// Package money represents amounts as a whole number of minor units (cents)
+// in a currency. Arithmetic reports overflow and currency mismatches
+// instead of silently wrapping or mixing currencies.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrOverflow         = errors.New("money: amount out of range")
+	ErrCurrencyMismatch = errors.New("money: currencies differ")
+)
+
+// Money is Amount minor units of Currency. Prices stored by the store are
+// all in its base currency, which is left empty until an amount is
+// converted for display.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New returns amount minor units of currency, with the code normalized.
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(strings.TrimSpace(currency))}
+}
+
+// Cents returns an amount in the base currency.
+func Cents(cents int) Money {
+	return Money{Amount: int64(cents)}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Mul returns m times n, such as a unit price times a quantity.
+func (m Money) Mul(n int64) (Money, error) {
+	if m.Amount == 0 || n == 0 {
+		return Money{Currency: m.Currency}, nil
+	}
+	product := m.Amount * n
+	if product/n != m.Amount || (m.Amount == -1 && n == math.MinInt64) || (n == -1 && m.Amount == math.MinInt64) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: product, Currency: m.Currency}, nil
+}
+
+// Percent returns percent% of m, truncated toward zero. The intermediate
+// product is exact, so only a result that does not fit overflows.
+func (m Money) Percent(percent int64) (Money, error) {
+	result := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(percent))
+	result.Quo(result, big.NewInt(100))
+	if !result.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: result.Int64(), Currency: m.Currency}, nil
+}
+
+// Sum adds values, which must all be in the same currency. An empty sum is
+// zero in the base currency.
+func Sum(values ...Money) (Money, error) {
+	if len(values) == 0 {
+		return Money{}, nil
+	}
+	total := Money{Currency: values[0].Currency}
+	for _, value := range values {
+		var err error
+		if total, err = total.Add(value); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// Int returns the amount as an int, for the models that still keep cents
+// in int fields.
+func (m Money) Int() (int, error) {
+	if int64(int(m.Amount)) != m.Amount {
+		return 0, ErrOverflow
+	}
+	return int(m.Amount), nil
+}
+
+// String formats m assuming two decimal places, e.g. "12.50 BRL".
+func (m Money) String() string {
+	sign := ""
+	amount := new(big.Int).SetInt64(m.Amount)
+	if amount.Sign() < 0 {
+		sign = "-"
+		amount.Neg(amount)
+	}
+	units, cents := new(big.Int).QuoRem(amount, big.NewInt(100), new(big.Int))
+	s := fmt.Sprintf("%s%s.%02d", sign, units, cents.Int64())
+	if m.Currency != "" {
+		s += " " + m.Currency
+	}
+	return s
+}
+
+type jsonMoney struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency,omitempty"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.Amount, Currency: m.Currency})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v jsonMoney
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*m = New(v.Amount, v.Currency)
+	return nil
+}
@@ -0,0 +1,124 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		name          string
+		op            func() (Money, error)
+		expected      Money
+		expectedError error
+	}{
+		{
+			name:     "add",
+			op:       func() (Money, error) { return Cents(1250).Add(Cents(350)) },
+			expected: Cents(1600),
+		},
+		{
+			name:          "add overflows",
+			op:            func() (Money, error) { return New(math.MaxInt64, "BRL").Add(New(1, "BRL")) },
+			expectedError: ErrOverflow,
+		},
+		{
+			name:          "add negative overflows",
+			op:            func() (Money, error) { return New(math.MinInt64, "BRL").Add(New(-1, "BRL")) },
+			expectedError: ErrOverflow,
+		},
+		{
+			name:          "add mixed currencies",
+			op:            func() (Money, error) { return New(100, "BRL").Add(New(100, "USD")) },
+			expectedError: ErrCurrencyMismatch,
+		},
+		{
+			name:     "sub",
+			op:       func() (Money, error) { return Cents(1000).Sub(Cents(1250)) },
+			expected: Cents(-250),
+		},
+		{
+			name:          "sub overflows",
+			op:            func() (Money, error) { return Cents(0).Sub(New(math.MinInt64, "")) },
+			expectedError: ErrOverflow,
+		},
+		{
+			name:     "mul",
+			op:       func() (Money, error) { return New(450, "usd").Mul(12) },
+			expected: New(5400, "USD"),
+		},
+		{
+			name:          "mul overflows",
+			op:            func() (Money, error) { return New(math.MaxInt64/2+1, "").Mul(2) },
+			expectedError: ErrOverflow,
+		},
+		{
+			name:          "mul min by minus one overflows",
+			op:            func() (Money, error) { return New(math.MinInt64, "").Mul(-1) },
+			expectedError: ErrOverflow,
+		},
+		{
+			name:     "percent truncates",
+			op:       func() (Money, error) { return Cents(999).Percent(15) },
+			expected: Cents(149),
+		},
+		{
+			name:     "percent of a large amount does not overflow early",
+			op:       func() (Money, error) { return New(math.MaxInt64, "").Percent(50) },
+			expected: New(math.MaxInt64/2, ""),
+		},
+		{
+			name:          "percent overflows",
+			op:            func() (Money, error) { return New(math.MaxInt64, "").Percent(200) },
+			expectedError: ErrOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op()
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestSum(t *testing.T) {
+	total, err := Sum(Cents(100), Cents(200), Cents(300))
+	require.NoError(t, err)
+	require.Equal(t, Cents(600), total)
+
+	total, err = Sum()
+	require.NoError(t, err)
+	require.Equal(t, Money{}, total)
+
+	_, err = Sum(New(math.MaxInt64, ""), Cents(1))
+	require.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestString(t *testing.T) {
+	require.Equal(t, "12.50 BRL", New(1250, "BRL").String())
+	require.Equal(t, "-0.05", Cents(-5).String())
+	require.Equal(t, "-92233720368547758.08", New(math.MinInt64, "").String())
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(New(1250, "brl"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":1250,"currency":"BRL"}`, string(data))
+
+	data, err = json.Marshal(Cents(300))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":300}`, string(data))
+
+	var m Money
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":99,"currency":" usd "}`), &m))
+	require.Equal(t, New(99, "USD"), m)
+}
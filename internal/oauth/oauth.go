@@ -0,0 +1,159 @@
+// Package oauth implements the OAuth 2.0 authorization code flow of the
+// social logins customers can use instead of a password: Google and
+// GitHub. Each provider is enabled by setting its client ID and secret.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+var errNoSubject = errors.New("profile has no user ID")
+
+// New returns the providers with credentials in cfg, none when no social
+// login is configured.
+func New(cfg *config.Config) ([]service.OAuthProvider, error) {
+	settings, err := httpclient.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var providers []service.OAuthProvider
+	for _, p := range []struct {
+		name, id, secret string
+		build            func(id, secret string) *Provider
+	}{
+		{"GOOGLE", cfg.GoogleClientID, cfg.GoogleClientSecret, Google},
+		{"GITHUB", cfg.GitHubClientID, cfg.GitHubClientSecret, GitHub},
+	} {
+		switch {
+		case p.id == "" && p.secret == "":
+			continue
+		case p.id == "" || p.secret == "":
+			return nil, fmt.Errorf("%s_CLIENT_ID and %s_CLIENT_SECRET must be set together", p.name, p.name)
+		}
+		provider := p.build(p.id, p.secret)
+		provider.http = httpclient.New("oauth_"+strings.ToLower(p.name), 10*time.Second, settings)
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
+// Provider is an OAuth 2.0 provider using the authorization code flow with
+// a client secret. How the profile is read differs per provider.
+type Provider struct {
+	name         string
+	clientID     string
+	clientSecret string
+	authURL      string
+	tokenURL     string
+	scopes       []string
+	profile      func(ctx context.Context, p *Provider, accessToken string) (*models.OAuthProfile, error)
+	http         *http.Client
+
+	// apiURL is the base of the provider's user API.
+	apiURL string
+}
+
+var _ service.OAuthProvider = (*Provider)(nil)
+
+func newProvider(name, clientID, clientSecret string) *Provider {
+	return &Provider{
+		name:         name,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		http:         &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+func (p *Provider) AuthCodeURL(state, redirectURI string) string {
+	query := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.clientID},
+		"redirect_uri":  {redirectURI},
+		"scope":         {strings.Join(p.scopes, " ")},
+		"state":         {state},
+	}
+	return p.authURL + "?" + query.Encode()
+}
+
+// Exchange redeems code for an access token and reads the profile with it.
+// The token is not kept: the store only needs to know who logged in.
+func (p *Provider) Exchange(ctx context.Context, code, redirectURI string) (*models.OAuthProfile, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.clientID},
+		"client_secret": {p.clientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	// GitHub reports a bad code with 200 and an error field, the others
+	// with 400 and the same field.
+	if err := p.do(req, &token, http.StatusBadRequest); err != nil {
+		return nil, err
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("%s: exchanging code: %s: %s", p.name, token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("%s: exchanging code: no access token in response", p.name)
+	}
+	return p.profile(ctx, p, token.AccessToken)
+}
+
+// get reads a JSON resource of the provider's API with accessToken.
+func (p *Provider) get(ctx context.Context, url, accessToken string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+	return p.do(req, v)
+}
+
+// do sends req and decodes the JSON response into v, for 200 and the
+// other statuses in accept.
+func (p *Provider) do(req *http.Request, v any, accept ...int) error {
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && !slices.Contains(accept, resp.StatusCode) {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s %s: %s: %s", p.name, req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%s: decoding response: %w", p.name, err)
+	}
+	return nil
+}
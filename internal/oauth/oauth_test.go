@@ -0,0 +1,117 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	providers, err := New(&config.Config{})
+	require.NoError(t, err)
+	require.Empty(t, providers)
+
+	providers, err = New(&config.Config{GoogleClientID: "id", GoogleClientSecret: "secret", GitHubClientID: "id", GitHubClientSecret: "secret"})
+	require.NoError(t, err)
+	require.Len(t, providers, 2)
+	require.Equal(t, "google", providers[0].Name())
+	require.Equal(t, "github", providers[1].Name())
+
+	_, err = New(&config.Config{GitHubClientID: "id"})
+	require.EqualError(t, err, "GITHUB_CLIENT_ID and GITHUB_CLIENT_SECRET must be set together")
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	link, err := url.Parse(Google("client-id", "secret").AuthCodeURL("state-1", "https://loja.example/callback"))
+	require.NoError(t, err)
+	require.Equal(t, "accounts.google.com", link.Host)
+	query := link.Query()
+	require.Equal(t, "code", query.Get("response_type"))
+	require.Equal(t, "client-id", query.Get("client_id"))
+	require.Equal(t, "https://loja.example/callback", query.Get("redirect_uri"))
+	require.Equal(t, "openid email profile", query.Get("scope"))
+	require.Equal(t, "state-1", query.Get("state"))
+}
+
+// fakeProvider serves a token endpoint and the user APIs of Google and
+// GitHub for one code.
+func fakeProvider(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client-id", r.PostForm.Get("client_id"))
+		require.Equal(t, "secret", r.PostForm.Get("client_secret"))
+		require.Equal(t, "https://loja.example/callback", r.PostForm.Get("redirect_uri"))
+		if r.PostForm.Get("code") != "good-code" {
+			json.NewEncoder(w).Encode(map[string]string{"error": "bad_verification_code", "error_description": "The code is incorrect or expired."})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "provider-token", "token_type": "bearer"})
+	})
+	authorized := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer provider-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next(w, r)
+		}
+	}
+	mux.HandleFunc("GET /v1/userinfo", authorized(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sub":"1081","email":"ana@example.com","email_verified":true,"name":"Ana Lima"}`))
+	}))
+	mux.HandleFunc("GET /user", authorized(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":583231,"login":"analima","name":null}`))
+	}))
+	mux.HandleFunc("GET /user/emails", authorized(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"email":"old@example.com","primary":false,"verified":true},{"email":"ana@example.com","primary":true,"verified":true},{"email":"spam@example.com","primary":false,"verified":false}]`))
+	}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func pointAt(p *Provider, server *httptest.Server) *Provider {
+	p.tokenURL = server.URL + "/token"
+	p.apiURL = server.URL
+	return p
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	server := fakeProvider(t)
+	ctx := context.Background()
+
+	profile, err := pointAt(Google("client-id", "secret"), server).Exchange(ctx, "good-code", "https://loja.example/callback")
+	require.NoError(t, err)
+	require.Equal(t, "1081", profile.Subject)
+	require.Equal(t, "ana@example.com", profile.Email)
+	require.Equal(t, "Ana Lima", profile.Name)
+
+	profile, err = pointAt(GitHub("client-id", "secret"), server).Exchange(ctx, "good-code", "https://loja.example/callback")
+	require.NoError(t, err)
+	require.Equal(t, "583231", profile.Subject)
+	require.Equal(t, "ana@example.com", profile.Email)
+	require.Equal(t, "analima", profile.Name)
+
+	_, err = pointAt(GitHub("client-id", "secret"), server).Exchange(ctx, "bad-code", "https://loja.example/callback")
+	require.ErrorContains(t, err, "bad_verification_code")
+}
+
+func TestGoogleProfile_UnverifiedEmail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sub":"1081","email":"ana@example.com","email_verified":false}`))
+	}))
+	defer server.Close()
+
+	profile, err := googleProfile(context.Background(), pointAt(Google("client-id", "secret"), server), "provider-token")
+	require.NoError(t, err)
+	require.Equal(t, "1081", profile.Subject)
+	require.Empty(t, profile.Email)
+}
@@ -0,0 +1,87 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+// Google logs in with a Google account over OpenID Connect's user info
+// endpoint.
+func Google(clientID, clientSecret string) *Provider {
+	p := newProvider("google", clientID, clientSecret)
+	p.authURL = "https://accounts.google.com/o/oauth2/v2/auth"
+	p.tokenURL = "https://oauth2.googleapis.com/token"
+	p.apiURL = "https://openidconnect.googleapis.com"
+	p.scopes = []string{"openid", "email", "profile"}
+	p.profile = googleProfile
+	return p
+}
+
+func googleProfile(ctx context.Context, p *Provider, accessToken string) (*models.OAuthProfile, error) {
+	var user struct {
+		Subject       string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := p.get(ctx, p.apiURL+"/v1/userinfo", accessToken, &user); err != nil {
+		return nil, err
+	}
+	if user.Subject == "" {
+		return nil, fmt.Errorf("%s: %w", p.name, errNoSubject)
+	}
+	profile := &models.OAuthProfile{Subject: user.Subject, Name: user.Name}
+	if user.EmailVerified {
+		profile.Email = user.Email
+	}
+	return profile, nil
+}
+
+// GitHub logs in with a GitHub account. The email comes from the account's
+// verified addresses, preferring the primary one, since the public profile
+// may not show any.
+func GitHub(clientID, clientSecret string) *Provider {
+	p := newProvider("github", clientID, clientSecret)
+	p.authURL = "https://github.com/login/oauth/authorize"
+	p.tokenURL = "https://github.com/login/oauth/access_token"
+	p.apiURL = "https://api.github.com"
+	p.scopes = []string{"read:user", "user:email"}
+	p.profile = githubProfile
+	return p
+}
+
+func githubProfile(ctx context.Context, p *Provider, accessToken string) (*models.OAuthProfile, error) {
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := p.get(ctx, p.apiURL+"/user", accessToken, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("%s: %w", p.name, errNoSubject)
+	}
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := p.get(ctx, p.apiURL+"/user/emails", accessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	profile := &models.OAuthProfile{Subject: strconv.FormatInt(user.ID, 10), Name: user.Name}
+	if profile.Name == "" {
+		profile.Name = user.Login
+	}
+	for _, e := range emails {
+		if e.Verified && (e.Primary || profile.Email == "") {
+			profile.Email = e.Email
+		}
+	}
+	return profile, nil
+}
@@ -0,0 +1,122 @@
+// Package password hashes passwords with argon2id. Hashes are stored in the
+// PHC string format, which carries the salt and the cost parameters along
+// with the key, so a hash keeps verifying after the parameters change and
+// NeedsRehash can tell it was made with older ones.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var ErrInvalidHash = errors.New("password: hash is not an argon2id PHC string")
+
+// Params are the argon2id cost parameters. Memory is in KiB.
+type Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultParams follow the OWASP recommendation for argon2id: 19 MiB of
+// memory and two passes.
+var DefaultParams = Params{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Validate rejects parameters argon2 cannot work with.
+func (p Params) Validate() error {
+	switch {
+	case p.Iterations < 1:
+		return errors.New("password: iterations must be at least 1")
+	case p.Parallelism < 1:
+		return errors.New("password: parallelism must be at least 1")
+	case p.Memory < 8*uint32(p.Parallelism):
+		return errors.New("password: memory must be at least 8 KiB per thread")
+	case p.SaltLength < 8:
+		return errors.New("password: salt must be at least 8 bytes")
+	case p.KeyLength < 16:
+		return errors.New("password: key must be at least 16 bytes")
+	}
+	return nil
+}
+
+// Hash derives a key from password under a fresh random salt and returns
+// it encoded as $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>.
+func Hash(password string, p Params) (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify reports whether password matches encoded, comparing the keys in
+// constant time.
+func Verify(password, encoded string) (bool, error) {
+	p, salt, key, err := decode(encoded)
+	if err != nil {
+		return false, err
+	}
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash reports whether encoded was made with parameters other than
+// p, or cannot be read at all.
+func NeedsRehash(encoded string, p Params) bool {
+	current, _, _, err := decode(encoded)
+	return err != nil || current != p
+}
+
+func decode(encoded string) (Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	fields := strings.Split(encoded, "$")
+	if len(fields) != 6 || fields[0] != "" || fields[1] != "argon2id" {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(fields[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	var p Params
+	if _, err := fmt.Sscanf(fields[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(fields[4])
+	if err != nil {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(fields[5])
+	if err != nil {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+	if p.Validate() != nil {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	return p, salt, key, nil
+}
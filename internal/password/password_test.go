@@ -0,0 +1,79 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testParams keep the tests fast; real deployments use DefaultParams or
+// stronger.
+var testParams = Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestHash(t *testing.T) {
+	hash, err := Hash("correct horse", testParams)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+	require.NotContains(t, hash, "correct horse")
+
+	// Every hash gets its own salt.
+	again, err := Hash("correct horse", testParams)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, again)
+
+	_, err = Hash("correct horse", Params{Memory: 64, Iterations: 0, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	require.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	hash, err := Hash("correct horse", testParams)
+	require.NoError(t, err)
+
+	ok, err := Verify("correct horse", hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = Verify("Correct horse", hash)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for _, bad := range []string{
+		"",
+		"correct horse",
+		"$2a$10$abcdefghijklmnopqrstuv",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=64,t=0,p=1$c2FsdHNhbHRzYWx0$a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$not base64$a2V5a2V5a2V5a2V5a2V5a2V5",
+	} {
+		_, err := Verify("correct horse", bad)
+		require.ErrorIs(t, err, ErrInvalidHash, bad)
+	}
+}
+
+func TestVerify_KnownHash(t *testing.T) {
+	// Made by the reference implementation:
+	// echo -n password | argon2 somesalt -id -t 2 -k 64 -p 1 -l 24 -e
+	hash := "$argon2id$v=19$m=64,t=2,p=1$c29tZXNhbHQ$Bo1ismRVk2qm6+YAYLCmWHDb+j3fjUH3"
+
+	ok, err := Verify("password", hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hash, err := Hash("correct horse", testParams)
+	require.NoError(t, err)
+	require.False(t, NeedsRehash(hash, testParams))
+
+	stronger := testParams
+	stronger.Iterations = 2
+	require.True(t, NeedsRehash(hash, stronger))
+
+	longer := testParams
+	longer.KeyLength = 64
+	require.True(t, NeedsRehash(hash, longer))
+
+	require.True(t, NeedsRehash("not a hash", testParams))
+}
@@ -0,0 +1,254 @@
+// Package pii encrypts customers' personal data before it reaches the
+// database. Model fields tagged `gorm:"serializer:pii"` are sealed with
+// AES-256-GCM on write and opened on read, so a dump of the database only
+// shows ciphertext. Since ciphertext cannot be compared in SQL, lookups go
+// through a separate column holding Hash of the value.
+//
+// Without a key, values are stored as they are. Rows written before a key
+// was configured keep reading fine, since only values carrying the
+// encrypted prefix are decrypted.
+//
+// Encrypted values name the key that sealed them. To rotate the key, the
+// old one is kept among the previous keys, which only decrypt; the values
+// and hashes written with it are then rewritten with the new key, see
+// CurrentPrefix.
+package pii
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"gorm.io/gorm/schema"
+)
+
+// Prefix marks encrypted values. Version 2 values are followed by the ID
+// of their key, enc:v2:<key ID>:<sealed>; version 1 values, written before
+// keys had IDs, by the sealed value alone.
+const Prefix = "enc:"
+
+const (
+	prefixV1 = Prefix + "v1:"
+	prefixV2 = Prefix + "v2:"
+)
+
+// KeySize is the length of the key Configure takes, in bytes.
+const KeySize = 32
+
+var ErrNoKey = errors.New("pii: value is encrypted but no key is configured")
+
+type key struct {
+	id    string
+	aead  cipher.AEAD
+	index []byte
+}
+
+// keyring is the key values are sealed with, first, and the previous keys.
+type keyring []*key
+
+var current atomic.Pointer[keyring]
+
+func init() {
+	schema.RegisterSerializer("pii", Serializer{})
+}
+
+// Configure sets the key values are encrypted with and the previous keys
+// still used to decrypt, all base64 encoded as they come from the
+// environment or a KMS. An empty key turns encryption off.
+func Configure(encoded string, previous ...string) error {
+	if encoded == "" {
+		if len(previous) > 0 {
+			return errors.New("pii: previous keys need a current key")
+		}
+		current.Store(nil)
+		return nil
+	}
+
+	ring := make(keyring, 0, 1+len(previous))
+	for _, encoded := range append([]string{encoded}, previous...) {
+		k, err := parseKey(encoded)
+		if err != nil {
+			return err
+		}
+		ring = append(ring, k)
+	}
+	current.Store(&ring)
+	return nil
+}
+
+func parseKey(encoded string) (*key, error) {
+	secret, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(secret) != KeySize {
+		return nil, fmt.Errorf("pii: key must be %d bytes, base64 encoded", KeySize)
+	}
+
+	block, err := aes.NewCipher(secret)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// The ID and the index key are derived from the encryption key, so one
+	// secret covers all three without revealing anything about it.
+	derive := func(label string) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(label))
+		return mac.Sum(nil)
+	}
+	return &key{id: hex.EncodeToString(derive("pii key id")[:4]), aead: aead, index: derive("pii index")}, nil
+}
+
+// Enabled reports whether a key is configured.
+func Enabled() bool {
+	return current.Load() != nil
+}
+
+// CurrentPrefix is the prefix of the values sealed with the current key,
+// or "" without one. Values stored without it are in plain text or sealed
+// with a previous key, and their hashes need recomputing too.
+func CurrentPrefix() string {
+	ring := current.Load()
+	if ring == nil {
+		return ""
+	}
+	return prefixV2 + (*ring)[0].id + ":"
+}
+
+// Encrypt seals value, or returns it unchanged without a key.
+func Encrypt(value string) (string, error) {
+	ring := current.Load()
+	if ring == nil {
+		return value, nil
+	}
+	k := (*ring)[0]
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := k.aead.Seal(nonce, nonce, []byte(value), nil)
+	return prefixV2 + k.id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value written by Encrypt with the current or a previous
+// key. Values without the prefix were stored in plain text and are
+// returned as they are.
+func Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, Prefix) {
+		return value, nil
+	}
+	ring := current.Load()
+	if ring == nil {
+		return "", ErrNoKey
+	}
+
+	// Version 1 values do not say which key sealed them; GCM refuses the
+	// wrong ones, so each is tried.
+	candidates := *ring
+	var encoded string
+	switch {
+	case strings.HasPrefix(value, prefixV2):
+		id, rest, ok := strings.Cut(value[len(prefixV2):], ":")
+		if !ok {
+			return "", errors.New("pii: malformed encrypted value")
+		}
+		candidates = nil
+		for _, k := range *ring {
+			if k.id == id {
+				candidates = keyring{k}
+			}
+		}
+		if candidates == nil {
+			return "", fmt.Errorf("pii: value is encrypted with unknown key %s", id)
+		}
+		encoded = rest
+	case strings.HasPrefix(value, prefixV1):
+		encoded = value[len(prefixV1):]
+	default:
+		return "", errors.New("pii: unknown encrypted value version")
+	}
+
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errors.New("pii: malformed encrypted value")
+	}
+	var plain []byte
+	for _, k := range candidates {
+		if len(sealed) < k.aead.NonceSize() {
+			return "", errors.New("pii: malformed encrypted value")
+		}
+		nonce, ciphertext := sealed[:k.aead.NonceSize()], sealed[k.aead.NonceSize():]
+		if plain, err = k.aead.Open(nil, nonce, ciphertext, nil); err == nil {
+			return string(plain), nil
+		}
+	}
+	return "", fmt.Errorf("pii: decrypting value: %w", err)
+}
+
+// Hash is the lookup key of value: an HMAC of it trimmed and lowercased,
+// so emails match however they were typed. It changes with the current
+// key.
+func Hash(value string) string {
+	var index []byte
+	if ring := current.Load(); ring != nil {
+		index = (*ring)[0].index
+	}
+	mac := hmac.New(sha256.New, index)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Serializer is the GORM serializer registered as "pii". It handles string
+// and []byte fields.
+type Serializer struct{}
+
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var stored string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("pii: cannot scan %T into %s", dbValue, field.Name)
+	}
+
+	plain, err := Decrypt(stored)
+	if err != nil {
+		return err
+	}
+	if field.FieldType.Kind() == reflect.Slice {
+		if dbValue == nil {
+			return field.Set(ctx, dst, []byte(nil))
+		}
+		return field.Set(ctx, dst, []byte(plain))
+	}
+	return field.Set(ctx, dst, plain)
+}
+
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	switch v := fieldValue.(type) {
+	case string:
+		return Encrypt(v)
+	case []byte:
+		if v == nil {
+			return nil, nil
+		}
+		sealed, err := Encrypt(string(v))
+		return []byte(sealed), err
+	default:
+		return nil, fmt.Errorf("pii: unsupported field type %T for %s", fieldValue, field.Name)
+	}
+}
@@ -0,0 +1,103 @@
+package pii
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { Configure("") })
+
+	require.Error(t, Configure("not base64!"))
+	require.Error(t, Configure(base64.StdEncoding.EncodeToString([]byte("short"))))
+	require.False(t, Enabled())
+
+	require.NoError(t, Configure(testKey))
+	require.True(t, Enabled())
+	require.NoError(t, Configure(""))
+	require.False(t, Enabled())
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Cleanup(func() { Configure("") })
+
+	plain, err := Encrypt("ana@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "ana@example.com", plain)
+	unkeyed := Hash("ana@example.com")
+
+	require.NoError(t, Configure(testKey))
+	sealed, err := Encrypt("ana@example.com")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(sealed, Prefix))
+	require.NotContains(t, sealed, "ana")
+
+	// Every encryption gets a fresh nonce.
+	again, err := Encrypt("ana@example.com")
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again)
+
+	opened, err := Decrypt(sealed)
+	require.NoError(t, err)
+	require.Equal(t, "ana@example.com", opened)
+
+	// Values stored before encryption was turned on read as they are.
+	opened, err = Decrypt("bruno@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "bruno@example.com", opened)
+
+	_, err = Decrypt(sealed[:len(sealed)-2])
+	require.Error(t, err)
+
+	require.Equal(t, Hash("ana@example.com"), Hash(" Ana@Example.COM "))
+	require.NotEqual(t, unkeyed, Hash("ana@example.com"))
+
+	require.NoError(t, Configure(""))
+	_, err = Decrypt(sealed)
+	require.ErrorIs(t, err, ErrNoKey)
+}
+
+func TestRotation(t *testing.T) {
+	t.Cleanup(func() { Configure("") })
+	newKey := base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210"))
+
+	require.Error(t, Configure("", testKey))
+	require.Equal(t, "", CurrentPrefix())
+
+	require.NoError(t, Configure(testKey))
+	oldPrefix := CurrentPrefix()
+	sealed, err := Encrypt("ana@example.com")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(sealed, oldPrefix))
+	oldHash := Hash("ana@example.com")
+
+	// Values from before keys had IDs.
+	k := (*current.Load())[0]
+	nonce := make([]byte, k.aead.NonceSize())
+	v1 := prefixV1 + base64.RawStdEncoding.EncodeToString(k.aead.Seal(nonce, nonce, []byte("bruno@example.com"), nil))
+
+	require.NoError(t, Configure(newKey, testKey))
+	require.NotEqual(t, oldPrefix, CurrentPrefix())
+	require.NotEqual(t, oldHash, Hash("ana@example.com"))
+	resealed, err := Encrypt("ana@example.com")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(resealed, CurrentPrefix()))
+
+	for value, plain := range map[string]string{sealed: "ana@example.com", resealed: "ana@example.com", v1: "bruno@example.com"} {
+		opened, err := Decrypt(value)
+		require.NoError(t, err)
+		require.Equal(t, plain, opened)
+	}
+
+	// Once the old key is dropped, what it sealed cannot be read.
+	require.NoError(t, Configure(newKey))
+	_, err = Decrypt(sealed)
+	require.ErrorContains(t, err, "unknown key")
+	_, err = Decrypt(v1)
+	require.Error(t, err)
+}
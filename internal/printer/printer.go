@@ -0,0 +1,83 @@
+// Package printer sends tickets to a receipt printer. A print webhook, such
+// as a print server in front of an ESC/POS printer, is the only transport
+// supported.
+package printer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// New returns the printer of PRINT_PROVIDER, or nil when it is none.
+func New(cfg *config.Config) (service.TicketPrinter, error) {
+	switch cfg.PrintProvider {
+	case "", "none":
+		return nil, nil
+	case "webhook":
+	default:
+		return nil, fmt.Errorf("unknown PRINT_PROVIDER %q: must be none or webhook", cfg.PrintProvider)
+	}
+	if cfg.PrintWebhookURL == "" {
+		return nil, fmt.Errorf("PRINT_WEBHOOK_URL is required with PRINT_PROVIDER=webhook")
+	}
+	if u, err := url.Parse(cfg.PrintWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid PRINT_WEBHOOK_URL: must be an http or https URL")
+	}
+	settings, err := httpclient.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Webhook{
+		url:    cfg.PrintWebhookURL,
+		secret: cfg.PrintWebhookSecret,
+		http:   httpclient.New("printer", 10*time.Second, settings),
+	}, nil
+}
+
+// Webhook POSTs each ticket as JSON to a print server. With a secret, the
+// body is signed like the store's webhooks, in X-Cupcake-Signature.
+type Webhook struct {
+	url    string
+	secret string
+	http   *http.Client
+}
+
+var _ service.TicketPrinter = (*Webhook)(nil)
+
+func (p *Webhook) Print(ctx context.Context, ticket *models.PrintTicket) error {
+	body, err := json.Marshal(ticket)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.secret != "" {
+		req.Header.Set("X-Cupcake-Signature", "sha256="+service.SignWebhookPayload(p.secret, body))
+	}
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("print webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("print webhook: %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+}
@@ -0,0 +1,63 @@
+package printer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	printer, err := New(&config.Config{PrintProvider: "none"})
+	require.NoError(t, err)
+	require.Nil(t, printer)
+
+	printer, err = New(&config.Config{PrintProvider: "webhook", PrintWebhookURL: "http://printer.local/print"})
+	require.NoError(t, err)
+	require.Equal(t, "http://printer.local/print", printer.(*Webhook).url)
+
+	_, err = New(&config.Config{PrintProvider: "webhook"})
+	require.EqualError(t, err, "PRINT_WEBHOOK_URL is required with PRINT_PROVIDER=webhook")
+	_, err = New(&config.Config{PrintProvider: "webhook", PrintWebhookURL: "printer.local"})
+	require.EqualError(t, err, "invalid PRINT_WEBHOOK_URL: must be an http or https URL")
+	_, err = New(&config.Config{PrintProvider: "escpos"})
+	require.ErrorContains(t, err, `unknown PRINT_PROVIDER "escpos"`)
+}
+
+func TestWebhook_Print(t *testing.T) {
+	var received models.PrintTicket
+	var signature string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(body, &received))
+		signature = r.Header.Get("X-Cupcake-Signature")
+		require.Equal(t, "sha256="+service.SignWebhookPayload("s3cret", body), signature)
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			w.Write([]byte("paper out"))
+		}
+	}))
+	defer server.Close()
+
+	printer, err := New(&config.Config{PrintProvider: "webhook", PrintWebhookURL: server.URL, PrintWebhookSecret: "s3cret"})
+	require.NoError(t, err)
+
+	ticket := &models.PrintTicket{Ticket: models.TicketKitchen, ReservationID: 7, LocationID: 1, Lines: []string{"KITCHEN", "Order #7", "2x Vanilla"}}
+	require.NoError(t, printer.Print(context.Background(), ticket))
+	require.Equal(t, *ticket, received)
+	require.NotEmpty(t, signature)
+
+	status = http.StatusServiceUnavailable
+	err = printer.Print(context.Background(), ticket)
+	require.EqualError(t, err, "print webhook: 503 Service Unavailable: paper out")
+}
@@ -0,0 +1,247 @@
+// Package push sends push notifications to customers' devices. Firebase
+// Cloud Messaging, through its HTTP v1 API, is the only provider
+// supported.
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+const (
+	fcmAPIURL   = "https://fcm.googleapis.com"
+	fcmScope    = "https://www.googleapis.com/auth/firebase.messaging"
+	googleToken = "https://oauth2.googleapis.com/token"
+)
+
+// New returns the sender of PUSH_PROVIDER, or nil when it is none.
+func New(cfg *config.Config) (service.PushSender, error) {
+	switch cfg.PushProvider {
+	case "", "none":
+		return nil, nil
+	case "fcm":
+	default:
+		return nil, fmt.Errorf("unknown PUSH_PROVIDER %q: must be none or fcm", cfg.PushProvider)
+	}
+	if cfg.FCMCredentials == "" {
+		return nil, fmt.Errorf("FCM_CREDENTIALS is required with PUSH_PROVIDER=fcm")
+	}
+	raw := []byte(cfg.FCMCredentials)
+	if !strings.HasPrefix(strings.TrimSpace(cfg.FCMCredentials), "{") {
+		var err error
+		if raw, err = os.ReadFile(cfg.FCMCredentials); err != nil {
+			return nil, fmt.Errorf("reading FCM_CREDENTIALS: %w", err)
+		}
+	}
+	account, err := parseServiceAccount(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FCM_CREDENTIALS: %w", err)
+	}
+	settings, err := httpclient.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &FCM{
+		apiURL:  fcmAPIURL,
+		account: account,
+		http:    httpclient.New("push", 10*time.Second, settings),
+		now:     time.Now,
+	}, nil
+}
+
+// serviceAccount is the part of a Google service account key file the
+// sender needs.
+type serviceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+
+	key *rsa.PrivateKey
+}
+
+func parseServiceAccount(raw []byte) (*serviceAccount, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(raw, &account); err != nil {
+		return nil, err
+	}
+	if account.ProjectID == "" || account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, errors.New("project_id, client_email and private_key are required")
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = googleToken
+	}
+	block, _ := pem.Decode([]byte(account.PrivateKey))
+	if block == nil {
+		return nil, errors.New("private_key is not PEM")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("private_key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private_key is not an RSA key")
+	}
+	account.key = rsaKey
+	return &account, nil
+}
+
+// FCM sends notifications with the FCM HTTP v1 API, as the service
+// account. The access token it is exchanged for is kept until shortly
+// before it expires.
+type FCM struct {
+	apiURL  string
+	account *serviceAccount
+	http    *http.Client
+	now     func() time.Time
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+var _ service.PushSender = (*FCM)(nil)
+
+func (f *FCM) Send(ctx context.Context, token string, message *models.PushMessage) error {
+	accessToken, err := f.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	type notification struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}
+	body, err := json.Marshal(map[string]any{"message": map[string]any{
+		"token":        token,
+		"notification": notification{Title: message.Title, Body: message.Body},
+		"data":         message.Data,
+	}})
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", f.apiURL, url.PathEscape(f.account.ProjectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("fcm: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		f.mu.Lock()
+		f.accessToken = ""
+		f.mu.Unlock()
+	}
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var failure struct {
+		Error struct {
+			Message string `json:"message"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(raw, &failure) != nil {
+		return fmt.Errorf("fcm: %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+	for _, detail := range failure.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return service.ErrPushTokenInvalid
+		}
+	}
+	return fmt.Errorf("fcm: %s: %s", resp.Status, failure.Error.Message)
+}
+
+// token returns an access token of the service account, exchanging a
+// signed JWT for a new one when the last is about to expire.
+func (f *FCM) token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := f.now()
+	if f.accessToken != "" && now.Add(time.Minute).Before(f.expiresAt) {
+		return f.accessToken, nil
+	}
+
+	assertion, err := f.assertion(now)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"}, "assertion": {assertion}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fcm: token: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fcm: token: %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+	var grant struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(raw, &grant); err != nil || grant.AccessToken == "" {
+		return "", fmt.Errorf("fcm: token: unexpected response")
+	}
+	f.accessToken = grant.AccessToken
+	f.expiresAt = now.Add(time.Duration(grant.ExpiresIn) * time.Second)
+	return f.accessToken, nil
+}
+
+// assertion is the JWT, signed with the service account's key, that the
+// token endpoint exchanges for an access token.
+func (f *FCM) assertion(now time.Time) (string, error) {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   f.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   f.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(nil, f.account.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,126 @@
+package push
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func testCredentials(t *testing.T, tokenURI string) (string, *rsa.PublicKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "cupcake-store",
+		"client_email": "push@cupcake-store.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    tokenURI,
+	})
+	require.NoError(t, err)
+	return string(credentials), &key.PublicKey
+}
+
+func TestNew(t *testing.T) {
+	sender, err := New(&config.Config{PushProvider: "none"})
+	require.NoError(t, err)
+	require.Nil(t, sender)
+
+	credentials, _ := testCredentials(t, "")
+	sender, err = New(&config.Config{PushProvider: "fcm", FCMCredentials: credentials})
+	require.NoError(t, err)
+	require.Equal(t, "cupcake-store", sender.(*FCM).account.ProjectID)
+	require.Equal(t, googleToken, sender.(*FCM).account.TokenURI)
+
+	_, err = New(&config.Config{PushProvider: "fcm"})
+	require.EqualError(t, err, "FCM_CREDENTIALS is required with PUSH_PROVIDER=fcm")
+	_, err = New(&config.Config{PushProvider: "fcm", FCMCredentials: `{"project_id":"cupcake-store"}`})
+	require.EqualError(t, err, "invalid FCM_CREDENTIALS: project_id, client_email and private_key are required")
+	_, err = New(&config.Config{PushProvider: "apns"})
+	require.ErrorContains(t, err, `unknown PUSH_PROVIDER "apns"`)
+}
+
+func TestFCM_Send(t *testing.T) {
+	var publicKey *rsa.PublicKey
+	var grants int
+	var sent []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			require.NoError(t, r.ParseForm())
+			require.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+			parts := strings.Split(r.PostForm.Get("assertion"), ".")
+			require.Len(t, parts, 3)
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			require.NoError(t, err)
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			require.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature))
+			claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+			require.NoError(t, err)
+			require.Contains(t, string(claims), `"scope":"`+fcmScope+`"`)
+			grants++
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "access-1", "expires_in": 3600})
+		case "/v1/projects/cupcake-store/messages:send":
+			require.Equal(t, "Bearer access-1", r.Header.Get("Authorization"))
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			message := body["message"].(map[string]any)
+			if message["token"] == "gone" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND","details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"UNREGISTERED"}]}}`))
+				return
+			}
+			if message["token"] == "quota" {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error":{"code":429,"message":"Quota exceeded.","status":"RESOURCE_EXHAUSTED"}}`))
+				return
+			}
+			sent = append(sent, message)
+			w.Write([]byte(`{"name":"projects/cupcake-store/messages/1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	credentials, key := testCredentials(t, server.URL+"/token")
+	publicKey = key
+	account, err := parseServiceAccount([]byte(credentials))
+	require.NoError(t, err)
+	now := time.Now()
+	sender := &FCM{apiURL: server.URL, account: account, http: server.Client(), now: func() time.Time { return now }}
+
+	message := &models.PushMessage{Title: "Your order is ready", Body: "Order #12 is ready for pickup at Centro.", Data: map[string]string{"reservation_id": "12"}}
+	require.NoError(t, sender.Send(context.Background(), "phone", message))
+	require.NoError(t, sender.Send(context.Background(), "tablet", message))
+	require.Len(t, sent, 2)
+	require.Equal(t, "phone", sent[0]["token"])
+	require.Equal(t, map[string]any{"title": "Your order is ready", "body": "Order #12 is ready for pickup at Centro."}, sent[0]["notification"])
+	require.Equal(t, map[string]any{"reservation_id": "12"}, sent[0]["data"])
+
+	// The access token is reused until it is about to expire.
+	require.Equal(t, 1, grants)
+	now = now.Add(time.Hour)
+	require.NoError(t, sender.Send(context.Background(), "phone", message))
+	require.Equal(t, 2, grants)
+
+	require.ErrorIs(t, sender.Send(context.Background(), "gone", message), service.ErrPushTokenInvalid)
+	require.EqualError(t, sender.Send(context.Background(), "quota", message), "fcm: 429 Too Many Requests: Quota exceeded.")
+}
@@ -0,0 +1,88 @@
+package redact
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "URL DSN password",
+			input:    "dial postgres://cupcake:hunter2@db:5432/store",
+			expected: "dial postgres://cupcake:[REDACTED]@db:5432/store",
+		},
+		{
+			name:     "key value DSN password",
+			input:    "failed to connect to `host=db user=cupcake password=hunter2 dbname=store`",
+			expected: "failed to connect to `host=db user=cupcake password=[REDACTED] dbname=store`",
+		},
+		{
+			name:     "authorization header",
+			input:    "Authorization: Bearer s3cret.token, Basic dXNlcjpwYXNz",
+			expected: "Authorization: Bearer [REDACTED], Basic [REDACTED]",
+		},
+		{
+			name:     "query string secrets",
+			input:    `"GET http://localhost/api/v1/data-exports/1/download?expires=1700000000&signature=abc123 HTTP/1.1"`,
+			expected: `"GET http://localhost/api/v1/data-exports/1/download?expires=1700000000&signature=[REDACTED] HTTP/1.1"`,
+		},
+		{
+			name:     "JSON fields",
+			input:    `{"card_number":"4111 1111 1111 1111","cvv":"123","name":"Ana"}`,
+			expected: `{"card_number":[REDACTED],"cvv":[REDACTED],"name":"Ana"}`,
+		},
+		{
+			name:     "slog attributes",
+			input:    `level=INFO msg=config DB_DSN=/var/lib/store.db ADMIN_TOKEN=abc`,
+			expected: `level=INFO msg=config DB_DSN=[REDACTED] ADMIN_TOKEN=[REDACTED]`,
+		},
+		{
+			name:     "emails in SQL and query strings",
+			input:    `INSERT INTO subscriptions (customer_email) VALUES ("ana@example.com") /me?email=bruno%40example.com`,
+			expected: `INSERT INTO subscriptions (customer_email) VALUES ("[REDACTED]") /me?email=[REDACTED]`,
+		},
+		{
+			name:     "card numbers pass the Luhn check",
+			input:    "paid with 4111-1111-1111-1111 at 20261016083000",
+			expected: "paid with [REDACTED] at 20261016083000",
+		},
+		{
+			name:     "gift card codes",
+			input:    `"GET http://localhost/api/v1/gift-cards/ABCD-EFGH-IJKL-MNOP HTTP/1.1"`,
+			expected: `"GET http://localhost/api/v1/gift-cards/[REDACTED] HTTP/1.1"`,
+		},
+		{
+			name:     "nothing sensitive",
+			input:    "SELECT * FROM cupcakes WHERE id = 42 LIMIT 1",
+			expected: "SELECT * FROM cupcakes WHERE id = 42 LIMIT 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, String(tt.input))
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(NewWriter(&buf), "", 0)
+
+	logger.Printf("export ready for %s", "ana@example.com")
+	require.Equal(t, "export ready for [REDACTED]\n", buf.String())
+
+	buf.Reset()
+	slog.New(slog.NewJSONHandler(NewWriter(&buf), nil)).Info("secrets loaded", "vault_token", "s.abc", "count", 3)
+	require.Contains(t, buf.String(), `"vault_token":[REDACTED]`)
+	require.Contains(t, buf.String(), `"count":3`)
+}
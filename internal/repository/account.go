@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+type AccountRepository struct {
+	db *gorm.DB
+}
+
+var _ AccountRepositoryInterface = (*AccountRepository)(nil)
+
+func NewAccountRepository(db *gorm.DB) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+func (r *AccountRepository) Create(account *models.Account) error {
+	return translateError(r.db.Create(account).Error)
+}
+
+func (r *AccountRepository) FindByID(id uint) (*models.Account, error) {
+	var account models.Account
+	if err := r.db.First(&account, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &account, nil
+}
+
+func (r *AccountRepository) FindByEmail(email string) (*models.Account, error) {
+	var account models.Account
+	if err := r.db.Where("email_hash = ?", pii.Hash(email)).First(&account).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &account, nil
+}
+
+// MarkEmailVerified records when the account's email was verified.
+func (r *AccountRepository) MarkEmailVerified(id uint, at time.Time) error {
+	result := r.db.Model(&models.Account{}).Where("id = ?", id).Update("email_verified_at", at)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// UpdatePasswordHash replaces only the hash, leaving the rest of the row as
+// it was read.
+func (r *AccountRepository) UpdatePasswordHash(id uint, hash string) error {
+	result := r.db.Model(&models.Account{}).Where("id = ?", id).Update("password_hash", hash)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CreateWithIdentity creates an account and links it to an OAuth provider
+// login in one transaction.
+func (r *AccountRepository) CreateWithIdentity(account *models.Account, identity *models.AccountIdentity) error {
+	return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return err
+		}
+		identity.AccountID = account.ID
+		return tx.Create(identity).Error
+	}))
+}
+
+// FindByIdentity returns the account linked to a provider login.
+func (r *AccountRepository) FindByIdentity(provider, subject string) (*models.Account, error) {
+	var account models.Account
+	err := r.db.
+		Joins("JOIN account_identities ON account_identities.account_id = accounts.id").
+		Where("account_identities.provider = ? AND account_identities.subject = ?", provider, subject).
+		First(&account).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &account, nil
+}
+
+func (r *AccountRepository) FindIdentities(accountID uint) ([]models.AccountIdentity, error) {
+	var identities []models.AccountIdentity
+	if err := r.db.Where("account_id = ?", accountID).Order("provider").Find(&identities).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return identities, nil
+}
+
+func (r *AccountRepository) CreateIdentity(identity *models.AccountIdentity) error {
+	return translateError(r.db.Create(identity).Error)
+}
+
+func (r *AccountRepository) DeleteIdentity(accountID uint, provider string) error {
+	result := r.db.Where("account_id = ? AND provider = ?", accountID, provider).Delete(&models.AccountIdentity{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAccountRepository(db)
+
+	account := &models.Account{Name: "Ana", Email: "ana@example.com", PasswordHash: "$argon2id$old"}
+	require.NoError(t, repo.Create(account))
+	require.ErrorIs(t, repo.Create(&models.Account{Name: "Ana", Email: "ana@example.com", PasswordHash: "x"}), ErrDuplicate)
+
+	found, err := repo.FindByEmail("ANA@example.com ")
+	require.NoError(t, err)
+	require.Equal(t, account.ID, found.ID)
+	_, err = repo.FindByEmail("bruno@example.com")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, repo.UpdatePasswordHash(account.ID, "$argon2id$new"))
+	found, err = repo.FindByID(account.ID)
+	require.NoError(t, err)
+	require.Equal(t, "$argon2id$new", found.PasswordHash)
+	require.Equal(t, "Ana", found.Name)
+
+	require.ErrorIs(t, repo.UpdatePasswordHash(999, "x"), ErrNotFound)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type AddonRepository struct {
+	db *gorm.DB
+}
+
+var _ AddonRepositoryInterface = (*AddonRepository)(nil)
+
+func NewAddonRepository(db *gorm.DB) *AddonRepository {
+	return &AddonRepository{db: db}
+}
+
+func (r *AddonRepository) Create(addon *models.Addon) error {
+	return translateError(r.db.Create(addon).Error)
+}
+
+func (r *AddonRepository) FindByID(id uint) (*models.Addon, error) {
+	var addon models.Addon
+	err := r.db.First(&addon, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &addon, nil
+}
+
+func (r *AddonRepository) FindByName(name string) (*models.Addon, error) {
+	var addon models.Addon
+	err := r.db.Where("name = ?", name).First(&addon).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &addon, nil
+}
+
+func (r *AddonRepository) FindAll() ([]models.Addon, error) {
+	var addons []models.Addon
+	err := r.db.Order("name").Find(&addons).Error
+	return addons, translateError(err)
+}
+
+func (r *AddonRepository) Update(addon *models.Addon) error {
+	return translateError(r.db.Save(addon).Error)
+}
+
+func (r *AddonRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Addon{}, id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
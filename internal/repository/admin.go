@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+type AdminRepository struct {
+	db *gorm.DB
+}
+
+var _ AdminRepositoryInterface = (*AdminRepository)(nil)
+
+func NewAdminRepository(db *gorm.DB) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+func (r *AdminRepository) Create(admin *models.Admin) error {
+	return translateError(r.db.Create(admin).Error)
+}
+
+func (r *AdminRepository) FindByID(id uint) (*models.Admin, error) {
+	var admin models.Admin
+	if err := r.db.First(&admin, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &admin, nil
+}
+
+func (r *AdminRepository) FindByEmail(email string) (*models.Admin, error) {
+	var admin models.Admin
+	if err := r.db.Where("email_hash = ?", pii.Hash(email)).First(&admin).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &admin, nil
+}
+
+func (r *AdminRepository) FindAll() ([]models.Admin, error) {
+	var admins []models.Admin
+	if err := r.db.Order("id").Find(&admins).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return admins, nil
+}
+
+func (r *AdminRepository) Update(admin *models.Admin) error {
+	return translateError(r.db.Save(admin).Error)
+}
+
+func (r *AdminRepository) UpdatePasswordHash(id uint, hash string) error {
+	result := r.db.Model(&models.Admin{}).Where("id = ?", id).Update("password_hash", hash)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ClaimTOTPStep records step as the last one a code was accepted for,
+// unless a code of that step or a later one was already accepted. It
+// reports whether the step was claimed, so two requests racing with the
+// same code cannot both get through.
+func (r *AdminRepository) ClaimTOTPStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&models.Admin{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected == 1, translateError(result.Error)
+}
+
+// SetSMSCode stores the hash of a code sent by SMS at sentAt, replacing
+// any earlier one.
+func (r *AdminRepository) SetSMSCode(id uint, hash string, sentAt time.Time) error {
+	result := r.db.Model(&models.Admin{}).Where("id = ?", id).
+		Updates(map[string]any{"sms_code_hash": hash, "sms_code_sent_at": sentAt})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ClaimSMSCode clears the admin's SMS code if it has hash and was sent
+// after since, and reports whether it did, so a code works only once.
+func (r *AdminRepository) ClaimSMSCode(id uint, hash string, since time.Time) (bool, error) {
+	result := r.db.Model(&models.Admin{}).
+		Where("id = ? AND sms_code_hash = ? AND sms_code_sent_at > ?", id, hash, since).
+		Updates(map[string]any{"sms_code_hash": "", "sms_code_sent_at": nil})
+	return result.RowsAffected == 1, translateError(result.Error)
+}
+
+// ReplaceBackupCodes drops the admin's backup codes, used or not, and
+// stores hashes in their place.
+func (r *AdminRepository) ReplaceBackupCodes(adminID uint, hashes []string) error {
+	return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("admin_id = ?", adminID).Delete(&models.AdminBackupCode{}).Error; err != nil {
+			return err
+		}
+		if len(hashes) == 0 {
+			return nil
+		}
+		codes := make([]models.AdminBackupCode, len(hashes))
+		for i, hash := range hashes {
+			codes[i] = models.AdminBackupCode{AdminID: adminID, CodeHash: hash}
+		}
+		return tx.Create(&codes).Error
+	}))
+}
+
+// UseBackupCode marks the unused code with hash used and reports whether
+// there was one.
+func (r *AdminRepository) UseBackupCode(adminID uint, hash string, at time.Time) (bool, error) {
+	result := r.db.Model(&models.AdminBackupCode{}).
+		Where("admin_id = ? AND code_hash = ? AND used_at IS NULL", adminID, hash).
+		Update("used_at", at)
+	return result.RowsAffected > 0, translateError(result.Error)
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrQuotaExhausted is returned by AddUsage when the request would go over
+// one of the key's quotas.
+var ErrQuotaExhausted = errors.New("api key quota exhausted")
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+var _ APIKeyRepositoryInterface = (*APIKeyRepository)(nil)
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(key *models.APIKey) error {
+	return translateError(r.db.Create(key).Error)
+}
+
+func (r *APIKeyRepository) FindByID(id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.First(&key, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) FindByKeyHash(hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.Where("key_hash = ?", hash).First(&key).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) FindAll() ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.Order("id").Find(&keys).Error
+	return keys, translateError(err)
+}
+
+func (r *APIKeyRepository) Update(key *models.APIKey) error {
+	return translateError(r.db.Save(key).Error)
+}
+
+func (r *APIKeyRepository) Revoke(id uint, at time.Time) error {
+	result := r.db.Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AddUsage counts one request with the key on day, unless the key has
+// used up its daily quota or, counting from monthStart, its monthly one; a
+// zero quota is unlimited. The check and the count are a single UPDATE, so
+// concurrent requests cannot go over a quota.
+func (r *APIKeyRepository) AddUsage(apiKeyID uint, day, monthStart string, dailyQuota, monthlyQuota int) error {
+	if dailyQuota == 0 && monthlyQuota == 0 {
+		err := r.db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "api_key_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"requests": gorm.Expr("api_key_usage.requests + 1")}),
+		}).Create(&models.APIKeyUsage{APIKeyID: apiKeyID, Day: day, Requests: 1}).Error
+		return translateError(err)
+	}
+
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.APIKeyUsage{APIKeyID: apiKeyID, Day: day}).Error
+	if err != nil {
+		return translateError(err)
+	}
+
+	query := r.db.Model(&models.APIKeyUsage{}).Where("api_key_id = ? AND day = ?", apiKeyID, day)
+	if dailyQuota > 0 {
+		query = query.Where("requests < ?", dailyQuota)
+	}
+	if monthlyQuota > 0 {
+		// Earlier days no longer change, so the row lock the UPDATE takes on
+		// today's count is enough to keep the monthly total in check.
+		query = query.Where(
+			"requests + (SELECT COALESCE(SUM(earlier.requests), 0) FROM api_key_usage AS earlier WHERE earlier.api_key_id = ? AND earlier.day >= ? AND earlier.day < ?) < ?",
+			apiKeyID, monthStart, day, monthlyQuota,
+		)
+	}
+	result := query.UpdateColumn("requests", gorm.Expr("requests + 1"))
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrQuotaExhausted
+	}
+	return nil
+}
+
+// FindUsage returns the key's daily counts from day since onwards, oldest
+// first.
+func (r *APIKeyRepository) FindUsage(apiKeyID uint, since string) ([]models.APIKeyUsage, error) {
+	var usage []models.APIKeyUsage
+	err := r.db.Where("api_key_id = ? AND day >= ?", apiKeyID, since).Order("day").Find(&usage).Error
+	return usage, translateError(err)
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepository_AddUsage(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAPIKeyRepository(db)
+	require.NoError(t, db.Create(&models.APIKeyUsage{APIKeyID: 1, Day: "2026-03-29", Requests: 4}).Error)
+	require.NoError(t, db.Create(&models.APIKeyUsage{APIKeyID: 1, Day: "2026-02-28", Requests: 9}).Error)
+
+	tests := []struct {
+		name          string
+		dailyQuota    int
+		monthlyQuota  int
+		expectedError error
+	}{
+		{name: "unlimited"},
+		{name: "under the daily quota", dailyQuota: 3},
+		{name: "daily quota used up", dailyQuota: 2, expectedError: ErrQuotaExhausted},
+		{name: "under the monthly quota", monthlyQuota: 7},
+		{name: "monthly quota counts earlier days", monthlyQuota: 6, expectedError: ErrQuotaExhausted},
+		{name: "tighter quota wins", dailyQuota: 10, monthlyQuota: 6, expectedError: ErrQuotaExhausted},
+	}
+
+	// Each case starts from two requests today and four earlier this month.
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, db.Save(&models.APIKeyUsage{APIKeyID: 1, Day: "2026-03-30", Requests: 2}).Error)
+
+			err := repo.AddUsage(1, "2026-03-30", "2026-03-01", tt.dailyQuota, tt.monthlyQuota)
+			require.ErrorIs(t, err, tt.expectedError)
+
+			usage, err := repo.FindUsage(1, "2026-03-30")
+			require.NoError(t, err)
+			expected := 3
+			if tt.expectedError != nil {
+				expected = 2
+			}
+			require.Equal(t, expected, usage[0].Requests)
+		})
+	}
+
+	// The first request of a day creates its row.
+	require.NoError(t, repo.AddUsage(1, "2026-03-31", "2026-03-01", 1, 0))
+	require.ErrorIs(t, repo.AddUsage(1, "2026-03-31", "2026-03-01", 1, 0), ErrQuotaExhausted)
+}
+
+func TestAPIKeyRepository_AddUsageConcurrently(t *testing.T) {
+	db := setupTestDB(t)
+	// Every connection to ":memory:" opens a new database, so share one.
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	repo := NewAPIKeyRepository(db)
+
+	const requests, quota = 20, 5
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		counted  int
+		rejected int
+	)
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.AddUsage(1, "2026-03-30", "2026-03-01", quota, quota)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				counted++
+			case errors.Is(err, ErrQuotaExhausted):
+				rejected++
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, quota, counted)
+	require.Equal(t, requests-quota, rejected)
+	usage, err := repo.FindUsage(1, "2026-03-01")
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	require.Equal(t, quota, usage[0].Requests)
+}
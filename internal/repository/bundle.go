@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type BundleRepository struct {
+	db *gorm.DB
+}
+
+var _ BundleRepositoryInterface = (*BundleRepository)(nil)
+
+func NewBundleRepository(db *gorm.DB) *BundleRepository {
+	return &BundleRepository{db: db}
+}
+
+func (r *BundleRepository) Create(bundle *models.Bundle) error {
+	return translateError(r.db.Create(bundle).Error)
+}
+
+func (r *BundleRepository) FindByID(id uint) (*models.Bundle, error) {
+	var bundle models.Bundle
+	err := r.db.Preload("Items").First(&bundle, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &bundle, nil
+}
+
+func (r *BundleRepository) FindByName(name string) (*models.Bundle, error) {
+	var bundle models.Bundle
+	err := r.db.Where("name = ?", name).First(&bundle).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &bundle, nil
+}
+
+func (r *BundleRepository) FindAll() ([]models.Bundle, error) {
+	var bundles []models.Bundle
+	err := r.db.Preload("Items").Order("name").Find(&bundles).Error
+	return bundles, translateError(err)
+}
+
+// Update saves the bundle and replaces its items with bundle.Items.
+func (r *BundleRepository) Update(bundle *models.Bundle) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items").Save(bundle).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("bundle_id = ?", bundle.ID).Delete(&models.BundleItem{}).Error; err != nil {
+			return err
+		}
+		for i := range bundle.Items {
+			bundle.Items[i].ID = 0
+			bundle.Items[i].BundleID = bundle.ID
+		}
+		if len(bundle.Items) == 0 {
+			return nil
+		}
+		return tx.Create(&bundle.Items).Error
+	})
+	return translateError(err)
+}
+
+func (r *BundleRepository) Delete(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Bundle{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Where("bundle_id = ?", id).Delete(&models.BundleItem{}).Error
+	})
+	return translateError(err)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrCouponLimitReached = errors.New("coupon usage limit reached")
+
+type CouponRepository struct {
+	db *gorm.DB
+}
+
+var _ CouponRepositoryInterface = (*CouponRepository)(nil)
+
+func NewCouponRepository(db *gorm.DB) *CouponRepository {
+	return &CouponRepository{db: db}
+}
+
+func (r *CouponRepository) Create(coupon *models.Coupon) error {
+	return translateError(r.db.Create(coupon).Error)
+}
+
+func (r *CouponRepository) FindByID(id uint) (*models.Coupon, error) {
+	var coupon models.Coupon
+	err := r.db.First(&coupon, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &coupon, nil
+}
+
+func (r *CouponRepository) FindByCode(code string) (*models.Coupon, error) {
+	var coupon models.Coupon
+	err := r.db.Where("code = ?", code).First(&coupon).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &coupon, nil
+}
+
+func (r *CouponRepository) FindAll() ([]models.Coupon, error) {
+	var coupons []models.Coupon
+	err := r.db.Find(&coupons).Error
+	return coupons, translateError(err)
+}
+
+func (r *CouponRepository) Update(coupon *models.Coupon) error {
+	return translateError(r.db.Save(coupon).Error)
+}
+
+func (r *CouponRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Coupon{}, id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// The conditional update keeps the usage limit safe under concurrent redemptions.
+func (r *CouponRepository) Redeem(redemption *models.CouponRedemption) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Coupon{}).
+			Where("id = ? AND (max_redemptions = 0 OR times_redeemed < max_redemptions)", redemption.CouponID).
+			UpdateColumn("times_redeemed", gorm.Expr("times_redeemed + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCouponLimitReached
+		}
+		return tx.Create(redemption).Error
+	})
+	return translateError(err)
+}
+
+func (r *CouponRepository) DeactivateExpired(at time.Time) (int64, error) {
+	result := r.db.Model(&models.Coupon{}).
+		Where("is_active = ? AND expires_at IS NOT NULL AND expires_at <= ?", true, at).
+		Update("is_active", false)
+	return result.RowsAffected, translateError(result.Error)
+}
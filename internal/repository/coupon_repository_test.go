@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCouponRepository_FindByCode(t *testing.T) {
+	tests := []struct {
+		name          string
+		code          string
+		setupCoupon   *models.Coupon
+		expectedError string
+	}{
+		{
+			name: "finds existing coupon",
+			code: "SWEET10",
+			setupCoupon: &models.Coupon{
+				Code:          "SWEET10",
+				DiscountType:  models.DiscountTypePercentage,
+				DiscountValue: 10,
+				IsActive:      true,
+			},
+		},
+		{
+			name:          "returns error for unknown code",
+			code:          "MISSING",
+			expectedError: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewCouponRepository(db)
+
+			if tt.setupCoupon != nil {
+				require.NoError(t, repo.Create(tt.setupCoupon))
+			}
+
+			coupon, err := repo.FindByCode(tt.code)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, coupon)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.code, coupon.Code)
+			}
+		})
+	}
+}
+
+func TestCouponRepository_Redeem(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxRedemptions  int
+		redemptions     int
+		expectedError   error
+		expectedRedeems int
+	}{
+		{
+			name:            "unlimited coupon accepts every redemption",
+			maxRedemptions:  0,
+			redemptions:     3,
+			expectedRedeems: 3,
+		},
+		{
+			name:            "redemption within limit",
+			maxRedemptions:  2,
+			redemptions:     2,
+			expectedRedeems: 2,
+		},
+		{
+			name:            "redemption beyond limit is rejected",
+			maxRedemptions:  1,
+			redemptions:     2,
+			expectedError:   ErrCouponLimitReached,
+			expectedRedeems: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewCouponRepository(db)
+
+			coupon := &models.Coupon{
+				Code:           "LIMITED",
+				DiscountType:   models.DiscountTypeFixed,
+				DiscountValue:  100,
+				MaxRedemptions: tt.maxRedemptions,
+				IsActive:       true,
+			}
+			require.NoError(t, repo.Create(coupon))
+
+			var err error
+			for i := 0; i < tt.redemptions; i++ {
+				err = repo.Redeem(&models.CouponRedemption{
+					CouponID:      coupon.ID,
+					OrderCents:    1000,
+					DiscountCents: 100,
+				})
+			}
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			stored, err := repo.FindByID(coupon.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedRedeems, stored.TimesRedeemed)
+
+			var count int64
+			require.NoError(t, db.Model(&models.CouponRedemption{}).Count(&count).Error)
+			require.Equal(t, int64(tt.expectedRedeems), count)
+		})
+	}
+}
+
+func TestCouponRepository_DeactivateExpired(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name           string
+		expiresAt      *time.Time
+		expectedRows   int64
+		expectedActive bool
+	}{
+		{name: "expired coupon", expiresAt: &past, expectedRows: 1, expectedActive: false},
+		{name: "coupon not yet expired", expiresAt: &future, expectedRows: 0, expectedActive: true},
+		{name: "coupon without expiry", expectedRows: 0, expectedActive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewCouponRepository(db)
+
+			coupon := &models.Coupon{
+				Code:          "SPRING10",
+				DiscountType:  models.DiscountTypePercentage,
+				DiscountValue: 10,
+				ExpiresAt:     tt.expiresAt,
+				IsActive:      true,
+			}
+			require.NoError(t, repo.Create(coupon))
+
+			rows, err := repo.DeactivateExpired(now)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedRows, rows)
+
+			found, err := repo.FindByID(coupon.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedActive, found.IsActive)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"strings"
+
 	"github.com/julimonteiro/cupcake-store/internal/models"
 	"gorm.io/gorm"
 )
@@ -16,41 +18,228 @@ func NewCupcakeRepository(db *gorm.DB) *CupcakeRepository {
 }
 
 func (r *CupcakeRepository) Create(cupcake *models.Cupcake) error {
-	return r.db.Create(cupcake).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(cupcake).Error; err != nil {
+			return err
+		}
+		if err := saveVersion(tx, cupcake); err != nil {
+			return err
+		}
+		return recordChange(tx, cupcake.ID, false)
+	})
+	return translateError(err)
 }
 
 func (r *CupcakeRepository) FindByID(id uint) (*models.Cupcake, error) {
 	var cupcake models.Cupcake
 	err := r.db.First(&cupcake, id).Error
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
+	}
+	return &cupcake, nil
+}
+
+func (r *CupcakeRepository) FindBySKU(sku string) (*models.Cupcake, error) {
+	var cupcake models.Cupcake
+	err := r.db.Where("sku = ?", sku).First(&cupcake).Error
+	if err != nil {
+		return nil, translateError(err)
 	}
 	return &cupcake, nil
 }
 
+func (r *CupcakeRepository) FindBySlug(slug string) (*models.Cupcake, error) {
+	var cupcake models.Cupcake
+	err := r.db.Where("slug = ?", slug).First(&cupcake).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &cupcake, nil
+}
+
+// FindAll returns every cupcake in display order, ties broken by ID.
 func (r *CupcakeRepository) FindAll() ([]models.Cupcake, error) {
 	var cupcakes []models.Cupcake
-	err := r.db.Find(&cupcakes).Error
-	return cupcakes, err
+	err := r.db.Order("display_order, id").Find(&cupcakes).Error
+	return cupcakes, translateError(err)
 }
 
-func (r *CupcakeRepository) Update(cupcake *models.Cupcake) error {
-	return r.db.Save(cupcake).Error
+// FindPage returns up to limit cupcakes in display order starting at
+// offset, along with the total number of cupcakes.
+func (r *CupcakeRepository) FindPage(offset, limit int) ([]models.Cupcake, int64, error) {
+	var total int64
+	if err := r.db.Model(&models.Cupcake{}).Count(&total).Error; err != nil {
+		return nil, 0, translateError(err)
+	}
+
+	var cupcakes []models.Cupcake
+	err := r.db.Order("display_order, id").Offset(offset).Limit(limit).Find(&cupcakes).Error
+	return cupcakes, total, translateError(err)
 }
 
-func (r *CupcakeRepository) Delete(id uint) error {
-	result := r.db.Delete(&models.Cupcake{}, id)
-	if result.Error != nil {
-		return result.Error
+// Stream calls fn for every cupcake in ID order, scanning rows from a cursor
+// so the whole table is never held in memory. It stops at the first error
+// returned by fn.
+func (r *CupcakeRepository) Stream(fn func(*models.Cupcake) error) error {
+	rows, err := r.db.Model(&models.Cupcake{}).Order("id").Rows()
+	if err != nil {
+		return translateError(err)
 	}
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+	defer rows.Close()
+
+	for rows.Next() {
+		var cupcake models.Cupcake
+		if err := r.db.ScanRows(rows, &cupcake); err != nil {
+			return translateError(err)
+		}
+		if err := fn(&cupcake); err != nil {
+			return err
+		}
 	}
-	return nil
+	return translateError(rows.Err())
+}
+
+func (r *CupcakeRepository) FindByFlavor(flavor string) ([]models.Cupcake, error) {
+	var cupcakes []models.Cupcake
+	err := r.db.Where("LOWER(flavor) = LOWER(?)", flavor).Find(&cupcakes).Error
+	return cupcakes, translateError(err)
+}
+
+// Search returns the available cupcakes whose name, flavor or SKU contains
+// text, ignoring case, ordered by name. It backs catalog search when no
+// search index is available.
+func (r *CupcakeRepository) Search(text string) ([]models.Cupcake, error) {
+	var cupcakes []models.Cupcake
+	pattern := "%" + strings.ToLower(text) + "%"
+	err := r.db.Where("is_available = ?", true).
+		Where("LOWER(name) LIKE ? OR LOWER(flavor) LIKE ? OR LOWER(sku) LIKE ?", pattern, pattern, pattern).
+		Order("name").Find(&cupcakes).Error
+	return cupcakes, translateError(err)
+}
+
+// FindRelated returns up to limit available cupcakes with the same flavor as
+// the cupcake id, excluding it, in a single query.
+func (r *CupcakeRepository) FindRelated(id uint, limit int) ([]models.Cupcake, error) {
+	var cupcakes []models.Cupcake
+	flavor := r.db.Model(&models.Cupcake{}).Select("LOWER(flavor)").Where("id = ?", id)
+	err := r.db.Where("LOWER(flavor) = (?) AND id <> ? AND is_available = ?", flavor, id, true).
+		Order("id").Limit(limit).Find(&cupcakes).Error
+	return cupcakes, translateError(err)
+}
+
+func (r *CupcakeRepository) Update(cupcake *models.Cupcake) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(cupcake).Error; err != nil {
+			return err
+		}
+		if err := saveVersion(tx, cupcake); err != nil {
+			return err
+		}
+		return recordChange(tx, cupcake.ID, false)
+	})
+	return translateError(err)
+}
+
+func (r *CupcakeRepository) UpdatePrices(cupcakes []models.Cupcake) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, cupcake := range cupcakes {
+			err := tx.Model(&models.Cupcake{}).
+				Where("id = ?", cupcake.ID).
+				Updates(map[string]interface{}{"price_cents": cupcake.PriceCents, "updated_by": cupcake.UpdatedBy}).Error
+			if err != nil {
+				return err
+			}
+			if err := saveVersion(tx, &cupcake); err != nil {
+				return err
+			}
+			if err := recordChange(tx, cupcake.ID, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return translateError(err)
+}
+
+func (r *CupcakeRepository) Delete(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Cupcake{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return recordChange(tx, id, true)
+	})
+	return translateError(err)
 }
 
 func (r *CupcakeRepository) Exists(id uint) (bool, error) {
 	var count int64
 	err := r.db.Model(&models.Cupcake{}).Where("id = ?", id).Count(&count).Error
-	return count > 0, err
+	return count > 0, translateError(err)
+}
+
+func (r *CupcakeRepository) FindVersions(cupcakeID uint) ([]models.CupcakeVersion, error) {
+	var versions []models.CupcakeVersion
+	err := r.db.Where("cupcake_id = ?", cupcakeID).Order("version DESC").Find(&versions).Error
+	return versions, translateError(err)
+}
+
+func (r *CupcakeRepository) FindVersion(cupcakeID uint, version int) (*models.CupcakeVersion, error) {
+	var snapshot models.CupcakeVersion
+	err := r.db.Where("cupcake_id = ? AND version = ?", cupcakeID, version).First(&snapshot).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &snapshot, nil
+}
+
+// FindChanges returns up to limit change log entries after the cursor
+// since, oldest first.
+func (r *CupcakeRepository) FindChanges(since uint, limit int) ([]models.CupcakeChange, error) {
+	var changes []models.CupcakeChange
+	err := r.db.Where("id > ?", since).Order("id").Limit(limit).Find(&changes).Error
+	return changes, translateError(err)
+}
+
+// LastChangeID is the cursor of the newest change, 0 when there is none.
+func (r *CupcakeRepository) LastChangeID() (uint, error) {
+	var last uint
+	err := r.db.Model(&models.CupcakeChange{}).Select("COALESCE(MAX(id), 0)").Scan(&last).Error
+	return last, translateError(err)
+}
+
+// saveVersion appends a snapshot of cupcake as its next version. It runs in
+// the same transaction as the write it records.
+func saveVersion(tx *gorm.DB, cupcake *models.Cupcake) error {
+	var last int
+	err := tx.Model(&models.CupcakeVersion{}).
+		Where("cupcake_id = ?", cupcake.ID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&last).Error
+	if err != nil {
+		return translateError(err)
+	}
+
+	return tx.Create(&models.CupcakeVersion{
+		CupcakeID:     cupcake.ID,
+		Version:       last + 1,
+		Name:          cupcake.Name,
+		Flavor:        cupcake.Flavor,
+		SKU:           cupcake.SKU,
+		Slug:          cupcake.Slug,
+		Description:   cupcake.Description,
+		DisplayOrder:  cupcake.DisplayOrder,
+		PriceCents:    cupcake.PriceCents,
+		IsAvailable:   cupcake.IsAvailable,
+		AvailableFrom: cupcake.AvailableFrom,
+	}).Error
+}
+
+// recordChange appends to the change log read by offline sync clients. It
+// runs in the same transaction as the write it records.
+func recordChange(tx *gorm.DB, cupcakeID uint, deleted bool) error {
+	return tx.Create(&models.CupcakeChange{CupcakeID: cupcakeID, Deleted: deleted}).Error
 }
@@ -1,21 +1,19 @@
 package repository
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/testutil"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 func setupTestDB(t *testing.T) *gorm.DB {
 	t.Helper()
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
-	err = db.AutoMigrate(&models.Cupcake{})
-	require.NoError(t, err)
-	return db
+	return testutil.NewDB(t)
 }
 
 func TestNewCupcakeRepository(t *testing.T) {
@@ -116,7 +114,7 @@ func TestCupcakeRepository_FindByID(t *testing.T) {
 		{
 			name:          "returns error for non-existent cupcake",
 			cupcakeID:     999,
-			expectedError: "record not found",
+			expectedError: "not found",
 		},
 	}
 
@@ -222,6 +220,84 @@ func TestCupcakeRepository_FindAll(t *testing.T) {
 	}
 }
 
+func TestCupcakeRepository_FindPage(t *testing.T) {
+	tests := []struct {
+		name          string
+		offset        int
+		limit         int
+		expectedNames []string
+	}{
+		{name: "first page", offset: 0, limit: 2, expectedNames: []string{"Cupcake 1", "Cupcake 2"}},
+		{name: "last partial page", offset: 4, limit: 2, expectedNames: []string{"Cupcake 5"}},
+		{name: "past the end", offset: 10, limit: 2, expectedNames: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewCupcakeRepository(db)
+
+			for _, cupcake := range factory.Cupcakes(5) {
+				require.NoError(t, repo.Create(&cupcake))
+			}
+
+			cupcakes, total, err := repo.FindPage(tt.offset, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, int64(5), total)
+
+			var names []string
+			for _, cupcake := range cupcakes {
+				names = append(names, cupcake.Name)
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestCupcakeRepository_Stream(t *testing.T) {
+	tests := []struct {
+		name          string
+		count         int
+		stopAfter     int
+		expectedError bool
+		expectedSeen  int
+	}{
+		{name: "empty table", count: 0, expectedSeen: 0},
+		{name: "visits every row", count: 5, expectedSeen: 5},
+		{name: "stops on callback error", count: 5, stopAfter: 2, expectedError: true, expectedSeen: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewCupcakeRepository(db)
+
+			for _, cupcake := range factory.Cupcakes(tt.count) {
+				require.NoError(t, repo.Create(&cupcake))
+			}
+
+			var ids []uint
+			err := repo.Stream(func(cupcake *models.Cupcake) error {
+				ids = append(ids, cupcake.ID)
+				if tt.stopAfter > 0 && len(ids) == tt.stopAfter {
+					return errors.New("stop")
+				}
+				return nil
+			})
+
+			if tt.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Len(t, ids, tt.expectedSeen)
+			for i, id := range ids {
+				require.Equal(t, uint(i+1), id)
+			}
+		})
+	}
+}
+
 func TestCupcakeRepository_Update(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -315,7 +391,7 @@ func TestCupcakeRepository_Delete(t *testing.T) {
 		{
 			name:          "returns error for non-existent cupcake",
 			cupcakeID:     999,
-			expectedError: "record not found",
+			expectedError: "not found",
 		},
 	}
 
@@ -393,3 +469,145 @@ func TestCupcakeRepository_Exists(t *testing.T) {
 		})
 	}
 }
+
+func TestCupcakeRepository_Versions(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCupcakeRepository(db)
+
+	cupcake := &models.Cupcake{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1000, IsAvailable: true}
+	require.NoError(t, repo.Create(cupcake))
+
+	cupcake.Name = "Dark Chocolate"
+	require.NoError(t, repo.Update(cupcake))
+
+	cupcake.PriceCents = 1200
+	admin := uint(4)
+	cupcake.UpdatedBy = &admin
+	require.NoError(t, repo.UpdatePrices([]models.Cupcake{*cupcake}))
+
+	stored, err := repo.FindByID(cupcake.ID)
+	require.NoError(t, err)
+	require.Equal(t, &admin, stored.UpdatedBy)
+
+	versions, err := repo.FindVersions(cupcake.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	require.Equal(t, 3, versions[0].Version)
+	require.Equal(t, 1200, versions[0].PriceCents)
+	require.Equal(t, "Dark Chocolate", versions[1].Name)
+	require.Equal(t, "Chocolate", versions[2].Name)
+
+	first, err := repo.FindVersion(cupcake.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1000, first.PriceCents)
+
+	_, err = repo.FindVersion(cupcake.ID, 9)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCupcakeRepository_Changes(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCupcakeRepository(db)
+
+	last, err := repo.LastChangeID()
+	require.NoError(t, err)
+	require.Zero(t, last)
+
+	cupcake := &models.Cupcake{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1000, IsAvailable: true}
+	require.NoError(t, repo.Create(cupcake))
+	cupcake.PriceCents = 1200
+	require.NoError(t, repo.Update(cupcake))
+	require.NoError(t, repo.Delete(cupcake.ID))
+	require.ErrorIs(t, repo.Delete(cupcake.ID), ErrNotFound)
+
+	changes, err := repo.FindChanges(0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	require.False(t, changes[0].Deleted)
+	require.False(t, changes[1].Deleted)
+	require.True(t, changes[2].Deleted)
+	require.Equal(t, cupcake.ID, changes[2].CupcakeID)
+
+	page, err := repo.FindChanges(changes[0].ID, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, changes[1].ID, page[0].ID)
+
+	last, err = repo.LastChangeID()
+	require.NoError(t, err)
+	require.Equal(t, changes[2].ID, last)
+}
+
+func TestCupcakeRepository_FindRelated(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          uint
+		limit       int
+		expectedIDs []uint
+	}{
+		{name: "same flavor in any case, available only", id: 1, limit: 10, expectedIDs: []uint{2, 5}},
+		{name: "limited", id: 1, limit: 1, expectedIDs: []uint{2}},
+		{name: "no other cupcake with the flavor", id: 3, limit: 10, expectedIDs: []uint{}},
+		{name: "missing cupcake", id: 99, limit: 10, expectedIDs: []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewCupcakeRepository(setupTestDB(t))
+			cupcakes := []models.Cupcake{
+				factory.Cupcake(factory.WithName("Vanilla"), factory.WithFlavor("Vanilla")),
+				factory.Cupcake(factory.WithName("Vanilla Bean"), factory.WithFlavor("vanilla")),
+				factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Chocolate")),
+				factory.Cupcake(factory.WithName("Vanilla Sold Out"), factory.WithFlavor("Vanilla"), factory.Unavailable()),
+				factory.Cupcake(factory.WithName("Vanilla Berry"), factory.WithFlavor("Vanilla")),
+			}
+			for _, cupcake := range cupcakes {
+				require.NoError(t, repo.Create(&cupcake))
+			}
+
+			related, err := repo.FindRelated(tt.id, tt.limit)
+			require.NoError(t, err)
+			ids := []uint{}
+			for _, cupcake := range related {
+				ids = append(ids, cupcake.ID)
+			}
+			require.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestCupcakeRepository_Search(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expectedNames []string
+	}{
+		{name: "name or flavor, any case", text: "CHOC", expectedNames: []string{"Chocolate", "Mint Chip"}},
+		{name: "SKU", text: "vnl", expectedNames: []string{"Vanilla"}},
+		{name: "empty text lists available cupcakes", text: "", expectedNames: []string{"Chocolate", "Mint Chip", "Vanilla"}},
+		{name: "no match", text: "lemon", expectedNames: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewCupcakeRepository(setupTestDB(t))
+			cupcakes := []models.Cupcake{
+				factory.Cupcake(factory.WithName("Vanilla"), factory.WithFlavor("Vanilla"), factory.WithSKU("VNL-001")),
+				factory.Cupcake(factory.WithName("Mint Chip"), factory.WithFlavor("Chocolate Mint")),
+				factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Cocoa")),
+				factory.Cupcake(factory.WithName("Chocolate Sold Out"), factory.WithFlavor("Cocoa"), factory.Unavailable()),
+			}
+			for _, cupcake := range cupcakes {
+				require.NoError(t, repo.Create(&cupcake))
+			}
+
+			found, err := repo.Search(tt.text)
+			require.NoError(t, err)
+			names := []string{}
+			for _, cupcake := range found {
+				names = append(names, cupcake.Name)
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type CustomOptionRepository struct {
+	db *gorm.DB
+}
+
+var _ CustomOptionRepositoryInterface = (*CustomOptionRepository)(nil)
+
+func NewCustomOptionRepository(db *gorm.DB) *CustomOptionRepository {
+	return &CustomOptionRepository{db: db}
+}
+
+func (r *CustomOptionRepository) Create(option *models.CustomOption) error {
+	return translateError(r.db.Create(option).Error)
+}
+
+func (r *CustomOptionRepository) FindByID(id uint) (*models.CustomOption, error) {
+	var option models.CustomOption
+	err := r.db.First(&option, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &option, nil
+}
+
+func (r *CustomOptionRepository) FindByIDs(ids []uint) ([]models.CustomOption, error) {
+	var options []models.CustomOption
+	if len(ids) == 0 {
+		return options, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&options).Error
+	return options, translateError(err)
+}
+
+func (r *CustomOptionRepository) FindAll() ([]models.CustomOption, error) {
+	var options []models.CustomOption
+	err := r.db.Order("kind, name").Find(&options).Error
+	return options, translateError(err)
+}
+
+func (r *CustomOptionRepository) Update(option *models.CustomOption) error {
+	return translateError(r.db.Save(option).Error)
+}
+
+func (r *CustomOptionRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.CustomOption{}, id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+type DataExportRepository struct {
+	db *gorm.DB
+}
+
+var _ DataExportRepositoryInterface = (*DataExportRepository)(nil)
+
+func NewDataExportRepository(db *gorm.DB) *DataExportRepository {
+	return &DataExportRepository{db: db}
+}
+
+func (r *DataExportRepository) Create(export *models.DataExport) error {
+	return translateError(r.db.Create(export).Error)
+}
+
+func (r *DataExportRepository) FindByID(id uint) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := r.db.First(&export, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &export, nil
+}
+
+// FindPending returns the export of email still being built, if any.
+func (r *DataExportRepository) FindPending(email string) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.db.Omit("archive").
+		Where("customer_email_hash = ? AND status = ?", pii.Hash(email), models.DataExportPending).
+		Order("id DESC").
+		First(&export).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &export, nil
+}
+
+func (r *DataExportRepository) Update(export *models.DataExport) error {
+	return translateError(r.db.Save(export).Error)
+}
+
+// DeleteExpired drops the exports whose link expired before now, archive
+// and all.
+func (r *DataExportRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.DataExport{})
+	return result.RowsAffected, translateError(result.Error)
+}
+
+// FindCustomerData gathers what is stored under email, compared without
+// regard to case since it was typed in by the customer each time.
+func (r *DataExportRepository) FindCustomerData(email string) (*models.CustomerData, error) {
+	data := &models.CustomerData{Profile: models.CustomerProfile{Email: email}}
+	hash := pii.Hash(email)
+
+	err := r.db.Where("customer_email_hash = ?", hash).Order("id").Find(&data.Subscriptions).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	err = r.db.Preload("Items").Where("customer_email_hash = ?", hash).Order("id").Find(&data.PickupReservations).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	err = r.db.Where("customer_email_hash = ?", hash).Order("id").Find(&data.Tickets).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	var accounts []models.Account
+	if err := r.db.Where("email_hash = ?", hash).Limit(1).Find(&accounts).Error; err != nil {
+		return nil, translateError(err)
+	}
+	if len(accounts) > 0 {
+		data.Account = &accounts[0]
+		if err := r.db.Where("account_id = ?", accounts[0].ID).Order("provider").Find(&data.AccountIdentities).Error; err != nil {
+			return nil, translateError(err)
+		}
+		if err := r.db.Where("account_id = ?", accounts[0].ID).Order("event").Find(&data.NotificationPreferences).Error; err != nil {
+			return nil, translateError(err)
+		}
+		if err := r.db.Where("account_id = ?", accounts[0].ID).Order("id").Find(&data.DeviceTokens).Error; err != nil {
+			return nil, translateError(err)
+		}
+	}
+
+	var wholesale []models.WholesaleAccount
+	if err := r.db.Where("email_hash = ?", hash).Limit(1).Find(&wholesale).Error; err != nil {
+		return nil, translateError(err)
+	}
+	if len(wholesale) > 0 {
+		data.WholesaleAccount = &wholesale[0]
+	}
+	return data, nil
+}
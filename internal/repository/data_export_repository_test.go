@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExportRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewDataExportRepository(db)
+
+	require.NoError(t, db.Create(&models.Subscription{CustomerEmail: "Ana@Example.com", CupcakeID: 1, Quantity: 1, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: time.Now()}).Error)
+	require.NoError(t, db.Create(&models.WholesaleAccount{Name: "Ana Café", Email: "ana@example.com"}).Error)
+
+	data, err := repo.FindCustomerData("ANA@example.com")
+	require.NoError(t, err)
+	require.Len(t, data.Subscriptions, 1)
+	require.Empty(t, data.PickupReservations)
+	require.NotNil(t, data.WholesaleAccount)
+	require.Equal(t, "Ana Café", data.WholesaleAccount.Name)
+
+	export := &models.DataExport{CustomerEmail: "ana@example.com", Status: models.DataExportPending}
+	require.NoError(t, repo.Create(export))
+	pending, err := repo.FindPending("Ana@example.com")
+	require.NoError(t, err)
+	require.Equal(t, export.ID, pending.ID)
+
+	expiresAt := time.Now().Add(-time.Minute)
+	export.Status = models.DataExportReady
+	export.ExpiresAt = &expiresAt
+	require.NoError(t, repo.Update(export))
+	_, err = repo.FindPending("ana@example.com")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	deleted, err := repo.DeleteExpired(time.Now())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	_, err = repo.FindByID(export.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+}
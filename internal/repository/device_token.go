@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceTokenRepository struct {
+	db *gorm.DB
+}
+
+var _ DeviceTokenRepositoryInterface = (*DeviceTokenRepository)(nil)
+
+func NewDeviceTokenRepository(db *gorm.DB) *DeviceTokenRepository {
+	return &DeviceTokenRepository{db: db}
+}
+
+func (r *DeviceTokenRepository) FindByAccount(accountID uint) ([]models.DeviceToken, error) {
+	var devices []models.DeviceToken
+	err := r.db.Where("account_id = ?", accountID).Order("id").Find(&devices).Error
+	return devices, translateError(err)
+}
+
+// Save registers device, or moves its token to device's account when it
+// is already registered, as happens when someone else logs in on the
+// device. device is reloaded with the stored row.
+func (r *DeviceTokenRepository) Save(device *models.DeviceToken) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.AssignmentColumns([]string{"account_id", "platform", "updated_at"}),
+	}).Create(device).Error
+	if err != nil {
+		return translateError(err)
+	}
+	return translateError(r.db.Where("token = ?", device.Token).First(device).Error)
+}
+
+// Delete unregisters token from the account; ErrNotFound when the account
+// has no such token.
+func (r *DeviceTokenRepository) Delete(accountID uint, token string) error {
+	result := r.db.Where("account_id = ? AND token = ?", accountID, token).Delete(&models.DeviceToken{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteToken drops a token the push provider no longer accepts, whoever
+// it belongs to. One already gone is not an error.
+func (r *DeviceTokenRepository) DeleteToken(token string) error {
+	return translateError(r.db.Where("token = ?", token).Delete(&models.DeviceToken{}).Error)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceTokenRepository(t *testing.T) {
+	repo := NewDeviceTokenRepository(setupTestDB(t))
+
+	phone := &models.DeviceToken{AccountID: 1, Token: "token-a", Platform: models.PlatformAndroid}
+	require.NoError(t, repo.Save(phone))
+	require.NotZero(t, phone.ID)
+	require.NoError(t, repo.Save(&models.DeviceToken{AccountID: 1, Token: "token-b", Platform: models.PlatformWeb}))
+
+	// A token registered again moves to the account that registered it.
+	moved := &models.DeviceToken{AccountID: 2, Token: "token-a", Platform: models.PlatformIOS}
+	require.NoError(t, repo.Save(moved))
+	require.Equal(t, phone.ID, moved.ID)
+
+	devices, err := repo.FindByAccount(1)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	require.Equal(t, "token-b", devices[0].Token)
+	devices, err = repo.FindByAccount(2)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	require.Equal(t, models.PlatformIOS, devices[0].Platform)
+
+	// Accounts only unregister their own tokens.
+	require.ErrorIs(t, repo.Delete(1, "token-a"), ErrNotFound)
+	require.NoError(t, repo.Delete(1, "token-b"))
+	require.ErrorIs(t, repo.Delete(1, "token-b"), ErrNotFound)
+
+	require.NoError(t, repo.DeleteToken("token-a"))
+	require.NoError(t, repo.DeleteToken("token-a"))
+	devices, err = repo.FindByAccount(2)
+	require.NoError(t, err)
+	require.Empty(t, devices)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmailTemplateRepository struct {
+	db *gorm.DB
+}
+
+var _ EmailTemplateRepositoryInterface = (*EmailTemplateRepository)(nil)
+
+func NewEmailTemplateRepository(db *gorm.DB) *EmailTemplateRepository {
+	return &EmailTemplateRepository{db: db}
+}
+
+// FindAll returns the overrides for every store and, unless locationID is
+// 0, those for that location.
+func (r *EmailTemplateRepository) FindAll(locationID uint) ([]models.EmailTemplate, error) {
+	var templates []models.EmailTemplate
+	err := r.db.Where("location_id IN ?", []uint{0, locationID}).Order("name, location_id").Find(&templates).Error
+	return templates, translateError(err)
+}
+
+// Set creates or replaces the override for the template's name and
+// location.
+func (r *EmailTemplateRepository) Set(template *models.EmailTemplate) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}, {Name: "location_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"subject", "body", "updated_at"}),
+	}).Create(template).Error
+	return translateError(err)
+}
+
+func (r *EmailTemplateRepository) Delete(name string, locationID uint) error {
+	result := r.db.Where("name = ? AND location_id = ?", name, locationID).Delete(&models.EmailTemplate{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+)
+
+// erasedName replaces the names given with erased records.
+const erasedName = "[erased]"
+
+type ErasureRepository struct {
+	db *gorm.DB
+}
+
+var _ ErasureRepositoryInterface = (*ErasureRepository)(nil)
+
+func NewErasureRepository(db *gorm.DB) *ErasureRepository {
+	return &ErasureRepository{db: db}
+}
+
+// Erase anonymizes everything stored under email and records erasure, in
+// one transaction. Subscriptions, pickup reservations and tickets are kept
+// for the books with their names and email replaced by a placeholder
+// unique to the erasure, the reservations' phone and the tickets' subject
+// and message blanked out; active subscriptions are cancelled. Data
+// exports, the customer's account with its social logins, sessions,
+// notification preferences and device tokens, and the webhook deliveries
+// and queued jobs whose payload holds the email are deleted outright. The counts of the
+// customer's records touched are set on erasure.
+func (r *ErasureRepository) Erase(email string, erasure *models.Erasure) error {
+	return translateError(r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(erasure).Error; err != nil {
+			return err
+		}
+		hash := pii.Hash(email)
+		placeholder := fmt.Sprintf("erased-%d@erased.invalid", erasure.ID)
+		placeholderHash := pii.Hash(placeholder)
+		// Map updates skip the pii serializer, so the values are sealed
+		// here to be stored like the rest.
+		sealedPlaceholder, err := pii.Encrypt(placeholder)
+		if err != nil {
+			return err
+		}
+		sealedName, err := pii.Encrypt(erasedName)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Subscription{}).
+			Where("customer_email_hash = ?", hash).
+			Updates(map[string]any{"customer_email": sealedPlaceholder, "customer_email_hash": placeholderHash, "status": models.SubscriptionCancelled})
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.Subscriptions = result.RowsAffected
+
+		result = tx.Model(&models.PickupReservation{}).
+			Where("customer_email_hash = ?", hash).
+			Updates(map[string]any{"customer_name": sealedName, "customer_email": sealedPlaceholder, "customer_email_hash": placeholderHash, "customer_phone": ""})
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.PickupReservations = result.RowsAffected
+
+		result = tx.Model(&models.Ticket{}).
+			Where("customer_email_hash = ?", hash).
+			Updates(map[string]any{"customer_email": sealedPlaceholder, "customer_email_hash": placeholderHash, "subject": erasedName, "message": sealedName})
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.Tickets = result.RowsAffected
+
+		result = tx.Model(&models.WholesaleAccount{}).
+			Where("email_hash = ?", hash).
+			Updates(map[string]any{"name": erasedName, "email": sealedPlaceholder, "email_hash": placeholderHash})
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.WholesaleAccounts = result.RowsAffected
+
+		result = tx.Where("customer_email_hash = ?", hash).Delete(&models.DataExport{})
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.DataExports = result.RowsAffected
+
+		accountIDs := tx.Model(&models.Account{}).Select("id").Where("email_hash = ?", hash)
+		if err := tx.Where("account_id IN (?)", accountIDs).Delete(&models.AccountIdentity{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("account_id IN (?)", accountIDs).Delete(&models.Session{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("account_id IN (?)", accountIDs).Delete(&models.NotificationPreference{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("account_id IN (?)", accountIDs).Delete(&models.DeviceToken{}).Error; err != nil {
+			return err
+		}
+		result = tx.Where("email_hash = ?", hash).Delete(&models.Account{})
+		if result.Error != nil {
+			return result.Error
+		}
+		erasure.Accounts = result.RowsAffected
+
+		// Event payloads are stored as sent, with the email in plain text.
+		mentions := "%" + escapeLike(strings.ToLower(email)) + "%"
+		if err := tx.Where(`LOWER(payload) LIKE ? ESCAPE '\'`, mentions).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where(`LOWER(payload) LIKE ? ESCAPE '\'`, mentions).Delete(&models.Job{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Save(erasure).Error
+	}))
+}
+
+func (r *ErasureRepository) FindAll() ([]models.Erasure, error) {
+	var erasures []models.Erasure
+	if err := r.db.Order("id DESC").Find(&erasures).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return erasures, nil
+}
+
+func (r *ErasureRepository) FindByEmailHash(hash string) ([]models.Erasure, error) {
+	var erasures []models.Erasure
+	if err := r.db.Where("email_hash = ?", hash).Order("id DESC").Find(&erasures).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return erasures, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s, for a pattern with
+// ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErasureRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewErasureRepository(db)
+
+	subscription := &models.Subscription{CustomerEmail: "Ana@Example.com", CupcakeID: 1, Quantity: 2, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: time.Now()}
+	require.NoError(t, db.Create(subscription).Error)
+	require.NoError(t, db.Create(&models.Subscription{CustomerEmail: "bruno@example.com", CupcakeID: 1, Quantity: 1, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: time.Now()}).Error)
+	reservation := &models.PickupReservation{SlotID: 1, CustomerName: "Ana Lima", CustomerEmail: "ana@example.com", Items: []models.PickupReservationItem{{CupcakeID: 1, Quantity: 3}}}
+	require.NoError(t, db.Create(reservation).Error)
+	ticket := &models.Ticket{OrderType: models.TicketOrderPickup, OrderID: reservation.ID, CustomerEmail: "ana@example.com", Subject: "Caixa amassada", Message: "Sou a Ana, 11 99999-0000", Status: models.TicketOpen}
+	require.NoError(t, db.Create(ticket).Error)
+	require.NoError(t, db.Create(&models.WholesaleAccount{Name: "Ana Café", Email: "ana@example.com"}).Error)
+	require.NoError(t, db.Create(&models.DataExport{CustomerEmail: "ana@example.com", Status: models.DataExportPending}).Error)
+	account := &models.Account{Name: "Ana Lima", Email: "ana@example.com", PasswordHash: "hash"}
+	require.NoError(t, db.Create(account).Error)
+	require.NoError(t, db.Create(&models.NotificationPreference{AccountID: account.ID, Event: models.EventTicketStatusChanged, SMS: true}).Error)
+	require.NoError(t, db.Create(&models.DeviceToken{AccountID: account.ID, Token: "fcm-token", Platform: models.PlatformAndroid}).Error)
+	// Event payloads keep the email as it was sent, in any case.
+	require.NoError(t, db.Create(&models.WebhookDelivery{WebhookID: 1, Event: models.EventTicketStatusChanged, Payload: `{"data":{"customer_email":"ANA@example.com"}}`, Attempt: 1}).Error)
+	require.NoError(t, db.Create(&models.WebhookDelivery{WebhookID: 1, Event: models.EventTicketStatusChanged, Payload: `{"data":{"customer_email":"bruno@example.com"}}`, Attempt: 1}).Error)
+	require.NoError(t, db.Create(&models.Job{Type: "webhook.deliver", Payload: `{"event":"ticket.status_changed","body":{"data":{"customer_email":"ana@example.com"}}}`, Status: models.JobPending, MaxAttempts: 5, RunAt: time.Now()}).Error)
+	require.NoError(t, db.Create(&models.Job{Type: "webhook.deliver", Payload: `{"event":"ticket.status_changed","body":{"data":{"customer_email":"bruno@example.com"}}}`, Status: models.JobPending, MaxAttempts: 5, RunAt: time.Now()}).Error)
+
+	erasure := &models.Erasure{EmailHash: "hash", RequestedBy: models.ErasureByAdmin}
+	require.NoError(t, repo.Erase("ana@example.com", erasure))
+	require.Equal(t, int64(1), erasure.Subscriptions)
+	require.Equal(t, int64(1), erasure.PickupReservations)
+	require.Equal(t, int64(1), erasure.Tickets)
+	require.Equal(t, int64(1), erasure.WholesaleAccounts)
+	require.Equal(t, int64(1), erasure.DataExports)
+	require.Equal(t, int64(1), erasure.Accounts)
+
+	var erasedSubscription models.Subscription
+	require.NoError(t, db.First(&erasedSubscription, subscription.ID).Error)
+	require.Equal(t, "erased-1@erased.invalid", erasedSubscription.CustomerEmail)
+	require.Equal(t, models.SubscriptionCancelled, erasedSubscription.Status)
+	require.Equal(t, 2, erasedSubscription.Quantity)
+
+	var erasedReservation models.PickupReservation
+	require.NoError(t, db.Preload("Items").First(&erasedReservation, reservation.ID).Error)
+	require.Equal(t, "[erased]", erasedReservation.CustomerName)
+	require.Equal(t, "erased-1@erased.invalid", erasedReservation.CustomerEmail)
+	require.Len(t, erasedReservation.Items, 1)
+
+	var erasedTicket models.Ticket
+	require.NoError(t, db.First(&erasedTicket, ticket.ID).Error)
+	require.Equal(t, "erased-1@erased.invalid", erasedTicket.CustomerEmail)
+	require.Equal(t, "[erased]", erasedTicket.Message)
+	require.Equal(t, reservation.ID, erasedTicket.OrderID)
+
+	var remaining int64
+	require.NoError(t, db.Model(&models.Subscription{}).Where("customer_email = ?", "bruno@example.com").Count(&remaining).Error)
+	require.Equal(t, int64(1), remaining)
+	require.NoError(t, db.Model(&models.NotificationPreference{}).Where("account_id = ?", account.ID).Count(&remaining).Error)
+	require.Zero(t, remaining)
+	require.NoError(t, db.Model(&models.DeviceToken{}).Where("account_id = ?", account.ID).Count(&remaining).Error)
+	require.Zero(t, remaining)
+	var deliveries []models.WebhookDelivery
+	require.NoError(t, db.Find(&deliveries).Error)
+	require.Len(t, deliveries, 1)
+	require.Contains(t, deliveries[0].Payload, "bruno@example.com")
+	var jobs []models.Job
+	require.NoError(t, db.Find(&jobs).Error)
+	require.Len(t, jobs, 1)
+	require.Contains(t, jobs[0].Payload, "bruno@example.com")
+
+	// A second erasure gets a placeholder of its own, which the unique
+	// wholesale email requires.
+	require.NoError(t, db.Create(&models.WholesaleAccount{Name: "Bruno", Email: "bruno@example.com"}).Error)
+	second := &models.Erasure{EmailHash: "other", RequestedBy: models.ErasureByCustomer}
+	require.NoError(t, repo.Erase("bruno@example.com", second))
+	require.Equal(t, int64(1), second.WholesaleAccounts)
+
+	all, err := repo.FindAll()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, second.ID, all[0].ID)
+
+	found, err := repo.FindByEmailHash("hash")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, int64(1), found[0].Subscriptions)
+}
+
+func TestErasureRepository_Encrypted(t *testing.T) {
+	require.NoError(t, pii.Configure(base64.StdEncoding.EncodeToString(make([]byte, pii.KeySize))))
+	t.Cleanup(func() { pii.Configure("") })
+
+	db := setupTestDB(t)
+	repo := NewErasureRepository(db)
+	require.NoError(t, db.Create(&models.PickupReservation{SlotID: 1, CustomerName: "Ana Lima", CustomerEmail: "Ana@Example.com"}).Error)
+	require.NoError(t, db.Create(&models.WholesaleAccount{Name: "Ana Café", Email: "ana@example.com"}).Error)
+
+	// Encrypted emails are still unique through their hash.
+	err := db.Create(&models.WholesaleAccount{Name: "Other", Email: "ANA@example.com"}).Error
+	require.ErrorIs(t, translateError(err), ErrDuplicate)
+
+	data, err := NewDataExportRepository(db).FindCustomerData("ana@example.com")
+	require.NoError(t, err)
+	require.Len(t, data.PickupReservations, 1)
+	require.Equal(t, "Ana Lima", data.PickupReservations[0].CustomerName)
+
+	erasure := &models.Erasure{EmailHash: "hash", RequestedBy: models.ErasureByAdmin}
+	require.NoError(t, repo.Erase("ana@example.com", erasure))
+	require.Equal(t, int64(1), erasure.PickupReservations)
+	require.Equal(t, int64(1), erasure.WholesaleAccounts)
+
+	var reservation models.PickupReservation
+	require.NoError(t, db.First(&reservation).Error)
+	require.Equal(t, "[erased]", reservation.CustomerName)
+	require.Equal(t, "erased-1@erased.invalid", reservation.CustomerEmail)
+
+	var stored string
+	require.NoError(t, db.Raw("SELECT customer_name FROM pickup_reservations").Scan(&stored).Error)
+	require.True(t, strings.HasPrefix(stored, pii.Prefix))
+}
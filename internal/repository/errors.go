@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Errors returned by every repository in place of the GORM and driver
+// errors behind them, so callers never depend on the storage layer.
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrDuplicate = errors.New("already exists")
+	ErrConflict  = errors.New("conflicts with the current data")
+)
+
+// translateError maps err onto the package errors and returns any other
+// error unchanged. Unique and foreign key violations are only recognized
+// when the connection was opened with gorm.Config.TranslateError.
+func translateError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return ErrDuplicate
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return ErrConflict
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestTranslateError(t *testing.T) {
+	other := errors.New("connection reset")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{name: "nil", err: nil, expected: nil},
+		{name: "record not found", err: gorm.ErrRecordNotFound, expected: ErrNotFound},
+		{name: "duplicated key", err: gorm.ErrDuplicatedKey, expected: ErrDuplicate},
+		{name: "foreign key violated", err: gorm.ErrForeignKeyViolated, expected: ErrConflict},
+		{name: "already translated", err: ErrNotFound, expected: ErrNotFound},
+		{name: "other errors pass through", err: other, expected: other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, translateError(tt.err))
+		})
+	}
+}
+
+func TestRepositoryErrors_Duplicate(t *testing.T) {
+	repo := NewCouponRepository(setupTestDB(t))
+
+	coupon := models.Coupon{Code: "SWEET10", DiscountType: models.DiscountTypePercentage, DiscountValue: 10}
+	require.NoError(t, repo.Create(&coupon))
+
+	again := coupon
+	again.ID = 0
+	err := repo.Create(&again)
+	require.ErrorIs(t, err, ErrDuplicate)
+	require.NotContains(t, err.Error(), "UNIQUE")
+}
+
+func TestRepositoryErrors_NotFound(t *testing.T) {
+	repo := NewCupcakeRepository(setupTestDB(t))
+
+	_, err := repo.FindByID(999)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.False(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
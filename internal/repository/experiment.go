@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExperimentRepository struct {
+	db *gorm.DB
+}
+
+var _ ExperimentRepositoryInterface = (*ExperimentRepository)(nil)
+
+func NewExperimentRepository(db *gorm.DB) *ExperimentRepository {
+	return &ExperimentRepository{db: db}
+}
+
+func (r *ExperimentRepository) Create(experiment *models.Experiment) error {
+	return translateError(r.db.Create(experiment).Error)
+}
+
+func (r *ExperimentRepository) FindByID(id uint) (*models.Experiment, error) {
+	var experiment models.Experiment
+	err := r.db.Preload("Variants").First(&experiment, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &experiment, nil
+}
+
+func (r *ExperimentRepository) FindByKey(key string) (*models.Experiment, error) {
+	var experiment models.Experiment
+	err := r.db.Preload("Variants").Where("key = ?", key).First(&experiment).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &experiment, nil
+}
+
+func (r *ExperimentRepository) FindAll() ([]models.Experiment, error) {
+	var experiments []models.Experiment
+	err := r.db.Preload("Variants").Order("id DESC").Find(&experiments).Error
+	return experiments, translateError(err)
+}
+
+func (r *ExperimentRepository) FindActive() ([]models.Experiment, error) {
+	var experiments []models.Experiment
+	err := r.db.Preload("Variants").Where("is_active = ?", true).Order("id").Find(&experiments).Error
+	return experiments, translateError(err)
+}
+
+// Update saves the experiment itself; variants are fixed once it starts.
+func (r *ExperimentRepository) Update(experiment *models.Experiment) error {
+	return translateError(r.db.Omit("Variants").Save(experiment).Error)
+}
+
+// RecordExposures stores the exposures of visitors not yet exposed to each
+// experiment; a visitor keeps the variant of their first exposure.
+func (r *ExperimentRepository) RecordExposures(exposures []models.ExperimentExposure) error {
+	if len(exposures) == 0 {
+		return nil
+	}
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&exposures).Error
+	return translateError(err)
+}
+
+func (r *ExperimentRepository) RecordConversion(conversion *models.ExperimentConversion) error {
+	return translateError(r.db.Create(conversion).Error)
+}
+
+// Results counts distinct exposed and converted visitors per variant, with
+// the total value of the conversions. Variants with neither are left out.
+func (r *ExperimentRepository) Results(experimentID uint) ([]models.VariantResults, error) {
+	var exposures []struct {
+		Variant string
+		Count   int64
+	}
+	err := r.db.Model(&models.ExperimentExposure{}).
+		Select("variant, COUNT(*) AS count").
+		Where("experiment_id = ?", experimentID).
+		Group("variant").
+		Scan(&exposures).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	var conversions []struct {
+		Variant string
+		Count   int64
+		Revenue int64
+	}
+	err = r.db.Model(&models.ExperimentConversion{}).
+		Select("variant, COUNT(DISTINCT visitor_id) AS count, COALESCE(SUM(value_cents), 0) AS revenue").
+		Where("experiment_id = ?", experimentID).
+		Group("variant").
+		Scan(&conversions).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	results := make([]models.VariantResults, 0, len(exposures))
+	index := make(map[string]int, len(exposures))
+	for _, exposure := range exposures {
+		index[exposure.Variant] = len(results)
+		results = append(results, models.VariantResults{Variant: exposure.Variant, Exposures: exposure.Count})
+	}
+	for _, conversion := range conversions {
+		i, ok := index[conversion.Variant]
+		if !ok {
+			i = len(results)
+			index[conversion.Variant] = i
+			results = append(results, models.VariantResults{Variant: conversion.Variant})
+		}
+		results[i].Conversions = conversion.Count
+		results[i].RevenueCents = conversion.Revenue
+	}
+	return results, nil
+}
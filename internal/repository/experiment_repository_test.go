@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExperimentRepository(t *testing.T) {
+	repo := NewExperimentRepository(setupTestDB(t))
+
+	experiment := &models.Experiment{
+		Key:      "price-test",
+		IsActive: true,
+		Variants: []models.ExperimentVariant{
+			{Name: "control", Weight: 1},
+			{Name: "higher", Weight: 1, PriceAdjustmentPercent: 10},
+		},
+	}
+	require.NoError(t, repo.Create(experiment))
+	require.ErrorIs(t, repo.Create(&models.Experiment{Key: "price-test"}), ErrDuplicate)
+
+	found, err := repo.FindByKey("price-test")
+	require.NoError(t, err)
+	require.Len(t, found.Variants, 2)
+	_, err = repo.FindByKey("missing")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// A visitor keeps the variant of their first exposure.
+	require.NoError(t, repo.RecordExposures([]models.ExperimentExposure{
+		{ExperimentID: experiment.ID, VisitorID: "a", Variant: "control"},
+		{ExperimentID: experiment.ID, VisitorID: "b", Variant: "higher"},
+	}))
+	require.NoError(t, repo.RecordExposures([]models.ExperimentExposure{
+		{ExperimentID: experiment.ID, VisitorID: "a", Variant: "higher"},
+	}))
+	require.NoError(t, repo.RecordConversion(&models.ExperimentConversion{ExperimentID: experiment.ID, VisitorID: "b", Variant: "higher", ValueCents: 1100}))
+	require.NoError(t, repo.RecordConversion(&models.ExperimentConversion{ExperimentID: experiment.ID, VisitorID: "b", Variant: "higher", ValueCents: 2200}))
+
+	results, err := repo.Results(experiment.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []models.VariantResults{
+		{Variant: "control", Exposures: 1},
+		{Variant: "higher", Exposures: 1, Conversions: 1, RevenueCents: 3300},
+	}, results)
+
+	found.IsActive = false
+	require.NoError(t, repo.Update(found))
+	active, err := repo.FindActive()
+	require.NoError(t, err)
+	require.Empty(t, active)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInsufficientBalance = errors.New("gift card balance is insufficient or card is voided")
+
+type GiftCardRepository struct {
+	db *gorm.DB
+}
+
+var _ GiftCardRepositoryInterface = (*GiftCardRepository)(nil)
+
+func NewGiftCardRepository(db *gorm.DB) *GiftCardRepository {
+	return &GiftCardRepository{db: db}
+}
+
+func (r *GiftCardRepository) Create(giftCard *models.GiftCard) error {
+	return translateError(r.db.Create(giftCard).Error)
+}
+
+func (r *GiftCardRepository) FindByID(id uint) (*models.GiftCard, error) {
+	var giftCard models.GiftCard
+	err := r.db.First(&giftCard, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &giftCard, nil
+}
+
+func (r *GiftCardRepository) FindByCode(code string) (*models.GiftCard, error) {
+	var giftCard models.GiftCard
+	err := r.db.Where("code = ?", code).First(&giftCard).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &giftCard, nil
+}
+
+func (r *GiftCardRepository) FindAll() ([]models.GiftCard, error) {
+	var giftCards []models.GiftCard
+	err := r.db.Find(&giftCards).Error
+	return giftCards, translateError(err)
+}
+
+func (r *GiftCardRepository) Void(id uint, at time.Time) error {
+	result := r.db.Model(&models.GiftCard{}).
+		Where("id = ? AND voided_at IS NULL", id).
+		Update("voided_at", at)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// The conditional update rejects the debit instead of letting the balance go negative.
+func (r *GiftCardRepository) Debit(redemption *models.GiftCardRedemption) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.GiftCard{}).
+			Where("id = ? AND voided_at IS NULL AND balance_cents >= ?", redemption.GiftCardID, redemption.AmountCents).
+			UpdateColumn("balance_cents", gorm.Expr("balance_cents - ?", redemption.AmountCents))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInsufficientBalance
+		}
+		return tx.Create(redemption).Error
+	})
+	return translateError(err)
+}
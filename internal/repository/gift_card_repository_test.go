@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGiftCardRepository_Debit(t *testing.T) {
+	tests := []struct {
+		name            string
+		balanceCents    int
+		voided          bool
+		debitCents      int
+		expectedError   error
+		expectedBalance int
+	}{
+		{
+			name:            "debits within balance",
+			balanceCents:    5000,
+			debitCents:      1500,
+			expectedBalance: 3500,
+		},
+		{
+			name:            "debits the full balance",
+			balanceCents:    5000,
+			debitCents:      5000,
+			expectedBalance: 0,
+		},
+		{
+			name:            "rejects debit above balance",
+			balanceCents:    1000,
+			debitCents:      1001,
+			expectedError:   ErrInsufficientBalance,
+			expectedBalance: 1000,
+		},
+		{
+			name:            "rejects debit on voided card",
+			balanceCents:    1000,
+			voided:          true,
+			debitCents:      100,
+			expectedError:   ErrInsufficientBalance,
+			expectedBalance: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewGiftCardRepository(db)
+
+			giftCard := &models.GiftCard{
+				Code:         "ABCD-EFGH-JKLM-NPQR",
+				InitialCents: tt.balanceCents,
+				BalanceCents: tt.balanceCents,
+			}
+			require.NoError(t, repo.Create(giftCard))
+
+			if tt.voided {
+				require.NoError(t, repo.Void(giftCard.ID, time.Now()))
+			}
+
+			err := repo.Debit(&models.GiftCardRedemption{
+				GiftCardID:  giftCard.ID,
+				AmountCents: tt.debitCents,
+			})
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			stored, err := repo.FindByID(giftCard.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedBalance, stored.BalanceCents)
+		})
+	}
+}
+
+func TestGiftCardRepository_Void(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGiftCardRepository(db)
+
+	giftCard := &models.GiftCard{Code: "ABCD-EFGH-JKLM-NPQR", InitialCents: 100, BalanceCents: 100}
+	require.NoError(t, repo.Create(giftCard))
+
+	require.NoError(t, repo.Void(giftCard.ID, time.Now()))
+
+	err := repo.Void(giftCard.ID, time.Now())
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,404 @@
+// Package inmem holds map-backed repositories, e.g. for demos, fuzzing and
+// fast handler tests. Only the catalog has one so far: the API still needs
+// SQLite, and with it CGO, for everything else. Data lives only as long as
+// the process.
+package inmem
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var (
+	// ErrDuplicateSKU mirrors the unique index on cupcakes.sku.
+	ErrDuplicateSKU = fmt.Errorf("sku %w", repository.ErrDuplicate)
+	// ErrDuplicateSlug mirrors the unique index on cupcakes.slug.
+	ErrDuplicateSlug = fmt.Errorf("slug %w", repository.ErrDuplicate)
+)
+
+// CupcakeRepository behaves like repository.CupcakeRepository: misses return
+// repository.ErrNotFound and every write records a version and a change.
+// Cupcakes are copied in and out so callers never share memory with the
+// store.
+type CupcakeRepository struct {
+	mu       sync.RWMutex
+	cupcakes map[uint]models.Cupcake
+	versions map[uint][]models.CupcakeVersion
+	changes  []models.CupcakeChange
+	nextID   uint
+	now      func() time.Time
+}
+
+var _ repository.CupcakeRepositoryInterface = (*CupcakeRepository)(nil)
+
+func NewCupcakeRepository() *CupcakeRepository {
+	return &CupcakeRepository{
+		cupcakes: make(map[uint]models.Cupcake),
+		versions: make(map[uint][]models.CupcakeVersion),
+		nextID:   1,
+		now:      time.Now,
+	}
+}
+
+func (r *CupcakeRepository) Create(cupcake *models.Cupcake) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkUnique(cupcake); err != nil {
+		return err
+	}
+
+	now := r.now()
+	cupcake.ID = r.nextID
+	cupcake.CreatedAt = now
+	cupcake.UpdatedAt = now
+	r.nextID++
+
+	r.cupcakes[cupcake.ID] = clone(*cupcake)
+	r.saveVersion(cupcake)
+	r.recordChange(cupcake.ID, false)
+	return nil
+}
+
+func (r *CupcakeRepository) FindByID(id uint) (*models.Cupcake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cupcake, ok := r.cupcakes[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	cupcake = clone(cupcake)
+	return &cupcake, nil
+}
+
+func (r *CupcakeRepository) FindBySKU(sku string) (*models.Cupcake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, cupcake := range r.sorted() {
+		if cupcake.SKU != nil && *cupcake.SKU == sku {
+			return &cupcake, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *CupcakeRepository) FindBySlug(slug string) (*models.Cupcake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, cupcake := range r.sorted() {
+		if cupcake.Slug != nil && *cupcake.Slug == slug {
+			return &cupcake, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *CupcakeRepository) FindAll() ([]models.Cupcake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.displayOrdered(), nil
+}
+
+func (r *CupcakeRepository) FindPage(offset, limit int) ([]models.Cupcake, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := r.displayOrdered()
+	total := int64(len(all))
+	if offset >= len(all) {
+		return []models.Cupcake{}, total, nil
+	}
+	end := len(all)
+	if limit >= 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return all[offset:end], total, nil
+}
+
+// Stream iterates over a snapshot taken up front, so fn may call back into
+// the repository without deadlocking.
+func (r *CupcakeRepository) Stream(fn func(*models.Cupcake) error) error {
+	r.mu.RLock()
+	all := r.sorted()
+	r.mu.RUnlock()
+
+	for i := range all {
+		if err := fn(&all[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *CupcakeRepository) FindByFlavor(flavor string) ([]models.Cupcake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cupcakes := []models.Cupcake{}
+	for _, cupcake := range r.sorted() {
+		if strings.EqualFold(cupcake.Flavor, flavor) {
+			cupcakes = append(cupcakes, cupcake)
+		}
+	}
+	return cupcakes, nil
+}
+
+func (r *CupcakeRepository) Search(text string) ([]models.Cupcake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	text = strings.ToLower(text)
+	cupcakes := []models.Cupcake{}
+	for _, cupcake := range r.sorted() {
+		if !cupcake.IsAvailable {
+			continue
+		}
+		sku := ""
+		if cupcake.SKU != nil {
+			sku = *cupcake.SKU
+		}
+		if strings.Contains(strings.ToLower(cupcake.Name), text) ||
+			strings.Contains(strings.ToLower(cupcake.Flavor), text) ||
+			strings.Contains(strings.ToLower(sku), text) {
+			cupcakes = append(cupcakes, cupcake)
+		}
+	}
+	sort.SliceStable(cupcakes, func(i, j int) bool { return cupcakes[i].Name < cupcakes[j].Name })
+	return cupcakes, nil
+}
+
+func (r *CupcakeRepository) FindRelated(id uint, limit int) ([]models.Cupcake, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cupcakes := []models.Cupcake{}
+	target, ok := r.cupcakes[id]
+	if !ok {
+		return cupcakes, nil
+	}
+	for _, cupcake := range r.sorted() {
+		if len(cupcakes) == limit {
+			break
+		}
+		if cupcake.ID != id && cupcake.IsAvailable && strings.EqualFold(cupcake.Flavor, target.Flavor) {
+			cupcakes = append(cupcakes, cupcake)
+		}
+	}
+	return cupcakes, nil
+}
+
+// Update saves every field like gorm's Save, creating the cupcake when it
+// has no ID yet.
+func (r *CupcakeRepository) Update(cupcake *models.Cupcake) error {
+	if cupcake.ID == 0 {
+		return r.Create(cupcake)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkUnique(cupcake); err != nil {
+		return err
+	}
+
+	cupcake.UpdatedAt = r.now()
+	r.cupcakes[cupcake.ID] = clone(*cupcake)
+	r.saveVersion(cupcake)
+	r.recordChange(cupcake.ID, false)
+	return nil
+}
+
+func (r *CupcakeRepository) UpdatePrices(cupcakes []models.Cupcake) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for _, cupcake := range cupcakes {
+		stored, ok := r.cupcakes[cupcake.ID]
+		if !ok {
+			continue
+		}
+		stored.PriceCents = cupcake.PriceCents
+		stored.UpdatedBy = cloneUint(cupcake.UpdatedBy)
+		stored.UpdatedAt = now
+		r.cupcakes[cupcake.ID] = stored
+		r.saveVersion(&cupcake)
+		r.recordChange(cupcake.ID, false)
+	}
+	return nil
+}
+
+func (r *CupcakeRepository) Delete(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.cupcakes[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(r.cupcakes, id)
+	r.recordChange(id, true)
+	return nil
+}
+
+func (r *CupcakeRepository) Exists(id uint) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.cupcakes[id]
+	return ok, nil
+}
+
+func (r *CupcakeRepository) FindVersions(cupcakeID uint) ([]models.CupcakeVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored := r.versions[cupcakeID]
+	versions := make([]models.CupcakeVersion, len(stored))
+	for i := range stored {
+		versions[len(stored)-1-i] = stored[i]
+	}
+	return versions, nil
+}
+
+func (r *CupcakeRepository) FindVersion(cupcakeID uint, version int) (*models.CupcakeVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, snapshot := range r.versions[cupcakeID] {
+		if snapshot.Version == version {
+			return &snapshot, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *CupcakeRepository) FindChanges(since uint, limit int) ([]models.CupcakeChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Change IDs are positions in the log, starting at 1.
+	changes := []models.CupcakeChange{}
+	for i := int(since); i < len(r.changes) && len(changes) < limit; i++ {
+		changes = append(changes, r.changes[i])
+	}
+	return changes, nil
+}
+
+func (r *CupcakeRepository) LastChangeID() (uint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return uint(len(r.changes)), nil
+}
+
+// checkUnique enforces SKU and slug uniqueness. Callers must hold the write
+// lock.
+func (r *CupcakeRepository) checkUnique(cupcake *models.Cupcake) error {
+	for id, existing := range r.cupcakes {
+		if id == cupcake.ID {
+			continue
+		}
+		if cupcake.SKU != nil && existing.SKU != nil && *existing.SKU == *cupcake.SKU {
+			return ErrDuplicateSKU
+		}
+		if cupcake.Slug != nil && existing.Slug != nil && *existing.Slug == *cupcake.Slug {
+			return ErrDuplicateSlug
+		}
+	}
+	return nil
+}
+
+// saveVersion appends a snapshot of cupcake as its next version. Callers
+// must hold the write lock.
+func (r *CupcakeRepository) saveVersion(cupcake *models.Cupcake) {
+	history := r.versions[cupcake.ID]
+	r.versions[cupcake.ID] = append(history, models.CupcakeVersion{
+		CupcakeID:     cupcake.ID,
+		Version:       len(history) + 1,
+		Name:          cupcake.Name,
+		Flavor:        cupcake.Flavor,
+		SKU:           cloneString(cupcake.SKU),
+		Slug:          cloneString(cupcake.Slug),
+		Description:   cupcake.Description,
+		DisplayOrder:  cupcake.DisplayOrder,
+		PriceCents:    cupcake.PriceCents,
+		IsAvailable:   cupcake.IsAvailable,
+		AvailableFrom: cloneTime(cupcake.AvailableFrom),
+		CreatedAt:     r.now(),
+	})
+}
+
+// recordChange appends to the change log. Callers must hold the write lock.
+func (r *CupcakeRepository) recordChange(cupcakeID uint, deleted bool) {
+	r.changes = append(r.changes, models.CupcakeChange{
+		ID:        uint(len(r.changes) + 1),
+		CupcakeID: cupcakeID,
+		Deleted:   deleted,
+		CreatedAt: r.now(),
+	})
+}
+
+// sorted returns copies of every cupcake in ID order. Callers must hold the
+// lock.
+func (r *CupcakeRepository) sorted() []models.Cupcake {
+	cupcakes := make([]models.Cupcake, 0, len(r.cupcakes))
+	for _, cupcake := range r.cupcakes {
+		cupcakes = append(cupcakes, clone(cupcake))
+	}
+	sort.Slice(cupcakes, func(i, j int) bool { return cupcakes[i].ID < cupcakes[j].ID })
+	return cupcakes
+}
+
+// displayOrdered is sorted reordered by DisplayOrder, ties staying in ID
+// order. Callers must hold the lock.
+func (r *CupcakeRepository) displayOrdered() []models.Cupcake {
+	cupcakes := r.sorted()
+	sort.SliceStable(cupcakes, func(i, j int) bool { return cupcakes[i].DisplayOrder < cupcakes[j].DisplayOrder })
+	return cupcakes
+}
+
+func clone(cupcake models.Cupcake) models.Cupcake {
+	cupcake.SKU = cloneString(cupcake.SKU)
+	cupcake.Slug = cloneString(cupcake.Slug)
+	cupcake.AvailableFrom = cloneTime(cupcake.AvailableFrom)
+	cupcake.CreatedBy = cloneUint(cupcake.CreatedBy)
+	cupcake.UpdatedBy = cloneUint(cupcake.UpdatedBy)
+	cupcake.EffectivePriceCents = nil
+	cupcake.Currency = ""
+	cupcake.PreOrder = false
+	return cupcake
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+func cloneUint(n *uint) *uint {
+	if n == nil {
+		return nil
+	}
+	v := *n
+	return &v
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := *t
+	return &v
+}
@@ -0,0 +1,347 @@
+package inmem
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func seed(t *testing.T, repo *CupcakeRepository, cupcakes ...models.Cupcake) {
+	t.Helper()
+	for i := range cupcakes {
+		require.NoError(t, repo.Create(&cupcakes[i]))
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestCupcakeRepository_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      []models.Cupcake
+		cupcake       models.Cupcake
+		expectedID    uint
+		expectedError error
+	}{
+		{
+			name:       "assigns the first ID",
+			cupcake:    factory.Cupcake(),
+			expectedID: 1,
+		},
+		{
+			name:       "IDs increase",
+			existing:   []models.Cupcake{{Name: "Vanilla", Flavor: "Vanilla", PriceCents: 800}},
+			cupcake:    factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Cocoa")),
+			expectedID: 2,
+		},
+		{
+			name:          "duplicate SKU",
+			existing:      []models.Cupcake{{Name: "Vanilla", Flavor: "Vanilla", PriceCents: 800, SKU: stringPtr("VAN-001")}},
+			cupcake:       models.Cupcake{Name: "Other", Flavor: "Vanilla", PriceCents: 800, SKU: stringPtr("VAN-001")},
+			expectedError: ErrDuplicateSKU,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewCupcakeRepository()
+			seed(t, repo, tt.existing...)
+
+			err := repo.Create(&tt.cupcake)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedID, tt.cupcake.ID)
+			require.False(t, tt.cupcake.CreatedAt.IsZero())
+
+			versions, err := repo.FindVersions(tt.cupcake.ID)
+			require.NoError(t, err)
+			require.Len(t, versions, 1)
+			require.Equal(t, 1, versions[0].Version)
+		})
+	}
+}
+
+func TestCupcakeRepository_Find(t *testing.T) {
+	repo := NewCupcakeRepository()
+	seed(t, repo,
+		factory.Cupcake(factory.WithSKU("VAN-001")),
+		factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Cocoa")),
+		factory.Cupcake(factory.WithName("Dark Chocolate"), factory.WithFlavor("cocoa")),
+	)
+
+	tests := []struct {
+		name          string
+		find          func() ([]models.Cupcake, error)
+		expectedNames []string
+		expectedError error
+	}{
+		{
+			name:          "by ID",
+			find:          func() ([]models.Cupcake, error) { return one(repo.FindByID(2)) },
+			expectedNames: []string{"Chocolate"},
+		},
+		{
+			name:          "missing ID",
+			find:          func() ([]models.Cupcake, error) { return one(repo.FindByID(99)) },
+			expectedError: repository.ErrNotFound,
+		},
+		{
+			name:          "by SKU",
+			find:          func() ([]models.Cupcake, error) { return one(repo.FindBySKU("VAN-001")) },
+			expectedNames: []string{"Vanilla"},
+		},
+		{
+			name:          "missing SKU",
+			find:          func() ([]models.Cupcake, error) { return one(repo.FindBySKU("NOPE")) },
+			expectedError: repository.ErrNotFound,
+		},
+		{
+			name:          "all in ID order",
+			find:          repo.FindAll,
+			expectedNames: []string{"Vanilla", "Chocolate", "Dark Chocolate"},
+		},
+		{
+			name:          "by flavor ignores case",
+			find:          func() ([]models.Cupcake, error) { return repo.FindByFlavor("COCOA") },
+			expectedNames: []string{"Chocolate", "Dark Chocolate"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cupcakes, err := tt.find()
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedNames, names(cupcakes))
+		})
+	}
+}
+
+func TestCupcakeRepository_FindPage(t *testing.T) {
+	repo := NewCupcakeRepository()
+	seed(t, repo, factory.Cupcakes(5)...)
+
+	tests := []struct {
+		name          string
+		offset, limit int
+		expectedIDs   []uint
+	}{
+		{name: "first page", offset: 0, limit: 2, expectedIDs: []uint{1, 2}},
+		{name: "last partial page", offset: 4, limit: 2, expectedIDs: []uint{5}},
+		{name: "past the end", offset: 10, limit: 2, expectedIDs: []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cupcakes, total, err := repo.FindPage(tt.offset, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, int64(5), total)
+			ids := []uint{}
+			for _, c := range cupcakes {
+				ids = append(ids, c.ID)
+			}
+			require.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestCupcakeRepository_Stream(t *testing.T) {
+	repo := NewCupcakeRepository()
+	seed(t, repo,
+		factory.Cupcake(),
+		factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Cocoa")),
+	)
+
+	stop := errors.New("stop")
+	var seen []string
+	err := repo.Stream(func(c *models.Cupcake) error {
+		seen = append(seen, c.Name)
+		// Reading back inside the callback must not deadlock.
+		_, err := repo.FindByID(c.ID)
+		require.NoError(t, err)
+		if len(seen) == 1 {
+			return stop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, stop)
+	require.Equal(t, []string{"Vanilla"}, seen)
+}
+
+func TestCupcakeRepository_Update(t *testing.T) {
+	repo := NewCupcakeRepository()
+	seed(t, repo,
+		factory.Cupcake(factory.WithSKU("VAN-001")),
+		factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Cocoa")),
+	)
+
+	cupcake, err := repo.FindByID(2)
+	require.NoError(t, err)
+	cupcake.SKU = stringPtr("VAN-001")
+	require.ErrorIs(t, repo.Update(cupcake), ErrDuplicateSKU)
+
+	cupcake.SKU = nil
+	cupcake.Name = "Milk Chocolate"
+	require.NoError(t, repo.Update(cupcake))
+	admin := uint(4)
+	require.NoError(t, repo.UpdatePrices([]models.Cupcake{{ID: 2, Name: "Milk Chocolate", Flavor: "Cocoa", PriceCents: 1100, UpdatedBy: &admin}}))
+
+	stored, err := repo.FindByID(2)
+	require.NoError(t, err)
+	require.Equal(t, "Milk Chocolate", stored.Name)
+	require.Equal(t, 1100, stored.PriceCents)
+	require.Equal(t, &admin, stored.UpdatedBy)
+
+	versions, err := repo.FindVersions(2)
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	require.Equal(t, 3, versions[0].Version)
+	require.Equal(t, 1100, versions[0].PriceCents)
+
+	snapshot, err := repo.FindVersion(2, 1)
+	require.NoError(t, err)
+	require.Equal(t, "Chocolate", snapshot.Name)
+
+	_, err = repo.FindVersion(2, 9)
+	require.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestCupcakeRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            uint
+		expectedError error
+	}{
+		{name: "existing cupcake", id: 1},
+		{name: "missing cupcake", id: 99, expectedError: repository.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewCupcakeRepository()
+			seed(t, repo, factory.Cupcake())
+
+			err := repo.Delete(tt.id)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			exists, err := repo.Exists(tt.id)
+			require.NoError(t, err)
+			require.False(t, exists)
+		})
+	}
+}
+
+func TestCupcakeRepository_ReturnsCopies(t *testing.T) {
+	repo := NewCupcakeRepository()
+	seed(t, repo, factory.Cupcake(factory.WithSKU("VAN-001")))
+
+	cupcake, err := repo.FindByID(1)
+	require.NoError(t, err)
+	cupcake.Name = "Changed"
+	*cupcake.SKU = "CHANGED"
+
+	stored, err := repo.FindByID(1)
+	require.NoError(t, err)
+	require.Equal(t, "Vanilla", stored.Name)
+	require.Equal(t, "VAN-001", *stored.SKU)
+}
+
+func one(cupcake *models.Cupcake, err error) ([]models.Cupcake, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []models.Cupcake{*cupcake}, nil
+}
+
+func names(cupcakes []models.Cupcake) []string {
+	result := make([]string, len(cupcakes))
+	for i, c := range cupcakes {
+		result[i] = c.Name
+	}
+	return result
+}
+
+func TestCupcakeRepository_FindRelated(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          uint
+		limit       int
+		expectedIDs []uint
+	}{
+		{name: "same flavor in any case, available only", id: 1, limit: 10, expectedIDs: []uint{2, 5}},
+		{name: "limited", id: 1, limit: 1, expectedIDs: []uint{2}},
+		{name: "no other cupcake with the flavor", id: 3, limit: 10, expectedIDs: []uint{}},
+		{name: "missing cupcake", id: 99, limit: 10, expectedIDs: []uint{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewCupcakeRepository()
+			cupcakes := []models.Cupcake{
+				factory.Cupcake(factory.WithName("Vanilla"), factory.WithFlavor("Vanilla")),
+				factory.Cupcake(factory.WithName("Vanilla Bean"), factory.WithFlavor("vanilla")),
+				factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Chocolate")),
+				factory.Cupcake(factory.WithName("Vanilla Sold Out"), factory.WithFlavor("Vanilla"), factory.Unavailable()),
+				factory.Cupcake(factory.WithName("Vanilla Berry"), factory.WithFlavor("Vanilla")),
+			}
+			seed(t, repo, cupcakes...)
+
+			related, err := repo.FindRelated(tt.id, tt.limit)
+			require.NoError(t, err)
+			ids := []uint{}
+			for _, cupcake := range related {
+				ids = append(ids, cupcake.ID)
+			}
+			require.Equal(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
+func TestCupcakeRepository_Search(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expectedNames []string
+	}{
+		{name: "name or flavor, any case", text: "CHOC", expectedNames: []string{"Chocolate", "Mint Chip"}},
+		{name: "SKU", text: "vnl", expectedNames: []string{"Vanilla"}},
+		{name: "empty text lists available cupcakes", text: "", expectedNames: []string{"Chocolate", "Mint Chip", "Vanilla"}},
+		{name: "no match", text: "lemon", expectedNames: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewCupcakeRepository()
+			cupcakes := []models.Cupcake{
+				factory.Cupcake(factory.WithName("Vanilla"), factory.WithFlavor("Vanilla"), factory.WithSKU("VNL-001")),
+				factory.Cupcake(factory.WithName("Mint Chip"), factory.WithFlavor("Chocolate Mint")),
+				factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Cocoa")),
+				factory.Cupcake(factory.WithName("Chocolate Sold Out"), factory.WithFlavor("Cocoa"), factory.Unavailable()),
+			}
+			seed(t, repo, cupcakes...)
+
+			found, err := repo.Search(tt.text)
+			require.NoError(t, err)
+			names := []string{}
+			for _, cupcake := range found {
+				names = append(names, cupcake.Name)
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
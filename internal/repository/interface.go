@@ -1,14 +1,306 @@
 package repository
 
-import "github.com/julimonteiro/cupcake-store/internal/models"
+import (
+	"time"
 
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
 
 type CupcakeRepositoryInterface interface {
 	Create(cupcake *models.Cupcake) error
 	FindByID(id uint) (*models.Cupcake, error)
+	FindBySKU(sku string) (*models.Cupcake, error)
+	FindBySlug(slug string) (*models.Cupcake, error)
 	FindAll() ([]models.Cupcake, error)
+	FindPage(offset, limit int) ([]models.Cupcake, int64, error)
+	Stream(fn func(*models.Cupcake) error) error
+	FindByFlavor(flavor string) ([]models.Cupcake, error)
+	FindRelated(id uint, limit int) ([]models.Cupcake, error)
+	Search(text string) ([]models.Cupcake, error)
 	Update(cupcake *models.Cupcake) error
+	UpdatePrices(cupcakes []models.Cupcake) error
+	FindVersions(cupcakeID uint) ([]models.CupcakeVersion, error)
+	FindVersion(cupcakeID uint, version int) (*models.CupcakeVersion, error)
 	Delete(id uint) error
 	Exists(id uint) (bool, error)
+	FindChanges(since uint, limit int) ([]models.CupcakeChange, error)
+	LastChangeID() (uint, error)
 }
 
+type CouponRepositoryInterface interface {
+	Create(coupon *models.Coupon) error
+	FindByID(id uint) (*models.Coupon, error)
+	FindByCode(code string) (*models.Coupon, error)
+	FindAll() ([]models.Coupon, error)
+	Update(coupon *models.Coupon) error
+	Delete(id uint) error
+	Redeem(redemption *models.CouponRedemption) error
+	DeactivateExpired(at time.Time) (int64, error)
+}
+
+type PromotionRepositoryInterface interface {
+	Create(promotion *models.Promotion) error
+	FindByID(id uint) (*models.Promotion, error)
+	FindAll() ([]models.Promotion, error)
+	FindActive(cupcakeIDs []uint, at time.Time) ([]models.Promotion, error)
+	FindAllActive(at time.Time) ([]models.Promotion, error)
+	Update(promotion *models.Promotion) error
+	Delete(id uint) error
+}
+
+type GiftCardRepositoryInterface interface {
+	Create(giftCard *models.GiftCard) error
+	FindByID(id uint) (*models.GiftCard, error)
+	FindByCode(code string) (*models.GiftCard, error)
+	FindAll() ([]models.GiftCard, error)
+	Void(id uint, at time.Time) error
+	Debit(redemption *models.GiftCardRedemption) error
+}
+
+type SubscriptionRepositoryInterface interface {
+	Create(subscription *models.Subscription) error
+	FindByID(id uint) (*models.Subscription, error)
+	FindAll() ([]models.Subscription, error)
+	FindScheduled(from, to time.Time) ([]models.Subscription, error)
+	Update(subscription *models.Subscription) error
+}
+
+type WebhookRepositoryInterface interface {
+	Create(webhook *models.Webhook) error
+	FindByID(id uint) (*models.Webhook, error)
+	FindAll() ([]models.Webhook, error)
+	FindActive() ([]models.Webhook, error)
+	Update(webhook *models.Webhook) error
+	Delete(id uint) error
+	CreateDelivery(delivery *models.WebhookDelivery) error
+	FindDeliveries(webhookID uint) ([]models.WebhookDelivery, error)
+}
+
+type LocationRepositoryInterface interface {
+	Create(location *models.Location) error
+	FindByID(id uint) (*models.Location, error)
+	FindAll() ([]models.Location, error)
+	Update(location *models.Location) error
+	Delete(id uint) error
+	SetStock(stock *models.LocationStock) error
+	FindStock(locationID uint) ([]models.LocationStock, error)
+	FindLowStock() ([]models.LocationStock, error)
+}
+
+type StockAlertRepositoryInterface interface {
+	Create(alert *models.StockAlert) error
+	FindByID(id uint) (*models.StockAlert, error)
+	FindAll(status string) ([]models.StockAlert, error)
+	FindUnresolved() ([]models.StockAlert, error)
+	FindLatest(locationID, cupcakeID uint) (*models.StockAlert, error)
+	Update(alert *models.StockAlert) error
+}
+
+type BundleRepositoryInterface interface {
+	Create(bundle *models.Bundle) error
+	FindByID(id uint) (*models.Bundle, error)
+	FindByName(name string) (*models.Bundle, error)
+	FindAll() ([]models.Bundle, error)
+	Update(bundle *models.Bundle) error
+	Delete(id uint) error
+}
+
+type WholesaleRepositoryInterface interface {
+	Create(account *models.WholesaleAccount) error
+	FindByID(id uint) (*models.WholesaleAccount, error)
+	FindByEmail(email string) (*models.WholesaleAccount, error)
+	FindAll() ([]models.WholesaleAccount, error)
+	Update(account *models.WholesaleAccount) error
+	Delete(id uint) error
+	SetPrice(price *models.WholesalePrice) error
+	DeletePrice(accountID, cupcakeID uint) error
+	FindPrices(accountID uint) ([]models.WholesalePrice, error)
+}
+
+type SupplierRepositoryInterface interface {
+	Create(supplier *models.Supplier) error
+	FindByID(id uint) (*models.Supplier, error)
+	FindByName(name string) (*models.Supplier, error)
+	FindAll() ([]models.Supplier, error)
+	Update(supplier *models.Supplier) error
+}
+
+type IngredientRepositoryInterface interface {
+	Create(ingredient *models.Ingredient) error
+	FindByID(id uint) (*models.Ingredient, error)
+	FindByIDs(ids []uint) ([]models.Ingredient, error)
+	FindByName(name string) (*models.Ingredient, error)
+	FindAll() ([]models.Ingredient, error)
+	Update(ingredient *models.Ingredient) error
+}
+
+type PurchaseOrderRepositoryInterface interface {
+	Create(order *models.PurchaseOrder) error
+	FindByID(id uint) (*models.PurchaseOrder, error)
+	FindAll(status string) ([]models.PurchaseOrder, error)
+	Receive(order *models.PurchaseOrder, at time.Time) error
+	Cancel(id uint) error
+}
+
+type RecipeRepositoryInterface interface {
+	FindRecipe(cupcakeID uint) ([]models.RecipeIngredient, error)
+	SetRecipe(cupcakeID uint, lines []models.RecipeIngredient) error
+	RecordProduction(batch *models.ProductionBatch, lines []models.RecipeIngredient) error
+	FindBatches(cupcakeID uint) ([]models.ProductionBatch, error)
+}
+
+type TranslationRepositoryInterface interface {
+	Set(translation *models.CupcakeTranslation) error
+	Delete(cupcakeID uint, locale string) error
+	FindByCupcake(cupcakeID uint) ([]models.CupcakeTranslation, error)
+	FindForCupcakes(cupcakeIDs []uint, locales []string) ([]models.CupcakeTranslation, error)
+}
+
+type ViewRepositoryInterface interface {
+	AddViews(day string, counts map[uint]int) error
+	FindMostViewed(since string, limit int) ([]models.CupcakeViewTotal, error)
+}
+
+type AddonRepositoryInterface interface {
+	Create(addon *models.Addon) error
+	FindByID(id uint) (*models.Addon, error)
+	FindByName(name string) (*models.Addon, error)
+	FindAll() ([]models.Addon, error)
+	Update(addon *models.Addon) error
+	Delete(id uint) error
+}
+
+type CustomOptionRepositoryInterface interface {
+	Create(option *models.CustomOption) error
+	FindByID(id uint) (*models.CustomOption, error)
+	FindByIDs(ids []uint) ([]models.CustomOption, error)
+	FindAll() ([]models.CustomOption, error)
+	Update(option *models.CustomOption) error
+	Delete(id uint) error
+}
+
+type PickupRepositoryInterface interface {
+	CreateSlot(slot *models.PickupSlot) error
+	FindSlot(id uint) (*models.PickupSlot, error)
+	FindSlots(locationID uint, from, to time.Time) ([]models.PickupSlot, error)
+	Reserve(reservation *models.PickupReservation) error
+	FindReservation(id uint) (*models.PickupReservation, error)
+	FindReservations(slotIDs []uint) ([]models.PickupReservation, error)
+	FindReservationsBetween(from, to time.Time) ([]models.PickupReservation, error)
+	FindOpenReservations(from, to time.Time) ([]models.PickupReservation, error)
+	MarkReady(id uint, at time.Time) error
+	MarkStarted(id uint, at time.Time) error
+	Cancel(id uint, reason string, at time.Time) error
+}
+
+type JobRepositoryInterface interface {
+	Create(job *models.Job) error
+	ClaimNext(now time.Time, lease time.Duration) (*models.Job, error)
+	Update(job *models.Job) error
+	FindByStatus(status string) ([]models.Job, error)
+	DeleteCompletedBefore(before time.Time) (int64, error)
+}
+
+type SettingRepositoryInterface interface {
+	FindAll() ([]models.Setting, error)
+	Set(setting *models.Setting) error
+	Delete(key string) error
+}
+
+type ExperimentRepositoryInterface interface {
+	Create(experiment *models.Experiment) error
+	FindByID(id uint) (*models.Experiment, error)
+	FindByKey(key string) (*models.Experiment, error)
+	FindAll() ([]models.Experiment, error)
+	FindActive() ([]models.Experiment, error)
+	Update(experiment *models.Experiment) error
+	RecordExposures(exposures []models.ExperimentExposure) error
+	RecordConversion(conversion *models.ExperimentConversion) error
+	Results(experimentID uint) ([]models.VariantResults, error)
+}
+
+type DataExportRepositoryInterface interface {
+	Create(export *models.DataExport) error
+	FindByID(id uint) (*models.DataExport, error)
+	FindPending(email string) (*models.DataExport, error)
+	Update(export *models.DataExport) error
+	DeleteExpired(now time.Time) (int64, error)
+	FindCustomerData(email string) (*models.CustomerData, error)
+}
+
+type ErasureRepositoryInterface interface {
+	Erase(email string, erasure *models.Erasure) error
+	FindAll() ([]models.Erasure, error)
+	FindByEmailHash(hash string) ([]models.Erasure, error)
+}
+
+type AccountRepositoryInterface interface {
+	Create(account *models.Account) error
+	FindByID(id uint) (*models.Account, error)
+	FindByEmail(email string) (*models.Account, error)
+	UpdatePasswordHash(id uint, hash string) error
+	MarkEmailVerified(id uint, at time.Time) error
+	CreateWithIdentity(account *models.Account, identity *models.AccountIdentity) error
+	FindByIdentity(provider, subject string) (*models.Account, error)
+	FindIdentities(accountID uint) ([]models.AccountIdentity, error)
+	CreateIdentity(identity *models.AccountIdentity) error
+	DeleteIdentity(accountID uint, provider string) error
+}
+
+type SessionRepositoryInterface interface {
+	Create(session *models.Session) error
+	FindByTokenHash(hash string) (*models.Session, error)
+	Extend(id uint, expiresAt time.Time) error
+	Delete(id uint) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type TicketRepositoryInterface interface {
+	Create(ticket *models.Ticket) error
+	FindByID(id uint) (*models.Ticket, error)
+	FindAll(status string) ([]models.Ticket, error)
+	Update(ticket *models.Ticket) error
+}
+
+type APIKeyRepositoryInterface interface {
+	Create(key *models.APIKey) error
+	FindByID(id uint) (*models.APIKey, error)
+	FindByKeyHash(hash string) (*models.APIKey, error)
+	FindAll() ([]models.APIKey, error)
+	Update(key *models.APIKey) error
+	Revoke(id uint, at time.Time) error
+	AddUsage(apiKeyID uint, day, monthStart string, dailyQuota, monthlyQuota int) error
+	FindUsage(apiKeyID uint, since string) ([]models.APIKeyUsage, error)
+}
+
+type AdminRepositoryInterface interface {
+	Create(admin *models.Admin) error
+	FindByID(id uint) (*models.Admin, error)
+	FindByEmail(email string) (*models.Admin, error)
+	FindAll() ([]models.Admin, error)
+	Update(admin *models.Admin) error
+	UpdatePasswordHash(id uint, hash string) error
+	ClaimTOTPStep(id uint, step int64) (bool, error)
+	ReplaceBackupCodes(adminID uint, hashes []string) error
+	UseBackupCode(adminID uint, hash string, at time.Time) (bool, error)
+	SetSMSCode(id uint, hash string, sentAt time.Time) error
+	ClaimSMSCode(id uint, hash string, since time.Time) (bool, error)
+}
+
+type EmailTemplateRepositoryInterface interface {
+	FindAll(locationID uint) ([]models.EmailTemplate, error)
+	Set(template *models.EmailTemplate) error
+	Delete(name string, locationID uint) error
+}
+
+type NotificationPreferenceRepositoryInterface interface {
+	FindByAccount(accountID uint) ([]models.NotificationPreference, error)
+	Set(preferences []models.NotificationPreference) error
+}
+
+type DeviceTokenRepositoryInterface interface {
+	FindByAccount(accountID uint) ([]models.DeviceToken, error)
+	Save(device *models.DeviceToken) error
+	Delete(accountID uint, token string) error
+	DeleteToken(token string) error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type JobRepository struct {
+	db *gorm.DB
+}
+
+var _ JobRepositoryInterface = (*JobRepository)(nil)
+
+func NewJobRepository(db *gorm.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+func (r *JobRepository) Create(job *models.Job) error {
+	return translateError(r.db.Create(job).Error)
+}
+
+// ClaimNext marks the oldest due job as running for lease and returns it.
+// Due jobs are pending ones whose run_at has come, and running ones whose
+// lease has expired; running jobs from before leases count as expired once
+// they went untouched for a lease. The status and attempts check in the
+// update keeps two workers from claiming the same job.
+func (r *JobRepository) ClaimNext(now time.Time, lease time.Duration) (*models.Job, error) {
+	var job models.Job
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("status = ? AND run_at <= ?", models.JobPending, now).
+			Or("status = ? AND locked_until <= ?", models.JobRunning, now).
+			Or("status = ? AND locked_until IS NULL AND updated_at <= ?", models.JobRunning, now.Add(-lease)).
+			Order("run_at, id").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Job{}).
+			Where("id = ? AND status = ? AND attempts = ?", job.ID, job.Status, job.Attempts).
+			Updates(map[string]interface{}{
+				"status":       models.JobRunning,
+				"attempts":     gorm.Expr("attempts + 1"),
+				"locked_until": now.Add(lease),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+
+		return tx.First(&job, job.ID).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &job, nil
+}
+
+func (r *JobRepository) Update(job *models.Job) error {
+	return translateError(r.db.Save(job).Error)
+}
+
+func (r *JobRepository) FindByStatus(status string) ([]models.Job, error) {
+	var jobs []models.Job
+	err := r.db.Where("status = ?", status).Order("id desc").Find(&jobs).Error
+	return jobs, translateError(err)
+}
+
+// DeleteCompletedBefore deletes the completed jobs last updated before
+// before and returns how many there were. Dead jobs are kept for admins to
+// look into.
+func (r *JobRepository) DeleteCompletedBefore(before time.Time) (int64, error) {
+	result := r.db.Where("status = ? AND updated_at < ?", models.JobCompleted, before).Delete(&models.Job{})
+	return result.RowsAffected, translateError(result.Error)
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRepository_ClaimNext(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	lease := 10 * time.Minute
+	leased := now.Add(time.Minute)
+	expired := now.Add(-time.Minute)
+
+	tests := []struct {
+		name             string
+		jobs             []models.Job
+		expectedType     string
+		expectedAttempts int
+		expectedError    error
+	}{
+		{
+			name:          "no jobs",
+			expectedError: ErrNotFound,
+		},
+		{
+			name: "claims oldest due job",
+			jobs: []models.Job{
+				{Type: "later", Status: models.JobPending, MaxAttempts: 5, RunAt: now.Add(-time.Minute)},
+				{Type: "earlier", Status: models.JobPending, MaxAttempts: 5, RunAt: now.Add(-time.Hour)},
+			},
+			expectedType:     "earlier",
+			expectedAttempts: 1,
+		},
+		{
+			name: "skips jobs scheduled in the future",
+			jobs: []models.Job{
+				{Type: "future", Status: models.JobPending, MaxAttempts: 5, RunAt: now.Add(time.Minute)},
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name: "skips dead jobs and running jobs within their lease",
+			jobs: []models.Job{
+				{Type: "dead", Status: models.JobDead, MaxAttempts: 5, RunAt: now.Add(-time.Hour)},
+				{Type: "running", Status: models.JobRunning, Attempts: 1, MaxAttempts: 5, RunAt: now.Add(-time.Hour), LockedUntil: &leased},
+				{Type: "unleased", Status: models.JobRunning, Attempts: 1, MaxAttempts: 5, RunAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Minute)},
+			},
+			expectedError: ErrNotFound,
+		},
+		{
+			name: "reclaims running job whose lease expired",
+			jobs: []models.Job{
+				{Type: "stuck", Status: models.JobRunning, Attempts: 1, MaxAttempts: 5, RunAt: now.Add(-time.Hour), LockedUntil: &expired},
+			},
+			expectedType:     "stuck",
+			expectedAttempts: 2,
+		},
+		{
+			name: "reclaims running job left without a lease",
+			jobs: []models.Job{
+				{Type: "stuck", Status: models.JobRunning, Attempts: 1, MaxAttempts: 5, RunAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour)},
+			},
+			expectedType:     "stuck",
+			expectedAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewJobRepository(db)
+
+			for i := range tt.jobs {
+				require.NoError(t, repo.Create(&tt.jobs[i]))
+			}
+
+			job, err := repo.ClaimNext(now, lease)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				require.Nil(t, job)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedType, job.Type)
+			require.Equal(t, models.JobRunning, job.Status)
+			require.Equal(t, tt.expectedAttempts, job.Attempts)
+			require.Equal(t, now.Add(lease), job.LockedUntil.UTC())
+		})
+	}
+}
+
+func TestJobRepository_DeleteCompletedBefore(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewJobRepository(db)
+	now := time.Now()
+
+	jobs := []models.Job{
+		{Type: "old", Status: models.JobCompleted, MaxAttempts: 5, RunAt: now, UpdatedAt: now.Add(-48 * time.Hour)},
+		{Type: "recent", Status: models.JobCompleted, MaxAttempts: 5, RunAt: now, UpdatedAt: now.Add(-time.Hour)},
+		{Type: "dead", Status: models.JobDead, MaxAttempts: 5, RunAt: now, UpdatedAt: now.Add(-48 * time.Hour)},
+	}
+	for i := range jobs {
+		require.NoError(t, repo.Create(&jobs[i]))
+	}
+
+	deleted, err := repo.DeleteCompletedBefore(now.Add(-24 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	var left []string
+	require.NoError(t, db.Model(&models.Job{}).Order("id").Pluck("type", &left).Error)
+	require.Equal(t, []string{"recent", "dead"}, left)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LocationRepository struct {
+	db *gorm.DB
+}
+
+var _ LocationRepositoryInterface = (*LocationRepository)(nil)
+
+func NewLocationRepository(db *gorm.DB) *LocationRepository {
+	return &LocationRepository{db: db}
+}
+
+func (r *LocationRepository) Create(location *models.Location) error {
+	return translateError(r.db.Create(location).Error)
+}
+
+func (r *LocationRepository) FindByID(id uint) (*models.Location, error) {
+	var location models.Location
+	err := r.db.First(&location, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &location, nil
+}
+
+func (r *LocationRepository) FindAll() ([]models.Location, error) {
+	var locations []models.Location
+	err := r.db.Order("name").Find(&locations).Error
+	return locations, translateError(err)
+}
+
+func (r *LocationRepository) Update(location *models.Location) error {
+	return translateError(r.db.Save(location).Error)
+}
+
+// Delete removes the location together with its stock rows.
+func (r *LocationRepository) Delete(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Location{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Where("location_id = ?", id).Delete(&models.LocationStock{}).Error
+	})
+	return translateError(err)
+}
+
+// SetStock creates or replaces the quantity of a cupcake at a location.
+func (r *LocationRepository) SetStock(stock *models.LocationStock) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "location_id"}, {Name: "cupcake_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"quantity", "reorder_threshold", "updated_at"}),
+	}).Create(stock).Error
+	return translateError(err)
+}
+
+func (r *LocationRepository) FindStock(locationID uint) ([]models.LocationStock, error) {
+	var stock []models.LocationStock
+	err := r.db.Where("location_id = ?", locationID).Order("cupcake_id").Find(&stock).Error
+	return stock, translateError(err)
+}
+
+// FindLowStock lists the stock rows, across locations, whose quantity has
+// fallen to their reorder threshold.
+func (r *LocationRepository) FindLowStock() ([]models.LocationStock, error) {
+	var stock []models.LocationStock
+	err := r.db.Where("reorder_threshold > 0 AND quantity <= reorder_threshold").
+		Order("location_id, cupcake_id").
+		Find(&stock).Error
+	return stock, translateError(err)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationRepository_SetStock(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLocationRepository(db)
+
+	location := &models.Location{Name: "Centro", Address: "Rua Augusta, 100"}
+	require.NoError(t, repo.Create(location))
+
+	require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: location.ID, CupcakeID: 2, Quantity: 5}))
+	require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: location.ID, CupcakeID: 1, Quantity: 3}))
+	require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: location.ID, CupcakeID: 2, Quantity: 0}))
+	require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: location.ID + 1, CupcakeID: 1, Quantity: 9}))
+
+	stock, err := repo.FindStock(location.ID)
+	require.NoError(t, err)
+	require.Len(t, stock, 2, "setting stock twice should update the existing row")
+	require.Equal(t, uint(1), stock[0].CupcakeID)
+	require.Equal(t, 3, stock[0].Quantity)
+	require.Equal(t, uint(2), stock[1].CupcakeID)
+	require.Equal(t, 0, stock[1].Quantity)
+}
+
+func TestLocationRepository_FindLowStock(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewLocationRepository(db)
+
+	require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: 1, CupcakeID: 1, Quantity: 5, ReorderThreshold: 5}))
+	require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: 1, CupcakeID: 2, Quantity: 6, ReorderThreshold: 5}))
+	require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: 1, CupcakeID: 3, Quantity: 0}))
+	require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: 2, CupcakeID: 2, Quantity: 1, ReorderThreshold: 2}))
+
+	low, err := repo.FindLowStock()
+	require.NoError(t, err)
+	require.Len(t, low, 2, "stock without a threshold never runs low")
+	require.Equal(t, [2]uint{1, 1}, [2]uint{low[0].LocationID, low[0].CupcakeID})
+	require.Equal(t, [2]uint{2, 2}, [2]uint{low[1].LocationID, low[1].CupcakeID})
+}
+
+func TestLocationRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            uint
+		expectedError error
+	}{
+		{name: "removes location and its stock", id: 1},
+		{name: "unknown location", id: 999, expectedError: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewLocationRepository(db)
+
+			location := &models.Location{Name: "Centro", Address: "Rua Augusta, 100"}
+			require.NoError(t, repo.Create(location))
+			require.NoError(t, repo.SetStock(&models.LocationStock{LocationID: location.ID, CupcakeID: 1, Quantity: 3}))
+
+			err := repo.Delete(tt.id)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			_, err = repo.FindByID(tt.id)
+			require.ErrorIs(t, err, ErrNotFound)
+			stock, err := repo.FindStock(tt.id)
+			require.NoError(t, err)
+			require.Empty(t, stock)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+var _ NotificationPreferenceRepositoryInterface = (*NotificationPreferenceRepository)(nil)
+
+func NewNotificationPreferenceRepository(db *gorm.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+func (r *NotificationPreferenceRepository) FindByAccount(accountID uint) ([]models.NotificationPreference, error) {
+	var preferences []models.NotificationPreference
+	err := r.db.Where("account_id = ?", accountID).Order("event").Find(&preferences).Error
+	return preferences, translateError(err)
+}
+
+// Set creates or replaces the preferences given, in one statement.
+func (r *NotificationPreferenceRepository) Set(preferences []models.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "event"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "sms", "push", "updated_at"}),
+	}).Create(&preferences).Error
+	return translateError(err)
+}
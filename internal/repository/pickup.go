@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSlotFull          = errors.New("pickup slot is full")
+	ErrReservationClosed = errors.New("pickup reservation is started, ready or cancelled")
+)
+
+type PickupRepository struct {
+	db *gorm.DB
+}
+
+var _ PickupRepositoryInterface = (*PickupRepository)(nil)
+
+func NewPickupRepository(db *gorm.DB) *PickupRepository {
+	return &PickupRepository{db: db}
+}
+
+func (r *PickupRepository) CreateSlot(slot *models.PickupSlot) error {
+	return translateError(r.db.Create(slot).Error)
+}
+
+func (r *PickupRepository) FindSlot(id uint) (*models.PickupSlot, error) {
+	var slot models.PickupSlot
+	err := r.db.First(&slot, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &slot, nil
+}
+
+// FindSlots returns the location's slots starting in [from, to).
+func (r *PickupRepository) FindSlots(locationID uint, from, to time.Time) ([]models.PickupSlot, error) {
+	var slots []models.PickupSlot
+	err := r.db.
+		Where("location_id = ? AND starts_at >= ? AND starts_at < ?", locationID, from, to).
+		Order("starts_at").
+		Find(&slots).Error
+	return slots, translateError(err)
+}
+
+// Reserve books a place in the slot and stores the reservation with its
+// items. The capacity check and the booking are one conditional update, so
+// concurrent reservations can never overfill a slot.
+func (r *PickupRepository) Reserve(reservation *models.PickupReservation) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PickupSlot{}).
+			Where("id = ? AND booked < capacity", reservation.SlotID).
+			UpdateColumn("booked", gorm.Expr("booked + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSlotFull
+		}
+		return tx.Create(reservation).Error
+	})
+	return translateError(err)
+}
+
+// FindReservationsBetween lists the reservations not cancelled, with their
+// items, for slots at any location starting in [from, to).
+func (r *PickupRepository) FindReservationsBetween(from, to time.Time) ([]models.PickupReservation, error) {
+	var reservations []models.PickupReservation
+	err := r.db.Preload("Items").
+		Joins("JOIN pickup_slots ON pickup_slots.id = pickup_reservations.slot_id").
+		Where("pickup_slots.starts_at >= ? AND pickup_slots.starts_at < ?", from, to).
+		Where("pickup_reservations.cancelled_at IS NULL").
+		Order("pickup_reservations.id").
+		Find(&reservations).Error
+	return reservations, translateError(err)
+}
+
+// FindOpenReservations lists the reservations neither ready nor cancelled,
+// with their items, for slots at any location starting in [from, to), in
+// the order the slots start.
+func (r *PickupRepository) FindOpenReservations(from, to time.Time) ([]models.PickupReservation, error) {
+	var reservations []models.PickupReservation
+	err := r.db.Preload("Items").
+		Joins("JOIN pickup_slots ON pickup_slots.id = pickup_reservations.slot_id").
+		Where("pickup_slots.starts_at >= ? AND pickup_slots.starts_at < ?", from, to).
+		Where("pickup_reservations.ready_at IS NULL AND pickup_reservations.cancelled_at IS NULL").
+		Order("pickup_slots.starts_at, pickup_reservations.id").
+		Find(&reservations).Error
+	return reservations, translateError(err)
+}
+
+func (r *PickupRepository) FindReservation(id uint) (*models.PickupReservation, error) {
+	var reservation models.PickupReservation
+	if err := r.db.Preload("Items").First(&reservation, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &reservation, nil
+}
+
+func (r *PickupRepository) FindReservations(slotIDs []uint) ([]models.PickupReservation, error) {
+	var reservations []models.PickupReservation
+	if len(slotIDs) == 0 {
+		return reservations, nil
+	}
+	err := r.db.Preload("Items").Where("slot_id IN ?", slotIDs).Order("id").Find(&reservations).Error
+	return reservations, translateError(err)
+}
+
+// MarkReady records when the reservation's order was ready. A cancelled
+// reservation fails with ErrReservationClosed.
+func (r *PickupRepository) MarkReady(id uint, at time.Time) error {
+	result := r.db.Model(&models.PickupReservation{}).Where("id = ? AND cancelled_at IS NULL", id).Update("ready_at", at)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return r.missingOrClosed(id)
+	}
+	return nil
+}
+
+// MarkStarted records when the kitchen started the reservation's order. An
+// order already ready or cancelled fails with ErrReservationClosed.
+func (r *PickupRepository) MarkStarted(id uint, at time.Time) error {
+	result := r.db.Model(&models.PickupReservation{}).
+		Where("id = ? AND ready_at IS NULL AND cancelled_at IS NULL", id).
+		Update("started_at", at)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return r.missingOrClosed(id)
+	}
+	return nil
+}
+
+// missingOrClosed tells why a conditional update of a reservation changed
+// nothing: ErrNotFound when it does not exist, ErrReservationClosed when
+// it was not in a state to change.
+func (r *PickupRepository) missingOrClosed(id uint) error {
+	var count int64
+	if err := r.db.Model(&models.PickupReservation{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return translateError(err)
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return ErrReservationClosed
+}
+
+// Cancel records the reservation as cancelled for reason and frees its
+// place in the slot. Both happen in one transaction, and only while the
+// kitchen has not started the order and it is not cancelled; otherwise it
+// fails with ErrReservationClosed.
+func (r *PickupRepository) Cancel(id uint, reason string, at time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var reservation models.PickupReservation
+		if err := tx.Select("id", "slot_id").First(&reservation, id).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.PickupReservation{}).
+			Where("id = ? AND started_at IS NULL AND ready_at IS NULL AND cancelled_at IS NULL", id).
+			Updates(map[string]any{"cancelled_at": at, "cancellation_reason": reason})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrReservationClosed
+		}
+		return tx.Model(&models.PickupSlot{}).
+			Where("id = ? AND booked > 0", reservation.SlotID).
+			UpdateColumn("booked", gorm.Expr("booked - 1")).Error
+	})
+	return translateError(err)
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickupRepository_Reserve(t *testing.T) {
+	tests := []struct {
+		name          string
+		booked        int
+		expectedError error
+	}{
+		{name: "room left", booked: 1},
+		{name: "slot full", booked: 2, expectedError: ErrSlotFull},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewPickupRepository(db)
+
+			start := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+			slot := &models.PickupSlot{LocationID: 1, StartsAt: start, EndsAt: start.Add(30 * time.Minute), Capacity: 2, Booked: tt.booked}
+			require.NoError(t, repo.CreateSlot(slot))
+
+			reservation := &models.PickupReservation{
+				SlotID:        slot.ID,
+				CustomerName:  "Ana",
+				CustomerEmail: "ana@example.com",
+				Items:         []models.PickupReservationItem{{CupcakeID: 1, Quantity: 2}},
+			}
+			err := repo.Reserve(reservation)
+
+			stored, findErr := repo.FindSlot(slot.ID)
+			require.NoError(t, findErr)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				require.Equal(t, tt.booked, stored.Booked)
+				reservations, err := repo.FindReservations([]uint{slot.ID})
+				require.NoError(t, err)
+				require.Empty(t, reservations)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.booked+1, stored.Booked)
+
+			reservations, err := repo.FindReservations([]uint{slot.ID})
+			require.NoError(t, err)
+			require.Len(t, reservations, 1)
+			require.Len(t, reservations[0].Items, 1)
+			require.Equal(t, 2, reservations[0].Items[0].Quantity)
+		})
+	}
+}
+
+func TestPickupRepository_FindSlots(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPickupRepository(db)
+
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	for _, slot := range []models.PickupSlot{
+		{LocationID: 1, StartsAt: day.Add(14 * time.Hour), EndsAt: day.Add(15 * time.Hour), Capacity: 1},
+		{LocationID: 1, StartsAt: day.Add(9 * time.Hour), EndsAt: day.Add(10 * time.Hour), Capacity: 1},
+		{LocationID: 1, StartsAt: day.Add(33 * time.Hour), EndsAt: day.Add(34 * time.Hour), Capacity: 1},
+		{LocationID: 2, StartsAt: day.Add(9 * time.Hour), EndsAt: day.Add(10 * time.Hour), Capacity: 1},
+	} {
+		require.NoError(t, repo.CreateSlot(&slot))
+	}
+
+	slots, err := repo.FindSlots(1, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, slots, 2)
+	require.Equal(t, 9, slots[0].StartsAt.UTC().Hour())
+	require.Equal(t, 14, slots[1].StartsAt.UTC().Hour())
+}
+
+func TestPickupRepository_Cancel(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPickupRepository(db)
+
+	start := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	slot := &models.PickupSlot{LocationID: 1, StartsAt: start, EndsAt: start.Add(30 * time.Minute), Capacity: 2}
+	require.NoError(t, repo.CreateSlot(slot))
+	for _, name := range []string{"Ana", "Bia"} {
+		require.NoError(t, repo.Reserve(&models.PickupReservation{
+			SlotID:        slot.ID,
+			CustomerName:  name,
+			CustomerEmail: "cliente@example.com",
+			Items:         []models.PickupReservationItem{{CupcakeID: 1, Quantity: 1}},
+		}))
+	}
+	require.NoError(t, repo.MarkReady(2, start))
+
+	require.NoError(t, repo.Cancel(1, models.CancellationStoreClosed, start))
+	cancelled, err := repo.FindReservation(1)
+	require.NoError(t, err)
+	require.NotNil(t, cancelled.CancelledAt)
+	require.Equal(t, models.CancellationStoreClosed, cancelled.CancellationReason)
+	stored, err := repo.FindSlot(slot.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, stored.Booked)
+
+	// Neither a cancelled nor a ready order can be cancelled, and a
+	// cancelled one cannot be marked ready.
+	require.ErrorIs(t, repo.Cancel(1, models.CancellationOther, start), ErrReservationClosed)
+	require.ErrorIs(t, repo.Cancel(2, models.CancellationOther, start), ErrReservationClosed)
+	require.ErrorIs(t, repo.MarkReady(1, start), ErrReservationClosed)
+	require.ErrorIs(t, repo.Cancel(999, models.CancellationOther, start), ErrNotFound)
+	require.ErrorIs(t, repo.MarkReady(999, start), ErrNotFound)
+	stored, err = repo.FindSlot(slot.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, stored.Booked)
+
+	between, err := repo.FindReservationsBetween(start.Add(-time.Hour), start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, between, 1)
+	require.Equal(t, uint(2), between[0].ID)
+}
+
+func TestPickupRepository_FindOpenReservations(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPickupRepository(db)
+
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	late := &models.PickupSlot{LocationID: 1, StartsAt: day.Add(15 * time.Hour), EndsAt: day.Add(16 * time.Hour), Capacity: 5}
+	early := &models.PickupSlot{LocationID: 2, StartsAt: day.Add(9 * time.Hour), EndsAt: day.Add(10 * time.Hour), Capacity: 5}
+	nextDay := &models.PickupSlot{LocationID: 1, StartsAt: day.Add(33 * time.Hour), EndsAt: day.Add(34 * time.Hour), Capacity: 5}
+	for _, slot := range []*models.PickupSlot{late, early, nextDay} {
+		require.NoError(t, repo.CreateSlot(slot))
+	}
+	for _, slotID := range []uint{late.ID, late.ID, early.ID, early.ID, nextDay.ID} {
+		require.NoError(t, repo.Reserve(&models.PickupReservation{
+			SlotID:        slotID,
+			CustomerName:  "Ana",
+			CustomerEmail: "ana@example.com",
+			Items:         []models.PickupReservationItem{{CupcakeID: 1, Quantity: 1}},
+		}))
+	}
+	require.NoError(t, repo.MarkStarted(1, day))
+	require.NoError(t, repo.MarkReady(2, day))
+	require.NoError(t, repo.Cancel(4, models.CancellationOther, day))
+
+	open, err := repo.FindOpenReservations(day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, open, 2)
+	require.Equal(t, uint(3), open[0].ID)
+	require.Equal(t, uint(1), open[1].ID)
+	require.NotNil(t, open[1].StartedAt)
+	require.Len(t, open[1].Items, 1)
+
+	// A started order can be made ready but no longer cancelled; a ready
+	// one cannot be started.
+	require.ErrorIs(t, repo.Cancel(1, models.CancellationOther, day), ErrReservationClosed)
+	require.ErrorIs(t, repo.MarkStarted(2, day), ErrReservationClosed)
+	require.ErrorIs(t, repo.MarkStarted(999, day), ErrNotFound)
+	require.NoError(t, repo.MarkReady(1, day))
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrPurchaseOrderNotOpen = errors.New("purchase order is not open")
+
+type SupplierRepository struct {
+	db *gorm.DB
+}
+
+var _ SupplierRepositoryInterface = (*SupplierRepository)(nil)
+
+func NewSupplierRepository(db *gorm.DB) *SupplierRepository {
+	return &SupplierRepository{db: db}
+}
+
+func (r *SupplierRepository) Create(supplier *models.Supplier) error {
+	return translateError(r.db.Create(supplier).Error)
+}
+
+func (r *SupplierRepository) FindByID(id uint) (*models.Supplier, error) {
+	var supplier models.Supplier
+	err := r.db.First(&supplier, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &supplier, nil
+}
+
+func (r *SupplierRepository) FindByName(name string) (*models.Supplier, error) {
+	var supplier models.Supplier
+	err := r.db.Where("name = ?", name).First(&supplier).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &supplier, nil
+}
+
+func (r *SupplierRepository) FindAll() ([]models.Supplier, error) {
+	var suppliers []models.Supplier
+	err := r.db.Order("name").Find(&suppliers).Error
+	return suppliers, translateError(err)
+}
+
+func (r *SupplierRepository) Update(supplier *models.Supplier) error {
+	return translateError(r.db.Save(supplier).Error)
+}
+
+type IngredientRepository struct {
+	db *gorm.DB
+}
+
+var _ IngredientRepositoryInterface = (*IngredientRepository)(nil)
+
+func NewIngredientRepository(db *gorm.DB) *IngredientRepository {
+	return &IngredientRepository{db: db}
+}
+
+func (r *IngredientRepository) Create(ingredient *models.Ingredient) error {
+	return translateError(r.db.Create(ingredient).Error)
+}
+
+func (r *IngredientRepository) FindByID(id uint) (*models.Ingredient, error) {
+	var ingredient models.Ingredient
+	err := r.db.First(&ingredient, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &ingredient, nil
+}
+
+func (r *IngredientRepository) FindByIDs(ids []uint) ([]models.Ingredient, error) {
+	var ingredients []models.Ingredient
+	if len(ids) == 0 {
+		return ingredients, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&ingredients).Error
+	return ingredients, translateError(err)
+}
+
+func (r *IngredientRepository) FindByName(name string) (*models.Ingredient, error) {
+	var ingredient models.Ingredient
+	err := r.db.Where("name = ?", name).First(&ingredient).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &ingredient, nil
+}
+
+func (r *IngredientRepository) FindAll() ([]models.Ingredient, error) {
+	var ingredients []models.Ingredient
+	err := r.db.Order("name").Find(&ingredients).Error
+	return ingredients, translateError(err)
+}
+
+// Update saves the ingredient's details. Stock and cost are left alone;
+// they only move through purchase orders and production.
+func (r *IngredientRepository) Update(ingredient *models.Ingredient) error {
+	return translateError(r.db.Model(ingredient).Select("name", "unit", "updated_at").Updates(ingredient).Error)
+}
+
+type PurchaseOrderRepository struct {
+	db *gorm.DB
+}
+
+var _ PurchaseOrderRepositoryInterface = (*PurchaseOrderRepository)(nil)
+
+func NewPurchaseOrderRepository(db *gorm.DB) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{db: db}
+}
+
+func (r *PurchaseOrderRepository) Create(order *models.PurchaseOrder) error {
+	return translateError(r.db.Create(order).Error)
+}
+
+func (r *PurchaseOrderRepository) FindByID(id uint) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	err := r.db.Preload("Lines").First(&order, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &order, nil
+}
+
+// FindAll lists purchase orders, newest first, optionally only those in
+// the given status.
+func (r *PurchaseOrderRepository) FindAll(status string) ([]models.PurchaseOrder, error) {
+	var orders []models.PurchaseOrder
+	query := r.db.Preload("Lines").Order("id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&orders).Error
+	return orders, translateError(err)
+}
+
+// Receive marks an open order received and adds its lines to ingredient
+// stock, recording each line's unit cost as the ingredient's latest cost,
+// in one transaction. The status change is conditional, so an order can
+// only ever be received once.
+func (r *PurchaseOrderRepository) Receive(order *models.PurchaseOrder, at time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PurchaseOrder{}).
+			Where("id = ? AND status = ?", order.ID, models.PurchaseOrderOpen).
+			Updates(map[string]interface{}{"status": models.PurchaseOrderReceived, "received_at": at})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPurchaseOrderNotOpen
+		}
+
+		for _, line := range order.Lines {
+			err := tx.Model(&models.Ingredient{}).
+				Where("id = ?", line.IngredientID).
+				UpdateColumns(map[string]interface{}{
+					"stock_quantity":  gorm.Expr("stock_quantity + ?", line.Quantity),
+					"unit_cost_cents": line.UnitCostCents,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return translateError(err)
+}
+
+func (r *PurchaseOrderRepository) Cancel(id uint) error {
+	result := r.db.Model(&models.PurchaseOrder{}).
+		Where("id = ? AND status = ?", id, models.PurchaseOrderOpen).
+		Update("status", models.PurchaseOrderCancelled)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPurchaseOrderNotOpen
+	}
+	return nil
+}
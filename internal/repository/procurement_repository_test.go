@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurchaseOrderRepository_Receive(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		expectedStock int
+		expectedError error
+	}{
+		{name: "open order", status: models.PurchaseOrderOpen, expectedStock: 1500},
+		{name: "already received", status: models.PurchaseOrderReceived, expectedStock: 500, expectedError: ErrPurchaseOrderNotOpen},
+		{name: "cancelled", status: models.PurchaseOrderCancelled, expectedStock: 500, expectedError: ErrPurchaseOrderNotOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			ingredients := NewIngredientRepository(db)
+			orders := NewPurchaseOrderRepository(db)
+
+			flour := &models.Ingredient{Name: "Flour", Unit: "g", StockQuantity: 500}
+			require.NoError(t, ingredients.Create(flour))
+			order := &models.PurchaseOrder{
+				SupplierID: 1,
+				Status:     tt.status,
+				Lines:      []models.PurchaseOrderLine{{IngredientID: flour.ID, Quantity: 1000, UnitCostCents: 1}},
+			}
+			require.NoError(t, orders.Create(order))
+
+			err := orders.Receive(order, time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			stored, err := ingredients.FindByID(flour.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedStock, stored.StockQuantity)
+			if tt.expectedError == nil {
+				require.Equal(t, 1, stored.UnitCostCents)
+			}
+		})
+	}
+}
+
+func TestIngredientRepository_UpdateKeepsStock(t *testing.T) {
+	repo := NewIngredientRepository(setupTestDB(t))
+
+	flour := &models.Ingredient{Name: "Flour", Unit: "g", StockQuantity: 500}
+	require.NoError(t, repo.Create(flour))
+
+	flour.Name = "Wheat flour"
+	flour.StockQuantity = 0
+	require.NoError(t, repo.Update(flour))
+
+	stored, err := repo.FindByID(flour.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Wheat flour", stored.Name)
+	require.Equal(t, 500, stored.StockQuantity)
+}
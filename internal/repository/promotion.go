@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type PromotionRepository struct {
+	db *gorm.DB
+}
+
+var _ PromotionRepositoryInterface = (*PromotionRepository)(nil)
+
+func NewPromotionRepository(db *gorm.DB) *PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+func (r *PromotionRepository) Create(promotion *models.Promotion) error {
+	return translateError(r.db.Create(promotion).Error)
+}
+
+func (r *PromotionRepository) FindByID(id uint) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := r.db.First(&promotion, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &promotion, nil
+}
+
+func (r *PromotionRepository) FindAll() ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := r.db.Order("starts_at").Find(&promotions).Error
+	return promotions, translateError(err)
+}
+
+func (r *PromotionRepository) FindActive(cupcakeIDs []uint, at time.Time) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	if len(cupcakeIDs) == 0 {
+		return promotions, nil
+	}
+	err := r.db.
+		Where("cupcake_id IN ? AND starts_at <= ? AND ends_at > ?", cupcakeIDs, at, at).
+		Find(&promotions).Error
+	return promotions, translateError(err)
+}
+
+func (r *PromotionRepository) FindAllActive(at time.Time) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := r.db.Where("starts_at <= ? AND ends_at > ?", at, at).Find(&promotions).Error
+	return promotions, translateError(err)
+}
+
+func (r *PromotionRepository) Update(promotion *models.Promotion) error {
+	return translateError(r.db.Save(promotion).Error)
+}
+
+func (r *PromotionRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Promotion{}, id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromotionRepository_FindActive(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		promotions    []*models.Promotion
+		cupcakeIDs    []uint
+		expectedCount int
+	}{
+		{
+			name:          "no cupcake IDs returns empty list",
+			cupcakeIDs:    []uint{},
+			expectedCount: 0,
+		},
+		{
+			name: "returns only promotions inside the window",
+			promotions: []*models.Promotion{
+				{CupcakeID: 1, DiscountType: models.DiscountTypePercentage, DiscountValue: 10, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+				{CupcakeID: 1, DiscountType: models.DiscountTypePercentage, DiscountValue: 20, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+				{CupcakeID: 1, DiscountType: models.DiscountTypePercentage, DiscountValue: 30, StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)},
+			},
+			cupcakeIDs:    []uint{1},
+			expectedCount: 1,
+		},
+		{
+			name: "filters by cupcake ID",
+			promotions: []*models.Promotion{
+				{CupcakeID: 1, DiscountType: models.DiscountTypeFixed, DiscountValue: 100, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+				{CupcakeID: 2, DiscountType: models.DiscountTypeFixed, DiscountValue: 100, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+			},
+			cupcakeIDs:    []uint{2},
+			expectedCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := NewPromotionRepository(db)
+
+			for _, promotion := range tt.promotions {
+				require.NoError(t, repo.Create(promotion))
+			}
+
+			promotions, err := repo.FindActive(tt.cupcakeIDs, now)
+			require.NoError(t, err)
+			require.Len(t, promotions, tt.expectedCount)
+		})
+	}
+}
+
+func TestPromotionRepository_FindAllActive(t *testing.T) {
+	now := time.Now()
+	db := setupTestDB(t)
+	repo := NewPromotionRepository(db)
+
+	for _, promotion := range []*models.Promotion{
+		{CupcakeID: 1, DiscountType: models.DiscountTypePercentage, DiscountValue: 10, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{CupcakeID: 2, DiscountType: models.DiscountTypeFixed, DiscountValue: 100, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{CupcakeID: 3, DiscountType: models.DiscountTypeFixed, DiscountValue: 100, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+	} {
+		require.NoError(t, repo.Create(promotion))
+	}
+
+	promotions, err := repo.FindAllActive(now)
+	require.NoError(t, err)
+	require.Len(t, promotions, 2)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrInsufficientIngredients = errors.New("not enough ingredients in stock")
+
+type RecipeRepository struct {
+	db *gorm.DB
+}
+
+var _ RecipeRepositoryInterface = (*RecipeRepository)(nil)
+
+func NewRecipeRepository(db *gorm.DB) *RecipeRepository {
+	return &RecipeRepository{db: db}
+}
+
+func (r *RecipeRepository) FindRecipe(cupcakeID uint) ([]models.RecipeIngredient, error) {
+	var lines []models.RecipeIngredient
+	err := r.db.Where("cupcake_id = ?", cupcakeID).Order("ingredient_id").Find(&lines).Error
+	return lines, translateError(err)
+}
+
+// SetRecipe replaces a cupcake's recipe.
+func (r *RecipeRepository) SetRecipe(cupcakeID uint, lines []models.RecipeIngredient) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("cupcake_id = ?", cupcakeID).Delete(&models.RecipeIngredient{}).Error; err != nil {
+			return err
+		}
+		for i := range lines {
+			lines[i].ID = 0
+			lines[i].CupcakeID = cupcakeID
+		}
+		return tx.Create(&lines).Error
+	})
+	return translateError(err)
+}
+
+// RecordProduction takes the batch's ingredients out of stock and saves
+// the batch in one transaction. Each decrement is conditional on enough
+// stock being left, so concurrent batches can never drive stock negative.
+func (r *RecipeRepository) RecordProduction(batch *models.ProductionBatch, lines []models.RecipeIngredient) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, line := range lines {
+			needed := line.Quantity * batch.Quantity
+			result := tx.Model(&models.Ingredient{}).
+				Where("id = ? AND stock_quantity >= ?", line.IngredientID, needed).
+				UpdateColumn("stock_quantity", gorm.Expr("stock_quantity - ?", needed))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrInsufficientIngredients
+			}
+		}
+		return tx.Create(batch).Error
+	})
+	return translateError(err)
+}
+
+func (r *RecipeRepository) FindBatches(cupcakeID uint) ([]models.ProductionBatch, error) {
+	var batches []models.ProductionBatch
+	err := r.db.Where("cupcake_id = ?", cupcakeID).Order("id DESC").Find(&batches).Error
+	return batches, translateError(err)
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecipeRepository_RecordProduction(t *testing.T) {
+	tests := []struct {
+		name           string
+		quantity       int
+		expectedFlour  int
+		expectedButter int
+		expectedError  error
+	}{
+		{name: "enough stock", quantity: 4, expectedFlour: 300, expectedButter: 20},
+		{name: "second ingredient runs short", quantity: 6, expectedFlour: 500, expectedButter: 100, expectedError: ErrInsufficientIngredients},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			ingredients := NewIngredientRepository(db)
+			repo := NewRecipeRepository(db)
+
+			flour := &models.Ingredient{Name: "Flour", Unit: "g", StockQuantity: 500}
+			butter := &models.Ingredient{Name: "Butter", Unit: "g", StockQuantity: 100}
+			require.NoError(t, ingredients.Create(flour))
+			require.NoError(t, ingredients.Create(butter))
+			require.NoError(t, repo.SetRecipe(1, []models.RecipeIngredient{
+				{IngredientID: flour.ID, Quantity: 50},
+				{IngredientID: butter.ID, Quantity: 20},
+			}))
+			lines, err := repo.FindRecipe(1)
+			require.NoError(t, err)
+			require.Len(t, lines, 2)
+
+			err = repo.RecordProduction(&models.ProductionBatch{CupcakeID: 1, Quantity: tt.quantity}, lines)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			storedFlour, err := ingredients.FindByID(flour.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedFlour, storedFlour.StockQuantity, "a refused batch takes nothing out of stock")
+			storedButter, err := ingredients.FindByID(butter.ID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedButter, storedButter.StockQuantity)
+
+			batches, err := repo.FindBatches(1)
+			require.NoError(t, err)
+			if tt.expectedError != nil {
+				require.Empty(t, batches)
+			} else {
+				require.Len(t, batches, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+var _ SessionRepositoryInterface = (*SessionRepository)(nil)
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(session *models.Session) error {
+	return translateError(r.db.Create(session).Error)
+}
+
+func (r *SessionRepository) FindByTokenHash(hash string) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.Where("token_hash = ?", hash).First(&session).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &session, nil
+}
+
+func (r *SessionRepository) Extend(id uint, expiresAt time.Time) error {
+	return translateError(r.db.Model(&models.Session{}).Where("id = ?", id).Update("expires_at", expiresAt).Error)
+}
+
+// Delete ends a session; one already gone is not an error.
+func (r *SessionRepository) Delete(id uint) error {
+	return translateError(r.db.Delete(&models.Session{}, id).Error)
+}
+
+// DeleteExpired drops the sessions that expired before now.
+func (r *SessionRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.Session{})
+	return result.RowsAffected, translateError(result.Error)
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SettingRepository struct {
+	db *gorm.DB
+}
+
+var _ SettingRepositoryInterface = (*SettingRepository)(nil)
+
+func NewSettingRepository(db *gorm.DB) *SettingRepository {
+	return &SettingRepository{db: db}
+}
+
+func (r *SettingRepository) FindAll() ([]models.Setting, error) {
+	var settings []models.Setting
+	err := r.db.Order("key").Find(&settings).Error
+	return settings, translateError(err)
+}
+
+// Set creates or replaces the value stored for the setting's key.
+func (r *SettingRepository) Set(setting *models.Setting) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(setting).Error
+	return translateError(err)
+}
+
+func (r *SettingRepository) Delete(key string) error {
+	result := r.db.Where("key = ?", key).Delete(&models.Setting{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingRepository(t *testing.T) {
+	repo := NewSettingRepository(setupTestDB(t))
+
+	require.NoError(t, repo.Set(&models.Setting{Key: "maintenance_mode", Value: "true"}))
+	require.NoError(t, repo.Set(&models.Setting{Key: "default_currency", Value: `"USD"`}))
+
+	// Setting an existing key replaces its value instead of adding a row.
+	require.NoError(t, repo.Set(&models.Setting{Key: "maintenance_mode", Value: "false"}))
+
+	settings, err := repo.FindAll()
+	require.NoError(t, err)
+	require.Len(t, settings, 2)
+	require.Equal(t, "default_currency", settings[0].Key)
+	require.Equal(t, "maintenance_mode", settings[1].Key)
+	require.Equal(t, "false", settings[1].Value)
+
+	require.NoError(t, repo.Delete("maintenance_mode"))
+	require.ErrorIs(t, repo.Delete("maintenance_mode"), ErrNotFound)
+
+	settings, err = repo.FindAll()
+	require.NoError(t, err)
+	require.Len(t, settings, 1)
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type StockAlertRepository struct {
+	db *gorm.DB
+}
+
+var _ StockAlertRepositoryInterface = (*StockAlertRepository)(nil)
+
+func NewStockAlertRepository(db *gorm.DB) *StockAlertRepository {
+	return &StockAlertRepository{db: db}
+}
+
+func (r *StockAlertRepository) Create(alert *models.StockAlert) error {
+	return translateError(r.db.Create(alert).Error)
+}
+
+func (r *StockAlertRepository) FindByID(id uint) (*models.StockAlert, error) {
+	var alert models.StockAlert
+	if err := r.db.First(&alert, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &alert, nil
+}
+
+// FindAll lists the alerts with status, or all of them when it is empty,
+// newest first.
+func (r *StockAlertRepository) FindAll(status string) ([]models.StockAlert, error) {
+	query := r.db.Order("id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var alerts []models.StockAlert
+	err := query.Find(&alerts).Error
+	return alerts, translateError(err)
+}
+
+// FindUnresolved lists the open and acknowledged alerts.
+func (r *StockAlertRepository) FindUnresolved() ([]models.StockAlert, error) {
+	var alerts []models.StockAlert
+	err := r.db.Where("status <> ?", models.StockAlertResolved).Order("id").Find(&alerts).Error
+	return alerts, translateError(err)
+}
+
+// FindLatest returns the last alert raised for a cupcake at a location.
+func (r *StockAlertRepository) FindLatest(locationID, cupcakeID uint) (*models.StockAlert, error) {
+	var alert models.StockAlert
+	err := r.db.Where("location_id = ? AND cupcake_id = ?", locationID, cupcakeID).
+		Order("id DESC").
+		First(&alert).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &alert, nil
+}
+
+func (r *StockAlertRepository) Update(alert *models.StockAlert) error {
+	return translateError(r.db.Save(alert).Error)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+var _ SubscriptionRepositoryInterface = (*SubscriptionRepository)(nil)
+
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+func (r *SubscriptionRepository) Create(subscription *models.Subscription) error {
+	return translateError(r.db.Create(subscription).Error)
+}
+
+func (r *SubscriptionRepository) FindByID(id uint) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.First(&subscription, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &subscription, nil
+}
+
+func (r *SubscriptionRepository) FindAll() ([]models.Subscription, error) {
+	var subscriptions []models.Subscription
+	err := r.db.Find(&subscriptions).Error
+	return subscriptions, translateError(err)
+}
+
+// FindScheduled lists the active subscriptions whose next delivery falls in
+// [from, to).
+func (r *SubscriptionRepository) FindScheduled(from, to time.Time) ([]models.Subscription, error) {
+	var subscriptions []models.Subscription
+	err := r.db.
+		Where("status = ? AND next_delivery_at >= ? AND next_delivery_at < ?", models.SubscriptionActive, from, to).
+		Order("next_delivery_at").
+		Find(&subscriptions).Error
+	return subscriptions, translateError(err)
+}
+
+func (r *SubscriptionRepository) Update(subscription *models.Subscription) error {
+	return translateError(r.db.Save(subscription).Error)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type TicketRepository struct {
+	db *gorm.DB
+}
+
+var _ TicketRepositoryInterface = (*TicketRepository)(nil)
+
+func NewTicketRepository(db *gorm.DB) *TicketRepository {
+	return &TicketRepository{db: db}
+}
+
+func (r *TicketRepository) Create(ticket *models.Ticket) error {
+	return translateError(r.db.Create(ticket).Error)
+}
+
+func (r *TicketRepository) FindByID(id uint) (*models.Ticket, error) {
+	var ticket models.Ticket
+	if err := r.db.First(&ticket, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &ticket, nil
+}
+
+// FindAll lists the tickets with status, or all of them when it is empty,
+// newest first.
+func (r *TicketRepository) FindAll(status string) ([]models.Ticket, error) {
+	query := r.db.Order("id DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var tickets []models.Ticket
+	err := query.Find(&tickets).Error
+	return tickets, translateError(err)
+}
+
+func (r *TicketRepository) Update(ticket *models.Ticket) error {
+	return translateError(r.db.Save(ticket).Error)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TranslationRepository struct {
+	db *gorm.DB
+}
+
+var _ TranslationRepositoryInterface = (*TranslationRepository)(nil)
+
+func NewTranslationRepository(db *gorm.DB) *TranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+// Set creates or replaces the translation of a cupcake for its locale.
+func (r *TranslationRepository) Set(translation *models.CupcakeTranslation) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cupcake_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "flavor", "description", "updated_at"}),
+	}).Create(translation).Error
+	return translateError(err)
+}
+
+func (r *TranslationRepository) Delete(cupcakeID uint, locale string) error {
+	result := r.db.Where("cupcake_id = ? AND locale = ?", cupcakeID, locale).Delete(&models.CupcakeTranslation{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *TranslationRepository) FindByCupcake(cupcakeID uint) ([]models.CupcakeTranslation, error) {
+	var translations []models.CupcakeTranslation
+	err := r.db.Where("cupcake_id = ?", cupcakeID).Order("locale").Find(&translations).Error
+	return translations, translateError(err)
+}
+
+// FindForCupcakes returns the translations of the given cupcakes in any of
+// the given locales, in one query.
+func (r *TranslationRepository) FindForCupcakes(cupcakeIDs []uint, locales []string) ([]models.CupcakeTranslation, error) {
+	var translations []models.CupcakeTranslation
+	if len(cupcakeIDs) == 0 || len(locales) == 0 {
+		return translations, nil
+	}
+	err := r.db.Where("cupcake_id IN ? AND locale IN ?", cupcakeIDs, locales).Find(&translations).Error
+	return translations, translateError(err)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslationRepository(t *testing.T) {
+	repo := NewTranslationRepository(setupTestDB(t))
+
+	require.NoError(t, repo.Set(&models.CupcakeTranslation{CupcakeID: 1, Locale: "en", Name: "Chocolate", Flavor: "Chocolate", Description: "Chocolate frosting"}))
+	require.NoError(t, repo.Set(&models.CupcakeTranslation{CupcakeID: 1, Locale: "es", Name: "Chocolate", Flavor: "Chocolate"}))
+	require.NoError(t, repo.Set(&models.CupcakeTranslation{CupcakeID: 2, Locale: "en", Name: "Strawberry", Flavor: "Strawberry"}))
+
+	// Setting an existing locale replaces it instead of adding a row.
+	require.NoError(t, repo.Set(&models.CupcakeTranslation{CupcakeID: 1, Locale: "en", Name: "Dark Chocolate", Flavor: "Dark chocolate", Description: "Dark chocolate frosting"}))
+
+	translations, err := repo.FindByCupcake(1)
+	require.NoError(t, err)
+	require.Len(t, translations, 2)
+	require.Equal(t, "en", translations[0].Locale)
+	require.Equal(t, "Dark Chocolate", translations[0].Name)
+	require.Equal(t, "Dark chocolate frosting", translations[0].Description)
+	require.Equal(t, "es", translations[1].Locale)
+
+	tests := []struct {
+		name       string
+		cupcakeIDs []uint
+		locales    []string
+		expected   int
+	}{
+		{name: "both cupcakes", cupcakeIDs: []uint{1, 2}, locales: []string{"en"}, expected: 2},
+		{name: "several locales", cupcakeIDs: []uint{1}, locales: []string{"en", "es", "fr"}, expected: 2},
+		{name: "missing locale", cupcakeIDs: []uint{2}, locales: []string{"es"}, expected: 0},
+		{name: "no locales", cupcakeIDs: []uint{1, 2}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.FindForCupcakes(tt.cupcakeIDs, tt.locales)
+			require.NoError(t, err)
+			require.Len(t, found, tt.expected)
+		})
+	}
+
+	require.NoError(t, repo.Delete(1, "es"))
+	err = repo.Delete(1, "es")
+	require.ErrorIs(t, err, ErrNotFound)
+}
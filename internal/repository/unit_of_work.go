@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repositories is the set of repositories handed to a unit of work. Inside
+// WithTransaction every one of them runs in the same transaction, so a
+// service can compose several without touching *gorm.DB itself.
+type Repositories struct {
+	Cupcakes       CupcakeRepositoryInterface
+	Coupons        CouponRepositoryInterface
+	Promotions     PromotionRepositoryInterface
+	GiftCards      GiftCardRepositoryInterface
+	Subscriptions  SubscriptionRepositoryInterface
+	Webhooks       WebhookRepositoryInterface
+	Locations      LocationRepositoryInterface
+	Bundles        BundleRepositoryInterface
+	Wholesale      WholesaleRepositoryInterface
+	Suppliers      SupplierRepositoryInterface
+	Ingredients    IngredientRepositoryInterface
+	PurchaseOrders PurchaseOrderRepositoryInterface
+	Recipes        RecipeRepositoryInterface
+	Translations   TranslationRepositoryInterface
+	Views          ViewRepositoryInterface
+	Addons         AddonRepositoryInterface
+	CustomOptions  CustomOptionRepositoryInterface
+	Pickups        PickupRepositoryInterface
+	Jobs           JobRepositoryInterface
+}
+
+// UnitOfWork runs multi-step operations atomically.
+type UnitOfWork interface {
+	// WithTransaction calls fn with repositories bound to a new transaction,
+	// committing it when fn returns nil and rolling it back otherwise.
+	WithTransaction(ctx context.Context, fn func(tx Repositories) error) error
+}
+
+type GormUnitOfWork struct {
+	db       *gorm.DB
+	cupcakes CupcakeRepositoryInterface
+}
+
+var _ UnitOfWork = (*GormUnitOfWork)(nil)
+
+func NewUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// WithCupcakes hands out repo as the Cupcakes of every transaction in place
+// of the GORM one, for a catalog kept outside the database such as the
+// in-memory one of DB_DIALECT=memory. Its changes are not rolled back with
+// the transaction.
+func (u *GormUnitOfWork) WithCupcakes(repo CupcakeRepositoryInterface) *GormUnitOfWork {
+	u.cupcakes = repo
+	return u
+}
+
+// WithTransaction runs fn in a GORM transaction. Repository methods that
+// open transactions of their own nest inside it as savepoints.
+func (u *GormUnitOfWork) WithTransaction(ctx context.Context, fn func(tx Repositories) error) error {
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repos := newRepositories(tx)
+		if u.cupcakes != nil {
+			repos.Cupcakes = u.cupcakes
+		}
+		return fn(repos)
+	})
+	return translateError(err)
+}
+
+func newRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Cupcakes:       NewCupcakeRepository(db),
+		Coupons:        NewCouponRepository(db),
+		Promotions:     NewPromotionRepository(db),
+		GiftCards:      NewGiftCardRepository(db),
+		Subscriptions:  NewSubscriptionRepository(db),
+		Webhooks:       NewWebhookRepository(db),
+		Locations:      NewLocationRepository(db),
+		Bundles:        NewBundleRepository(db),
+		Wholesale:      NewWholesaleRepository(db),
+		Suppliers:      NewSupplierRepository(db),
+		Ingredients:    NewIngredientRepository(db),
+		PurchaseOrders: NewPurchaseOrderRepository(db),
+		Recipes:        NewRecipeRepository(db),
+		Translations:   NewTranslationRepository(db),
+		Views:          NewViewRepository(db),
+		Addons:         NewAddonRepository(db),
+		CustomOptions:  NewCustomOptionRepository(db),
+		Pickups:        NewPickupRepository(db),
+		Jobs:           NewJobRepository(db),
+	}
+}
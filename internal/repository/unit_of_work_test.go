@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitOfWork_WithTransaction(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name          string
+		fnError       error
+		expectedError error
+		expectedRows  int64
+	}{
+		{
+			name:         "commits every repository",
+			expectedRows: 1,
+		},
+		{
+			name:          "rolls back every repository",
+			fnError:       errBoom,
+			expectedError: errBoom,
+			expectedRows:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			uow := NewUnitOfWork(db)
+
+			err := uow.WithTransaction(context.Background(), func(tx Repositories) error {
+				// Cupcakes.Create opens a transaction of its own, which has
+				// to nest rather than commit early.
+				if err := tx.Cupcakes.Create(&models.Cupcake{Name: "Lemon", Flavor: "Lemon", PriceCents: 900}); err != nil {
+					return err
+				}
+				if err := tx.Coupons.Create(&models.Coupon{Code: "LEMON10", DiscountType: models.DiscountTypePercentage, DiscountValue: 10}); err != nil {
+					return err
+				}
+				return tt.fnError
+			})
+			require.ErrorIs(t, err, tt.expectedError)
+
+			var cupcakes, coupons int64
+			require.NoError(t, db.Model(&models.Cupcake{}).Count(&cupcakes).Error)
+			require.NoError(t, db.Model(&models.Coupon{}).Count(&coupons).Error)
+			require.Equal(t, tt.expectedRows, cupcakes)
+			require.Equal(t, tt.expectedRows, coupons)
+		})
+	}
+}
+
+func TestUnitOfWork_WithCupcakes(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakes := NewCupcakeRepository(setupTestDB(t))
+	uow := NewUnitOfWork(db).WithCupcakes(cupcakes)
+
+	err := uow.WithTransaction(context.Background(), func(tx Repositories) error {
+		require.Same(t, cupcakes, tx.Cupcakes)
+		return tx.Cupcakes.Create(&models.Cupcake{Name: "Lemon", Flavor: "Lemon", PriceCents: 900})
+	})
+	require.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Cupcake{}).Count(&count).Error)
+	require.Zero(t, count)
+	all, err := cupcakes.FindAll()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ViewRepository struct {
+	db *gorm.DB
+}
+
+var _ ViewRepositoryInterface = (*ViewRepository)(nil)
+
+func NewViewRepository(db *gorm.DB) *ViewRepository {
+	return &ViewRepository{db: db}
+}
+
+// AddViews adds counts, keyed by cupcake ID, to the day's totals in one
+// transaction.
+func (r *ViewRepository) AddViews(day string, counts map[uint]int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for cupcakeID, count := range counts {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "cupcake_id"}, {Name: "day"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("cupcake_view_counts.count + excluded.count")}),
+			}).Create(&models.CupcakeViewCount{CupcakeID: cupcakeID, Day: day, Count: count}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return translateError(err)
+}
+
+// FindMostViewed sums the views from day since onwards and returns the top
+// limit cupcakes, most viewed first.
+func (r *ViewRepository) FindMostViewed(since string, limit int) ([]models.CupcakeViewTotal, error) {
+	var totals []models.CupcakeViewTotal
+	err := r.db.Model(&models.CupcakeViewCount{}).
+		Select("cupcake_id, SUM(count) AS views").
+		Where("day >= ?", since).
+		Group("cupcake_id").
+		Order("views DESC, cupcake_id").
+		Limit(limit).
+		Scan(&totals).Error
+	return totals, translateError(err)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewRepository(t *testing.T) {
+	repo := NewViewRepository(setupTestDB(t))
+
+	require.NoError(t, repo.AddViews("2026-10-10", map[uint]int{1: 5, 2: 1}))
+	require.NoError(t, repo.AddViews("2026-10-15", map[uint]int{1: 1, 2: 3, 3: 2}))
+	require.NoError(t, repo.AddViews("2026-10-15", map[uint]int{2: 4}))
+
+	tests := []struct {
+		name     string
+		since    string
+		limit    int
+		expected []models.CupcakeViewTotal
+	}{
+		{
+			name:  "whole history",
+			since: "2026-10-01",
+			limit: 10,
+			expected: []models.CupcakeViewTotal{
+				{CupcakeID: 2, Views: 8},
+				{CupcakeID: 1, Views: 6},
+				{CupcakeID: 3, Views: 2},
+			},
+		},
+		{
+			name:  "recent window",
+			since: "2026-10-15",
+			limit: 10,
+			expected: []models.CupcakeViewTotal{
+				{CupcakeID: 2, Views: 7},
+				{CupcakeID: 3, Views: 2},
+				{CupcakeID: 1, Views: 1},
+			},
+		},
+		{
+			name:     "limited",
+			since:    "2026-10-01",
+			limit:    1,
+			expected: []models.CupcakeViewTotal{{CupcakeID: 2, Views: 8}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			totals, err := repo.FindMostViewed(tt.since, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, totals)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+var _ WebhookRepositoryInterface = (*WebhookRepository)(nil)
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(webhook *models.Webhook) error {
+	return translateError(r.db.Create(webhook).Error)
+}
+
+func (r *WebhookRepository) FindByID(id uint) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.First(&webhook, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &webhook, nil
+}
+
+func (r *WebhookRepository) FindAll() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Find(&webhooks).Error
+	return webhooks, translateError(err)
+}
+
+func (r *WebhookRepository) FindActive() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := r.db.Where("is_active = ?", true).Find(&webhooks).Error
+	return webhooks, translateError(err)
+}
+
+func (r *WebhookRepository) Update(webhook *models.Webhook) error {
+	return translateError(r.db.Save(webhook).Error)
+}
+
+func (r *WebhookRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Webhook{}, id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) CreateDelivery(delivery *models.WebhookDelivery) error {
+	return translateError(r.db.Create(delivery).Error)
+}
+
+func (r *WebhookRepository) FindDeliveries(webhookID uint) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.Where("webhook_id = ?", webhookID).Order("id desc").Find(&deliveries).Error
+	return deliveries, translateError(err)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WholesaleRepository struct {
+	db *gorm.DB
+}
+
+var _ WholesaleRepositoryInterface = (*WholesaleRepository)(nil)
+
+func NewWholesaleRepository(db *gorm.DB) *WholesaleRepository {
+	return &WholesaleRepository{db: db}
+}
+
+func (r *WholesaleRepository) Create(account *models.WholesaleAccount) error {
+	return translateError(r.db.Create(account).Error)
+}
+
+func (r *WholesaleRepository) FindByID(id uint) (*models.WholesaleAccount, error) {
+	var account models.WholesaleAccount
+	err := r.db.First(&account, id).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &account, nil
+}
+
+func (r *WholesaleRepository) FindByEmail(email string) (*models.WholesaleAccount, error) {
+	var account models.WholesaleAccount
+	err := r.db.Where("email_hash = ?", pii.Hash(email)).First(&account).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &account, nil
+}
+
+func (r *WholesaleRepository) FindAll() ([]models.WholesaleAccount, error) {
+	var accounts []models.WholesaleAccount
+	err := r.db.Order("name").Find(&accounts).Error
+	return accounts, translateError(err)
+}
+
+func (r *WholesaleRepository) Update(account *models.WholesaleAccount) error {
+	return translateError(r.db.Save(account).Error)
+}
+
+func (r *WholesaleRepository) Delete(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.WholesaleAccount{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Where("account_id = ?", id).Delete(&models.WholesalePrice{}).Error
+	})
+	return translateError(err)
+}
+
+// SetPrice creates or replaces the negotiated price of a cupcake for an
+// account.
+func (r *WholesaleRepository) SetPrice(price *models.WholesalePrice) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "cupcake_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price_cents", "updated_at"}),
+	}).Create(price).Error
+	return translateError(err)
+}
+
+func (r *WholesaleRepository) DeletePrice(accountID, cupcakeID uint) error {
+	result := r.db.Where("account_id = ? AND cupcake_id = ?", accountID, cupcakeID).Delete(&models.WholesalePrice{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *WholesaleRepository) FindPrices(accountID uint) ([]models.WholesalePrice, error) {
+	var prices []models.WholesalePrice
+	err := r.db.Where("account_id = ?", accountID).Order("cupcake_id").Find(&prices).Error
+	return prices, translateError(err)
+}
@@ -0,0 +1,93 @@
+package router
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/handler"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// requireAdmin answers 401 unless the request carries the static admin
+// token or the access token of an admin account as a bearer token, and
+// records which one it was for the handlers. token is asked on every
+// request so a rotated token applies at once; while it is empty only admin
+// accounts get in. The comparison takes constant time so the token cannot
+// be guessed byte by byte. Paths in exempt, the admin login, need neither.
+func requireAdmin(token func() string, admins service.AdminServiceInterface, exempt ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(exempt, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			current := token()
+			expected := []byte("Bearer " + current)
+			if current != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) == 1 {
+				next.ServeHTTP(w, r.WithContext(handler.WithAdmin(r.Context(), nil)))
+				return
+			}
+
+			admin, err := authenticateAdmin(r, admins)
+			if err != nil {
+				sendError(w, "Error checking access token", http.StatusInternalServerError)
+				return
+			}
+			if admin == nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				sendError(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(handler.WithAdmin(r.Context(), admin)))
+		})
+	}
+}
+
+// authenticateAdmin returns the admin of the request's bearer token, or nil
+// when there is no valid one.
+func authenticateAdmin(r *http.Request, admins service.AdminServiceInterface) (*models.Admin, error) {
+	bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if bearer == "" || admins == nil {
+		return nil, nil
+	}
+	admin, err := admins.Authenticate(bearer)
+	if errors.Is(err, service.ErrAccessTokenInvalid) || errors.Is(err, service.ErrAccessTokenExpired) {
+		return nil, nil
+	}
+	return admin, err
+}
+
+// requireVerifiedEmail answers 401 unless the request carries the access
+// token of an account, and 403 while that account's email is unverified.
+func requireVerifiedEmail(accounts service.AccountServiceInterface) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				sendError(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			account, err := accounts.Authenticate(token)
+			if errors.Is(err, service.ErrAccessTokenInvalid) || errors.Is(err, service.ErrAccessTokenExpired) {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				sendError(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				sendError(w, "Error checking access token", http.StatusInternalServerError)
+				return
+			}
+			if account.EmailVerifiedAt == nil {
+				sendError(w, service.ErrEmailNotVerified.Error(), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package router
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// captchaHeader carries the token the captcha widget gave the client.
+const captchaHeader = "X-Captcha-Token"
+
+// CaptchaEndpoints names the anonymous endpoints that can require a
+// captcha, for CAPTCHA_ENDPOINTS.
+var CaptchaEndpoints = []string{"register", "login", "subscribe", "pickup", "ticket", "data_export", "erasure"}
+
+// ParseCaptchaEndpoints reads a comma-separated list of CaptchaEndpoints,
+// dropping blanks.
+func ParseCaptchaEndpoints(raw string) ([]string, error) {
+	var endpoints []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(CaptchaEndpoints, name) {
+			return nil, fmt.Errorf("unknown endpoint %q: must be one of %s", name, strings.Join(CaptchaEndpoints, ", "))
+		}
+		endpoints = append(endpoints, name)
+	}
+	return endpoints, nil
+}
+
+// requireCaptcha answers 400 to requests without a captcha token and 403
+// to those whose token the provider rejects. When the provider cannot be
+// reached the request is refused with 503 rather than let through.
+func requireCaptcha(verifier service.CaptchaVerifier, trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get(captchaHeader)
+			if token == "" {
+				sendError(w, "captcha required", http.StatusBadRequest)
+				return
+			}
+			ok, err := verifier.Verify(r.Context(), token, clientIP(r, trustedProxies))
+			if err != nil {
+				log.Printf("Error verifying captcha: %v", err)
+				sendError(w, "captcha verification unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			if !ok {
+				sendError(w, "captcha verification failed", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
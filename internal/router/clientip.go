@@ -0,0 +1,105 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ParseNetworks reads a comma-separated list of CIDR ranges, where a bare
+// IP stands for itself, dropping blanks.
+func ParseNetworks(raw string) ([]netip.Prefix, error) {
+	var networks []netip.Prefix
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			addr = addr.Unmap()
+			networks = append(networks, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		network, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: must be an IP or a CIDR range", entry)
+		}
+		if network.Addr().Is4In6() && network.Bits() >= 96 {
+			// Matched against client IPs, which are unmapped.
+			network = netip.PrefixFrom(network.Addr().Unmap(), network.Bits()-96)
+		}
+		networks = append(networks, network.Masked())
+	}
+	return networks, nil
+}
+
+// allowNetworks answers 403 to clients outside networks.
+func allowNetworks(networks, trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addr, ok := clientAddr(r, trustedProxies)
+			if !ok || !inNetworks(networks, addr) {
+				sendError(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP is the IP requests from r's client are counted under.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	if addr, ok := clientAddr(r, trustedProxies); ok {
+		return addr.String()
+	}
+	return r.RemoteAddr
+}
+
+// clientAddr is the IP of the client that made r. It is the peer's address
+// unless the peer is a trusted proxy. Then X-Forwarded-For is read from the
+// right, past the addresses each trusted proxy appended, and the first one
+// that is not a trusted proxy is the client: anything to its left was sent
+// by the client itself and could say anything.
+func clientAddr(r *http.Request, trustedProxies []netip.Prefix) (netip.Addr, bool) {
+	addr, ok := parseAddr(r.RemoteAddr)
+	if !ok || !inNetworks(trustedProxies, addr) {
+		return addr, ok
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseAddr(strings.TrimSpace(hops[i]))
+		if !ok {
+			// Garbled; the last hop read is as far as can be told.
+			break
+		}
+		addr = hop
+		if !inNetworks(trustedProxies, addr) {
+			break
+		}
+	}
+	return addr, true
+}
+
+// parseAddr reads an IP with or without a port, as in RemoteAddr.
+func parseAddr(s string) (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		addrPort, err := netip.ParseAddrPort(s)
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		addr = addrPort.Addr()
+	}
+	return addr.Unmap().WithZone(""), true
+}
+
+func inNetworks(networks []netip.Prefix, addr netip.Addr) bool {
+	for _, network := range networks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
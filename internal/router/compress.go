@@ -0,0 +1,37 @@
+package router
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/andybalholm/brotli"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// compressibleTypes lists the responses worth compressing. Images such as
+// the PNG QR codes are already compressed and pass through untouched.
+var compressibleTypes = []string{
+	"application/json",
+	"application/vnd.cupcake-store.v2+json",
+	"application/x-ndjson",
+	"application/xml",
+	"text/csv",
+	"text/html",
+	"text/css",
+	"text/plain",
+	"text/javascript",
+	"application/javascript",
+	"image/svg+xml",
+}
+
+// compress negotiates gzip/deflate, and brotli when enabled, from the
+// request's Accept-Encoding header.
+func compress(level int, enableBrotli bool) func(http.Handler) http.Handler {
+	compressor := middleware.NewCompressor(level, compressibleTypes...)
+	if enableBrotli {
+		compressor.SetEncoder("br", func(w io.Writer, level int) io.Writer {
+			return brotli.NewWriterLevel(w, level)
+		})
+	}
+	return compressor.Handler
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// allowMethods are the methods probed when building the Allow header.
+var allowMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// notFound answers unknown API routes in the same JSON shape as the
+// handlers' errors.
+func notFound(w http.ResponseWriter, r *http.Request) {
+	sendError(w, "not found", http.StatusNotFound)
+}
+
+// methodNotAllowed answers a known path hit with the wrong method in the
+// handlers' JSON shape. chi only fills in Allow from its default handler,
+// so the header is rebuilt by probing the innermost router that matched.
+func methodNotAllowed(root chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		routes, path := matchedRouter(root, r)
+
+		var allowed []string
+		for _, method := range allowMethods {
+			if routes.Match(chi.NewRouteContext(), method, path) {
+				allowed = append(allowed, method)
+			}
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		sendError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// matchedRouter follows the mount patterns recorded while routing r down to
+// the subrouter that rejected it, and returns it with the path left for it
+// to match. Probing from the root instead is unreliable: chi's Find treats
+// a path ending exactly at a mount point as a route of its own.
+func matchedRouter(root chi.Routes, r *http.Request) (chi.Routes, string) {
+	rctx := chi.RouteContext(r.Context())
+	routes := root
+	for _, pattern := range rctx.RoutePatterns {
+		mount := strings.TrimSuffix(strings.TrimSuffix(pattern, "*"), "/") + "/*"
+		sub := subRoutes(routes, mount)
+		if sub == nil {
+			break
+		}
+		routes = sub
+	}
+
+	if rctx.RoutePath != "" {
+		return routes, rctx.RoutePath
+	}
+	if r.URL.RawPath != "" {
+		return routes, r.URL.RawPath
+	}
+	return routes, r.URL.Path
+}
+
+func subRoutes(routes chi.Routes, pattern string) chi.Routes {
+	for _, route := range routes.Routes() {
+		if route.Pattern == pattern && route.SubRoutes != nil {
+			return route.SubRoutes
+		}
+	}
+	return nil
+}
+
+func sendError(w http.ResponseWriter, message string, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/julimonteiro/cupcake-store/internal/database"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"gorm.io/gorm"
+)
+
+// metrics serves the database pool and query metrics, and those of the
+// outbound HTTP clients, for Prometheus.
+func metrics(db *gorm.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := database.WriteMetrics(w, db); err != nil {
+			log.Printf("Error writing metrics: %v", err)
+		}
+		httpclient.WriteMetrics(w)
+	}
+}
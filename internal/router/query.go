@@ -0,0 +1,101 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// globalQueryParams are read on every API route.
+var globalQueryParams = []string{"lang"}
+
+// queryParams lists the query parameters each API route reads, keyed by
+// method and route pattern without a trailing slash. Routes missing here
+// take only the global ones. TestQueryParams_CoverHandlers fails when a
+// handler reads a parameter its route does not list.
+var queryParams = map[string][]string{
+	"GET /api/v1/cupcakes":                              {"currency", "fields", "format", "location_id", "page", "per_page"},
+	"GET /api/v1/cupcakes/by-sku/{sku}":                 {"currency", "fields"},
+	"GET /api/v1/cupcakes/slug/{slug}":                  {"currency", "fields"},
+	"GET /api/v1/cupcakes/trending":                     {"limit", "window"},
+	"GET /api/v1/cupcakes/{id}":                         {"currency", "fields"},
+	"GET /api/v1/cupcakes/{id}/qr":                      {"format", "size"},
+	"GET /api/v1/cupcakes/{id}/og":                      {"format"},
+	"GET /api/v1/cupcakes/{id}/related":                 {"currency", "limit"},
+	"GET /api/v1/sync/cupcakes":                         {"limit", "since"},
+	"GET /api/v1/search":                                {"facet", "limit", "q"},
+	"GET /api/v1/locations/{id}/slots":                  {"date"},
+	"GET /api/v1/me/data-export":                        {"email"},
+	"GET /api/v1/data-exports/{id}/download":            {"expires", "signature"},
+	"DELETE /api/v1/me":                                 {"email", "expires", "token"},
+	"GET /api/v1/auth/verify":                           {"token"},
+	"GET /api/v1/auth/oauth/{provider}/callback":        {"authuser", "code", "error", "error_description", "error_uri", "hd", "prompt", "scope", "state"},
+	"POST /api/v1/admin/cupcakes":                       {"force"},
+	"GET /api/v1/admin/kitchen/production-plan":         {"date"},
+	"GET /api/v1/admin/kitchen/queue":                   {"date", "location_id"},
+	"GET /api/v1/admin/stats/top-cupcakes":              {"flavor", "from", "limit", "to"},
+	"GET /api/v1/admin/purchase-orders":                 {"status"},
+	"GET /api/v1/admin/alerts":                          {"status"},
+	"GET /api/v1/admin/locations/{id}/pickups":          {"date"},
+	"DELETE /api/v1/admin/customers":                    {"email"},
+	"GET /api/v1/admin/erasures":                        {"email"},
+	"GET /api/v1/admin/api-keys/{id}/usage":             {"window"},
+	"GET /api/v1/admin/tickets":                         {"status"},
+	"GET /api/v1/admin/email-templates":                 {"location_id"},
+	"GET /api/v1/admin/email-templates/{name}":          {"location_id"},
+	"DELETE /api/v1/admin/email-templates/{name}":       {"location_id"},
+	"POST /api/v1/admin/email-templates/{name}/preview": {"format"},
+}
+
+// rejectUnknownQuery answers 400 when a request carries a query parameter
+// its route does not read, so a typo such as ?availible=true fails instead
+// of silently returning unfiltered results. It is installed on the API
+// subrouter and looks the route up in routes before it runs; requests that
+// match no route are left for the 404 and 405 handlers.
+func rejectUnknownQuery(routes chi.Routes) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.RawQuery == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rctx := chi.RouteContext(r.Context())
+			pattern := routes.Find(chi.NewRouteContext(), r.Method, rctx.RoutePath)
+			if pattern == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Find stops at mount points, answering "/cupcakes" where routing
+			// ends up at "/cupcakes/", so both forms are looked up without
+			// the slash.
+			pattern = strings.TrimSuffix(strings.TrimSuffix(rctx.RoutePattern(), "/*")+pattern, "/")
+			supported := append(slices.Clone(globalQueryParams), queryParams[r.Method+" "+pattern]...)
+			var unknown []string
+			for name := range r.URL.Query() {
+				if !slices.Contains(supported, name) {
+					unknown = append(unknown, name)
+				}
+			}
+			if len(unknown) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			slices.Sort(unknown)
+			slices.Sort(supported)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{
+				"error":     fmt.Sprintf("unknown query parameter: %s", strings.Join(unknown, ", ")),
+				"unknown":   unknown,
+				"supported": supported,
+			})
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package router
+
+import (
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimit answers 429 once a client IP has made limit requests in the
+// current window, telling it in Retry-After when the window ends. Behind
+// trustedProxies the client IP comes from X-Forwarded-For.
+func rateLimit(limit int, window time.Duration, trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	l := &limiter{limit: limit, window: window, now: time.Now, counts: map[string]int{}}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, retryAfter := l.allow(clientIP(r, trustedProxies)); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				sendError(w, "too many requests", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// limiter counts requests per client in fixed windows shared by every
+// client, so all counters are dropped together when a window ends and
+// memory stays bounded by the clients seen in one window.
+type limiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	now    func() time.Time
+	start  time.Time
+	counts map[string]int
+}
+
+// allow records a request from client and reports whether it is within the
+// limit or, when it is not, how long until the window ends.
+func (l *limiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.start) >= l.window {
+		l.start = now
+		clear(l.counts)
+	}
+	if l.counts[client] >= l.limit {
+		return false, l.start.Add(l.window).Sub(now)
+	}
+	l.counts[client]++
+	return true, 0
+}
@@ -1,58 +1,741 @@
 package router
 
 import (
+	"context"
+	"log"
 	"net/http"
+	"net/netip"
+	"os"
+	"slices"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/julimonteiro/cupcake-store/internal/currency"
 	"github.com/julimonteiro/cupcake-store/internal/handler"
+	"github.com/julimonteiro/cupcake-store/internal/health"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+	"github.com/julimonteiro/cupcake-store/internal/redact"
 	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/rpc"
+	"github.com/julimonteiro/cupcake-store/internal/scheduler"
 	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/web"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
-func Setup(db *gorm.DB) http.Handler {
-	r := chi.NewRouter()
+// Options carries the process-wide dependencies built in cmd. Zero values
+// fall back to in-process defaults, which is what the tests rely on.
+type Options struct {
+	Publisher service.EventPublisher
+	Jobs      *service.JobService
+	// JobRetention is how long completed jobs are kept before the
+	// purge-jobs task deletes them; a week when zero.
+	JobRetention time.Duration
+	// Views buffers cupcake page views; the caller runs it so the batches
+	// are written. Without one, views are not tracked.
+	Views     *service.ViewCounter
+	Scheduler *scheduler.Scheduler
+	Converter *currency.Converter
+	// GRPCServer, when set, gets the catalog service registered on it so
+	// both transports share the same CupcakeService.
+	GRPCServer *grpc.Server
+	// CompressionLevel enables response compression when positive; Brotli
+	// additionally offers the br encoding.
+	CompressionLevel int
+	Brotli           bool
+	// MaxBodyBytes caps request bodies on write endpoints; zero means 1 MiB.
+	MaxBodyBytes int64
+	Maintenance  *service.MaintenanceService
+	// Validation holds the cupcake validation rules, editable at runtime
+	// through the admin API. Nil means models.DefaultValidationRules.
+	Validation *service.ValidationService
+	// CupcakeRepository replaces the GORM-backed catalog, e.g. with the
+	// inmem repository when DB_DIALECT=memory.
+	CupcakeRepository repository.CupcakeRepositoryInterface
+	// Services replaces the default wiring from NewServices, e.g. to swap a
+	// service for a fake in tests.
+	Services *Services
+	// SearchIndex, when set, answers catalog searches; without one they run
+	// against the database.
+	SearchIndex service.SearchIndex
+	// DefaultLocale is the language cupcakes are written in; other locales
+	// come from translations. Empty means pt-BR.
+	DefaultLocale string
+	// Health lists the readiness checks served at /health/ready; nil checks
+	// the database only.
+	Health *health.Checker
+	// AdminToken, when set, is the bearer token every admin API request
+	// must carry. AdminTokenFunc replaces it with a token read on every
+	// request, for tokens rotated while running.
+	AdminToken     string
+	AdminTokenFunc func() string
+	// PublicRateLimit and AdminRateLimit cap the requests per minute from
+	// one client IP to each API; zero means unlimited.
+	PublicRateLimit int
+	AdminRateLimit  int
+	// AdminNetworks, when set, limits the admin API and metrics to client
+	// IPs in these ranges. TrustedProxies are the proxies whose
+	// X-Forwarded-For is believed when telling a client's IP, here and in
+	// the rate limits, and whose X-Forwarded-Proto is believed when there
+	// is no PublicURL.
+	AdminNetworks  []netip.Prefix
+	TrustedProxies []netip.Prefix
+	// DataExportSecret signs the download links of customer data exports;
+	// empty means a random secret, so links only work on this instance
+	// until it restarts.
+	DataExportSecret string
+	// ErasureSecret signs the links customers confirm the erasure of their
+	// data with; empty means a random secret, as for DataExportSecret.
+	ErasureSecret string
+	// PasswordParams are the argon2id costs account passwords are hashed
+	// with; zero means password.DefaultParams. AuthTokenSecret signs the
+	// access tokens accounts log in with, random when empty, and
+	// AuthTokenTTL is how long they last, an hour when zero. SessionTTL is
+	// how long a session of the web app lasts unused, a day when zero.
+	PasswordParams  password.Params
+	AuthTokenSecret string
+	AuthTokenTTL    time.Duration
+	SessionTTL      time.Duration
+	// RequireVerifiedEmail has pickup reservations and subscriptions
+	// placed only with the access token of an account whose email is
+	// verified.
+	RequireVerifiedEmail bool
+	// OAuthProviders are the social logins customers can use; none when
+	// empty.
+	OAuthProviders []service.OAuthProvider
+	// Captcha, when set, has the CaptchaEndpoints named in
+	// CaptchaEndpoints require a captcha token.
+	Captcha          service.CaptchaVerifier
+	CaptchaEndpoints []string
 
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(middleware.RequestID)
-	r.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", "*")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token")
-			w.Header().Set("Access-Control-Expose-Headers", "Link")
-			w.Header().Set("Access-Control-Max-Age", "300")
+	// SMS, when set, texts customers who asked for it and admins who need
+	// a two-factor code.
+	SMS service.SMSSender
+	// Push, when set, sends the push notifications customers asked for to
+	// the devices registered to their account.
+	Push service.PushSender
+	// Email, when set, sends customers the download links of their data
+	// exports and the confirmation links of their erasure requests, as
+	// links under PublicURL; without it both requests answer 503.
+	// PublicURL is also the base of the social login callbacks, of shared
+	// links and of whether cookies are Secure; when empty it is rebuilt
+	// from each request.
+	Email     service.EmailSender
+	PublicURL string
+	// Printer, when set, prints the kitchen ticket and the customer
+	// receipt of every pickup reservation; without it reprints answer 409.
+	Printer service.TicketPrinter
+	// HTTPClient holds the retry and circuit breaker settings of webhook
+	// deliveries; the zero value neither retries nor breaks.
+	HTTPClient httpclient.Settings
+}
+
+const (
+	defaultRetryAfter   = 2 * time.Minute
+	defaultJobRetention = 7 * 24 * time.Hour
+	defaultLocale       = "pt-BR"
+	// adminLoginPath and adminLoginCodePath are let through the admin
+	// token check and read-only mode, or admins could never log in to turn
+	// it off.
+	adminLoginPath     = "/api/v1/admin/auth/login"
+	adminLoginCodePath = "/api/v1/admin/auth/login/sms"
+	// DatabasePingTimeout bounds the readiness check of the database.
+	DatabasePingTimeout = 2 * time.Second
+)
 
-			if r.Method == "OPTIONS" {
-				w.WriteHeader(http.StatusOK)
-				return
-			}
+// requestLogger is chi's request log written through redact, since query
+// strings carry emails, signatures and confirmation tokens.
+var requestLogger = middleware.RequestLogger(&middleware.DefaultLogFormatter{
+	Logger: log.New(redact.NewWriter(os.Stdout), "", log.LstdFlags),
+})
 
-			next.ServeHTTP(w, r)
-		})
+// Setup serves the storefront API, the admin API and the web app from a
+// single handler.
+func Setup(db *gorm.DB, opts Options) http.Handler {
+	a := newAPI(db, opts)
+	return a.handler(true, true)
+}
+
+// SetupSplit builds separate handlers for the storefront (public API and
+// web app) and the admin API, to serve them on different listeners. Both
+// share one set of services, so they see the same state.
+func SetupSplit(db *gorm.DB, opts Options) (public, admin http.Handler) {
+	a := newAPI(db, opts)
+	return a.handler(true, false), a.handler(false, true)
+}
+
+// api holds the route groups and the middleware stacks of both audiences.
+type api struct {
+	db   *gorm.DB
+	opts Options
+
+	readOnlyGate func(http.Handler) http.Handler
+	healthCheck  http.HandlerFunc
+	ready        http.HandlerFunc
+
+	publicMiddlewares chi.Middlewares
+	adminMiddlewares  chi.Middlewares
+	// adminNetworks guards what only admins reach outside the admin API,
+	// the metrics.
+	adminNetworks chi.Middlewares
+
+	public func(r chi.Router)
+	admin  func(r chi.Router)
+}
+
+func newAPI(db *gorm.DB, opts Options) *api {
+	services := NewServices(db, opts)
+	if opts.Services != nil {
+		services = *opts.Services
+	}
+
+	sched := opts.Scheduler
+	if sched == nil {
+		sched, _ = scheduler.New(nil)
+	}
+	jobHandler := handler.NewJobHandler(services.Jobs, sched)
+
+	maintenance := services.Maintenance
+	if maintenance == nil {
+		maintenance = service.NewMaintenanceService(false, false, defaultRetryAfter)
+	}
+	maintenanceHandler := handler.NewMaintenanceHandler(maintenance)
+
+	// Stored settings override the configuration they stand for, such as
+	// MAINTENANCE_MODE, once written.
+	settings := services.Settings
+	if settings == nil {
+		settings = service.NewSettingsService(repository.NewSettingRepository(db), maintenance, opts.Converter)
+	}
+	if err := settings.Load(); err != nil {
+		log.Printf("Error loading settings: %v", err)
+	}
+	settingsHandler := handler.NewSettingsHandler(settings)
+
+	validation := services.Validation
+	if validation == nil {
+		validation = service.DefaultValidationService()
+	}
+	validationHandler := handler.NewValidationHandler(validation)
+
+	webhookHandler := handler.NewWebhookHandler(services.Webhooks)
+	cupcakeHandler := handler.NewCupcakeHandler(services.Cupcakes)
+	if services.Experiments != nil {
+		cupcakeHandler.WithExperiments(services.Experiments)
+	}
+	experimentHandler := handler.NewExperimentHandler(services.Experiments)
+	dataExportHandler := handler.NewDataExportHandler(services.DataExports)
+	erasureHandler := handler.NewErasureHandler(services.Erasures)
+	authHandler := handler.NewAuthHandler(services.Accounts)
+	adminHandler := handler.NewAdminHandler(services.Admins)
+	oauthHandler := handler.NewOAuthHandler(services.OAuth)
+	sessionHandler := handler.NewSessionHandler(services.Sessions)
+	apiKeyHandler := handler.NewAPIKeyHandler(services.APIKeys)
+	ticketHandler := handler.NewTicketHandler(services.Tickets)
+	stockAlertHandler := handler.NewStockAlertHandler(services.StockAlerts)
+	emailTemplateHandler := handler.NewEmailTemplateHandler(services.EmailTemplates)
+	notificationHandler := handler.NewNotificationHandler(services.Notifications, services.Accounts)
+	deviceHandler := handler.NewDeviceHandler(services.Devices, services.Accounts)
+	if opts.GRPCServer != nil {
+		rpc.Register(opts.GRPCServer, services.Cupcakes)
+	}
+	couponHandler := handler.NewCouponHandler(services.Coupons)
+	promotionHandler := handler.NewPromotionHandler(services.Promotions)
+	giftCardHandler := handler.NewGiftCardHandler(services.GiftCards)
+	subscriptionHandler := handler.NewSubscriptionHandler(services.Subscriptions, services.Accounts)
+	locationHandler := handler.NewLocationHandler(services.Locations)
+	pickupHandler := handler.NewPickupHandler(services.Pickups)
+	printHandler := handler.NewPrintHandler(services.Prints)
+	customCupcakeHandler := handler.NewCustomCupcakeHandler(services.CustomCupcakes)
+	addonHandler := handler.NewAddonHandler(services.Addons)
+	bundleHandler := handler.NewBundleHandler(services.Bundles)
+	wholesaleHandler := handler.NewWholesaleHandler(services.Wholesale)
+	procurementHandler := handler.NewProcurementHandler(services.Procurement)
+	recipeHandler := handler.NewRecipeHandler(services.Recipes)
+	kitchenHandler := handler.NewKitchenHandler(services.Kitchen)
+	statsHandler := handler.NewStatsHandler(services.Stats)
+	syncHandler := handler.NewSyncHandler(services.Sync)
+	var views service.ViewRecorder
+	if services.Views != nil {
+		views = services.Views
+	}
+	trendingHandler := handler.NewTrendingHandler(services.Trending, views)
+	searchHandler := handler.NewSearchHandler(services.Search)
+	translationHandler := handler.NewTranslationHandler(services.Translations)
+
+	sched.Register(scheduler.TaskExpireCoupons, func() error {
+		_, err := services.Coupons.ExpireCoupons()
+		return err
+	})
+	jobRetention := opts.JobRetention
+	if jobRetention == 0 {
+		jobRetention = defaultJobRetention
+	}
+	sched.Register(scheduler.TaskPurgeJobs, func() error {
+		_, err := services.Jobs.PurgeCompleted(jobRetention)
+		return err
+	})
+	sched.Register(scheduler.TaskCheckStock, func() error {
+		_, err := services.StockAlerts.CheckStock()
+		return err
 	})
 
-	cupcakeRepo := repository.NewCupcakeRepository(db)
-	cupcakeService := service.NewCupcakeService(cupcakeRepo)
-	cupcakeHandler := handler.NewCupcakeHandler(cupcakeService)
+	checker := opts.Health
+	if checker == nil {
+		checker = health.New()
+		checker.Add("database", DatabasePingTimeout, PingDatabase(db))
+	}
+	healthHandler := handler.NewHealthHandler(checker)
 
-	r.Get("/health", cupcakeHandler.HealthCheck)
+	a := &api{
+		db:           db,
+		opts:         opts,
+		readOnlyGate: maintenanceHandler.ReadOnlyGate("/api/v1/admin/maintenance", "/api/v1/admin/read-only", adminLoginPath, adminLoginCodePath),
+		healthCheck:  cupcakeHandler.HealthCheck,
+		ready:        healthHandler.Ready,
+	}
+	a.publicMiddlewares = append(a.publicMiddlewares, withStoreURL(opts.PublicURL, opts.TrustedProxies))
+	if opts.PublicRateLimit > 0 {
+		a.publicMiddlewares = append(a.publicMiddlewares, rateLimit(opts.PublicRateLimit, time.Minute, opts.TrustedProxies))
+	}
+	if services.APIKeys != nil {
+		a.publicMiddlewares = append(a.publicMiddlewares, apiKeyHandler.Meter)
+	}
+	if services.Sessions != nil {
+		a.publicMiddlewares = append(a.publicMiddlewares, sessionHandler.Authenticate)
+	}
+	if len(opts.AdminNetworks) > 0 {
+		a.adminNetworks = append(a.adminNetworks, allowNetworks(opts.AdminNetworks, opts.TrustedProxies))
+		a.adminMiddlewares = append(a.adminMiddlewares, a.adminNetworks...)
+	}
+	// Without an admin token only admin accounts get in, and with neither
+	// the admin API refuses everything but the login.
+	adminToken := opts.AdminTokenFunc
+	if adminToken == nil {
+		adminToken = func() string { return opts.AdminToken }
+	}
+	// The limiter counts requests before they are authenticated, so
+	// guessing the admin token or a password is throttled too.
+	if opts.AdminRateLimit > 0 {
+		a.adminMiddlewares = append(a.adminMiddlewares, rateLimit(opts.AdminRateLimit, time.Minute, opts.TrustedProxies))
+	}
+	a.adminMiddlewares = append(a.adminMiddlewares, requireAdmin(adminToken, services.Admins, adminLoginPath, adminLoginCodePath))
+
+	var orderGate chi.Middlewares
+	if opts.RequireVerifiedEmail {
+		orderGate = append(orderGate, requireVerifiedEmail(services.Accounts))
+	}
+	captcha := func(endpoint string) chi.Middlewares {
+		if opts.Captcha == nil || !slices.Contains(opts.CaptchaEndpoints, endpoint) {
+			return nil
+		}
+		return chi.Middlewares{requireCaptcha(opts.Captcha, opts.TrustedProxies)}
+	}
+
+	a.public = func(r chi.Router) {
+		r.Use(maintenanceHandler.Gate)
 
-	r.Route("/api/v1", func(r chi.Router) {
 		r.Route("/cupcakes", func(r chi.Router) {
 			r.Get("/", cupcakeHandler.GetAllCupcakes)
+			r.Get("/by-sku/{sku}", cupcakeHandler.GetCupcakeBySKU)
+			r.Get("/slug/{slug}", cupcakeHandler.GetCupcakeBySlug)
+			r.Get("/trending", trendingHandler.GetTrending)
+			r.Route("/{id}", func(r chi.Router) {
+				r.With(trendingHandler.Track).Get("/", cupcakeHandler.GetCupcake)
+				r.Get("/qr", cupcakeHandler.GetCupcakeQR)
+				r.Get("/og", cupcakeHandler.GetCupcakeOG)
+				r.Get("/og/image", cupcakeHandler.GetCupcakeOGImage)
+				r.Get("/related", cupcakeHandler.GetRelatedCupcakes)
+			})
+		})
+
+		r.Get("/addons", addonHandler.GetAvailableAddons)
+		r.Get("/bundles", bundleHandler.GetAvailableBundles)
+
+		r.Route("/custom-cupcakes", func(r chi.Router) {
+			r.Get("/options", customCupcakeHandler.GetAvailableOptions)
+			r.Post("/quote", customCupcakeHandler.Quote)
+		})
+
+		r.Get("/gift-cards/{code}", giftCardHandler.GetBalance)
+
+		r.Get("/sync/cupcakes", syncHandler.SyncCupcakes)
+		r.Get("/search", searchHandler.Search)
+		r.Get("/settings", settingsHandler.GetPublicSettings)
+		r.Get("/experiments/assignments", experimentHandler.GetAssignments)
+		r.Post("/experiments/{key}/conversions", experimentHandler.RecordConversion)
+
+		r.With(captcha("data_export")...).Get("/me/data-export", dataExportHandler.RequestDataExport)
+		r.Get("/data-exports/{id}/download", dataExportHandler.DownloadDataExport)
+		r.With(captcha("erasure")...).Delete("/me", erasureHandler.EraseMe)
+		r.Get("/me/notification-preferences", notificationHandler.GetPreferences)
+		r.Put("/me/notification-preferences", notificationHandler.UpdatePreferences)
+		r.Get("/me/devices", deviceHandler.GetDevices)
+		r.Post("/me/devices", deviceHandler.RegisterDevice)
+		r.Delete("/me/devices/{token}", deviceHandler.UnregisterDevice)
+
+		r.Route("/auth", func(r chi.Router) {
+			r.With(captcha("register")...).Post("/register", authHandler.Register)
+			r.With(captcha("login")...).Post("/login", authHandler.Login)
+			r.Get("/me", authHandler.Me)
+			r.Get("/verify", authHandler.VerifyEmail)
+			r.Post("/verify/resend", authHandler.ResendVerification)
+			r.Get("/session", sessionHandler.Get)
+			r.With(captcha("login")...).Post("/session", sessionHandler.Login)
+			r.Delete("/session", sessionHandler.Logout)
+			r.Route("/oauth", func(r chi.Router) {
+				r.Get("/", oauthHandler.GetProviders)
+				r.Get("/{provider}", oauthHandler.Login)
+				r.Get("/{provider}/callback", oauthHandler.Callback)
+				r.Post("/{provider}/link", oauthHandler.Link)
+			})
+			r.Get("/identities", oauthHandler.GetIdentities)
+			r.Delete("/identities/{provider}", oauthHandler.Unlink)
+		})
+
+		r.Route("/locations", func(r chi.Router) {
+			r.Get("/", locationHandler.GetAllLocations)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", locationHandler.GetLocation)
+				r.Post("/pickup-check", locationHandler.CheckPickup)
+				r.Get("/slots", pickupHandler.GetSlots)
+				r.With(orderGate...).With(captcha("pickup")...).Post("/slots/{slotID}/reservations", pickupHandler.Reserve)
+			})
+		})
+
+		r.With(captcha("ticket")...).Post("/tickets", ticketHandler.CreateTicket)
+
+		r.Route("/subscriptions", func(r chi.Router) {
+			r.With(orderGate...).With(captcha("subscribe")...).Post("/", subscriptionHandler.Subscribe)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", subscriptionHandler.GetSubscription)
+				r.Post("/pause", subscriptionHandler.PauseSubscription)
+				r.Post("/resume", subscriptionHandler.ResumeSubscription)
+				r.Post("/cancel", subscriptionHandler.CancelSubscription)
+			})
+		})
+	}
+
+	a.admin = func(r chi.Router) {
+		r.Get("/maintenance", maintenanceHandler.GetStatus)
+		r.Post("/maintenance", maintenanceHandler.SetStatus)
+		r.Post("/read-only", maintenanceHandler.SetReadOnly)
+		r.Get("/validation-rules", validationHandler.GetRules)
+		r.Put("/validation-rules", validationHandler.SetRules)
+		r.Route("/settings", func(r chi.Router) {
+			r.Get("/", settingsHandler.GetSettings)
+			r.Get("/{key}", settingsHandler.GetSetting)
+			r.Put("/{key}", settingsHandler.SetSetting)
+			r.Delete("/{key}", settingsHandler.ResetSetting)
+		})
+		r.Post("/search/reindex", searchHandler.Reindex)
+
+		r.Get("/kitchen/production-plan", kitchenHandler.ProductionPlan)
+		r.Get("/kitchen/queue", kitchenHandler.Queue)
+		r.Get("/stats/top-cupcakes", statsHandler.TopCupcakes)
+
+		r.Delete("/customers", erasureHandler.EraseCustomer)
+		r.Get("/erasures", erasureHandler.GetErasures)
+
+		r.Post("/auth/login", adminHandler.Login)
+		r.Post("/auth/login/sms", adminHandler.SendLoginCode)
+		r.Route("/admins", func(r chi.Router) {
+			r.Get("/", adminHandler.GetAdmins)
+			r.Post("/", adminHandler.CreateAdmin)
+			r.Delete("/{id}/2fa", adminHandler.ResetTOTP)
+		})
+		r.Route("/me", func(r chi.Router) {
+			r.Get("/", adminHandler.Me)
+			r.Put("/phone", adminHandler.SetPhone)
+			r.Post("/2fa", adminHandler.BeginTOTP)
+			r.Post("/2fa/confirm", adminHandler.ConfirmTOTP)
+			r.Post("/2fa/disable", adminHandler.DisableTOTP)
+			r.Post("/2fa/backup-codes", adminHandler.RegenerateBackupCodes)
+		})
+
+		r.Route("/cupcakes", func(r chi.Router) {
 			r.Post("/", cupcakeHandler.CreateCupcake)
+			r.Post("/price-update", cupcakeHandler.BulkUpdatePrices)
 			r.Route("/{id}", func(r chi.Router) {
-				r.Get("/", cupcakeHandler.GetCupcake)
 				r.Put("/", cupcakeHandler.UpdateCupcake)
+				r.Patch("/", cupcakeHandler.PatchCupcake)
 				r.Delete("/", cupcakeHandler.DeleteCupcake)
+				r.Get("/versions", cupcakeHandler.GetCupcakeVersions)
+				r.Post("/revert/{version}", cupcakeHandler.RevertCupcake)
+				r.Get("/recipe", recipeHandler.GetRecipe)
+				r.Put("/recipe", recipeHandler.SetRecipe)
+				r.Get("/production", recipeHandler.GetProductionBatches)
+				r.Post("/production", recipeHandler.RecordProduction)
+				r.Get("/translations", translationHandler.GetTranslations)
+				r.Put("/translations/{locale}", translationHandler.SetTranslation)
+				r.Delete("/translations/{locale}", translationHandler.DeleteTranslation)
+			})
+		})
+
+		r.Route("/coupons", func(r chi.Router) {
+			r.Get("/", couponHandler.GetAllCoupons)
+			r.Post("/", couponHandler.CreateCoupon)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", couponHandler.GetCoupon)
+				r.Put("/", couponHandler.UpdateCoupon)
+				r.Delete("/", couponHandler.DeleteCoupon)
+			})
+		})
+
+		r.Route("/promotions", func(r chi.Router) {
+			r.Get("/", promotionHandler.GetAllPromotions)
+			r.Post("/", promotionHandler.CreatePromotion)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", promotionHandler.GetPromotion)
+				r.Put("/", promotionHandler.UpdatePromotion)
+				r.Delete("/", promotionHandler.DeletePromotion)
+			})
+		})
+
+		r.Route("/addons", func(r chi.Router) {
+			r.Get("/", addonHandler.GetAllAddons)
+			r.Post("/", addonHandler.CreateAddon)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", addonHandler.GetAddon)
+				r.Put("/", addonHandler.UpdateAddon)
+				r.Delete("/", addonHandler.DeleteAddon)
+			})
+		})
+
+		r.Route("/bundles", func(r chi.Router) {
+			r.Get("/", bundleHandler.GetAllBundles)
+			r.Post("/", bundleHandler.CreateBundle)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", bundleHandler.GetBundle)
+				r.Put("/", bundleHandler.UpdateBundle)
+				r.Delete("/", bundleHandler.DeleteBundle)
 			})
 		})
+
+		r.Route("/wholesale-accounts", func(r chi.Router) {
+			r.Get("/", wholesaleHandler.GetAllAccounts)
+			r.Post("/", wholesaleHandler.CreateAccount)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", wholesaleHandler.GetAccount)
+				r.Put("/", wholesaleHandler.UpdateAccount)
+				r.Delete("/", wholesaleHandler.DeleteAccount)
+				r.Get("/prices", wholesaleHandler.GetPrices)
+				r.Put("/prices/{cupcakeID}", wholesaleHandler.SetPrice)
+				r.Delete("/prices/{cupcakeID}", wholesaleHandler.DeletePrice)
+				r.Post("/quote", wholesaleHandler.Quote)
+			})
+		})
+
+		r.Route("/suppliers", func(r chi.Router) {
+			r.Get("/", procurementHandler.GetAllSuppliers)
+			r.Post("/", procurementHandler.CreateSupplier)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", procurementHandler.GetSupplier)
+				r.Put("/", procurementHandler.UpdateSupplier)
+			})
+		})
+
+		r.Route("/ingredients", func(r chi.Router) {
+			r.Get("/", procurementHandler.GetAllIngredients)
+			r.Post("/", procurementHandler.CreateIngredient)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", procurementHandler.GetIngredient)
+				r.Put("/", procurementHandler.UpdateIngredient)
+			})
+		})
+
+		r.Route("/purchase-orders", func(r chi.Router) {
+			r.Get("/", procurementHandler.GetPurchaseOrders)
+			r.Post("/", procurementHandler.CreatePurchaseOrder)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", procurementHandler.GetPurchaseOrder)
+				r.Post("/receive", procurementHandler.ReceivePurchaseOrder)
+				r.Post("/cancel", procurementHandler.CancelPurchaseOrder)
+			})
+		})
+
+		r.Route("/custom-options", func(r chi.Router) {
+			r.Get("/", customCupcakeHandler.GetAllOptions)
+			r.Post("/", customCupcakeHandler.CreateOption)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Put("/", customCupcakeHandler.UpdateOption)
+				r.Delete("/", customCupcakeHandler.DeleteOption)
+			})
+		})
+
+		r.Route("/gift-cards", func(r chi.Router) {
+			r.Get("/", giftCardHandler.GetAllGiftCards)
+			r.Post("/", giftCardHandler.IssueGiftCard)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", giftCardHandler.GetGiftCard)
+				r.Post("/void", giftCardHandler.VoidGiftCard)
+			})
+		})
+
+		r.Get("/subscriptions", subscriptionHandler.GetAllSubscriptions)
+
+		r.Route("/locations", func(r chi.Router) {
+			r.Post("/", locationHandler.CreateLocation)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Put("/", locationHandler.UpdateLocation)
+				r.Delete("/", locationHandler.DeleteLocation)
+				r.Get("/stock", locationHandler.GetStock)
+				r.Put("/stock/{cupcakeID}", locationHandler.SetStock)
+				r.Post("/slots", pickupHandler.CreateSlot)
+				r.Get("/pickups", pickupHandler.GetSchedule)
+			})
+		})
+
+		r.Post("/pickups/{id}/start", pickupHandler.Start)
+		r.Post("/pickups/{id}/ready", pickupHandler.MarkReady)
+		r.Post("/pickups/{id}/cancel", pickupHandler.Cancel)
+		r.Post("/pickups/{id}/print", printHandler.PrintTickets)
+
+		r.Route("/alerts", func(r chi.Router) {
+			r.Get("/", stockAlertHandler.GetAlerts)
+			r.Post("/{id}/acknowledge", stockAlertHandler.AcknowledgeAlert)
+			r.Post("/{id}/resolve", stockAlertHandler.ResolveAlert)
+		})
+
+		r.Route("/tickets", func(r chi.Router) {
+			r.Get("/", ticketHandler.GetTickets)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", ticketHandler.GetTicket)
+				r.Put("/", ticketHandler.UpdateTicket)
+			})
+		})
+
+		r.Route("/email-templates", func(r chi.Router) {
+			r.Get("/", emailTemplateHandler.GetTemplates)
+			r.Route("/{name}", func(r chi.Router) {
+				r.Get("/", emailTemplateHandler.GetTemplate)
+				r.Put("/", emailTemplateHandler.SetTemplate)
+				r.Delete("/", emailTemplateHandler.ResetTemplate)
+				r.Post("/preview", emailTemplateHandler.PreviewTemplate)
+			})
+		})
+
+		r.Route("/api-keys", func(r chi.Router) {
+			r.Get("/", apiKeyHandler.GetAllAPIKeys)
+			r.Post("/", apiKeyHandler.CreateAPIKey)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", apiKeyHandler.GetAPIKey)
+				r.Put("/", apiKeyHandler.UpdateAPIKey)
+				r.Delete("/", apiKeyHandler.RevokeAPIKey)
+				r.Get("/usage", apiKeyHandler.GetUsage)
+			})
+		})
+
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Get("/", webhookHandler.GetAllWebhooks)
+			r.Post("/", webhookHandler.CreateWebhook)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", webhookHandler.GetWebhook)
+				r.Put("/", webhookHandler.UpdateWebhook)
+				r.Delete("/", webhookHandler.DeleteWebhook)
+				r.Get("/deliveries", webhookHandler.GetDeliveries)
+			})
+		})
+
+		r.Route("/experiments", func(r chi.Router) {
+			r.Get("/", experimentHandler.GetAllExperiments)
+			r.Post("/", experimentHandler.CreateExperiment)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", experimentHandler.GetExperiment)
+				r.Post("/stop", experimentHandler.StopExperiment)
+			})
+		})
+
+		r.Route("/jobs", func(r chi.Router) {
+			r.Get("/", jobHandler.GetScheduledTasks)
+			r.Get("/dead", jobHandler.GetDeadJobs)
+		})
+	}
+
+	return a
+}
+
+// handler builds a router serving the public routes, the admin routes or
+// both, each group behind its own middleware stack. Health checks are
+// always served; metrics go with the admin API and the web app with the
+// storefront.
+func (a *api) handler(public, admin bool) http.Handler {
+	r := chi.NewRouter()
+
+	r.Use(requestLogger)
+	r.Use(middleware.Recoverer)
+	r.Use(middleware.RequestID)
+	maxBodyBytes := a.opts.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = defaultMaxBodyBytes
+	}
+	r.Use(limitBody(maxBodyBytes))
+	if a.opts.CompressionLevel > 0 {
+		r.Use(compress(a.opts.CompressionLevel, a.opts.Brotli))
+	}
+	r.Use(cors)
+	r.Use(a.readOnlyGate)
+
+	r.NotFound(notFound)
+	r.MethodNotAllowed(methodNotAllowed(r))
+	r.Get("/health", a.healthCheck)
+	r.Get("/health/ready", a.ready)
+	if admin {
+		r.With(a.adminNetworks...).Get("/metrics", metrics(a.db))
+	}
+
+	r.Route("/api/v1", func(r chi.Router) {
+		r.Use(rejectUnknownQuery(r))
+		if public {
+			r.Group(func(r chi.Router) {
+				r.Use(a.publicMiddlewares...)
+				a.public(r)
+			})
+		}
+		if admin {
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(a.adminMiddlewares...)
+				a.admin(r)
+			})
+		}
 	})
 
-	r.Handle("/", http.FileServer(http.Dir("web")))
+	if public {
+		static := staticFiles(web.Assets)
+		r.Get("/*", static)
+		r.Head("/*", static)
+	}
 
 	return r
 }
+
+func cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Accept-Currency, Authorization, Content-Type, X-API-Key, X-Captcha-Token, X-CSRF-Token")
+		w.Header().Set("Access-Control-Expose-Headers", "Link, Retry-After, X-Quota-Limit, X-Quota-Remaining, X-Quota-Reset")
+		w.Header().Set("Access-Control-Max-Age", "300")
+
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// PingDatabase adapts the database connection to a readiness check.
+func PingDatabase(db *gorm.DB) func(context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
@@ -2,12 +2,35 @@ package router
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/julimonteiro/cupcake-store/internal/config"
 	"github.com/julimonteiro/cupcake-store/internal/database"
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+	"github.com/julimonteiro/cupcake-store/internal/repository/inmem"
+	"github.com/julimonteiro/cupcake-store/internal/service"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
@@ -24,6 +47,34 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	return db
 }
 
+// testAdminToken is the admin token of the routers built by setupRouter.
+const testAdminToken = "test-admin-token"
+
+// setupRouter builds the router with an admin token, which it sends on the
+// admin requests that carry no Authorization, for the tests that are not
+// about admin authentication.
+func setupRouter(db *gorm.DB, opts Options) http.Handler {
+	if opts.AdminToken == "" && opts.AdminTokenFunc == nil {
+		opts.AdminToken = testAdminToken
+	}
+	return asAdmin(Setup(db, opts))
+}
+
+func setupSplitRouter(db *gorm.DB, opts Options) (public, admin http.Handler) {
+	opts.AdminToken = testAdminToken
+	public, admin = SetupSplit(db, opts)
+	return public, asAdmin(admin)
+}
+
+func asAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/v1/admin/") && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Bearer "+testAdminToken)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func TestSetup(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -49,7 +100,7 @@ func TestSetup(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db := setupTestDB(t)
-			router := Setup(db)
+			router := setupRouter(db, Options{})
 
 			if tt.validateResult != nil {
 				tt.validateResult(t, router)
@@ -77,7 +128,7 @@ func TestSetup_APIRoutes(t *testing.T) {
 		{
 			name:        "POST /api/v1/cupcakes",
 			method:      "POST",
-			path:        "/api/v1/cupcakes",
+			path:        "/api/v1/admin/cupcakes",
 			body:        []byte(`{"name":"Test","flavor":"Test","price_cents":100}`),
 			status:      http.StatusCreated,
 			description: "should return 201 for valid POST request",
@@ -85,10 +136,10 @@ func TestSetup_APIRoutes(t *testing.T) {
 		{
 			name:        "POST /api/v1/cupcakes with invalid data",
 			method:      "POST",
-			path:        "/api/v1/cupcakes",
+			path:        "/api/v1/admin/cupcakes",
 			body:        []byte(`{"name":"A","flavor":"X","price_cents":1}`),
-			status:      http.StatusBadRequest,
-			description: "should return 400 for invalid POST request",
+			status:      http.StatusUnprocessableEntity,
+			description: "should return 422 for invalid POST request",
 		},
 		{
 			name:        "GET /api/v1/cupcakes/1",
@@ -100,17 +151,17 @@ func TestSetup_APIRoutes(t *testing.T) {
 		{
 			name:        "PUT /api/v1/cupcakes/1",
 			method:      "PUT",
-			path:        "/api/v1/cupcakes/1",
+			path:        "/api/v1/admin/cupcakes/1",
 			body:        []byte(`{"name":"Updated"}`),
-			status:      http.StatusBadRequest,
-			description: "should return 400 for non-existent cupcake update",
+			status:      http.StatusNotFound,
+			description: "should return 404 for non-existent cupcake update",
 		},
 		{
 			name:        "DELETE /api/v1/cupcakes/1",
 			method:      "DELETE",
-			path:        "/api/v1/cupcakes/1",
-			status:      http.StatusBadRequest,
-			description: "should return 400 for non-existent cupcake deletion",
+			path:        "/api/v1/admin/cupcakes/1",
+			status:      http.StatusNotFound,
+			description: "should return 404 for non-existent cupcake deletion",
 		},
 		{
 			name:        "GET /api/v1/cupcakes/invalid",
@@ -122,7 +173,7 @@ func TestSetup_APIRoutes(t *testing.T) {
 		{
 			name:        "PUT /api/v1/cupcakes/invalid",
 			method:      "PUT",
-			path:        "/api/v1/cupcakes/invalid",
+			path:        "/api/v1/admin/cupcakes/invalid",
 			body:        []byte(`{"name":"Updated"}`),
 			status:      http.StatusBadRequest,
 			description: "should return 400 for invalid ID format in PUT",
@@ -130,7 +181,7 @@ func TestSetup_APIRoutes(t *testing.T) {
 		{
 			name:        "DELETE /api/v1/cupcakes/invalid",
 			method:      "DELETE",
-			path:        "/api/v1/cupcakes/invalid",
+			path:        "/api/v1/admin/cupcakes/invalid",
 			status:      http.StatusBadRequest,
 			description: "should return 400 for invalid ID format in DELETE",
 		},
@@ -139,7 +190,7 @@ func TestSetup_APIRoutes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db := setupTestDB(t)
-			router := Setup(db)
+			router := setupRouter(db, Options{})
 
 			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBuffer(tt.body))
 			if tt.body != nil {
@@ -158,19 +209,25 @@ func TestSetup_StaticFiles(t *testing.T) {
 		name           string
 		path           string
 		expectedStatus int
+		expectedType   string
+		expectedCache  string
 		description    string
 	}{
 		{
 			name:           "GET / (root path)",
 			path:           "/",
-			expectedStatus: http.StatusNotFound,
-			description:    "should handle static file serving (404 expected in test environment)",
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/html; charset=utf-8",
+			expectedCache:  "no-cache",
+			description:    "should serve the embedded index.html",
 		},
 		{
 			name:           "GET /index.html",
 			path:           "/index.html",
-			expectedStatus: http.StatusNotFound,
-			description:    "should handle static file serving for specific file",
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/html; charset=utf-8",
+			expectedCache:  "no-cache",
+			description:    "should serve index.html directly",
 		},
 		{
 			name:           "GET /nonexistent.html",
@@ -178,6 +235,42 @@ func TestSetup_StaticFiles(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			description:    "should return 404 for non-existent static file",
 		},
+		{
+			name:           "client-side route",
+			path:           "/cupcakes/42",
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/html; charset=utf-8",
+			expectedCache:  "no-cache",
+			description:    "should fall back to index.html",
+		},
+		{
+			name:           "unknown API version",
+			path:           "/api/v9/cupcakes",
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "application/json",
+			description:    "should return a JSON 404 instead of the app shell",
+		},
+		{
+			name:           "unknown API route",
+			path:           "/api/v1/unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "application/json",
+			description:    "should return a JSON 404 from the API router",
+		},
+		{
+			name:           "unknown admin route",
+			path:           "/api/v1/admin/unknown",
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "application/json",
+			description:    "should return a JSON 404 from nested routers",
+		},
+		{
+			name:           "below health check",
+			path:           "/health/details",
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "application/json",
+			description:    "should not serve the app shell under /health",
+		},
 	}
 
 	for _, tt := range tests {
@@ -191,14 +284,51 @@ func TestSetup_StaticFiles(t *testing.T) {
 			db, err := database.Init(cfg)
 			require.NoError(t, err)
 
-			router := Setup(db)
+			router := setupRouter(db, Options{})
 			require.NotNil(t, router)
 
 			req := httptest.NewRequest("GET", tt.path, nil)
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 
-			require.NotEqual(t, http.StatusInternalServerError, w.Code, tt.description)
+			require.Equal(t, tt.expectedStatus, w.Code, tt.description)
+			if tt.expectedType != "" {
+				require.Equal(t, tt.expectedType, w.Header().Get("Content-Type"))
+			}
+			require.Equal(t, tt.expectedCache, w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestStaticFiles_CacheHeaders(t *testing.T) {
+	assets := fstest.MapFS{
+		"index.html":                 {Data: []byte("<html></html>")},
+		"assets/app.3f9a2b1c.js":     {Data: []byte("console.log(1)")},
+		"assets/logo.svg":            {Data: []byte("<svg></svg>")},
+		"assets/vendor.0123abcd.css": {Data: []byte("body{}")},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedCache  string
+	}{
+		{name: "hashed script is immutable", path: "/assets/app.3f9a2b1c.js", expectedStatus: http.StatusOK, expectedCache: "public, max-age=31536000, immutable"},
+		{name: "hashed stylesheet is immutable", path: "/assets/vendor.0123abcd.css", expectedStatus: http.StatusOK, expectedCache: "public, max-age=31536000, immutable"},
+		{name: "unhashed asset is revalidated", path: "/assets/logo.svg", expectedStatus: http.StatusOK, expectedCache: "no-cache"},
+		{name: "directory falls back to index", path: "/assets", expectedStatus: http.StatusOK, expectedCache: "no-cache"},
+		{name: "missing asset", path: "/assets/app.deadbeef.js", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			staticFiles(assets).ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Equal(t, tt.expectedCache, w.Header().Get("Cache-Control"))
 		})
 	}
 }
@@ -225,8 +355,8 @@ func TestSetup_CORS(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedHeaders: map[string]string{
 				"Access-Control-Allow-Origin":  "*",
-				"Access-Control-Allow-Methods": "GET, POST, PUT, DELETE, OPTIONS",
-				"Access-Control-Allow-Headers": "Accept, Authorization, Content-Type, X-CSRF-Token",
+				"Access-Control-Allow-Methods": "GET, POST, PUT, PATCH, DELETE, OPTIONS",
+				"Access-Control-Allow-Headers": "Accept, Accept-Currency, Authorization, Content-Type, X-API-Key, X-Captcha-Token, X-CSRF-Token",
 			},
 			description: "should handle CORS preflight request",
 		},
@@ -238,8 +368,8 @@ func TestSetup_CORS(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedHeaders: map[string]string{
 				"Access-Control-Allow-Origin":  "*",
-				"Access-Control-Allow-Methods": "GET, POST, PUT, DELETE, OPTIONS",
-				"Access-Control-Allow-Headers": "Accept, Authorization, Content-Type, X-CSRF-Token",
+				"Access-Control-Allow-Methods": "GET, POST, PUT, PATCH, DELETE, OPTIONS",
+				"Access-Control-Allow-Headers": "Accept, Accept-Currency, Authorization, Content-Type, X-API-Key, X-Captcha-Token, X-CSRF-Token",
 			},
 			description: "should handle OPTIONS request without CORS headers",
 		},
@@ -269,7 +399,7 @@ func TestSetup_CORS(t *testing.T) {
 			db, err := database.Init(cfg)
 			require.NoError(t, err)
 
-			router := Setup(db)
+			router := setupRouter(db, Options{})
 			require.NotNil(t, router)
 
 			req := httptest.NewRequest(tt.method, tt.path, nil)
@@ -314,7 +444,7 @@ func TestSetup_Middleware(t *testing.T) {
 		{
 			name:           "POST /api/v1/cupcakes with logger middleware",
 			method:         "POST",
-			path:           "/api/v1/cupcakes",
+			path:           "/api/v1/admin/cupcakes",
 			expectedStatus: http.StatusBadRequest,
 			description:    "should apply logger middleware to POST requests",
 		},
@@ -323,7 +453,7 @@ func TestSetup_Middleware(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db := setupTestDB(t)
-			router := Setup(db)
+			router := setupRouter(db, Options{})
 
 			req := httptest.NewRequest(tt.method, tt.path, nil)
 			if tt.method == "POST" {
@@ -362,7 +492,7 @@ func TestSetup_RouteStructure(t *testing.T) {
 		{
 			name:           "cupcake create route",
 			method:         "POST",
-			path:           "/api/v1/cupcakes",
+			path:           "/api/v1/admin/cupcakes",
 			expectedStatus: http.StatusBadRequest,
 			description:    "should have cupcake create route",
 		},
@@ -376,23 +506,44 @@ func TestSetup_RouteStructure(t *testing.T) {
 		{
 			name:           "cupcake update route",
 			method:         "PUT",
-			path:           "/api/v1/cupcakes/1",
+			path:           "/api/v1/admin/cupcakes/1",
 			expectedStatus: http.StatusBadRequest,
 			description:    "should have cupcake update route",
 		},
 		{
 			name:           "cupcake delete route",
 			method:         "DELETE",
-			path:           "/api/v1/cupcakes/1",
-			expectedStatus: http.StatusBadRequest,
+			path:           "/api/v1/admin/cupcakes/1",
+			expectedStatus: http.StatusNotFound,
 			description:    "should have cupcake delete route",
 		},
+		{
+			name:           "admin coupons list route",
+			method:         "GET",
+			path:           "/api/v1/admin/coupons",
+			expectedStatus: http.StatusOK,
+			description:    "should have admin coupons list route",
+		},
+		{
+			name:           "admin scheduled jobs route",
+			method:         "GET",
+			path:           "/api/v1/admin/jobs",
+			expectedStatus: http.StatusOK,
+			description:    "should have admin scheduled jobs status route",
+		},
+		{
+			name:           "admin dead jobs route",
+			method:         "GET",
+			path:           "/api/v1/admin/jobs/dead",
+			expectedStatus: http.StatusOK,
+			description:    "should have admin dead letter jobs route",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db := setupTestDB(t)
-			router := Setup(db)
+			router := setupRouter(db, Options{})
 
 			req := httptest.NewRequest(tt.method, tt.path, nil)
 			if tt.method == "POST" || tt.method == "PUT" {
@@ -418,7 +569,7 @@ func TestSetup_ErrorHandling(t *testing.T) {
 		{
 			name:           "malformed JSON in POST",
 			method:         "POST",
-			path:           "/api/v1/cupcakes",
+			path:           "/api/v1/admin/cupcakes",
 			body:           []byte(`{"name":"Test", "flavor":"Test", "price_cents":1000, "extra_field": "invalid"`),
 			expectedStatus: http.StatusBadRequest,
 			description:    "should handle malformed JSON",
@@ -426,7 +577,7 @@ func TestSetup_ErrorHandling(t *testing.T) {
 		{
 			name:           "invalid JSON in PUT",
 			method:         "PUT",
-			path:           "/api/v1/cupcakes/1",
+			path:           "/api/v1/admin/cupcakes/1",
 			body:           []byte(`{"name":"Test", "flavor":"Test", "price_cents":1000,}`),
 			expectedStatus: http.StatusBadRequest,
 			description:    "should handle invalid JSON in PUT",
@@ -434,7 +585,7 @@ func TestSetup_ErrorHandling(t *testing.T) {
 		{
 			name:           "empty body in POST",
 			method:         "POST",
-			path:           "/api/v1/cupcakes",
+			path:           "/api/v1/admin/cupcakes",
 			body:           []byte(``),
 			expectedStatus: http.StatusBadRequest,
 			description:    "should handle empty body",
@@ -442,7 +593,7 @@ func TestSetup_ErrorHandling(t *testing.T) {
 		{
 			name:           "non-JSON body in POST",
 			method:         "POST",
-			path:           "/api/v1/cupcakes",
+			path:           "/api/v1/admin/cupcakes",
 			body:           []byte(`not json`),
 			expectedStatus: http.StatusBadRequest,
 			description:    "should handle non-JSON body",
@@ -452,7 +603,7 @@ func TestSetup_ErrorHandling(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db := setupTestDB(t)
-			router := Setup(db)
+			router := setupRouter(db, Options{})
 
 			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBuffer(tt.body))
 			req.Header.Set("Content-Type", "application/json")
@@ -463,3 +614,1310 @@ func TestSetup_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestSetup_MethodNotAllowed(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		expectedAllow string
+	}{
+		{name: "cupcake item", method: "POST", path: "/api/v1/cupcakes/1", expectedAllow: "GET"},
+		{name: "cupcake collection", method: "DELETE", path: "/api/v1/cupcakes", expectedAllow: "GET"},
+		{name: "admin cupcake item", method: "GET", path: "/api/v1/admin/cupcakes/1", expectedAllow: "PUT, PATCH, DELETE"},
+		{name: "nested admin route", method: "POST", path: "/api/v1/admin/jobs/dead", expectedAllow: "GET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRouter(setupTestDB(t), Options{})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+			require.Equal(t, tt.expectedAllow, w.Header().Get("Allow"))
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			require.Equal(t, "method not allowed", body["error"])
+		})
+	}
+}
+
+func TestSetup_NotFoundJSON(t *testing.T) {
+	router := setupRouter(setupTestDB(t), Options{})
+
+	for _, path := range []string{"/api/v1/unknown", "/api/v1/cupcakes/1/unknown", "/health/unknown"} {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code, path)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"), path)
+		require.JSONEq(t, `{"error":"not found"}`, w.Body.String(), path)
+	}
+}
+
+func TestSetup_UnknownQueryParams(t *testing.T) {
+	router := setupRouter(setupTestDB(t), Options{})
+	seedCupcakes(t, router, 1)
+
+	tests := []struct {
+		name              string
+		method            string
+		path              string
+		expectedStatus    int
+		expectedUnknown   []string
+		expectedSupported []string
+	}{
+		{
+			name:           "supported parameters",
+			method:         "GET",
+			path:           "/api/v1/cupcakes?fields=id,name&lang=en",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:              "typo is rejected",
+			method:            "GET",
+			path:              "/api/v1/cupcakes?availible=true",
+			expectedStatus:    http.StatusBadRequest,
+			expectedUnknown:   []string{"availible"},
+			expectedSupported: []string{"currency", "fields", "format", "lang", "location_id", "page", "per_page"},
+		},
+		{
+			name:              "every unknown parameter is listed",
+			method:            "GET",
+			path:              "/api/v1/cupcakes/1?limit=5&currency=BRL&sort=name",
+			expectedStatus:    http.StatusBadRequest,
+			expectedUnknown:   []string{"limit", "sort"},
+			expectedSupported: []string{"currency", "fields", "lang"},
+		},
+		{
+			name:              "routes without parameters accept only the global ones",
+			method:            "GET",
+			path:              "/api/v1/addons?flavor=lemon",
+			expectedStatus:    http.StatusBadRequest,
+			expectedUnknown:   []string{"flavor"},
+			expectedSupported: []string{"lang"},
+		},
+		{
+			name:              "parameters are per method",
+			method:            "POST",
+			path:              "/api/v1/admin/cupcakes?fields=id",
+			expectedStatus:    http.StatusBadRequest,
+			expectedUnknown:   []string{"fields"},
+			expectedSupported: []string{"force", "lang"},
+		},
+		{
+			name:           "unknown routes still answer 404",
+			method:         "GET",
+			path:           "/api/v1/unknown?foo=bar",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "static files are not checked",
+			method:         "GET",
+			path:           "/?cupcake=1",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedUnknown == nil {
+				return
+			}
+
+			var response struct {
+				Error     string   `json:"error"`
+				Unknown   []string `json:"unknown"`
+				Supported []string `json:"supported"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Contains(t, response.Error, "unknown query parameter")
+			require.Equal(t, tt.expectedUnknown, response.Unknown)
+			require.Equal(t, tt.expectedSupported, response.Supported)
+		})
+	}
+}
+
+// TestQueryParams_RoutesExist keeps the query parameter table in step with
+// the routes, so a renamed route does not silently lose its parameters.
+func TestQueryParams_RoutesExist(t *testing.T) {
+	routes := Setup(setupTestDB(t), Options{}).(chi.Routes)
+
+	registered := map[string]bool{}
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		registered[method+" "+strings.TrimSuffix(route, "/")] = true
+		return nil
+	})
+	require.NoError(t, err)
+
+	for key := range queryParams {
+		require.True(t, registered[key], key)
+	}
+}
+
+// TestQueryParams_CoverHandlers keeps the query parameter table in step
+// with the handlers: every parameter a route's handler reads, directly or
+// through the handler package's helpers, must be listed for the route, or
+// the router answers 400 before the handler ever sees it.
+func TestQueryParams_CoverHandlers(t *testing.T) {
+	reads := handlerQueryReads(t, "../handler")
+	routes := Setup(setupTestDB(t), Options{}).(chi.Routes)
+
+	checked := 0
+	err := chi.Walk(routes, func(method, route string, h http.Handler, _ ...func(http.Handler) http.Handler) error {
+		name := runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+		// github.com/.../internal/handler.(*StatsHandler).TopCupcakes-fm
+		name, ok := strings.CutPrefix(name, "github.com/julimonteiro/cupcake-store/internal/handler.")
+		if !ok {
+			return nil
+		}
+		name = strings.TrimSuffix(strings.NewReplacer("(*", "", ")", "").Replace(name), "-fm")
+
+		key := method + " " + strings.TrimSuffix(route, "/")
+		supported := append(slices.Clone(globalQueryParams), queryParams[key]...)
+		for _, param := range reads(name) {
+			require.Contains(t, supported, param, "%s reads ?%s=, which is missing from queryParams", key, param)
+		}
+		checked++
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotZero(t, checked)
+}
+
+// handlerQueryReads parses the handler package and returns, for a function
+// or a method named "Type.Method", the query parameters it reads: literal
+// names passed to Get, Has or an index of r.URL.Query() or of a url.Values,
+// including those read by the package's functions and methods it calls.
+func handlerQueryReads(t *testing.T, dir string) func(name string) []string {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool { return !strings.HasSuffix(fi.Name(), "_test.go") }, 0)
+	require.NoError(t, err)
+
+	direct := map[string][]string{}
+	calls := map[string][]string{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				name, recv, recvType := fn.Name.Name, "", ""
+				if fn.Recv != nil && len(fn.Recv.List) == 1 {
+					typ := fn.Recv.List[0].Type
+					if star, ok := typ.(*ast.StarExpr); ok {
+						typ = star.X
+					}
+					if ident, ok := typ.(*ast.Ident); ok {
+						recvType = ident.Name
+						name = recvType + "." + name
+					}
+					if len(fn.Recv.List[0].Names) == 1 {
+						recv = fn.Recv.List[0].Names[0].Name
+					}
+				}
+
+				// Identifiers holding a url.Values: parameters of that type
+				// and variables assigned r.URL.Query().
+				values := map[string]bool{}
+				for _, field := range fn.Type.Params.List {
+					if sel, ok := field.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Values" {
+						for _, n := range field.Names {
+							values[n.Name] = true
+						}
+					}
+				}
+				isQuery := func(expr ast.Expr) bool {
+					if ident, ok := expr.(*ast.Ident); ok {
+						return values[ident.Name]
+					}
+					call, ok := expr.(*ast.CallExpr)
+					if !ok {
+						return false
+					}
+					sel, ok := call.Fun.(*ast.SelectorExpr)
+					if !ok || sel.Sel.Name != "Query" {
+						return false
+					}
+					inner, ok := sel.X.(*ast.SelectorExpr)
+					return ok && inner.Sel.Name == "URL"
+				}
+				literal := func(expr ast.Expr) (string, bool) {
+					lit, ok := expr.(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						return "", false
+					}
+					s, err := strconv.Unquote(lit.Value)
+					return s, err == nil
+				}
+
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.AssignStmt:
+						if len(n.Lhs) == 1 && len(n.Rhs) == 1 && isQuery(n.Rhs[0]) {
+							if ident, ok := n.Lhs[0].(*ast.Ident); ok {
+								values[ident.Name] = true
+							}
+						}
+					case *ast.IndexExpr:
+						if param, ok := literal(n.Index); ok && isQuery(n.X) {
+							direct[name] = append(direct[name], param)
+						}
+					case *ast.CallExpr:
+						switch fun := n.Fun.(type) {
+						case *ast.Ident:
+							calls[name] = append(calls[name], fun.Name)
+						case *ast.SelectorExpr:
+							if (fun.Sel.Name == "Get" || fun.Sel.Name == "Has") && len(n.Args) == 1 && isQuery(fun.X) {
+								if param, ok := literal(n.Args[0]); ok {
+									direct[name] = append(direct[name], param)
+								}
+							}
+							if ident, ok := fun.X.(*ast.Ident); ok && ident.Name == recv && recvType != "" {
+								calls[name] = append(calls[name], recvType+"."+fun.Sel.Name)
+							}
+						}
+					}
+					return true
+				})
+			}
+		}
+	}
+
+	return func(name string) []string {
+		seen := map[string]bool{}
+		var params []string
+		var visit func(string)
+		visit = func(name string) {
+			if seen[name] {
+				return
+			}
+			seen[name] = true
+			params = append(params, direct[name]...)
+			for _, callee := range calls[name] {
+				visit(callee)
+			}
+		}
+		visit(name)
+		slices.Sort(params)
+		return slices.Compact(params)
+	}
+}
+
+func seedCupcakes(tb testing.TB, router http.Handler, n int) {
+	tb.Helper()
+
+	for i := 0; i < n; i++ {
+		body := fmt.Sprintf(`{"name":"Cupcake %d","flavor":"Vanilla Bean","price_cents":%d}`, i+1, 1000+i)
+		req := httptest.NewRequest("POST", "/api/v1/admin/cupcakes", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(tb, http.StatusCreated, w.Code)
+	}
+}
+
+func TestSetup_Compression(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             Options
+		path             string
+		acceptEncoding   string
+		expectedEncoding string
+	}{
+		{
+			name:             "disabled by default",
+			opts:             Options{},
+			path:             "/api/v1/cupcakes",
+			acceptEncoding:   "gzip",
+			expectedEncoding: "",
+		},
+		{
+			name:             "gzip for JSON listings",
+			opts:             Options{CompressionLevel: 5},
+			path:             "/api/v1/cupcakes",
+			acceptEncoding:   "gzip",
+			expectedEncoding: "gzip",
+		},
+		{
+			name:             "identity when client does not ask",
+			opts:             Options{CompressionLevel: 5},
+			path:             "/api/v1/cupcakes",
+			expectedEncoding: "",
+		},
+		{
+			name:             "brotli preferred when enabled",
+			opts:             Options{CompressionLevel: 5, Brotli: true},
+			path:             "/api/v1/cupcakes",
+			acceptEncoding:   "gzip, br",
+			expectedEncoding: "br",
+		},
+		{
+			name:             "brotli not offered unless enabled",
+			opts:             Options{CompressionLevel: 5},
+			path:             "/api/v1/cupcakes",
+			acceptEncoding:   "br",
+			expectedEncoding: "",
+		},
+		{
+			name:             "PNG responses are left alone",
+			opts:             Options{CompressionLevel: 5},
+			path:             "/api/v1/cupcakes/1/qr",
+			acceptEncoding:   "gzip",
+			expectedEncoding: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			router := setupRouter(db, tt.opts)
+			seedCupcakes(t, router, 3)
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Equal(t, tt.expectedEncoding, w.Header().Get("Content-Encoding"))
+
+			if tt.expectedEncoding == "gzip" {
+				zr, err := gzip.NewReader(w.Body)
+				require.NoError(t, err)
+				var cupcakes []map[string]interface{}
+				require.NoError(t, json.NewDecoder(zr).Decode(&cupcakes))
+				require.Len(t, cupcakes, 3)
+			}
+		})
+	}
+}
+
+// BenchmarkListCupcakes_Compression reports the response size of a large
+// listing for each encoding; compare the resp-bytes metric across runs.
+func BenchmarkListCupcakes_Compression(b *testing.B) {
+	for _, encoding := range []string{"identity", "gzip", "br"} {
+		b.Run(encoding, func(b *testing.B) {
+			db, err := database.Init(&config.Config{DBDialect: "sqlite", DBDSN: ":memory:", LogLevel: "error"})
+			require.NoError(b, err)
+			router := setupRouter(db, Options{CompressionLevel: 5, Brotli: true})
+			seedCupcakes(b, router, 500)
+
+			var size int
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				req := httptest.NewRequest("GET", "/api/v1/cupcakes", nil)
+				req.Header.Set("Accept-Encoding", encoding)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				size = w.Body.Len()
+			}
+			b.ReportMetric(float64(size), "resp-bytes")
+		})
+	}
+}
+
+func TestSetup_BodyLimit(t *testing.T) {
+	oversized := `{"name":"` + strings.Repeat("a", 2048) + `","flavor":"Vanilla","price_cents":1000}`
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		chunked        bool
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "body within limit",
+			method:         "POST",
+			path:           "/api/v1/admin/cupcakes",
+			body:           `{"name":"Chocolate","flavor":"Cocoa","price_cents":1000}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "oversized Content-Length is rejected up front",
+			method:         "POST",
+			path:           "/api/v1/admin/cupcakes",
+			body:           oversized,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedError:  "request body exceeds 1024 bytes",
+		},
+		{
+			name:           "oversized body without Content-Length stops while decoding",
+			method:         "POST",
+			path:           "/api/v1/admin/cupcakes",
+			body:           oversized,
+			chunked:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedError:  "request body exceeds 1024 bytes",
+		},
+		{
+			name:           "limit applies to admin write endpoints",
+			method:         "POST",
+			path:           "/api/v1/admin/coupons",
+			body:           oversized,
+			chunked:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedError:  "request body exceeds 1024 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			router := setupRouter(db, Options{MaxBodyBytes: 1024})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response map[string]string
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				require.Equal(t, tt.expectedError, response["error"])
+			}
+		})
+	}
+}
+
+func TestSetup_Maintenance(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "public API is unavailable", method: "GET", path: "/api/v1/cupcakes", expectedStatus: http.StatusServiceUnavailable},
+		{name: "public writes are unavailable", method: "POST", path: "/api/v1/subscriptions", expectedStatus: http.StatusServiceUnavailable},
+		{name: "health check keeps working", method: "GET", path: "/health", expectedStatus: http.StatusOK},
+		{name: "admin routes keep working", method: "GET", path: "/api/v1/admin/coupons", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			router := setupRouter(db, Options{Maintenance: service.NewMaintenanceService(true, false, time.Minute)})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusServiceUnavailable {
+				require.Equal(t, "60", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestSetup_ReadOnly(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupRouter(db, Options{})
+
+	send := func(method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, send("POST", "/api/v1/admin/read-only", `{"enabled":true}`))
+	require.Equal(t, http.StatusServiceUnavailable, send("POST", "/api/v1/admin/cupcakes", `{"name":"Chocolate","flavor":"Cocoa","price_cents":1000}`))
+	require.Equal(t, http.StatusServiceUnavailable, send("POST", "/api/v1/admin/coupons", `{}`))
+	require.Equal(t, http.StatusOK, send("GET", "/api/v1/cupcakes", ""))
+	require.Equal(t, http.StatusOK, send("POST", "/api/v1/admin/read-only", `{"enabled":false}`))
+	require.Equal(t, http.StatusCreated, send("POST", "/api/v1/admin/cupcakes", `{"name":"Chocolate","flavor":"Cocoa","price_cents":1000}`))
+}
+
+// stubCupcakeService implements only what the listing needs; any other
+// method panics on the nil embedded interface.
+type stubCupcakeService struct {
+	service.CupcakeServiceInterface
+	cupcakes []models.Cupcake
+	err      error
+}
+
+func (s *stubCupcakeService) GetAllCupcakes() ([]models.Cupcake, error) {
+	return s.cupcakes, s.err
+}
+
+func (s *stubCupcakeService) ConvertPrices([]models.Cupcake, string) error {
+	return nil
+}
+
+func (s *stubCupcakeService) Localize([]models.Cupcake, string) (string, error) {
+	return "", nil
+}
+
+func TestSetup_Services(t *testing.T) {
+	tests := []struct {
+		name           string
+		stub           *stubCupcakeService
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "swapped service serves the listing",
+			stub:           &stubCupcakeService{cupcakes: []models.Cupcake{{ID: 7, Name: "Stub", Flavor: "Vanilla", PriceCents: 500}}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"name":"Stub"`,
+		},
+		{
+			name:           "swapped service errors surface as 500",
+			stub:           &stubCupcakeService{err: errors.New("boom")},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Error fetching cupcakes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			services := NewServices(db, Options{})
+			services.Cupcakes = tt.stub
+			router := setupRouter(db, Options{Services: &services})
+
+			req := httptest.NewRequest("GET", "/api/v1/cupcakes", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			require.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestSetup_InMemoryCatalog(t *testing.T) {
+	db := setupTestDB(t)
+	catalog := inmem.NewCupcakeRepository()
+	router := setupRouter(db, Options{CupcakeRepository: catalog})
+
+	body := `{"name":"Chocolate","flavor":"Cocoa","price_cents":1000,"sku":"CHO-001"}`
+	req := httptest.NewRequest("POST", "/api/v1/admin/cupcakes", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/cupcakes/by-sku/CHO-001", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"name":"Chocolate"`)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Cupcake{}).Count(&count).Error)
+	require.Zero(t, count)
+	exists, err := catalog.Exists(1)
+	require.NoError(t, err)
+	require.True(t, exists)
+}
+
+func TestSetup_Metrics(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupRouter(db, Options{})
+
+	req := httptest.NewRequest("GET", "/api/v1/cupcakes", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/metrics", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	require.Contains(t, w.Body.String(), "cupcake_store_db_open_connections ")
+	require.Contains(t, w.Body.String(), "cupcake_store_db_wait_count_total ")
+	require.Contains(t, w.Body.String(), `cupcake_store_db_queries_total{operation="query"}`)
+}
+
+func TestSetup_Readiness(t *testing.T) {
+	db := setupTestDB(t)
+
+	req := httptest.NewRequest("GET", "/health/ready", nil)
+	w := httptest.NewRecorder()
+	setupRouter(db, Options{}).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"database":{"status":"ok"`)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	w = httptest.NewRecorder()
+	setupRouter(db, Options{}).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"unavailable"`)
+}
+
+func TestSetupSplit(t *testing.T) {
+	public, admin := setupSplitRouter(setupTestDB(t), Options{})
+
+	tests := []struct {
+		name           string
+		handler        http.Handler
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{name: "public serves the storefront", handler: public, method: "GET", path: "/api/v1/cupcakes", expectedStatus: http.StatusOK},
+		{name: "public serves the web app", handler: public, method: "GET", path: "/", expectedStatus: http.StatusOK},
+		{name: "public hides the admin API", handler: public, method: "GET", path: "/api/v1/admin/coupons", expectedStatus: http.StatusNotFound},
+		{name: "admin serves the admin API", handler: admin, method: "GET", path: "/api/v1/admin/coupons", expectedStatus: http.StatusOK},
+		{name: "admin serves metrics", handler: admin, method: "GET", path: "/metrics", expectedStatus: http.StatusOK},
+		{name: "admin hides the storefront", handler: admin, method: "GET", path: "/api/v1/cupcakes", expectedStatus: http.StatusNotFound},
+		{name: "admin hides the web app", handler: admin, method: "GET", path: "/", expectedStatus: http.StatusNotFound},
+		{name: "both serve health checks", handler: admin, method: "GET", path: "/health", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestSetup_AdminToken(t *testing.T) {
+	router := Setup(setupTestDB(t), Options{AdminToken: "s3cret"})
+
+	tests := []struct {
+		name           string
+		path           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "missing token", path: "/api/v1/admin/coupons", expectedStatus: http.StatusUnauthorized},
+		{name: "wrong token", path: "/api/v1/admin/coupons", authorization: "Bearer guess", expectedStatus: http.StatusUnauthorized},
+		{name: "valid token", path: "/api/v1/admin/coupons", authorization: "Bearer s3cret", expectedStatus: http.StatusOK},
+		{name: "storefront needs no token", path: "/api/v1/cupcakes", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				require.Equal(t, `Bearer realm="admin"`, w.Header().Get("WWW-Authenticate"))
+				require.Contains(t, w.Body.String(), "unauthorized")
+			}
+		})
+	}
+}
+
+func TestSetup_AdminWithoutToken(t *testing.T) {
+	// Without an admin token or accounts, the admin API is closed.
+	router := Setup(setupTestDB(t), Options{AuthTokenSecret: "test-secret"})
+
+	for _, tt := range []struct{ method, path, body string }{
+		{"GET", "/api/v1/admin/coupons", ""},
+		{"POST", "/api/v1/admin/admins", `{"name":"Ana","email":"ana@example.com","password":"correct horse","role":"super_admin"}`},
+		{"DELETE", "/api/v1/admin/customers?email=ana@example.com", ""},
+	} {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusUnauthorized, w.Code, tt.path)
+	}
+
+	// The login stays reachable for admin accounts.
+	req := httptest.NewRequest("POST", "/api/v1/admin/auth/login", strings.NewReader(`{"email":"ana@example.com","password":"correct horse"}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.NotContains(t, w.Body.String(), `"unauthorized"`)
+}
+
+func TestSetup_AdminTokenFunc(t *testing.T) {
+	token := "first"
+	router := Setup(setupTestDB(t), Options{AdminTokenFunc: func() string { return token }})
+
+	get := func(authorization string) int {
+		req := httptest.NewRequest("GET", "/api/v1/admin/coupons", nil)
+		req.Header.Set("Authorization", authorization)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, get("Bearer first"))
+	token = "second"
+	require.Equal(t, http.StatusUnauthorized, get("Bearer first"))
+	require.Equal(t, http.StatusOK, get("Bearer second"))
+	token = ""
+	require.Equal(t, http.StatusUnauthorized, get("Bearer "))
+}
+
+func TestSetup_AdminAccounts(t *testing.T) {
+	router := Setup(setupTestDB(t), Options{
+		AdminToken:      "s3cret",
+		AuthTokenSecret: "test-secret",
+		PasswordParams:  password.Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+	})
+
+	do := func(method, path, authorization, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The static token bootstraps the first super-admin.
+	w := do("POST", "/api/v1/admin/admins", "Bearer s3cret", `{"name":"Ana","email":"ana@example.com","password":"correct horse","role":"super_admin"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, http.StatusUnauthorized, do("GET", "/api/v1/admin/me", "Bearer s3cret", "").Code)
+
+	w = do("POST", "/api/v1/admin/auth/login", "", `{"email":"ana@example.com","password":"correct horse"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var token models.AdminAccessToken
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&token))
+	bearer := "Bearer " + token.AccessToken
+
+	require.Equal(t, http.StatusOK, do("GET", "/api/v1/admin/coupons", bearer, "").Code)
+	require.Equal(t, http.StatusOK, do("GET", "/api/v1/admin/me", bearer, "").Code)
+	require.Equal(t, http.StatusUnauthorized, do("GET", "/api/v1/admin/coupons", bearer+"x", "").Code)
+
+	w = do("POST", "/api/v1/admin/admins", bearer, `{"name":"Bruno","email":"bruno@example.com","password":"correct horse"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = do("POST", "/api/v1/admin/auth/login", "", `{"email":"bruno@example.com","password":"correct horse"}`)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&token))
+	require.Equal(t, http.StatusForbidden, do("POST", "/api/v1/admin/admins", "Bearer "+token.AccessToken, `{"name":"Carla","email":"carla@example.com","password":"correct horse"}`).Code)
+	require.Equal(t, http.StatusForbidden, do("DELETE", "/api/v1/admin/admins/1/2fa", "Bearer "+token.AccessToken, "").Code)
+	require.Equal(t, http.StatusOK, do("DELETE", "/api/v1/admin/admins/2/2fa", bearer, "").Code)
+}
+
+func TestSetup_APIKeyQuota(t *testing.T) {
+	router := setupRouter(setupTestDB(t), Options{})
+	do := func(method, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("POST", "/api/v1/admin/api-keys", "", `{"name":"Partner","daily_quota":1}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created models.CreatedAPIKey
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+
+	w = do("GET", "/api/v1/cupcakes", created.Key, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "0", w.Header().Get("X-Quota-Remaining"))
+	require.Contains(t, w.Header().Get("Access-Control-Expose-Headers"), "X-Quota-Remaining")
+	require.Equal(t, http.StatusTooManyRequests, do("GET", "/api/v1/cupcakes", created.Key, "").Code)
+	// Requests without a key are not metered.
+	require.Equal(t, http.StatusOK, do("GET", "/api/v1/cupcakes", "", "").Code)
+
+	w = do("GET", fmt.Sprintf("/api/v1/admin/api-keys/%d/usage?window=1d", created.ID), "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"today":1`)
+}
+
+func TestSetup_SessionCookie(t *testing.T) {
+	sent := 0
+	sender := &mocks.EmailSender{SendFunc: func(context.Context, string, *models.RenderedEmail) error {
+		sent++
+		return nil
+	}}
+	router := setupRouter(setupTestDB(t), Options{
+		AuthTokenSecret: "test-secret",
+		PasswordParams:  password.Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		Email:           sender,
+		PublicURL:       "https://loja.example.com",
+	})
+
+	var cookie *http.Cookie
+	do := func(method, path, csrf, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		if csrf != "" {
+			req.Header.Set("X-CSRF-Token", csrf)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("POST", "/api/v1/auth/register", "", `{"name":"Ana","email":"ana@example.com","password":"correct horse"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	w = do("POST", "/api/v1/auth/session", "", `{"email":"ana@example.com","password":"correct horse"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "access_token")
+	var session models.WebSession
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&session))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.True(t, cookies[0].HttpOnly)
+	cookie = cookies[0]
+
+	// The cookie stands in for the bearer token.
+	w = do("GET", "/api/v1/auth/me", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "ana@example.com")
+	w = do("GET", "/api/v1/auth/session", "", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), session.CSRFToken)
+
+	// Changes need the CSRF token as well.
+	require.Equal(t, http.StatusForbidden, do("POST", "/api/v1/auth/verify/resend", "", "").Code)
+	require.Equal(t, http.StatusForbidden, do("POST", "/api/v1/auth/verify/resend", "0123", "").Code)
+	require.Equal(t, http.StatusAccepted, do("POST", "/api/v1/auth/verify/resend", session.CSRFToken, "").Code)
+	require.Equal(t, 2, sent)
+
+	require.Equal(t, http.StatusForbidden, do("DELETE", "/api/v1/auth/session", "", "").Code)
+	require.Equal(t, http.StatusNoContent, do("DELETE", "/api/v1/auth/session", session.CSRFToken, "").Code)
+	require.Equal(t, http.StatusUnauthorized, do("GET", "/api/v1/auth/me", "", "").Code)
+	require.Equal(t, http.StatusUnauthorized, do("GET", "/api/v1/auth/session", "", "").Code)
+}
+
+func TestSetup_StoreURL(t *testing.T) {
+	proxies, err := ParseNetworks("10.0.0.1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		publicURL      string
+		remoteAddr     string
+		forwardedProto string
+		expectedURL    string
+		expectedSecure bool
+	}{
+		{name: "public URL wins over the request", publicURL: "https://loja.example.com/", remoteAddr: "10.0.0.1:1234", forwardedProto: "http", expectedURL: "https://loja.example.com", expectedSecure: true},
+		{name: "trusted proxy sets the scheme", remoteAddr: "10.0.0.1:1234", forwardedProto: "https", expectedURL: "https://evil.example.com", expectedSecure: true},
+		{name: "client cannot set the scheme", remoteAddr: "198.51.100.1:1234", forwardedProto: "https", expectedURL: "http://evil.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			var redirectURI string
+			services := NewServices(db, Options{})
+			services.OAuth = &mocks.OAuthService{BeginFunc: func(provider, uri string) (*models.OAuthAuthorization, error) {
+				redirectURI = uri
+				return &models.OAuthAuthorization{AuthorizationURL: "https://accounts.example.com/auth", Nonce: "nonce"}, nil
+			}}
+			router := setupRouter(db, Options{Services: &services, PublicURL: tt.publicURL, TrustedProxies: proxies})
+
+			req := httptest.NewRequest("GET", "http://evil.example.com/api/v1/auth/oauth/google", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-Proto", tt.forwardedProto)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusFound, w.Code, w.Body.String())
+			require.Equal(t, tt.expectedURL+"/api/v1/auth/oauth/google/callback", redirectURI)
+			cookies := w.Result().Cookies()
+			require.Len(t, cookies, 1)
+			require.Equal(t, tt.expectedSecure, cookies[0].Secure)
+		})
+	}
+}
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := ParseNetworks(" 10.0.0.0/8, 192.0.2.7 ,,2001:db8::/32,::ffff:198.51.100.0/120")
+	require.NoError(t, err)
+	require.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.0.2.7/32"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+	}, networks)
+
+	networks, err = ParseNetworks("")
+	require.NoError(t, err)
+	require.Empty(t, networks)
+
+	_, err = ParseNetworks("10.0.0.0/8,intranet")
+	require.EqualError(t, err, `invalid network "intranet": must be an IP or a CIDR range`)
+}
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseNetworks("10.0.0.0/8")
+	require.NoError(t, err)
+	ip := func(remoteAddr string, forwardedFor ...string) string {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = remoteAddr
+		for _, value := range forwardedFor {
+			req.Header.Add("X-Forwarded-For", value)
+		}
+		return clientIP(req, proxies)
+	}
+
+	require.Equal(t, "192.0.2.1", ip("192.0.2.1:1234"))
+	// Only trusted proxies get to say who the client is.
+	require.Equal(t, "192.0.2.1", ip("192.0.2.1:1234", "203.0.113.9"))
+	require.Equal(t, "203.0.113.9", ip("10.0.0.1:1234", "203.0.113.9"))
+	// The client can put anything first; the proxies append to the end.
+	require.Equal(t, "203.0.113.9", ip("10.0.0.1:1234", "198.51.100.1, 203.0.113.9, 10.0.0.2"))
+	require.Equal(t, "203.0.113.9", ip("10.0.0.1:1234", "198.51.100.1", "203.0.113.9,10.0.0.2"))
+	require.Equal(t, "203.0.113.9", ip("10.0.0.1:1234", "garbage, 203.0.113.9"))
+	require.Equal(t, "10.0.0.2", ip("10.0.0.1:1234", "garbage, 10.0.0.2"))
+	require.Equal(t, "10.0.0.1", ip("10.0.0.1:1234"))
+	require.Equal(t, "192.0.2.1", ip("[::ffff:192.0.2.1]:1234"))
+}
+
+func TestSetup_AdminNetworks(t *testing.T) {
+	networks, err := ParseNetworks("192.0.2.0/24")
+	require.NoError(t, err)
+	proxies, err := ParseNetworks("10.0.0.1")
+	require.NoError(t, err)
+	router := setupRouter(setupTestDB(t), Options{AdminNetworks: networks, TrustedProxies: proxies})
+
+	get := func(path, remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, get("/api/v1/admin/coupons", "192.0.2.1:1234", ""))
+	require.Equal(t, http.StatusOK, get("/metrics", "192.0.2.1:1234", ""))
+	require.Equal(t, http.StatusForbidden, get("/api/v1/admin/coupons", "198.51.100.1:1234", ""))
+	require.Equal(t, http.StatusForbidden, get("/metrics", "198.51.100.1:1234", ""))
+	require.Equal(t, http.StatusForbidden, get("/api/v1/admin/coupons", "198.51.100.1:1234", "192.0.2.1"))
+	require.Equal(t, http.StatusOK, get("/api/v1/admin/coupons", "10.0.0.1:1234", "192.0.2.1"))
+	require.Equal(t, http.StatusForbidden, get("/api/v1/admin/coupons", "10.0.0.1:1234", "198.51.100.1"))
+	require.Equal(t, http.StatusForbidden, get("/api/v1/admin/coupons", "10.0.0.1:1234", "192.0.2.1, 198.51.100.1"))
+
+	// The storefront and health checks stay open.
+	require.Equal(t, http.StatusOK, get("/api/v1/cupcakes", "198.51.100.1:1234", ""))
+	require.Equal(t, http.StatusOK, get("/health", "198.51.100.1:1234", ""))
+}
+
+func TestParseCaptchaEndpoints(t *testing.T) {
+	endpoints, err := ParseCaptchaEndpoints(" register, ,login")
+	require.NoError(t, err)
+	require.Equal(t, []string{"register", "login"}, endpoints)
+
+	_, err = ParseCaptchaEndpoints("register,reviews")
+	require.ErrorContains(t, err, `unknown endpoint "reviews"`)
+}
+
+func TestSetup_Captcha(t *testing.T) {
+	verifier := &mocks.CaptchaVerifier{
+		VerifyFunc: func(_ context.Context, token, remoteIP string) (bool, error) {
+			require.Equal(t, "192.0.2.1", remoteIP)
+			if token == "down" {
+				return false, errors.New("connection refused")
+			}
+			return token == "solved", nil
+		},
+	}
+	router := setupRouter(setupTestDB(t), Options{
+		PasswordParams:   password.Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		Captcha:          verifier,
+		CaptchaEndpoints: []string{"register"},
+	})
+	post := func(path, captcha, body string) int {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.RemoteAddr = "192.0.2.1:1234"
+		if captcha != "" {
+			req.Header.Set("X-Captcha-Token", captcha)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	register := `{"name":"Ana","email":"ana@example.com","password":"correct horse"}`
+
+	require.Equal(t, http.StatusBadRequest, post("/api/v1/auth/register", "", register))
+	require.Equal(t, http.StatusForbidden, post("/api/v1/auth/register", "forged", register))
+	require.Equal(t, http.StatusServiceUnavailable, post("/api/v1/auth/register", "down", register))
+	require.Equal(t, http.StatusCreated, post("/api/v1/auth/register", "solved", register))
+
+	// Endpoints not listed are left alone.
+	require.Equal(t, http.StatusOK, post("/api/v1/auth/login", "", `{"email":"ana@example.com","password":"correct horse"}`))
+}
+
+func TestSetup_RateLimit(t *testing.T) {
+	router := setupRouter(setupTestDB(t), Options{PublicRateLimit: 2, AdminRateLimit: 1})
+
+	get := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, get("/api/v1/cupcakes", "192.0.2.1:1234").Code)
+	require.Equal(t, http.StatusOK, get("/api/v1/cupcakes", "192.0.2.1:5678").Code)
+	w := get("/api/v1/cupcakes", "192.0.2.1:1234")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	require.Equal(t, http.StatusOK, get("/api/v1/cupcakes", "192.0.2.2:1234").Code)
+	require.Equal(t, http.StatusOK, get("/api/v1/admin/coupons", "192.0.2.1:1234").Code)
+	require.Equal(t, http.StatusTooManyRequests, get("/api/v1/admin/coupons", "192.0.2.1:1234").Code)
+	require.Equal(t, http.StatusOK, get("/health", "192.0.2.1:1234").Code)
+}
+
+func TestSetup_AdminRateLimitCountsFailedAuth(t *testing.T) {
+	router := setupRouter(setupTestDB(t), Options{AdminRateLimit: 3})
+
+	guess := func() int {
+		req := httptest.NewRequest("GET", "/api/v1/admin/coupons", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("Authorization", "Bearer wrong-token")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusUnauthorized, guess())
+	}
+	require.Equal(t, http.StatusTooManyRequests, guess())
+}
+
+func TestLimiter_Window(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := &limiter{limit: 1, window: time.Minute, now: func() time.Time { return now }, counts: map[string]int{}}
+
+	ok, _ := l.allow("a")
+	require.True(t, ok)
+	now = now.Add(20 * time.Second)
+	ok, retryAfter := l.allow("a")
+	require.False(t, ok)
+	require.Equal(t, 40*time.Second, retryAfter)
+
+	now = now.Add(40 * time.Second)
+	ok, _ = l.allow("a")
+	require.True(t, ok)
+}
+
+func TestSetup_Settings(t *testing.T) {
+	db := setupTestDB(t)
+	router := setupRouter(db, Options{})
+
+	send := func(method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusOK, send("GET", "/api/v1/settings", ""))
+	require.Equal(t, http.StatusOK, send("PUT", "/api/v1/admin/settings/maintenance_mode", `{"value":true}`))
+	require.Equal(t, http.StatusServiceUnavailable, send("GET", "/api/v1/cupcakes", ""))
+
+	// A restart keeps the stored setting.
+	router = setupRouter(db, Options{})
+	require.Equal(t, http.StatusServiceUnavailable, send("GET", "/api/v1/cupcakes", ""))
+	require.Equal(t, http.StatusNoContent, send("DELETE", "/api/v1/admin/settings/maintenance_mode", ""))
+	require.Equal(t, http.StatusOK, send("GET", "/api/v1/cupcakes", ""))
+}
+
+func TestSetup_Experiments(t *testing.T) {
+	router := setupRouter(setupTestDB(t), Options{})
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Visitor-ID", "visitor-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusCreated, send("POST", "/api/v1/admin/cupcakes", `{"name":"Vanilla","flavor":"Vanilla","price_cents":1000}`).Code)
+	require.Equal(t, http.StatusCreated, send("POST", "/api/v1/admin/experiments", `{"key":"price-test","variants":[{"name":"a","weight":1,"price_adjustment_percent":10},{"name":"b","weight":1,"price_adjustment_percent":10}]}`).Code)
+
+	w := send("GET", "/api/v1/cupcakes", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Regexp(t, `^price-test=(a|b)$`, w.Header().Get("X-Experiments"))
+	require.Contains(t, w.Body.String(), `"effective_price_cents":1100`)
+
+	require.Equal(t, http.StatusOK, send("GET", "/api/v1/experiments/assignments", "").Code)
+	require.Equal(t, http.StatusNoContent, send("POST", "/api/v1/experiments/price-test/conversions", `{"value_cents":1100}`).Code)
+	require.Equal(t, http.StatusOK, send("GET", "/api/v1/admin/experiments/1", "").Code)
+	require.Equal(t, http.StatusOK, send("POST", "/api/v1/admin/experiments/1/stop", "").Code)
+	require.Empty(t, send("GET", "/api/v1/cupcakes", "").Header().Get("X-Experiments"))
+}
+
+func TestSetup_DataExport(t *testing.T) {
+	w := httptest.NewRecorder()
+	setupRouter(setupTestDB(t), Options{}).ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/me/data-export?email=ana@example.com", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	sender := &mocks.EmailSender{SendFunc: func(context.Context, string, *models.RenderedEmail) error { return nil }}
+	router := setupRouter(setupTestDB(t), Options{DataExportSecret: "test-secret", Email: sender, PublicURL: "https://loja.example.com"})
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/me/data-export?email=ana@example.com", nil))
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/data-exports/1/download?expires=1&signature=bad", nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestSetup_Erasure(t *testing.T) {
+	w := httptest.NewRecorder()
+	setupRouter(setupTestDB(t), Options{}).ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/me?email=ana@example.com", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var sent []string
+	sender := &mocks.EmailSender{SendFunc: func(_ context.Context, to string, _ *models.RenderedEmail) error {
+		sent = append(sent, to)
+		return nil
+	}}
+	router := setupRouter(setupTestDB(t), Options{ErasureSecret: "test-secret", Email: sender, PublicURL: "https://loja.example.com"})
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/me?email=ana@example.com", nil))
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, []string{"ana@example.com"}, sent)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/me?email=ana@example.com&expires=1&token=bad", nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/admin/customers?email=ana@example.com", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/erasures", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"requested_by":"admin"`)
+}
+
+func TestSetup_TopCupcakes(t *testing.T) {
+	db := setupTestDB(t)
+	services := NewServices(db, Options{})
+	var flavor string
+	var limit int
+	services.Stats = &mocks.StatsService{TopCupcakesFunc: func(from, to time.Time, f string, l int) (*models.TopCupcakes, error) {
+		flavor, limit = f, l
+		return &models.TopCupcakes{From: from.Format(time.DateOnly), To: to.Format(time.DateOnly)}, nil
+	}}
+	router := setupRouter(db, Options{Services: &services})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/stats/top-cupcakes?from=2026-10-01&to=2026-10-15&flavor=Vanilla&limit=5", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), `"from":"2026-10-01"`)
+	require.Equal(t, "Vanilla", flavor)
+	require.Equal(t, 5, limit)
+}
+
+func TestSetup_KitchenQueue(t *testing.T) {
+	db := setupTestDB(t)
+	services := NewServices(db, Options{})
+	var day time.Time
+	var locationID uint
+	services.Kitchen = &mocks.KitchenService{QueueFunc: func(d time.Time, id uint) ([]models.KitchenQueueEntry, error) {
+		day, locationID = d, id
+		return []models.KitchenQueueEntry{}, nil
+	}}
+	router := setupRouter(db, Options{Services: &services})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/kitchen/queue?date=2026-10-20&location_id=2", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "2026-10-20", day.Format(time.DateOnly))
+	require.Equal(t, uint(2), locationID)
+}
+
+func TestSetup_StockAlerts(t *testing.T) {
+	db := setupTestDB(t)
+	services := NewServices(db, Options{})
+	var status string
+	services.StockAlerts = &mocks.StockAlertService{GetAlertsFunc: func(s string) ([]models.StockAlert, error) {
+		status = s
+		return []models.StockAlert{}, nil
+	}}
+	router := setupRouter(db, Options{Services: &services})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/admin/alerts?status=open", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, "open", status)
+}
+
+func TestSetup_RequireVerifiedEmail(t *testing.T) {
+	verifiedAt := time.Now()
+	db := setupTestDB(t)
+	services := NewServices(db, Options{})
+	services.Accounts = &mocks.AccountService{AuthenticateFunc: func(token string) (*models.Account, error) {
+		switch token {
+		case "verified":
+			return &models.Account{ID: 1, EmailVerifiedAt: &verifiedAt}, nil
+		case "unverified":
+			return &models.Account{ID: 2}, nil
+		}
+		return nil, service.ErrAccessTokenInvalid
+	}}
+
+	post := func(router http.Handler, path, authorization string) int {
+		req := httptest.NewRequest("POST", path, strings.NewReader("{}"))
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	gated := setupRouter(db, Options{Services: &services, RequireVerifiedEmail: true})
+	for _, path := range []string{"/api/v1/subscriptions", "/api/v1/locations/1/slots/1/reservations"} {
+		require.Equal(t, http.StatusUnauthorized, post(gated, path, ""), path)
+		require.Equal(t, http.StatusUnauthorized, post(gated, path, "Bearer bad"), path)
+		require.Equal(t, http.StatusForbidden, post(gated, path, "Bearer unverified"), path)
+		// Past the gate, the empty order fails validation.
+		require.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, post(gated, path, "Bearer verified"), path)
+	}
+
+	open := setupRouter(db, Options{Services: &services})
+	require.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, post(open, "/api/v1/subscriptions", ""))
+}
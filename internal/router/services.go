@@ -0,0 +1,183 @@
+package router
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"gorm.io/gorm"
+)
+
+// Services is the composition root for the HTTP and gRPC transports: every
+// handler is built from these fields, so any of them can be replaced with a
+// fake before calling Setup.
+type Services struct {
+	Cupcakes       service.CupcakeServiceInterface
+	Coupons        service.CouponServiceInterface
+	Promotions     service.PromotionServiceInterface
+	GiftCards      service.GiftCardServiceInterface
+	CustomCupcakes service.CustomCupcakeServiceInterface
+	Addons         service.AddonServiceInterface
+	Bundles        service.BundleServiceInterface
+	Wholesale      service.WholesaleServiceInterface
+	Procurement    service.ProcurementServiceInterface
+	Recipes        service.RecipeServiceInterface
+	Kitchen        service.KitchenServiceInterface
+	Stats          service.StatsServiceInterface
+	Sync           service.SyncServiceInterface
+	Trending       service.TrendingServiceInterface
+	Search         service.SearchServiceInterface
+	Translations   service.TranslationServiceInterface
+	Subscriptions  service.SubscriptionServiceInterface
+	Locations      service.LocationServiceInterface
+	Pickups        service.PickupServiceInterface
+	Prints         service.PrintServiceInterface
+	Webhooks       service.WebhookServiceInterface
+	Experiments    service.ExperimentServiceInterface
+	DataExports    service.DataExportServiceInterface
+	Erasures       service.ErasureServiceInterface
+	Accounts       service.AccountServiceInterface
+	Admins         service.AdminServiceInterface
+	OAuth          service.OAuthServiceInterface
+	Sessions       service.SessionServiceInterface
+	APIKeys        service.APIKeyServiceInterface
+	Tickets        service.TicketServiceInterface
+	StockAlerts    service.StockAlertServiceInterface
+	EmailTemplates service.EmailTemplateServiceInterface
+	Notifications  service.NotificationServiceInterface
+	Devices        service.DeviceServiceInterface
+	Jobs           *service.JobService
+	Views          *service.ViewCounter
+	Validation     *service.ValidationService
+	Maintenance    *service.MaintenanceService
+	Settings       *service.SettingsService
+}
+
+// NewServices wires the default GORM-backed repositories and services.
+func NewServices(db *gorm.DB, opts Options) Services {
+	// Without a caller-supplied worker, jobs are still queued and picked up by
+	// whichever process runs one against the same database.
+	jobs := opts.Jobs
+	if jobs == nil {
+		jobs = service.NewJobService(repository.NewJobRepository(db))
+	}
+
+	// Deliveries are POSTs, retried by the job queue rather than the
+	// client; it adds the circuit breaker in front of failing receivers.
+	webhookService := service.NewWebhookService(repository.NewWebhookRepository(db), jobs).
+		WithHTTPClient(httpclient.New("webhooks", 10*time.Second, opts.HTTPClient))
+	var events service.EventPublisher = webhookService
+	if opts.Publisher != nil {
+		events = service.EventPublishers{webhookService, opts.Publisher}
+	}
+
+	var cupcakeRepo repository.CupcakeRepositoryInterface = repository.NewCupcakeRepository(db)
+	if opts.CupcakeRepository != nil {
+		cupcakeRepo = opts.CupcakeRepository
+	}
+	promotionRepo := repository.NewPromotionRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	bundleRepo := repository.NewBundleRepository(db)
+	ingredientRepo := repository.NewIngredientRepository(db)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	pickupRepo := repository.NewPickupRepository(db)
+	viewRepo := repository.NewViewRepository(db)
+	adminRepo := repository.NewAdminRepository(db)
+	accountRepo := repository.NewAccountRepository(db)
+	locationService := service.NewLocationService(locationRepo, cupcakeRepo, bundleRepo)
+	// Customer notifications go out on the channels each customer chose.
+	notifications := service.NewNotificationService(repository.NewNotificationPreferenceRepository(db), accountRepo, events)
+	adminService := service.NewAdminService(adminRepo, opts.PasswordParams, opts.AuthTokenSecret, opts.AuthTokenTTL)
+	if opts.SMS != nil {
+		notifications.WithSender(models.ChannelSMS, service.NewSMSNotifier(opts.SMS))
+		adminService.WithSMS(opts.SMS)
+	}
+	deviceRepo := repository.NewDeviceTokenRepository(db)
+	if opts.Push != nil {
+		notifications.WithSender(models.ChannelPush, service.NewPushNotifier(opts.Push, deviceRepo, accountRepo))
+	}
+	// Multi-step operations share one unit of work, which keeps to the
+	// in-memory catalog when there is one.
+	uow := repository.NewUnitOfWork(db)
+	if opts.CupcakeRepository != nil {
+		uow.WithCupcakes(cupcakeRepo)
+	}
+	pickups := service.NewPickupService(pickupRepo, locationService, notifications)
+	prints := service.NewPrintService(pickupRepo, locationService, cupcakeRepo, jobs)
+	if opts.Printer != nil {
+		prints.WithPrinter(opts.Printer)
+		pickups.WithPrinting(prints)
+	}
+	dataExports := service.NewDataExportService(repository.NewDataExportRepository(db), jobs, opts.DataExportSecret)
+	erasures := service.NewErasureService(repository.NewErasureRepository(db), opts.ErasureSecret)
+	if opts.Email != nil {
+		dataExports.WithEmail(opts.Email, opts.PublicURL)
+		erasures.WithEmail(opts.Email, opts.PublicURL)
+	}
+
+	contentLocale := opts.DefaultLocale
+	if contentLocale == "" {
+		contentLocale = defaultLocale
+	}
+	validation := opts.Validation
+	if validation == nil {
+		validation = service.DefaultValidationService()
+	}
+
+	maintenance := opts.Maintenance
+	if maintenance == nil {
+		maintenance = service.NewMaintenanceService(false, false, defaultRetryAfter)
+	}
+
+	translationService := service.NewTranslationService(repository.NewTranslationRepository(db), cupcakeRepo, contentLocale)
+	// Social logins and web sessions issue the same access tokens as
+	// password logins.
+	accountService := service.NewAccountService(accountRepo, opts.PasswordParams, opts.AuthTokenSecret, opts.AuthTokenTTL)
+	if opts.Email != nil {
+		accountService.WithEmail(opts.Email, opts.PublicURL)
+	}
+
+	return Services{
+		Cupcakes:       service.NewCupcakeService(cupcakeRepo, promotionRepo, locationRepo, events, opts.Converter, translationService, validation).WithUnitOfWork(uow),
+		Coupons:        service.NewCouponService(repository.NewCouponRepository(db), uow),
+		Promotions:     service.NewPromotionService(promotionRepo, cupcakeRepo),
+		GiftCards:      service.NewGiftCardService(repository.NewGiftCardRepository(db)),
+		CustomCupcakes: service.NewCustomCupcakeService(repository.NewCustomOptionRepository(db)),
+		Addons:         service.NewAddonService(repository.NewAddonRepository(db)),
+		Bundles:        service.NewBundleService(bundleRepo, cupcakeRepo),
+		Wholesale:      service.NewWholesaleService(repository.NewWholesaleRepository(db), cupcakeRepo),
+		Procurement:    service.NewProcurementService(repository.NewSupplierRepository(db), ingredientRepo, repository.NewPurchaseOrderRepository(db)),
+		Recipes:        service.NewRecipeService(repository.NewRecipeRepository(db), ingredientRepo, cupcakeRepo),
+		Kitchen:        service.NewKitchenService(subscriptionRepo, pickupRepo, cupcakeRepo),
+		Stats:          service.NewStatsService(pickupRepo, cupcakeRepo),
+		Sync:           service.NewSyncService(cupcakeRepo),
+		Trending:       service.NewTrendingService(viewRepo, cupcakeRepo, promotionRepo),
+		Search:         service.NewSearchService(opts.SearchIndex, cupcakeRepo, promotionRepo),
+		Translations:   translationService,
+		Subscriptions:  service.NewSubscriptionService(subscriptionRepo, cupcakeRepo),
+		Locations:      locationService,
+		Pickups:        pickups,
+		Prints:         prints,
+		Webhooks:       webhookService,
+		Experiments:    service.NewExperimentService(repository.NewExperimentRepository(db), cupcakeRepo),
+		DataExports:    dataExports,
+		Erasures:       erasures,
+		Accounts:       accountService,
+		Admins:         adminService,
+		OAuth:          service.NewOAuthService(accountService, opts.OAuthProviders...),
+		Sessions:       service.NewSessionService(repository.NewSessionRepository(db), accountService, opts.SessionTTL),
+		APIKeys:        service.NewAPIKeyService(repository.NewAPIKeyRepository(db)),
+		StockAlerts:    service.NewStockAlertService(repository.NewStockAlertRepository(db), locationRepo, cupcakeRepo, events),
+		Tickets:        service.NewTicketService(repository.NewTicketRepository(db), pickupRepo, subscriptionRepo, adminRepo, notifications),
+		EmailTemplates: service.NewEmailTemplateService(repository.NewEmailTemplateRepository(db), locationRepo),
+		Notifications:  notifications,
+		Devices:        service.NewDeviceService(deviceRepo),
+		Jobs:           jobs,
+		Views:          opts.Views,
+		Validation:     validation,
+		Maintenance:    maintenance,
+		Settings:       service.NewSettingsService(repository.NewSettingRepository(db), maintenance, opts.Converter),
+	}
+}
@@ -0,0 +1,74 @@
+package router
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// hashedAsset matches fingerprinted build output such as app.3f9a2b1c.js,
+// whose content never changes under the same name.
+var hashedAsset = regexp.MustCompile(`\.[0-9a-f]{8,}\.[a-z0-9]+$`)
+
+// staticFiles serves the frontend. Paths without a matching file fall back to
+// index.html so client-side routes survive a reload, except for API paths,
+// which get a JSON 404, and missing files with an extension, which get a
+// plain 404.
+func staticFiles(assets fs.FS) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if isAPIPath(r.URL.Path) {
+			notFound(w, r)
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+		if name == "" {
+			name = "index.html"
+		}
+		if serveAsset(w, r, assets, name) {
+			return
+		}
+		if path.Ext(name) != "" || !serveAsset(w, r, assets, "index.html") {
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// serveAsset writes the named file with its cache policy and reports whether
+// it existed.
+func serveAsset(w http.ResponseWriter, r *http.Request, assets fs.FS, name string) bool {
+	f, err := assets.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		return false
+	}
+
+	if hashedAsset.MatchString(name) {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	http.ServeContent(w, r, name, info.ModTime(), content)
+	return true
+}
+
+func isAPIPath(p string) bool {
+	for _, prefix := range []string{"/api", "/health"} {
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
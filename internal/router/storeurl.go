@@ -0,0 +1,39 @@
+package router
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/handler"
+)
+
+// withStoreURL records the store's public base URL for the handlers that
+// build links, redirect URIs and cookies from it. It is publicURL when set.
+// Otherwise it is rebuilt from the request, believing X-Forwarded-Proto only
+// from trustedProxies: anyone else could set it.
+func withStoreURL(publicURL string, trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	publicURL = strings.TrimSuffix(publicURL, "/")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			url := publicURL
+			if url == "" {
+				url = requestURL(r, trustedProxies)
+			}
+			next.ServeHTTP(w, r.WithContext(handler.WithStoreURL(r.Context(), url)))
+		})
+	}
+}
+
+func requestURL(r *http.Request, trustedProxies []netip.Prefix) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if addr, ok := parseAddr(r.RemoteAddr); ok && inNetworks(trustedProxies, addr) {
+		if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+			scheme = proto
+		}
+	}
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,131 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/pkg/catalogpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CatalogServer struct {
+	catalogpb.UnimplementedCatalogServiceServer
+	service service.CupcakeServiceInterface
+}
+
+func NewCatalogServer(service service.CupcakeServiceInterface) *CatalogServer {
+	return &CatalogServer{service: service}
+}
+
+func Register(server *grpc.Server, cupcakeService service.CupcakeServiceInterface) {
+	catalogpb.RegisterCatalogServiceServer(server, NewCatalogServer(cupcakeService))
+}
+
+func (s *CatalogServer) GetCupcake(ctx context.Context, req *catalogpb.GetCupcakeRequest) (*catalogpb.Cupcake, error) {
+	cupcake, err := s.service.GetCupcake(uint(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProto(cupcake), nil
+}
+
+func (s *CatalogServer) GetCupcakeBySKU(ctx context.Context, req *catalogpb.GetCupcakeBySKURequest) (*catalogpb.Cupcake, error) {
+	cupcake, err := s.service.GetCupcakeBySKU(req.GetSku())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProto(cupcake), nil
+}
+
+func (s *CatalogServer) ListCupcakes(ctx context.Context, req *catalogpb.ListCupcakesRequest) (*catalogpb.ListCupcakesResponse, error) {
+	cupcakes, err := s.service.GetAllCupcakes()
+	if err != nil {
+		return nil, status.Error(codes.Internal, "error fetching cupcakes")
+	}
+
+	resp := &catalogpb.ListCupcakesResponse{Cupcakes: make([]*catalogpb.Cupcake, 0, len(cupcakes))}
+	for i := range cupcakes {
+		resp.Cupcakes = append(resp.Cupcakes, toProto(&cupcakes[i]))
+	}
+	return resp, nil
+}
+
+func (s *CatalogServer) CreateCupcake(ctx context.Context, req *catalogpb.CreateCupcakeRequest) (*catalogpb.Cupcake, error) {
+	cupcake, err := s.service.CreateCupcake(&models.CreateCupcakeRequest{
+		Name:       req.GetName(),
+		Flavor:     req.GetFlavor(),
+		PriceCents: int(req.GetPriceCents()),
+		SKU:        req.Sku,
+		// The proto has no way to confirm a likely duplicate, so gRPC
+		// clients keep creating without the similar-name check.
+		Force: true,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return toProto(cupcake), nil
+}
+
+func (s *CatalogServer) UpdateCupcake(ctx context.Context, req *catalogpb.UpdateCupcakeRequest) (*catalogpb.Cupcake, error) {
+	update := &models.UpdateCupcakeRequest{
+		Name:        req.Name,
+		Flavor:      req.Flavor,
+		IsAvailable: req.IsAvailable,
+		SKU:         req.Sku,
+	}
+	if req.PriceCents != nil {
+		price := int(req.GetPriceCents())
+		update.PriceCents = &price
+	}
+
+	cupcake, err := s.service.UpdateCupcake(uint(req.GetId()), update)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProto(cupcake), nil
+}
+
+func (s *CatalogServer) DeleteCupcake(ctx context.Context, req *catalogpb.DeleteCupcakeRequest) (*catalogpb.DeleteCupcakeResponse, error) {
+	if err := s.service.DeleteCupcake(uint(req.GetId())); err != nil {
+		return nil, toStatus(err)
+	}
+	return &catalogpb.DeleteCupcakeResponse{}, nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, "cupcake not found")
+	case errors.Is(err, repository.ErrDuplicate):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repository.ErrConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func toProto(cupcake *models.Cupcake) *catalogpb.Cupcake {
+	pb := &catalogpb.Cupcake{
+		Id:          uint32(cupcake.ID),
+		Name:        cupcake.Name,
+		Flavor:      cupcake.Flavor,
+		PriceCents:  int32(cupcake.PriceCents),
+		IsAvailable: cupcake.IsAvailable,
+		CreatedAt:   timestamppb.New(cupcake.CreatedAt),
+		UpdatedAt:   timestamppb.New(cupcake.UpdatedAt),
+	}
+	if cupcake.SKU != nil {
+		pb.Sku = *cupcake.SKU
+	}
+	if cupcake.EffectivePriceCents != nil {
+		effective := int32(*cupcake.EffectivePriceCents)
+		pb.EffectivePriceCents = &effective
+	}
+	return pb
+}
@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+	"github.com/julimonteiro/cupcake-store/internal/testutil"
+	"github.com/julimonteiro/cupcake-store/pkg/catalogpb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T) catalogpb.CatalogServiceClient {
+	t.Helper()
+
+	db := testutil.NewDB(t)
+
+	cupcakeService := service.NewCupcakeService(repository.NewCupcakeRepository(db), repository.NewPromotionRepository(db), repository.NewLocationRepository(db), nil, nil, nil, nil)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	Register(server, cupcakeService)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return catalogpb.NewCatalogServiceClient(conn)
+}
+
+func TestCatalogServer_Lifecycle(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	sku := "cc-choc-01"
+	created, err := client.CreateCupcake(ctx, &catalogpb.CreateCupcakeRequest{
+		Name:       "Chocolate",
+		Flavor:     "Cocoa",
+		PriceCents: 1200,
+		Sku:        &sku,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, created.Id)
+	require.Equal(t, "CC-CHOC-01", created.Sku)
+	require.True(t, created.IsAvailable)
+
+	fetched, err := client.GetCupcakeBySKU(ctx, &catalogpb.GetCupcakeBySKURequest{Sku: "CC-CHOC-01"})
+	require.NoError(t, err)
+	require.Equal(t, created.Id, fetched.Id)
+
+	price := int32(1500)
+	available := false
+	updated, err := client.UpdateCupcake(ctx, &catalogpb.UpdateCupcakeRequest{
+		Id:          created.Id,
+		PriceCents:  &price,
+		IsAvailable: &available,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1500), updated.PriceCents)
+	require.False(t, updated.IsAvailable)
+
+	list, err := client.ListCupcakes(ctx, &catalogpb.ListCupcakesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Cupcakes, 1)
+
+	_, err = client.DeleteCupcake(ctx, &catalogpb.DeleteCupcakeRequest{Id: created.Id})
+	require.NoError(t, err)
+
+	_, err = client.GetCupcake(ctx, &catalogpb.GetCupcakeRequest{Id: created.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCatalogServer_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(client catalogpb.CatalogServiceClient) error
+		expectedCode codes.Code
+	}{
+		{
+			name: "create with invalid data",
+			call: func(client catalogpb.CatalogServiceClient) error {
+				_, err := client.CreateCupcake(context.Background(), &catalogpb.CreateCupcakeRequest{Name: "Chocolate", Flavor: "Cocoa"})
+				return err
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "get missing cupcake",
+			call: func(client catalogpb.CatalogServiceClient) error {
+				_, err := client.GetCupcake(context.Background(), &catalogpb.GetCupcakeRequest{Id: 42})
+				return err
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "update missing cupcake",
+			call: func(client catalogpb.CatalogServiceClient) error {
+				name := "Vanilla"
+				_, err := client.UpdateCupcake(context.Background(), &catalogpb.UpdateCupcakeRequest{Id: 42, Name: &name})
+				return err
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "delete missing cupcake",
+			call: func(client catalogpb.CatalogServiceClient) error {
+				_, err := client.DeleteCupcake(context.Background(), &catalogpb.DeleteCupcakeRequest{Id: 42})
+				return err
+			},
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t)
+
+			err := tt.call(client)
+
+			require.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	TaskExpireCoupons = "expire-coupons"
+	TaskPurgeJobs     = "purge-jobs"
+	TaskCheckStock    = "check-stock"
+)
+
+// Disabled turns off a task when used as its schedule.
+const Disabled = "off"
+
+type TaskFunc func() error
+
+type TaskStatus struct {
+	Name         string     `json:"name"`
+	Schedule     string     `json:"schedule"`
+	Running      bool       `json:"running"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	LastDuration string     `json:"last_duration,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+}
+
+type task struct {
+	status   TaskStatus
+	schedule cron.Schedule
+}
+
+type Scheduler struct {
+	cron      *cron.Cron
+	specs     map[string]string
+	schedules map[string]cron.Schedule
+	now       func() time.Time
+
+	mu    sync.Mutex
+	tasks map[string]*task
+}
+
+// New parses the cron spec of every named task up front so a bad
+// configuration fails at startup. Tasks scheduled as Disabled are skipped.
+func New(specs map[string]string) (*Scheduler, error) {
+	schedules := make(map[string]cron.Schedule)
+	for name, spec := range specs {
+		if spec == "" || spec == Disabled {
+			continue
+		}
+		schedule, err := cron.ParseStandard(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule for %s: %w", name, err)
+		}
+		schedules[name] = schedule
+	}
+
+	return &Scheduler{
+		cron:      cron.New(),
+		specs:     specs,
+		schedules: schedules,
+		now:       time.Now,
+		tasks:     make(map[string]*task),
+	}, nil
+}
+
+func (s *Scheduler) Register(name string, fn TaskFunc) {
+	schedule, ok := s.schedules[name]
+	if !ok {
+		return
+	}
+
+	t := &task{status: TaskStatus{Name: name, Schedule: s.specs[name]}, schedule: schedule}
+	s.cron.Schedule(schedule, cron.FuncJob(func() { s.run(t, fn) }))
+
+	s.mu.Lock()
+	s.tasks[name] = t
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop prevents new runs and waits for running tasks to finish.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
+
+func (s *Scheduler) Statuses() []TaskStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]TaskStatus, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		status := t.status
+		next := t.schedule.Next(s.now())
+		status.NextRunAt = &next
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+func (s *Scheduler) run(t *task, fn TaskFunc) {
+	s.mu.Lock()
+	if t.status.Running {
+		s.mu.Unlock()
+		return
+	}
+	t.status.Running = true
+	s.mu.Unlock()
+
+	startedAt := s.now()
+	err := call(fn)
+	duration := s.now().Sub(startedAt)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t.status.Running = false
+	t.status.LastRunAt = &startedAt
+	t.status.LastDuration = duration.String()
+	t.status.LastError = ""
+	if err != nil {
+		t.status.LastError = err.Error()
+		log.Printf("Scheduled task %s failed: %v", t.status.Name, err)
+	}
+}
+
+func call(fn TaskFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+	return fn()
+}
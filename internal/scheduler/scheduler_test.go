@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		specs         map[string]string
+		expectedError string
+	}{
+		{
+			name:  "valid schedules",
+			specs: map[string]string{TaskExpireCoupons: "@hourly", "other-task": "*/15 * * * *"},
+		},
+		{
+			name:  "disabled and empty schedules are skipped",
+			specs: map[string]string{TaskExpireCoupons: Disabled, "other-task": ""},
+		},
+		{
+			name:          "invalid schedule",
+			specs:         map[string]string{TaskExpireCoupons: "every hour"},
+			expectedError: "invalid schedule for expire-coupons",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler, err := New(tt.specs)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, scheduler)
+				require.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, scheduler)
+		})
+	}
+}
+
+func TestScheduler_Statuses(t *testing.T) {
+	tests := []struct {
+		name          string
+		task          TaskFunc
+		expectedError string
+	}{
+		{
+			name: "successful run",
+			task: func() error { return nil },
+		},
+		{
+			name:          "failed run",
+			task:          func() error { return errors.New("database unavailable") },
+			expectedError: "database unavailable",
+		},
+		{
+			name:          "panicking run",
+			task:          func() error { panic("boom") },
+			expectedError: "task panicked: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler, err := New(map[string]string{
+				TaskExpireCoupons: "@hourly",
+				"other-task":      Disabled,
+			})
+			require.NoError(t, err)
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			scheduler.now = func() time.Time { return now }
+
+			scheduler.Register(TaskExpireCoupons, tt.task)
+			scheduler.Register("other-task", tt.task)
+
+			scheduler.mu.Lock()
+			task := scheduler.tasks[TaskExpireCoupons]
+			scheduler.mu.Unlock()
+			scheduler.run(task, tt.task)
+
+			statuses := scheduler.Statuses()
+			require.Len(t, statuses, 1)
+			require.Equal(t, TaskExpireCoupons, statuses[0].Name)
+			require.Equal(t, "@hourly", statuses[0].Schedule)
+			require.False(t, statuses[0].Running)
+			require.Equal(t, now, *statuses[0].LastRunAt)
+			require.Equal(t, tt.expectedError, statuses[0].LastError)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// facetFields maps each facet to the keyword field it is aggregated on. The
+// index relies on dynamic mapping, which adds a .keyword subfield to text.
+var facetFields = map[string]string{
+	"flavor": "flavor.keyword",
+}
+
+// Client talks to the document and search REST API shared by Elasticsearch
+// and OpenSearch.
+type Client struct {
+	baseURL string
+	index   string
+	http    *http.Client
+}
+
+var _ service.SearchIndex = (*Client)(nil)
+
+func NewClient(baseURL, index string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		index:   index,
+		http:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (c *Client) Index(ctx context.Context, doc models.SearchDocument) error {
+	_, err := c.do(ctx, http.MethodPut, c.docURL(doc.ID), doc)
+	return err
+}
+
+// Delete removes a cupcake; deleting one that was never indexed succeeds.
+func (c *Client) Delete(ctx context.Context, id uint) error {
+	_, err := c.do(ctx, http.MethodDelete, c.docURL(id), nil)
+	if err, ok := err.(*statusError); ok && err.status == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// Search runs a fuzzy match on name, flavor and SKU over available cupcakes,
+// with a terms aggregation per requested facet.
+func (c *Client) Search(ctx context.Context, query models.SearchQuery) (*models.SearchHits, error) {
+	match := map[string]interface{}{"match_all": map[string]interface{}{}}
+	if query.Text != "" {
+		match = map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":     query.Text,
+				"fields":    []string{"name^2", "flavor", "sku"},
+				"fuzziness": "AUTO",
+			},
+		}
+	}
+
+	aggs := map[string]interface{}{}
+	for _, facet := range query.Facets {
+		field, ok := facetFields[facet]
+		if !ok {
+			return nil, fmt.Errorf("unsupported facet: %s", facet)
+		}
+		aggs[facet] = map[string]interface{}{"terms": map[string]interface{}{"field": field}}
+	}
+
+	body := map[string]interface{}{
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"_source":          false,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must":   match,
+				"filter": map[string]interface{}{"term": map[string]interface{}{"is_available": true}},
+			},
+		},
+	}
+	if len(aggs) > 0 {
+		body["aggs"] = aggs
+	}
+
+	raw, err := c.do(ctx, http.MethodPost, c.baseURL+"/"+c.index+"/_search", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+		Aggregations map[string]struct {
+			Buckets []struct {
+				Key      string `json:"key"`
+				DocCount int    `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"aggregations"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("decoding search response: %w", err)
+	}
+
+	hits := &models.SearchHits{Total: resp.Hits.Total.Value, IDs: make([]uint, 0, len(resp.Hits.Hits))}
+	for _, hit := range resp.Hits.Hits {
+		id, err := strconv.ParseUint(hit.ID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected document id %q", hit.ID)
+		}
+		hits.IDs = append(hits.IDs, uint(id))
+	}
+	if len(query.Facets) > 0 {
+		hits.Facets = make(map[string][]models.FacetCount, len(query.Facets))
+		for _, facet := range query.Facets {
+			counts := []models.FacetCount{}
+			for _, bucket := range resp.Aggregations[facet].Buckets {
+				counts = append(counts, models.FacetCount{Value: bucket.Key, Count: bucket.DocCount})
+			}
+			hits.Facets[facet] = counts
+		}
+	}
+	return hits, nil
+}
+
+func (c *Client) docURL(id uint) string {
+	return fmt.Sprintf("%s/%s/_doc/%d", c.baseURL, c.index, id)
+}
+
+type statusError struct {
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("search index returned %d: %s", e.status, e.body)
+}
+
+func (c *Client) do(ctx context.Context, method, url string, payload interface{}) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, &statusError{status: resp.StatusCode, body: strings.TrimSpace(string(raw))}
+	}
+	return raw, nil
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		backend       string
+		expectIndex   bool
+		expectedError string
+	}{
+		{name: "disabled", backend: "none"},
+		{name: "empty", backend: ""},
+		{name: "elasticsearch", backend: "elasticsearch", expectIndex: true},
+		{name: "opensearch", backend: "opensearch", expectIndex: true},
+		{name: "unknown", backend: "solr", expectedError: "unsupported search backend: solr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := New(&config.Config{SearchBackend: tt.backend, SearchURL: "http://localhost:9200", SearchIndex: "cupcakes"})
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectIndex, index != nil)
+		})
+	}
+}
+
+type recordedRequest struct {
+	method string
+	path   string
+	body   map[string]interface{}
+}
+
+func newTestServer(t *testing.T, status int, response string) (*Client, *[]recordedRequest) {
+	t.Helper()
+
+	var requests []recordedRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := recordedRequest{method: r.Method, path: r.URL.Path}
+		if raw, _ := io.ReadAll(r.Body); len(raw) > 0 {
+			require.NoError(t, json.Unmarshal(raw, &req.body))
+		}
+		requests = append(requests, req)
+		w.WriteHeader(status)
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL+"/", "cupcakes"), &requests
+}
+
+func TestClient_Index(t *testing.T) {
+	client, requests := newTestServer(t, http.StatusCreated, `{"result":"created"}`)
+
+	require.NoError(t, client.Index(context.Background(), models.SearchDocument{ID: 7, Name: "Red Velvet", Flavor: "Cocoa", IsAvailable: true}))
+	require.Len(t, *requests, 1)
+	require.Equal(t, "PUT", (*requests)[0].method)
+	require.Equal(t, "/cupcakes/_doc/7", (*requests)[0].path)
+	require.Equal(t, "Red Velvet", (*requests)[0].body["name"])
+	require.Equal(t, true, (*requests)[0].body["is_available"])
+}
+
+func TestClient_Delete(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{name: "deleted", status: http.StatusOK},
+		{name: "never indexed", status: http.StatusNotFound},
+		{name: "index down", status: http.StatusServiceUnavailable, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newTestServer(t, tt.status, `{}`)
+
+			err := client.Delete(context.Background(), 7)
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, "DELETE", (*requests)[0].method)
+			require.Equal(t, "/cupcakes/_doc/7", (*requests)[0].path)
+		})
+	}
+}
+
+func TestClient_Search(t *testing.T) {
+	client, requests := newTestServer(t, http.StatusOK, `{
+		"hits": {"total": {"value": 3}, "hits": [{"_id": "2"}, {"_id": "5"}]},
+		"aggregations": {"flavor": {"buckets": [{"key": "Cocoa", "doc_count": 2}, {"key": "Vanilla", "doc_count": 1}]}}
+	}`)
+
+	hits, err := client.Search(context.Background(), models.SearchQuery{Text: "choclate", Facets: []string{"flavor"}, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, &models.SearchHits{
+		IDs:   []uint{2, 5},
+		Total: 3,
+		Facets: map[string][]models.FacetCount{
+			"flavor": {{Value: "Cocoa", Count: 2}, {Value: "Vanilla", Count: 1}},
+		},
+	}, hits)
+
+	req := (*requests)[0]
+	require.Equal(t, "POST", req.method)
+	require.Equal(t, "/cupcakes/_search", req.path)
+	require.EqualValues(t, 2, req.body["size"])
+	query := req.body["query"].(map[string]interface{})["bool"].(map[string]interface{})
+	match := query["must"].(map[string]interface{})["multi_match"].(map[string]interface{})
+	require.Equal(t, "choclate", match["query"])
+	require.Equal(t, "AUTO", match["fuzziness"])
+	require.Contains(t, req.body["aggs"], "flavor")
+}
+
+func TestClient_SearchErrors(t *testing.T) {
+	client, _ := newTestServer(t, http.StatusInternalServerError, `{"error":"boom"}`)
+	_, err := client.Search(context.Background(), models.SearchQuery{Limit: 10})
+	require.EqualError(t, err, `search index returned 500: {"error":"boom"}`)
+
+	_, err = client.Search(context.Background(), models.SearchQuery{Facets: []string{"price"}, Limit: 10})
+	require.EqualError(t, err, "unsupported facet: price")
+}
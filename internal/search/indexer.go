@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+const (
+	indexTimeout   = 5 * time.Second
+	indexQueueSize = 256
+)
+
+// Indexer applies cupcake events to the index. It is an EventPublisher, so
+// it plugs into the same fan-out as webhooks and the broker. Updates are
+// applied in order by a single worker and never block the request; when the
+// queue is full or the index fails the update is logged and dropped, and a
+// reindex repairs the drift.
+type Indexer struct {
+	index service.SearchIndex
+	queue chan func(context.Context) error
+	once  sync.Once
+	done  chan struct{}
+}
+
+var _ service.EventPublisher = (*Indexer)(nil)
+
+func NewIndexer(index service.SearchIndex) *Indexer {
+	i := &Indexer{
+		index: index,
+		queue: make(chan func(context.Context) error, indexQueueSize),
+		done:  make(chan struct{}),
+	}
+	go i.run()
+	return i
+}
+
+func (i *Indexer) Publish(event string, data interface{}) {
+	var update func(context.Context) error
+	switch event {
+	case models.EventCupcakeCreated, models.EventCupcakeUpdated:
+		cupcake, ok := data.(models.CupcakeResponse)
+		if !ok {
+			return
+		}
+		doc := models.NewSearchDocument(&cupcake)
+		update = func(ctx context.Context) error { return i.index.Index(ctx, doc) }
+	case models.EventCupcakeDeleted:
+		data, ok := data.(map[string]uint)
+		if !ok {
+			return
+		}
+		id := data["id"]
+		update = func(ctx context.Context) error { return i.index.Delete(ctx, id) }
+	default:
+		return
+	}
+
+	select {
+	case i.queue <- update:
+	default:
+		log.Printf("Search index queue full, dropping %s", event)
+	}
+}
+
+func (i *Indexer) run() {
+	defer close(i.done)
+	for update := range i.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+		if err := update(ctx); err != nil {
+			log.Printf("Error updating search index: %v", err)
+		}
+		cancel()
+	}
+}
+
+// Close applies the queued updates and stops the worker. Publish must not be
+// called afterwards.
+func (i *Indexer) Close() error {
+	i.once.Do(func() { close(i.queue) })
+	<-i.done
+	return nil
+}
@@ -0,0 +1,33 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexer(t *testing.T) {
+	var applied []string
+	index := &mocks.SearchIndex{
+		IndexFunc: func(ctx context.Context, doc models.SearchDocument) error {
+			applied = append(applied, "index "+doc.Name)
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, id uint) error {
+			applied = append(applied, "delete")
+			return nil
+		},
+	}
+
+	indexer := NewIndexer(index)
+	indexer.Publish(models.EventCupcakeCreated, models.CupcakeResponse{ID: 1, Name: "Vanilla"})
+	indexer.Publish(models.EventCupcakeUpdated, models.CupcakeResponse{ID: 1, Name: "Vanilla Bean"})
+	indexer.Publish("coupon.created", map[string]uint{"id": 1})
+	indexer.Publish(models.EventCupcakeDeleted, map[string]uint{"id": 1})
+	require.NoError(t, indexer.Close())
+
+	require.Equal(t, []string{"index Vanilla", "index Vanilla Bean", "delete"}, applied)
+}
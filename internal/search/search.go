@@ -0,0 +1,33 @@
+// Package search keeps an optional Elasticsearch or OpenSearch index of the
+// catalog in sync with cupcake events. The database stays the source of
+// truth: the index only ranks and counts, and search falls back to SQL
+// whenever it is missing or unreachable.
+package search
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+// New returns the index selected by SEARCH_BACKEND, or nil when search runs
+// on the database only.
+func New(cfg *config.Config) (service.SearchIndex, error) {
+	switch cfg.SearchBackend {
+	case "", "none":
+		return nil, nil
+	case "elasticsearch", "opensearch":
+		settings, err := httpclient.FromConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client := NewClient(cfg.SearchURL, cfg.SearchIndex)
+		client.http = httpclient.New("search", 5*time.Second, settings)
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unsupported search backend: %s", cfg.SearchBackend)
+	}
+}
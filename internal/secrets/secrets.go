@@ -0,0 +1,141 @@
+// Package secrets fetches configuration values such as database DSNs and
+// signing keys from a secrets manager at startup, so they need not live in
+// .env files. The secret is one JSON object keyed by environment variable
+// name, e.g. {"DB_DSN": "...", "ADMIN_TOKEN": "..."}.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+)
+
+// Provider fetches the current values of the secret.
+type Provider interface {
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// New returns the provider selected by SECRETS_PROVIDER, or nil when the
+// configuration comes from the environment only.
+func New(cfg *config.Config) (Provider, error) {
+	switch cfg.SecretsProvider {
+	case "", "none":
+		return nil, nil
+	case "vault":
+		return NewVaultProvider(cfg.VaultAddr, cfg.VaultToken, cfg.VaultSecretPath), nil
+	case "aws":
+		if cfg.AWSAccessKeyID == "" || cfg.AWSSecretAccessKey == "" {
+			return nil, fmt.Errorf("aws secrets provider needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		creds := Credentials{AccessKeyID: cfg.AWSAccessKeyID, SecretAccessKey: cfg.AWSSecretAccessKey, SessionToken: cfg.AWSSessionToken}
+		return NewAWSProvider(cfg.AWSRegion, cfg.AWSSecretID, cfg.AWSEndpointURL, creds), nil
+	default:
+		return nil, fmt.Errorf("unsupported secrets provider: %s", cfg.SecretsProvider)
+	}
+}
+
+// Store caches the values of a Provider. Lookups are answered from memory;
+// only Refresh goes to the provider, and a failed refresh keeps the values
+// fetched last.
+type Store struct {
+	provider Provider
+
+	mu     sync.RWMutex
+	values map[string]string
+	hooks  map[string][]func(value string)
+}
+
+var _ config.Source = (*Store)(nil)
+
+func NewStore(provider Provider) *Store {
+	return &Store{provider: provider, values: map[string]string{}, hooks: map[string][]func(string){}}
+}
+
+func (s *Store) Lookup(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+// OnRotate registers fn to be called with the new value whenever a
+// refresh finds key changed.
+func (s *Store) OnRotate(key string, fn func(value string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks[key] = append(s.hooks[key], fn)
+}
+
+// Refresh fetches the secret again and runs the rotation hooks of the keys
+// whose value changed. The first refresh runs no hooks.
+func (s *Store) Refresh(ctx context.Context) error {
+	values, err := s.provider.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	first := len(s.values) == 0
+	var rotated []func()
+	for key, fns := range s.hooks {
+		value, old := values[key], s.values[key]
+		if first || value == old {
+			continue
+		}
+		for _, fn := range fns {
+			rotated = append(rotated, func() { fn(value) })
+		}
+	}
+	s.values = maps.Clone(values)
+	s.mu.Unlock()
+
+	for _, fn := range rotated {
+		fn()
+	}
+	return nil
+}
+
+// Run refreshes the store every interval until ctx is done.
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Printf("Error refreshing secrets: %v", err)
+			}
+		}
+	}
+}
+
+// decodeValues reads a secret's JSON object, turning numbers and booleans
+// into strings as they would be read from the environment.
+func decodeValues(raw []byte) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var data map[string]any
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case json.Number, bool:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return values, nil
+}
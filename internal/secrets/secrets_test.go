@@ -0,0 +1,127 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProvider struct {
+	values map[string]string
+	err    error
+}
+
+func (p *stubProvider) Fetch(context.Context) (map[string]string, error) {
+	return p.values, p.err
+}
+
+func TestNew(t *testing.T) {
+	provider, err := New(&config.Config{SecretsProvider: "none"})
+	require.NoError(t, err)
+	require.Nil(t, provider)
+
+	provider, err = New(&config.Config{SecretsProvider: "vault", VaultAddr: "http://vault:8200"})
+	require.NoError(t, err)
+	require.IsType(t, &VaultProvider{}, provider)
+
+	_, err = New(&config.Config{SecretsProvider: "aws"})
+	require.ErrorContains(t, err, "AWS_ACCESS_KEY_ID")
+
+	_, err = New(&config.Config{SecretsProvider: "etcd"})
+	require.EqualError(t, err, "unsupported secrets provider: etcd")
+}
+
+func TestStore(t *testing.T) {
+	provider := &stubProvider{values: map[string]string{"ADMIN_TOKEN": "first", "DB_DSN": "postgres://db"}}
+	store := NewStore(provider)
+
+	var rotated []string
+	store.OnRotate("ADMIN_TOKEN", func(value string) { rotated = append(rotated, value) })
+
+	require.NoError(t, store.Refresh(context.Background()))
+	value, ok := store.Lookup("ADMIN_TOKEN")
+	require.True(t, ok)
+	require.Equal(t, "first", value)
+	require.Empty(t, rotated)
+
+	// The environment wins over the store.
+	t.Setenv("DB_DSN", "from-env")
+	cfg := config.Load(store)
+	require.Equal(t, "first", cfg.AdminToken)
+	require.Equal(t, "from-env", cfg.DBDSN)
+
+	provider.values = map[string]string{"ADMIN_TOKEN": "second", "DB_DSN": "postgres://db"}
+	require.NoError(t, store.Refresh(context.Background()))
+	require.Equal(t, []string{"second"}, rotated)
+
+	// A failed refresh keeps the values fetched last.
+	provider.err = errors.New("unreachable")
+	require.Error(t, store.Refresh(context.Background()))
+	value, _ = store.Lookup("ADMIN_TOKEN")
+	require.Equal(t, "second", value)
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/cupcake-store":
+			w.Write([]byte(`{"data":{"data":{"DB_DSN":"postgres://db","PUBLIC_RATE_LIMIT":120},"metadata":{"version":3}}}`))
+		case "/v1/kv/cupcake-store":
+			w.Write([]byte(`{"data":{"ADMIN_TOKEN":"token"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	values, err := NewVaultProvider(server.URL, "root", "secret/data/cupcake-store").Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"DB_DSN": "postgres://db", "PUBLIC_RATE_LIMIT": "120"}, values)
+
+	values, err = NewVaultProvider(server.URL+"/", "root", "/kv/cupcake-store").Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"ADMIN_TOKEN": "token"}, values)
+
+	_, err = NewVaultProvider(server.URL, "wrong", "secret/data/cupcake-store").Fetch(context.Background())
+	require.ErrorContains(t, err, "403")
+}
+
+func TestAWSProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		require.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		require.Contains(t, r.Header.Get("Authorization"), "Credential=AKID/20240102/sa-east-1/secretsmanager/aws4_request")
+		require.Contains(t, r.Header.Get("Authorization"), "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target")
+		w.Write([]byte(`{"Name":"cupcake-store","SecretString":"{\"DB_DSN\":\"postgres://db\"}"}`))
+	}))
+	defer server.Close()
+
+	provider := NewAWSProvider("sa-east-1", "cupcake-store", server.URL, Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session"})
+	provider.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	values, err := provider.Fetch(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"DB_DSN": "postgres://db"}, values)
+}
+
+// TestSignV4 checks the signer against the get-vanilla case of the AWS
+// Signature Version 4 test suite.
+func TestSignV4(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	req.Header = http.Header{}
+	creds := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signV4(req, nil, creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	require.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
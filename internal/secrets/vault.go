@@ -0,0 +1,72 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultProvider reads a secret from HashiCorp Vault's KV engine, version 1
+// or 2, authenticating with a token.
+type VaultProvider struct {
+	addr  string
+	token string
+	path  string
+	http  *http.Client
+}
+
+var _ Provider = (*VaultProvider)(nil)
+
+// NewVaultProvider reads path under addr/v1/; for KV version 2 that is
+// "<mount>/data/<name>".
+func NewVaultProvider(addr, token, path string) *VaultProvider {
+	return &VaultProvider{
+		addr:  strings.TrimRight(addr, "/"),
+		token: token,
+		path:  strings.Trim(path, "/"),
+		http:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (p *VaultProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("vault: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("vault: decoding response: %w", err)
+	}
+	// KV version 2 wraps the values in data.data, next to their metadata.
+	var v2 struct {
+		Data     json.RawMessage `json:"data"`
+		Metadata json.RawMessage `json:"metadata"`
+	}
+	secret := body.Data
+	if err := json.Unmarshal(body.Data, &v2); err == nil && v2.Metadata != nil {
+		secret = v2.Data
+	}
+	values, err := decodeValues(secret)
+	if err != nil {
+		return nil, fmt.Errorf("vault: decoding secret: %w", err)
+	}
+	return values, nil
+}
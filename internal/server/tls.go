@@ -0,0 +1,78 @@
+// Package server holds the transport setup shared by the HTTP entry point:
+// TLS defaults, automatic certificates and the plain HTTP redirect.
+package server
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSConfig returns modern defaults: TLS 1.2 or newer, forward-secret AEAD
+// suites only (TLS 1.3 suites are not configurable and already qualify).
+func TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		CurvePreferences: []tls.CurveID{
+			tls.X25519,
+			tls.CurveP256,
+		},
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+		},
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+}
+
+// Autocert obtains certificates from Let's Encrypt for the given domains,
+// caching them in cacheDir. The returned manager's HTTPHandler must be
+// reachable on port 80 to answer HTTP-01 challenges.
+func Autocert(domains []string, cacheDir string) (*autocert.Manager, *tls.Config) {
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Cache:      autocert.DirCache(cacheDir),
+	}
+
+	cfg := TLSConfig()
+	cfg.GetCertificate = manager.GetCertificate
+	cfg.NextProtos = append(cfg.NextProtos, acme.ALPNProto)
+	return manager, cfg
+}
+
+// ParseDomains splits a comma-separated domain list, dropping blanks.
+func ParseDomains(raw string) []string {
+	var domains []string
+	for _, domain := range strings.Split(raw, ",") {
+		if domain = strings.TrimSpace(domain); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// RedirectHTTPS permanently redirects every request to the same host and
+// path over HTTPS. A non-default httpsPort is added to the host.
+func RedirectHTTPS(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "" && httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
+}
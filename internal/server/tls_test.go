@@ -0,0 +1,89 @@
+package server
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/acme"
+)
+
+func TestTLSConfig(t *testing.T) {
+	cfg := TLSConfig()
+
+	require.Equal(t, uint16(tls.VersionTLS12), cfg.MinVersion)
+	require.Equal(t, []string{"h2", "http/1.1"}, cfg.NextProtos)
+	for _, id := range cfg.CipherSuites {
+		for _, insecure := range tls.InsecureCipherSuites() {
+			require.NotEqual(t, insecure.ID, id, "insecure suite %s", insecure.Name)
+		}
+	}
+}
+
+func TestAutocert(t *testing.T) {
+	manager, cfg := Autocert([]string{"shop.example.com"}, t.TempDir())
+
+	require.NotNil(t, cfg.GetCertificate)
+	require.Contains(t, cfg.NextProtos, acme.ALPNProto)
+	require.NoError(t, manager.HostPolicy(t.Context(), "shop.example.com"))
+	require.Error(t, manager.HostPolicy(t.Context(), "evil.example.com"))
+}
+
+func TestParseDomains(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []string
+	}{
+		{name: "empty", raw: "", expected: nil},
+		{name: "single", raw: "shop.example.com", expected: []string{"shop.example.com"}},
+		{name: "list with blanks", raw: " shop.example.com, ,www.shop.example.com ", expected: []string{"shop.example.com", "www.shop.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ParseDomains(tt.raw))
+		})
+	}
+}
+
+func TestRedirectHTTPS(t *testing.T) {
+	tests := []struct {
+		name             string
+		httpsPort        string
+		target           string
+		expectedLocation string
+	}{
+		{
+			name:             "default port",
+			httpsPort:        "443",
+			target:           "http://shop.example.com/api/v1/cupcakes?page=2",
+			expectedLocation: "https://shop.example.com/api/v1/cupcakes?page=2",
+		},
+		{
+			name:             "drops the plain HTTP port",
+			httpsPort:        "443",
+			target:           "http://shop.example.com:80/",
+			expectedLocation: "https://shop.example.com/",
+		},
+		{
+			name:             "custom HTTPS port",
+			httpsPort:        "8443",
+			target:           "http://localhost:8080/health",
+			expectedLocation: "https://localhost:8443/health",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			w := httptest.NewRecorder()
+			RedirectHTTPS(tt.httpsPort).ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusPermanentRedirect, w.Code)
+			require.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAccessTokenInvalid = errors.New("access token is invalid")
+	ErrAccessTokenExpired = errors.New("access token has expired")
+)
+
+// jwtHeader is the only header accessTokens issues or accepts.
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type accessClaims struct {
+	Subject   string `json:"sub"`
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Audiences of the access tokens, so a customer's token is refused where
+// an admin's is expected and the other way around.
+const (
+	accountTokenAudience = "account"
+	adminTokenAudience   = "admin"
+)
+
+// accessTokens issues the bearer tokens accounts and admins log in with:
+// HS256 JSON Web Tokens carrying the ID as subject. Like the signed links,
+// they are checked without being stored.
+type accessTokens struct {
+	secret   []byte
+	audience string
+	ttl      time.Duration
+}
+
+// newAccessTokens falls back to a random secret when none is configured;
+// tokens then only work on this instance until it restarts.
+func newAccessTokens(secret, audience string, ttl time.Duration) accessTokens {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		rand.Read(key)
+	}
+	return accessTokens{secret: key, audience: audience, ttl: ttl}
+}
+
+func (t accessTokens) issue(id uint, now time.Time) (string, error) {
+	claims, err := json.Marshal(accessClaims{
+		Subject:   strconv.FormatUint(uint64(id), 10),
+		Audience:  t.audience,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(t.ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+	return unsigned + "." + t.sign(unsigned), nil
+}
+
+// parse checks token and returns the ID it was issued to.
+func (t accessTokens) parse(token string, now time.Time) (uint, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return 0, ErrAccessTokenInvalid
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(t.sign(parts[0]+"."+parts[1]))) {
+		return 0, ErrAccessTokenInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, ErrAccessTokenInvalid
+	}
+	var claims accessClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return 0, ErrAccessTokenInvalid
+	}
+	id, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil || id == 0 || claims.Audience != t.audience {
+		return 0, ErrAccessTokenInvalid
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return 0, ErrAccessTokenExpired
+	}
+	return uint(id), nil
+}
+
+func (t accessTokens) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
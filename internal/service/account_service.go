@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	// DefaultAccessTokenTTL is how long a login lasts when no TTL is
+	// configured.
+	DefaultAccessTokenTTL = time.Hour
+
+	// EmailVerificationTTL is how long an email verification link works.
+	EmailVerificationTTL = 48 * time.Hour
+)
+
+var (
+	// ErrInvalidCredentials is returned for both an unknown email and a
+	// wrong password, so a login attempt does not reveal which accounts
+	// exist.
+	ErrInvalidCredentials = errors.New("invalid email or password")
+
+	ErrVerificationTokenInvalid = errors.New("verification token is invalid")
+	ErrVerificationTokenExpired = errors.New("verification token has expired")
+	ErrEmailNotVerified         = errors.New("email is not verified")
+	// ErrVerificationUnavailable is returned for resends while no email
+	// sender is configured, and ErrVerificationNotSent when it failed.
+	ErrVerificationUnavailable = errors.New("email verification is not available")
+	ErrVerificationNotSent     = errors.New("verification email could not be sent")
+)
+
+// AccountService registers customer accounts and logs them in. Passwords
+// are hashed with argon2id under params; a login whose stored hash was
+// made with other params rehashes the password while it is at hand. New
+// accounts get a verification link by email; it is never published as an
+// event.
+type AccountService struct {
+	repo      repository.AccountRepositoryInterface
+	email     EmailSender
+	publicURL string
+	creds     *credentials
+	tokens    accessTokens
+	signer    linkSigner
+	now       func() time.Time
+}
+
+var _ AccountServiceInterface = (*AccountService)(nil)
+
+// NewAccountService signs access tokens and verification links with
+// tokenSecret; access tokens are valid for tokenTTL. Zero params mean
+// password.DefaultParams, and a zero TTL means DefaultAccessTokenTTL.
+func NewAccountService(repo repository.AccountRepositoryInterface, params password.Params, tokenSecret string, tokenTTL time.Duration) *AccountService {
+	if tokenTTL <= 0 {
+		tokenTTL = DefaultAccessTokenTTL
+	}
+	return &AccountService{
+		repo:   repo,
+		creds:  newCredentials(params),
+		tokens: newAccessTokens(tokenSecret, accountTokenAudience, tokenTTL),
+		signer: newLinkSigner(tokenSecret),
+		now:    time.Now,
+	}
+}
+
+// WithEmail sends the verification links through sender, as links under
+// publicURL. Until it is called, new accounts get no link and resends fail
+// with ErrVerificationUnavailable.
+func (s *AccountService) WithEmail(sender EmailSender, publicURL string) *AccountService {
+	s.email = sender
+	s.publicURL = strings.TrimSuffix(publicURL, "/")
+	return s
+}
+
+func (s *AccountService) Register(req *models.RegisterRequest) (*models.Account, error) {
+	account := &models.Account{
+		Name:  strings.TrimSpace(req.Name),
+		Email: strings.ToLower(strings.TrimSpace(req.Email)),
+	}
+	if err := s.validateRegistration(account, req.Password); err != nil {
+		return nil, err
+	}
+
+	_, err := s.repo.FindByEmail(account.Email)
+	if err == nil {
+		return nil, i18n.NewError(msgAccountEmailTaken, nil)
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	hash, err := s.creds.hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	account.PasswordHash = hash
+
+	if err := s.repo.Create(account); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, i18n.NewError(msgAccountEmailTaken, nil)
+		}
+		return nil, err
+	}
+	// The account is there either way; the customer can ask for another
+	// link.
+	if err := s.sendVerification(account); err != nil && !errors.Is(err, ErrVerificationUnavailable) {
+		log.Printf("Error sending verification email to account %d: %v", account.ID, err)
+	}
+	return account, nil
+}
+
+// VerifyEmail checks a verification link's token and marks the email of
+// its account verified. Following a link again is harmless.
+func (s *AccountService) VerifyEmail(token string) (*models.Account, error) {
+	// <account id>.<expires>.<signature>
+	fields := strings.Split(token, ".")
+	if len(fields) != 3 {
+		return nil, ErrVerificationTokenInvalid
+	}
+	id, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil {
+		return nil, ErrVerificationTokenInvalid
+	}
+	expires, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, ErrVerificationTokenInvalid
+	}
+
+	account, err := s.repo.FindByID(uint(id))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrVerificationTokenInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The email is signed too, so a link stops working if it changes.
+	if !s.signer.valid(fields[2], "verify-email", account.ID, account.Email, expires) {
+		return nil, ErrVerificationTokenInvalid
+	}
+	if !s.now().Before(time.Unix(expires, 0)) {
+		return nil, ErrVerificationTokenExpired
+	}
+
+	if account.EmailVerifiedAt == nil {
+		now := s.now()
+		if err := s.repo.MarkEmailVerified(account.ID, now); err != nil {
+			return nil, err
+		}
+		account.EmailVerifiedAt = &now
+	}
+	return account, nil
+}
+
+// ResendVerification sends a new verification link to the account of an
+// access token, for when the first one expired or got lost.
+func (s *AccountService) ResendVerification(accessToken string) error {
+	account, err := s.Authenticate(accessToken)
+	if err != nil {
+		return err
+	}
+	if account.EmailVerifiedAt != nil {
+		return nil
+	}
+	return s.sendVerification(account)
+}
+
+// sendVerification emails the account its verification link, which only
+// whoever receives mail at the address gets.
+func (s *AccountService) sendVerification(account *models.Account) error {
+	if s.email == nil {
+		return ErrVerificationUnavailable
+	}
+	expiresAt := s.now().Add(EmailVerificationTTL)
+	expires := expiresAt.Unix()
+	token := fmt.Sprintf("%d.%d.%s", account.ID, expires, s.signer.sign("verify-email", account.ID, account.Email, expires))
+	link := s.publicURL + "/api/v1/auth/verify?" + url.Values{"token": {token}}.Encode()
+	message := &models.RenderedEmail{
+		Subject: "Confirm your email",
+		HTML: fmt.Sprintf("<p>Hi %s,</p>\n<p><a href=\"%s\">Confirm your email</a> before %s UTC to finish setting up your account.</p>\n<p>If you did not create an account, you can ignore this email.</p>\n",
+			html.EscapeString(account.Name), html.EscapeString(link), expiresAt.UTC().Format("02/01/2006 15:04")),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), emailSendTimeout)
+	defer cancel()
+	if err := s.email.Send(ctx, account.Email, message); err != nil {
+		return fmt.Errorf("%w: %w", ErrVerificationNotSent, err)
+	}
+	return nil
+}
+
+// Login checks the credentials and issues an access token.
+func (s *AccountService) Login(req *models.LoginRequest) (*models.AccessToken, error) {
+	account, err := s.checkLogin(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.accessToken(account)
+}
+
+// checkLogin returns the account of the credentials.
+func (s *AccountService) checkLogin(req *models.LoginRequest) (*models.Account, error) {
+	account, err := s.repo.FindByEmail(strings.ToLower(strings.TrimSpace(req.Email)))
+	if errors.Is(err, repository.ErrNotFound) {
+		s.creds.checkUnknown(req.Password)
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if account.PasswordHash == "" {
+		// Created through a social login, without a password.
+		s.creds.checkUnknown(req.Password)
+		return nil, ErrInvalidCredentials
+	}
+
+	ok, rehash, err := s.creds.check(req.Password, account.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+	if rehash {
+		s.rehash(account, req.Password)
+	}
+	return account, nil
+}
+
+// accessToken logs account in.
+func (s *AccountService) accessToken(account *models.Account) (*models.AccessToken, error) {
+	token, err := s.tokens.issue(account.ID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return &models.AccessToken{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.tokens.ttl.Seconds()),
+		Account:     account,
+	}, nil
+}
+
+// Authenticate returns the account an access token was issued to.
+func (s *AccountService) Authenticate(token string) (*models.Account, error) {
+	id, err := s.tokens.parse(token, s.now())
+	if err != nil {
+		return nil, err
+	}
+	account, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		// Deleted, e.g. erased, since the token was issued.
+		return nil, ErrAccessTokenInvalid
+	}
+	return account, err
+}
+
+// rehash stores the password under the current params. The login goes
+// ahead when this fails; the next one tries again.
+func (s *AccountService) rehash(account *models.Account, plain string) {
+	hash, err := s.creds.hash(plain)
+	if err == nil {
+		err = s.repo.UpdatePasswordHash(account.ID, hash)
+	}
+	if err != nil {
+		log.Printf("Error rehashing password of account %d: %v", account.ID, err)
+		return
+	}
+	account.PasswordHash = hash
+}
+
+func (s *AccountService) validateRegistration(account *models.Account, plain string) error {
+	if account.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if utf8.RuneCountInString(account.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if account.Email == "" {
+		return i18n.NewError(msgEmailRequired, nil)
+	}
+	if _, err := mail.ParseAddress(account.Email); err != nil {
+		return i18n.NewError(msgEmailInvalid, nil)
+	}
+	return validatePassword(plain)
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// fastPasswordParams keep the account tests quick.
+var fastPasswordParams = password.Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestAccountService_Register(t *testing.T) {
+	db := setupTestDB(t)
+	svc := NewAccountService(repository.NewAccountRepository(db), fastPasswordParams, "test-secret", time.Hour)
+
+	tests := []struct {
+		name string
+		req  models.RegisterRequest
+		err  string
+	}{
+		{"missing name", models.RegisterRequest{Email: "ana@example.com", Password: "correct horse"}, "name is required"},
+		{"invalid email", models.RegisterRequest{Name: "Ana", Email: "ana", Password: "correct horse"}, "email is invalid"},
+		{"missing password", models.RegisterRequest{Name: "Ana", Email: "ana@example.com"}, "password is required"},
+		{"short password", models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: "horse"}, "password must have at least 8 characters"},
+		{"long password", models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: strings.Repeat("a", PasswordMaxLength+1)}, "password must be at most 128 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Register(&tt.req)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+
+	account, err := svc.Register(&models.RegisterRequest{Name: " Ana Lima ", Email: " Ana@Example.com ", Password: "correct horse"})
+	require.NoError(t, err)
+	require.Equal(t, "Ana Lima", account.Name)
+	require.Equal(t, "ana@example.com", account.Email)
+	require.True(t, strings.HasPrefix(account.PasswordHash, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	_, err = svc.Register(&models.RegisterRequest{Name: "Ana", Email: "ANA@example.com", Password: "another horse"})
+	require.EqualError(t, err, "an account with this email already exists")
+}
+
+func TestAccountService_Login(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewAccountRepository(db)
+	svc := NewAccountService(repo, fastPasswordParams, "test-secret", time.Hour)
+
+	account, err := svc.Register(&models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	_, err = svc.Login(&models.LoginRequest{Email: "ana@example.com", Password: "wrong horse"})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = svc.Login(&models.LoginRequest{Email: "bruno@example.com", Password: "correct horse"})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	token, err := svc.Login(&models.LoginRequest{Email: " ANA@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.Equal(t, "Bearer", token.TokenType)
+	require.Equal(t, 3600, token.ExpiresIn)
+	require.Equal(t, account.ID, token.Account.ID)
+
+	me, err := svc.Authenticate(token.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, me.ID)
+
+	_, err = svc.Authenticate(token.AccessToken + "x")
+	require.ErrorIs(t, err, ErrAccessTokenInvalid)
+	other := NewAccountService(repo, fastPasswordParams, "other-secret", time.Hour)
+	_, err = other.Authenticate(token.AccessToken)
+	require.ErrorIs(t, err, ErrAccessTokenInvalid)
+
+	svc.now = func() time.Time { return time.Now().Add(time.Hour + time.Minute) }
+	_, err = svc.Authenticate(token.AccessToken)
+	require.ErrorIs(t, err, ErrAccessTokenExpired)
+}
+
+func TestAccountService_LoginRehashes(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewAccountRepository(db)
+	old := NewAccountService(repo, fastPasswordParams, "test-secret", time.Hour)
+	account, err := old.Register(&models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	stronger := fastPasswordParams
+	stronger.Iterations = 2
+	svc := NewAccountService(repo, stronger, "test-secret", time.Hour)
+
+	// A failed login leaves the hash alone.
+	_, err = svc.Login(&models.LoginRequest{Email: "ana@example.com", Password: "wrong horse"})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	stored, err := repo.FindByID(account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.PasswordHash, stored.PasswordHash)
+
+	_, err = svc.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	stored, err = repo.FindByID(account.ID)
+	require.NoError(t, err)
+	require.False(t, password.NeedsRehash(stored.PasswordHash, stronger))
+
+	// The old hash is gone, but the password still works.
+	_, err = svc.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+}
+
+func TestAccountService_RehashFailureDoesNotFailLogin(t *testing.T) {
+	hash, err := password.Hash("correct horse", fastPasswordParams)
+	require.NoError(t, err)
+	repo := &mocks.AccountRepository{
+		FindByEmailFunc: func(email string) (*models.Account, error) {
+			return &models.Account{ID: 1, Email: email, PasswordHash: hash}, nil
+		},
+		UpdatePasswordHashFunc: func(id uint, hash string) error {
+			return repository.ErrConflict
+		},
+	}
+	stronger := fastPasswordParams
+	stronger.Iterations = 2
+	svc := NewAccountService(repo, stronger, "test-secret", time.Hour)
+
+	token, err := svc.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.Equal(t, hash, token.Account.PasswordHash)
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewAccountRepository(db)
+	var sent []string
+	var links []*url.URL
+	sender := &mocks.EmailSender{SendFunc: func(_ context.Context, to string, email *models.RenderedEmail) error {
+		href := regexp.MustCompile(`href="([^"]+)"`).FindStringSubmatch(email.HTML)
+		require.NotNil(t, href)
+		link, err := url.Parse(html.UnescapeString(href[1]))
+		require.NoError(t, err)
+		sent = append(sent, to)
+		links = append(links, link)
+		return nil
+	}}
+	svc := NewAccountService(repo, fastPasswordParams, "test-secret", time.Hour)
+
+	// Without an email sender the account is still created, but no link
+	// can be sent.
+	first, err := svc.Register(&models.RegisterRequest{Name: "Caio", Email: "caio@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	firstLogin, err := svc.Login(&models.LoginRequest{Email: "caio@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.ResendVerification(firstLogin.AccessToken), ErrVerificationUnavailable)
+	require.Nil(t, first.EmailVerifiedAt)
+	svc.WithEmail(sender, "https://loja.example.com/")
+
+	account, err := svc.Register(&models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.Nil(t, account.EmailVerifiedAt)
+	require.Equal(t, []string{"ana@example.com"}, sent)
+
+	link := links[0]
+	require.Equal(t, "loja.example.com", link.Host)
+	require.Equal(t, "/api/v1/auth/verify", link.Path)
+	token := link.Query().Get("token")
+
+	for _, bad := range []string{"", "x", "1.2", token + "0", "999" + token[1:]} {
+		_, err := svc.VerifyEmail(bad)
+		require.ErrorIs(t, err, ErrVerificationTokenInvalid, bad)
+	}
+
+	now := svc.now
+	svc.now = func() time.Time { return time.Now().Add(EmailVerificationTTL + time.Minute) }
+	_, err = svc.VerifyEmail(token)
+	require.ErrorIs(t, err, ErrVerificationTokenExpired)
+	svc.now = now
+
+	verified, err := svc.VerifyEmail(token)
+	require.NoError(t, err)
+	require.NotNil(t, verified.EmailVerifiedAt)
+	stored, err := repo.FindByID(account.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.EmailVerifiedAt)
+
+	// The link keeps working, and a verified account gets no new one.
+	_, err = svc.VerifyEmail(token)
+	require.NoError(t, err)
+	login, err := svc.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.NoError(t, svc.ResendVerification(login.AccessToken))
+	require.Len(t, sent, 1)
+
+	// A link stops working once the email changes.
+	require.NoError(t, db.Model(&models.Account{}).Where("id = ?", account.ID).Updates(map[string]any{"email": "bruno@example.com", "email_verified_at": nil}).Error)
+	_, err = svc.VerifyEmail(token)
+	require.ErrorIs(t, err, ErrVerificationTokenInvalid)
+	require.NoError(t, svc.ResendVerification(login.AccessToken))
+	require.Equal(t, []string{"ana@example.com", "bruno@example.com"}, sent)
+
+	sender.SendFunc = func(context.Context, string, *models.RenderedEmail) error {
+		return errors.New("smtp: connection refused")
+	}
+	require.ErrorIs(t, svc.ResendVerification(login.AccessToken), ErrVerificationNotSent)
+
+	require.ErrorIs(t, svc.ResendVerification("bad"), ErrAccessTokenInvalid)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var ErrAddonNotFound = errors.New("add-on not found")
+
+type AddonService struct {
+	repo repository.AddonRepositoryInterface
+}
+
+var _ AddonServiceInterface = (*AddonService)(nil)
+
+func NewAddonService(repo repository.AddonRepositoryInterface) *AddonService {
+	return &AddonService{repo: repo}
+}
+
+func (s *AddonService) CreateAddon(req *models.CreateAddonRequest) (*models.Addon, error) {
+	addon := &models.Addon{
+		Name:        strings.TrimSpace(req.Name),
+		PriceCents:  req.PriceCents,
+		IsAvailable: true,
+	}
+	if err := s.validateAddon(addon); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(addon); err != nil {
+		return nil, err
+	}
+
+	return addon, nil
+}
+
+func (s *AddonService) GetAddon(id uint) (*models.Addon, error) {
+	addon, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAddonNotFound
+		}
+		return nil, err
+	}
+	return addon, nil
+}
+
+func (s *AddonService) GetAllAddons() ([]models.Addon, error) {
+	return s.repo.FindAll()
+}
+
+// GetAvailableAddons lists the add-ons customers can currently pick.
+func (s *AddonService) GetAvailableAddons() ([]models.Addon, error) {
+	addons, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]models.Addon, 0, len(addons))
+	for _, addon := range addons {
+		if addon.IsAvailable {
+			available = append(available, addon)
+		}
+	}
+	return available, nil
+}
+
+func (s *AddonService) UpdateAddon(id uint, req *models.UpdateAddonRequest) (*models.Addon, error) {
+	addon, err := s.GetAddon(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		addon.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.PriceCents != nil {
+		addon.PriceCents = *req.PriceCents
+	}
+
+	if req.IsAvailable != nil {
+		addon.IsAvailable = *req.IsAvailable
+	}
+
+	if err := s.validateAddon(addon); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(addon); err != nil {
+		return nil, err
+	}
+
+	return addon, nil
+}
+
+func (s *AddonService) DeleteAddon(id uint) error {
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAddonNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *AddonService) validateAddon(addon *models.Addon) error {
+	if addon.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if len(addon.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if addon.PriceCents < 0 {
+		return i18n.NewError(msgPriceNegative, nil)
+	}
+
+	existing, err := s.repo.FindByName(addon.Name)
+	if err == nil && existing.ID != addon.ID {
+		return i18n.NewError(msgAddonNameTaken, nil)
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAddon(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateAddonRequest
+		expectedError string
+	}{
+		{name: "success", request: &models.CreateAddonRequest{Name: " Gift wrapping ", PriceCents: 300}},
+		{name: "free add-on", request: &models.CreateAddonRequest{Name: "Napkins"}},
+		{name: "missing name", request: &models.CreateAddonRequest{Name: "  ", PriceCents: 300}, expectedError: "name is required"},
+		{name: "negative price", request: &models.CreateAddonRequest{Name: "Ribbon", PriceCents: -1}, expectedError: "price cannot be negative"},
+		{name: "duplicate name", request: &models.CreateAddonRequest{Name: "Candles", PriceCents: 100}, expectedError: "add-on name already exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAddonService(repository.NewAddonRepository(setupTestDB(t)))
+			_, err := svc.CreateAddon(&models.CreateAddonRequest{Name: "Candles", PriceCents: 150})
+			require.NoError(t, err)
+
+			addon, err := svc.CreateAddon(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, addon.ID)
+			require.True(t, addon.IsAvailable)
+			require.Equal(t, tt.request.PriceCents, addon.PriceCents)
+		})
+	}
+}
+
+func TestUpdateAddon(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            uint
+		request       *models.UpdateAddonRequest
+		expectedError string
+	}{
+		{name: "keeps its own name", id: 1, request: &models.UpdateAddonRequest{Name: stringPtr("Candles"), PriceCents: intPtr(200)}},
+		{name: "withdraw", id: 1, request: &models.UpdateAddonRequest{IsAvailable: boolPtr(false)}},
+		{name: "name taken", id: 1, request: &models.UpdateAddonRequest{Name: stringPtr("Gift wrapping")}, expectedError: "add-on name already exists"},
+		{name: "unknown add-on", id: 999, request: &models.UpdateAddonRequest{PriceCents: intPtr(200)}, expectedError: ErrAddonNotFound.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAddonService(repository.NewAddonRepository(setupTestDB(t)))
+			for _, req := range []models.CreateAddonRequest{{Name: "Candles", PriceCents: 150}, {Name: "Gift wrapping", PriceCents: 300}} {
+				_, err := svc.CreateAddon(&req)
+				require.NoError(t, err)
+			}
+
+			_, err := svc.UpdateAddon(tt.id, tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			available, err := svc.GetAvailableAddons()
+			require.NoError(t, err)
+			if tt.request.IsAvailable != nil {
+				require.Len(t, available, 1)
+				require.Equal(t, "Gift wrapping", available[0].Name)
+			} else {
+				require.Len(t, available, 2)
+			}
+		})
+	}
+}
@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/totp"
+)
+
+const (
+	// TOTPIssuer names the store in authenticator apps.
+	TOTPIssuer = "Cupcake Store"
+
+	// BackupCodeCount is how many backup codes an admin gets at a time.
+	BackupCodeCount = 10
+
+	// smsCodeTTL is how long a code sent by SMS can be used, and
+	// smsCodeResendAfter how long before another one can be asked for.
+	smsCodeTTL         = 5 * time.Minute
+	smsCodeResendAfter = time.Minute
+)
+
+var (
+	ErrAdminNotFound = errors.New("admin not found")
+
+	// ErrSecondFactorRequired is returned by a login with the right
+	// password but no code, for an admin with two-factor authentication
+	// on.
+	ErrSecondFactorRequired = errors.New("two-factor code required")
+	ErrSecondFactorInvalid  = errors.New("two-factor code is invalid")
+
+	ErrTOTPNotEnrolled    = errors.New("two-factor authentication has not been set up")
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnabled     = errors.New("two-factor authentication is not enabled")
+
+	// ErrSMSUnavailable is returned when asking for a code by SMS without
+	// two-factor authentication on, a phone on file or an SMS provider.
+	ErrSMSUnavailable = errors.New("two-factor codes by SMS are not available for this admin")
+	ErrSMSCodeTooSoon = errors.New("an SMS code was sent less than a minute ago")
+)
+
+// AdminService manages the admin accounts of the admin API and logs them
+// in. Two-factor authentication is optional per admin: enrolling stores a
+// TOTP secret, and confirming it with a first code turns it on and hands
+// out backup codes. From then on a login needs a code from the
+// authenticator or an unused backup code besides the password. Admins
+// with a phone on file can be sent a code by SMS instead, when an SMS
+// provider is configured. A super-admin can turn it off for an admin who
+// lost both.
+type AdminService struct {
+	repo   repository.AdminRepositoryInterface
+	creds  *credentials
+	tokens accessTokens
+	sms    SMSSender
+	now    func() time.Time
+}
+
+var _ AdminServiceInterface = (*AdminService)(nil)
+
+// NewAdminService signs access tokens with tokenSecret, valid for tokenTTL.
+// Zero params mean password.DefaultParams, and a zero TTL means
+// DefaultAccessTokenTTL.
+func NewAdminService(repo repository.AdminRepositoryInterface, params password.Params, tokenSecret string, tokenTTL time.Duration) *AdminService {
+	if tokenTTL <= 0 {
+		tokenTTL = DefaultAccessTokenTTL
+	}
+	return &AdminService{
+		repo:   repo,
+		creds:  newCredentials(params),
+		tokens: newAccessTokens(tokenSecret, adminTokenAudience, tokenTTL),
+		now:    time.Now,
+	}
+}
+
+// WithSMS lets admins ask for their two-factor code by SMS.
+func (s *AdminService) WithSMS(sms SMSSender) *AdminService {
+	s.sms = sms
+	return s
+}
+
+func (s *AdminService) CreateAdmin(req *models.CreateAdminRequest) (*models.Admin, error) {
+	admin := &models.Admin{
+		Name:  strings.TrimSpace(req.Name),
+		Email: strings.ToLower(strings.TrimSpace(req.Email)),
+		Role:  req.Role,
+	}
+	if admin.Role == "" {
+		admin.Role = models.AdminRoleAdmin
+	}
+	if err := s.validateAdmin(admin, req.Password); err != nil {
+		return nil, err
+	}
+
+	hash, err := s.creds.hash(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	admin.PasswordHash = hash
+
+	if err := s.repo.Create(admin); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, i18n.NewError(msgAdminEmailTaken, nil)
+		}
+		return nil, err
+	}
+	return admin, nil
+}
+
+func (s *AdminService) GetAdmins() ([]models.Admin, error) {
+	return s.repo.FindAll()
+}
+
+// Login checks the credentials, and the second factor when the admin has
+// turned it on, and issues an access token.
+func (s *AdminService) Login(req *models.AdminLoginRequest) (*models.AdminAccessToken, error) {
+	admin, rehash, err := s.checkPassword(req.Email, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if admin.TOTPEnabledAt != nil {
+		if strings.TrimSpace(req.Code) == "" {
+			return nil, ErrSecondFactorRequired
+		}
+		if err := s.checkSecondFactor(admin, req.Code); err != nil {
+			return nil, err
+		}
+	}
+	if rehash {
+		s.rehash(admin, req.Password)
+	}
+
+	token, err := s.tokens.issue(admin.ID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return &models.AdminAccessToken{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.tokens.ttl.Seconds()),
+		Admin:       admin,
+	}, nil
+}
+
+// SendLoginCode texts the admin a one-time code to log in with instead of
+// one from the authenticator. The credentials are checked first, so codes
+// only go to someone who knows the password.
+func (s *AdminService) SendLoginCode(req *models.AdminLoginRequest) error {
+	admin, _, err := s.checkPassword(req.Email, req.Password)
+	if err != nil {
+		return err
+	}
+	if admin.TOTPEnabledAt == nil || admin.Phone == "" || s.sms == nil {
+		return ErrSMSUnavailable
+	}
+	now := s.now()
+	if admin.SMSCodeSentAt != nil && now.Sub(*admin.SMSCodeSentAt) < smsCodeResendAfter {
+		return ErrSMSCodeTooSoon
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return err
+	}
+	code := fmt.Sprintf("%06d", n.Int64())
+	if err := s.repo.SetSMSCode(admin.ID, hashBackupCode(code), now); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), smsSendTimeout)
+	defer cancel()
+	return s.sms.Send(ctx, admin.Phone, fmt.Sprintf("Your %s admin code is %s. It expires in %d minutes.", TOTPIssuer, code, int(smsCodeTTL.Minutes())))
+}
+
+// SetPhone sets the phone the admin's SMS codes go to, or removes it when
+// phone is empty. A code already sent stops working.
+func (s *AdminService) SetPhone(adminID uint, phone string) (*models.Admin, error) {
+	phone = strings.TrimSpace(phone)
+	if phone != "" && !phonePattern.MatchString(phone) {
+		return nil, i18n.NewError(msgPhoneInvalid, nil)
+	}
+	admin, err := s.findAdmin(adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	admin.Phone = phone
+	admin.SMSCodeHash = ""
+	admin.SMSCodeSentAt = nil
+	if err := s.repo.Update(admin); err != nil {
+		return nil, err
+	}
+	return admin, nil
+}
+
+// Authenticate returns the admin an access token was issued to.
+func (s *AdminService) Authenticate(token string) (*models.Admin, error) {
+	id, err := s.tokens.parse(token, s.now())
+	if err != nil {
+		return nil, err
+	}
+	admin, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrAccessTokenInvalid
+	}
+	return admin, err
+}
+
+// BeginTOTP starts enrolling the admin in two-factor authentication with a
+// new secret, replacing any enrollment left unconfirmed.
+func (s *AdminService) BeginTOTP(adminID uint) (*models.TOTPEnrollment, error) {
+	admin, err := s.findAdmin(adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.TOTPEnabledAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	admin.TOTPSecret = secret
+	if err := s.repo.Update(admin); err != nil {
+		return nil, err
+	}
+	return &models.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(TOTPIssuer, admin.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP turns two-factor authentication on once code shows the
+// authenticator was set up with the enrolled secret, and returns the
+// admin's backup codes.
+func (s *AdminService) ConfirmTOTP(adminID uint, code string) (*models.BackupCodes, error) {
+	admin, err := s.findAdmin(adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.TOTPEnabledAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if admin.TOTPSecret == "" {
+		return nil, ErrTOTPNotEnrolled
+	}
+	if err := s.checkTOTP(admin, code); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	admin.TOTPEnabledAt = &now
+	if err := s.repo.Update(admin); err != nil {
+		return nil, err
+	}
+	return s.newBackupCodes(admin.ID)
+}
+
+// DisableTOTP turns two-factor authentication off, given a current code or
+// a backup code.
+func (s *AdminService) DisableTOTP(adminID uint, code string) error {
+	admin, err := s.findAdmin(adminID)
+	if err != nil {
+		return err
+	}
+	if admin.TOTPEnabledAt == nil {
+		return ErrTOTPNotEnabled
+	}
+	if err := s.checkSecondFactor(admin, code); err != nil {
+		return err
+	}
+	return s.clearTOTP(admin)
+}
+
+// RegenerateBackupCodes replaces the admin's backup codes, given a current
+// code or a backup code.
+func (s *AdminService) RegenerateBackupCodes(adminID uint, code string) (*models.BackupCodes, error) {
+	admin, err := s.findAdmin(adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.TOTPEnabledAt == nil {
+		return nil, ErrTOTPNotEnabled
+	}
+	if err := s.checkSecondFactor(admin, code); err != nil {
+		return nil, err
+	}
+	return s.newBackupCodes(admin.ID)
+}
+
+// ResetTOTP turns two-factor authentication off for an admin locked out of
+// it, without a code. Only super-admins may call it; the admin can enroll
+// again after logging in with the password alone.
+func (s *AdminService) ResetTOTP(adminID uint) (*models.Admin, error) {
+	admin, err := s.findAdmin(adminID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.clearTOTP(admin); err != nil {
+		return nil, err
+	}
+	log.Printf("Two-factor authentication of admin %d reset", admin.ID)
+	return admin, nil
+}
+
+// checkPassword returns the admin with email once password matches, and
+// whether the password should be rehashed.
+func (s *AdminService) checkPassword(email, password string) (*models.Admin, bool, error) {
+	admin, err := s.repo.FindByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, repository.ErrNotFound) {
+		s.creds.checkUnknown(password)
+		return nil, false, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	ok, rehash, err := s.creds.check(password, admin.PasswordHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, ErrInvalidCredentials
+	}
+	return admin, rehash, nil
+}
+
+func (s *AdminService) findAdmin(id uint) (*models.Admin, error) {
+	admin, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrAdminNotFound
+	}
+	return admin, err
+}
+
+func (s *AdminService) clearTOTP(admin *models.Admin) error {
+	admin.TOTPSecret = ""
+	admin.TOTPEnabledAt = nil
+	if err := s.repo.Update(admin); err != nil {
+		return err
+	}
+	return s.repo.ReplaceBackupCodes(admin.ID, nil)
+}
+
+// checkSecondFactor accepts a six-digit code from the authenticator or
+// sent by SMS, or an unused backup code.
+func (s *AdminService) checkSecondFactor(admin *models.Admin, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		err := s.checkTOTP(admin, code)
+		if errors.Is(err, ErrSecondFactorInvalid) && admin.SMSCodeHash != "" {
+			return s.checkSMSCode(admin, code)
+		}
+		return err
+	}
+
+	used, err := s.repo.UseBackupCode(admin.ID, hashBackupCode(code), s.now())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrSecondFactorInvalid
+	}
+	return nil
+}
+
+// checkTOTP accepts code once: the step it belongs to is claimed, so the
+// same code cannot be replayed while it is still valid.
+func (s *AdminService) checkTOTP(admin *models.Admin, code string) error {
+	step, ok := totp.Validate(admin.TOTPSecret, strings.TrimSpace(code), s.now())
+	if !ok {
+		return ErrSecondFactorInvalid
+	}
+	claimed, err := s.repo.ClaimTOTPStep(admin.ID, step)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrSecondFactorInvalid
+	}
+	admin.TOTPLastStep = step
+	return nil
+}
+
+// checkSMSCode accepts the code last sent by SMS, once and only while it
+// is fresh.
+func (s *AdminService) checkSMSCode(admin *models.Admin, code string) error {
+	claimed, err := s.repo.ClaimSMSCode(admin.ID, hashBackupCode(code), s.now().Add(-smsCodeTTL))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return ErrSecondFactorInvalid
+	}
+	admin.SMSCodeHash = ""
+	admin.SMSCodeSentAt = nil
+	return nil
+}
+
+// newBackupCodes replaces the admin's backup codes with new ones, returned
+// in the xxxxx-xxxxx form they are shown in. Only their hashes are stored.
+func (s *AdminService) newBackupCodes(adminID uint) (*models.BackupCodes, error) {
+	codes := make([]string, BackupCodeCount)
+	hashes := make([]string, BackupCodeCount)
+	for i := range codes {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(raw))[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashBackupCode(code)
+	}
+	if err := s.repo.ReplaceBackupCodes(adminID, hashes); err != nil {
+		return nil, err
+	}
+	return &models.BackupCodes{BackupCodes: codes}, nil
+}
+
+// hashBackupCode ignores case, spaces and dashes, so a code typed as shown
+// or not matches. Codes sent by SMS are hashed the same way.
+func hashBackupCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// rehash stores the password under the current params. The login goes
+// ahead when this fails; the next one tries again.
+func (s *AdminService) rehash(admin *models.Admin, plain string) {
+	hash, err := s.creds.hash(plain)
+	if err == nil {
+		err = s.repo.UpdatePasswordHash(admin.ID, hash)
+	}
+	if err != nil {
+		log.Printf("Error rehashing password of admin %d: %v", admin.ID, err)
+		return
+	}
+	admin.PasswordHash = hash
+}
+
+func (s *AdminService) validateAdmin(admin *models.Admin, plain string) error {
+	if admin.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if utf8.RuneCountInString(admin.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if admin.Email == "" {
+		return i18n.NewError(msgEmailRequired, nil)
+	}
+	if _, err := mail.ParseAddress(admin.Email); err != nil {
+		return i18n.NewError(msgEmailInvalid, nil)
+	}
+	if admin.Role != models.AdminRoleAdmin && admin.Role != models.AdminRoleSuperAdmin {
+		return i18n.NewError(msgAdminRoleInvalid, nil)
+	}
+	return validatePassword(plain)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/totp"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAdminService(t *testing.T) *AdminService {
+	t.Helper()
+	return NewAdminService(repository.NewAdminRepository(setupTestDB(t)), fastPasswordParams, "test-secret", time.Hour)
+}
+
+func TestAdminService_CreateAdmin(t *testing.T) {
+	svc := newTestAdminService(t)
+
+	_, err := svc.CreateAdmin(&models.CreateAdminRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse", Role: "owner"})
+	require.EqualError(t, err, "role must be admin or super_admin")
+
+	admin, err := svc.CreateAdmin(&models.CreateAdminRequest{Name: "Ana", Email: " Ana@Example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.Equal(t, models.AdminRoleAdmin, admin.Role)
+	require.Equal(t, "ana@example.com", admin.Email)
+
+	_, err = svc.CreateAdmin(&models.CreateAdminRequest{Name: "Ana", Email: "ana@example.com", Password: "another horse"})
+	require.EqualError(t, err, "an admin with this email already exists")
+
+	admins, err := svc.GetAdmins()
+	require.NoError(t, err)
+	require.Len(t, admins, 1)
+}
+
+func TestAdminService_TwoFactorLogin(t *testing.T) {
+	svc := newTestAdminService(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	admin, err := svc.CreateAdmin(&models.CreateAdminRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	login := func(code string) (*models.AdminAccessToken, error) {
+		return svc.Login(&models.AdminLoginRequest{Email: "ana@example.com", Password: "correct horse", Code: code})
+	}
+
+	// Without two-factor authentication the password is enough.
+	token, err := login("")
+	require.NoError(t, err)
+	me, err := svc.Authenticate(token.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, admin.ID, me.ID)
+
+	_, err = svc.ConfirmTOTP(admin.ID, "123456")
+	require.ErrorIs(t, err, ErrTOTPNotEnrolled)
+
+	enrollment, err := svc.BeginTOTP(admin.ID)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/Cupcake%20Store:ana@example.com?"))
+
+	// Enrolling alone changes nothing.
+	_, err = login("")
+	require.NoError(t, err)
+
+	code, err := totp.Code(enrollment.Secret, now)
+	require.NoError(t, err)
+	backup, err := svc.ConfirmTOTP(admin.ID, code)
+	require.NoError(t, err)
+	require.Len(t, backup.BackupCodes, BackupCodeCount)
+	_, err = svc.BeginTOTP(admin.ID)
+	require.ErrorIs(t, err, ErrTOTPAlreadyEnabled)
+
+	_, err = svc.Login(&models.AdminLoginRequest{Email: "ana@example.com", Password: "wrong horse", Code: code})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = login("")
+	require.ErrorIs(t, err, ErrSecondFactorRequired)
+	// The code that confirmed the enrollment is spent.
+	_, err = login(code)
+	require.ErrorIs(t, err, ErrSecondFactorInvalid)
+
+	now = now.Add(totp.Period)
+	code, err = totp.Code(enrollment.Secret, now)
+	require.NoError(t, err)
+	_, err = login(code)
+	require.NoError(t, err)
+	_, err = login(code)
+	require.ErrorIs(t, err, ErrSecondFactorInvalid)
+
+	// Backup codes work once each, typed with or without the dash.
+	_, err = login(strings.ToUpper(strings.ReplaceAll(backup.BackupCodes[0], "-", "")))
+	require.NoError(t, err)
+	_, err = login(backup.BackupCodes[0])
+	require.ErrorIs(t, err, ErrSecondFactorInvalid)
+
+	regenerated, err := svc.RegenerateBackupCodes(admin.ID, backup.BackupCodes[1])
+	require.NoError(t, err)
+	_, err = login(backup.BackupCodes[2])
+	require.ErrorIs(t, err, ErrSecondFactorInvalid)
+
+	require.ErrorIs(t, svc.DisableTOTP(admin.ID, "000000"), ErrSecondFactorInvalid)
+	require.NoError(t, svc.DisableTOTP(admin.ID, regenerated.BackupCodes[0]))
+	_, err = login("")
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.DisableTOTP(admin.ID, regenerated.BackupCodes[1]), ErrTOTPNotEnabled)
+}
+
+func TestAdminService_SMSLoginCode(t *testing.T) {
+	svc := newTestAdminService(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	admin, err := svc.CreateAdmin(&models.CreateAdminRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	request := &models.AdminLoginRequest{Email: "ana@example.com", Password: "correct horse"}
+	login := func(code string) error {
+		_, err := svc.Login(&models.AdminLoginRequest{Email: "ana@example.com", Password: "correct horse", Code: code})
+		return err
+	}
+
+	_, err = svc.SetPhone(admin.ID, "11 91234-5678")
+	require.EqualError(t, err, "phone must be in international format, such as +5511912345678")
+	updated, err := svc.SetPhone(admin.ID, "+5511912345678")
+	require.NoError(t, err)
+	require.Equal(t, "+5511912345678", updated.Phone)
+
+	// Codes by SMS stand in for the authenticator, so they need two-factor
+	// authentication on and a provider.
+	require.ErrorIs(t, svc.SendLoginCode(request), ErrSMSUnavailable)
+	enrollment, err := svc.BeginTOTP(admin.ID)
+	require.NoError(t, err)
+	code, err := totp.Code(enrollment.Secret, now)
+	require.NoError(t, err)
+	_, err = svc.ConfirmTOTP(admin.ID, code)
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.SendLoginCode(request), ErrSMSUnavailable)
+
+	sms := &recordingSMS{}
+	svc.WithSMS(sms)
+	require.ErrorIs(t, svc.SendLoginCode(&models.AdminLoginRequest{Email: "ana@example.com", Password: "wrong horse"}), ErrInvalidCredentials)
+	require.NoError(t, svc.SendLoginCode(request))
+	require.Len(t, sms.sent, 1)
+	require.Equal(t, "+5511912345678", sms.sent[0].to)
+	smsCode := regexp.MustCompile(`[0-9]{6}`).FindString(sms.sent[0].body)
+	require.ErrorIs(t, svc.SendLoginCode(request), ErrSMSCodeTooSoon)
+
+	// A code works once.
+	require.NoError(t, login(smsCode))
+	require.ErrorIs(t, login(smsCode), ErrSecondFactorInvalid)
+
+	// Nor after it expires.
+	now = now.Add(smsCodeResendAfter)
+	require.NoError(t, svc.SendLoginCode(request))
+	smsCode = regexp.MustCompile(`[0-9]{6}`).FindString(sms.sent[1].body)
+	now = now.Add(smsCodeTTL)
+	require.ErrorIs(t, login(smsCode), ErrSecondFactorInvalid)
+
+	// Removing the phone turns them off.
+	_, err = svc.SetPhone(admin.ID, "")
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.SendLoginCode(request), ErrSMSUnavailable)
+}
+
+func TestAdminService_ResetTOTP(t *testing.T) {
+	db := setupTestDB(t)
+	svc := NewAdminService(repository.NewAdminRepository(db), fastPasswordParams, "test-secret", time.Hour)
+	admin, err := svc.CreateAdmin(&models.CreateAdminRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	enrollment, err := svc.BeginTOTP(admin.ID)
+	require.NoError(t, err)
+	code, err := totp.Code(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	_, err = svc.ConfirmTOTP(admin.ID, code)
+	require.NoError(t, err)
+
+	reset, err := svc.ResetTOTP(admin.ID)
+	require.NoError(t, err)
+	require.Nil(t, reset.TOTPEnabledAt)
+
+	_, err = svc.Login(&models.AdminLoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	var backupCodes int64
+	require.NoError(t, db.Model(&models.AdminBackupCode{}).Count(&backupCodes).Error)
+	require.Zero(t, backupCodes)
+
+	_, err = svc.ResetTOTP(999)
+	require.ErrorIs(t, err, ErrAdminNotFound)
+}
+
+func TestAdminService_TokensAreNotAccountTokens(t *testing.T) {
+	db := setupTestDB(t)
+	admins := NewAdminService(repository.NewAdminRepository(db), fastPasswordParams, "test-secret", time.Hour)
+	accounts := NewAccountService(repository.NewAccountRepository(db), fastPasswordParams, "test-secret", time.Hour)
+
+	_, err := admins.CreateAdmin(&models.CreateAdminRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	_, err = accounts.Register(&models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	adminToken, err := admins.Login(&models.AdminLoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	accountToken, err := accounts.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	_, err = accounts.Authenticate(adminToken.AccessToken)
+	require.ErrorIs(t, err, ErrAccessTokenInvalid)
+	_, err = admins.Authenticate(accountToken.AccessToken)
+	require.ErrorIs(t, err, ErrAccessTokenInvalid)
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	// APIKeyPrefix starts every key, so leaked keys are easy to search for.
+	APIKeyPrefix = "csk_"
+
+	// DefaultUsageWindow is how many days a usage report covers when none
+	// is asked for.
+	DefaultUsageWindow = 30
+	maxUsageWindow     = 366
+
+	apiKeyPrefixLength = len(APIKeyPrefix) + 8
+)
+
+var (
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	// ErrAPIKeyInvalid is returned for keys that do not exist or were
+	// revoked.
+	ErrAPIKeyInvalid = errors.New("API key is invalid or has been revoked")
+	ErrQuotaExceeded = errors.New("API key quota exceeded")
+)
+
+// APIKeyService issues the keys integrations call the public API with and
+// meters their requests against daily and monthly quotas. Requests are
+// counted per key and day in the database, so every instance sees the same
+// usage; days and months follow the store's local time.
+type APIKeyService struct {
+	repo repository.APIKeyRepositoryInterface
+	now  func() time.Time
+}
+
+var _ APIKeyServiceInterface = (*APIKeyService)(nil)
+
+func NewAPIKeyService(repo repository.APIKeyRepositoryInterface) *APIKeyService {
+	return &APIKeyService{repo: repo, now: time.Now}
+}
+
+// CreateAPIKey returns the new key along with it; it is not shown again.
+func (s *APIKeyService) CreateAPIKey(req *models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error) {
+	key := &models.APIKey{Name: strings.TrimSpace(req.Name), DailyQuota: req.DailyQuota, MonthlyQuota: req.MonthlyQuota}
+	if err := validateAPIKey(key); err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	secret := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	key.Prefix = secret[:apiKeyPrefixLength]
+	key.KeyHash = hashAPIKey(secret)
+	if err := s.repo.Create(key); err != nil {
+		return nil, err
+	}
+	return &models.CreatedAPIKey{APIKey: *key, Key: secret}, nil
+}
+
+func (s *APIKeyService) GetAPIKeys() ([]models.APIKey, error) {
+	return s.repo.FindAll()
+}
+
+func (s *APIKeyService) GetAPIKey(id uint) (*models.APIKey, error) {
+	key, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// UpdateAPIKey renames a key or changes its quotas, which apply from the
+// next request on.
+func (s *APIKeyService) UpdateAPIKey(id uint, req *models.UpdateAPIKeyRequest) (*models.APIKey, error) {
+	key, err := s.GetAPIKey(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		key.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.DailyQuota != nil {
+		key.DailyQuota = *req.DailyQuota
+	}
+	if req.MonthlyQuota != nil {
+		key.MonthlyQuota = *req.MonthlyQuota
+	}
+	if err := validateAPIKey(key); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// RevokeAPIKey stops the key from working. Its usage is kept for reports.
+func (s *APIKeyService) RevokeAPIKey(id uint) (*models.APIKey, error) {
+	if err := s.repo.Revoke(id, s.now()); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return s.repo.FindByID(id)
+}
+
+// Use counts a request made with secret and returns where the key stands
+// against its quotas. Once a quota is used up the request is not counted
+// and ErrQuotaExceeded comes with the quota, so the caller can tell the
+// client when it resets. The quota is checked as the request is counted,
+// so requests racing for the last units of a quota cannot all get through.
+func (s *APIKeyService) Use(secret string) (*models.APIKeyQuota, error) {
+	if !strings.HasPrefix(secret, APIKeyPrefix) {
+		return nil, ErrAPIKeyInvalid
+	}
+	key, err := s.repo.FindByKeyHash(hashAPIKey(secret))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrAPIKeyInvalid
+	}
+
+	now := s.now()
+	month := startOfMonth(now).Format(time.DateOnly)
+	err = s.repo.AddUsage(key.ID, now.Format(time.DateOnly), month, key.DailyQuota, key.MonthlyQuota)
+	exhausted := errors.Is(err, repository.ErrQuotaExhausted)
+	if err != nil && !exhausted {
+		return nil, err
+	}
+	if key.DailyQuota == 0 && key.MonthlyQuota == 0 {
+		return &models.APIKeyQuota{}, nil
+	}
+
+	// The usage is read back only to report the quota; the count above is
+	// what enforces it.
+	usage, err := s.repo.FindUsage(key.ID, month)
+	if err != nil {
+		return nil, err
+	}
+	quota := currentQuota(key, usage, now)
+	if exhausted {
+		return quota, ErrQuotaExceeded
+	}
+	return quota, nil
+}
+
+// Usage reports the key's requests over the last window days, today
+// included.
+func (s *APIKeyService) Usage(id uint, window int) (*models.APIKeyUsageReport, error) {
+	if window < 1 || window > maxUsageWindow {
+		return nil, i18n.NewError(msgWindowOutOfRange, map[string]any{"Max": maxUsageWindow})
+	}
+	key, err := s.GetAPIKey(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read from the start of the month too, for this month's total.
+	now := s.now()
+	today := now.Format(time.DateOnly)
+	from := now.AddDate(0, 0, 1-window).Format(time.DateOnly)
+	month := startOfMonth(now).Format(time.DateOnly)
+	usage, err := s.repo.FindUsage(key.ID, min(from, month))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.APIKeyUsageReport{
+		APIKeyID:     key.ID,
+		DailyQuota:   key.DailyQuota,
+		MonthlyQuota: key.MonthlyQuota,
+		Days:         []models.APIKeyUsage{},
+	}
+	for _, day := range usage {
+		if day.Day == today {
+			report.Today = day.Requests
+		}
+		if day.Day >= month {
+			report.ThisMonth += day.Requests
+		}
+		if day.Day >= from {
+			report.Total += day.Requests
+			report.Days = append(report.Days, day)
+		}
+	}
+	return report, nil
+}
+
+// currentQuota picks, of the key's quotas, the one with the fewest
+// requests left, the monthly one when they tie since it resets later.
+func currentQuota(key *models.APIKey, usage []models.APIKeyUsage, now time.Time) *models.APIKeyQuota {
+	today := now.Format(time.DateOnly)
+	var usedToday, usedThisMonth int
+	for _, day := range usage {
+		if day.Day == today {
+			usedToday += day.Requests
+		}
+		usedThisMonth += day.Requests
+	}
+
+	var quota *models.APIKeyQuota
+	if key.MonthlyQuota > 0 {
+		quota = &models.APIKeyQuota{
+			Limit:     key.MonthlyQuota,
+			Remaining: max(key.MonthlyQuota-usedThisMonth, 0),
+			Reset:     startOfMonth(now).AddDate(0, 1, 0),
+		}
+	}
+	if key.DailyQuota > 0 {
+		remaining := max(key.DailyQuota-usedToday, 0)
+		if quota == nil || remaining < quota.Remaining {
+			y, m, d := now.Date()
+			quota = &models.APIKeyQuota{
+				Limit:     key.DailyQuota,
+				Remaining: remaining,
+				Reset:     time.Date(y, m, d+1, 0, 0, 0, 0, now.Location()),
+			}
+		}
+	}
+	return quota
+}
+
+func startOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+func validateAPIKey(key *models.APIKey) error {
+	if key.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if utf8.RuneCountInString(key.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if key.DailyQuota < 0 || key.MonthlyQuota < 0 {
+		return i18n.NewError(msgQuotaNegative, nil)
+	}
+	return nil
+}
+
+// hashAPIKey is what keys are stored and looked up by, as session tokens
+// are.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	db := setupTestDB(t)
+	svc := NewAPIKeyService(repository.NewAPIKeyRepository(db))
+
+	_, err := svc.CreateAPIKey(&models.CreateAPIKeyRequest{Name: "  "})
+	require.EqualError(t, err, "name is required")
+	_, err = svc.CreateAPIKey(&models.CreateAPIKeyRequest{Name: "POS", DailyQuota: -1})
+	require.EqualError(t, err, "quota cannot be negative")
+
+	created, err := svc.CreateAPIKey(&models.CreateAPIKeyRequest{Name: "POS", DailyQuota: 100})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(created.Key, APIKeyPrefix))
+	require.True(t, strings.HasPrefix(created.Key, created.Prefix))
+
+	// Only a hash of the key is stored.
+	var stored models.APIKey
+	require.NoError(t, db.First(&stored, created.ID).Error)
+	require.NotContains(t, stored.KeyHash, created.Key)
+
+	revoked, err := svc.RevokeAPIKey(created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	_, err = svc.Use(created.Key)
+	require.ErrorIs(t, err, ErrAPIKeyInvalid)
+	_, err = svc.RevokeAPIKey(created.ID)
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+
+	_, err = svc.Use(APIKeyPrefix + "unknown")
+	require.ErrorIs(t, err, ErrAPIKeyInvalid)
+}
+
+func TestAPIKeyService_Use(t *testing.T) {
+	db := setupTestDB(t)
+	svc := NewAPIKeyService(repository.NewAPIKeyRepository(db))
+	now := time.Date(2026, 3, 30, 15, 0, 0, 0, time.Local)
+	svc.now = func() time.Time { return now }
+
+	created, err := svc.CreateAPIKey(&models.CreateAPIKeyRequest{Name: "Partner", DailyQuota: 2, MonthlyQuota: 3})
+	require.NoError(t, err)
+
+	quota, err := svc.Use(created.Key)
+	require.NoError(t, err)
+	require.Equal(t, models.APIKeyQuota{Limit: 2, Remaining: 1, Reset: time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)}, *quota)
+	_, err = svc.Use(created.Key)
+	require.NoError(t, err)
+
+	quota, err = svc.Use(created.Key)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+	require.Equal(t, 0, quota.Remaining)
+	require.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local), quota.Reset)
+
+	// The next day only one request of the month is left, so the monthly
+	// quota is the one reported.
+	now = now.AddDate(0, 0, 1)
+	quota, err = svc.Use(created.Key)
+	require.NoError(t, err)
+	require.Equal(t, models.APIKeyQuota{Limit: 3, Remaining: 0, Reset: time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local)}, *quota)
+	_, err = svc.Use(created.Key)
+	require.ErrorIs(t, err, ErrQuotaExceeded)
+
+	now = time.Date(2026, 4, 1, 9, 0, 0, 0, time.Local)
+	_, err = svc.Use(created.Key)
+	require.NoError(t, err)
+
+	// Raising the quotas applies right away; zero is unlimited.
+	unlimited := 0
+	_, err = svc.UpdateAPIKey(created.ID, &models.UpdateAPIKeyRequest{DailyQuota: &unlimited, MonthlyQuota: &unlimited})
+	require.NoError(t, err)
+	quota, err = svc.Use(created.Key)
+	require.NoError(t, err)
+	require.Zero(t, quota.Limit)
+
+	report, err := svc.Usage(created.ID, 7)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Today)
+	require.Equal(t, 2, report.ThisMonth)
+	require.Equal(t, 5, report.Total)
+	require.Equal(t, []models.APIKeyUsage{
+		{APIKeyID: created.ID, Day: "2026-03-30", Requests: 2},
+		{APIKeyID: created.ID, Day: "2026-03-31", Requests: 1},
+		{APIKeyID: created.ID, Day: "2026-04-01", Requests: 2},
+	}, report.Days)
+
+	report, err = svc.Usage(created.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Total)
+	require.Len(t, report.Days, 1)
+
+	_, err = svc.Usage(created.ID, 0)
+	require.EqualError(t, err, "window must be between 1 and 366 days")
+	_, err = svc.Usage(999, 7)
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
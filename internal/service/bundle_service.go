@@ -0,0 +1,184 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var ErrBundleNotFound = errors.New("bundle not found")
+
+type BundleService struct {
+	repo        repository.BundleRepositoryInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+}
+
+var _ BundleServiceInterface = (*BundleService)(nil)
+
+func NewBundleService(repo repository.BundleRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface) *BundleService {
+	return &BundleService{repo: repo, cupcakeRepo: cupcakeRepo}
+}
+
+func (s *BundleService) CreateBundle(req *models.CreateBundleRequest) (*models.Bundle, error) {
+	bundle := &models.Bundle{
+		Name:        strings.TrimSpace(req.Name),
+		PriceCents:  req.PriceCents,
+		IsAvailable: true,
+	}
+	items, err := s.bundleItems(req.Items)
+	if err != nil {
+		return nil, err
+	}
+	bundle.Items = items
+
+	if err := s.validateBundle(bundle); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(bundle); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+func (s *BundleService) GetBundle(id uint) (*models.Bundle, error) {
+	bundle, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrBundleNotFound
+		}
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func (s *BundleService) GetAllBundles() ([]models.Bundle, error) {
+	return s.repo.FindAll()
+}
+
+// GetAvailableBundles is the bundle section of the public catalog.
+func (s *BundleService) GetAvailableBundles() ([]models.Bundle, error) {
+	bundles, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]models.Bundle, 0, len(bundles))
+	for _, bundle := range bundles {
+		if bundle.IsAvailable {
+			available = append(available, bundle)
+		}
+	}
+	return available, nil
+}
+
+func (s *BundleService) UpdateBundle(id uint, req *models.UpdateBundleRequest) (*models.Bundle, error) {
+	bundle, err := s.GetBundle(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		bundle.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.PriceCents != nil {
+		bundle.PriceCents = *req.PriceCents
+	}
+
+	if req.IsAvailable != nil {
+		bundle.IsAvailable = *req.IsAvailable
+	}
+
+	if req.Items != nil {
+		items, err := s.bundleItems(req.Items)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Items = items
+	}
+
+	if err := s.validateBundle(bundle); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(bundle); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+func (s *BundleService) DeleteBundle(id uint) error {
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrBundleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// bundleItems checks the requested contents: existing cupcakes, each listed
+// once with a positive quantity.
+func (s *BundleService) bundleItems(requested []models.PickupItem) ([]models.BundleItem, error) {
+	if len(requested) == 0 {
+		return nil, i18n.NewError(msgItemsRequired, nil)
+	}
+
+	items := make([]models.BundleItem, 0, len(requested))
+	seen := make(map[uint]bool, len(requested))
+	for _, item := range requested {
+		if item.Quantity <= 0 {
+			return nil, i18n.NewError(msgQuantityNotPositive, nil)
+		}
+		if seen[item.CupcakeID] {
+			return nil, i18n.NewError(msgCupcakeRepeated, map[string]any{"ID": item.CupcakeID})
+		}
+		seen[item.CupcakeID] = true
+
+		exists, err := s.cupcakeRepo.Exists(item.CupcakeID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, i18n.NewError(msgCupcakeIDNotFound, map[string]any{"ID": item.CupcakeID})
+		}
+
+		items = append(items, models.BundleItem{CupcakeID: item.CupcakeID, Quantity: item.Quantity})
+	}
+	return items, nil
+}
+
+func (s *BundleService) validateBundle(bundle *models.Bundle) error {
+	if bundle.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if len(bundle.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if bundle.PriceCents <= 0 {
+		return i18n.NewError(msgPriceNotPositive, nil)
+	}
+
+	units := 0
+	for _, item := range bundle.Items {
+		units += item.Quantity
+	}
+	if units < 2 {
+		return i18n.NewError(msgBundleTooSmall, nil)
+	}
+
+	existing, err := s.repo.FindByName(bundle.Name)
+	if err == nil && existing.ID != bundle.ID {
+		return i18n.NewError(msgBundleNameTaken, nil)
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return nil
+}
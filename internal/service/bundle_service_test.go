@@ -0,0 +1,207 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedBundleCupcakes adds Vanilla (1), Chocolate (2) and Lemon (3).
+func seedBundleCupcakes(t *testing.T, db *gorm.DB) *repository.CupcakeRepository {
+	t.Helper()
+
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate")),
+		factory.Cupcake(factory.WithName("Lemon")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+	return cupcakeRepo
+}
+
+func TestCreateBundle(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateBundleRequest
+		expectedError string
+	}{
+		{
+			name:    "success",
+			request: &models.CreateBundleRequest{Name: " Box of 6 ", PriceCents: 4500, Items: []models.PickupItem{{CupcakeID: 1, Quantity: 3}, {CupcakeID: 2, Quantity: 3}}},
+		},
+		{
+			name:          "missing name",
+			request:       &models.CreateBundleRequest{PriceCents: 4500, Items: []models.PickupItem{{CupcakeID: 1, Quantity: 6}}},
+			expectedError: "name is required",
+		},
+		{
+			name:          "free bundle",
+			request:       &models.CreateBundleRequest{Name: "Box of 6", Items: []models.PickupItem{{CupcakeID: 1, Quantity: 6}}},
+			expectedError: "price must be greater than zero",
+		},
+		{
+			name:          "no items",
+			request:       &models.CreateBundleRequest{Name: "Box of 6", PriceCents: 4500},
+			expectedError: "at least one item is required",
+		},
+		{
+			name:          "single cupcake",
+			request:       &models.CreateBundleRequest{Name: "Box of 1", PriceCents: 800, Items: []models.PickupItem{{CupcakeID: 1, Quantity: 1}}},
+			expectedError: "a bundle must contain at least two cupcakes",
+		},
+		{
+			name:          "zero quantity",
+			request:       &models.CreateBundleRequest{Name: "Box of 6", PriceCents: 4500, Items: []models.PickupItem{{CupcakeID: 1, Quantity: 0}}},
+			expectedError: "quantity must be greater than zero",
+		},
+		{
+			name:          "cupcake listed twice",
+			request:       &models.CreateBundleRequest{Name: "Box of 6", PriceCents: 4500, Items: []models.PickupItem{{CupcakeID: 1, Quantity: 3}, {CupcakeID: 1, Quantity: 3}}},
+			expectedError: "cupcake 1 is listed more than once",
+		},
+		{
+			name:          "unknown cupcake",
+			request:       &models.CreateBundleRequest{Name: "Box of 6", PriceCents: 4500, Items: []models.PickupItem{{CupcakeID: 999, Quantity: 6}}},
+			expectedError: "cupcake 999 not found",
+		},
+		{
+			name:          "duplicate name",
+			request:       &models.CreateBundleRequest{Name: "Party pack", PriceCents: 4500, Items: []models.PickupItem{{CupcakeID: 1, Quantity: 6}}},
+			expectedError: "bundle name already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			svc := NewBundleService(repository.NewBundleRepository(db), seedBundleCupcakes(t, db))
+			_, err := svc.CreateBundle(&models.CreateBundleRequest{Name: "Party pack", PriceCents: 9000, Items: []models.PickupItem{{CupcakeID: 3, Quantity: 12}}})
+			require.NoError(t, err)
+
+			bundle, err := svc.CreateBundle(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, bundle.ID)
+			require.True(t, bundle.IsAvailable)
+			require.Equal(t, "Box of 6", bundle.Name)
+			require.Len(t, bundle.Items, len(tt.request.Items))
+		})
+	}
+}
+
+func TestUpdateBundle(t *testing.T) {
+	db := setupTestDB(t)
+	svc := NewBundleService(repository.NewBundleRepository(db), seedBundleCupcakes(t, db))
+	bundle, err := svc.CreateBundle(&models.CreateBundleRequest{Name: "Box of 6", PriceCents: 4500, Items: []models.PickupItem{{CupcakeID: 1, Quantity: 3}, {CupcakeID: 2, Quantity: 3}}})
+	require.NoError(t, err)
+
+	updated, err := svc.UpdateBundle(bundle.ID, &models.UpdateBundleRequest{Items: []models.PickupItem{{CupcakeID: 3, Quantity: 6}}})
+	require.NoError(t, err)
+	require.Equal(t, 4500, updated.PriceCents)
+
+	stored, err := svc.GetBundle(bundle.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Items, 1)
+	require.Equal(t, uint(3), stored.Items[0].CupcakeID)
+	require.Equal(t, 6, stored.Items[0].Quantity)
+
+	_, err = svc.UpdateBundle(bundle.ID, &models.UpdateBundleRequest{IsAvailable: boolPtr(false)})
+	require.NoError(t, err)
+	available, err := svc.GetAvailableBundles()
+	require.NoError(t, err)
+	require.Empty(t, available)
+	all, err := svc.GetAllBundles()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+
+	_, err = svc.UpdateBundle(999, &models.UpdateBundleRequest{})
+	require.ErrorIs(t, err, ErrBundleNotFound)
+	require.NoError(t, svc.DeleteBundle(bundle.ID))
+	require.ErrorIs(t, svc.DeleteBundle(bundle.ID), ErrBundleNotFound)
+}
+
+func TestCheckPickupWithBundles(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.PickupCheckRequest
+		expected      []models.PickupItem
+		expectedError string
+	}{
+		{
+			name:     "bundle expanded into its cupcakes",
+			request:  &models.PickupCheckRequest{Bundles: []models.PickupBundle{{BundleID: 1, Quantity: 1}}},
+			expected: []models.PickupItem{{CupcakeID: 1, Quantity: 2}, {CupcakeID: 2, Quantity: 2}},
+		},
+		{
+			name: "bundle and loose cupcakes merged",
+			request: &models.PickupCheckRequest{
+				Items:   []models.PickupItem{{CupcakeID: 2, Quantity: 1}},
+				Bundles: []models.PickupBundle{{BundleID: 1, Quantity: 2}},
+			},
+			expected: []models.PickupItem{{CupcakeID: 2, Quantity: 5}, {CupcakeID: 1, Quantity: 4}},
+		},
+		{
+			name: "bundle pushes a cupcake past the stock",
+			request: &models.PickupCheckRequest{
+				Items:   []models.PickupItem{{CupcakeID: 1, Quantity: 3}},
+				Bundles: []models.PickupBundle{{BundleID: 1, Quantity: 1}},
+			},
+			expectedError: "only 4 of Vanilla available at this location",
+		},
+		{
+			name:          "unavailable bundle",
+			request:       &models.PickupCheckRequest{Bundles: []models.PickupBundle{{BundleID: 2, Quantity: 1}}},
+			expectedError: "Lemon duo is not available",
+		},
+		{
+			name:          "unknown bundle",
+			request:       &models.PickupCheckRequest{Bundles: []models.PickupBundle{{BundleID: 999, Quantity: 1}}},
+			expectedError: "bundle 999 not found",
+		},
+		{
+			name:          "zero bundles",
+			request:       &models.PickupCheckRequest{Bundles: []models.PickupBundle{{BundleID: 1, Quantity: 0}}},
+			expectedError: "quantity must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			cupcakeRepo := seedBundleCupcakes(t, db)
+			bundleRepo := repository.NewBundleRepository(db)
+			bundles := NewBundleService(bundleRepo, cupcakeRepo)
+			_, err := bundles.CreateBundle(&models.CreateBundleRequest{Name: "Duo", PriceCents: 3000, Items: []models.PickupItem{{CupcakeID: 1, Quantity: 2}, {CupcakeID: 2, Quantity: 2}}})
+			require.NoError(t, err)
+			_, err = bundles.CreateBundle(&models.CreateBundleRequest{Name: "Lemon duo", PriceCents: 1500, Items: []models.PickupItem{{CupcakeID: 3, Quantity: 2}}})
+			require.NoError(t, err)
+			_, err = bundles.UpdateBundle(2, &models.UpdateBundleRequest{IsAvailable: boolPtr(false)})
+			require.NoError(t, err)
+
+			svc := NewLocationService(repository.NewLocationRepository(db), cupcakeRepo, bundleRepo)
+			_, err = svc.CreateLocation(&models.CreateLocationRequest{Name: "Centro", Address: "Rua Augusta, 100"})
+			require.NoError(t, err)
+			for cupcakeID, quantity := range map[uint]int{1: 4, 2: 10, 3: 10} {
+				_, err = svc.SetStock(1, cupcakeID, &models.SetStockRequest{Quantity: intPtr(quantity)})
+				require.NoError(t, err)
+			}
+
+			basket, err := svc.CheckPickup(1, tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, basket)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package service
+
+import "context"
+
+// CaptchaVerifier checks the token a captcha widget, such as Cloudflare
+// Turnstile, gave a client for proving it is not a bot.
+type CaptchaVerifier interface {
+	// Verify reports whether token is genuine and unused; remoteIP, when
+	// known, lets the provider match it to the client that solved it.
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
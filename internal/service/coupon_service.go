@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/money"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+type CouponService struct {
+	repo repository.CouponRepositoryInterface
+	uow  repository.UnitOfWork
+	now  func() time.Time
+}
+
+var _ CouponServiceInterface = (*CouponService)(nil)
+
+func NewCouponService(repo repository.CouponRepositoryInterface, uow repository.UnitOfWork) *CouponService {
+	return &CouponService{repo: repo, uow: uow, now: time.Now}
+}
+
+func (s *CouponService) CreateCoupon(req *models.CreateCouponRequest) (*models.Coupon, error) {
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	code := normalizeCouponCode(req.Code)
+	if _, err := s.repo.FindByCode(code); err == nil {
+		return nil, i18n.NewError(msgCouponCodeTaken, nil)
+	}
+
+	coupon := &models.Coupon{
+		Code:           code,
+		DiscountType:   req.DiscountType,
+		DiscountValue:  req.DiscountValue,
+		MinOrderCents:  req.MinOrderCents,
+		MaxRedemptions: req.MaxRedemptions,
+		ExpiresAt:      req.ExpiresAt,
+		IsActive:       true,
+	}
+
+	if err := s.repo.Create(coupon); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+func (s *CouponService) GetCoupon(id uint) (*models.Coupon, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *CouponService) GetAllCoupons() ([]models.Coupon, error) {
+	return s.repo.FindAll()
+}
+
+func (s *CouponService) UpdateCoupon(id uint, req *models.UpdateCouponRequest) (*models.Coupon, error) {
+	coupon, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DiscountType != nil {
+		coupon.DiscountType = *req.DiscountType
+	}
+
+	if req.DiscountValue != nil {
+		coupon.DiscountValue = *req.DiscountValue
+	}
+
+	if req.MinOrderCents != nil {
+		if *req.MinOrderCents < 0 {
+			return nil, i18n.NewError(msgMinimumOrderNegative, nil)
+		}
+		coupon.MinOrderCents = *req.MinOrderCents
+	}
+
+	if req.MaxRedemptions != nil {
+		if *req.MaxRedemptions < 0 {
+			return nil, i18n.NewError(msgMaxRedemptionsNegative, nil)
+		}
+		coupon.MaxRedemptions = *req.MaxRedemptions
+	}
+
+	if req.ExpiresAt != nil {
+		coupon.ExpiresAt = req.ExpiresAt
+	}
+
+	if req.IsActive != nil {
+		coupon.IsActive = *req.IsActive
+	}
+
+	if err := validateDiscount(coupon.DiscountType, coupon.DiscountValue); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(coupon); err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+func (s *CouponService) DeleteCoupon(id uint) error {
+	return s.repo.Delete(id)
+}
+
+// ApplyCoupon redeems a coupon for an order. The coupon is checked and
+// redeemed in one transaction, so it cannot be deactivated or changed in
+// between.
+//
+// No route calls it yet: pickup reservations carry no totals to discount.
+func (s *CouponService) ApplyCoupon(req *models.ApplyCouponRequest) (*models.CouponRedemption, error) {
+	if req.OrderCents <= 0 {
+		return nil, i18n.NewError(msgOrderTotalNotPositive, nil)
+	}
+
+	var redemption *models.CouponRedemption
+	err := s.uow.WithTransaction(context.Background(), func(tx repository.Repositories) error {
+		coupon, err := tx.Coupons.FindByCode(normalizeCouponCode(req.Code))
+		if err != nil {
+			return i18n.NewError(msgCouponNotFound, nil)
+		}
+
+		if !coupon.IsActive {
+			return i18n.NewError(msgCouponInactive, nil)
+		}
+
+		if coupon.ExpiresAt != nil && !s.now().Before(*coupon.ExpiresAt) {
+			return i18n.NewError(msgCouponExpired, nil)
+		}
+
+		if req.OrderCents < coupon.MinOrderCents {
+			return i18n.NewError(msgOrderBelowCouponMinimum, nil)
+		}
+
+		discount := calculateDiscount(coupon.DiscountType, coupon.DiscountValue, money.Cents(req.OrderCents))
+		redemption = &models.CouponRedemption{
+			CouponID:      coupon.ID,
+			OrderCents:    req.OrderCents,
+			DiscountCents: int(discount.Amount),
+		}
+		return tx.Coupons.Redeem(redemption)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+func (s *CouponService) ExpireCoupons() (int64, error) {
+	return s.repo.DeactivateExpired(s.now())
+}
+
+func (s *CouponService) validateCreateRequest(req *models.CreateCouponRequest) error {
+	if strings.TrimSpace(req.Code) == "" {
+		return i18n.NewError(msgCouponCodeRequired, nil)
+	}
+
+	if req.MinOrderCents < 0 {
+		return i18n.NewError(msgMinimumOrderNegative, nil)
+	}
+
+	if req.MaxRedemptions < 0 {
+		return i18n.NewError(msgMaxRedemptionsNegative, nil)
+	}
+
+	return validateDiscount(req.DiscountType, req.DiscountValue)
+}
+
+func validateDiscount(discountType string, value int) error {
+	switch discountType {
+	case models.DiscountTypePercentage:
+		if value <= 0 || value > 100 {
+			return i18n.NewError(msgPercentageDiscountRange, nil)
+		}
+	case models.DiscountTypeFixed:
+		if value <= 0 {
+			return i18n.NewError(msgFixedDiscountNotPositive, nil)
+		}
+	default:
+		return i18n.NewError(msgDiscountTypeInvalid, nil)
+	}
+	return nil
+}
+
+// calculateDiscount returns the discount on amount, never more than amount
+// itself. validateDiscount caps percentages at 100, so the percentage
+// cannot overflow.
+func calculateDiscount(discountType string, value int, amount money.Money) money.Money {
+	discount := money.Money{Amount: int64(value), Currency: amount.Currency}
+	if discountType == models.DiscountTypePercentage {
+		discount, _ = amount.Percent(int64(value))
+	}
+	if discount.Amount > amount.Amount {
+		discount = amount
+	}
+	return discount
+}
+
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCouponService(t *testing.T) *CouponService {
+	t.Helper()
+
+	db := setupTestDB(t)
+	repo := repository.NewCouponRepository(db)
+	return NewCouponService(repo, repository.NewUnitOfWork(db))
+}
+
+func TestCreateCoupon(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          *models.CreateCouponRequest
+		expectedError    string
+		validateResponse func(t *testing.T, coupon *models.Coupon)
+	}{
+		{
+			name: "success with normalized code",
+			request: &models.CreateCouponRequest{
+				Code:          " sweet10 ",
+				DiscountType:  models.DiscountTypePercentage,
+				DiscountValue: 10,
+			},
+			validateResponse: func(t *testing.T, coupon *models.Coupon) {
+				require.Greater(t, coupon.ID, uint(0))
+				require.Equal(t, "SWEET10", coupon.Code)
+				require.True(t, coupon.IsActive)
+			},
+		},
+		{
+			name: "validation error - empty code",
+			request: &models.CreateCouponRequest{
+				DiscountType:  models.DiscountTypeFixed,
+				DiscountValue: 500,
+			},
+			expectedError: "code is required",
+		},
+		{
+			name: "validation error - unknown discount type",
+			request: &models.CreateCouponRequest{
+				Code:          "BOGUS",
+				DiscountType:  "bogus",
+				DiscountValue: 5,
+			},
+			expectedError: "discount type must be percentage or fixed",
+		},
+		{
+			name: "validation error - percentage above 100",
+			request: &models.CreateCouponRequest{
+				Code:          "TOOMUCH",
+				DiscountType:  models.DiscountTypePercentage,
+				DiscountValue: 150,
+			},
+			expectedError: "percentage discount must be between 1 and 100",
+		},
+		{
+			name: "validation error - negative minimum order",
+			request: &models.CreateCouponRequest{
+				Code:          "NEGATIVE",
+				DiscountType:  models.DiscountTypeFixed,
+				DiscountValue: 100,
+				MinOrderCents: -1,
+			},
+			expectedError: "minimum order cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestCouponService(t)
+
+			coupon, err := service.CreateCoupon(tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, coupon)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				if tt.validateResponse != nil {
+					tt.validateResponse(t, coupon)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateCoupon_DuplicateCode(t *testing.T) {
+	service := newTestCouponService(t)
+
+	request := &models.CreateCouponRequest{
+		Code:          "SWEET10",
+		DiscountType:  models.DiscountTypePercentage,
+		DiscountValue: 10,
+	}
+
+	_, err := service.CreateCoupon(request)
+	require.NoError(t, err)
+
+	_, err = service.CreateCoupon(request)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "coupon code already exists")
+}
+
+func TestUpdateCoupon(t *testing.T) {
+	tests := []struct {
+		name             string
+		updateRequest    *models.UpdateCouponRequest
+		expectedError    string
+		validateResponse func(t *testing.T, coupon *models.Coupon)
+	}{
+		{
+			name: "success - deactivate coupon",
+			updateRequest: &models.UpdateCouponRequest{
+				IsActive: boolPtr(false),
+			},
+			validateResponse: func(t *testing.T, coupon *models.Coupon) {
+				require.False(t, coupon.IsActive)
+			},
+		},
+		{
+			name: "success - switch to fixed discount",
+			updateRequest: &models.UpdateCouponRequest{
+				DiscountType:  stringPtr(models.DiscountTypeFixed),
+				DiscountValue: intPtr(300),
+			},
+			validateResponse: func(t *testing.T, coupon *models.Coupon) {
+				require.Equal(t, models.DiscountTypeFixed, coupon.DiscountType)
+				require.Equal(t, 300, coupon.DiscountValue)
+			},
+		},
+		{
+			name: "validation error - percentage out of range",
+			updateRequest: &models.UpdateCouponRequest{
+				DiscountValue: intPtr(101),
+			},
+			expectedError: "percentage discount must be between 1 and 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestCouponService(t)
+
+			created, err := service.CreateCoupon(&models.CreateCouponRequest{
+				Code:          "SWEET10",
+				DiscountType:  models.DiscountTypePercentage,
+				DiscountValue: 10,
+			})
+			require.NoError(t, err)
+
+			coupon, err := service.UpdateCoupon(created.ID, tt.updateRequest)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, coupon)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				if tt.validateResponse != nil {
+					tt.validateResponse(t, coupon)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyCoupon(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name             string
+		coupon           *models.CreateCouponRequest
+		deactivate       bool
+		request          *models.ApplyCouponRequest
+		expectedError    string
+		expectedDiscount int
+	}{
+		{
+			name: "percentage discount",
+			coupon: &models.CreateCouponRequest{
+				Code:          "SWEET10",
+				DiscountType:  models.DiscountTypePercentage,
+				DiscountValue: 10,
+			},
+			request:          &models.ApplyCouponRequest{Code: "sweet10", OrderCents: 2500},
+			expectedDiscount: 250,
+		},
+		{
+			name: "fixed discount capped at order total",
+			coupon: &models.CreateCouponRequest{
+				Code:          "FIVEOFF",
+				DiscountType:  models.DiscountTypeFixed,
+				DiscountValue: 500,
+			},
+			request:          &models.ApplyCouponRequest{Code: "FIVEOFF", OrderCents: 300},
+			expectedDiscount: 300,
+		},
+		{
+			name:          "unknown coupon",
+			request:       &models.ApplyCouponRequest{Code: "NOPE", OrderCents: 1000},
+			expectedError: "coupon not found",
+		},
+		{
+			name: "inactive coupon",
+			coupon: &models.CreateCouponRequest{
+				Code:          "OFF",
+				DiscountType:  models.DiscountTypeFixed,
+				DiscountValue: 100,
+			},
+			deactivate:    true,
+			request:       &models.ApplyCouponRequest{Code: "OFF", OrderCents: 1000},
+			expectedError: "coupon is not active",
+		},
+		{
+			name: "expired coupon",
+			coupon: &models.CreateCouponRequest{
+				Code:          "OLD",
+				DiscountType:  models.DiscountTypeFixed,
+				DiscountValue: 100,
+				ExpiresAt:     &past,
+			},
+			request:       &models.ApplyCouponRequest{Code: "OLD", OrderCents: 1000},
+			expectedError: "coupon has expired",
+		},
+		{
+			name: "order below minimum",
+			coupon: &models.CreateCouponRequest{
+				Code:          "BIGORDER",
+				DiscountType:  models.DiscountTypeFixed,
+				DiscountValue: 100,
+				MinOrderCents: 5000,
+			},
+			request:       &models.ApplyCouponRequest{Code: "BIGORDER", OrderCents: 1000},
+			expectedError: "order total is below the coupon minimum",
+		},
+		{
+			name: "usage limit reached",
+			coupon: &models.CreateCouponRequest{
+				Code:           "ONCE",
+				DiscountType:   models.DiscountTypeFixed,
+				DiscountValue:  100,
+				MaxRedemptions: 1,
+			},
+			request:       &models.ApplyCouponRequest{Code: "ONCE", OrderCents: 1000},
+			expectedError: "coupon usage limit reached",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestCouponService(t)
+
+			if tt.coupon != nil {
+				created, err := service.CreateCoupon(tt.coupon)
+				require.NoError(t, err)
+
+				if tt.deactivate {
+					_, err = service.UpdateCoupon(created.ID, &models.UpdateCouponRequest{IsActive: boolPtr(false)})
+					require.NoError(t, err)
+				}
+
+				if tt.coupon.MaxRedemptions > 0 {
+					for i := 0; i < tt.coupon.MaxRedemptions; i++ {
+						_, err = service.ApplyCoupon(tt.request)
+						require.NoError(t, err)
+					}
+				}
+			}
+
+			redemption, err := service.ApplyCoupon(tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, redemption)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Greater(t, redemption.ID, uint(0))
+				require.Equal(t, tt.expectedDiscount, redemption.DiscountCents)
+			}
+		})
+	}
+}
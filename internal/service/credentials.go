@@ -0,0 +1,72 @@
+package service
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/password"
+)
+
+const (
+	// PasswordMinLength and PasswordMaxLength bound passwords in
+	// characters. The maximum keeps hashing a request body cheap.
+	PasswordMinLength = 8
+	PasswordMaxLength = 128
+)
+
+// credentials hashes passwords with argon2id under params and checks them
+// for the account and admin logins.
+type credentials struct {
+	params password.Params
+
+	// dummyHash is checked against when the login is unknown, so such
+	// logins take as long as the others.
+	dummyOnce sync.Once
+	dummyHash string
+}
+
+// newCredentials uses password.DefaultParams when params are zero.
+func newCredentials(params password.Params) *credentials {
+	if params == (password.Params{}) {
+		params = password.DefaultParams
+	}
+	return &credentials{params: params}
+}
+
+func (c *credentials) hash(plain string) (string, error) {
+	return password.Hash(plain, c.params)
+}
+
+// check reports whether plain matches hash, and whether hash was made with
+// other params and should be replaced while plain is at hand.
+func (c *credentials) check(plain, hash string) (ok, rehash bool, err error) {
+	ok, err = password.Verify(plain, hash)
+	if err != nil || !ok {
+		return false, false, err
+	}
+	return true, password.NeedsRehash(hash, c.params), nil
+}
+
+// checkUnknown spends the time check would for a login that does not
+// exist.
+func (c *credentials) checkUnknown(plain string) {
+	c.dummyOnce.Do(func() {
+		c.dummyHash, _ = password.Hash("dummy password", c.params)
+	})
+	password.Verify(plain, c.dummyHash)
+}
+
+func validatePassword(plain string) error {
+	if plain == "" {
+		return i18n.NewError(msgPasswordRequired, nil)
+	}
+	length := utf8.RuneCountInString(plain)
+	if length < PasswordMinLength {
+		return i18n.NewError(msgPasswordTooShort, map[string]any{"Min": PasswordMinLength})
+	}
+	if length > PasswordMaxLength {
+		return i18n.NewError(msgPasswordTooLong, map[string]any{"Max": PasswordMaxLength})
+	}
+	return nil
+}
@@ -1,37 +1,137 @@
 package service
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/julimonteiro/cupcake-store/internal/currency"
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
 	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/money"
 	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/text/unicode/norm"
 )
 
+var skuPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{2,63}$`)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+const maxSlugLength = 100
+
+// descriptionPolicy keeps the formatting tags of user-generated content and
+// strips scripts, styles, event handlers and unsafe URLs from descriptions.
+var descriptionPolicy = bluemonday.UGCPolicy()
+
+const maxDescriptionLength = 2000
+
+var nameNumbers = regexp.MustCompile(`[0-9]+`)
+
+const maxPerPage = 100
+
+const (
+	DefaultRelatedLimit = 4
+	maxRelated          = 20
+)
+
+var ErrVersionNotFound = errors.New("version not found")
+
+// duplicateThreshold is the name similarity, from 0 to 1, from which an
+// existing cupcake is reported as a likely duplicate of a new one.
+const duplicateThreshold = 0.8
+
+// DuplicateCupcakeError lists the existing cupcakes whose names are close to
+// the one being created, most similar first.
+type DuplicateCupcakeError struct {
+	Matches []models.Cupcake
+}
+
+func (e *DuplicateCupcakeError) Error() string {
+	return "cupcakes with a similar name already exist"
+}
+
 type CupcakeService struct {
-	repo repository.CupcakeRepositoryInterface
+	repo          repository.CupcakeRepositoryInterface
+	promotionRepo repository.PromotionRepositoryInterface
+	locationRepo  repository.LocationRepositoryInterface
+	events        EventPublisher
+	converter     *currency.Converter
+	translations  *TranslationService
+	rules         *ValidationService
+	uow           repository.UnitOfWork
+	now           func() time.Time
 }
 
-func NewCupcakeService(repo repository.CupcakeRepositoryInterface) *CupcakeService {
-	return &CupcakeService{repo: repo}
+var _ CupcakeServiceInterface = (*CupcakeService)(nil)
+
+func NewCupcakeService(repo repository.CupcakeRepositoryInterface, promotionRepo repository.PromotionRepositoryInterface, locationRepo repository.LocationRepositoryInterface, events EventPublisher, converter *currency.Converter, translations *TranslationService, rules *ValidationService) *CupcakeService {
+	if rules == nil {
+		rules = DefaultValidationService()
+	}
+	return &CupcakeService{repo: repo, promotionRepo: promotionRepo, locationRepo: locationRepo, events: events, converter: converter, translations: translations, rules: rules, now: time.Now}
+}
+
+// WithUnitOfWork runs the multi-step catalog changes, such as bulk price
+// updates, in transactions of uow. Without one they run on the repository
+// as they go.
+func (s *CupcakeService) WithUnitOfWork(uow repository.UnitOfWork) *CupcakeService {
+	s.uow = uow
+	return s
 }
 
 func (s *CupcakeService) CreateCupcake(req *models.CreateCupcakeRequest) (*models.Cupcake, error) {
-	if err := s.validateCreateRequest(req); err != nil {
+	var errs fieldErrors
+	s.validateCreateRequest(&errs, req)
+	cupcake := &models.Cupcake{
+		Name:          strings.TrimSpace(req.Name),
+		Flavor:        strings.TrimSpace(req.Flavor),
+		Description:   sanitizeDescription(&errs, req.Description),
+		DisplayOrder:  req.DisplayOrder,
+		PriceCents:    req.PriceCents,
+		IsAvailable:   true,
+		AvailableFrom: req.AvailableFrom,
+		CreatedBy:     req.CreatedBy,
+		UpdatedBy:     req.CreatedBy,
+	}
+	if req.SKU != nil {
+		cupcake.SKU = s.checkSKU(&errs, *req.SKU, 0)
+	}
+	if req.Slug != nil {
+		cupcake.Slug = s.checkSlug(&errs, *req.Slug, 0)
+	}
+	if err := errs.err(); err != nil {
 		return nil, err
 	}
 
-	cupcake := &models.Cupcake{
-		Name:        strings.TrimSpace(req.Name),
-		Flavor:      strings.TrimSpace(req.Flavor),
-		PriceCents:  req.PriceCents,
-		IsAvailable: true,
+	if !req.Force {
+		if err := s.checkDuplicates(req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Slug == nil {
+		var err error
+		if cupcake.Slug, err = s.generateSlug(cupcake.Name); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.repo.Create(cupcake); err != nil {
 		return nil, err
 	}
 
+	cupcake.PreOrder = cupcake.IsPreOrder(s.now())
+	s.publish(models.EventCupcakeCreated, models.NewCupcakeResponse(cupcake))
 	return cupcake, nil
 }
 
@@ -40,25 +140,272 @@ func (s *CupcakeService) GetCupcake(id uint) (*models.Cupcake, error) {
 	if err != nil {
 		return nil, err
 	}
-	return cupcake, nil
+
+	cupcakes := []models.Cupcake{*cupcake}
+	if err := s.decorate(cupcakes); err != nil {
+		return nil, err
+	}
+	return &cupcakes[0], nil
+}
+
+func (s *CupcakeService) GetCupcakeBySKU(sku string) (*models.Cupcake, error) {
+	cupcake, err := s.repo.FindBySKU(normalizeSKU(sku))
+	if err != nil {
+		return nil, err
+	}
+
+	cupcakes := []models.Cupcake{*cupcake}
+	if err := s.decorate(cupcakes); err != nil {
+		return nil, err
+	}
+	return &cupcakes[0], nil
+}
+
+func (s *CupcakeService) GetCupcakeBySlug(slug string) (*models.Cupcake, error) {
+	cupcake, err := s.repo.FindBySlug(normalizeSlug(slug))
+	if err != nil {
+		return nil, err
+	}
+
+	cupcakes := []models.Cupcake{*cupcake}
+	if err := s.decorate(cupcakes); err != nil {
+		return nil, err
+	}
+	return &cupcakes[0], nil
+}
+
+// GetRelatedCupcakes returns up to limit other available cupcakes sharing
+// the flavor of the cupcake id, for "you may also like" suggestions.
+func (s *CupcakeService) GetRelatedCupcakes(id uint, limit int) ([]models.Cupcake, error) {
+	if limit < 1 || limit > maxRelated {
+		return nil, i18n.NewError(msgLimitOutOfRange, map[string]any{"Max": maxRelated})
+	}
+
+	cupcakes, err := s.repo.FindRelated(id, limit)
+	if err != nil {
+		return nil, err
+	}
+	// No match is also what an unknown ID yields; only then is the extra
+	// lookup needed.
+	if len(cupcakes) == 0 {
+		exists, err := s.repo.Exists(id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, repository.ErrNotFound
+		}
+	}
+
+	if err := s.decorate(cupcakes); err != nil {
+		return nil, err
+	}
+	return cupcakes, nil
 }
 
 func (s *CupcakeService) GetAllCupcakes() ([]models.Cupcake, error) {
-	return s.repo.FindAll()
+	cupcakes, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.decorate(cupcakes); err != nil {
+		return nil, err
+	}
+	return cupcakes, nil
+}
+
+// ListCupcakes returns one page of the catalog, starting at page 1, and the
+// total number of cupcakes.
+func (s *CupcakeService) ListCupcakes(page, perPage int) ([]models.Cupcake, int64, error) {
+	if err := validatePage(page, perPage); err != nil {
+		return nil, 0, err
+	}
+
+	cupcakes, total, err := s.repo.FindPage((page-1)*perPage, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.decorate(cupcakes); err != nil {
+		return nil, 0, err
+	}
+	return cupcakes, total, nil
+}
+
+// GetCupcakesAtLocation returns the cupcakes the location can sell right
+// now: available in the catalog, released and in stock there.
+func (s *CupcakeService) GetCupcakesAtLocation(locationID uint) ([]models.Cupcake, error) {
+	if s.locationRepo == nil {
+		return nil, i18n.NewError(msgLocationsNotConfigured, nil)
+	}
+	if _, err := findLocation(s.locationRepo, locationID); err != nil {
+		return nil, err
+	}
+
+	stock, err := s.locationRepo.FindStock(locationID)
+	if err != nil {
+		return nil, err
+	}
+	inStock := make(map[uint]bool, len(stock))
+	for _, entry := range stock {
+		inStock[entry.CupcakeID] = entry.Quantity > 0
+	}
+
+	all, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	cupcakes := make([]models.Cupcake, 0, len(stock))
+	for _, cupcake := range all {
+		if cupcake.IsAvailable && !cupcake.IsPreOrder(now) && inStock[cupcake.ID] {
+			cupcakes = append(cupcakes, cupcake)
+		}
+	}
+	sort.Slice(cupcakes, func(i, j int) bool { return cupcakes[i].ID < cupcakes[j].ID })
+
+	if err := s.decorate(cupcakes); err != nil {
+		return nil, err
+	}
+	return cupcakes, nil
+}
+
+// ListCupcakesAtLocation is ListCupcakes restricted to what the location can
+// sell. A location stocks a small part of the catalog, so the filtering is
+// done in memory.
+func (s *CupcakeService) ListCupcakesAtLocation(locationID uint, page, perPage int) ([]models.Cupcake, int64, error) {
+	if err := validatePage(page, perPage); err != nil {
+		return nil, 0, err
+	}
+
+	cupcakes, err := s.GetCupcakesAtLocation(locationID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total := int64(len(cupcakes))
+	start := min((page-1)*perPage, len(cupcakes))
+	end := min(start+perPage, len(cupcakes))
+	return cupcakes[start:end], total, nil
+}
+
+// decorate fills in the fields computed at read time: the promotional price
+// and whether the cupcake is still a pre-order.
+func (s *CupcakeService) decorate(cupcakes []models.Cupcake) error {
+	now := s.now()
+	if err := applyPromotions(s.promotionRepo, cupcakes, now); err != nil {
+		return err
+	}
+	for i := range cupcakes {
+		cupcakes[i].PreOrder = cupcakes[i].IsPreOrder(now)
+	}
+	return nil
+}
+
+func validatePage(page, perPage int) error {
+	if page < 1 {
+		return i18n.NewError(msgPageOutOfRange, nil)
+	}
+	if perPage < 1 || perPage > maxPerPage {
+		return i18n.NewError(msgPerPageOutOfRange, map[string]any{"Max": maxPerPage})
+	}
+	return nil
+}
+
+// Localize translates the cupcakes for acceptLanguage and returns the
+// content language. Without translations configured the content is left
+// as is and the language is unknown.
+func (s *CupcakeService) Localize(cupcakes []models.Cupcake, acceptLanguage string) (string, error) {
+	if s.translations == nil {
+		return "", nil
+	}
+	return s.translations.Localize(cupcakes, acceptLanguage)
+}
+
+// StreamCupcakes calls fn for every cupcake with promotions applied and
+// prices converted to code, without loading the whole catalog at once. An
+// unsupported currency is reported before fn is first called.
+func (s *CupcakeService) StreamCupcakes(code string, fn func(*models.Cupcake) error) error {
+	// Validate the currency up front so callers can still answer with an
+	// error status before writing any rows.
+	if err := s.ConvertPrices([]models.Cupcake{{}}, code); err != nil {
+		return err
+	}
+
+	now := s.now()
+	promotions, err := s.promotionRepo.FindAllActive(now)
+	if err != nil {
+		return err
+	}
+	byCupcake := make(map[uint][]models.Promotion)
+	for _, promotion := range promotions {
+		byCupcake[promotion.CupcakeID] = append(byCupcake[promotion.CupcakeID], promotion)
+	}
+
+	return s.repo.Stream(func(cupcake *models.Cupcake) error {
+		for _, promotion := range byCupcake[cupcake.ID] {
+			applyPromotion(cupcake, promotion)
+		}
+		cupcake.PreOrder = cupcake.IsPreOrder(now)
+
+		converted := []models.Cupcake{*cupcake}
+		if err := s.ConvertPrices(converted, code); err != nil {
+			return err
+		}
+		return fn(&converted[0])
+	})
+}
+
+// ConvertPrices rewrites prices into the requested currency, or the base
+// currency when code is empty.
+func (s *CupcakeService) ConvertPrices(cupcakes []models.Cupcake, code string) error {
+	if s.converter == nil {
+		if code == "" {
+			return nil
+		}
+		return &currency.UnsupportedError{Code: currency.Normalize(code)}
+	}
+
+	if code == "" {
+		code = s.converter.Default()
+	}
+	code = currency.Normalize(code)
+
+	for i := range cupcakes {
+		price, err := s.converter.Convert(cupcakes[i].PriceCents, code)
+		if err != nil {
+			return err
+		}
+		cupcakes[i].PriceCents = price
+
+		if cupcakes[i].EffectivePriceCents != nil {
+			effective, err := s.converter.Convert(*cupcakes[i].EffectivePriceCents, code)
+			if err != nil {
+				return err
+			}
+			cupcakes[i].EffectivePriceCents = &effective
+		}
+
+		cupcakes[i].Currency = code
+	}
+	return nil
 }
 
 func (s *CupcakeService) UpdateCupcake(id uint, req *models.UpdateCupcakeRequest) (*models.Cupcake, error) {
+	if err := s.mustExist(id); err != nil {
+		return nil, err
+	}
+
 	cupcake, err := s.repo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	var errs fieldErrors
 	if req.Name != nil {
-		name := strings.TrimSpace(*req.Name)
-		if len(name) < 2 {
-			return nil, errors.New("name must have at least 2 characters")
-		}
-		cupcake.Name = name
+		cupcake.Name = strings.TrimSpace(*req.Name)
+		s.checkName(&errs, cupcake.Name)
 	}
 
 	if req.Flavor != nil {
@@ -66,43 +413,537 @@ func (s *CupcakeService) UpdateCupcake(id uint, req *models.UpdateCupcakeRequest
 	}
 
 	if req.PriceCents != nil {
-		if *req.PriceCents <= 0 {
-			return nil, errors.New("price must be greater than zero")
-		}
 		cupcake.PriceCents = *req.PriceCents
+		s.checkPrice(&errs, cupcake.PriceCents)
 	}
 
 	if req.IsAvailable != nil {
 		cupcake.IsAvailable = *req.IsAvailable
 	}
 
+	if req.SKU != nil {
+		cupcake.SKU = s.checkSKU(&errs, *req.SKU, cupcake.ID)
+	}
+
+	if req.Slug != nil {
+		cupcake.Slug = s.checkSlug(&errs, *req.Slug, cupcake.ID)
+	}
+
+	if req.Description != nil {
+		cupcake.Description = sanitizeDescription(&errs, *req.Description)
+	}
+
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	if req.DisplayOrder != nil {
+		cupcake.DisplayOrder = *req.DisplayOrder
+	}
+
+	if req.AvailableFrom != nil {
+		cupcake.AvailableFrom = req.AvailableFrom
+	}
+	cupcake.UpdatedBy = req.UpdatedBy
+
 	if err := s.repo.Update(cupcake); err != nil {
 		return nil, err
 	}
 
+	cupcake.PreOrder = cupcake.IsPreOrder(s.now())
+	s.publish(models.EventCupcakeUpdated, models.NewCupcakeResponse(cupcake))
+	return cupcake, nil
+}
+
+// PatchCupcake applies a JSON Patch to the cupcake. The operations are
+// folded into a single update request, so they go through the same rules
+// as UpdateCupcake and are applied all or nothing.
+func (s *CupcakeService) PatchCupcake(id uint, ops []models.PatchOperation, updatedBy *uint) (*models.Cupcake, error) {
+	if len(ops) == 0 {
+		return nil, i18n.NewError(msgPatchRequired, nil)
+	}
+
+	req := models.UpdateCupcakeRequest{UpdatedBy: updatedBy}
+	for _, op := range ops {
+		if err := applyPatch(&req, op); err != nil {
+			return nil, err
+		}
+	}
+	return s.UpdateCupcake(id, &req)
+}
+
+// patchField points a JSON Patch path at its UpdateCupcakeRequest field.
+// clear is nil for fields a cupcake cannot do without.
+type patchField struct {
+	value func(req *models.UpdateCupcakeRequest) interface{}
+	clear func(req *models.UpdateCupcakeRequest)
+}
+
+var patchFields = map[string]patchField{
+	"name":           {value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.Name }},
+	"flavor":         {value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.Flavor }},
+	"price_cents":    {value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.PriceCents }},
+	"is_available":   {value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.IsAvailable }},
+	"display_order":  {value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.DisplayOrder }},
+	"available_from": {value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.AvailableFrom }},
+	"sku": {
+		value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.SKU },
+		clear: func(req *models.UpdateCupcakeRequest) { req.SKU = new(string) },
+	},
+	"slug": {
+		value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.Slug },
+		clear: func(req *models.UpdateCupcakeRequest) { req.Slug = new(string) },
+	},
+	"description": {
+		value: func(req *models.UpdateCupcakeRequest) interface{} { return &req.Description },
+		clear: func(req *models.UpdateCupcakeRequest) { req.Description = new(string) },
+	},
+}
+
+// applyPatch records a single operation on req. Every patchable field
+// always exists on a cupcake, so add and replace behave the same, and a
+// null value is treated as a remove.
+func applyPatch(req *models.UpdateCupcakeRequest, op models.PatchOperation) error {
+	name, ok := patchPath(op.Path)
+	field, known := patchFields[name]
+	if !ok || !known {
+		return i18n.NewError(msgPatchPathInvalid, map[string]any{"Path": op.Path})
+	}
+
+	switch op.Op {
+	case models.PatchAdd, models.PatchReplace:
+		if op.Value == nil {
+			return i18n.NewError(msgPatchValueInvalid, map[string]any{"Path": op.Path})
+		}
+		if !bytes.Equal(bytes.TrimSpace(op.Value), []byte("null")) {
+			if err := json.Unmarshal(op.Value, field.value(req)); err != nil {
+				return i18n.NewError(msgPatchValueInvalid, map[string]any{"Path": op.Path})
+			}
+			return nil
+		}
+	case models.PatchRemove:
+	default:
+		return i18n.NewError(msgPatchOpUnsupported, map[string]any{"Op": op.Op})
+	}
+
+	if field.clear == nil {
+		return i18n.NewError(msgPatchPathNotRemovable, map[string]any{"Path": op.Path})
+	}
+	field.clear(req)
+	return nil
+}
+
+// patchPath decodes a JSON Pointer naming a single top-level member.
+func patchPath(pointer string) (string, bool) {
+	if !strings.HasPrefix(pointer, "/") || strings.Count(pointer, "/") != 1 {
+		return "", false
+	}
+	name := strings.TrimPrefix(pointer, "/")
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(name), true
+}
+
+func (s *CupcakeService) GetCupcakeVersions(id uint) ([]models.CupcakeVersion, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindVersions(id)
+}
+
+// RevertCupcake restores the fields stored in the given version. The revert
+// is itself recorded as a new version, so it can be undone too.
+func (s *CupcakeService) RevertCupcake(id uint, version int, updatedBy *uint) (*models.Cupcake, error) {
+	cupcake, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.repo.FindVersion(id, version)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, err
+	}
+
+	var errs fieldErrors
+	if snapshot.SKU != nil {
+		s.checkSKU(&errs, *snapshot.SKU, id)
+	}
+	if snapshot.Slug != nil {
+		s.checkSlug(&errs, *snapshot.Slug, id)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	cupcake.Name = snapshot.Name
+	cupcake.Flavor = snapshot.Flavor
+	cupcake.SKU = snapshot.SKU
+	cupcake.Slug = snapshot.Slug
+	cupcake.Description = snapshot.Description
+	cupcake.DisplayOrder = snapshot.DisplayOrder
+	cupcake.PriceCents = snapshot.PriceCents
+	cupcake.IsAvailable = snapshot.IsAvailable
+	cupcake.AvailableFrom = snapshot.AvailableFrom
+	cupcake.UpdatedBy = updatedBy
+
+	if err := s.repo.Update(cupcake); err != nil {
+		return nil, err
+	}
+
+	cupcake.PreOrder = cupcake.IsPreOrder(s.now())
+
+	s.publish(models.EventCupcakeUpdated, models.NewCupcakeResponse(cupcake))
 	return cupcake, nil
 }
 
 func (s *CupcakeService) DeleteCupcake(id uint) error {
-	return s.repo.Delete(id)
+	if err := s.mustExist(id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+
+	s.publish(models.EventCupcakeDeleted, map[string]uint{"id": id})
+	return nil
+}
+
+// mustExist reports a missing cupcake as repository.ErrNotFound, so callers
+// can answer 404 while any other repository failure stays distinct.
+func (s *CupcakeService) mustExist(id uint) error {
+	exists, err := s.repo.Exists(id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (s *CupcakeService) BulkUpdatePrices(req *models.BulkPriceUpdateRequest) (*models.BulkPriceUpdateResponse, error) {
+	switch req.AdjustmentType {
+	case models.PriceAdjustmentPercentage:
+		if req.Value == 0 || req.Value <= -100 {
+			return nil, i18n.NewError(msgPercentageAdjustmentInvalid, nil)
+		}
+	case models.PriceAdjustmentAbsolute:
+		if req.Value == 0 {
+			return nil, i18n.NewError(msgAbsoluteAdjustmentZero, nil)
+		}
+	default:
+		return nil, i18n.NewError(msgAdjustmentTypeInvalid, nil)
+	}
+
+	// The prices are read and written in one transaction, so a cupcake
+	// edited meanwhile is not set from its old price.
+	var cupcakes []models.Cupcake
+	var changes []models.PriceChange
+	err := s.withTransaction(func(repo repository.CupcakeRepositoryInterface) error {
+		var err error
+		if flavor := strings.TrimSpace(req.Flavor); flavor != "" {
+			cupcakes, err = repo.FindByFlavor(flavor)
+		} else {
+			cupcakes, err = repo.FindAll()
+		}
+		if err != nil {
+			return err
+		}
+
+		maxPrice := s.rules.Rules().MaxPriceCents
+		changes = make([]models.PriceChange, 0, len(cupcakes))
+		for i := range cupcakes {
+			newPrice, err := adjustPrice(cupcakes[i].PriceCents, req.AdjustmentType, req.Value)
+			if err != nil {
+				return err
+			}
+			if newPrice <= 0 {
+				return i18n.NewError(msgAdjustedPriceNotPositive, map[string]any{"Name": cupcakes[i].Name})
+			}
+			if maxPrice > 0 && newPrice > maxPrice {
+				return i18n.NewError(msgAdjustedPriceTooHigh, map[string]any{"Name": cupcakes[i].Name, "Max": maxPrice})
+			}
+
+			changes = append(changes, models.PriceChange{
+				CupcakeID:     cupcakes[i].ID,
+				Name:          cupcakes[i].Name,
+				OldPriceCents: cupcakes[i].PriceCents,
+				NewPriceCents: newPrice,
+			})
+			cupcakes[i].PriceCents = newPrice
+			cupcakes[i].UpdatedBy = req.UpdatedBy
+		}
+
+		if req.DryRun || len(cupcakes) == 0 {
+			return nil
+		}
+		return repo.UpdatePrices(cupcakes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.DryRun {
+		for i := range cupcakes {
+			s.publish(models.EventCupcakeUpdated, models.NewCupcakeResponse(&cupcakes[i]))
+		}
+	}
+
+	return &models.BulkPriceUpdateResponse{
+		DryRun:   req.DryRun,
+		Affected: len(changes),
+		Changes:  changes,
+	}, nil
+}
+
+// withTransaction calls fn with the Cupcakes of a unit of work transaction,
+// or with s.repo when there is no unit of work.
+func (s *CupcakeService) withTransaction(fn func(repo repository.CupcakeRepositoryInterface) error) error {
+	if s.uow == nil {
+		return fn(s.repo)
+	}
+	return s.uow.WithTransaction(context.Background(), func(tx repository.Repositories) error {
+		return fn(tx.Cupcakes)
+	})
+}
+
+func (s *CupcakeService) publish(event string, data interface{}) {
+	if s.events != nil {
+		s.events.Publish(event, data)
+	}
+}
+
+// checkSKU normalizes and validates a SKU for the cupcake with the given ID.
+// An empty SKU clears it.
+func (s *CupcakeService) checkSKU(errs *fieldErrors, raw string, id uint) *string {
+	sku := normalizeSKU(raw)
+	if sku == "" {
+		return nil
+	}
+
+	if !skuPattern.MatchString(sku) {
+		errs.add("sku", RuleFormat, msgSKUInvalid, nil)
+		return nil
+	}
+
+	if existing, err := s.repo.FindBySKU(sku); err == nil && existing.ID != id {
+		errs.add("sku", RuleUnique, msgSKUTaken, nil)
+		return nil
+	}
+
+	return &sku
 }
 
-func (s *CupcakeService) validateCreateRequest(req *models.CreateCupcakeRequest) error {
-	if strings.TrimSpace(req.Name) == "" {
-		return errors.New("name is required")
+// checkSlug normalizes and validates a slug for the cupcake with the given
+// ID. An empty slug clears it.
+func (s *CupcakeService) checkSlug(errs *fieldErrors, raw string, id uint) *string {
+	slug := normalizeSlug(raw)
+	if slug == "" {
+		return nil
 	}
 
-	if len(strings.TrimSpace(req.Name)) < 2 {
-		return errors.New("name must have at least 2 characters")
+	if len(slug) > maxSlugLength || !slugPattern.MatchString(slug) {
+		errs.add("slug", RuleFormat, msgSlugInvalid, nil)
+		return nil
+	}
+
+	if existing, err := s.repo.FindBySlug(slug); err == nil && existing.ID != id {
+		errs.add("slug", RuleUnique, msgSlugTaken, nil)
+		return nil
+	}
+
+	return &slug
+}
+
+// generateSlug derives a slug from name for a new cupcake, adding a numeric
+// suffix ("red-velvet-2") when the plain one is taken.
+func (s *CupcakeService) generateSlug(name string) (*string, error) {
+	base := slugify(name)
+	if base == "" {
+		return nil, nil
+	}
+
+	for n := 1; ; n++ {
+		slug := base
+		if n > 1 {
+			suffix := "-" + strconv.Itoa(n)
+			slug = strings.TrimRight(base[:min(len(base), maxSlugLength-len(suffix))], "-") + suffix
+		}
+
+		_, err := s.repo.FindBySlug(slug)
+		if errors.Is(err, repository.ErrNotFound) {
+			return &slug, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *CupcakeService) validateCreateRequest(errs *fieldErrors, req *models.CreateCupcakeRequest) {
+	if name := strings.TrimSpace(req.Name); name == "" {
+		errs.add("name", RuleRequired, msgNameRequired, nil)
+	} else {
+		s.checkName(errs, name)
 	}
 
 	if strings.TrimSpace(req.Flavor) == "" {
-		return errors.New("flavor is required")
+		errs.add("flavor", RuleRequired, msgFlavorRequired, nil)
 	}
 
-	if req.PriceCents <= 0 {
-		return errors.New("price must be greater than zero")
+	s.checkPrice(errs, req.PriceCents)
+}
+
+// checkName checks a trimmed name against the configured length limits,
+// counted in characters rather than bytes.
+func (s *CupcakeService) checkName(errs *fieldErrors, name string) {
+	rules := s.rules.Rules()
+	length := utf8.RuneCountInString(name)
+	if length < rules.NameMinLength {
+		errs.add("name", RuleMinLength, msgNameTooShort, map[string]any{"Min": rules.NameMinLength})
+	}
+	if length > rules.NameMaxLength {
+		errs.add("name", RuleMaxLength, msgCupcakeNameTooLong, map[string]any{"Max": rules.NameMaxLength})
 	}
+}
 
-	return nil
+func (s *CupcakeService) checkPrice(errs *fieldErrors, priceCents int) {
+	if priceCents <= 0 {
+		errs.add("price_cents", RulePositive, msgPriceNotPositive, nil)
+	}
+	if maxPrice := s.rules.Rules().MaxPriceCents; maxPrice > 0 && priceCents > maxPrice {
+		errs.add("price_cents", RuleMax, msgPriceTooHigh, map[string]any{"Max": maxPrice})
+	}
+}
+
+// checkDuplicates returns a DuplicateCupcakeError when existing cupcakes
+// have a name similar to name, catching typos and case or spacing variants
+// of the same product.
+func (s *CupcakeService) checkDuplicates(name string) error {
+	cupcakes, err := s.repo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	name = normalizeName(name)
+	type match struct {
+		cupcake    models.Cupcake
+		similarity float64
+	}
+	var matches []match
+	for _, cupcake := range cupcakes {
+		existing := normalizeName(cupcake.Name)
+		// "Box of 6" and "Box of 12" are different products, however close.
+		if !slices.Equal(nameNumbers.FindAllString(name, -1), nameNumbers.FindAllString(existing, -1)) {
+			continue
+		}
+		if similarity := nameSimilarity(name, existing); similarity >= duplicateThreshold {
+			matches = append(matches, match{cupcake: cupcake, similarity: similarity})
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].similarity > matches[j].similarity })
+	duplicates := &DuplicateCupcakeError{Matches: make([]models.Cupcake, len(matches))}
+	for i, m := range matches {
+		duplicates.Matches[i] = m.cupcake
+	}
+	return duplicates
+}
+
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// nameSimilarity is 1 minus the Levenshtein distance between a and b over
+// the length of the longer one, so 1 means equal.
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func normalizeSKU(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
+
+func normalizeSlug(slug string) string {
+	return strings.ToLower(strings.TrimSpace(slug))
+}
+
+// slugify turns a name into a slug: "Pão de Mel" becomes "pao-de-mel".
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFD.String(strings.ToLower(name)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Accents split off by NFD.
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
+
+// sanitizeDescription strips unsafe HTML from a description.
+func sanitizeDescription(errs *fieldErrors, raw string) string {
+	description := strings.TrimSpace(descriptionPolicy.Sanitize(raw))
+	if len(description) > maxDescriptionLength {
+		errs.add("description", RuleMaxLength, msgDescriptionTooLong, nil)
+		return ""
+	}
+	return description
+}
+
+func adjustPrice(priceCents int, adjustmentType string, value int) (int, error) {
+	price := money.Cents(priceCents)
+	change := money.Cents(value)
+	if adjustmentType == models.PriceAdjustmentPercentage {
+		var err error
+		if change, err = price.Percent(int64(value)); err != nil {
+			return cents(change, err)
+		}
+	}
+	return cents(price.Add(change))
 }
@@ -1,25 +1,26 @@
 package service
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
 	"github.com/julimonteiro/cupcake-store/internal/models"
 	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/repository/inmem"
+	"github.com/julimonteiro/cupcake-store/internal/testutil"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
 	"github.com/stretchr/testify/require"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 func setupTestDB(t *testing.T) *gorm.DB {
 	t.Helper()
-
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
-	require.NoError(t, err)
-
-	err = db.AutoMigrate(&models.Cupcake{})
-	require.NoError(t, err)
-
-	return db
+	return testutil.NewDB(t)
 }
 
 func newTestService(t *testing.T) *CupcakeService {
@@ -27,7 +28,8 @@ func newTestService(t *testing.T) *CupcakeService {
 
 	db := setupTestDB(t)
 	repo := repository.NewCupcakeRepository(db)
-	return NewCupcakeService(repo)
+	return NewCupcakeService(repo, repository.NewPromotionRepository(db), repository.NewLocationRepository(db), nil, nil, nil, nil).
+		WithUnitOfWork(repository.NewUnitOfWork(db))
 }
 
 func TestCreateCupcake(t *testing.T) {
@@ -170,7 +172,7 @@ func TestGetCupcake(t *testing.T) {
 		{
 			name:          "error - non-existent cupcake",
 			cupcakeID:     999,
-			expectedError: "record not found",
+			expectedError: "not found",
 		},
 	}
 
@@ -201,6 +203,354 @@ func TestGetCupcake(t *testing.T) {
 	}
 }
 
+func TestCupcakeSKU(t *testing.T) {
+	tests := []struct {
+		name          string
+		existingSKU   *string
+		createSKU     *string
+		updateSKU     *string
+		expectedError string
+		expectedSKU   *string
+	}{
+		{
+			name:        "sku is normalized on create",
+			createSKU:   stringPtr("  cc-choc-01 "),
+			expectedSKU: stringPtr("CC-CHOC-01"),
+		},
+		{
+			name:          "invalid characters are rejected",
+			createSKU:     stringPtr("CC CHOC"),
+			expectedError: "sku must have 3 to 64 letters, digits or dashes",
+		},
+		{
+			name:          "too short sku is rejected",
+			createSKU:     stringPtr("AB"),
+			expectedError: "sku must have 3 to 64 letters, digits or dashes",
+		},
+		{
+			name:          "duplicate sku on create is rejected",
+			existingSKU:   stringPtr("CC-VAN-01"),
+			createSKU:     stringPtr("cc-van-01"),
+			expectedError: "sku already exists",
+		},
+		{
+			name:          "duplicate sku on update is rejected",
+			existingSKU:   stringPtr("CC-VAN-01"),
+			updateSKU:     stringPtr("CC-VAN-01"),
+			expectedError: "sku already exists",
+		},
+		{
+			name:        "keeping the same sku on update is allowed",
+			createSKU:   stringPtr("CC-CHOC-01"),
+			updateSKU:   stringPtr("cc-choc-01"),
+			expectedSKU: stringPtr("CC-CHOC-01"),
+		},
+		{
+			name:      "empty sku on update clears it",
+			createSKU: stringPtr("CC-CHOC-01"),
+			updateSKU: stringPtr(""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+
+			if tt.existingSKU != nil {
+				_, err := service.CreateCupcake(&models.CreateCupcakeRequest{
+					Name:       "Vanilla",
+					Flavor:     "Vanilla",
+					PriceCents: 900,
+					SKU:        tt.existingSKU,
+				})
+				require.NoError(t, err)
+			}
+
+			cupcake, err := service.CreateCupcake(&models.CreateCupcakeRequest{
+				Name:       "Chocolate",
+				Flavor:     "Cocoa",
+				PriceCents: 1000,
+				SKU:        tt.createSKU,
+			})
+			if err == nil && tt.updateSKU != nil {
+				cupcake, err = service.UpdateCupcake(cupcake.ID, &models.UpdateCupcakeRequest{SKU: tt.updateSKU})
+			}
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, cupcake)
+				require.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSKU, cupcake.SKU)
+
+			if tt.expectedSKU != nil {
+				found, err := service.GetCupcakeBySKU(strings.ToLower(*tt.expectedSKU))
+				require.NoError(t, err)
+				require.Equal(t, cupcake.ID, found.ID)
+			}
+		})
+	}
+}
+
+func TestCupcakeSlug(t *testing.T) {
+	tests := []struct {
+		name          string
+		existingSlug  *string
+		createSlug    *string
+		updateSlug    *string
+		expectedError string
+		expectedSlug  *string
+	}{
+		{
+			name:         "slug is derived from the name",
+			expectedSlug: stringPtr("chocolate-belga"),
+		},
+		{
+			name:         "derived slug gets a suffix when taken",
+			existingSlug: stringPtr("chocolate-belga"),
+			expectedSlug: stringPtr("chocolate-belga-2"),
+		},
+		{
+			name:         "slug is normalized on create",
+			createSlug:   stringPtr(" Belgian-Chocolate "),
+			expectedSlug: stringPtr("belgian-chocolate"),
+		},
+		{
+			name:          "invalid characters are rejected",
+			createSlug:    stringPtr("belgian chocolate"),
+			expectedError: "slug must have up to 100 lowercase letters or digits, separated by single dashes",
+		},
+		{
+			name:          "repeated dashes are rejected",
+			createSlug:    stringPtr("belgian--chocolate"),
+			expectedError: "slug must have up to 100 lowercase letters or digits, separated by single dashes",
+		},
+		{
+			name:          "duplicate slug on create is rejected",
+			existingSlug:  stringPtr("dark"),
+			createSlug:    stringPtr("DARK"),
+			expectedError: "slug already exists",
+		},
+		{
+			name:          "duplicate slug on update is rejected",
+			existingSlug:  stringPtr("dark"),
+			updateSlug:    stringPtr("dark"),
+			expectedError: "slug already exists",
+		},
+		{
+			name:       "empty slug on update clears it",
+			updateSlug: stringPtr(""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+
+			if tt.existingSlug != nil {
+				_, err := service.CreateCupcake(&models.CreateCupcakeRequest{
+					Name:       "Vanilla",
+					Flavor:     "Vanilla",
+					PriceCents: 900,
+					Slug:       tt.existingSlug,
+				})
+				require.NoError(t, err)
+			}
+
+			cupcake, err := service.CreateCupcake(&models.CreateCupcakeRequest{
+				Name:       "Chocolate Belga",
+				Flavor:     "Cocoa",
+				PriceCents: 1000,
+				Slug:       tt.createSlug,
+			})
+			if err == nil && tt.updateSlug != nil {
+				cupcake, err = service.UpdateCupcake(cupcake.ID, &models.UpdateCupcakeRequest{Slug: tt.updateSlug})
+			}
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, cupcake)
+				require.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSlug, cupcake.Slug)
+
+			if tt.expectedSlug != nil {
+				found, err := service.GetCupcakeBySlug(strings.ToUpper(*tt.expectedSlug))
+				require.NoError(t, err)
+				require.Equal(t, cupcake.ID, found.ID)
+			}
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Red Velvet", expected: "red-velvet"},
+		{name: "Pão de Mel", expected: "pao-de-mel"},
+		{name: "  Box of 6 (mixed)! ", expected: "box-of-6-mixed"},
+		{name: "Crème brûlée", expected: "creme-brulee"},
+		{name: "!!!", expected: ""},
+		{name: strings.Repeat("a", 120), expected: strings.Repeat("a", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, slugify(tt.name))
+		})
+	}
+}
+
+func TestCupcakeDescription(t *testing.T) {
+	service := newTestService(t)
+
+	cupcake, err := service.CreateCupcake(&models.CreateCupcakeRequest{
+		Name:        "Chocolate",
+		Flavor:      "Cocoa",
+		PriceCents:  1000,
+		Description: `<p onclick="steal()">Rich <em>dark</em> cocoa <a href="javascript:alert(1)">here</a></p><script>alert(1)</script>`,
+	})
+	require.NoError(t, err)
+	require.Equal(t, `<p>Rich <em>dark</em> cocoa here</p>`, cupcake.Description)
+
+	_, err = service.UpdateCupcake(cupcake.ID, &models.UpdateCupcakeRequest{Description: stringPtr(strings.Repeat("a", 2001))})
+	require.EqualError(t, err, "description must be at most 2000 characters")
+
+	updated, err := service.UpdateCupcake(cupcake.ID, &models.UpdateCupcakeRequest{Description: stringPtr("")})
+	require.NoError(t, err)
+	require.Empty(t, updated.Description)
+}
+
+func TestGetAllCupcakes_DisplayOrder(t *testing.T) {
+	service := newTestService(t)
+
+	for _, req := range []models.CreateCupcakeRequest{
+		{Name: "Vanilla", Flavor: "Vanilla", PriceCents: 900, DisplayOrder: 2},
+		{Name: "Lemon", Flavor: "Lemon", PriceCents: 900},
+		{Name: "Strawberry", Flavor: "Strawberry", PriceCents: 900, DisplayOrder: 1},
+		{Name: "Coffee", Flavor: "Coffee", PriceCents: 900},
+	} {
+		_, err := service.CreateCupcake(&req)
+		require.NoError(t, err)
+	}
+
+	cupcakes, err := service.GetAllCupcakes()
+	require.NoError(t, err)
+
+	names := make([]string, len(cupcakes))
+	for i, cupcake := range cupcakes {
+		names[i] = cupcake.Name
+	}
+	require.Equal(t, []string{"Lemon", "Coffee", "Strawberry", "Vanilla"}, names)
+}
+
+func TestBulkUpdatePrices(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        *models.BulkPriceUpdateRequest
+		expectedError  string
+		expectedPrices map[string]int
+		expectedStored map[string]int
+	}{
+		{
+			name:           "percentage increase for all cupcakes",
+			request:        &models.BulkPriceUpdateRequest{AdjustmentType: models.PriceAdjustmentPercentage, Value: 10},
+			expectedPrices: map[string]int{"Chocolate": 1100, "Vanilla": 990, "Lemon": 1320},
+			expectedStored: map[string]int{"Chocolate": 1100, "Vanilla": 990, "Lemon": 1320},
+		},
+		{
+			name:           "absolute decrease filtered by flavor",
+			request:        &models.BulkPriceUpdateRequest{Flavor: "citrus", AdjustmentType: models.PriceAdjustmentAbsolute, Value: -200},
+			expectedPrices: map[string]int{"Lemon": 1000},
+			expectedStored: map[string]int{"Chocolate": 1000, "Vanilla": 900, "Lemon": 1000},
+		},
+		{
+			name:           "dry run leaves prices untouched",
+			request:        &models.BulkPriceUpdateRequest{AdjustmentType: models.PriceAdjustmentPercentage, Value: -50, DryRun: true},
+			expectedPrices: map[string]int{"Chocolate": 500, "Vanilla": 450, "Lemon": 600},
+			expectedStored: map[string]int{"Chocolate": 1000, "Vanilla": 900, "Lemon": 1200},
+		},
+		{
+			name:           "rejects adjustment that makes a price non-positive",
+			request:        &models.BulkPriceUpdateRequest{AdjustmentType: models.PriceAdjustmentAbsolute, Value: -950},
+			expectedError:  "price for Vanilla would drop to zero or below",
+			expectedStored: map[string]int{"Chocolate": 1000, "Vanilla": 900, "Lemon": 1200},
+		},
+		{
+			name:          "rejects percentage of -100 or lower",
+			request:       &models.BulkPriceUpdateRequest{AdjustmentType: models.PriceAdjustmentPercentage, Value: -100},
+			expectedError: "percentage adjustment must be non-zero and greater than -100",
+		},
+		{
+			name:          "rejects unknown adjustment type",
+			request:       &models.BulkPriceUpdateRequest{AdjustmentType: "multiply", Value: 2},
+			expectedError: "adjustment type must be percentage or absolute",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+
+			for _, req := range []*models.CreateCupcakeRequest{
+				{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1000},
+				{Name: "Vanilla", Flavor: "Vanilla", PriceCents: 900},
+				{Name: "Lemon", Flavor: "Citrus", PriceCents: 1200},
+			} {
+				_, err := service.CreateCupcake(req)
+				require.NoError(t, err)
+			}
+
+			result, err := service.BulkUpdatePrices(tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, result)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.request.DryRun, result.DryRun)
+				require.Equal(t, len(tt.expectedPrices), result.Affected)
+				for _, change := range result.Changes {
+					require.Equal(t, tt.expectedPrices[change.Name], change.NewPriceCents)
+				}
+			}
+
+			cupcakes, err := service.GetAllCupcakes()
+			require.NoError(t, err)
+			for _, cupcake := range cupcakes {
+				if expected, ok := tt.expectedStored[cupcake.Name]; ok {
+					require.Equal(t, expected, cupcake.PriceCents)
+				}
+			}
+		})
+	}
+}
+
+func TestAdjustPrice(t *testing.T) {
+	price, err := adjustPrice(1000, models.PriceAdjustmentPercentage, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1150, price)
+
+	price, err = adjustPrice(1000, models.PriceAdjustmentAbsolute, -250)
+	require.NoError(t, err)
+	require.Equal(t, 750, price)
+
+	_, err = adjustPrice(math.MaxInt, models.PriceAdjustmentAbsolute, 1)
+	require.EqualError(t, err, "amount is too large")
+
+	_, err = adjustPrice(math.MaxInt, models.PriceAdjustmentPercentage, 10)
+	require.EqualError(t, err, "amount is too large")
+}
+
 func TestGetAllCupcakes(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -276,6 +626,96 @@ func TestGetAllCupcakes(t *testing.T) {
 	}
 }
 
+func TestListCupcakes(t *testing.T) {
+	tests := []struct {
+		name          string
+		page          int
+		perPage       int
+		expectedError string
+		expectedNames []string
+	}{
+		{name: "first page", page: 1, perPage: 2, expectedNames: []string{"Chocolate", "Vanilla"}},
+		{name: "second page", page: 2, perPage: 2, expectedNames: []string{"Lemon"}},
+		{name: "page zero", page: 0, perPage: 2, expectedError: "page must be at least 1"},
+		{name: "per_page too large", page: 1, perPage: 101, expectedError: "per_page must be between 1 and 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+
+			for _, name := range []string{"Chocolate", "Vanilla", "Lemon"} {
+				_, err := service.CreateCupcake(&models.CreateCupcakeRequest{Name: name, Flavor: name, PriceCents: 1000})
+				require.NoError(t, err)
+			}
+
+			cupcakes, total, err := service.ListCupcakes(tt.page, tt.perPage)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(3), total)
+
+			var names []string
+			for _, cupcake := range cupcakes {
+				names = append(names, cupcake.Name)
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestStreamCupcakes(t *testing.T) {
+	tests := []struct {
+		name          string
+		currency      string
+		fnErr         error
+		expectedError string
+		expectedNames []string
+	}{
+		{
+			name:          "streams every cupcake in ID order",
+			expectedNames: []string{"Chocolate", "Vanilla", "Lemon"},
+		},
+		{
+			name:          "unsupported currency fails before streaming",
+			currency:      "USD",
+			expectedError: "unsupported currency: USD",
+		},
+		{
+			name:          "callback error stops the stream",
+			fnErr:         errors.New("client went away"),
+			expectedError: "client went away",
+			expectedNames: []string{"Chocolate"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+
+			for _, name := range []string{"Chocolate", "Vanilla", "Lemon"} {
+				_, err := service.CreateCupcake(&models.CreateCupcakeRequest{Name: name, Flavor: name, PriceCents: 1000})
+				require.NoError(t, err)
+			}
+
+			var names []string
+			err := service.StreamCupcakes(tt.currency, func(c *models.Cupcake) error {
+				names = append(names, c.Name)
+				return tt.fnErr
+			})
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
+
 func TestUpdateCupcake(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -344,7 +784,7 @@ func TestUpdateCupcake(t *testing.T) {
 			name:          "error - non-existent cupcake",
 			cupcakeID:     999,
 			updateRequest: &models.UpdateCupcakeRequest{Name: stringPtr("Updated")},
-			expectedError: "record not found",
+			expectedError: "not found",
 		},
 		{
 			name:      "validation error - name too short",
@@ -429,6 +869,163 @@ func TestUpdateCupcake(t *testing.T) {
 	}
 }
 
+func TestPatchCupcake(t *testing.T) {
+	tests := []struct {
+		name             string
+		ops              string
+		expectedError    string
+		validateResponse func(t *testing.T, cupcake *models.Cupcake)
+	}{
+		{
+			name: "replace and add set fields",
+			ops:  `[{"op":"replace","path":"/price_cents","value":1500},{"op":"add","path":"/name","value":"Dark Chocolate"}]`,
+			validateResponse: func(t *testing.T, cupcake *models.Cupcake) {
+				require.Equal(t, 1500, cupcake.PriceCents)
+				require.Equal(t, "Dark Chocolate", cupcake.Name)
+				require.Equal(t, "Chocolate", cupcake.Flavor)
+			},
+		},
+		{
+			name: "remove clears optional fields",
+			ops:  `[{"op":"remove","path":"/sku"},{"op":"remove","path":"/description"}]`,
+			validateResponse: func(t *testing.T, cupcake *models.Cupcake) {
+				require.Nil(t, cupcake.SKU)
+				require.Empty(t, cupcake.Description)
+			},
+		},
+		{
+			name: "null value clears optional fields",
+			ops:  `[{"op":"replace","path":"/slug","value":null}]`,
+			validateResponse: func(t *testing.T, cupcake *models.Cupcake) {
+				require.Nil(t, cupcake.Slug)
+			},
+		},
+		{
+			name: "operations apply in order",
+			ops:  `[{"op":"remove","path":"/sku"},{"op":"add","path":"/sku","value":"choc-002"}]`,
+			validateResponse: func(t *testing.T, cupcake *models.Cupcake) {
+				require.Equal(t, "CHOC-002", *cupcake.SKU)
+			},
+		},
+		{
+			name:          "update rules still apply",
+			ops:           `[{"op":"replace","path":"/price_cents","value":0}]`,
+			expectedError: "price must be greater than zero",
+		},
+		{
+			name:          "empty patch is rejected",
+			ops:           `[]`,
+			expectedError: "at least one patch operation is required",
+		},
+		{
+			name:          "unsupported operation",
+			ops:           `[{"op":"move","from":"/name","path":"/flavor"}]`,
+			expectedError: "unsupported patch operation: move",
+		},
+		{
+			name:          "unknown path",
+			ops:           `[{"op":"replace","path":"/id","value":2}]`,
+			expectedError: "cannot patch /id",
+		},
+		{
+			name:          "nested path",
+			ops:           `[{"op":"replace","path":"/name/0","value":"x"}]`,
+			expectedError: "cannot patch /name/0",
+		},
+		{
+			name:          "required field cannot be removed",
+			ops:           `[{"op":"remove","path":"/name"}]`,
+			expectedError: "/name cannot be removed",
+		},
+		{
+			name:          "missing value",
+			ops:           `[{"op":"replace","path":"/name"}]`,
+			expectedError: "invalid value for /name",
+		},
+		{
+			name:          "value of the wrong type",
+			ops:           `[{"op":"replace","path":"/price_cents","value":"cheap"}]`,
+			expectedError: "invalid value for /price_cents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+			created, err := service.CreateCupcake(&models.CreateCupcakeRequest{
+				Name:        "Chocolate",
+				Flavor:      "Chocolate",
+				PriceCents:  1000,
+				SKU:         stringPtr("CHOC-001"),
+				Description: "<p>Rich</p>",
+			})
+			require.NoError(t, err)
+
+			var ops []models.PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+
+			cupcake, err := service.PatchCupcake(created.ID, ops, nil)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Equal(t, tt.expectedError, err.Error())
+
+				unchanged, err := service.GetCupcake(created.ID)
+				require.NoError(t, err)
+				require.Equal(t, 1000, unchanged.PriceCents)
+				require.Equal(t, "CHOC-001", *unchanged.SKU)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.validateResponse(t, cupcake)
+		})
+	}
+}
+
+func TestRevertCupcake(t *testing.T) {
+	tests := []struct {
+		name          string
+		cupcakeID     uint
+		version       int
+		expectedError error
+		expectedName  string
+		expectedPrice int
+	}{
+		{name: "revert to the original version", cupcakeID: 1, version: 1, expectedName: "Chocolate", expectedPrice: 1000},
+		{name: "revert to an intermediate version", cupcakeID: 1, version: 2, expectedName: "Dark Chocolate", expectedPrice: 1000},
+		{name: "unknown version", cupcakeID: 1, version: 9, expectedError: ErrVersionNotFound},
+		{name: "unknown cupcake", cupcakeID: 99, version: 1, expectedError: repository.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+
+			_, err := service.CreateCupcake(&models.CreateCupcakeRequest{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1000})
+			require.NoError(t, err)
+			_, err = service.UpdateCupcake(1, &models.UpdateCupcakeRequest{Name: stringPtr("Dark Chocolate")})
+			require.NoError(t, err)
+			_, err = service.UpdateCupcake(1, &models.UpdateCupcakeRequest{PriceCents: intPtr(1500)})
+			require.NoError(t, err)
+
+			cupcake, err := service.RevertCupcake(tt.cupcakeID, tt.version, nil)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedName, cupcake.Name)
+			require.Equal(t, tt.expectedPrice, cupcake.PriceCents)
+
+			versions, err := service.GetCupcakeVersions(1)
+			require.NoError(t, err)
+			require.Len(t, versions, 4)
+			require.Equal(t, tt.expectedName, versions[0].Name)
+		})
+	}
+}
+
 func TestDeleteCupcake(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -448,7 +1045,7 @@ func TestDeleteCupcake(t *testing.T) {
 		{
 			name:          "error - non-existent cupcake",
 			cupcakeID:     999,
-			expectedError: "record not found",
+			expectedError: "not found",
 		},
 	}
 
@@ -474,125 +1071,208 @@ func TestDeleteCupcake(t *testing.T) {
 	}
 }
 
+var errDatabase = errors.New("database is down")
+
+// newMockedService builds a CupcakeService on mocks. The event publisher
+// has no PublishFunc, so a failed write that still publishes panics.
+func newMockedService(repo *mocks.CupcakeRepository, promotionRepo *mocks.PromotionRepository) *CupcakeService {
+	if promotionRepo == nil {
+		promotionRepo = &mocks.PromotionRepository{}
+	}
+	return NewCupcakeService(repo, promotionRepo, &mocks.LocationRepository{}, &mocks.EventPublisher{}, nil, nil, nil)
+}
+
 func TestCreateCupcake_RepositoryError(t *testing.T) {
 	tests := []struct {
 		name          string
+		repo          *mocks.CupcakeRepository
 		request       *models.CreateCupcakeRequest
-		expectedError string
+		expectedError error
 	}{
 		{
-			name: "repository error handling",
+			name: "create fails",
+			repo: &mocks.CupcakeRepository{
+				FindAllFunc:    func() ([]models.Cupcake, error) { return nil, nil },
+				FindBySlugFunc: func(string) (*models.Cupcake, error) { return nil, repository.ErrNotFound },
+				CreateFunc:     func(*models.Cupcake) error { return errDatabase },
+			},
 			request: &models.CreateCupcakeRequest{
 				Name:       "Valid Name",
 				Flavor:     "Valid Flavor",
 				PriceCents: 1000,
 			},
-			expectedError: "",
+			expectedError: errDatabase,
+		},
+		{
+			name: "create with sku fails",
+			repo: &mocks.CupcakeRepository{
+				FindAllFunc:    func() ([]models.Cupcake, error) { return nil, nil },
+				FindBySKUFunc:  func(string) (*models.Cupcake, error) { return nil, repository.ErrNotFound },
+				FindBySlugFunc: func(string) (*models.Cupcake, error) { return nil, repository.ErrNotFound },
+				CreateFunc:     func(*models.Cupcake) error { return errDatabase },
+			},
+			request: &models.CreateCupcakeRequest{
+				Name:       "Valid Name",
+				Flavor:     "Valid Flavor",
+				PriceCents: 1000,
+				SKU:        stringPtr("VAL-001"),
+			},
+			expectedError: errDatabase,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			service := newTestService(t)
+			service := newMockedService(tt.repo, nil)
 
 			cupcake, err := service.CreateCupcake(tt.request)
 
-			if tt.expectedError != "" {
-				require.Error(t, err)
-				require.Nil(t, cupcake)
-				require.Contains(t, err.Error(), tt.expectedError)
-			} else {
-				require.NoError(t, err)
-				require.NotNil(t, cupcake)
-			}
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Nil(t, cupcake)
 		})
 	}
 }
 
-func TestUpdateCupcake_RepositoryError(t *testing.T) {
+func TestGetCupcake_RepositoryError(t *testing.T) {
 	tests := []struct {
 		name          string
-		cupcakeID     uint
-		updateRequest *models.UpdateCupcakeRequest
-		setupCupcake  *models.CreateCupcakeRequest
-		expectedError string
+		repo          *mocks.CupcakeRepository
+		promotionRepo *mocks.PromotionRepository
+		expectedError error
 	}{
 		{
-			name:      "repository error handling",
-			cupcakeID: 1,
-			setupCupcake: &models.CreateCupcakeRequest{
-				Name:       "Original Name",
-				Flavor:     "Original Flavor",
-				PriceCents: 1000,
+			name: "lookup fails",
+			repo: &mocks.CupcakeRepository{
+				FindByIDFunc: func(uint) (*models.Cupcake, error) { return nil, errDatabase },
 			},
-			updateRequest: &models.UpdateCupcakeRequest{
-				Name: stringPtr("Updated Name"),
+			expectedError: errDatabase,
+		},
+		{
+			name: "promotion lookup fails",
+			repo: &mocks.CupcakeRepository{
+				FindByIDFunc: func(id uint) (*models.Cupcake, error) {
+					return &models.Cupcake{ID: id, Name: "Vanilla", PriceCents: 1000}, nil
+				},
+			},
+			promotionRepo: &mocks.PromotionRepository{
+				FindActiveFunc: func([]uint, time.Time) ([]models.Promotion, error) { return nil, errDatabase },
 			},
-			expectedError: "",
+			expectedError: errDatabase,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			service := newTestService(t)
+			service := newMockedService(tt.repo, tt.promotionRepo)
 
-			if tt.setupCupcake != nil {
-				createdCupcake, err := service.CreateCupcake(tt.setupCupcake)
-				require.NoError(t, err)
-				tt.cupcakeID = createdCupcake.ID
-			}
-
-			cupcake, err := service.UpdateCupcake(tt.cupcakeID, tt.updateRequest)
+			cupcake, err := service.GetCupcake(1)
 
-			if tt.expectedError != "" {
-				require.Error(t, err)
-				require.Nil(t, cupcake)
-				require.Contains(t, err.Error(), tt.expectedError)
-			} else {
-				require.NoError(t, err)
-				require.NotNil(t, cupcake)
-			}
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Nil(t, cupcake)
 		})
 	}
 }
 
-func TestDeleteCupcake_RepositoryError(t *testing.T) {
+func TestUpdateCupcake_RepositoryError(t *testing.T) {
+	existing := func(id uint) (*models.Cupcake, error) {
+		return &models.Cupcake{ID: id, Name: "Original Name", Flavor: "Original Flavor", PriceCents: 1000}, nil
+	}
+	found := func(uint) (bool, error) { return true, nil }
+
 	tests := []struct {
 		name          string
-		cupcakeID     uint
-		setupCupcake  *models.CreateCupcakeRequest
-		expectedError string
+		repo          *mocks.CupcakeRepository
+		expectedError error
 	}{
 		{
-			name:      "repository error handling",
-			cupcakeID: 1,
-			setupCupcake: &models.CreateCupcakeRequest{
-				Name:       "To Delete",
-				Flavor:     "Test Flavor",
-				PriceCents: 1000,
+			name: "exists check fails",
+			repo: &mocks.CupcakeRepository{
+				ExistsFunc: func(uint) (bool, error) { return false, errDatabase },
+			},
+			expectedError: errDatabase,
+		},
+		{
+			name: "missing cupcake",
+			repo: &mocks.CupcakeRepository{
+				ExistsFunc: func(uint) (bool, error) { return false, nil },
 			},
-			expectedError: "",
+			expectedError: repository.ErrNotFound,
+		},
+		{
+			name: "lookup fails",
+			repo: &mocks.CupcakeRepository{
+				ExistsFunc:   found,
+				FindByIDFunc: func(uint) (*models.Cupcake, error) { return nil, errDatabase },
+			},
+			expectedError: errDatabase,
+		},
+		{
+			name: "update fails",
+			repo: &mocks.CupcakeRepository{
+				ExistsFunc:   found,
+				FindByIDFunc: existing,
+				UpdateFunc:   func(*models.Cupcake) error { return errDatabase },
+			},
+			expectedError: errDatabase,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			service := newTestService(t)
+			service := newMockedService(tt.repo, nil)
 
-			if tt.setupCupcake != nil {
-				createdCupcake, err := service.CreateCupcake(tt.setupCupcake)
-				require.NoError(t, err)
-				tt.cupcakeID = createdCupcake.ID
-			}
+			cupcake, err := service.UpdateCupcake(1, &models.UpdateCupcakeRequest{Name: stringPtr("Updated Name")})
 
-			err := service.DeleteCupcake(tt.cupcakeID)
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Nil(t, cupcake)
+		})
+	}
+}
 
-			if tt.expectedError != "" {
-				require.Error(t, err)
-				require.Contains(t, err.Error(), tt.expectedError)
-			} else {
-				require.NoError(t, err)
-			}
+func TestDeleteCupcake_RepositoryError(t *testing.T) {
+	tests := []struct {
+		name          string
+		exists        bool
+		existsErr     error
+		repoErr       error
+		expectedError error
+	}{
+		{name: "exists check fails", existsErr: errDatabase, expectedError: errDatabase},
+		{name: "missing cupcake", expectedError: repository.ErrNotFound},
+		{name: "delete fails", exists: true, repoErr: errDatabase, expectedError: errDatabase},
+		{name: "deleted concurrently", exists: true, repoErr: repository.ErrNotFound, expectedError: repository.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newMockedService(&mocks.CupcakeRepository{
+				ExistsFunc: func(uint) (bool, error) { return tt.exists, tt.existsErr },
+				DeleteFunc: func(uint) error { return tt.repoErr },
+			}, nil)
+
+			err := service.DeleteCupcake(1)
+
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestCupcakeNotFound_Backends(t *testing.T) {
+	backends := []struct {
+		name string
+		repo repository.CupcakeRepositoryInterface
+	}{
+		{name: "gorm", repo: repository.NewCupcakeRepository(setupTestDB(t))},
+		{name: "inmem", repo: inmem.NewCupcakeRepository()},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			service := NewCupcakeService(backend.repo, nil, nil, nil, nil, nil, nil)
+
+			_, err := service.UpdateCupcake(999, &models.UpdateCupcakeRequest{Name: stringPtr("Missing")})
+			require.ErrorIs(t, err, repository.ErrNotFound)
+			require.ErrorIs(t, service.DeleteCupcake(999), repository.ErrNotFound)
 		})
 	}
 }
@@ -608,3 +1288,206 @@ func intPtr(i int) *int {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestGetCupcakesAtLocation(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	svc := NewCupcakeService(cupcakeRepo, promotionRepo, locationRepo, nil, nil, nil, nil)
+
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate")),
+		factory.Cupcake(factory.WithName("Lemon"), factory.Unavailable()),
+		factory.Cupcake(factory.WithName("Carrot")),
+		factory.Cupcake(factory.WithName("Red Velvet")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+	location := &models.Location{Name: "Centro", Address: "Rua Augusta, 100"}
+	require.NoError(t, locationRepo.Create(location))
+	for cupcakeID, quantity := range map[uint]int{1: 5, 2: 0, 3: 8, 4: 2, 5: 1} {
+		require.NoError(t, locationRepo.SetStock(&models.LocationStock{LocationID: location.ID, CupcakeID: cupcakeID, Quantity: quantity}))
+	}
+	promotion := factory.Promotion(4)
+	require.NoError(t, promotionRepo.Create(&promotion))
+
+	cupcakes, err := svc.GetCupcakesAtLocation(location.ID)
+	require.NoError(t, err)
+	names := make([]string, len(cupcakes))
+	for i, cupcake := range cupcakes {
+		names[i] = cupcake.Name
+	}
+	require.Equal(t, []string{"Vanilla", "Carrot", "Red Velvet"}, names, "out of stock and unavailable cupcakes are left out")
+	require.NotNil(t, cupcakes[1].EffectivePriceCents, "promotions still apply")
+
+	page, total, err := svc.ListCupcakesAtLocation(location.ID, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Len(t, page, 1)
+	require.Equal(t, "Red Velvet", page[0].Name)
+
+	page, total, err = svc.ListCupcakesAtLocation(location.ID, 5, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Empty(t, page)
+
+	_, _, err = svc.ListCupcakesAtLocation(location.ID, 0, 2)
+	require.EqualError(t, err, "page must be at least 1")
+
+	_, err = svc.GetCupcakesAtLocation(999)
+	require.ErrorIs(t, err, ErrLocationNotFound)
+}
+
+func TestPreOrderCupcakes(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	svc := NewCupcakeService(cupcakeRepo, repository.NewPromotionRepository(db), locationRepo, nil, nil, nil, nil)
+
+	release := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return release.AddDate(0, 0, -7) }
+
+	_, err := svc.CreateCupcake(&models.CreateCupcakeRequest{Name: "Vanilla", Flavor: "Vanilla", PriceCents: 500})
+	require.NoError(t, err)
+	preOrder, err := svc.CreateCupcake(&models.CreateCupcakeRequest{Name: "Panettone", Flavor: "Christmas", PriceCents: 900, AvailableFrom: &release})
+	require.NoError(t, err)
+	require.True(t, preOrder.PreOrder)
+
+	location := &models.Location{Name: "Centro", Address: "Rua Augusta, 100"}
+	require.NoError(t, locationRepo.Create(location))
+	for _, cupcakeID := range []uint{1, 2} {
+		require.NoError(t, locationRepo.SetStock(&models.LocationStock{LocationID: location.ID, CupcakeID: cupcakeID, Quantity: 5}))
+	}
+
+	cupcake, err := svc.GetCupcake(preOrder.ID)
+	require.NoError(t, err)
+	require.True(t, cupcake.PreOrder, "pre-orders can still be looked up")
+	atLocation, err := svc.GetCupcakesAtLocation(location.ID)
+	require.NoError(t, err)
+	require.Len(t, atLocation, 1, "pre-orders are not available now")
+	require.Equal(t, "Vanilla", atLocation[0].Name)
+
+	svc.now = func() time.Time { return release }
+
+	cupcake, err = svc.GetCupcake(preOrder.ID)
+	require.NoError(t, err)
+	require.False(t, cupcake.PreOrder, "the flag flips on the release date")
+	atLocation, err = svc.GetCupcakesAtLocation(location.ID)
+	require.NoError(t, err)
+	require.Len(t, atLocation, 2)
+
+	later := release.AddDate(0, 1, 0)
+	updated, err := svc.UpdateCupcake(preOrder.ID, &models.UpdateCupcakeRequest{AvailableFrom: &later})
+	require.NoError(t, err)
+	require.True(t, updated.PreOrder, "the release date can be pushed back")
+
+	reverted, err := svc.RevertCupcake(preOrder.ID, 1, nil)
+	require.NoError(t, err)
+	require.True(t, reverted.AvailableFrom.Equal(release), "versions keep the release date")
+}
+
+func TestGetRelatedCupcakes(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            uint
+		limit         int
+		expectedNames []string
+		expectedError string
+	}{
+		{name: "same flavor", id: 1, limit: DefaultRelatedLimit, expectedNames: []string{"Vanilla Bean"}},
+		{name: "nothing related", id: 3, limit: DefaultRelatedLimit, expectedNames: []string{}},
+		{name: "unknown cupcake", id: 99, limit: DefaultRelatedLimit, expectedError: repository.ErrNotFound.Error()},
+		{name: "zero limit", id: 1, limit: 0, expectedError: "limit must be between 1 and 20"},
+		{name: "limit too large", id: 1, limit: 21, expectedError: "limit must be between 1 and 20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+			for _, req := range []models.CreateCupcakeRequest{
+				{Name: "Vanilla", Flavor: "Vanilla", PriceCents: 1000},
+				{Name: "Vanilla Bean", Flavor: "Vanilla", PriceCents: 1200},
+				{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1000},
+			} {
+				_, err := service.CreateCupcake(&req)
+				require.NoError(t, err)
+			}
+
+			related, err := service.GetRelatedCupcakes(tt.id, tt.limit)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			names := []string{}
+			for _, cupcake := range related {
+				names = append(names, cupcake.Name)
+			}
+			require.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestCreateCupcake_Duplicates(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         models.CreateCupcakeRequest
+		expectedMatches []string
+	}{
+		{name: "same name in another case", request: models.CreateCupcakeRequest{Name: "red velvet", Flavor: "Cocoa", PriceCents: 1000}, expectedMatches: []string{"Red Velvet"}},
+		{name: "typo", request: models.CreateCupcakeRequest{Name: "Choclate Fudge", Flavor: "Cocoa", PriceCents: 1000}, expectedMatches: []string{"Chocolate Fudge"}},
+		{name: "extra spaces", request: models.CreateCupcakeRequest{Name: "  Red   Velvet ", Flavor: "Cocoa", PriceCents: 1000}, expectedMatches: []string{"Red Velvet"}},
+		{name: "different name", request: models.CreateCupcakeRequest{Name: "Lemon Meringue", Flavor: "Lemon", PriceCents: 1000}},
+		{name: "longer variant", request: models.CreateCupcakeRequest{Name: "Red Velvet Deluxe", Flavor: "Cocoa", PriceCents: 1000}},
+		{name: "different number", request: models.CreateCupcakeRequest{Name: "Box of 12", Flavor: "Mixed", PriceCents: 1000}},
+		{name: "forced", request: models.CreateCupcakeRequest{Name: "Red Velvet", Flavor: "Cocoa", PriceCents: 1000, Force: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestService(t)
+			for _, name := range []string{"Red Velvet", "Chocolate Fudge", "Box of 6"} {
+				_, err := service.CreateCupcake(&models.CreateCupcakeRequest{Name: name, Flavor: "Cocoa", PriceCents: 1000})
+				require.NoError(t, err)
+			}
+
+			cupcake, err := service.CreateCupcake(&tt.request)
+			if tt.expectedMatches == nil {
+				require.NoError(t, err)
+				require.NotZero(t, cupcake.ID)
+				return
+			}
+
+			var duplicates *DuplicateCupcakeError
+			require.ErrorAs(t, err, &duplicates)
+			names := []string{}
+			for _, match := range duplicates.Matches {
+				names = append(names, match.Name)
+			}
+			require.Equal(t, tt.expectedMatches, names)
+			require.Nil(t, cupcake)
+		})
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"vanilla", "vanilla", 1},
+		{"", "", 1},
+		{"vanilla", "", 0},
+		{"chocolate", "choclate", 1 - 1.0/9},
+		{"pão de mel", "pao de mel", 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			require.InDelta(t, tt.expected, nameSimilarity(tt.a, tt.b), 1e-9)
+		})
+	}
+}
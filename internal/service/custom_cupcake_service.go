@@ -0,0 +1,198 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/money"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// MaxCustomToppings is how many toppings fit on one custom cupcake.
+const MaxCustomToppings = 3
+
+var ErrCustomOptionNotFound = errors.New("custom option not found")
+
+type CustomCupcakeService struct {
+	repo repository.CustomOptionRepositoryInterface
+}
+
+var _ CustomCupcakeServiceInterface = (*CustomCupcakeService)(nil)
+
+func NewCustomCupcakeService(repo repository.CustomOptionRepositoryInterface) *CustomCupcakeService {
+	return &CustomCupcakeService{repo: repo}
+}
+
+func (s *CustomCupcakeService) CreateOption(req *models.CreateCustomOptionRequest) (*models.CustomOption, error) {
+	switch req.Kind {
+	case models.CustomOptionBase, models.CustomOptionFrosting, models.CustomOptionTopping:
+	default:
+		return nil, i18n.NewError(msgCustomKindInvalid, nil)
+	}
+
+	option := &models.CustomOption{
+		Kind:        req.Kind,
+		Name:        strings.TrimSpace(req.Name),
+		PriceCents:  req.PriceCents,
+		IsAvailable: true,
+	}
+	if err := validateCustomOption(option); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(option); err != nil {
+		return nil, err
+	}
+
+	return option, nil
+}
+
+func (s *CustomCupcakeService) GetAllOptions() ([]models.CustomOption, error) {
+	return s.repo.FindAll()
+}
+
+// GetAvailableOptions is the builder menu shown to customers.
+func (s *CustomCupcakeService) GetAvailableOptions() ([]models.CustomOption, error) {
+	options, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]models.CustomOption, 0, len(options))
+	for _, option := range options {
+		if option.IsAvailable {
+			available = append(available, option)
+		}
+	}
+	return available, nil
+}
+
+func (s *CustomCupcakeService) UpdateOption(id uint, req *models.UpdateCustomOptionRequest) (*models.CustomOption, error) {
+	option, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrCustomOptionNotFound
+		}
+		return nil, err
+	}
+
+	if req.Name != nil {
+		option.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.PriceCents != nil {
+		option.PriceCents = *req.PriceCents
+	}
+
+	if req.IsAvailable != nil {
+		option.IsAvailable = *req.IsAvailable
+	}
+
+	if err := validateCustomOption(option); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(option); err != nil {
+		return nil, err
+	}
+
+	return option, nil
+}
+
+func (s *CustomCupcakeService) DeleteOption(id uint) error {
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrCustomOptionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Quote validates a custom combination and prices it from the current
+// option prices: one base, one frosting and up to MaxCustomToppings
+// different toppings, all of them available.
+func (s *CustomCupcakeService) Quote(req *models.CustomCupcakeQuoteRequest) (*models.CustomCupcakeQuote, error) {
+	if req.BaseID == 0 {
+		return nil, i18n.NewError(msgBaseRequired, nil)
+	}
+	if req.FrostingID == 0 {
+		return nil, i18n.NewError(msgFrostingRequired, nil)
+	}
+	if len(req.ToppingIDs) > MaxCustomToppings {
+		return nil, i18n.NewError(msgTooManyToppings, map[string]any{"Max": MaxCustomToppings})
+	}
+
+	ids := []uint{req.BaseID, req.FrostingID}
+	seen := make(map[uint]bool, len(req.ToppingIDs))
+	for _, id := range req.ToppingIDs {
+		if seen[id] {
+			return nil, i18n.NewError(msgToppingRepeated, nil)
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	options, err := s.repo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.CustomOption, len(options))
+	for _, option := range options {
+		byID[option.ID] = option
+	}
+
+	pick := func(id uint, kind string) (models.CustomOption, error) {
+		option, ok := byID[id]
+		if !ok {
+			return option, i18n.NewError(msgCustomOptionIDNotFound, map[string]any{"ID": id})
+		}
+		if option.Kind != kind {
+			return option, i18n.NewError(msgCustomOptionWrongKind, map[string]any{"Name": option.Name, "Kind": kind})
+		}
+		if !option.IsAvailable {
+			return option, i18n.NewError(msgNotAvailable, map[string]any{"Name": option.Name})
+		}
+		return option, nil
+	}
+
+	quote := &models.CustomCupcakeQuote{Toppings: make([]models.CustomOption, 0, len(req.ToppingIDs))}
+	if quote.Base, err = pick(req.BaseID, models.CustomOptionBase); err != nil {
+		return nil, err
+	}
+	if quote.Frosting, err = pick(req.FrostingID, models.CustomOptionFrosting); err != nil {
+		return nil, err
+	}
+	prices := []money.Money{money.Cents(quote.Base.PriceCents), money.Cents(quote.Frosting.PriceCents)}
+	for _, id := range req.ToppingIDs {
+		topping, err := pick(id, models.CustomOptionTopping)
+		if err != nil {
+			return nil, err
+		}
+		quote.Toppings = append(quote.Toppings, topping)
+		prices = append(prices, money.Cents(topping.PriceCents))
+	}
+
+	if quote.PriceCents, err = cents(money.Sum(prices...)); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+func validateCustomOption(option *models.CustomOption) error {
+	if option.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if len(option.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if option.PriceCents < 0 {
+		return i18n.NewError(msgPriceNegative, nil)
+	}
+	if option.Kind == models.CustomOptionBase && option.PriceCents == 0 {
+		return i18n.NewError(msgBasePriceNotPositive, nil)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCustomCupcakeService returns a service whose builder menu holds:
+// 1 Vanilla base (800), 2 Chocolate base (900), 3 Cream cheese frosting
+// (200), 4 Sprinkles (50), 5 Strawberries (150), 6 Nuts (100, unavailable)
+// and 7 Caramel (120) toppings.
+func newTestCustomCupcakeService(t *testing.T) *CustomCupcakeService {
+	t.Helper()
+
+	svc := NewCustomCupcakeService(repository.NewCustomOptionRepository(setupTestDB(t)))
+	for _, req := range []models.CreateCustomOptionRequest{
+		{Kind: models.CustomOptionBase, Name: "Vanilla", PriceCents: 800},
+		{Kind: models.CustomOptionBase, Name: "Chocolate", PriceCents: 900},
+		{Kind: models.CustomOptionFrosting, Name: "Cream cheese", PriceCents: 200},
+		{Kind: models.CustomOptionTopping, Name: "Sprinkles", PriceCents: 50},
+		{Kind: models.CustomOptionTopping, Name: "Strawberries", PriceCents: 150},
+		{Kind: models.CustomOptionTopping, Name: "Nuts", PriceCents: 100},
+		{Kind: models.CustomOptionTopping, Name: "Caramel", PriceCents: 120},
+	} {
+		_, err := svc.CreateOption(&req)
+		require.NoError(t, err)
+	}
+	_, err := svc.UpdateOption(6, &models.UpdateCustomOptionRequest{IsAvailable: boolPtr(false)})
+	require.NoError(t, err)
+	return svc
+}
+
+func TestCreateCustomOption(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateCustomOptionRequest
+		expectedError string
+	}{
+		{name: "success", request: &models.CreateCustomOptionRequest{Kind: models.CustomOptionTopping, Name: " Oreo ", PriceCents: 80}},
+		{name: "free frosting", request: &models.CreateCustomOptionRequest{Kind: models.CustomOptionFrosting, Name: "Plain", PriceCents: 0}},
+		{name: "unknown kind", request: &models.CreateCustomOptionRequest{Kind: "filling", Name: "Jam", PriceCents: 80}, expectedError: "kind must be base, frosting or topping"},
+		{name: "missing name", request: &models.CreateCustomOptionRequest{Kind: models.CustomOptionTopping, PriceCents: 80}, expectedError: "name is required"},
+		{name: "negative price", request: &models.CreateCustomOptionRequest{Kind: models.CustomOptionTopping, Name: "Oreo", PriceCents: -1}, expectedError: "price cannot be negative"},
+		{name: "free base", request: &models.CreateCustomOptionRequest{Kind: models.CustomOptionBase, Name: "Lemon"}, expectedError: "base price must be greater than zero"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewCustomCupcakeService(repository.NewCustomOptionRepository(setupTestDB(t)))
+
+			option, err := svc.CreateOption(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, option.ID)
+			require.True(t, option.IsAvailable)
+			require.Equal(t, strings.TrimSpace(tt.request.Name), option.Name)
+		})
+	}
+}
+
+func TestQuoteCustomCupcake(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CustomCupcakeQuoteRequest
+		expectedPrice int
+		expectedError string
+	}{
+		{
+			name:          "base and frosting only",
+			request:       &models.CustomCupcakeQuoteRequest{BaseID: 1, FrostingID: 3},
+			expectedPrice: 1000,
+		},
+		{
+			name:          "with toppings",
+			request:       &models.CustomCupcakeQuoteRequest{BaseID: 2, FrostingID: 3, ToppingIDs: []uint{4, 5, 7}},
+			expectedPrice: 1420,
+		},
+		{
+			name:          "missing base",
+			request:       &models.CustomCupcakeQuoteRequest{FrostingID: 3},
+			expectedError: "base is required",
+		},
+		{
+			name:          "missing frosting",
+			request:       &models.CustomCupcakeQuoteRequest{BaseID: 1},
+			expectedError: "frosting is required",
+		},
+		{
+			name:          "too many toppings",
+			request:       &models.CustomCupcakeQuoteRequest{BaseID: 1, FrostingID: 3, ToppingIDs: []uint{4, 5, 7, 6}},
+			expectedError: "at most 3 toppings are allowed",
+		},
+		{
+			name:          "repeated topping",
+			request:       &models.CustomCupcakeQuoteRequest{BaseID: 1, FrostingID: 3, ToppingIDs: []uint{4, 4}},
+			expectedError: "toppings cannot be repeated",
+		},
+		{
+			name:          "option of the wrong kind",
+			request:       &models.CustomCupcakeQuoteRequest{BaseID: 1, FrostingID: 2},
+			expectedError: "Chocolate is not a frosting",
+		},
+		{
+			name:          "unavailable topping",
+			request:       &models.CustomCupcakeQuoteRequest{BaseID: 1, FrostingID: 3, ToppingIDs: []uint{6}},
+			expectedError: "Nuts is not available",
+		},
+		{
+			name:          "unknown option",
+			request:       &models.CustomCupcakeQuoteRequest{BaseID: 1, FrostingID: 3, ToppingIDs: []uint{999}},
+			expectedError: "option 999 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestCustomCupcakeService(t)
+
+			quote, err := svc.Quote(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedPrice, quote.PriceCents)
+			require.Len(t, quote.Toppings, len(tt.request.ToppingIDs))
+		})
+	}
+}
+
+func TestGetAvailableCustomOptions(t *testing.T) {
+	svc := newTestCustomCupcakeService(t)
+
+	available, err := svc.GetAvailableOptions()
+	require.NoError(t, err)
+	require.Len(t, available, 6)
+	for _, option := range available {
+		require.NotEqual(t, "Nuts", option.Name)
+	}
+
+	all, err := svc.GetAllOptions()
+	require.NoError(t, err)
+	require.Len(t, all, 7)
+
+	require.ErrorIs(t, svc.DeleteOption(999), ErrCustomOptionNotFound)
+	_, err = svc.UpdateOption(999, &models.UpdateCustomOptionRequest{})
+	require.ErrorIs(t, err, ErrCustomOptionNotFound)
+}
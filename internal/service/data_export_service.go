@@ -0,0 +1,285 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	dataExportJob = "data_export.generate"
+
+	// DataExportLinkTTL is how long a finished export can be downloaded.
+	DataExportLinkTTL = 24 * time.Hour
+)
+
+var (
+	ErrDataExportNotFound    = errors.New("data export not found")
+	ErrDataExportLinkInvalid = errors.New("download link is invalid")
+	ErrDataExportExpired     = errors.New("download link has expired")
+	// ErrDataExportUnavailable is returned for requests while no email
+	// sender is configured, as the link would have no way to the customer.
+	ErrDataExportUnavailable = errors.New("data exports are not available")
+)
+
+type dataExportPayload struct {
+	ExportID uint `json:"export_id"`
+}
+
+// DataExportService builds customers' copies of their data in the
+// background. The download link is never returned to the caller nor
+// published as an event: it is emailed to the address, so only whoever
+// receives mail there can fetch the archive.
+type DataExportService struct {
+	repo      repository.DataExportRepositoryInterface
+	jobs      *JobService
+	email     EmailSender
+	publicURL string
+	signer    linkSigner
+	now       func() time.Time
+}
+
+var _ DataExportServiceInterface = (*DataExportService)(nil)
+
+// NewDataExportService signs download links with secret. Without one, a
+// random secret is used, and links only work on this instance until it
+// restarts.
+func NewDataExportService(repo repository.DataExportRepositoryInterface, jobs *JobService, secret string) *DataExportService {
+	s := &DataExportService{repo: repo, jobs: jobs, signer: newLinkSigner(secret), now: time.Now}
+	jobs.Register(dataExportJob, s.generate)
+	return s
+}
+
+// WithEmail sends the download links through sender, as links under
+// publicURL. Until it is called, requests fail with
+// ErrDataExportUnavailable.
+func (s *DataExportService) WithEmail(sender EmailSender, publicURL string) *DataExportService {
+	s.email = sender
+	s.publicURL = strings.TrimSuffix(publicURL, "/")
+	return s
+}
+
+// Request queues an export of the data stored under email. While one is
+// still being built, that one is returned instead of queueing another.
+func (s *DataExportService) Request(email string) (*models.DataExport, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, i18n.NewError(msgEmailRequired, nil)
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, i18n.NewError(msgEmailInvalid, nil)
+	}
+	if s.email == nil {
+		return nil, ErrDataExportUnavailable
+	}
+
+	if _, err := s.repo.DeleteExpired(s.now()); err != nil {
+		log.Printf("Error deleting expired data exports: %v", err)
+	}
+
+	pending, err := s.repo.FindPending(email)
+	if err == nil {
+		return pending, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	export := &models.DataExport{CustomerEmail: email, Status: models.DataExportPending}
+	if err := s.repo.Create(export); err != nil {
+		return nil, err
+	}
+	if err := s.jobs.Enqueue(dataExportJob, dataExportPayload{ExportID: export.ID}); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Download checks a signed link and returns the export it points to.
+func (s *DataExportService) Download(id uint, expires int64, signature string) (*models.DataExport, error) {
+	if !s.signer.valid(signature, id, expires) {
+		return nil, ErrDataExportLinkInvalid
+	}
+	if !s.now().Before(time.Unix(expires, 0)) {
+		return nil, ErrDataExportExpired
+	}
+
+	export, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrDataExportNotFound
+		}
+		return nil, err
+	}
+	if export.Status != models.DataExportReady {
+		return nil, ErrDataExportNotFound
+	}
+	return export, nil
+}
+
+// DownloadPath returns the signed link of a finished export.
+func (s *DataExportService) DownloadPath(export *models.DataExport) string {
+	expires := export.ExpiresAt.Unix()
+	return fmt.Sprintf("/api/v1/data-exports/%d/download?expires=%d&signature=%s", export.ID, expires, s.signer.sign(export.ID, expires))
+}
+
+func (s *DataExportService) generate(job *models.Job) error {
+	var payload dataExportPayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return err
+	}
+	export, err := s.repo.FindByID(payload.ExportID)
+	if errors.Is(err, repository.ErrNotFound) {
+		// Expired and deleted before the job ran; nothing left to do.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := s.repo.FindCustomerData(export.CustomerEmail)
+	if err != nil {
+		return err
+	}
+	data.ExportedAt = s.now().UTC()
+	data.Profile.Names = customerNames(data)
+
+	archive, err := buildDataExportArchive(data)
+	if err != nil {
+		export.Status = models.DataExportFailed
+		if updateErr := s.repo.Update(export); updateErr != nil {
+			log.Printf("Error marking data export %d failed: %v", export.ID, updateErr)
+		}
+		return err
+	}
+
+	now := s.now()
+	expiresAt := now.Add(DataExportLinkTTL)
+	export.Status = models.DataExportReady
+	export.Archive = archive
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	if err := s.repo.Update(export); err != nil {
+		return err
+	}
+	return s.sendLink(export)
+}
+
+// sendLink emails the download link of a finished export to its address.
+// A failure fails the job, which builds the archive again on its retry.
+func (s *DataExportService) sendLink(export *models.DataExport) error {
+	if s.email == nil {
+		return ErrDataExportUnavailable
+	}
+	link := s.publicURL + s.DownloadPath(export)
+	email := &models.RenderedEmail{
+		Subject: "Your data export is ready",
+		HTML: fmt.Sprintf("<p>Hi,</p>\n<p>The copy of your data you asked for is ready. <a href=\"%s\">Download it</a> before %s UTC.</p>\n<p>If you did not ask for it, you can ignore this email.</p>\n",
+			html.EscapeString(link), export.ExpiresAt.UTC().Format("02/01/2006 15:04")),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), emailSendTimeout)
+	defer cancel()
+	return s.email.Send(ctx, export.CustomerEmail, email)
+}
+
+// customerNames lists the distinct names given with the customer's email.
+func customerNames(data *models.CustomerData) []string {
+	names := []string{}
+	add := func(name string) {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if data.Account != nil {
+		add(data.Account.Name)
+	}
+	for _, reservation := range data.PickupReservations {
+		add(reservation.CustomerName)
+	}
+	if data.WholesaleAccount != nil {
+		add(data.WholesaleAccount.Name)
+	}
+	return names
+}
+
+// buildDataExportArchive zips the complete data as data.json, with the
+// subscriptions and pickup reservations also as CSV for spreadsheets.
+func buildDataExportArchive(data *models.CustomerData) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	f, err := archive.Create("data.json")
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return nil, err
+	}
+
+	subscriptions := [][]string{{"id", "cupcake_id", "quantity", "frequency", "status", "next_delivery_at", "created_at"}}
+	for _, subscription := range data.Subscriptions {
+		subscriptions = append(subscriptions, []string{
+			strconv.FormatUint(uint64(subscription.ID), 10),
+			strconv.FormatUint(uint64(subscription.CupcakeID), 10),
+			strconv.Itoa(subscription.Quantity),
+			subscription.Frequency,
+			subscription.Status,
+			subscription.NextDeliveryAt.UTC().Format(time.RFC3339),
+			subscription.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	if err := writeCSV(archive, "subscriptions.csv", subscriptions); err != nil {
+		return nil, err
+	}
+
+	// One row per item, repeating the reservation columns.
+	reservations := [][]string{{"reservation_id", "slot_id", "customer_name", "cupcake_id", "quantity", "created_at"}}
+	for _, reservation := range data.PickupReservations {
+		for _, item := range reservation.Items {
+			reservations = append(reservations, []string{
+				strconv.FormatUint(uint64(reservation.ID), 10),
+				strconv.FormatUint(uint64(reservation.SlotID), 10),
+				reservation.CustomerName,
+				strconv.FormatUint(uint64(item.CupcakeID), 10),
+				strconv.Itoa(item.Quantity),
+				reservation.CreatedAt.UTC().Format(time.RFC3339),
+			})
+		}
+	}
+	if err := writeCSV(archive, "pickup_reservations.csv", reservations); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCSV(archive *zip.Writer, name string, records [][]string) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.WriteAll(records)
+	return w.Error()
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"html"
+	"io"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExportService(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(&models.Subscription{CustomerEmail: "Ana@example.com", CupcakeID: 1, Quantity: 2, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: time.Now()}).Error)
+	require.NoError(t, db.Create(&models.Subscription{CustomerEmail: "bruno@example.com", CupcakeID: 1, Quantity: 1, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: time.Now()}).Error)
+	require.NoError(t, db.Create(&models.PickupReservation{SlotID: 1, CustomerName: "Ana Lima", CustomerEmail: "ana@example.com", Items: []models.PickupReservationItem{{CupcakeID: 1, Quantity: 3}}}).Error)
+
+	type sentEmail struct {
+		to    string
+		email *models.RenderedEmail
+	}
+	var sent []sentEmail
+	sender := &mocks.EmailSender{SendFunc: func(_ context.Context, to string, email *models.RenderedEmail) error {
+		sent = append(sent, sentEmail{to, email})
+		return nil
+	}}
+	jobs := NewJobService(repository.NewJobRepository(db))
+	svc := NewDataExportService(repository.NewDataExportRepository(db), jobs, "test-secret")
+
+	_, err := svc.Request("ana@example.com")
+	require.ErrorIs(t, err, ErrDataExportUnavailable)
+	svc.WithEmail(sender, "https://loja.example.com/")
+
+	_, err = svc.Request("not an email")
+	require.EqualError(t, err, "email is invalid")
+
+	export, err := svc.Request(" ana@example.com ")
+	require.NoError(t, err)
+	require.Equal(t, models.DataExportPending, export.Status)
+
+	// Asking again while the export is being built does not queue another.
+	again, err := svc.Request("ANA@example.com")
+	require.NoError(t, err)
+	require.Equal(t, export.ID, again.ID)
+
+	drainJobs(t, jobs)
+	require.Len(t, sent, 1)
+	require.Equal(t, "ana@example.com", sent[0].to)
+
+	href := regexp.MustCompile(`href="([^"]+)"`).FindStringSubmatch(sent[0].email.HTML)
+	require.NotNil(t, href)
+	link, err := url.Parse(html.UnescapeString(href[1]))
+	require.NoError(t, err)
+	require.Equal(t, "loja.example.com", link.Host)
+	require.Equal(t, "/api/v1/data-exports/"+strconv.Itoa(int(export.ID))+"/download", link.Path)
+	expires, err := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+
+	downloaded, err := svc.Download(export.ID, expires, link.Query().Get("signature"))
+	require.NoError(t, err)
+	files := readDataExportArchive(t, downloaded.Archive)
+	require.ElementsMatch(t, []string{"data.json", "subscriptions.csv", "pickup_reservations.csv"}, slices.Collect(maps.Keys(files)))
+
+	var data models.CustomerData
+	require.NoError(t, json.Unmarshal([]byte(files["data.json"]), &data))
+	require.Len(t, data.Subscriptions, 1)
+	require.Len(t, data.PickupReservations, 1)
+	require.Equal(t, []string{"Ana Lima"}, data.Profile.Names)
+	require.Len(t, strings.Split(strings.TrimSpace(files["pickup_reservations.csv"]), "\n"), 2)
+
+	_, err = svc.Download(export.ID, expires+1, link.Query().Get("signature"))
+	require.ErrorIs(t, err, ErrDataExportLinkInvalid)
+	_, err = svc.Download(export.ID+1, expires, link.Query().Get("signature"))
+	require.ErrorIs(t, err, ErrDataExportLinkInvalid)
+
+	svc.now = func() time.Time { return time.Now().Add(DataExportLinkTTL + time.Minute) }
+	_, err = svc.Download(export.ID, expires, link.Query().Get("signature"))
+	require.ErrorIs(t, err, ErrDataExportExpired)
+
+	// Expired exports are deleted with the next request.
+	_, err = svc.Request("bruno@example.com")
+	require.NoError(t, err)
+	var count int64
+	require.NoError(t, db.Model(&models.DataExport{}).Where("id = ?", export.ID).Count(&count).Error)
+	require.Zero(t, count)
+}
+
+func readDataExportArchive(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(body)
+	}
+	return files
+}
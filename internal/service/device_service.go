@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var ErrDeviceNotFound = errors.New("device not found")
+
+// maxDeviceTokenLength is the size of the token column. FCM tokens are
+// well under it.
+const maxDeviceTokenLength = 512
+
+// DeviceService keeps the push tokens of each account's devices, which
+// the push channel of the notifications is sent to.
+type DeviceService struct {
+	repo repository.DeviceTokenRepositoryInterface
+}
+
+var _ DeviceServiceInterface = (*DeviceService)(nil)
+
+func NewDeviceService(repo repository.DeviceTokenRepositoryInterface) *DeviceService {
+	return &DeviceService{repo: repo}
+}
+
+// GetDevices lists the devices registered to the account.
+func (s *DeviceService) GetDevices(accountID uint) ([]models.DeviceToken, error) {
+	return s.repo.FindByAccount(accountID)
+}
+
+// RegisterDevice adds a device to the account. Registering a token again,
+// from this account or another, keeps one device with it.
+func (s *DeviceService) RegisterDevice(accountID uint, req *models.RegisterDeviceRequest) (*models.DeviceToken, error) {
+	token := strings.TrimSpace(req.Token)
+	switch {
+	case token == "":
+		return nil, i18n.NewError(msgDeviceTokenRequired, nil)
+	case len(token) > maxDeviceTokenLength:
+		return nil, i18n.NewError(msgDeviceTokenTooLong, nil)
+	}
+	switch req.Platform {
+	case models.PlatformAndroid, models.PlatformIOS, models.PlatformWeb:
+	default:
+		return nil, i18n.NewError(msgDevicePlatformInvalid, nil)
+	}
+
+	device := &models.DeviceToken{AccountID: accountID, Token: token, Platform: req.Platform}
+	if err := s.repo.Save(device); err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// UnregisterDevice removes one of the account's devices, as the app does
+// on logout.
+func (s *DeviceService) UnregisterDevice(accountID uint, token string) error {
+	err := s.repo.Delete(accountID, token)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrDeviceNotFound
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceService(t *testing.T) {
+	svc := NewDeviceService(repository.NewDeviceTokenRepository(setupTestDB(t)))
+
+	_, err := svc.RegisterDevice(1, &models.RegisterDeviceRequest{Token: " ", Platform: models.PlatformAndroid})
+	require.EqualError(t, err, "token is required")
+	_, err = svc.RegisterDevice(1, &models.RegisterDeviceRequest{Token: "phone", Platform: "symbian"})
+	require.EqualError(t, err, "platform must be android, ios or web")
+
+	device, err := svc.RegisterDevice(1, &models.RegisterDeviceRequest{Token: " phone ", Platform: models.PlatformAndroid})
+	require.NoError(t, err)
+	require.Equal(t, "phone", device.Token)
+
+	devices, err := svc.GetDevices(1)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+
+	require.ErrorIs(t, svc.UnregisterDevice(2, "phone"), ErrDeviceNotFound)
+	require.NoError(t, svc.UnregisterDevice(1, "phone"))
+	devices, err = svc.GetDevices(1)
+	require.NoError(t, err)
+	require.Empty(t, devices)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+// EmailSender sends email through a mail server or provider.
+type EmailSender interface {
+	// Send delivers email to the address to.
+	Send(ctx context.Context, to string, email *models.RenderedEmail) error
+}
+
+// emailSendTimeout bounds how long a job waits on the mail server.
+const emailSendTimeout = 30 * time.Second
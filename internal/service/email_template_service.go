@@ -0,0 +1,296 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	htmltemplate "html/template"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var ErrEmailTemplateNotFound = errors.New("email template not found")
+
+// emailTemplate is a built-in email: its default copy and the sample data
+// it is previewed and checked with.
+type emailTemplate struct {
+	subject string
+	body    string
+	sample  func(store models.EmailStore, now time.Time) any
+}
+
+var emailTemplates = map[string]emailTemplate{
+	models.EmailOrderConfirmation: {
+		subject: `Your order #{{.OrderID}} at {{.Store.Name}}`,
+		body: `<p>Hi {{.CustomerName}},</p>
+<p>Thanks for your order! It will be ready for pickup at {{.Store.Name}} on {{.PickupAt.Format "02/01/2006 15:04"}}.</p>
+<ul>
+{{- range .Items}}
+<li>{{.Quantity}} × {{.Name}}</li>
+{{- end}}
+</ul>
+<p>{{.Store.Address}}{{with .Store.Hours}}<br>{{.}}{{end}}</p>
+`,
+		sample: func(store models.EmailStore, now time.Time) any {
+			return models.OrderConfirmationEmail{
+				Store:        store,
+				CustomerName: "Ana Lima",
+				OrderID:      1042,
+				PickupAt:     now.AddDate(0, 0, 1).Truncate(time.Hour),
+				Items: []models.EmailOrderItem{
+					{Name: "Red Velvet", Quantity: 2},
+					{Name: "Chocolate Belga", Quantity: 4},
+				},
+			}
+		},
+	},
+	models.EmailPasswordReset: {
+		subject: `Reset your {{.Store.Name}} password`,
+		body: `<p>Hi {{.CustomerName}},</p>
+<p>We got a request to reset your password. <a href="{{.ResetURL}}">Choose a new password</a> before {{.ExpiresAt.Format "02/01/2006 15:04"}}.</p>
+<p>If you did not ask for it, you can ignore this email.</p>
+`,
+		sample: func(store models.EmailStore, now time.Time) any {
+			return models.PasswordResetEmail{
+				Store:        store,
+				CustomerName: "Ana Lima",
+				ResetURL:     "https://example.com/reset?token=sample",
+				ExpiresAt:    now.Add(time.Hour).Truncate(time.Minute),
+			}
+		},
+	},
+	models.EmailWaitlist: {
+		subject: `{{.CupcakeName}} is back at {{.Store.Name}}`,
+		body: `<p>Hi {{.CustomerName}},</p>
+<p>Good news: {{.CupcakeName}} is available again. <a href="{{.CupcakeURL}}">Order yours</a> before it runs out.</p>
+`,
+		sample: func(store models.EmailStore, now time.Time) any {
+			return models.WaitlistEmail{
+				Store:        store,
+				CustomerName: "Ana Lima",
+				CupcakeName:  "Red Velvet",
+				CupcakeURL:   "https://example.com/cupcakes/1",
+			}
+		},
+	},
+}
+
+// sampleStore stands in for the store when a template for every store is
+// previewed.
+var sampleStore = models.EmailStore{Name: "Cupcake Store", Address: "Rua das Flores, 123 - São Paulo", Hours: "Mon-Sat 9am-7pm"}
+
+// EmailTemplateService keeps the copy of transactional emails. Each email
+// has a built-in template that admins can override for every store or for
+// one location, with the location's override winning; overrides are
+// stored, so copy changes without a deploy.
+type EmailTemplateService struct {
+	repo      repository.EmailTemplateRepositoryInterface
+	locations repository.LocationRepositoryInterface
+	now       func() time.Time
+}
+
+var _ EmailTemplateServiceInterface = (*EmailTemplateService)(nil)
+
+func NewEmailTemplateService(repo repository.EmailTemplateRepositoryInterface, locations repository.LocationRepositoryInterface) *EmailTemplateService {
+	return &EmailTemplateService{repo: repo, locations: locations, now: time.Now}
+}
+
+// GetTemplates returns the template each email is sent with at
+// locationID, or at every store when it is 0.
+func (s *EmailTemplateService) GetTemplates(locationID uint) ([]models.EmailTemplateResponse, error) {
+	if err := s.checkLocation(locationID); err != nil {
+		return nil, err
+	}
+	overrides, err := s.repo.FindAll(locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(emailTemplates))
+	for name := range emailTemplates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	templates := make([]models.EmailTemplateResponse, 0, len(names))
+	for _, name := range names {
+		templates = append(templates, effectiveEmailTemplate(name, locationID, overrides))
+	}
+	return templates, nil
+}
+
+func (s *EmailTemplateService) GetTemplate(name string, locationID uint) (*models.EmailTemplateResponse, error) {
+	if _, ok := emailTemplates[name]; !ok {
+		return nil, ErrEmailTemplateNotFound
+	}
+	if err := s.checkLocation(locationID); err != nil {
+		return nil, err
+	}
+	overrides, err := s.repo.FindAll(locationID)
+	if err != nil {
+		return nil, err
+	}
+	effective := effectiveEmailTemplate(name, locationID, overrides)
+	return &effective, nil
+}
+
+// SetTemplate overrides an email's template. The new copy is rendered with
+// the sample data first, so a typo in a field name is caught here rather
+// than when the email is sent.
+func (s *EmailTemplateService) SetTemplate(name string, req *models.UpdateEmailTemplateRequest) (*models.EmailTemplateResponse, error) {
+	builtin, ok := emailTemplates[name]
+	if !ok {
+		return nil, ErrEmailTemplateNotFound
+	}
+	store, err := s.store(req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := strings.TrimSpace(req.Subject)
+	if subject == "" {
+		return nil, i18n.NewError(msgSubjectRequired, nil)
+	}
+	if utf8.RuneCountInString(subject) > 200 {
+		return nil, i18n.NewError(msgSubjectTooLong, nil)
+	}
+	if strings.TrimSpace(req.Body) == "" {
+		return nil, i18n.NewError(msgBodyRequired, nil)
+	}
+	if _, err := renderEmail(name, subject, req.Body, builtin.sample(store, s.now())); err != nil {
+		return nil, err
+	}
+
+	override := &models.EmailTemplate{Name: name, LocationID: req.LocationID, Subject: subject, Body: req.Body}
+	if err := s.repo.Set(override); err != nil {
+		return nil, err
+	}
+	return s.GetTemplate(name, req.LocationID)
+}
+
+// ResetTemplate removes the override of an email at locationID, or the
+// one for every store when it is 0.
+func (s *EmailTemplateService) ResetTemplate(name string, locationID uint) error {
+	if _, ok := emailTemplates[name]; !ok {
+		return ErrEmailTemplateNotFound
+	}
+	if err := s.repo.Delete(name, locationID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Preview renders an email with sample data, using the subject and body
+// of req when given and the effective template otherwise.
+func (s *EmailTemplateService) Preview(name string, req *models.PreviewEmailTemplateRequest) (*models.RenderedEmail, error) {
+	builtin, ok := emailTemplates[name]
+	if !ok {
+		return nil, ErrEmailTemplateNotFound
+	}
+	store, err := s.store(req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	effective, err := s.GetTemplate(name, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, body := effective.Subject, effective.Body
+	if req.Subject != nil {
+		subject = *req.Subject
+	}
+	if req.Body != nil {
+		body = *req.Body
+	}
+	return renderEmail(name, subject, body, builtin.sample(store, s.now()))
+}
+
+// Render fills in the template an email is sent with at locationID with
+// data, which must be the email's models type, e.g.
+// models.OrderConfirmationEmail.
+func (s *EmailTemplateService) Render(name string, locationID uint, data any) (*models.RenderedEmail, error) {
+	effective, err := s.GetTemplate(name, locationID)
+	if err != nil {
+		return nil, err
+	}
+	return renderEmail(name, effective.Subject, effective.Body, data)
+}
+
+func (s *EmailTemplateService) checkLocation(locationID uint) error {
+	if locationID == 0 {
+		return nil
+	}
+	_, err := findLocation(s.locations, locationID)
+	return err
+}
+
+// store is the sample store for previews at locationID: the location
+// itself, or sampleStore for every store.
+func (s *EmailTemplateService) store(locationID uint) (models.EmailStore, error) {
+	if locationID == 0 {
+		return sampleStore, nil
+	}
+	location, err := findLocation(s.locations, locationID)
+	if err != nil {
+		return models.EmailStore{}, err
+	}
+	return models.EmailStore{Name: location.Name, Address: location.Address, Hours: location.Hours}, nil
+}
+
+// effectiveEmailTemplate picks the location's override, then the one for
+// every store, then the built-in.
+func effectiveEmailTemplate(name string, locationID uint, overrides []models.EmailTemplate) models.EmailTemplateResponse {
+	var chosen *models.EmailTemplate
+	for i := range overrides {
+		override := &overrides[i]
+		if override.Name != name {
+			continue
+		}
+		if override.LocationID == locationID || (override.LocationID == 0 && chosen == nil) {
+			chosen = override
+		}
+	}
+
+	if chosen == nil {
+		builtin := emailTemplates[name]
+		return models.EmailTemplateResponse{Name: name, LocationID: locationID, Subject: builtin.subject, Body: builtin.body, Source: models.EmailTemplateDefault}
+	}
+	source := models.EmailTemplateStore
+	if chosen.LocationID != 0 {
+		source = models.EmailTemplateLocation
+	}
+	return models.EmailTemplateResponse{Name: name, LocationID: locationID, Subject: chosen.Subject, Body: chosen.Body, Source: source, UpdatedAt: &chosen.UpdatedAt}
+}
+
+// renderEmail runs the subject as a text template and the body as an HTML
+// one, which escapes the data it is given.
+func renderEmail(name, subject, body string, data any) (*models.RenderedEmail, error) {
+	subjectTemplate, err := template.New(name + ".subject").Parse(subject)
+	if err != nil {
+		return nil, templateError(err)
+	}
+	bodyTemplate, err := htmltemplate.New(name + ".body").Parse(body)
+	if err != nil {
+		return nil, templateError(err)
+	}
+
+	var subjectOut, bodyOut bytes.Buffer
+	if err := subjectTemplate.Execute(&subjectOut, data); err != nil {
+		return nil, templateError(err)
+	}
+	if err := bodyTemplate.Execute(&bodyOut, data); err != nil {
+		return nil, templateError(err)
+	}
+	return &models.RenderedEmail{Subject: strings.TrimSpace(subjectOut.String()), HTML: bodyOut.String()}, nil
+}
+
+func templateError(err error) error {
+	return i18n.NewError(msgTemplateInvalid, map[string]any{"Error": err.Error()})
+}
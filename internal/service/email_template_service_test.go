@@ -0,0 +1,85 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTemplateService(t *testing.T) {
+	db := setupTestDB(t)
+	location := &models.Location{Name: "Pinheiros", Address: "Rua dos Pinheiros, 500"}
+	require.NoError(t, db.Create(location).Error)
+	svc := NewEmailTemplateService(repository.NewEmailTemplateRepository(db), repository.NewLocationRepository(db))
+	svc.now = func() time.Time { return time.Date(2026, 5, 4, 10, 30, 0, 0, time.UTC) }
+
+	templates, err := svc.GetTemplates(0)
+	require.NoError(t, err)
+	require.Len(t, templates, 3)
+	require.Equal(t, models.EmailOrderConfirmation, templates[0].Name)
+	require.Equal(t, models.EmailTemplateDefault, templates[0].Source)
+	require.Nil(t, templates[0].UpdatedAt)
+
+	// The built-ins render with their sample data.
+	for name := range emailTemplates {
+		email, err := svc.Preview(name, &models.PreviewEmailTemplateRequest{})
+		require.NoError(t, err, name)
+		require.NotEmpty(t, email.Subject, name)
+		require.Contains(t, email.HTML, "Ana Lima", name)
+	}
+
+	_, err = svc.SetTemplate("welcome", &models.UpdateEmailTemplateRequest{Subject: "Hi", Body: "<p>Hi</p>"})
+	require.ErrorIs(t, err, ErrEmailTemplateNotFound)
+	_, err = svc.SetTemplate(models.EmailWaitlist, &models.UpdateEmailTemplateRequest{LocationID: 999, Subject: "Hi", Body: "<p>Hi</p>"})
+	require.ErrorIs(t, err, ErrLocationNotFound)
+	_, err = svc.SetTemplate(models.EmailWaitlist, &models.UpdateEmailTemplateRequest{Subject: "Hi", Body: " "})
+	require.EqualError(t, err, "body is required")
+	_, err = svc.SetTemplate(models.EmailWaitlist, &models.UpdateEmailTemplateRequest{Subject: "Hi", Body: "<p>{{.CupcakeName}</p>"})
+	require.ErrorContains(t, err, "template is invalid")
+	// Fields the email does not have are caught before saving.
+	_, err = svc.SetTemplate(models.EmailWaitlist, &models.UpdateEmailTemplateRequest{Subject: "Hi", Body: "<p>{{.Cupcake}}</p>"})
+	require.ErrorContains(t, err, "can't evaluate field Cupcake")
+
+	store, err := svc.SetTemplate(models.EmailWaitlist, &models.UpdateEmailTemplateRequest{Subject: "{{.CupcakeName}} voltou!", Body: "<p>{{.CupcakeName}} em {{.Store.Name}}</p>"})
+	require.NoError(t, err)
+	require.Equal(t, models.EmailTemplateStore, store.Source)
+	require.NotNil(t, store.UpdatedAt)
+
+	// Locations without their own override use the one for every store.
+	effective, err := svc.GetTemplate(models.EmailWaitlist, location.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.EmailTemplateStore, effective.Source)
+
+	_, err = svc.SetTemplate(models.EmailWaitlist, &models.UpdateEmailTemplateRequest{LocationID: location.ID, Subject: "Novidade em {{.Store.Name}}", Body: "<p>{{.CupcakeName}}</p>"})
+	require.NoError(t, err)
+	email, err := svc.Render(models.EmailWaitlist, location.ID, models.WaitlistEmail{Store: models.EmailStore{Name: "Pinheiros"}, CupcakeName: "<b>Red Velvet</b>"})
+	require.NoError(t, err)
+	require.Equal(t, "Novidade em Pinheiros", email.Subject)
+	require.Equal(t, "<p>&lt;b&gt;Red Velvet&lt;/b&gt;</p>", email.HTML)
+
+	email, err = svc.Preview(models.EmailWaitlist, &models.PreviewEmailTemplateRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "Red Velvet voltou!", email.Subject)
+	require.Equal(t, "<p>Red Velvet em Cupcake Store</p>", email.HTML)
+
+	// A draft is rendered without being saved, with the location's sample
+	// store.
+	draft := "<p>{{.Store.Address}}</p>"
+	email, err = svc.Preview(models.EmailWaitlist, &models.PreviewEmailTemplateRequest{LocationID: location.ID, Body: &draft})
+	require.NoError(t, err)
+	require.Equal(t, "Novidade em Pinheiros", email.Subject)
+	require.Equal(t, "<p>Rua dos Pinheiros, 500</p>", email.HTML)
+
+	require.NoError(t, svc.ResetTemplate(models.EmailWaitlist, location.ID))
+	require.NoError(t, svc.ResetTemplate(models.EmailWaitlist, location.ID))
+	effective, err = svc.GetTemplate(models.EmailWaitlist, location.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.EmailTemplateStore, effective.Source)
+	require.NoError(t, svc.ResetTemplate(models.EmailWaitlist, 0))
+	effective, err = svc.GetTemplate(models.EmailWaitlist, location.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.EmailTemplateDefault, effective.Source)
+}
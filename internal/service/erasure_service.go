@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// ErasureConfirmTTL is how long a customer has to confirm an erasure.
+const ErasureConfirmTTL = 24 * time.Hour
+
+var (
+	ErrErasureTokenInvalid = errors.New("confirmation token is invalid")
+	ErrErasureTokenExpired = errors.New("confirmation token has expired")
+	// ErrErasureUnavailable is returned for customers' requests while no
+	// email sender is configured, as the link would have no way to them.
+	ErrErasureUnavailable = errors.New("erasure requests are not available")
+)
+
+// ErasureService erases the personal data stored under a customer's email.
+// Ordering does not need an account, so customers' requests are only
+// carried out once confirmed through a link emailed to the address; admins
+// erase directly. The link is never published as an event.
+type ErasureService struct {
+	repo      repository.ErasureRepositoryInterface
+	email     EmailSender
+	publicURL string
+	signer    linkSigner
+	now       func() time.Time
+}
+
+var _ ErasureServiceInterface = (*ErasureService)(nil)
+
+// NewErasureService signs confirmation links with secret. Without one, a
+// random secret is used, and links only work on this instance until it
+// restarts.
+func NewErasureService(repo repository.ErasureRepositoryInterface, secret string) *ErasureService {
+	return &ErasureService{repo: repo, signer: newLinkSigner(secret), now: time.Now}
+}
+
+// WithEmail sends the confirmation links through sender, as links to the
+// web app under publicURL. Until it is called, customers' requests fail
+// with ErrErasureUnavailable.
+func (s *ErasureService) WithEmail(sender EmailSender, publicURL string) *ErasureService {
+	s.email = sender
+	s.publicURL = strings.TrimSuffix(publicURL, "/")
+	return s
+}
+
+// Request emails the customer the link confirming the erasure of email.
+// Nothing is erased until the link is used.
+func (s *ErasureService) Request(email string) error {
+	email, err := normalizeErasureEmail(email)
+	if err != nil {
+		return err
+	}
+	if s.email == nil {
+		return ErrErasureUnavailable
+	}
+
+	expiresAt := s.now().Add(ErasureConfirmTTL)
+	expires := expiresAt.Unix()
+	query := url.Values{
+		"email":   {email},
+		"expires": {fmt.Sprint(expires)},
+		"token":   {s.signer.sign(email, expires)},
+	}
+	// The token goes in the fragment, which browsers do not send, so it
+	// stays out of access logs; the web app confirms with DELETE /api/v1/me.
+	link := s.publicURL + "/#erasure?" + query.Encode()
+	message := &models.RenderedEmail{
+		Subject: "Confirm the erasure of your data",
+		HTML: fmt.Sprintf("<p>Hi,</p>\n<p>We were asked to erase the personal data stored under this address. <a href=\"%s\">Confirm the erasure</a> before %s UTC; it cannot be undone.</p>\n<p>If you did not ask for it, you can ignore this email and nothing will be erased.</p>\n",
+			html.EscapeString(link), expiresAt.UTC().Format("02/01/2006 15:04")),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), emailSendTimeout)
+	defer cancel()
+	return s.email.Send(ctx, email, message)
+}
+
+// Confirm erases the data of email for a customer holding the token sent
+// by Request.
+func (s *ErasureService) Confirm(email string, expires int64, token string) (*models.Erasure, error) {
+	email, err := normalizeErasureEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if !s.signer.valid(token, email, expires) {
+		return nil, ErrErasureTokenInvalid
+	}
+	if !s.now().Before(time.Unix(expires, 0)) {
+		return nil, ErrErasureTokenExpired
+	}
+	return s.erase(email, models.ErasureByCustomer)
+}
+
+// Erase erases the data of email at an admin's request.
+func (s *ErasureService) Erase(email string) (*models.Erasure, error) {
+	email, err := normalizeErasureEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return s.erase(email, models.ErasureByAdmin)
+}
+
+// GetErasures lists the erasure log, newest first; with an email, only the
+// erasures of that address.
+func (s *ErasureService) GetErasures(email string) ([]models.Erasure, error) {
+	if strings.TrimSpace(email) == "" {
+		return s.repo.FindAll()
+	}
+	email, err := normalizeErasureEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByEmailHash(pii.Hash(email))
+}
+
+func (s *ErasureService) erase(email, requestedBy string) (*models.Erasure, error) {
+	erasure := &models.Erasure{EmailHash: pii.Hash(email), RequestedBy: requestedBy}
+	if err := s.repo.Erase(email, erasure); err != nil {
+		return nil, err
+	}
+	return erasure, nil
+}
+
+// normalizeErasureEmail lowercases email, so tokens and hashes do not
+// depend on how the customer typed it.
+func normalizeErasureEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", i18n.NewError(msgEmailRequired, nil)
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", i18n.NewError(msgEmailInvalid, nil)
+	}
+	return email, nil
+}
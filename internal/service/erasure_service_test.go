@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErasureService(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(&models.PickupReservation{SlotID: 1, CustomerName: "Ana Lima", CustomerEmail: "ana@example.com", CustomerPhone: "+5511912345678", Items: []models.PickupReservationItem{{CupcakeID: 1, Quantity: 3}}}).Error)
+
+	type sentEmail struct {
+		to    string
+		email *models.RenderedEmail
+	}
+	var sent []sentEmail
+	sender := &mocks.EmailSender{SendFunc: func(_ context.Context, to string, email *models.RenderedEmail) error {
+		sent = append(sent, sentEmail{to, email})
+		return nil
+	}}
+	svc := NewErasureService(repository.NewErasureRepository(db), "test-secret")
+
+	require.ErrorIs(t, svc.Request("ana@example.com"), ErrErasureUnavailable)
+	svc.WithEmail(sender, "https://loja.example.com/")
+
+	require.EqualError(t, svc.Request("not an email"), "email is invalid")
+
+	// Requesting only emails the confirmation link.
+	require.NoError(t, svc.Request(" Ana@Example.com "))
+	require.Len(t, sent, 1)
+	require.Equal(t, "ana@example.com", sent[0].to)
+	var count int64
+	require.NoError(t, db.Model(&models.PickupReservation{}).Where("customer_email = ?", "ana@example.com").Count(&count).Error)
+	require.Equal(t, int64(1), count)
+
+	href := regexp.MustCompile(`href="([^"]+)"`).FindStringSubmatch(sent[0].email.HTML)
+	require.NotNil(t, href)
+	link, err := url.Parse(html.UnescapeString(href[1]))
+	require.NoError(t, err)
+	require.Equal(t, "loja.example.com", link.Host)
+	require.Empty(t, link.RawQuery, "the token must stay out of the query sent to the server")
+	require.True(t, strings.HasPrefix(link.Fragment, "erasure?"))
+	confirm, err := url.ParseQuery(strings.TrimPrefix(link.Fragment, "erasure?"))
+	require.NoError(t, err)
+	require.Equal(t, "ana@example.com", confirm.Get("email"))
+	expires, err := strconv.ParseInt(confirm.Get("expires"), 10, 64)
+	require.NoError(t, err)
+	token := confirm.Get("token")
+
+	_, err = svc.Confirm("bruno@example.com", expires, token)
+	require.ErrorIs(t, err, ErrErasureTokenInvalid)
+	_, err = svc.Confirm("ana@example.com", expires+1, token)
+	require.ErrorIs(t, err, ErrErasureTokenInvalid)
+
+	now := svc.now
+	svc.now = func() time.Time { return time.Now().Add(ErasureConfirmTTL + time.Minute) }
+	_, err = svc.Confirm("ana@example.com", expires, token)
+	require.ErrorIs(t, err, ErrErasureTokenExpired)
+	svc.now = now
+
+	erasure, err := svc.Confirm("ANA@example.com", expires, token)
+	require.NoError(t, err)
+	require.Equal(t, models.ErasureByCustomer, erasure.RequestedBy)
+	require.Equal(t, int64(1), erasure.PickupReservations)
+	require.Equal(t, pii.Hash("ana@example.com"), erasure.EmailHash)
+	var reservation models.PickupReservation
+	require.NoError(t, db.First(&reservation).Error)
+	require.Empty(t, reservation.CustomerPhone)
+
+	erasure, err = svc.Erase("bruno@example.com")
+	require.NoError(t, err)
+	require.Equal(t, models.ErasureByAdmin, erasure.RequestedBy)
+	require.Zero(t, erasure.PickupReservations)
+
+	all, err := svc.GetErasures("")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	found, err := svc.GetErasures("Ana@example.com")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, models.ErasureByCustomer, found[0].RequestedBy)
+}
@@ -0,0 +1,13 @@
+package service
+
+type EventPublisher interface {
+	Publish(event string, data interface{})
+}
+
+type EventPublishers []EventPublisher
+
+func (p EventPublishers) Publish(event string, data interface{}) {
+	for _, publisher := range p {
+		publisher.Publish(event, data)
+	}
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"errors"
+	"hash/fnv"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var (
+	ErrExperimentNotFound = errors.New("experiment not found")
+	ErrExperimentStopped  = errors.New("experiment is not running")
+)
+
+const (
+	maxVisitorIDLength = 64
+	// minPriceAdjustmentPercent keeps a variant from giving cupcakes away.
+	minPriceAdjustmentPercent = -90
+	maxPriceAdjustmentPercent = 100
+)
+
+var experimentKeyPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ExperimentService assigns visitors to experiment variants and records how
+// they convert. Assignment hashes the experiment key with the visitor ID,
+// so a visitor keeps their variant across requests and instances without
+// any stored state.
+type ExperimentService struct {
+	repo        repository.ExperimentRepositoryInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+	now         func() time.Time
+}
+
+var _ ExperimentServiceInterface = (*ExperimentService)(nil)
+
+func NewExperimentService(repo repository.ExperimentRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface) *ExperimentService {
+	return &ExperimentService{repo: repo, cupcakeRepo: cupcakeRepo, now: time.Now}
+}
+
+func (s *ExperimentService) CreateExperiment(req *models.CreateExperimentRequest) (*models.Experiment, error) {
+	experiment := &models.Experiment{
+		Key:         strings.TrimSpace(req.Key),
+		Description: strings.TrimSpace(req.Description),
+		CupcakeID:   req.CupcakeID,
+		IsActive:    true,
+	}
+	if !experimentKeyPattern.MatchString(experiment.Key) || len(experiment.Key) > 64 {
+		return nil, i18n.NewError(msgExperimentKeyInvalid, nil)
+	}
+	if len(experiment.Description) > 255 {
+		return nil, i18n.NewError(msgExperimentDescriptionTooLong, nil)
+	}
+	if experiment.CupcakeID != nil {
+		exists, err := s.cupcakeRepo.Exists(*experiment.CupcakeID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, i18n.NewError(msgCupcakeIDNotFound, map[string]any{"ID": *experiment.CupcakeID})
+		}
+	}
+
+	variants, err := experimentVariants(req.Variants)
+	if err != nil {
+		return nil, err
+	}
+	experiment.Variants = variants
+
+	if _, err := s.repo.FindByKey(experiment.Key); err == nil {
+		return nil, i18n.NewError(msgExperimentKeyTaken, nil)
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.repo.Create(experiment); err != nil {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// GetExperiment returns the experiment with the results of every variant so
+// far.
+func (s *ExperimentService) GetExperiment(id uint) (*models.ExperimentResults, error) {
+	experiment, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExperimentNotFound
+		}
+		return nil, err
+	}
+
+	counted, err := s.repo.Results(id)
+	if err != nil {
+		return nil, err
+	}
+	byVariant := make(map[string]models.VariantResults, len(counted))
+	for _, result := range counted {
+		byVariant[result.Variant] = result
+	}
+
+	results := make([]models.VariantResults, len(experiment.Variants))
+	for i, variant := range experiment.Variants {
+		result := byVariant[variant.Name]
+		result.Variant = variant.Name
+		if result.Exposures > 0 {
+			result.ConversionRate = float64(result.Conversions) / float64(result.Exposures)
+		}
+		results[i] = result
+	}
+	return &models.ExperimentResults{Experiment: *experiment, Results: results}, nil
+}
+
+func (s *ExperimentService) GetAllExperiments() ([]models.Experiment, error) {
+	return s.repo.FindAll()
+}
+
+// StopExperiment ends the experiment: visitors go back to regular prices
+// and conversions are no longer recorded. Results stay available.
+func (s *ExperimentService) StopExperiment(id uint) (*models.Experiment, error) {
+	experiment, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrExperimentNotFound
+		}
+		return nil, err
+	}
+	if !experiment.IsActive {
+		return experiment, nil
+	}
+
+	now := s.now()
+	experiment.IsActive = false
+	experiment.StoppedAt = &now
+	if err := s.repo.Update(experiment); err != nil {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// Assign returns the visitor's variant in every running experiment and
+// records the exposures, for clients that render variants themselves.
+func (s *ExperimentService) Assign(visitorID string) ([]models.ExperimentAssignment, error) {
+	if err := checkVisitorID(visitorID); err != nil {
+		return nil, err
+	}
+	experiments, err := s.repo.FindActive()
+	if err != nil {
+		return nil, err
+	}
+
+	assignments := make([]models.ExperimentAssignment, 0, len(experiments))
+	exposures := make([]models.ExperimentExposure, 0, len(experiments))
+	for _, experiment := range experiments {
+		variant := assignVariant(&experiment, visitorID)
+		assignments = append(assignments, models.ExperimentAssignment{Experiment: experiment.Key, Variant: variant.Name})
+		exposures = append(exposures, models.ExperimentExposure{ExperimentID: experiment.ID, VisitorID: visitorID, Variant: variant.Name})
+	}
+	if err := s.repo.RecordExposures(exposures); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// ApplyPrices adjusts the effective prices of cupcakes for the visitor's
+// variants in running experiments that cover them, and returns those
+// assignments. Without a visitor ID prices are left alone. Failing to
+// record exposures is logged rather than failing the page.
+func (s *ExperimentService) ApplyPrices(cupcakes []models.Cupcake, visitorID string) ([]models.ExperimentAssignment, error) {
+	if visitorID == "" || len(cupcakes) == 0 {
+		return nil, nil
+	}
+	if err := checkVisitorID(visitorID); err != nil {
+		return nil, err
+	}
+	experiments, err := s.repo.FindActive()
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []models.ExperimentAssignment
+	var exposures []models.ExperimentExposure
+	for _, experiment := range experiments {
+		variant := assignVariant(&experiment, visitorID)
+		applied := false
+		for i := range cupcakes {
+			if experiment.CupcakeID != nil && *experiment.CupcakeID != cupcakes[i].ID {
+				continue
+			}
+			applyVariantPrice(&cupcakes[i], variant.PriceAdjustmentPercent)
+			applied = true
+		}
+		if !applied {
+			continue
+		}
+		assignments = append(assignments, models.ExperimentAssignment{Experiment: experiment.Key, Variant: variant.Name})
+		exposures = append(exposures, models.ExperimentExposure{ExperimentID: experiment.ID, VisitorID: visitorID, Variant: variant.Name})
+	}
+
+	if err := s.repo.RecordExposures(exposures); err != nil {
+		log.Printf("Error recording experiment exposures: %v", err)
+	}
+	return assignments, nil
+}
+
+// RecordConversion credits a conversion to the variant the visitor is
+// assigned to in the experiment.
+func (s *ExperimentService) RecordConversion(key, visitorID string, req *models.ConversionRequest) error {
+	if err := checkVisitorID(visitorID); err != nil {
+		return err
+	}
+	if req.ValueCents < 0 {
+		return i18n.NewError(msgValueNegative, nil)
+	}
+
+	experiment, err := s.repo.FindByKey(key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrExperimentNotFound
+		}
+		return err
+	}
+	if !experiment.IsActive {
+		return ErrExperimentStopped
+	}
+
+	variant := assignVariant(experiment, visitorID)
+	return s.repo.RecordConversion(&models.ExperimentConversion{
+		ExperimentID: experiment.ID,
+		VisitorID:    visitorID,
+		Variant:      variant.Name,
+		ValueCents:   req.ValueCents,
+	})
+}
+
+// experimentVariants checks the requested variants: at least two, with
+// distinct names, positive weights and sane price adjustments.
+func experimentVariants(requested []models.ExperimentVariantRequest) ([]models.ExperimentVariant, error) {
+	if len(requested) < 2 {
+		return nil, i18n.NewError(msgVariantsTooFew, nil)
+	}
+
+	variants := make([]models.ExperimentVariant, 0, len(requested))
+	seen := make(map[string]bool, len(requested))
+	for _, req := range requested {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			return nil, i18n.NewError(msgNameRequired, nil)
+		}
+		if len(name) > 50 {
+			return nil, i18n.NewError(msgVariantNameTooLong, nil)
+		}
+		if seen[name] {
+			return nil, i18n.NewError(msgVariantRepeated, map[string]any{"Name": name})
+		}
+		seen[name] = true
+		if req.Weight <= 0 {
+			return nil, i18n.NewError(msgWeightNotPositive, nil)
+		}
+		if req.PriceAdjustmentPercent < minPriceAdjustmentPercent || req.PriceAdjustmentPercent > maxPriceAdjustmentPercent {
+			return nil, i18n.NewError(msgPriceAdjustmentOutOfRange, map[string]any{"Min": minPriceAdjustmentPercent, "Max": maxPriceAdjustmentPercent})
+		}
+
+		variants = append(variants, models.ExperimentVariant{
+			Name:                   name,
+			Weight:                 req.Weight,
+			PriceAdjustmentPercent: req.PriceAdjustmentPercent,
+		})
+	}
+	return variants, nil
+}
+
+// assignVariant buckets the visitor by hashing the experiment key with
+// their ID, so each experiment splits visitors independently.
+func assignVariant(experiment *models.Experiment, visitorID string) models.ExperimentVariant {
+	total := 0
+	for _, variant := range experiment.Variants {
+		total += variant.Weight
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(experiment.Key + ":" + visitorID))
+	bucket := int(h.Sum32() % uint32(total))
+	for _, variant := range experiment.Variants {
+		if bucket < variant.Weight {
+			return variant
+		}
+		bucket -= variant.Weight
+	}
+	return experiment.Variants[len(experiment.Variants)-1]
+}
+
+// applyVariantPrice moves the effective price by percent, never below one
+// cent. A price the adjustment cannot represent is left as it was.
+func applyVariantPrice(cupcake *models.Cupcake, percent int) {
+	if percent == 0 {
+		return
+	}
+	price := cupcake.PriceCents
+	if cupcake.EffectivePriceCents != nil {
+		price = *cupcake.EffectivePriceCents
+	}
+	adjusted, err := adjustPrice(price, models.PriceAdjustmentPercentage, percent)
+	if err != nil {
+		return
+	}
+	adjusted = max(adjusted, 1)
+	cupcake.EffectivePriceCents = &adjusted
+}
+
+func checkVisitorID(visitorID string) error {
+	if visitorID == "" || len(visitorID) > maxVisitorIDLength {
+		return i18n.NewError(msgVisitorIDInvalid, map[string]any{"Max": maxVisitorIDLength})
+	}
+	return nil
+}
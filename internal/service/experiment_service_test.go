@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExperimentService(t *testing.T) (*ExperimentService, *repository.CupcakeRepository) {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla"), factory.WithPrice(1000)),
+		factory.Cupcake(factory.WithName("Chocolate"), factory.WithPrice(2000)),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+	return NewExperimentService(repository.NewExperimentRepository(db), cupcakeRepo), cupcakeRepo
+}
+
+func priceExperiment(key string, cupcakeID *uint) *models.CreateExperimentRequest {
+	return &models.CreateExperimentRequest{
+		Key:       key,
+		CupcakeID: cupcakeID,
+		Variants: []models.ExperimentVariantRequest{
+			{Name: "control", Weight: 1},
+			{Name: "higher", Weight: 1, PriceAdjustmentPercent: 10},
+		},
+	}
+}
+
+func TestCreateExperiment(t *testing.T) {
+	missing := uint(99)
+	tests := []struct {
+		name          string
+		modify        func(req *models.CreateExperimentRequest)
+		expectedError string
+	}{
+		{name: "success", modify: func(req *models.CreateExperimentRequest) {}},
+		{name: "invalid key", modify: func(req *models.CreateExperimentRequest) { req.Key = "Price Test" }, expectedError: "key must have up to 64 lowercase letters or digits, separated by single dashes"},
+		{name: "taken key", modify: func(req *models.CreateExperimentRequest) { req.Key = "existing" }, expectedError: "an experiment with this key already exists"},
+		{name: "one variant", modify: func(req *models.CreateExperimentRequest) { req.Variants = req.Variants[:1] }, expectedError: "at least two variants are required"},
+		{name: "repeated variant", modify: func(req *models.CreateExperimentRequest) { req.Variants[1].Name = "control" }, expectedError: "variant control is listed more than once"},
+		{name: "zero weight", modify: func(req *models.CreateExperimentRequest) { req.Variants[0].Weight = 0 }, expectedError: "weight must be greater than zero"},
+		{name: "adjustment out of range", modify: func(req *models.CreateExperimentRequest) { req.Variants[1].PriceAdjustmentPercent = -95 }, expectedError: "price adjustment must be between -90 and 100 percent"},
+		{name: "unknown cupcake", modify: func(req *models.CreateExperimentRequest) { req.CupcakeID = &missing }, expectedError: "cupcake 99 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestExperimentService(t)
+			_, err := svc.CreateExperiment(priceExperiment("existing", nil))
+			require.NoError(t, err)
+
+			req := priceExperiment("price-test", nil)
+			tt.modify(req)
+			experiment, err := svc.CreateExperiment(req)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, experiment.IsActive)
+			require.Len(t, experiment.Variants, 2)
+		})
+	}
+}
+
+func TestExperimentService_AssignIsDeterministic(t *testing.T) {
+	svc, _ := newTestExperimentService(t)
+	_, err := svc.CreateExperiment(priceExperiment("price-test", nil))
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	for i := range 200 {
+		visitor := fmt.Sprintf("visitor-%d", i)
+		first, err := svc.Assign(visitor)
+		require.NoError(t, err)
+		again, err := svc.Assign(visitor)
+		require.NoError(t, err)
+		require.Equal(t, first, again)
+		counts[first[0].Variant]++
+	}
+
+	// Equal weights split visitors roughly in half.
+	require.InDelta(t, 100, counts["control"], 30)
+	require.InDelta(t, 100, counts["higher"], 30)
+
+	_, err = svc.Assign("")
+	require.EqualError(t, err, "X-Visitor-ID must have 1 to 64 characters")
+}
+
+func TestExperimentService_ApplyPrices(t *testing.T) {
+	svc, cupcakeRepo := newTestExperimentService(t)
+	chocolate := uint(2)
+	_, err := svc.CreateExperiment(&models.CreateExperimentRequest{
+		Key:       "chocolate-price",
+		CupcakeID: &chocolate,
+		Variants: []models.ExperimentVariantRequest{
+			{Name: "lower", Weight: 1, PriceAdjustmentPercent: -25},
+			{Name: "lowest", Weight: 1, PriceAdjustmentPercent: -50},
+		},
+	})
+	require.NoError(t, err)
+
+	cupcakes, err := cupcakeRepo.FindAll()
+	require.NoError(t, err)
+
+	// Without a visitor, prices are left alone.
+	assignments, err := svc.ApplyPrices(cupcakes, "")
+	require.NoError(t, err)
+	require.Empty(t, assignments)
+	require.Nil(t, cupcakes[1].EffectivePriceCents)
+
+	assignments, err = svc.ApplyPrices(cupcakes, "visitor-1")
+	require.NoError(t, err)
+	require.Len(t, assignments, 1)
+	require.Equal(t, "chocolate-price", assignments[0].Experiment)
+	require.Nil(t, cupcakes[0].EffectivePriceCents)
+	require.NotNil(t, cupcakes[1].EffectivePriceCents)
+	expected := map[string]int{"lower": 1500, "lowest": 1000}[assignments[0].Variant]
+	require.Equal(t, expected, *cupcakes[1].EffectivePriceCents)
+
+	// Cupcakes outside the experiment record no exposure.
+	vanilla := cupcakes[:1]
+	assignments, err = svc.ApplyPrices(vanilla, "visitor-2")
+	require.NoError(t, err)
+	require.Empty(t, assignments)
+}
+
+func TestExperimentService_Results(t *testing.T) {
+	svc, _ := newTestExperimentService(t)
+	experiment, err := svc.CreateExperiment(priceExperiment("price-test", nil))
+	require.NoError(t, err)
+
+	for i := range 10 {
+		_, err := svc.Assign(fmt.Sprintf("visitor-%d", i))
+		require.NoError(t, err)
+	}
+	require.NoError(t, svc.RecordConversion("price-test", "visitor-1", &models.ConversionRequest{ValueCents: 1200}))
+
+	results, err := svc.GetExperiment(experiment.ID)
+	require.NoError(t, err)
+	require.Len(t, results.Results, 2)
+
+	var exposures, conversions int64
+	for _, result := range results.Results {
+		exposures += result.Exposures
+		conversions += result.Conversions
+		if result.Conversions > 0 {
+			require.Equal(t, int64(1200), result.RevenueCents)
+			require.InDelta(t, 1/float64(result.Exposures), result.ConversionRate, 0.0001)
+		}
+	}
+	require.Equal(t, int64(10), exposures)
+	require.Equal(t, int64(1), conversions)
+
+	_, err = svc.StopExperiment(experiment.ID)
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.RecordConversion("price-test", "visitor-1", &models.ConversionRequest{}), ErrExperimentStopped)
+	require.ErrorIs(t, svc.RecordConversion("missing", "visitor-1", &models.ConversionRequest{}), ErrExperimentNotFound)
+	_, err = svc.GetExperiment(99)
+	require.ErrorIs(t, err, ErrExperimentNotFound)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+)
+
+// Rules reported in a FieldError.
+const (
+	RuleRequired  = "required"
+	RuleMinLength = "min_length"
+	RuleMaxLength = "max_length"
+	RulePositive  = "positive"
+	RuleMax       = "max"
+	RuleMin       = "min"
+	RuleFormat    = "format"
+	RuleUnique    = "unique"
+)
+
+// FieldError is one validation rule a request field failed.
+type FieldError struct {
+	Field string
+	Rule  string
+	Err   *i18n.Error
+}
+
+// ValidationError lists every field error found in a request, so clients
+// can fix them all in one go. Its text joins the messages in English.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// fieldErrors gathers the field errors of a request while it is checked.
+type fieldErrors []FieldError
+
+func (f *fieldErrors) add(field, rule string, msg *i18n.Message, data map[string]any) {
+	*f = append(*f, FieldError{Field: field, Rule: rule, Err: &i18n.Error{Message: msg, Data: data}})
+}
+
+func (f fieldErrors) err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: f}
+}
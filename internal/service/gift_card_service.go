@@ -0,0 +1,123 @@
+package service
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const giftCardAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+type GiftCardService struct {
+	repo repository.GiftCardRepositoryInterface
+	now  func() time.Time
+}
+
+var _ GiftCardServiceInterface = (*GiftCardService)(nil)
+
+func NewGiftCardService(repo repository.GiftCardRepositoryInterface) *GiftCardService {
+	return &GiftCardService{repo: repo, now: time.Now}
+}
+
+func (s *GiftCardService) IssueGiftCard(req *models.IssueGiftCardRequest) (*models.GiftCard, error) {
+	if req.AmountCents <= 0 {
+		return nil, i18n.NewError(msgAmountNotPositive, nil)
+	}
+
+	code, err := generateGiftCardCode()
+	if err != nil {
+		return nil, err
+	}
+
+	giftCard := &models.GiftCard{
+		Code:         code,
+		InitialCents: req.AmountCents,
+		BalanceCents: req.AmountCents,
+	}
+
+	if err := s.repo.Create(giftCard); err != nil {
+		return nil, err
+	}
+
+	return giftCard, nil
+}
+
+func (s *GiftCardService) GetGiftCard(id uint) (*models.GiftCard, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *GiftCardService) GetAllGiftCards() ([]models.GiftCard, error) {
+	return s.repo.FindAll()
+}
+
+func (s *GiftCardService) GetBalance(code string) (*models.GiftCardBalanceResponse, error) {
+	giftCard, err := s.repo.FindByCode(normalizeGiftCardCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GiftCardBalanceResponse{
+		Code:         giftCard.Code,
+		BalanceCents: giftCard.BalanceCents,
+		IsVoided:     giftCard.VoidedAt != nil,
+	}, nil
+}
+
+func (s *GiftCardService) VoidGiftCard(id uint) (*models.GiftCard, error) {
+	if err := s.repo.Void(id, s.now()); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(id)
+}
+
+// RedeemGiftCard debits the card. No route calls it yet: pickup
+// reservations carry no totals to pay with it.
+func (s *GiftCardService) RedeemGiftCard(req *models.RedeemGiftCardRequest) (*models.GiftCardRedemption, error) {
+	if req.AmountCents <= 0 {
+		return nil, i18n.NewError(msgAmountNotPositive, nil)
+	}
+
+	giftCard, err := s.repo.FindByCode(normalizeGiftCardCode(req.Code))
+	if err != nil {
+		return nil, i18n.NewError(msgGiftCardNotFound, nil)
+	}
+
+	if giftCard.VoidedAt != nil {
+		return nil, i18n.NewError(msgGiftCardVoided, nil)
+	}
+
+	redemption := &models.GiftCardRedemption{
+		GiftCardID:  giftCard.ID,
+		AmountCents: req.AmountCents,
+	}
+
+	if err := s.repo.Debit(redemption); err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+func generateGiftCardCode() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	var code strings.Builder
+	for i, b := range buf {
+		if i > 0 && i%4 == 0 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(giftCardAlphabet[int(b)%len(giftCardAlphabet)])
+	}
+	return code.String(), nil
+}
+
+func normalizeGiftCardCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGiftCardService(t *testing.T) *GiftCardService {
+	t.Helper()
+
+	db := setupTestDB(t)
+	return NewGiftCardService(repository.NewGiftCardRepository(db))
+}
+
+func TestIssueGiftCard(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.IssueGiftCardRequest
+		expectedError string
+	}{
+		{
+			name:    "success",
+			request: &models.IssueGiftCardRequest{AmountCents: 5000},
+		},
+		{
+			name:          "validation error - zero amount",
+			request:       &models.IssueGiftCardRequest{AmountCents: 0},
+			expectedError: "amount must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestGiftCardService(t)
+
+			giftCard, err := service.IssueGiftCard(tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, giftCard)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Regexp(t, regexp.MustCompile(`^[A-Z2-9]{4}(-[A-Z2-9]{4}){3}$`), giftCard.Code)
+				require.Equal(t, tt.request.AmountCents, giftCard.InitialCents)
+				require.Equal(t, tt.request.AmountCents, giftCard.BalanceCents)
+			}
+		})
+	}
+}
+
+func TestRedeemGiftCard(t *testing.T) {
+	tests := []struct {
+		name            string
+		redeemCents     int
+		void            bool
+		unknownCode     bool
+		expectedError   string
+		expectedBalance int
+	}{
+		{
+			name:            "partial redemption",
+			redeemCents:     1200,
+			expectedBalance: 3800,
+		},
+		{
+			name:            "redemption above balance",
+			redeemCents:     6000,
+			expectedError:   "gift card balance is insufficient",
+			expectedBalance: 5000,
+		},
+		{
+			name:            "voided card",
+			redeemCents:     100,
+			void:            true,
+			expectedError:   "gift card has been voided",
+			expectedBalance: 5000,
+		},
+		{
+			name:            "unknown code",
+			redeemCents:     100,
+			unknownCode:     true,
+			expectedError:   "gift card not found",
+			expectedBalance: 5000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestGiftCardService(t)
+
+			giftCard, err := service.IssueGiftCard(&models.IssueGiftCardRequest{AmountCents: 5000})
+			require.NoError(t, err)
+
+			if tt.void {
+				_, err = service.VoidGiftCard(giftCard.ID)
+				require.NoError(t, err)
+			}
+
+			code := giftCard.Code
+			if tt.unknownCode {
+				code = "0000-0000-0000-0000"
+			}
+
+			redemption, err := service.RedeemGiftCard(&models.RedeemGiftCardRequest{Code: code, AmountCents: tt.redeemCents})
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, redemption)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.redeemCents, redemption.AmountCents)
+			}
+
+			balance, err := service.GetBalance(giftCard.Code)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedBalance, balance.BalanceCents)
+			require.Equal(t, tt.void, balance.IsVoided)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+type CupcakeServiceInterface interface {
+	CreateCupcake(req *models.CreateCupcakeRequest) (*models.Cupcake, error)
+	GetCupcake(id uint) (*models.Cupcake, error)
+	GetCupcakeBySKU(sku string) (*models.Cupcake, error)
+	GetCupcakeBySlug(slug string) (*models.Cupcake, error)
+	GetRelatedCupcakes(id uint, limit int) ([]models.Cupcake, error)
+	GetAllCupcakes() ([]models.Cupcake, error)
+	ListCupcakes(page, perPage int) ([]models.Cupcake, int64, error)
+	GetCupcakesAtLocation(locationID uint) ([]models.Cupcake, error)
+	ListCupcakesAtLocation(locationID uint, page, perPage int) ([]models.Cupcake, int64, error)
+	StreamCupcakes(code string, fn func(*models.Cupcake) error) error
+	ConvertPrices(cupcakes []models.Cupcake, code string) error
+	Localize(cupcakes []models.Cupcake, acceptLanguage string) (string, error)
+	UpdateCupcake(id uint, req *models.UpdateCupcakeRequest) (*models.Cupcake, error)
+	PatchCupcake(id uint, ops []models.PatchOperation, updatedBy *uint) (*models.Cupcake, error)
+	GetCupcakeVersions(id uint) ([]models.CupcakeVersion, error)
+	RevertCupcake(id uint, version int, updatedBy *uint) (*models.Cupcake, error)
+	DeleteCupcake(id uint) error
+	BulkUpdatePrices(req *models.BulkPriceUpdateRequest) (*models.BulkPriceUpdateResponse, error)
+}
+
+type CouponServiceInterface interface {
+	CreateCoupon(req *models.CreateCouponRequest) (*models.Coupon, error)
+	GetCoupon(id uint) (*models.Coupon, error)
+	GetAllCoupons() ([]models.Coupon, error)
+	UpdateCoupon(id uint, req *models.UpdateCouponRequest) (*models.Coupon, error)
+	DeleteCoupon(id uint) error
+	ApplyCoupon(req *models.ApplyCouponRequest) (*models.CouponRedemption, error)
+	ExpireCoupons() (int64, error)
+}
+
+type PromotionServiceInterface interface {
+	CreatePromotion(req *models.CreatePromotionRequest) (*models.Promotion, error)
+	GetPromotion(id uint) (*models.Promotion, error)
+	GetAllPromotions() ([]models.Promotion, error)
+	UpdatePromotion(id uint, req *models.UpdatePromotionRequest) (*models.Promotion, error)
+	DeletePromotion(id uint) error
+}
+
+type GiftCardServiceInterface interface {
+	IssueGiftCard(req *models.IssueGiftCardRequest) (*models.GiftCard, error)
+	GetGiftCard(id uint) (*models.GiftCard, error)
+	GetAllGiftCards() ([]models.GiftCard, error)
+	GetBalance(code string) (*models.GiftCardBalanceResponse, error)
+	VoidGiftCard(id uint) (*models.GiftCard, error)
+	RedeemGiftCard(req *models.RedeemGiftCardRequest) (*models.GiftCardRedemption, error)
+}
+
+type SubscriptionServiceInterface interface {
+	Subscribe(req *models.CreateSubscriptionRequest) (*models.CreatedSubscription, error)
+	Authorize(id uint, managementToken string, account *models.Account) (*models.Subscription, error)
+	GetSubscription(id uint) (*models.Subscription, error)
+	GetAllSubscriptions() ([]models.Subscription, error)
+	Pause(id uint) (*models.Subscription, error)
+	Resume(id uint) (*models.Subscription, error)
+	Cancel(id uint) (*models.Subscription, error)
+}
+
+type LocationServiceInterface interface {
+	CreateLocation(req *models.CreateLocationRequest) (*models.Location, error)
+	GetLocation(id uint) (*models.Location, error)
+	GetAllLocations() ([]models.Location, error)
+	UpdateLocation(id uint, req *models.UpdateLocationRequest) (*models.Location, error)
+	DeleteLocation(id uint) error
+	GetStock(locationID uint) ([]models.LocationStock, error)
+	SetStock(locationID, cupcakeID uint, req *models.SetStockRequest) (*models.LocationStock, error)
+	CheckPickup(locationID uint, req *models.PickupCheckRequest) ([]models.PickupItem, error)
+}
+
+type BundleServiceInterface interface {
+	CreateBundle(req *models.CreateBundleRequest) (*models.Bundle, error)
+	GetBundle(id uint) (*models.Bundle, error)
+	GetAllBundles() ([]models.Bundle, error)
+	GetAvailableBundles() ([]models.Bundle, error)
+	UpdateBundle(id uint, req *models.UpdateBundleRequest) (*models.Bundle, error)
+	DeleteBundle(id uint) error
+}
+
+type WholesaleServiceInterface interface {
+	CreateAccount(req *models.CreateWholesaleAccountRequest) (*models.WholesaleAccount, error)
+	GetAccount(id uint) (*models.WholesaleAccount, error)
+	GetAllAccounts() ([]models.WholesaleAccount, error)
+	UpdateAccount(id uint, req *models.UpdateWholesaleAccountRequest) (*models.WholesaleAccount, error)
+	DeleteAccount(id uint) error
+	GetPrices(accountID uint) ([]models.WholesalePrice, error)
+	SetPrice(accountID, cupcakeID uint, req *models.SetWholesalePriceRequest) (*models.WholesalePrice, error)
+	DeletePrice(accountID, cupcakeID uint) error
+	Quote(accountID uint, req *models.WholesaleQuoteRequest) (*models.WholesaleQuote, error)
+}
+
+type ProcurementServiceInterface interface {
+	CreateSupplier(req *models.CreateSupplierRequest) (*models.Supplier, error)
+	GetSupplier(id uint) (*models.Supplier, error)
+	GetAllSuppliers() ([]models.Supplier, error)
+	UpdateSupplier(id uint, req *models.UpdateSupplierRequest) (*models.Supplier, error)
+	CreateIngredient(req *models.CreateIngredientRequest) (*models.Ingredient, error)
+	GetIngredient(id uint) (*models.Ingredient, error)
+	GetAllIngredients() ([]models.Ingredient, error)
+	UpdateIngredient(id uint, req *models.UpdateIngredientRequest) (*models.Ingredient, error)
+	CreatePurchaseOrder(req *models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error)
+	GetPurchaseOrder(id uint) (*models.PurchaseOrder, error)
+	GetPurchaseOrders(status string) ([]models.PurchaseOrder, error)
+	ReceivePurchaseOrder(id uint) (*models.PurchaseOrder, error)
+	CancelPurchaseOrder(id uint) (*models.PurchaseOrder, error)
+}
+
+type RecipeServiceInterface interface {
+	GetRecipe(cupcakeID uint) (*models.Recipe, error)
+	SetRecipe(cupcakeID uint, req *models.SetRecipeRequest) (*models.Recipe, error)
+	RecordProduction(cupcakeID uint, req *models.RecordProductionRequest) (*models.ProductionBatch, error)
+	GetProductionBatches(cupcakeID uint) ([]models.ProductionBatch, error)
+}
+
+type KitchenServiceInterface interface {
+	ProductionPlan(day time.Time) (*models.ProductionPlan, error)
+	Queue(day time.Time, locationID uint) ([]models.KitchenQueueEntry, error)
+}
+
+type PrintServiceInterface interface {
+	Print(reservationID uint, tickets []string) ([]string, error)
+}
+
+type StatsServiceInterface interface {
+	TopCupcakes(from, to time.Time, flavor string, limit int) (*models.TopCupcakes, error)
+}
+
+type SyncServiceInterface interface {
+	SyncCupcakes(since uint, limit int) (*models.CupcakeSync, error)
+}
+
+type TrendingServiceInterface interface {
+	GetTrending(days, limit int) ([]models.TrendingCupcake, error)
+}
+
+type TranslationServiceInterface interface {
+	GetTranslations(cupcakeID uint) ([]models.CupcakeTranslation, error)
+	SetTranslation(cupcakeID uint, locale string, req *models.SetTranslationRequest) (*models.CupcakeTranslation, error)
+	DeleteTranslation(cupcakeID uint, locale string) error
+	Localize(cupcakes []models.Cupcake, acceptLanguage string) (string, error)
+}
+
+type SearchServiceInterface interface {
+	Search(query models.SearchQuery) (*models.SearchResult, error)
+	Reindex() (int, error)
+}
+
+// ViewRecorder counts a view of a cupcake's page.
+type ViewRecorder interface {
+	RecordView(cupcakeID uint)
+}
+
+type AddonServiceInterface interface {
+	CreateAddon(req *models.CreateAddonRequest) (*models.Addon, error)
+	GetAddon(id uint) (*models.Addon, error)
+	GetAllAddons() ([]models.Addon, error)
+	GetAvailableAddons() ([]models.Addon, error)
+	UpdateAddon(id uint, req *models.UpdateAddonRequest) (*models.Addon, error)
+	DeleteAddon(id uint) error
+}
+
+type CustomCupcakeServiceInterface interface {
+	CreateOption(req *models.CreateCustomOptionRequest) (*models.CustomOption, error)
+	GetAllOptions() ([]models.CustomOption, error)
+	GetAvailableOptions() ([]models.CustomOption, error)
+	UpdateOption(id uint, req *models.UpdateCustomOptionRequest) (*models.CustomOption, error)
+	DeleteOption(id uint) error
+	Quote(req *models.CustomCupcakeQuoteRequest) (*models.CustomCupcakeQuote, error)
+}
+
+type PickupServiceInterface interface {
+	CreateSlot(locationID uint, req *models.CreatePickupSlotRequest) (*models.PickupSlot, error)
+	GetSlots(locationID uint, day time.Time) ([]models.PickupSlot, error)
+	Reserve(locationID, slotID uint, req *models.ReservePickupRequest) (*models.PickupReservation, error)
+	GetSchedule(locationID uint, day time.Time) ([]models.PickupSchedule, error)
+	MarkReady(id uint) (*models.PickupReservation, error)
+	Start(id uint) (*models.PickupReservation, error)
+	Cancel(id uint, req *models.CancelPickupRequest) (*models.PickupReservation, error)
+}
+
+// WebhookServiceInterface also publishes events: other services hand it
+// their events to fan out to subscribed endpoints.
+type WebhookServiceInterface interface {
+	EventPublisher
+	CreateWebhook(req *models.CreateWebhookRequest) (*models.Webhook, error)
+	GetWebhook(id uint) (*models.Webhook, error)
+	GetAllWebhooks() ([]models.Webhook, error)
+	UpdateWebhook(id uint, req *models.UpdateWebhookRequest) (*models.Webhook, error)
+	DeleteWebhook(id uint) error
+	GetDeliveries(id uint) ([]models.WebhookDelivery, error)
+}
+
+type ExperimentServiceInterface interface {
+	CreateExperiment(req *models.CreateExperimentRequest) (*models.Experiment, error)
+	GetExperiment(id uint) (*models.ExperimentResults, error)
+	GetAllExperiments() ([]models.Experiment, error)
+	StopExperiment(id uint) (*models.Experiment, error)
+	Assign(visitorID string) ([]models.ExperimentAssignment, error)
+	ApplyPrices(cupcakes []models.Cupcake, visitorID string) ([]models.ExperimentAssignment, error)
+	RecordConversion(key, visitorID string, req *models.ConversionRequest) error
+}
+
+type DataExportServiceInterface interface {
+	Request(email string) (*models.DataExport, error)
+	Download(id uint, expires int64, signature string) (*models.DataExport, error)
+}
+
+type ErasureServiceInterface interface {
+	Request(email string) error
+	Confirm(email string, expires int64, token string) (*models.Erasure, error)
+	Erase(email string) (*models.Erasure, error)
+	GetErasures(email string) ([]models.Erasure, error)
+}
+
+type AccountServiceInterface interface {
+	Register(req *models.RegisterRequest) (*models.Account, error)
+	Login(req *models.LoginRequest) (*models.AccessToken, error)
+	Authenticate(token string) (*models.Account, error)
+	VerifyEmail(token string) (*models.Account, error)
+	ResendVerification(accessToken string) error
+}
+
+type SessionServiceInterface interface {
+	Login(req *models.LoginRequest) (*models.WebSession, error)
+	Get(token string) (*models.WebSession, error)
+	AccessToken(token string) (*models.AccessToken, error)
+	ValidCSRF(token, csrfToken string) bool
+	Logout(token string) error
+}
+
+type TicketServiceInterface interface {
+	CreateTicket(req *models.CreateTicketRequest) (*models.Ticket, error)
+	GetTicket(id uint) (*models.Ticket, error)
+	GetTickets(status string) ([]models.Ticket, error)
+	UpdateTicket(id uint, req *models.UpdateTicketRequest) (*models.Ticket, error)
+}
+
+type StockAlertServiceInterface interface {
+	CheckStock() (int, error)
+	GetAlerts(status string) ([]models.StockAlert, error)
+	AcknowledgeAlert(id uint) (*models.StockAlert, error)
+	ResolveAlert(id uint) (*models.StockAlert, error)
+}
+
+type APIKeyServiceInterface interface {
+	CreateAPIKey(req *models.CreateAPIKeyRequest) (*models.CreatedAPIKey, error)
+	GetAPIKeys() ([]models.APIKey, error)
+	GetAPIKey(id uint) (*models.APIKey, error)
+	UpdateAPIKey(id uint, req *models.UpdateAPIKeyRequest) (*models.APIKey, error)
+	RevokeAPIKey(id uint) (*models.APIKey, error)
+	Use(secret string) (*models.APIKeyQuota, error)
+	Usage(id uint, window int) (*models.APIKeyUsageReport, error)
+}
+
+type AdminServiceInterface interface {
+	CreateAdmin(req *models.CreateAdminRequest) (*models.Admin, error)
+	GetAdmins() ([]models.Admin, error)
+	Login(req *models.AdminLoginRequest) (*models.AdminAccessToken, error)
+	Authenticate(token string) (*models.Admin, error)
+	BeginTOTP(adminID uint) (*models.TOTPEnrollment, error)
+	ConfirmTOTP(adminID uint, code string) (*models.BackupCodes, error)
+	DisableTOTP(adminID uint, code string) error
+	RegenerateBackupCodes(adminID uint, code string) (*models.BackupCodes, error)
+	ResetTOTP(adminID uint) (*models.Admin, error)
+	SendLoginCode(req *models.AdminLoginRequest) error
+	SetPhone(adminID uint, phone string) (*models.Admin, error)
+}
+
+type OAuthServiceInterface interface {
+	Providers() []string
+	Begin(provider, redirectURI string) (*models.OAuthAuthorization, error)
+	BeginLink(accessToken, provider, redirectURI string) (*models.OAuthAuthorization, error)
+	Complete(ctx context.Context, provider, code, state, nonce, redirectURI string) (*models.AccessToken, error)
+	Identities(accessToken string) ([]models.AccountIdentity, error)
+	Unlink(accessToken, provider string) error
+}
+
+type EmailTemplateServiceInterface interface {
+	GetTemplates(locationID uint) ([]models.EmailTemplateResponse, error)
+	GetTemplate(name string, locationID uint) (*models.EmailTemplateResponse, error)
+	SetTemplate(name string, req *models.UpdateEmailTemplateRequest) (*models.EmailTemplateResponse, error)
+	ResetTemplate(name string, locationID uint) error
+	Preview(name string, req *models.PreviewEmailTemplateRequest) (*models.RenderedEmail, error)
+	Render(name string, locationID uint, data any) (*models.RenderedEmail, error)
+}
+
+type NotificationServiceInterface interface {
+	GetPreferences(accountID uint) ([]models.NotificationPreferenceResponse, error)
+	UpdatePreferences(accountID uint, req *models.UpdateNotificationPreferencesRequest) ([]models.NotificationPreferenceResponse, error)
+}
+
+type DeviceServiceInterface interface {
+	GetDevices(accountID uint) ([]models.DeviceToken, error)
+	RegisterDevice(accountID uint, req *models.RegisterDeviceRequest) (*models.DeviceToken, error)
+	UnregisterDevice(accountID uint, token string) error
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	jobMaxAttempts    = 5
+	jobInitialBackoff = time.Second
+	// jobLease is how long a worker holds a job before it is taken to have
+	// stopped. It must outlast the slowest handler, such as a data export.
+	jobLease = 10 * time.Minute
+)
+
+type JobHandler func(job *models.Job) error
+
+type JobService struct {
+	repo           repository.JobRepositoryInterface
+	handlers       map[string]JobHandler
+	maxAttempts    int
+	initialBackoff time.Duration
+	lease          time.Duration
+	now            func() time.Time
+}
+
+func NewJobService(repo repository.JobRepositoryInterface) *JobService {
+	return &JobService{
+		repo:           repo,
+		handlers:       make(map[string]JobHandler),
+		maxAttempts:    jobMaxAttempts,
+		initialBackoff: jobInitialBackoff,
+		lease:          jobLease,
+		now:            time.Now,
+	}
+}
+
+// Register sets the handler of jobType. Handlers are not guarded against
+// the worker, so every one must be registered before Run starts.
+func (s *JobService) Register(jobType string, handler JobHandler) {
+	s.handlers[jobType] = handler
+}
+
+func (s *JobService) Enqueue(jobType string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Create(&models.Job{
+		Type:        jobType,
+		Payload:     string(body),
+		Status:      models.JobPending,
+		MaxAttempts: s.maxAttempts,
+		RunAt:       s.now(),
+	})
+}
+
+func (s *JobService) GetDeadJobs() ([]models.Job, error) {
+	return s.repo.FindByStatus(models.JobDead)
+}
+
+// RunNext processes a single due job and reports whether one was found.
+func (s *JobService) RunNext() (bool, error) {
+	job, err := s.repo.ClaimNext(s.now(), s.lease)
+	// A conflict means another worker claimed the job first.
+	if errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	job.LockedUntil = nil
+
+	// Claimed again after its last attempt, the job was left running by a
+	// worker that stopped; it is not tried any more.
+	if job.Attempts > job.MaxAttempts {
+		job.Status = models.JobDead
+		job.LastError = "lease expired on the last attempt"
+		return true, s.repo.Update(job)
+	}
+
+	if err := s.execute(job); err != nil {
+		job.LastError = err.Error()
+		if job.Attempts >= job.MaxAttempts {
+			job.Status = models.JobDead
+		} else {
+			job.Status = models.JobPending
+			job.RunAt = s.now().Add(s.initialBackoff << (job.Attempts - 1))
+		}
+	} else {
+		job.Status = models.JobCompleted
+		job.LastError = ""
+	}
+
+	return true, s.repo.Update(job)
+}
+
+// PurgeCompleted deletes the jobs completed more than retention ago and
+// returns how many there were.
+func (s *JobService) PurgeCompleted(retention time.Duration) (int64, error) {
+	return s.repo.DeleteCompletedBefore(s.now().Add(-retention))
+}
+
+// Run polls for due jobs until ctx is cancelled, draining the queue before
+// each wait.
+func (s *JobService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			found, err := s.RunNext()
+			if err != nil {
+				log.Printf("Error running job: %v", err)
+				break
+			}
+			if !found {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *JobService) execute(job *models.Job) (err error) {
+	handler, ok := s.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %s", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(job)
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func drainJobs(t *testing.T, s *JobService) {
+	t.Helper()
+
+	for {
+		found, err := s.RunNext()
+		require.NoError(t, err)
+		if !found {
+			return
+		}
+	}
+}
+
+func TestRunNext(t *testing.T) {
+	tests := []struct {
+		name             string
+		jobType          string
+		failures         int
+		runs             int
+		expectedStatus   string
+		expectedAttempts int
+		expectedError    string
+		expectedDelay    time.Duration
+	}{
+		{
+			name:             "completes on first attempt",
+			jobType:          "test.job",
+			runs:             1,
+			expectedStatus:   models.JobCompleted,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "reschedules failed job with backoff",
+			jobType:          "test.job",
+			failures:         2,
+			runs:             2,
+			expectedStatus:   models.JobPending,
+			expectedAttempts: 2,
+			expectedError:    "temporary failure",
+			expectedDelay:    2 * time.Second,
+		},
+		{
+			name:             "moves job to dead letter after max attempts",
+			jobType:          "test.job",
+			failures:         jobMaxAttempts,
+			runs:             jobMaxAttempts,
+			expectedStatus:   models.JobDead,
+			expectedAttempts: jobMaxAttempts,
+			expectedError:    "temporary failure",
+		},
+		{
+			name:             "unknown job type is retried",
+			jobType:          "unknown.job",
+			runs:             1,
+			expectedStatus:   models.JobPending,
+			expectedAttempts: 1,
+			expectedError:    "no handler registered for job type unknown.job",
+			expectedDelay:    time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			repo := repository.NewJobRepository(db)
+			service := NewJobService(repo)
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			service.now = func() time.Time { return now }
+
+			calls := 0
+			service.Register("test.job", func(job *models.Job) error {
+				calls++
+				if calls <= tt.failures {
+					return errors.New("temporary failure")
+				}
+				return nil
+			})
+
+			require.NoError(t, service.Enqueue(tt.jobType, map[string]string{"key": "value"}))
+
+			for i := 0; i < tt.runs; i++ {
+				found, err := service.RunNext()
+				require.NoError(t, err)
+				require.True(t, found)
+				now = now.Add(time.Hour)
+			}
+			now = now.Add(-time.Hour)
+
+			var job models.Job
+			require.NoError(t, db.First(&job).Error)
+			require.Equal(t, tt.expectedStatus, job.Status)
+			require.Equal(t, tt.expectedAttempts, job.Attempts)
+			require.Equal(t, tt.expectedError, job.LastError)
+			require.JSONEq(t, `{"key":"value"}`, job.Payload)
+			if tt.expectedDelay > 0 {
+				require.True(t, job.RunAt.Equal(now.Add(tt.expectedDelay)))
+			}
+		})
+	}
+}
+
+func TestRunNext_NotDue(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewJobService(repository.NewJobRepository(db))
+	service.Register("test.job", func(job *models.Job) error {
+		return errors.New("temporary failure")
+	})
+
+	require.NoError(t, service.Enqueue("test.job", nil))
+
+	found, err := service.RunNext()
+	require.NoError(t, err)
+	require.True(t, found)
+
+	found, err = service.RunNext()
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestRunNext_LeaseExpired(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewJobService(repository.NewJobRepository(db))
+	service.maxAttempts = 2
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	runs := 0
+	service.Register("test.job", func(job *models.Job) error {
+		runs++
+		return nil
+	})
+	require.NoError(t, service.Enqueue("test.job", nil))
+
+	// A worker claims the job and stops before finishing it.
+	_, err := service.repo.ClaimNext(now, service.lease)
+	require.NoError(t, err)
+	found, err := service.RunNext()
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// Once the lease runs out another worker runs it.
+	now = now.Add(service.lease)
+	found, err = service.RunNext()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 1, runs)
+	var job models.Job
+	require.NoError(t, db.First(&job).Error)
+	require.Equal(t, models.JobCompleted, job.Status)
+	require.Nil(t, job.LockedUntil)
+}
+
+func TestRunNext_LeaseExpiredOnLastAttempt(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewJobService(repository.NewJobRepository(db))
+	service.maxAttempts = 1
+	now := time.Now()
+	service.now = func() time.Time { return now }
+	service.Register("test.job", func(job *models.Job) error {
+		t.Fatal("a job past its last attempt must not run")
+		return nil
+	})
+	require.NoError(t, service.Enqueue("test.job", nil))
+
+	_, err := service.repo.ClaimNext(now, service.lease)
+	require.NoError(t, err)
+	now = now.Add(service.lease)
+	found, err := service.RunNext()
+	require.NoError(t, err)
+	require.True(t, found)
+
+	dead, err := service.GetDeadJobs()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, "lease expired on the last attempt", dead[0].LastError)
+}
+
+func TestPurgeCompleted(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewJobService(repository.NewJobRepository(db))
+	service.Register("test.job", func(job *models.Job) error { return nil })
+	require.NoError(t, service.Enqueue("test.job", nil))
+	drainJobs(t, service)
+
+	purged, err := service.PurgeCompleted(time.Hour)
+	require.NoError(t, err)
+	require.Zero(t, purged)
+
+	service.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	purged, err = service.PurgeCompleted(time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+}
+
+func TestGetDeadJobs(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewJobService(repository.NewJobRepository(db))
+	service.maxAttempts = 1
+	service.Register("test.job", func(job *models.Job) error {
+		panic("boom")
+	})
+
+	require.NoError(t, service.Enqueue("test.job", nil))
+	drainJobs(t, service)
+
+	jobs, err := service.GetDeadJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, "job panicked: boom", jobs[0].LastError)
+}
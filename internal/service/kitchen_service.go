@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// KitchenService turns what is scheduled for a day into what the kitchen
+// has to bake.
+type KitchenService struct {
+	subscriptions repository.SubscriptionRepositoryInterface
+	pickups       repository.PickupRepositoryInterface
+	cupcakeRepo   repository.CupcakeRepositoryInterface
+}
+
+var _ KitchenServiceInterface = (*KitchenService)(nil)
+
+func NewKitchenService(subscriptions repository.SubscriptionRepositoryInterface, pickups repository.PickupRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface) *KitchenService {
+	return &KitchenService{subscriptions: subscriptions, pickups: pickups, cupcakeRepo: cupcakeRepo}
+}
+
+// ProductionPlan adds up, per cupcake, the active subscriptions delivering
+// on day and the pickups reserved in slots starting on day.
+func (s *KitchenService) ProductionPlan(day time.Time) (*models.ProductionPlan, error) {
+	from, to := dayBounds(day)
+
+	subscriptions, err := s.subscriptions.FindScheduled(from, to)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := s.pickups.FindReservationsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(map[uint]*models.ProductionPlanLine)
+	line := func(cupcakeID uint) *models.ProductionPlanLine {
+		if lines[cupcakeID] == nil {
+			lines[cupcakeID] = &models.ProductionPlanLine{CupcakeID: cupcakeID}
+		}
+		return lines[cupcakeID]
+	}
+	for _, subscription := range subscriptions {
+		line(subscription.CupcakeID).SubscriptionQuantity += subscription.Quantity
+	}
+	for _, reservation := range reservations {
+		for _, item := range reservation.Items {
+			line(item.CupcakeID).PickupQuantity += item.Quantity
+		}
+	}
+
+	plan := &models.ProductionPlan{
+		Date:  from.Format(time.DateOnly),
+		Lines: make([]models.ProductionPlanLine, 0, len(lines)),
+	}
+	for cupcakeID, l := range lines {
+		cupcake, err := s.cupcakeRepo.FindByID(cupcakeID)
+		switch {
+		case err == nil:
+			l.Name = cupcake.Name
+		case !errors.Is(err, repository.ErrNotFound):
+			return nil, err
+		}
+		l.Quantity = l.SubscriptionQuantity + l.PickupQuantity
+		plan.TotalQuantity += l.Quantity
+		plan.Lines = append(plan.Lines, *l)
+	}
+	sort.Slice(plan.Lines, func(i, j int) bool {
+		return plan.Lines[i].CupcakeID < plan.Lines[j].CupcakeID
+	})
+
+	return plan, nil
+}
+
+// Queue lists the pickup orders the kitchen still has to make for slots
+// starting on day, at every location or only at locationID, in the order
+// the slots start.
+func (s *KitchenService) Queue(day time.Time, locationID uint) ([]models.KitchenQueueEntry, error) {
+	from, to := dayBounds(day)
+	reservations, err := s.pickups.FindOpenReservations(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make(map[uint]*models.PickupSlot)
+	names := make(map[uint]string)
+	queue := make([]models.KitchenQueueEntry, 0, len(reservations))
+	for _, reservation := range reservations {
+		slot := slots[reservation.SlotID]
+		if slot == nil {
+			if slot, err = s.pickups.FindSlot(reservation.SlotID); err != nil {
+				return nil, err
+			}
+			slots[reservation.SlotID] = slot
+		}
+		if locationID != 0 && slot.LocationID != locationID {
+			continue
+		}
+
+		entry := models.KitchenQueueEntry{
+			ReservationID: reservation.ID,
+			LocationID:    slot.LocationID,
+			SlotStartsAt:  slot.StartsAt,
+			SlotEndsAt:    slot.EndsAt,
+			CustomerName:  reservation.CustomerName,
+			Status:        models.KitchenQueueOpen,
+			StartedAt:     reservation.StartedAt,
+			Items:         make([]models.KitchenQueueItem, len(reservation.Items)),
+		}
+		if reservation.StartedAt != nil {
+			entry.Status = models.KitchenQueueInProgress
+		}
+		for i, item := range reservation.Items {
+			name, ok := names[item.CupcakeID]
+			if !ok {
+				cupcake, err := s.cupcakeRepo.FindByID(item.CupcakeID)
+				switch {
+				case err == nil:
+					name = cupcake.Name
+				case !errors.Is(err, repository.ErrNotFound):
+					return nil, err
+				}
+				names[item.CupcakeID] = name
+			}
+			entry.Items[i] = models.KitchenQueueItem{CupcakeID: item.CupcakeID, Name: name, Quantity: item.Quantity}
+		}
+		queue = append(queue, entry)
+	}
+
+	return queue, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductionPlan(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local)
+	subscriptionRepo := repository.NewSubscriptionRepository(db)
+	for _, subscription := range []models.Subscription{
+		{CustomerEmail: "ana@example.com", CupcakeID: 1, Quantity: 6, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: day.Add(9 * time.Hour)},
+		{CustomerEmail: "bia@example.com", CupcakeID: 2, Quantity: 4, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: day.Add(15 * time.Hour)},
+		{CustomerEmail: "caio@example.com", CupcakeID: 1, Quantity: 12, Frequency: models.FrequencyWeekly, Status: models.SubscriptionPaused, NextDeliveryAt: day.Add(9 * time.Hour)},
+		{CustomerEmail: "davi@example.com", CupcakeID: 1, Quantity: 12, Frequency: models.FrequencyWeekly, Status: models.SubscriptionActive, NextDeliveryAt: day.AddDate(0, 0, 1)},
+	} {
+		require.NoError(t, subscriptionRepo.Create(&subscription))
+	}
+
+	pickupRepo := repository.NewPickupRepository(db)
+	today := &models.PickupSlot{LocationID: 1, StartsAt: day.Add(10 * time.Hour), EndsAt: day.Add(11 * time.Hour), Capacity: 5}
+	nextDay := &models.PickupSlot{LocationID: 2, StartsAt: day.Add(34 * time.Hour), EndsAt: day.Add(35 * time.Hour), Capacity: 5}
+	require.NoError(t, pickupRepo.CreateSlot(today))
+	require.NoError(t, pickupRepo.CreateSlot(nextDay))
+	for _, reservation := range []models.PickupReservation{
+		{SlotID: today.ID, CustomerName: "Eva", CustomerEmail: "eva@example.com", Items: []models.PickupReservationItem{{CupcakeID: 1, Quantity: 2}, {CupcakeID: 2, Quantity: 1}}},
+		{SlotID: nextDay.ID, CustomerName: "Fabio", CustomerEmail: "fabio@example.com", Items: []models.PickupReservationItem{{CupcakeID: 2, Quantity: 10}}},
+	} {
+		require.NoError(t, pickupRepo.Reserve(&reservation))
+	}
+
+	svc := NewKitchenService(subscriptionRepo, pickupRepo, cupcakeRepo)
+
+	plan, err := svc.ProductionPlan(day.Add(13 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, "2026-10-17", plan.Date)
+	require.Equal(t, []models.ProductionPlanLine{
+		{CupcakeID: 1, Name: "Vanilla", SubscriptionQuantity: 6, PickupQuantity: 2, Quantity: 8},
+		{CupcakeID: 2, Name: "Chocolate", SubscriptionQuantity: 4, PickupQuantity: 1, Quantity: 5},
+	}, plan.Lines)
+	require.Equal(t, 13, plan.TotalQuantity)
+
+	empty, err := svc.ProductionPlan(day.AddDate(0, 0, 5))
+	require.NoError(t, err)
+	require.Empty(t, empty.Lines)
+	require.Zero(t, empty.TotalQuantity)
+}
+
+func TestKitchenQueue(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local)
+	pickupRepo := repository.NewPickupRepository(db)
+	afternoon := &models.PickupSlot{LocationID: 1, StartsAt: day.Add(15 * time.Hour), EndsAt: day.Add(16 * time.Hour), Capacity: 5}
+	morning := &models.PickupSlot{LocationID: 2, StartsAt: day.Add(9 * time.Hour), EndsAt: day.Add(10 * time.Hour), Capacity: 5}
+	require.NoError(t, pickupRepo.CreateSlot(afternoon))
+	require.NoError(t, pickupRepo.CreateSlot(morning))
+	for _, reservation := range []models.PickupReservation{
+		{SlotID: afternoon.ID, CustomerName: "Eva", CustomerEmail: "eva@example.com", Items: []models.PickupReservationItem{{CupcakeID: 1, Quantity: 2}, {CupcakeID: 2, Quantity: 1}}},
+		{SlotID: morning.ID, CustomerName: "Fabio", CustomerEmail: "fabio@example.com", Items: []models.PickupReservationItem{{CupcakeID: 2, Quantity: 6}}},
+		{SlotID: morning.ID, CustomerName: "Gil", CustomerEmail: "gil@example.com", Items: []models.PickupReservationItem{{CupcakeID: 1, Quantity: 1}}},
+	} {
+		require.NoError(t, pickupRepo.Reserve(&reservation))
+	}
+	startedAt := day.Add(8 * time.Hour)
+	require.NoError(t, pickupRepo.MarkStarted(2, startedAt))
+	require.NoError(t, pickupRepo.MarkReady(3, startedAt))
+
+	svc := NewKitchenService(repository.NewSubscriptionRepository(db), pickupRepo, cupcakeRepo)
+
+	queue, err := svc.Queue(day.Add(13*time.Hour), 0)
+	require.NoError(t, err)
+	require.Len(t, queue, 2)
+	require.Equal(t, uint(2), queue[0].ReservationID)
+	require.Equal(t, models.KitchenQueueInProgress, queue[0].Status)
+	require.True(t, startedAt.Equal(*queue[0].StartedAt))
+	require.Equal(t, []models.KitchenQueueItem{{CupcakeID: 2, Name: "Chocolate", Quantity: 6}}, queue[0].Items)
+	require.Equal(t, uint(1), queue[1].ReservationID)
+	require.Equal(t, uint(1), queue[1].LocationID)
+	require.Equal(t, "Eva", queue[1].CustomerName)
+	require.Equal(t, models.KitchenQueueOpen, queue[1].Status)
+	require.Nil(t, queue[1].StartedAt)
+	require.Equal(t, []models.KitchenQueueItem{{CupcakeID: 1, Name: "Vanilla", Quantity: 2}, {CupcakeID: 2, Name: "Chocolate", Quantity: 1}}, queue[1].Items)
+
+	queue, err = svc.Queue(day, 1)
+	require.NoError(t, err)
+	require.Len(t, queue, 1)
+	require.Equal(t, uint(1), queue[0].ReservationID)
+
+	queue, err = svc.Queue(day.AddDate(0, 0, 1), 0)
+	require.NoError(t, err)
+	require.Empty(t, queue)
+}
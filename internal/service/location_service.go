@@ -0,0 +1,258 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var ErrLocationNotFound = errors.New("location not found")
+
+type LocationService struct {
+	repo        repository.LocationRepositoryInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+	bundleRepo  repository.BundleRepositoryInterface
+}
+
+var _ LocationServiceInterface = (*LocationService)(nil)
+
+func NewLocationService(repo repository.LocationRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface, bundleRepo repository.BundleRepositoryInterface) *LocationService {
+	return &LocationService{repo: repo, cupcakeRepo: cupcakeRepo, bundleRepo: bundleRepo}
+}
+
+func (s *LocationService) CreateLocation(req *models.CreateLocationRequest) (*models.Location, error) {
+	location := &models.Location{
+		Name:    strings.TrimSpace(req.Name),
+		Address: strings.TrimSpace(req.Address),
+		Hours:   strings.TrimSpace(req.Hours),
+	}
+	if err := validateLocation(location); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(location); err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+func (s *LocationService) GetLocation(id uint) (*models.Location, error) {
+	return findLocation(s.repo, id)
+}
+
+func (s *LocationService) GetAllLocations() ([]models.Location, error) {
+	return s.repo.FindAll()
+}
+
+func (s *LocationService) UpdateLocation(id uint, req *models.UpdateLocationRequest) (*models.Location, error) {
+	location, err := findLocation(s.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		location.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.Address != nil {
+		location.Address = strings.TrimSpace(*req.Address)
+	}
+
+	if req.Hours != nil {
+		location.Hours = strings.TrimSpace(*req.Hours)
+	}
+
+	if err := validateLocation(location); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(location); err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
+
+func (s *LocationService) DeleteLocation(id uint) error {
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrLocationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *LocationService) GetStock(locationID uint) ([]models.LocationStock, error) {
+	if _, err := findLocation(s.repo, locationID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindStock(locationID)
+}
+
+func (s *LocationService) SetStock(locationID, cupcakeID uint, req *models.SetStockRequest) (*models.LocationStock, error) {
+	if req.Quantity == nil {
+		return nil, i18n.NewError(msgQuantityRequired, nil)
+	}
+	if *req.Quantity < 0 {
+		return nil, i18n.NewError(msgQuantityNegative, nil)
+	}
+	if req.ReorderThreshold != nil && *req.ReorderThreshold < 0 {
+		return nil, i18n.NewError(msgReorderThresholdNegative, nil)
+	}
+
+	if _, err := findLocation(s.repo, locationID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.cupcakeRepo.Exists(cupcakeID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, i18n.NewError(msgCupcakeNotFound, nil)
+	}
+
+	stock := &models.LocationStock{LocationID: locationID, CupcakeID: cupcakeID, Quantity: *req.Quantity}
+	if req.ReorderThreshold != nil {
+		stock.ReorderThreshold = *req.ReorderThreshold
+	} else {
+		current, err := s.repo.FindStock(locationID)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range current {
+			if entry.CupcakeID == cupcakeID {
+				stock.ReorderThreshold = entry.ReorderThreshold
+			}
+		}
+	}
+	if err := s.repo.SetStock(stock); err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// CheckPickup confirms every item can be collected at the location: the
+// cupcake is still sold and the location has enough units of it. Bundles
+// are expanded into their cupcakes first. It returns the basket as one
+// line per cupcake, in the order the cupcakes first appear.
+func (s *LocationService) CheckPickup(locationID uint, req *models.PickupCheckRequest) ([]models.PickupItem, error) {
+	if len(req.Items) == 0 && len(req.Bundles) == 0 {
+		return nil, i18n.NewError(msgItemsRequired, nil)
+	}
+
+	if _, err := findLocation(s.repo, locationID); err != nil {
+		return nil, err
+	}
+
+	// The same cupcake may be listed more than once, directly or through
+	// bundles; check the total.
+	wanted := make(map[uint]int, len(req.Items))
+	var order []uint
+	add := func(cupcakeID uint, quantity int) {
+		if _, seen := wanted[cupcakeID]; !seen {
+			order = append(order, cupcakeID)
+		}
+		wanted[cupcakeID] += quantity
+	}
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, i18n.NewError(msgQuantityNotPositive, nil)
+		}
+		add(item.CupcakeID, item.Quantity)
+	}
+	for _, line := range req.Bundles {
+		if line.Quantity <= 0 {
+			return nil, i18n.NewError(msgQuantityNotPositive, nil)
+		}
+		bundle, err := s.findBundle(line.BundleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range bundle.Items {
+			add(item.CupcakeID, item.Quantity*line.Quantity)
+		}
+	}
+
+	stock, err := s.repo.FindStock(locationID)
+	if err != nil {
+		return nil, err
+	}
+	onHand := make(map[uint]int, len(stock))
+	for _, entry := range stock {
+		onHand[entry.CupcakeID] = entry.Quantity
+	}
+
+	basket := make([]models.PickupItem, 0, len(order))
+	for _, cupcakeID := range order {
+		cupcake, err := s.cupcakeRepo.FindByID(cupcakeID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, i18n.NewError(msgCupcakeIDNotFound, map[string]any{"ID": cupcakeID})
+			}
+			return nil, err
+		}
+		if !cupcake.IsAvailable {
+			return nil, i18n.NewError(msgNotAvailable, map[string]any{"Name": cupcake.Name})
+		}
+		if onHand[cupcakeID] < wanted[cupcakeID] {
+			return nil, i18n.NewError(msgInsufficientStock, map[string]any{"Available": onHand[cupcakeID], "Name": cupcake.Name})
+		}
+		basket = append(basket, models.PickupItem{CupcakeID: cupcakeID, Quantity: wanted[cupcakeID]})
+	}
+
+	return basket, nil
+}
+
+func (s *LocationService) findBundle(id uint) (*models.Bundle, error) {
+	if s.bundleRepo == nil {
+		return nil, i18n.NewError(msgBundlesNotConfigured, nil)
+	}
+	bundle, err := s.bundleRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, i18n.NewError(msgBundleIDNotFound, map[string]any{"ID": id})
+		}
+		return nil, err
+	}
+	if !bundle.IsAvailable {
+		return nil, i18n.NewError(msgNotAvailable, map[string]any{"Name": bundle.Name})
+	}
+	return bundle, nil
+}
+
+func findLocation(repo repository.LocationRepositoryInterface, id uint) (*models.Location, error) {
+	location, err := repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		return nil, err
+	}
+	return location, nil
+}
+
+func validateLocation(location *models.Location) error {
+	if location.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if location.Address == "" {
+		return i18n.NewError(msgAddressRequired, nil)
+	}
+	if len(location.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if len(location.Address) > 255 {
+		return i18n.NewError(msgAddressTooLong, nil)
+	}
+	if len(location.Hours) > 255 {
+		return i18n.NewError(msgHoursTooLong, nil)
+	}
+	return nil
+}
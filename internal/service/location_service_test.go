@@ -0,0 +1,202 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocationService(t *testing.T) (*LocationService, *repository.CupcakeRepository) {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	return NewLocationService(repository.NewLocationRepository(db), cupcakeRepo, repository.NewBundleRepository(db)), cupcakeRepo
+}
+
+func TestCreateLocation(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateLocationRequest
+		expectedError string
+	}{
+		{
+			name:    "success",
+			request: &models.CreateLocationRequest{Name: " Centro ", Address: "Rua Augusta, 100", Hours: "Seg-Sáb 9h-19h"},
+		},
+		{
+			name:          "validation error - missing name",
+			request:       &models.CreateLocationRequest{Address: "Rua Augusta, 100"},
+			expectedError: "name is required",
+		},
+		{
+			name:          "validation error - blank address",
+			request:       &models.CreateLocationRequest{Name: "Centro", Address: "   "},
+			expectedError: "address is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newTestLocationService(t)
+
+			location, err := svc.CreateLocation(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, location.ID)
+			require.Equal(t, "Centro", location.Name)
+		})
+	}
+}
+
+func TestGetLocation_NotFound(t *testing.T) {
+	svc, _ := newTestLocationService(t)
+
+	_, err := svc.GetLocation(999)
+	require.ErrorIs(t, err, ErrLocationNotFound)
+
+	require.ErrorIs(t, svc.DeleteLocation(999), ErrLocationNotFound)
+}
+
+func TestSetStock(t *testing.T) {
+	tests := []struct {
+		name          string
+		locationID    uint
+		cupcakeID     uint
+		quantity      *int
+		threshold     *int
+		expectedError string
+	}{
+		{name: "success", locationID: 1, cupcakeID: 1, quantity: intPtr(4)},
+		{name: "missing quantity", locationID: 1, cupcakeID: 1, expectedError: "quantity is required"},
+		{name: "negative quantity", locationID: 1, cupcakeID: 1, quantity: intPtr(-1), expectedError: "quantity cannot be negative"},
+		{name: "negative threshold", locationID: 1, cupcakeID: 1, quantity: intPtr(4), threshold: intPtr(-1), expectedError: "reorder threshold cannot be negative"},
+		{name: "unknown location", locationID: 999, cupcakeID: 1, quantity: intPtr(4), expectedError: ErrLocationNotFound.Error()},
+		{name: "unknown cupcake", locationID: 1, cupcakeID: 999, quantity: intPtr(4), expectedError: "cupcake not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, cupcakeRepo := newTestLocationService(t)
+			cupcake := factory.Cupcake()
+			require.NoError(t, cupcakeRepo.Create(&cupcake))
+			_, err := svc.CreateLocation(&models.CreateLocationRequest{Name: "Centro", Address: "Rua Augusta, 100"})
+			require.NoError(t, err)
+
+			stock, err := svc.SetStock(tt.locationID, tt.cupcakeID, &models.SetStockRequest{Quantity: tt.quantity, ReorderThreshold: tt.threshold})
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, *tt.quantity, stock.Quantity)
+		})
+	}
+}
+
+func TestSetStock_KeepsReorderThreshold(t *testing.T) {
+	svc, cupcakeRepo := newTestLocationService(t)
+	cupcake := factory.Cupcake()
+	require.NoError(t, cupcakeRepo.Create(&cupcake))
+	_, err := svc.CreateLocation(&models.CreateLocationRequest{Name: "Centro", Address: "Rua Augusta, 100"})
+	require.NoError(t, err)
+
+	_, err = svc.SetStock(1, cupcake.ID, &models.SetStockRequest{Quantity: intPtr(12), ReorderThreshold: intPtr(5)})
+	require.NoError(t, err)
+	stock, err := svc.SetStock(1, cupcake.ID, &models.SetStockRequest{Quantity: intPtr(4)})
+	require.NoError(t, err)
+	require.Equal(t, 5, stock.ReorderThreshold)
+
+	stored, err := svc.GetStock(1)
+	require.NoError(t, err)
+	require.Equal(t, 4, stored[0].Quantity)
+	require.Equal(t, 5, stored[0].ReorderThreshold)
+}
+
+func TestCheckPickup(t *testing.T) {
+	tests := []struct {
+		name          string
+		locationID    uint
+		items         []models.PickupItem
+		expectedError string
+	}{
+		{
+			name:       "enough stock",
+			locationID: 1,
+			items:      []models.PickupItem{{CupcakeID: 1, Quantity: 2}, {CupcakeID: 1, Quantity: 1}},
+		},
+		{
+			name:          "repeated items add up past the stock",
+			locationID:    1,
+			items:         []models.PickupItem{{CupcakeID: 1, Quantity: 2}, {CupcakeID: 1, Quantity: 2}},
+			expectedError: "only 3 of Vanilla available at this location",
+		},
+		{
+			name:          "not stocked at this location",
+			locationID:    1,
+			items:         []models.PickupItem{{CupcakeID: 2, Quantity: 1}},
+			expectedError: "only 0 of Chocolate available at this location",
+		},
+		{
+			name:          "cupcake withdrawn from the catalog",
+			locationID:    1,
+			items:         []models.PickupItem{{CupcakeID: 3, Quantity: 1}},
+			expectedError: "Lemon is not available",
+		},
+		{
+			name:          "unknown cupcake",
+			locationID:    1,
+			items:         []models.PickupItem{{CupcakeID: 999, Quantity: 1}},
+			expectedError: "cupcake 999 not found",
+		},
+		{
+			name:          "no items",
+			locationID:    1,
+			expectedError: "at least one item is required",
+		},
+		{
+			name:          "zero quantity",
+			locationID:    1,
+			items:         []models.PickupItem{{CupcakeID: 1, Quantity: 0}},
+			expectedError: "quantity must be greater than zero",
+		},
+		{
+			name:          "unknown location",
+			locationID:    999,
+			items:         []models.PickupItem{{CupcakeID: 1, Quantity: 1}},
+			expectedError: ErrLocationNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, cupcakeRepo := newTestLocationService(t)
+			for _, cupcake := range []models.Cupcake{
+				factory.Cupcake(factory.WithName("Vanilla")),
+				factory.Cupcake(factory.WithName("Chocolate")),
+				factory.Cupcake(factory.WithName("Lemon"), factory.Unavailable()),
+			} {
+				require.NoError(t, cupcakeRepo.Create(&cupcake))
+			}
+			_, err := svc.CreateLocation(&models.CreateLocationRequest{Name: "Centro", Address: "Rua Augusta, 100"})
+			require.NoError(t, err)
+			_, err = svc.SetStock(1, 1, &models.SetStockRequest{Quantity: intPtr(3)})
+			require.NoError(t, err)
+			_, err = svc.SetStock(1, 3, &models.SetStockRequest{Quantity: intPtr(10)})
+			require.NoError(t, err)
+
+			_, err = svc.CheckPickup(tt.locationID, &models.PickupCheckRequest{Items: tt.items})
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+// MaintenanceService holds the process-local maintenance and read-only
+// switches. They are not persisted, so each instance behind a load balancer
+// is toggled separately.
+type MaintenanceService struct {
+	mu     sync.RWMutex
+	status models.MaintenanceStatus
+	now    func() time.Time
+}
+
+func NewMaintenanceService(enabled, readOnly bool, retryAfter time.Duration) *MaintenanceService {
+	s := &MaintenanceService{now: time.Now}
+	s.status.RetryAfterSeconds = int(retryAfter / time.Second)
+	s.status.ReadOnly = readOnly
+	if enabled {
+		since := s.now()
+		s.status.Enabled = true
+		s.status.Since = &since
+	}
+	return s
+}
+
+func (s *MaintenanceService) Status() models.MaintenanceStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+func (s *MaintenanceService) SetStatus(req *models.MaintenanceRequest) (models.MaintenanceStatus, error) {
+	if req.Enabled == nil {
+		return models.MaintenanceStatus{}, i18n.NewError(msgEnabledRequired, nil)
+	}
+	if req.RetryAfterSeconds != nil && *req.RetryAfterSeconds <= 0 {
+		return models.MaintenanceStatus{}, i18n.NewError(msgRetryAfterNotPositive, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.RetryAfterSeconds != nil {
+		s.status.RetryAfterSeconds = *req.RetryAfterSeconds
+	}
+	if *req.Enabled && !s.status.Enabled {
+		since := s.now()
+		s.status.Since = &since
+	}
+	if !*req.Enabled {
+		s.status.Since = nil
+	}
+	s.status.Enabled = *req.Enabled
+	return s.status, nil
+}
+
+func (s *MaintenanceService) SetReadOnly(req *models.ReadOnlyRequest) (models.MaintenanceStatus, error) {
+	if req.Enabled == nil {
+		return models.MaintenanceStatus{}, i18n.NewError(msgEnabledRequired, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.ReadOnly = *req.Enabled
+	return s.status, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceService_SetStatus(t *testing.T) {
+	tests := []struct {
+		name               string
+		startEnabled       bool
+		request            models.MaintenanceRequest
+		expectedError      string
+		expectedEnabled    bool
+		expectedRetryAfter int
+	}{
+		{
+			name:               "enable keeps the configured retry-after",
+			request:            models.MaintenanceRequest{Enabled: boolPtr(true)},
+			expectedEnabled:    true,
+			expectedRetryAfter: 120,
+		},
+		{
+			name:               "enable with custom retry-after",
+			request:            models.MaintenanceRequest{Enabled: boolPtr(true), RetryAfterSeconds: intPtr(30)},
+			expectedEnabled:    true,
+			expectedRetryAfter: 30,
+		},
+		{
+			name:               "disable",
+			startEnabled:       true,
+			request:            models.MaintenanceRequest{Enabled: boolPtr(false)},
+			expectedEnabled:    false,
+			expectedRetryAfter: 120,
+		},
+		{
+			name:          "enabled is required",
+			request:       models.MaintenanceRequest{},
+			expectedError: "enabled is required",
+		},
+		{
+			name:          "retry-after must be positive",
+			request:       models.MaintenanceRequest{Enabled: boolPtr(true), RetryAfterSeconds: intPtr(0)},
+			expectedError: "retry_after_seconds must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMaintenanceService(tt.startEnabled, false, 2*time.Minute)
+
+			status, err := service.SetStatus(&tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				require.Equal(t, tt.startEnabled, service.Status().Enabled)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedEnabled, status.Enabled)
+			require.Equal(t, tt.expectedRetryAfter, status.RetryAfterSeconds)
+			require.Equal(t, tt.expectedEnabled, status.Since != nil)
+			require.Equal(t, status, service.Status())
+		})
+	}
+}
+
+func TestMaintenanceService_SinceIsKeptWhileEnabled(t *testing.T) {
+	service := NewMaintenanceService(false, false, time.Minute)
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return start }
+
+	_, err := service.SetStatus(&models.MaintenanceRequest{Enabled: boolPtr(true)})
+	require.NoError(t, err)
+
+	service.now = func() time.Time { return start.Add(time.Hour) }
+	status, err := service.SetStatus(&models.MaintenanceRequest{Enabled: boolPtr(true), RetryAfterSeconds: intPtr(60)})
+	require.NoError(t, err)
+	require.Equal(t, start, *status.Since)
+}
+
+func TestMaintenanceService_SetReadOnly(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          models.ReadOnlyRequest
+		expectedError    string
+		expectedReadOnly bool
+	}{
+		{name: "enable", request: models.ReadOnlyRequest{Enabled: boolPtr(true)}, expectedReadOnly: true},
+		{name: "disable", request: models.ReadOnlyRequest{Enabled: boolPtr(false)}, expectedReadOnly: false},
+		{name: "enabled is required", request: models.ReadOnlyRequest{}, expectedError: "enabled is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMaintenanceService(false, false, time.Minute)
+
+			status, err := service.SetReadOnly(&tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedReadOnly, status.ReadOnly)
+			require.False(t, status.Enabled)
+		})
+	}
+}
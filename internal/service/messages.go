@@ -0,0 +1,208 @@
+package service
+
+import "github.com/julimonteiro/cupcake-store/internal/i18n"
+
+// Validation messages. The English text here must match
+// internal/i18n/locales/en.json; other languages live next to it.
+var (
+	msgNameRequired           = &i18n.Message{ID: "NameRequired", Other: "name is required"}
+	msgNameTooShort           = &i18n.Message{ID: "NameTooShort", Other: "name must have at least {{.Min}} characters"}
+	msgNameTooLong            = &i18n.Message{ID: "NameTooLong", Other: "name must be at most 100 characters"}
+	msgFlavorRequired         = &i18n.Message{ID: "FlavorRequired", Other: "flavor is required"}
+	msgEmailRequired          = &i18n.Message{ID: "EmailRequired", Other: "email is required"}
+	msgEmailInvalid           = &i18n.Message{ID: "EmailInvalid", Other: "email is invalid"}
+	msgCustomerNameRequired   = &i18n.Message{ID: "CustomerNameRequired", Other: "customer name is required"}
+	msgCustomerNameTooLong    = &i18n.Message{ID: "CustomerNameTooLong", Other: "customer name must be at most 100 characters"}
+	msgCustomerEmailRequired  = &i18n.Message{ID: "CustomerEmailRequired", Other: "customer email is required"}
+	msgCustomerEmailInvalid   = &i18n.Message{ID: "CustomerEmailInvalid", Other: "customer email is invalid"}
+	msgCustomerPhoneInvalid   = &i18n.Message{ID: "CustomerPhoneInvalid", Other: "customer phone must be in international format, such as +5511912345678"}
+	msgPhoneInvalid           = &i18n.Message{ID: "PhoneInvalid", Other: "phone must be in international format, such as +5511912345678"}
+	msgPriceRequired          = &i18n.Message{ID: "PriceRequired", Other: "price is required"}
+	msgPriceNotPositive       = &i18n.Message{ID: "PriceNotPositive", Other: "price must be greater than zero"}
+	msgPriceNegative          = &i18n.Message{ID: "PriceNegative", Other: "price cannot be negative"}
+	msgAmountNotPositive      = &i18n.Message{ID: "AmountNotPositive", Other: "amount must be greater than zero"}
+	msgQuantityRequired       = &i18n.Message{ID: "QuantityRequired", Other: "quantity is required"}
+	msgQuantityNotPositive    = &i18n.Message{ID: "QuantityNotPositive", Other: "quantity must be greater than zero"}
+	msgQuantityNegative       = &i18n.Message{ID: "QuantityNegative", Other: "quantity cannot be negative"}
+	msgItemsRequired          = &i18n.Message{ID: "ItemsRequired", Other: "at least one item is required"}
+	msgLimitOutOfRange        = &i18n.Message{ID: "LimitOutOfRange", Other: "limit must be between 1 and {{.Max}}"}
+	msgCupcakeNotFound        = &i18n.Message{ID: "CupcakeNotFound", Other: "cupcake not found"}
+	msgCupcakeIDNotFound      = &i18n.Message{ID: "CupcakeIDNotFound", Other: "cupcake {{.ID}} not found"}
+	msgCupcakeRepeated        = &i18n.Message{ID: "CupcakeRepeated", Other: "cupcake {{.ID}} is listed more than once"}
+	msgNotAvailable           = &i18n.Message{ID: "NotAvailable", Other: "{{.Name}} is not available"}
+	msgIngredientIDNotFound   = &i18n.Message{ID: "IngredientIDNotFound", Other: "ingredient {{.ID}} not found"}
+	msgIngredientRepeated     = &i18n.Message{ID: "IngredientRepeated", Other: "ingredient {{.ID}} is listed more than once"}
+	msgMinimumOrderNegative   = &i18n.Message{ID: "MinimumOrderNegative", Other: "minimum order cannot be negative"}
+	msgMaxRedemptionsNegative = &i18n.Message{ID: "MaxRedemptionsNegative", Other: "max redemptions cannot be negative"}
+	msgSecretTooShort         = &i18n.Message{ID: "SecretTooShort", Other: "secret must have at least 16 characters"}
+	msgAmountTooLarge         = &i18n.Message{ID: "AmountTooLarge", Other: "amount is too large"}
+)
+
+var (
+	msgPageOutOfRange              = &i18n.Message{ID: "PageOutOfRange", Other: "page must be at least 1"}
+	msgPerPageOutOfRange           = &i18n.Message{ID: "PerPageOutOfRange", Other: "per_page must be between 1 and {{.Max}}"}
+	msgLocationsNotConfigured      = &i18n.Message{ID: "LocationsNotConfigured", Other: "locations are not configured"}
+	msgPercentageAdjustmentInvalid = &i18n.Message{ID: "PercentageAdjustmentInvalid", Other: "percentage adjustment must be non-zero and greater than -100"}
+	msgAbsoluteAdjustmentZero      = &i18n.Message{ID: "AbsoluteAdjustmentZero", Other: "absolute adjustment must be non-zero"}
+	msgAdjustmentTypeInvalid       = &i18n.Message{ID: "AdjustmentTypeInvalid", Other: "adjustment type must be percentage or absolute"}
+	msgAdjustedPriceNotPositive    = &i18n.Message{ID: "AdjustedPriceNotPositive", Other: "price for {{.Name}} would drop to zero or below"}
+	msgSKUInvalid                  = &i18n.Message{ID: "SKUInvalid", Other: "sku must have 3 to 64 letters, digits or dashes"}
+	msgSKUTaken                    = &i18n.Message{ID: "SKUTaken", Other: "sku already exists"}
+	msgSlugInvalid                 = &i18n.Message{ID: "SlugInvalid", Other: "slug must have up to 100 lowercase letters or digits, separated by single dashes"}
+	msgSlugTaken                   = &i18n.Message{ID: "SlugTaken", Other: "slug already exists"}
+	msgDescriptionTooLong          = &i18n.Message{ID: "DescriptionTooLong", Other: "description must be at most 2000 characters"}
+	msgTranslationNameTooShort     = &i18n.Message{ID: "TranslationNameTooShort", Other: "name must be at least 2 characters"}
+	msgLocaleInvalid               = &i18n.Message{ID: "LocaleInvalid", Other: "invalid locale"}
+	msgDefaultLocaleNotTranslated  = &i18n.Message{ID: "DefaultLocaleNotTranslated", Other: "the default locale is edited on the cupcake itself"}
+	msgWindowOutOfRange            = &i18n.Message{ID: "WindowOutOfRange", Other: "window must be between 1 and {{.Max}} days"}
+	msgUnsupportedFacet            = &i18n.Message{ID: "UnsupportedFacet", Other: "unsupported facet: {{.Facet}}"}
+	msgPatchRequired               = &i18n.Message{ID: "PatchRequired", Other: "at least one patch operation is required"}
+	msgPatchOpUnsupported          = &i18n.Message{ID: "PatchOpUnsupported", Other: "unsupported patch operation: {{.Op}}"}
+	msgPatchPathInvalid            = &i18n.Message{ID: "PatchPathInvalid", Other: "cannot patch {{.Path}}"}
+	msgPatchPathNotRemovable       = &i18n.Message{ID: "PatchPathNotRemovable", Other: "{{.Path}} cannot be removed"}
+	msgPatchValueInvalid           = &i18n.Message{ID: "PatchValueInvalid", Other: "invalid value for {{.Path}}"}
+	msgCupcakeNameTooLong          = &i18n.Message{ID: "CupcakeNameTooLong", Other: "name must be at most {{.Max}} characters"}
+	msgPriceTooHigh                = &i18n.Message{ID: "PriceTooHigh", Other: "price must be at most {{.Max}} cents"}
+	msgAdjustedPriceTooHigh        = &i18n.Message{ID: "AdjustedPriceTooHigh", Other: "price for {{.Name}} would exceed {{.Max}} cents"}
+	msgNameMinLengthInvalid        = &i18n.Message{ID: "NameMinLengthInvalid", Other: "name_min_length must be at least 1"}
+	msgNameMaxLengthInvalid        = &i18n.Message{ID: "NameMaxLengthInvalid", Other: "name_max_length must be between name_min_length and {{.Max}}"}
+	msgMaxPriceNegative            = &i18n.Message{ID: "MaxPriceNegative", Other: "max_price_cents cannot be negative"}
+)
+
+var (
+	msgAddonNameTaken           = &i18n.Message{ID: "AddonNameTaken", Other: "add-on name already exists"}
+	msgBundleTooSmall           = &i18n.Message{ID: "BundleTooSmall", Other: "a bundle must contain at least two cupcakes"}
+	msgBundleNameTaken          = &i18n.Message{ID: "BundleNameTaken", Other: "bundle name already exists"}
+	msgBundleIDNotFound         = &i18n.Message{ID: "BundleIDNotFound", Other: "bundle {{.ID}} not found"}
+	msgBundlesNotConfigured     = &i18n.Message{ID: "BundlesNotConfigured", Other: "bundles are not configured"}
+	msgCustomKindInvalid        = &i18n.Message{ID: "CustomKindInvalid", Other: "kind must be base, frosting or topping"}
+	msgBaseRequired             = &i18n.Message{ID: "BaseRequired", Other: "base is required"}
+	msgFrostingRequired         = &i18n.Message{ID: "FrostingRequired", Other: "frosting is required"}
+	msgTooManyToppings          = &i18n.Message{ID: "TooManyToppings", Other: "at most {{.Max}} toppings are allowed"}
+	msgToppingRepeated          = &i18n.Message{ID: "ToppingRepeated", Other: "toppings cannot be repeated"}
+	msgCustomOptionIDNotFound   = &i18n.Message{ID: "CustomOptionIDNotFound", Other: "option {{.ID}} not found"}
+	msgCustomOptionWrongKind    = &i18n.Message{ID: "CustomOptionWrongKind", Other: "{{.Name}} is not a {{.Kind}}"}
+	msgBasePriceNotPositive     = &i18n.Message{ID: "BasePriceNotPositive", Other: "base price must be greater than zero"}
+	msgInsufficientStock        = &i18n.Message{ID: "InsufficientStock", Other: "only {{.Available}} of {{.Name}} available at this location"}
+	msgAddressRequired          = &i18n.Message{ID: "AddressRequired", Other: "address is required"}
+	msgAddressTooLong           = &i18n.Message{ID: "AddressTooLong", Other: "address must be at most 255 characters"}
+	msgHoursTooLong             = &i18n.Message{ID: "HoursTooLong", Other: "hours must be at most 255 characters"}
+	msgSlotWindowRequired       = &i18n.Message{ID: "SlotWindowRequired", Other: "slot window is required"}
+	msgSlotEndsBeforeStart      = &i18n.Message{ID: "SlotEndsBeforeStart", Other: "slot must end after it starts"}
+	msgCapacityNotPositive      = &i18n.Message{ID: "CapacityNotPositive", Other: "capacity must be greater than zero"}
+	msgSlotStarted              = &i18n.Message{ID: "SlotStarted", Other: "pickup slot has already started"}
+	msgPromotionWindowRequired  = &i18n.Message{ID: "PromotionWindowRequired", Other: "promotion window is required"}
+	msgPromotionEndsBeforeStart = &i18n.Message{ID: "PromotionEndsBeforeStart", Other: "promotion must end after it starts"}
+)
+
+var (
+	msgCouponCodeTaken          = &i18n.Message{ID: "CouponCodeTaken", Other: "coupon code already exists"}
+	msgCouponCodeRequired       = &i18n.Message{ID: "CouponCodeRequired", Other: "code is required"}
+	msgCouponNotFound           = &i18n.Message{ID: "CouponNotFound", Other: "coupon not found"}
+	msgCouponInactive           = &i18n.Message{ID: "CouponInactive", Other: "coupon is not active"}
+	msgCouponExpired            = &i18n.Message{ID: "CouponExpired", Other: "coupon has expired"}
+	msgOrderTotalNotPositive    = &i18n.Message{ID: "OrderTotalNotPositive", Other: "order total must be greater than zero"}
+	msgOrderBelowCouponMinimum  = &i18n.Message{ID: "OrderBelowCouponMinimum", Other: "order total is below the coupon minimum"}
+	msgPercentageDiscountRange  = &i18n.Message{ID: "PercentageDiscountRange", Other: "percentage discount must be between 1 and 100"}
+	msgFixedDiscountNotPositive = &i18n.Message{ID: "FixedDiscountNotPositive", Other: "fixed discount must be greater than zero"}
+	msgDiscountTypeInvalid      = &i18n.Message{ID: "DiscountTypeInvalid", Other: "discount type must be percentage or fixed"}
+	msgGiftCardNotFound         = &i18n.Message{ID: "GiftCardNotFound", Other: "gift card not found"}
+	msgGiftCardVoided           = &i18n.Message{ID: "GiftCardVoided", Other: "gift card has been voided"}
+)
+
+var (
+	msgLinesRequired              = &i18n.Message{ID: "LinesRequired", Other: "at least one line is required"}
+	msgUnitCostNegative           = &i18n.Message{ID: "UnitCostNegative", Other: "unit cost cannot be negative"}
+	msgPurchaseOrderStatusInvalid = &i18n.Message{ID: "PurchaseOrderStatusInvalid", Other: "status must be open, received or cancelled"}
+	msgSupplierNameTaken          = &i18n.Message{ID: "SupplierNameTaken", Other: "supplier name already exists"}
+	msgUnitRequired               = &i18n.Message{ID: "UnitRequired", Other: "unit is required"}
+	msgUnitTooLong                = &i18n.Message{ID: "UnitTooLong", Other: "unit must be at most 20 characters"}
+	msgIngredientNameTaken        = &i18n.Message{ID: "IngredientNameTaken", Other: "ingredient name already exists"}
+	msgIngredientsRequired        = &i18n.Message{ID: "IngredientsRequired", Other: "at least one ingredient is required"}
+	msgBelowMinimumOrder          = &i18n.Message{ID: "BelowMinimumOrder", Other: "minimum order is {{.Min}} units"}
+	msgMinOrderQuantityNegative   = &i18n.Message{ID: "MinOrderQuantityNegative", Other: "minimum order quantity cannot be negative"}
+	msgWholesaleEmailTaken        = &i18n.Message{ID: "WholesaleEmailTaken", Other: "a wholesale account with this email already exists"}
+)
+
+var (
+	msgEnabledRequired          = &i18n.Message{ID: "EnabledRequired", Other: "enabled is required"}
+	msgRetryAfterNotPositive    = &i18n.Message{ID: "RetryAfterNotPositive", Other: "retry_after_seconds must be greater than zero"}
+	msgFirstDeliveryInPast      = &i18n.Message{ID: "FirstDeliveryInPast", Other: "first delivery must be in the future"}
+	msgSubscriptionCancelled    = &i18n.Message{ID: "SubscriptionCancelled", Other: "subscription is already cancelled"}
+	msgSubscriptionNotInStatus  = &i18n.Message{ID: "SubscriptionNotInStatus", Other: "subscription is not {{.Status}}"}
+	msgFrequencyInvalid         = &i18n.Message{ID: "FrequencyInvalid", Other: "frequency must be weekly, biweekly or monthly"}
+	msgWebhookURLInvalid        = &i18n.Message{ID: "WebhookURLInvalid", Other: "url must be an absolute http or https URL"}
+	msgEventsRequired           = &i18n.Message{ID: "EventsRequired", Other: "at least one event is required"}
+	msgUnsupportedEvent         = &i18n.Message{ID: "UnsupportedEvent", Other: "unsupported event: {{.Event}}"}
+	msgSettingValueRequired     = &i18n.Message{ID: "SettingValueRequired", Other: "value is required"}
+	msgSettingNotBool           = &i18n.Message{ID: "SettingNotBool", Other: "{{.Key}} must be true or false"}
+	msgSettingNotString         = &i18n.Message{ID: "SettingNotString", Other: "{{.Key}} must be a string"}
+	msgSettingNotNonNegativeInt = &i18n.Message{ID: "SettingNotNonNegativeInt", Other: "{{.Key}} must be a whole number of zero or more"}
+)
+
+var (
+	msgExperimentKeyInvalid         = &i18n.Message{ID: "ExperimentKeyInvalid", Other: "key must have up to 64 lowercase letters or digits, separated by single dashes"}
+	msgExperimentKeyTaken           = &i18n.Message{ID: "ExperimentKeyTaken", Other: "an experiment with this key already exists"}
+	msgExperimentDescriptionTooLong = &i18n.Message{ID: "ExperimentDescriptionTooLong", Other: "description must be at most 255 characters"}
+	msgVariantsTooFew               = &i18n.Message{ID: "VariantsTooFew", Other: "at least two variants are required"}
+	msgVariantNameTooLong           = &i18n.Message{ID: "VariantNameTooLong", Other: "variant name must be at most 50 characters"}
+	msgVariantRepeated              = &i18n.Message{ID: "VariantRepeated", Other: "variant {{.Name}} is listed more than once"}
+	msgWeightNotPositive            = &i18n.Message{ID: "WeightNotPositive", Other: "weight must be greater than zero"}
+	msgPriceAdjustmentOutOfRange    = &i18n.Message{ID: "PriceAdjustmentOutOfRange", Other: "price adjustment must be between {{.Min}} and {{.Max}} percent"}
+	msgVisitorIDInvalid             = &i18n.Message{ID: "VisitorIDInvalid", Other: "X-Visitor-ID must have 1 to {{.Max}} characters"}
+	msgValueNegative                = &i18n.Message{ID: "ValueNegative", Other: "value cannot be negative"}
+)
+
+var (
+	msgPasswordRequired  = &i18n.Message{ID: "PasswordRequired", Other: "password is required"}
+	msgPasswordTooShort  = &i18n.Message{ID: "PasswordTooShort", Other: "password must have at least {{.Min}} characters"}
+	msgPasswordTooLong   = &i18n.Message{ID: "PasswordTooLong", Other: "password must be at most {{.Max}} characters"}
+	msgAccountEmailTaken = &i18n.Message{ID: "AccountEmailTaken", Other: "an account with this email already exists"}
+	msgAdminEmailTaken   = &i18n.Message{ID: "AdminEmailTaken", Other: "an admin with this email already exists"}
+	msgAdminRoleInvalid  = &i18n.Message{ID: "AdminRoleInvalid", Other: "role must be admin or super_admin"}
+)
+
+var (
+	msgQuotaNegative = &i18n.Message{ID: "QuotaNegative", Other: "quota cannot be negative"}
+)
+
+var (
+	msgSubjectRequired     = &i18n.Message{ID: "SubjectRequired", Other: "subject is required"}
+	msgSubjectTooLong      = &i18n.Message{ID: "SubjectTooLong", Other: "subject must be at most 200 characters"}
+	msgMessageRequired     = &i18n.Message{ID: "MessageRequired", Other: "message is required"}
+	msgMessageTooLong      = &i18n.Message{ID: "MessageTooLong", Other: "message must be at most 5000 characters"}
+	msgOrderTypeInvalid    = &i18n.Message{ID: "OrderTypeInvalid", Other: "order type must be pickup_reservation or subscription"}
+	msgTicketStatusInvalid = &i18n.Message{ID: "TicketStatusInvalid", Other: "status must be open, investigating or resolved"}
+	msgAssigneeNotFound    = &i18n.Message{ID: "AssigneeNotFound", Other: "admin {{.ID}} not found"}
+)
+
+var (
+	msgBodyRequired    = &i18n.Message{ID: "BodyRequired", Other: "body is required"}
+	msgTemplateInvalid = &i18n.Message{ID: "TemplateInvalid", Other: "template is invalid: {{.Error}}"}
+)
+
+var (
+	msgReorderThresholdNegative = &i18n.Message{ID: "ReorderThresholdNegative", Other: "reorder threshold cannot be negative"}
+	msgStockAlertStatusInvalid  = &i18n.Message{ID: "StockAlertStatusInvalid", Other: "status must be open, acknowledged or resolved"}
+	msgStockAlertResolved       = &i18n.Message{ID: "StockAlertResolved", Other: "the alert is already resolved"}
+)
+
+var (
+	msgDateRangeInvalid = &i18n.Message{ID: "DateRangeInvalid", Other: "to cannot be before from"}
+	msgDateRangeTooLong = &i18n.Message{ID: "DateRangeTooLong", Other: "the date range must be at most {{.Max}} days"}
+)
+
+var (
+	msgCancellationReasonInvalid = &i18n.Message{ID: "CancellationReasonInvalid", Other: "reason must be customer_request, out_of_stock, store_closed, no_show or other"}
+	msgTicketInvalid             = &i18n.Message{ID: "TicketInvalid", Other: "{{.Ticket}} is not a ticket: must be kitchen or receipt"}
+)
+
+var (
+	msgNotificationEventInvalid = &i18n.Message{ID: "NotificationEventInvalid", Other: "{{.Event}} is not a notification event"}
+)
+
+var (
+	msgDeviceTokenRequired   = &i18n.Message{ID: "DeviceTokenRequired", Other: "token is required"}
+	msgDeviceTokenTooLong    = &i18n.Message{ID: "DeviceTokenTooLong", Other: "token must be at most 512 characters"}
+	msgDevicePlatformInvalid = &i18n.Message{ID: "DevicePlatformInvalid", Other: "platform must be android, ios or web"}
+)
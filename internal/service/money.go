@@ -0,0 +1,23 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/money"
+)
+
+// cents turns the result of money arithmetic back into the int cents kept
+// on the models, reporting an overflow as a validation error.
+func cents(m money.Money, err error) (int, error) {
+	if err == nil {
+		var n int
+		if n, err = m.Int(); err == nil {
+			return n, nil
+		}
+	}
+	if errors.Is(err, money.ErrOverflow) {
+		return 0, i18n.NewError(msgAmountTooLarge, nil)
+	}
+	return 0, err
+}
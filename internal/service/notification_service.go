@@ -0,0 +1,186 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// notificationEvent is an event customers choose how to be told about,
+// and the channels used until they do.
+type notificationEvent struct {
+	description string
+	email       bool
+	sms         bool
+	push        bool
+}
+
+// notificationEvents are the events that honor preferences. Those the
+// customer asked for themselves, such as the verification link or a data
+// export, always go out by email.
+var notificationEvents = map[string]notificationEvent{
+	models.EventTicketStatusChanged: {description: "Status changes of your support tickets", email: true},
+	models.EventPickupReady:         {description: "Your pickup order is ready", email: true, sms: true, push: true},
+	models.EventPickupCancelled:     {description: "Your pickup order was cancelled", email: true, sms: true, push: true},
+}
+
+// NotificationSender delivers notifications on a channel other than
+// email, which goes out as the event itself.
+type NotificationSender interface {
+	Send(event string, notification models.Notification) error
+}
+
+// NotificationService keeps each account's notification preferences and
+// dispatches the events in notificationEvents by them. It is an
+// EventPublisher, put in front of the store's: the email channel is the
+// event reaching webhooks and the broker, for the email integration,
+// and the other channels go to their senders. Customers without an
+// account, or who never changed a preference, get the defaults.
+type NotificationService struct {
+	repo     repository.NotificationPreferenceRepositoryInterface
+	accounts repository.AccountRepositoryInterface
+	events   EventPublisher
+	senders  map[string]NotificationSender
+}
+
+var (
+	_ NotificationServiceInterface = (*NotificationService)(nil)
+	_ EventPublisher               = (*NotificationService)(nil)
+)
+
+func NewNotificationService(repo repository.NotificationPreferenceRepositoryInterface, accounts repository.AccountRepositoryInterface, events EventPublisher) *NotificationService {
+	return &NotificationService{repo: repo, accounts: accounts, events: events, senders: map[string]NotificationSender{}}
+}
+
+// WithSender delivers the notifications of channel through sender. Until
+// a channel has one, customers can choose it but nothing is sent on it.
+func (s *NotificationService) WithSender(channel string, sender NotificationSender) *NotificationService {
+	s.senders[channel] = sender
+	return s
+}
+
+// GetPreferences returns the channels of every notification event for the
+// account.
+func (s *NotificationService) GetPreferences(accountID uint) ([]models.NotificationPreferenceResponse, error) {
+	stored, err := s.repo.FindByAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]string, 0, len(notificationEvents))
+	for event := range notificationEvents {
+		events = append(events, event)
+	}
+	slices.Sort(events)
+
+	preferences := make([]models.NotificationPreferenceResponse, 0, len(events))
+	for _, event := range events {
+		preferences = append(preferences, effectivePreference(event, stored))
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences changes the channels given in req and returns every
+// preference of the account.
+func (s *NotificationService) UpdatePreferences(accountID uint, req *models.UpdateNotificationPreferencesRequest) ([]models.NotificationPreferenceResponse, error) {
+	stored, err := s.repo.FindByAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make([]models.NotificationPreference, 0, len(req.Preferences))
+	for _, update := range req.Preferences {
+		if _, ok := notificationEvents[update.Event]; !ok {
+			return nil, i18n.NewError(msgNotificationEventInvalid, map[string]any{"Event": update.Event})
+		}
+		current := effectivePreference(update.Event, stored)
+		preference := models.NotificationPreference{AccountID: accountID, Event: update.Event, Email: current.Email, SMS: current.SMS, Push: current.Push}
+		if update.Email != nil {
+			preference.Email = *update.Email
+		}
+		if update.SMS != nil {
+			preference.SMS = *update.SMS
+		}
+		if update.Push != nil {
+			preference.Push = *update.Push
+		}
+		changed = append(changed, preference)
+	}
+
+	if err := s.repo.Set(changed); err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(accountID)
+}
+
+// Publish dispatches notification events on the recipient's channels and
+// passes every other event through.
+func (s *NotificationService) Publish(event string, data interface{}) {
+	notification, ok := data.(models.Notification)
+	if _, notifies := notificationEvents[event]; !notifies || !ok {
+		s.events.Publish(event, data)
+		return
+	}
+
+	preference, err := s.recipientPreference(event, notification.NotificationRecipient())
+	if err != nil {
+		log.Printf("Error loading notification preferences for %s, using the defaults: %v", event, err)
+	}
+
+	if preference.Email {
+		s.events.Publish(event, data)
+	}
+	channels := []struct {
+		name    string
+		enabled bool
+	}{{models.ChannelSMS, preference.SMS}, {models.ChannelPush, preference.Push}}
+	for _, channel := range channels {
+		sender := s.senders[channel.name]
+		if !channel.enabled || sender == nil {
+			continue
+		}
+		if err := sender.Send(event, notification); err != nil {
+			log.Printf("Error sending %s notification for %s: %v", channel.name, event, err)
+		}
+	}
+}
+
+// recipientPreference is the preference of the account registered with
+// email, or the event's defaults when there is none. On error the defaults
+// are returned with it.
+func (s *NotificationService) recipientPreference(event, email string) (models.NotificationPreferenceResponse, error) {
+	defaults := effectivePreference(event, nil)
+	account, err := s.accounts.FindByEmail(email)
+	if errors.Is(err, repository.ErrNotFound) {
+		return defaults, nil
+	}
+	if err != nil {
+		return defaults, err
+	}
+	stored, err := s.repo.FindByAccount(account.ID)
+	if err != nil {
+		return defaults, err
+	}
+	return effectivePreference(event, stored), nil
+}
+
+func effectivePreference(event string, stored []models.NotificationPreference) models.NotificationPreferenceResponse {
+	defaults := notificationEvents[event]
+	for _, preference := range stored {
+		if preference.Event == event {
+			return models.NotificationPreferenceResponse{
+				Event:       event,
+				Description: defaults.description,
+				Email:       preference.Email,
+				SMS:         preference.SMS,
+				Push:        preference.Push,
+				UpdatedAt:   &preference.UpdatedAt,
+			}
+		}
+	}
+	return models.NotificationPreferenceResponse{Event: event, Description: defaults.description, Email: defaults.email, SMS: defaults.sms, Push: defaults.push}
+}
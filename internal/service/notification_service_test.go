@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSender struct {
+	sent []models.Notification
+	err  error
+}
+
+func (s *recordingSender) Send(event string, notification models.Notification) error {
+	s.sent = append(s.sent, notification)
+	return s.err
+}
+
+func TestNotificationService_Preferences(t *testing.T) {
+	db := setupTestDB(t)
+	svc := NewNotificationService(repository.NewNotificationPreferenceRepository(db), repository.NewAccountRepository(db), &mocks.EventPublisher{})
+
+	preferences, err := svc.GetPreferences(1)
+	require.NoError(t, err)
+	require.Equal(t, []models.NotificationPreferenceResponse{{
+		Event:       models.EventPickupCancelled,
+		Description: "Your pickup order was cancelled",
+		Email:       true,
+		SMS:         true,
+		Push:        true,
+	}, {
+		Event:       models.EventPickupReady,
+		Description: "Your pickup order is ready",
+		Email:       true,
+		SMS:         true,
+		Push:        true,
+	}, {
+		Event:       models.EventTicketStatusChanged,
+		Description: "Status changes of your support tickets",
+		Email:       true,
+	}}, preferences)
+
+	_, err = svc.UpdatePreferences(1, &models.UpdateNotificationPreferencesRequest{Preferences: []models.NotificationPreferenceUpdate{{Event: models.EventCupcakeCreated}}})
+	require.EqualError(t, err, "cupcake.created is not a notification event")
+
+	// Channels left out keep their setting.
+	on := true
+	preferences, err = svc.UpdatePreferences(1, &models.UpdateNotificationPreferencesRequest{Preferences: []models.NotificationPreferenceUpdate{{Event: models.EventTicketStatusChanged, SMS: &on}}})
+	require.NoError(t, err)
+	require.True(t, preferences[2].Email)
+	require.True(t, preferences[2].SMS)
+	require.False(t, preferences[2].Push)
+	require.NotNil(t, preferences[2].UpdatedAt)
+
+	off := false
+	preferences, err = svc.UpdatePreferences(1, &models.UpdateNotificationPreferencesRequest{Preferences: []models.NotificationPreferenceUpdate{{Event: models.EventTicketStatusChanged, Email: &off}}})
+	require.NoError(t, err)
+	require.False(t, preferences[2].Email)
+	require.True(t, preferences[2].SMS)
+
+	// Other accounts are untouched.
+	preferences, err = svc.GetPreferences(2)
+	require.NoError(t, err)
+	require.True(t, preferences[2].Email)
+	require.Nil(t, preferences[2].UpdatedAt)
+}
+
+func TestNotificationService_Publish(t *testing.T) {
+	db := setupTestDB(t)
+	account := &models.Account{Name: "Ana Lima", Email: "ana@example.com", PasswordHash: "x"}
+	require.NoError(t, db.Create(account).Error)
+
+	var published []string
+	publisher := &mocks.EventPublisher{PublishFunc: func(event string, data interface{}) {
+		published = append(published, event)
+	}}
+	sms := &recordingSender{}
+	svc := NewNotificationService(repository.NewNotificationPreferenceRepository(db), repository.NewAccountRepository(db), publisher).
+		WithSender(models.ChannelSMS, sms)
+
+	// Other events pass through.
+	svc.Publish(models.EventCupcakeCreated, models.Cupcake{})
+	require.Equal(t, []string{models.EventCupcakeCreated}, published)
+
+	// Customers without an account get the defaults: email only.
+	svc.Publish(models.EventTicketStatusChanged, models.TicketStatusEvent{CustomerEmail: "bruno@example.com"})
+	require.Len(t, published, 2)
+	require.Empty(t, sms.sent)
+
+	off, on := false, true
+	_, err := svc.UpdatePreferences(account.ID, &models.UpdateNotificationPreferencesRequest{Preferences: []models.NotificationPreferenceUpdate{{Event: models.EventTicketStatusChanged, Email: &off, SMS: &on, Push: &on}}})
+	require.NoError(t, err)
+
+	// Push has no sender here, so only the SMS goes out; a failed send is
+	// only logged.
+	sms.err = errors.New("provider down")
+	event := models.TicketStatusEvent{TicketID: 7, CustomerEmail: "ANA@example.com", Status: models.TicketResolved}
+	svc.Publish(models.EventTicketStatusChanged, event)
+	require.Len(t, published, 2)
+	require.Equal(t, []models.Notification{event}, sms.sent)
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// OAuthStateTTL is how long a customer has to log in at the provider.
+const OAuthStateTTL = 10 * time.Minute
+
+var (
+	ErrOAuthProviderNotFound = errors.New("login provider not found")
+	ErrOAuthStateInvalid     = errors.New("login state is invalid or has expired")
+	ErrOAuthLoginFailed      = errors.New("login with the provider failed")
+	ErrOAuthEmailMissing     = errors.New("the provider did not share a verified email")
+
+	// ErrOAuthAccountExists is returned by a social login whose email
+	// already has an account. The customer links the provider to that
+	// account after logging in to it, rather than the two being merged on
+	// the provider's word.
+	ErrOAuthAccountExists = errors.New("an account with this email already exists; log in and link the provider to it")
+
+	ErrOAuthIdentityTaken    = errors.New("this login is linked to another account")
+	ErrOAuthAlreadyLinked    = errors.New("the account already has a login with this provider")
+	ErrOAuthIdentityNotFound = errors.New("the account has no login with this provider")
+	ErrOAuthLastLoginMethod  = errors.New("cannot unlink the account's only way to log in")
+)
+
+// OAuthProvider is an OAuth 2.0 provider customers can log in with, such
+// as Google.
+type OAuthProvider interface {
+	// Name identifies the provider in URLs and linked identities.
+	Name() string
+	// AuthCodeURL is where to send the customer to log in at the provider.
+	AuthCodeURL(state, redirectURI string) string
+	// Exchange trades the code the provider redirects back with for the
+	// profile of the customer who logged in.
+	Exchange(ctx context.Context, code, redirectURI string) (*models.OAuthProfile, error)
+}
+
+// OAuthService logs customers in through OAuth providers. A first social
+// login creates an account with the provider's verified email; an account
+// made with a password links a provider explicitly, from a link flow
+// started with its access token. The tokens it issues are the account
+// service's, so both logins work everywhere.
+//
+// The state sent through the provider is signed, expires and carries a
+// nonce the handler also keeps in a cookie, so a callback only completes
+// in the browser that started the login.
+type OAuthService struct {
+	accounts  *AccountService
+	providers map[string]OAuthProvider
+	names     []string
+}
+
+var _ OAuthServiceInterface = (*OAuthService)(nil)
+
+func NewOAuthService(accounts *AccountService, providers ...OAuthProvider) *OAuthService {
+	s := &OAuthService{accounts: accounts, providers: make(map[string]OAuthProvider)}
+	for _, p := range providers {
+		s.providers[p.Name()] = p
+		s.names = append(s.names, p.Name())
+	}
+	return s
+}
+
+// Providers lists the names of the configured providers.
+func (s *OAuthService) Providers() []string {
+	return append([]string{}, s.names...)
+}
+
+// Begin starts a social login.
+func (s *OAuthService) Begin(provider, redirectURI string) (*models.OAuthAuthorization, error) {
+	return s.begin(provider, 0, redirectURI)
+}
+
+// BeginLink starts linking a provider to the account of accessToken.
+func (s *OAuthService) BeginLink(accessToken, provider, redirectURI string) (*models.OAuthAuthorization, error) {
+	account, err := s.accounts.Authenticate(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.begin(provider, account.ID, redirectURI)
+}
+
+func (s *OAuthService) begin(provider string, accountID uint, redirectURI string) (*models.OAuthAuthorization, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, ErrOAuthProviderNotFound
+	}
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	nonce := hex.EncodeToString(raw)
+	expires := s.accounts.now().Add(OAuthStateTTL).Unix()
+
+	// <nonce>.<account id, 0 to log in>.<expires>.<signature>
+	state := fmt.Sprintf("%s.%d.%d.%s", nonce, accountID, expires, s.accounts.signer.sign("oauth-state", provider, nonce, accountID, expires))
+	return &models.OAuthAuthorization{
+		AuthorizationURL: p.AuthCodeURL(state, redirectURI),
+		Nonce:            nonce,
+	}, nil
+}
+
+// Complete finishes a login or link flow when the provider redirects back
+// with code and state; nonce is the one kept when it began.
+func (s *OAuthService) Complete(ctx context.Context, provider, code, state, nonce, redirectURI string) (*models.AccessToken, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return nil, ErrOAuthProviderNotFound
+	}
+	accountID, err := s.checkState(provider, state, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := p.Exchange(ctx, code, redirectURI)
+	if err != nil {
+		log.Printf("Error completing %s login: %v", provider, err)
+		return nil, ErrOAuthLoginFailed
+	}
+
+	account, err := s.accounts.repo.FindByIdentity(provider, profile.Subject)
+	switch {
+	case err == nil:
+		if accountID != 0 && account.ID != accountID {
+			return nil, ErrOAuthIdentityTaken
+		}
+		return s.accounts.accessToken(account)
+	case !errors.Is(err, repository.ErrNotFound):
+		return nil, err
+	case accountID != 0:
+		return s.link(accountID, provider, profile)
+	default:
+		return s.signUp(provider, profile)
+	}
+}
+
+// checkState returns the account a link flow was started for, or 0 for a
+// login.
+func (s *OAuthService) checkState(provider, state, nonce string) (uint, error) {
+	fields := strings.Split(state, ".")
+	if len(fields) != 4 || nonce == "" || !hmac.Equal([]byte(fields[0]), []byte(nonce)) {
+		return 0, ErrOAuthStateInvalid
+	}
+	accountID, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil {
+		return 0, ErrOAuthStateInvalid
+	}
+	expires, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return 0, ErrOAuthStateInvalid
+	}
+	if !s.accounts.signer.valid(fields[3], "oauth-state", provider, nonce, uint(accountID), expires) {
+		return 0, ErrOAuthStateInvalid
+	}
+	if !s.accounts.now().Before(time.Unix(expires, 0)) {
+		return 0, ErrOAuthStateInvalid
+	}
+	return uint(accountID), nil
+}
+
+func (s *OAuthService) link(accountID uint, provider string, profile *models.OAuthProfile) (*models.AccessToken, error) {
+	account, err := s.accounts.repo.FindByID(accountID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrOAuthStateInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &models.AccountIdentity{AccountID: account.ID, Provider: provider, Subject: profile.Subject}
+	if err := s.accounts.repo.CreateIdentity(identity); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrOAuthAlreadyLinked
+		}
+		return nil, err
+	}
+	return s.accounts.accessToken(account)
+}
+
+// signUp creates the account of a first social login. The provider has
+// verified the email, so the account starts verified.
+func (s *OAuthService) signUp(provider string, profile *models.OAuthProfile) (*models.AccessToken, error) {
+	email := strings.ToLower(strings.TrimSpace(profile.Email))
+	if email == "" {
+		return nil, ErrOAuthEmailMissing
+	}
+	_, err := s.accounts.repo.FindByEmail(email)
+	if err == nil {
+		return nil, ErrOAuthAccountExists
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	name := []rune(strings.TrimSpace(profile.Name))
+	if len(name) == 0 {
+		local, _, _ := strings.Cut(email, "@")
+		name = []rune(local)
+	}
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	now := s.accounts.now()
+	account := &models.Account{Name: string(name), Email: email, EmailVerifiedAt: &now}
+	identity := &models.AccountIdentity{Provider: provider, Subject: profile.Subject}
+	if err := s.accounts.repo.CreateWithIdentity(account, identity); err != nil {
+		if errors.Is(err, repository.ErrDuplicate) {
+			return nil, ErrOAuthAccountExists
+		}
+		return nil, err
+	}
+	return s.accounts.accessToken(account)
+}
+
+// Identities lists the providers linked to the account of accessToken.
+func (s *OAuthService) Identities(accessToken string) ([]models.AccountIdentity, error) {
+	account, err := s.accounts.Authenticate(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.accounts.repo.FindIdentities(account.ID)
+}
+
+// Unlink removes a provider from the account of accessToken, unless the
+// account has no password and no other provider to log in with.
+func (s *OAuthService) Unlink(accessToken, provider string) error {
+	account, err := s.accounts.Authenticate(accessToken)
+	if err != nil {
+		return err
+	}
+	if account.PasswordHash == "" {
+		identities, err := s.accounts.repo.FindIdentities(account.ID)
+		if err != nil {
+			return err
+		}
+		if len(identities) == 1 && identities[0].Provider == provider {
+			return ErrOAuthLastLoginMethod
+		}
+	}
+
+	err = s.accounts.repo.DeleteIdentity(account.ID, provider)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrOAuthIdentityNotFound
+	}
+	return err
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// stubOAuthProvider hands out the profile registered for each code.
+type stubOAuthProvider struct {
+	name     string
+	profiles map[string]*models.OAuthProfile
+}
+
+func (p *stubOAuthProvider) Name() string {
+	return p.name
+}
+
+func (p *stubOAuthProvider) AuthCodeURL(state, redirectURI string) string {
+	return "https://" + p.name + ".example/auth?" + url.Values{"state": {state}, "redirect_uri": {redirectURI}}.Encode()
+}
+
+func (p *stubOAuthProvider) Exchange(_ context.Context, code, _ string) (*models.OAuthProfile, error) {
+	profile, ok := p.profiles[code]
+	if !ok {
+		return nil, errors.New("bad code")
+	}
+	return profile, nil
+}
+
+func newTestOAuthService(t *testing.T) (*OAuthService, *AccountService) {
+	t.Helper()
+	accounts := NewAccountService(repository.NewAccountRepository(setupTestDB(t)), fastPasswordParams, "test-secret", time.Hour)
+	google := &stubOAuthProvider{name: "google", profiles: map[string]*models.OAuthProfile{
+		"ana":   {Subject: "g-1", Email: "Ana@Example.com", Name: "Ana Lima"},
+		"bruno": {Subject: "g-2", Email: "bruno@example.com"},
+		"carla": {Subject: "g-3", Email: "carla@example.com"},
+		"anon":  {Subject: "g-4"},
+	}}
+	github := &stubOAuthProvider{name: "github", profiles: map[string]*models.OAuthProfile{
+		"ana": {Subject: "h-1", Email: "ana@example.com", Name: "analima"},
+	}}
+	return NewOAuthService(accounts, google, github), accounts
+}
+
+// completeOAuth runs a flow the way the browser would: to the provider
+// with the state and back with the code.
+func completeOAuth(t *testing.T, svc *OAuthService, authorization *models.OAuthAuthorization, provider, code string) (*models.AccessToken, error) {
+	t.Helper()
+	link, err := url.Parse(authorization.AuthorizationURL)
+	require.NoError(t, err)
+	query := link.Query()
+	return svc.Complete(context.Background(), provider, code, query.Get("state"), authorization.Nonce, query.Get("redirect_uri"))
+}
+
+func TestOAuthService_Login(t *testing.T) {
+	svc, accounts := newTestOAuthService(t)
+	require.Equal(t, []string{"google", "github"}, svc.Providers())
+
+	_, err := svc.Begin("facebook", "https://loja.example/callback")
+	require.ErrorIs(t, err, ErrOAuthProviderNotFound)
+
+	login := func(provider, code string) (*models.AccessToken, error) {
+		authorization, err := svc.Begin(provider, "https://loja.example/callback")
+		require.NoError(t, err)
+		return completeOAuth(t, svc, authorization, provider, code)
+	}
+
+	// A first login creates a verified account.
+	token, err := login("google", "ana")
+	require.NoError(t, err)
+	require.Equal(t, "ana@example.com", token.Account.Email)
+	require.Equal(t, "Ana Lima", token.Account.Name)
+	require.NotNil(t, token.Account.EmailVerifiedAt)
+	me, err := accounts.Authenticate(token.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, token.Account.ID, me.ID)
+
+	again, err := login("google", "ana")
+	require.NoError(t, err)
+	require.Equal(t, token.Account.ID, again.Account.ID)
+
+	bruno, err := login("google", "bruno")
+	require.NoError(t, err)
+	require.Equal(t, "bruno", bruno.Account.Name)
+
+	// The account has no password to log in with.
+	_, err = accounts.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	// Another provider with the same email is not merged in on its own.
+	_, err = login("github", "ana")
+	require.ErrorIs(t, err, ErrOAuthAccountExists)
+
+	_, err = login("google", "anon")
+	require.ErrorIs(t, err, ErrOAuthEmailMissing)
+	_, err = login("google", "wrong")
+	require.ErrorIs(t, err, ErrOAuthLoginFailed)
+}
+
+func TestOAuthService_State(t *testing.T) {
+	svc, _ := newTestOAuthService(t)
+	authorization, err := svc.Begin("google", "https://loja.example/callback")
+	require.NoError(t, err)
+	link, err := url.Parse(authorization.AuthorizationURL)
+	require.NoError(t, err)
+	state := link.Query().Get("state")
+	complete := func(provider, state, nonce string) error {
+		_, err := svc.Complete(context.Background(), provider, "carla", state, nonce, "https://loja.example/callback")
+		return err
+	}
+
+	// A callback in another browser, without the nonce, fails.
+	require.ErrorIs(t, complete("google", state, ""), ErrOAuthStateInvalid)
+	require.ErrorIs(t, complete("google", state, "0123"), ErrOAuthStateInvalid)
+	// The state is bound to the provider and cannot be edited.
+	require.ErrorIs(t, complete("github", state, authorization.Nonce), ErrOAuthStateInvalid)
+	require.ErrorIs(t, complete("google", authorization.Nonce+".7"+state[len(authorization.Nonce)+2:], authorization.Nonce), ErrOAuthStateInvalid)
+
+	svc.accounts.now = func() time.Time { return time.Now().Add(OAuthStateTTL + time.Minute) }
+	require.ErrorIs(t, complete("google", state, authorization.Nonce), ErrOAuthStateInvalid)
+	svc.accounts.now = time.Now
+	require.NoError(t, complete("google", state, authorization.Nonce))
+}
+
+func TestOAuthService_Link(t *testing.T) {
+	svc, accounts := newTestOAuthService(t)
+	account, err := accounts.Register(&models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	password, err := accounts.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	link := func(provider, code string) (*models.AccessToken, error) {
+		authorization, err := svc.BeginLink(password.AccessToken, provider, "https://loja.example/callback")
+		require.NoError(t, err)
+		return completeOAuth(t, svc, authorization, provider, code)
+	}
+
+	_, err = svc.BeginLink("not-a-token", "google", "https://loja.example/callback")
+	require.ErrorIs(t, err, ErrAccessTokenInvalid)
+
+	token, err := link("github", "ana")
+	require.NoError(t, err)
+	require.Equal(t, account.ID, token.Account.ID)
+	token, err = link("google", "ana")
+	require.NoError(t, err)
+	require.Equal(t, account.ID, token.Account.ID)
+
+	// Both providers now log in to the account.
+	authorization, err := svc.Begin("github", "https://loja.example/callback")
+	require.NoError(t, err)
+	token, err = completeOAuth(t, svc, authorization, "github", "ana")
+	require.NoError(t, err)
+	require.Equal(t, account.ID, token.Account.ID)
+
+	_, err = link("google", "bruno")
+	require.ErrorIs(t, err, ErrOAuthAlreadyLinked)
+
+	identities, err := svc.Identities(password.AccessToken)
+	require.NoError(t, err)
+	require.Len(t, identities, 2)
+	require.Equal(t, "github", identities[0].Provider)
+
+	require.NoError(t, svc.Unlink(password.AccessToken, "github"))
+	require.ErrorIs(t, svc.Unlink(password.AccessToken, "github"), ErrOAuthIdentityNotFound)
+	// The account still has its password after unlinking the last provider.
+	require.NoError(t, svc.Unlink(password.AccessToken, "google"))
+}
+
+func TestOAuthService_LinkTakenAndLastLogin(t *testing.T) {
+	svc, accounts := newTestOAuthService(t)
+	authorization, err := svc.Begin("google", "https://loja.example/callback")
+	require.NoError(t, err)
+	social, err := completeOAuth(t, svc, authorization, "google", "bruno")
+	require.NoError(t, err)
+
+	// Without a password, the only provider cannot be unlinked.
+	require.ErrorIs(t, svc.Unlink(social.AccessToken, "google"), ErrOAuthLastLoginMethod)
+
+	_, err = accounts.Register(&models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	password, err := accounts.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	authorization, err = svc.BeginLink(password.AccessToken, "google", "https://loja.example/callback")
+	require.NoError(t, err)
+	_, err = completeOAuth(t, svc, authorization, "google", "bruno")
+	require.ErrorIs(t, err, ErrOAuthIdentityTaken)
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var (
+	ErrSlotNotFound         = errors.New("pickup slot not found")
+	ErrSlotFull             = errors.New("pickup slot is full")
+	ErrReservationNotFound  = errors.New("pickup reservation not found")
+	ErrReservationStarted   = errors.New("pickup order is already being made")
+	ErrReservationReady     = errors.New("pickup order is already ready")
+	ErrReservationCancelled = errors.New("pickup reservation is cancelled")
+)
+
+// phonePattern is an E.164 number: a plus sign and up to 15 digits.
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+type PickupService struct {
+	repo      repository.PickupRepositoryInterface
+	locations LocationServiceInterface
+	events    EventPublisher
+	printing  PrintServiceInterface
+	now       func() time.Time
+}
+
+var _ PickupServiceInterface = (*PickupService)(nil)
+
+func NewPickupService(repo repository.PickupRepositoryInterface, locations LocationServiceInterface, events EventPublisher) *PickupService {
+	return &PickupService{repo: repo, locations: locations, events: events, now: time.Now}
+}
+
+// WithPrinting prints the kitchen ticket and the customer receipt of every
+// new reservation.
+func (s *PickupService) WithPrinting(printing PrintServiceInterface) *PickupService {
+	s.printing = printing
+	return s
+}
+
+func (s *PickupService) CreateSlot(locationID uint, req *models.CreatePickupSlotRequest) (*models.PickupSlot, error) {
+	if req.StartsAt.IsZero() || req.EndsAt.IsZero() {
+		return nil, i18n.NewError(msgSlotWindowRequired, nil)
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, i18n.NewError(msgSlotEndsBeforeStart, nil)
+	}
+	if req.Capacity <= 0 {
+		return nil, i18n.NewError(msgCapacityNotPositive, nil)
+	}
+
+	if _, err := s.locations.GetLocation(locationID); err != nil {
+		return nil, err
+	}
+
+	slot := &models.PickupSlot{
+		LocationID: locationID,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		Capacity:   req.Capacity,
+	}
+	if err := s.repo.CreateSlot(slot); err != nil {
+		return nil, err
+	}
+
+	return slot, nil
+}
+
+// GetSlots lists the location's slots starting on the given day.
+func (s *PickupService) GetSlots(locationID uint, day time.Time) ([]models.PickupSlot, error) {
+	if _, err := s.locations.GetLocation(locationID); err != nil {
+		return nil, err
+	}
+
+	from, to := dayBounds(day)
+	return s.repo.FindSlots(locationID, from, to)
+}
+
+// Reserve books the customer into a slot once the basket is confirmed to be
+// collectable at the location. Bundles are stored as the cupcakes they
+// contain. A full slot fails with ErrSlotFull. Tickets that cannot be
+// queued for printing are logged and do not fail the reservation.
+func (s *PickupService) Reserve(locationID, slotID uint, req *models.ReservePickupRequest) (*models.PickupReservation, error) {
+	name := strings.TrimSpace(req.CustomerName)
+	if name == "" {
+		return nil, i18n.NewError(msgCustomerNameRequired, nil)
+	}
+	if len(name) > 100 {
+		return nil, i18n.NewError(msgCustomerNameTooLong, nil)
+	}
+	if strings.TrimSpace(req.CustomerEmail) == "" {
+		return nil, i18n.NewError(msgCustomerEmailRequired, nil)
+	}
+	if _, err := mail.ParseAddress(req.CustomerEmail); err != nil {
+		return nil, i18n.NewError(msgCustomerEmailInvalid, nil)
+	}
+	phone := strings.TrimSpace(req.CustomerPhone)
+	if phone != "" && !phonePattern.MatchString(phone) {
+		return nil, i18n.NewError(msgCustomerPhoneInvalid, nil)
+	}
+
+	slot, err := s.repo.FindSlot(slotID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSlotNotFound
+		}
+		return nil, err
+	}
+	if slot.LocationID != locationID {
+		return nil, ErrSlotNotFound
+	}
+	if !slot.StartsAt.After(s.now()) {
+		return nil, i18n.NewError(msgSlotStarted, nil)
+	}
+
+	basket, err := s.locations.CheckPickup(locationID, &models.PickupCheckRequest{Items: req.Items, Bundles: req.Bundles})
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := &models.PickupReservation{
+		SlotID:        slot.ID,
+		CustomerName:  name,
+		CustomerEmail: strings.TrimSpace(req.CustomerEmail),
+		CustomerPhone: phone,
+		Items:         make([]models.PickupReservationItem, len(basket)),
+	}
+	for i, item := range basket {
+		reservation.Items[i] = models.PickupReservationItem{CupcakeID: item.CupcakeID, Quantity: item.Quantity}
+	}
+
+	if err := s.repo.Reserve(reservation); err != nil {
+		if errors.Is(err, repository.ErrSlotFull) {
+			return nil, ErrSlotFull
+		}
+		return nil, err
+	}
+
+	if s.printing != nil {
+		if _, err := s.printing.Print(reservation.ID, nil); err != nil {
+			log.Printf("Error queueing the tickets of pickup reservation %d: %v", reservation.ID, err)
+		}
+	}
+	return reservation, nil
+}
+
+// GetSchedule is the admin view of a location's pickups on the given day:
+// every slot with the reservations booked into it.
+func (s *PickupService) GetSchedule(locationID uint, day time.Time) ([]models.PickupSchedule, error) {
+	slots, err := s.GetSlots(locationID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(slots))
+	for i, slot := range slots {
+		ids[i] = slot.ID
+	}
+	reservations, err := s.repo.FindReservations(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	bySlot := make(map[uint][]models.PickupReservation, len(slots))
+	for _, reservation := range reservations {
+		bySlot[reservation.SlotID] = append(bySlot[reservation.SlotID], reservation)
+	}
+
+	schedule := make([]models.PickupSchedule, len(slots))
+	for i, slot := range slots {
+		schedule[i] = models.PickupSchedule{PickupSlot: slot, Reservations: bySlot[slot.ID]}
+		if schedule[i].Reservations == nil {
+			schedule[i].Reservations = []models.PickupReservation{}
+		}
+	}
+
+	return schedule, nil
+}
+
+// MarkReady records that the reservation's order is ready and tells the
+// customer, by SMS too when they left a phone number and kept the channel
+// on. Marking it again changes nothing and sends nothing.
+func (s *PickupService) MarkReady(id uint) (*models.PickupReservation, error) {
+	reservation, err := s.repo.FindReservation(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, err
+	}
+	if reservation.CancelledAt != nil {
+		return nil, ErrReservationCancelled
+	}
+	if reservation.ReadyAt != nil {
+		return reservation, nil
+	}
+
+	slot, err := s.repo.FindSlot(reservation.SlotID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := s.locations.GetLocation(slot.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if err := s.repo.MarkReady(id, now); err != nil {
+		if errors.Is(err, repository.ErrReservationClosed) {
+			return nil, ErrReservationCancelled
+		}
+		return nil, err
+	}
+	reservation.ReadyAt = &now
+
+	s.events.Publish(models.EventPickupReady, models.PickupReadyEvent{
+		ReservationID: reservation.ID,
+		CustomerName:  reservation.CustomerName,
+		CustomerEmail: reservation.CustomerEmail,
+		CustomerPhone: reservation.CustomerPhone,
+		LocationID:    location.ID,
+		LocationName:  location.Name,
+		SlotStartsAt:  slot.StartsAt,
+		SlotEndsAt:    slot.EndsAt,
+	})
+	return reservation, nil
+}
+
+// Start records that the kitchen started making the reservation's order.
+// Starting it again changes nothing.
+func (s *PickupService) Start(id uint) (*models.PickupReservation, error) {
+	reservation, err := s.repo.FindReservation(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, err
+	}
+	switch {
+	case reservation.CancelledAt != nil:
+		return nil, ErrReservationCancelled
+	case reservation.ReadyAt != nil:
+		return nil, ErrReservationReady
+	case reservation.StartedAt != nil:
+		return reservation, nil
+	}
+
+	now := s.now()
+	if err := s.repo.MarkStarted(id, now); err != nil {
+		if errors.Is(err, repository.ErrReservationClosed) {
+			return nil, ErrReservationReady
+		}
+		return nil, err
+	}
+	reservation.StartedAt = &now
+	return reservation, nil
+}
+
+// Cancel cancels a reservation whose order the kitchen has not started,
+// freeing its place in the slot, and tells the customer. Reservations are not paid
+// and do not take stock, so there is nothing to refund or put back.
+// Cancelling it again changes nothing and sends nothing.
+func (s *PickupService) Cancel(id uint, req *models.CancelPickupRequest) (*models.PickupReservation, error) {
+	switch req.Reason {
+	case models.CancellationCustomerRequest, models.CancellationOutOfStock, models.CancellationStoreClosed, models.CancellationNoShow, models.CancellationOther:
+	default:
+		return nil, i18n.NewError(msgCancellationReasonInvalid, nil)
+	}
+
+	reservation, err := s.repo.FindReservation(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, err
+	}
+	if reservation.CancelledAt != nil {
+		return reservation, nil
+	}
+	if reservation.ReadyAt != nil {
+		return nil, ErrReservationReady
+	}
+	if reservation.StartedAt != nil {
+		return nil, ErrReservationStarted
+	}
+
+	slot, err := s.repo.FindSlot(reservation.SlotID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := s.locations.GetLocation(slot.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if err := s.repo.Cancel(id, req.Reason, now); err != nil {
+		if errors.Is(err, repository.ErrReservationClosed) {
+			return nil, ErrReservationStarted
+		}
+		return nil, err
+	}
+	reservation.CancelledAt = &now
+	reservation.CancellationReason = req.Reason
+
+	s.events.Publish(models.EventPickupCancelled, models.PickupCancelledEvent{
+		ReservationID: reservation.ID,
+		CustomerName:  reservation.CustomerName,
+		CustomerEmail: reservation.CustomerEmail,
+		CustomerPhone: reservation.CustomerPhone,
+		LocationID:    location.ID,
+		LocationName:  location.Name,
+		SlotStartsAt:  slot.StartsAt,
+		SlotEndsAt:    slot.EndsAt,
+		Reason:        req.Reason,
+	})
+	return reservation, nil
+}
+
+func dayBounds(day time.Time) (time.Time, time.Time) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	return from, from.AddDate(0, 0, 1)
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+var pickupDay = time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+// newTestPickupService returns a service whose clock reads 08:00 on
+// pickupDay, with one location stocking three units of cupcake 1.
+func newTestPickupService(t *testing.T) *PickupService {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	locations := NewLocationService(repository.NewLocationRepository(db), cupcakeRepo, repository.NewBundleRepository(db))
+	cupcake := factory.Cupcake(factory.WithName("Vanilla"))
+	require.NoError(t, cupcakeRepo.Create(&cupcake))
+	_, err := locations.CreateLocation(&models.CreateLocationRequest{Name: "Centro", Address: "Rua Augusta, 100"})
+	require.NoError(t, err)
+	_, err = locations.SetStock(1, 1, &models.SetStockRequest{Quantity: intPtr(3)})
+	require.NoError(t, err)
+
+	svc := NewPickupService(repository.NewPickupRepository(db), locations, &mocks.EventPublisher{})
+	svc.now = func() time.Time { return pickupDay.Add(8 * time.Hour) }
+	return svc
+}
+
+func TestCreatePickupSlot(t *testing.T) {
+	tests := []struct {
+		name          string
+		locationID    uint
+		request       *models.CreatePickupSlotRequest
+		expectedError string
+	}{
+		{
+			name:       "success",
+			locationID: 1,
+			request:    &models.CreatePickupSlotRequest{StartsAt: pickupDay.Add(10 * time.Hour), EndsAt: pickupDay.Add(11 * time.Hour), Capacity: 5},
+		},
+		{
+			name:          "missing window",
+			locationID:    1,
+			request:       &models.CreatePickupSlotRequest{Capacity: 5},
+			expectedError: "slot window is required",
+		},
+		{
+			name:          "ends before it starts",
+			locationID:    1,
+			request:       &models.CreatePickupSlotRequest{StartsAt: pickupDay.Add(11 * time.Hour), EndsAt: pickupDay.Add(10 * time.Hour), Capacity: 5},
+			expectedError: "slot must end after it starts",
+		},
+		{
+			name:          "no capacity",
+			locationID:    1,
+			request:       &models.CreatePickupSlotRequest{StartsAt: pickupDay.Add(10 * time.Hour), EndsAt: pickupDay.Add(11 * time.Hour)},
+			expectedError: "capacity must be greater than zero",
+		},
+		{
+			name:          "unknown location",
+			locationID:    999,
+			request:       &models.CreatePickupSlotRequest{StartsAt: pickupDay.Add(10 * time.Hour), EndsAt: pickupDay.Add(11 * time.Hour), Capacity: 5},
+			expectedError: ErrLocationNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestPickupService(t)
+
+			slot, err := svc.CreateSlot(tt.locationID, tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, slot.ID)
+			require.Zero(t, slot.Booked)
+		})
+	}
+}
+
+func TestReservePickup(t *testing.T) {
+	valid := models.ReservePickupRequest{
+		CustomerName:  "Ana",
+		CustomerEmail: "ana@example.com",
+		Items:         []models.PickupItem{{CupcakeID: 1, Quantity: 2}},
+	}
+
+	tests := []struct {
+		name          string
+		locationID    uint
+		slotID        uint
+		modify        func(req *models.ReservePickupRequest)
+		expectedError string
+	}{
+		{name: "success", locationID: 1, slotID: 1},
+		{name: "slot full", locationID: 1, slotID: 2, expectedError: ErrSlotFull.Error()},
+		{name: "slot already started", locationID: 1, slotID: 3, expectedError: "pickup slot has already started"},
+		{name: "unknown slot", locationID: 1, slotID: 999, expectedError: ErrSlotNotFound.Error()},
+		{name: "slot at another location", locationID: 2, slotID: 1, expectedError: ErrSlotNotFound.Error()},
+		{
+			name:          "not enough stock",
+			locationID:    1,
+			slotID:        1,
+			modify:        func(req *models.ReservePickupRequest) { req.Items = []models.PickupItem{{CupcakeID: 1, Quantity: 4}} },
+			expectedError: "only 3 of Vanilla available at this location",
+		},
+		{
+			name:          "missing name",
+			locationID:    1,
+			slotID:        1,
+			modify:        func(req *models.ReservePickupRequest) { req.CustomerName = "  " },
+			expectedError: "customer name is required",
+		},
+		{
+			name:          "invalid email",
+			locationID:    1,
+			slotID:        1,
+			modify:        func(req *models.ReservePickupRequest) { req.CustomerEmail = "ana" },
+			expectedError: "customer email is invalid",
+		},
+		{
+			name:          "invalid phone",
+			locationID:    1,
+			slotID:        1,
+			modify:        func(req *models.ReservePickupRequest) { req.CustomerPhone = "11 91234-5678" },
+			expectedError: "customer phone must be in international format, such as +5511912345678",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestPickupService(t)
+			for _, req := range []models.CreatePickupSlotRequest{
+				{StartsAt: pickupDay.Add(10 * time.Hour), EndsAt: pickupDay.Add(11 * time.Hour), Capacity: 2},
+				{StartsAt: pickupDay.Add(11 * time.Hour), EndsAt: pickupDay.Add(12 * time.Hour), Capacity: 1},
+				{StartsAt: pickupDay.Add(7 * time.Hour), EndsAt: pickupDay.Add(9 * time.Hour), Capacity: 1},
+			} {
+				_, err := svc.CreateSlot(1, &req)
+				require.NoError(t, err)
+			}
+			_, err := svc.Reserve(1, 2, &valid)
+			require.NoError(t, err)
+
+			req := valid
+			if tt.modify != nil {
+				tt.modify(&req)
+			}
+			reservation, err := svc.Reserve(tt.locationID, tt.slotID, &req)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, reservation.ID)
+			require.Len(t, reservation.Items, 1)
+		})
+	}
+}
+
+func TestGetPickupSchedule(t *testing.T) {
+	svc := newTestPickupService(t)
+	for _, start := range []time.Duration{14 * time.Hour, 10 * time.Hour, 34 * time.Hour} {
+		_, err := svc.CreateSlot(1, &models.CreatePickupSlotRequest{StartsAt: pickupDay.Add(start), EndsAt: pickupDay.Add(start + time.Hour), Capacity: 3})
+		require.NoError(t, err)
+	}
+	req := &models.ReservePickupRequest{CustomerName: "Ana", CustomerEmail: "ana@example.com", Items: []models.PickupItem{{CupcakeID: 1, Quantity: 1}}}
+	_, err := svc.Reserve(1, 2, req)
+	require.NoError(t, err)
+	_, err = svc.Reserve(1, 2, req)
+	require.NoError(t, err)
+
+	schedule, err := svc.GetSchedule(1, pickupDay.Add(12*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, schedule, 2, "the next day's slot should be left out")
+	require.Equal(t, uint(2), schedule[0].ID)
+	require.Equal(t, 2, schedule[0].Booked)
+	require.Len(t, schedule[0].Reservations, 2)
+	require.Equal(t, uint(1), schedule[1].ID)
+	require.Empty(t, schedule[1].Reservations)
+
+	_, err = svc.GetSchedule(999, pickupDay)
+	require.ErrorIs(t, err, ErrLocationNotFound)
+}
+
+func TestMarkPickupReady(t *testing.T) {
+	svc := newTestPickupService(t)
+	var published []models.PickupReadyEvent
+	svc.events = &mocks.EventPublisher{PublishFunc: func(event string, data interface{}) {
+		require.Equal(t, models.EventPickupReady, event)
+		published = append(published, data.(models.PickupReadyEvent))
+	}}
+
+	slot, err := svc.CreateSlot(1, &models.CreatePickupSlotRequest{StartsAt: pickupDay.Add(10 * time.Hour), EndsAt: pickupDay.Add(11 * time.Hour), Capacity: 2})
+	require.NoError(t, err)
+	reservation, err := svc.Reserve(1, slot.ID, &models.ReservePickupRequest{CustomerName: "Ana", CustomerEmail: "ana@example.com", CustomerPhone: "+5511912345678", Items: []models.PickupItem{{CupcakeID: 1, Quantity: 1}}})
+	require.NoError(t, err)
+
+	ready, err := svc.MarkReady(reservation.ID)
+	require.NoError(t, err)
+	require.Equal(t, pickupDay.Add(8*time.Hour), *ready.ReadyAt)
+	require.Equal(t, []models.PickupReadyEvent{{
+		ReservationID: reservation.ID,
+		CustomerName:  "Ana",
+		CustomerEmail: "ana@example.com",
+		CustomerPhone: "+5511912345678",
+		LocationID:    1,
+		LocationName:  "Centro",
+		SlotStartsAt:  slot.StartsAt,
+		SlotEndsAt:    slot.EndsAt,
+	}}, published)
+
+	// The customer is only told once.
+	_, err = svc.MarkReady(reservation.ID)
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+
+	_, err = svc.MarkReady(999)
+	require.ErrorIs(t, err, ErrReservationNotFound)
+}
+
+func TestCancelPickup(t *testing.T) {
+	svc := newTestPickupService(t)
+	var published []models.PickupCancelledEvent
+	svc.events = &mocks.EventPublisher{PublishFunc: func(event string, data interface{}) {
+		if event == models.EventPickupCancelled {
+			published = append(published, data.(models.PickupCancelledEvent))
+		}
+	}}
+
+	slot, err := svc.CreateSlot(1, &models.CreatePickupSlotRequest{StartsAt: pickupDay.Add(10 * time.Hour), EndsAt: pickupDay.Add(11 * time.Hour), Capacity: 1})
+	require.NoError(t, err)
+	reservation, err := svc.Reserve(1, slot.ID, &models.ReservePickupRequest{CustomerName: "Ana", CustomerEmail: "ana@example.com", Items: []models.PickupItem{{CupcakeID: 1, Quantity: 1}}})
+	require.NoError(t, err)
+
+	_, err = svc.Cancel(reservation.ID, &models.CancelPickupRequest{Reason: "changed_mind"})
+	require.EqualError(t, err, "reason must be customer_request, out_of_stock, store_closed, no_show or other")
+
+	cancelled, err := svc.Cancel(reservation.ID, &models.CancelPickupRequest{Reason: models.CancellationCustomerRequest})
+	require.NoError(t, err)
+	require.Equal(t, pickupDay.Add(8*time.Hour), *cancelled.CancelledAt)
+	require.Equal(t, models.CancellationCustomerRequest, cancelled.CancellationReason)
+	require.Equal(t, []models.PickupCancelledEvent{{
+		ReservationID: reservation.ID,
+		CustomerName:  "Ana",
+		CustomerEmail: "ana@example.com",
+		LocationID:    1,
+		LocationName:  "Centro",
+		SlotStartsAt:  slot.StartsAt,
+		SlotEndsAt:    slot.EndsAt,
+		Reason:        models.CancellationCustomerRequest,
+	}}, published)
+
+	// The customer is only told once, and the order can no longer be
+	// made ready.
+	_, err = svc.Cancel(reservation.ID, &models.CancelPickupRequest{Reason: models.CancellationOther})
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	_, err = svc.MarkReady(reservation.ID)
+	require.ErrorIs(t, err, ErrReservationCancelled)
+
+	// The slot has room again, but a ready order cannot be cancelled.
+	again, err := svc.Reserve(1, slot.ID, &models.ReservePickupRequest{CustomerName: "Bia", CustomerEmail: "bia@example.com", Items: []models.PickupItem{{CupcakeID: 1, Quantity: 1}}})
+	require.NoError(t, err)
+	_, err = svc.MarkReady(again.ID)
+	require.NoError(t, err)
+	_, err = svc.Cancel(again.ID, &models.CancelPickupRequest{Reason: models.CancellationNoShow})
+	require.ErrorIs(t, err, ErrReservationReady)
+
+	_, err = svc.Cancel(999, &models.CancelPickupRequest{Reason: models.CancellationOther})
+	require.ErrorIs(t, err, ErrReservationNotFound)
+}
+
+func TestStartPickup(t *testing.T) {
+	svc := newTestPickupService(t)
+	svc.events = &mocks.EventPublisher{PublishFunc: func(string, interface{}) {}}
+
+	slot, err := svc.CreateSlot(1, &models.CreatePickupSlotRequest{StartsAt: pickupDay.Add(10 * time.Hour), EndsAt: pickupDay.Add(11 * time.Hour), Capacity: 3})
+	require.NoError(t, err)
+	reserve := func() *models.PickupReservation {
+		reservation, err := svc.Reserve(1, slot.ID, &models.ReservePickupRequest{CustomerName: "Ana", CustomerEmail: "ana@example.com", Items: []models.PickupItem{{CupcakeID: 1, Quantity: 1}}})
+		require.NoError(t, err)
+		return reservation
+	}
+
+	reservation := reserve()
+	started, err := svc.Start(reservation.ID)
+	require.NoError(t, err)
+	require.Equal(t, pickupDay.Add(8*time.Hour), *started.StartedAt)
+	_, err = svc.Start(reservation.ID)
+	require.NoError(t, err)
+
+	// Once started, the order can only be made ready.
+	_, err = svc.Cancel(reservation.ID, &models.CancelPickupRequest{Reason: models.CancellationOutOfStock})
+	require.ErrorIs(t, err, ErrReservationStarted)
+	_, err = svc.MarkReady(reservation.ID)
+	require.NoError(t, err)
+	_, err = svc.Start(reservation.ID)
+	require.ErrorIs(t, err, ErrReservationReady)
+
+	cancelled := reserve()
+	_, err = svc.Cancel(cancelled.ID, &models.CancelPickupRequest{Reason: models.CancellationOther})
+	require.NoError(t, err)
+	_, err = svc.Start(cancelled.ID)
+	require.ErrorIs(t, err, ErrReservationCancelled)
+
+	_, err = svc.Start(999)
+	require.ErrorIs(t, err, ErrReservationNotFound)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	printTicketJob = "print.ticket"
+	// printTimeout bounds how long a job waits on the printer.
+	printTimeout = 10 * time.Second
+)
+
+var ErrPrintingDisabled = errors.New("ticket printing is not configured")
+
+// TicketPrinter sends tickets to a receipt printer, such as an ESC/POS
+// printer behind a print server.
+type TicketPrinter interface {
+	Print(ctx context.Context, ticket *models.PrintTicket) error
+}
+
+type printTicketPayload struct {
+	ReservationID uint   `json:"reservation_id"`
+	Ticket        string `json:"ticket"`
+}
+
+// PrintService prints the kitchen ticket and the customer receipt of
+// pickup orders. Tickets go through the job queue, so a printer that is
+// offline gets them once it is back. Jobs only hold the reservation ID;
+// the ticket is built when it prints.
+type PrintService struct {
+	pickups     repository.PickupRepositoryInterface
+	locations   LocationServiceInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+	jobs        *JobService
+	printer     TicketPrinter
+}
+
+var _ PrintServiceInterface = (*PrintService)(nil)
+
+func NewPrintService(pickups repository.PickupRepositoryInterface, locations LocationServiceInterface, cupcakeRepo repository.CupcakeRepositoryInterface, jobs *JobService) *PrintService {
+	s := &PrintService{pickups: pickups, locations: locations, cupcakeRepo: cupcakeRepo, jobs: jobs}
+	jobs.Register(printTicketJob, s.print)
+	return s
+}
+
+// WithPrinter prints through printer. Until it is called, printing fails
+// with ErrPrintingDisabled.
+func (s *PrintService) WithPrinter(printer TicketPrinter) *PrintService {
+	s.printer = printer
+	return s
+}
+
+// Print queues the named tickets of the reservation, or both when none are
+// named.
+func (s *PrintService) Print(reservationID uint, tickets []string) ([]string, error) {
+	if len(tickets) == 0 {
+		tickets = []string{models.TicketKitchen, models.TicketReceipt}
+	}
+	for _, ticket := range tickets {
+		if ticket != models.TicketKitchen && ticket != models.TicketReceipt {
+			return nil, i18n.NewError(msgTicketInvalid, map[string]any{"Ticket": ticket})
+		}
+	}
+	if s.printer == nil {
+		return nil, ErrPrintingDisabled
+	}
+
+	if _, err := s.pickups.FindReservation(reservationID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrReservationNotFound
+		}
+		return nil, err
+	}
+	for _, ticket := range tickets {
+		if err := s.jobs.Enqueue(printTicketJob, printTicketPayload{ReservationID: reservationID, Ticket: ticket}); err != nil {
+			return nil, err
+		}
+	}
+	return tickets, nil
+}
+
+func (s *PrintService) print(job *models.Job) error {
+	var payload printTicketPayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return err
+	}
+	if s.printer == nil {
+		return ErrPrintingDisabled
+	}
+
+	reservation, err := s.pickups.FindReservation(payload.ReservationID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ticket, err := s.ticket(reservation, payload.Ticket)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), printTimeout)
+	defer cancel()
+	return s.printer.Print(ctx, ticket)
+}
+
+// ticket lays out the kitchen ticket or the customer receipt of a
+// reservation. The kitchen ticket leads with what to make; the receipt
+// with where and when to pick it up.
+func (s *PrintService) ticket(reservation *models.PickupReservation, kind string) (*models.PrintTicket, error) {
+	slot, err := s.pickups.FindSlot(reservation.SlotID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := s.locations.GetLocation(slot.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	starts, ends := slot.StartsAt.Local(), slot.EndsAt.Local()
+	pickup := fmt.Sprintf("Pickup: %s %s-%s", starts.Format(time.DateOnly), starts.Format("15:04"), ends.Format("15:04"))
+	items := make([]string, len(reservation.Items))
+	for i, item := range reservation.Items {
+		name := fmt.Sprintf("Cupcake #%d", item.CupcakeID)
+		cupcake, err := s.cupcakeRepo.FindByID(item.CupcakeID)
+		switch {
+		case err == nil:
+			name = cupcake.Name
+		case !errors.Is(err, repository.ErrNotFound):
+			return nil, err
+		}
+		items[i] = fmt.Sprintf("%dx %s", item.Quantity, name)
+	}
+
+	var lines []string
+	switch kind {
+	case models.TicketKitchen:
+		lines = append(lines, "KITCHEN", fmt.Sprintf("Order #%d", reservation.ID), pickup, "Customer: "+reservation.CustomerName, "")
+		lines = append(lines, items...)
+		if reservation.CancelledAt != nil {
+			lines = append(lines, "", "*** CANCELLED ***")
+		}
+	default:
+		lines = append(lines, location.Name, location.Address, "", fmt.Sprintf("Order #%d", reservation.ID), "Customer: "+reservation.CustomerName, pickup, "")
+		lines = append(lines, items...)
+		lines = append(lines, "", "Thank you!")
+	}
+
+	return &models.PrintTicket{Ticket: kind, ReservationID: reservation.ID, LocationID: location.ID, Lines: lines}, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintService(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	locations := NewLocationService(repository.NewLocationRepository(db), cupcakeRepo, repository.NewBundleRepository(db))
+	cupcake := factory.Cupcake(factory.WithName("Vanilla"))
+	require.NoError(t, cupcakeRepo.Create(&cupcake))
+	_, err := locations.CreateLocation(&models.CreateLocationRequest{Name: "Centro", Address: "Rua Augusta, 100"})
+	require.NoError(t, err)
+	_, err = locations.SetStock(1, 1, &models.SetStockRequest{Quantity: intPtr(5)})
+	require.NoError(t, err)
+
+	pickupRepo := repository.NewPickupRepository(db)
+	jobs := NewJobService(repository.NewJobRepository(db))
+	prints := NewPrintService(pickupRepo, locations, cupcakeRepo, jobs)
+	pickups := NewPickupService(pickupRepo, locations, &mocks.EventPublisher{}).WithPrinting(prints)
+	pickups.now = func() time.Time { return pickupDay.Add(8 * time.Hour) }
+
+	var printed []*models.PrintTicket
+	printer := &mocks.TicketPrinter{PrintFunc: func(_ context.Context, ticket *models.PrintTicket) error {
+		printed = append(printed, ticket)
+		return nil
+	}}
+
+	starts := pickupDay.Add(10 * time.Hour)
+	pickupLine := "Pickup: " + starts.Local().Format("2006-01-02 15:04") + "-" + starts.Add(time.Hour).Local().Format("15:04")
+	slot, err := pickups.CreateSlot(1, &models.CreatePickupSlotRequest{StartsAt: starts, EndsAt: starts.Add(time.Hour), Capacity: 5})
+	require.NoError(t, err)
+	reserve := &models.ReservePickupRequest{CustomerName: "Ana", CustomerEmail: "ana@example.com", Items: []models.PickupItem{{CupcakeID: 1, Quantity: 2}}}
+
+	// Without a printer, reserving still works and reprints are refused.
+	first, err := pickups.Reserve(1, slot.ID, reserve)
+	require.NoError(t, err)
+	_, err = prints.Print(first.ID, nil)
+	require.ErrorIs(t, err, ErrPrintingDisabled)
+
+	prints.WithPrinter(printer)
+	reservation, err := pickups.Reserve(1, slot.ID, reserve)
+	require.NoError(t, err)
+	drainJobs(t, jobs)
+	require.Len(t, printed, 2)
+	require.Equal(t, &models.PrintTicket{
+		Ticket:        models.TicketKitchen,
+		ReservationID: reservation.ID,
+		LocationID:    1,
+		Lines:         []string{"KITCHEN", "Order #2", pickupLine, "Customer: Ana", "", "2x Vanilla"},
+	}, printed[0])
+	require.Equal(t, &models.PrintTicket{
+		Ticket:        models.TicketReceipt,
+		ReservationID: reservation.ID,
+		LocationID:    1,
+		Lines:         []string{"Centro", "Rua Augusta, 100", "", "Order #2", "Customer: Ana", pickupLine, "", "2x Vanilla", "", "Thank you!"},
+	}, printed[1])
+
+	tickets, err := prints.Print(reservation.ID, []string{models.TicketReceipt})
+	require.NoError(t, err)
+	require.Equal(t, []string{models.TicketReceipt}, tickets)
+	drainJobs(t, jobs)
+	require.Len(t, printed, 3)
+	require.Equal(t, models.TicketReceipt, printed[2].Ticket)
+
+	_, err = prints.Print(reservation.ID, []string{"invoice"})
+	require.EqualError(t, err, "invoice is not a ticket: must be kitchen or receipt")
+	_, err = prints.Print(999, nil)
+	require.ErrorIs(t, err, ErrReservationNotFound)
+}
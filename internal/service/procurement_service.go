@@ -0,0 +1,307 @@
+package service
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/money"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var (
+	ErrSupplierNotFound      = errors.New("supplier not found")
+	ErrIngredientNotFound    = errors.New("ingredient not found")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrPurchaseOrderNotOpen  = errors.New("purchase order is not open")
+)
+
+// ProcurementService manages suppliers, ingredients and the purchase
+// orders that bring ingredients into stock.
+type ProcurementService struct {
+	suppliers   repository.SupplierRepositoryInterface
+	ingredients repository.IngredientRepositoryInterface
+	orders      repository.PurchaseOrderRepositoryInterface
+	now         func() time.Time
+}
+
+var _ ProcurementServiceInterface = (*ProcurementService)(nil)
+
+func NewProcurementService(suppliers repository.SupplierRepositoryInterface, ingredients repository.IngredientRepositoryInterface, orders repository.PurchaseOrderRepositoryInterface) *ProcurementService {
+	return &ProcurementService{suppliers: suppliers, ingredients: ingredients, orders: orders, now: time.Now}
+}
+
+func (s *ProcurementService) CreateSupplier(req *models.CreateSupplierRequest) (*models.Supplier, error) {
+	supplier := &models.Supplier{
+		Name:  strings.TrimSpace(req.Name),
+		Email: strings.TrimSpace(req.Email),
+		Phone: strings.TrimSpace(req.Phone),
+	}
+	if err := s.validateSupplier(supplier); err != nil {
+		return nil, err
+	}
+
+	if err := s.suppliers.Create(supplier); err != nil {
+		return nil, err
+	}
+
+	return supplier, nil
+}
+
+func (s *ProcurementService) GetSupplier(id uint) (*models.Supplier, error) {
+	supplier, err := s.suppliers.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	return supplier, nil
+}
+
+func (s *ProcurementService) GetAllSuppliers() ([]models.Supplier, error) {
+	return s.suppliers.FindAll()
+}
+
+func (s *ProcurementService) UpdateSupplier(id uint, req *models.UpdateSupplierRequest) (*models.Supplier, error) {
+	supplier, err := s.GetSupplier(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		supplier.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.Email != nil {
+		supplier.Email = strings.TrimSpace(*req.Email)
+	}
+
+	if req.Phone != nil {
+		supplier.Phone = strings.TrimSpace(*req.Phone)
+	}
+
+	if err := s.validateSupplier(supplier); err != nil {
+		return nil, err
+	}
+
+	if err := s.suppliers.Update(supplier); err != nil {
+		return nil, err
+	}
+
+	return supplier, nil
+}
+
+func (s *ProcurementService) CreateIngredient(req *models.CreateIngredientRequest) (*models.Ingredient, error) {
+	ingredient := &models.Ingredient{
+		Name: strings.TrimSpace(req.Name),
+		Unit: strings.TrimSpace(req.Unit),
+	}
+	if err := s.validateIngredient(ingredient); err != nil {
+		return nil, err
+	}
+
+	if err := s.ingredients.Create(ingredient); err != nil {
+		return nil, err
+	}
+
+	return ingredient, nil
+}
+
+func (s *ProcurementService) GetIngredient(id uint) (*models.Ingredient, error) {
+	ingredient, err := s.ingredients.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrIngredientNotFound
+		}
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+func (s *ProcurementService) GetAllIngredients() ([]models.Ingredient, error) {
+	return s.ingredients.FindAll()
+}
+
+func (s *ProcurementService) UpdateIngredient(id uint, req *models.UpdateIngredientRequest) (*models.Ingredient, error) {
+	ingredient, err := s.GetIngredient(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		ingredient.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.Unit != nil {
+		ingredient.Unit = strings.TrimSpace(*req.Unit)
+	}
+
+	if err := s.validateIngredient(ingredient); err != nil {
+		return nil, err
+	}
+
+	if err := s.ingredients.Update(ingredient); err != nil {
+		return nil, err
+	}
+
+	return ingredient, nil
+}
+
+func (s *ProcurementService) CreatePurchaseOrder(req *models.CreatePurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	if len(req.Lines) == 0 {
+		return nil, i18n.NewError(msgLinesRequired, nil)
+	}
+
+	if _, err := s.GetSupplier(req.SupplierID); err != nil {
+		return nil, err
+	}
+
+	order := &models.PurchaseOrder{
+		SupplierID: req.SupplierID,
+		Status:     models.PurchaseOrderOpen,
+		Lines:      make([]models.PurchaseOrderLine, 0, len(req.Lines)),
+	}
+	seen := make(map[uint]bool, len(req.Lines))
+	for _, line := range req.Lines {
+		if line.Quantity <= 0 {
+			return nil, i18n.NewError(msgQuantityNotPositive, nil)
+		}
+		if line.UnitCostCents < 0 {
+			return nil, i18n.NewError(msgUnitCostNegative, nil)
+		}
+		if seen[line.IngredientID] {
+			return nil, i18n.NewError(msgIngredientRepeated, map[string]any{"ID": line.IngredientID})
+		}
+		seen[line.IngredientID] = true
+
+		if _, err := s.ingredients.FindByID(line.IngredientID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, i18n.NewError(msgIngredientIDNotFound, map[string]any{"ID": line.IngredientID})
+			}
+			return nil, err
+		}
+
+		order.Lines = append(order.Lines, models.PurchaseOrderLine{
+			IngredientID:  line.IngredientID,
+			Quantity:      line.Quantity,
+			UnitCostCents: line.UnitCostCents,
+		})
+		cost, err := money.Cents(line.UnitCostCents).Mul(int64(line.Quantity))
+		if err == nil {
+			cost, err = cost.Add(money.Cents(order.TotalCents))
+		}
+		if order.TotalCents, err = cents(cost, err); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.orders.Create(order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (s *ProcurementService) GetPurchaseOrder(id uint) (*models.PurchaseOrder, error) {
+	order, err := s.orders.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+func (s *ProcurementService) GetPurchaseOrders(status string) ([]models.PurchaseOrder, error) {
+	switch status {
+	case "", models.PurchaseOrderOpen, models.PurchaseOrderReceived, models.PurchaseOrderCancelled:
+	default:
+		return nil, i18n.NewError(msgPurchaseOrderStatusInvalid, nil)
+	}
+	return s.orders.FindAll(status)
+}
+
+// ReceivePurchaseOrder records the delivery of an open order and adds
+// every line to ingredient stock.
+func (s *ProcurementService) ReceivePurchaseOrder(id uint) (*models.PurchaseOrder, error) {
+	order, err := s.GetPurchaseOrder(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.orders.Receive(order, s.now()); err != nil {
+		if errors.Is(err, repository.ErrPurchaseOrderNotOpen) {
+			return nil, ErrPurchaseOrderNotOpen
+		}
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(id)
+}
+
+func (s *ProcurementService) CancelPurchaseOrder(id uint) (*models.PurchaseOrder, error) {
+	if _, err := s.GetPurchaseOrder(id); err != nil {
+		return nil, err
+	}
+
+	if err := s.orders.Cancel(id); err != nil {
+		if errors.Is(err, repository.ErrPurchaseOrderNotOpen) {
+			return nil, ErrPurchaseOrderNotOpen
+		}
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(id)
+}
+
+func (s *ProcurementService) validateSupplier(supplier *models.Supplier) error {
+	if supplier.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if len(supplier.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if supplier.Email != "" {
+		if _, err := mail.ParseAddress(supplier.Email); err != nil {
+			return i18n.NewError(msgEmailInvalid, nil)
+		}
+	}
+
+	existing, err := s.suppliers.FindByName(supplier.Name)
+	if err == nil && existing.ID != supplier.ID {
+		return i18n.NewError(msgSupplierNameTaken, nil)
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *ProcurementService) validateIngredient(ingredient *models.Ingredient) error {
+	if ingredient.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if len(ingredient.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if ingredient.Unit == "" {
+		return i18n.NewError(msgUnitRequired, nil)
+	}
+	if len(ingredient.Unit) > 20 {
+		return i18n.NewError(msgUnitTooLong, nil)
+	}
+
+	existing, err := s.ingredients.FindByName(ingredient.Name)
+	if err == nil && existing.ID != ingredient.ID {
+		return i18n.NewError(msgIngredientNameTaken, nil)
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProcurementService returns a service with the supplier "Moinho
+// Paulista" (1) and the ingredients Flour (1, g) and Butter (2, g).
+func newTestProcurementService(t *testing.T) *ProcurementService {
+	t.Helper()
+
+	db := setupTestDB(t)
+	svc := NewProcurementService(repository.NewSupplierRepository(db), repository.NewIngredientRepository(db), repository.NewPurchaseOrderRepository(db))
+	svc.now = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+
+	_, err := svc.CreateSupplier(&models.CreateSupplierRequest{Name: "Moinho Paulista", Email: "vendas@moinho.com"})
+	require.NoError(t, err)
+	for _, name := range []string{"Flour", "Butter"} {
+		_, err := svc.CreateIngredient(&models.CreateIngredientRequest{Name: name, Unit: "g"})
+		require.NoError(t, err)
+	}
+	return svc
+}
+
+func TestCreateSupplier(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateSupplierRequest
+		expectedError string
+	}{
+		{name: "success", request: &models.CreateSupplierRequest{Name: " Laticínios Serra ", Phone: "11 5555-0000"}},
+		{name: "missing name", request: &models.CreateSupplierRequest{Email: "contato@serra.com"}, expectedError: "name is required"},
+		{name: "invalid email", request: &models.CreateSupplierRequest{Name: "Laticínios Serra", Email: "serra"}, expectedError: "email is invalid"},
+		{name: "duplicate name", request: &models.CreateSupplierRequest{Name: "Moinho Paulista"}, expectedError: "supplier name already exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestProcurementService(t)
+
+			supplier, err := svc.CreateSupplier(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, supplier.ID)
+			require.Equal(t, "Laticínios Serra", supplier.Name)
+		})
+	}
+}
+
+func TestCreateIngredient(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateIngredientRequest
+		expectedError string
+	}{
+		{name: "success", request: &models.CreateIngredientRequest{Name: "Sugar", Unit: "g"}},
+		{name: "missing unit", request: &models.CreateIngredientRequest{Name: "Sugar"}, expectedError: "unit is required"},
+		{name: "duplicate name", request: &models.CreateIngredientRequest{Name: "Flour", Unit: "kg"}, expectedError: "ingredient name already exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestProcurementService(t)
+
+			ingredient, err := svc.CreateIngredient(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Zero(t, ingredient.StockQuantity)
+		})
+	}
+}
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreatePurchaseOrderRequest
+		expectedTotal int
+		expectedError string
+	}{
+		{
+			name:          "success",
+			request:       &models.CreatePurchaseOrderRequest{SupplierID: 1, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 1, Quantity: 5000, UnitCostCents: 1}, {IngredientID: 2, Quantity: 1000, UnitCostCents: 4}}},
+			expectedTotal: 9000,
+		},
+		{
+			name:          "unknown supplier",
+			request:       &models.CreatePurchaseOrderRequest{SupplierID: 999, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 1, Quantity: 5000}}},
+			expectedError: ErrSupplierNotFound.Error(),
+		},
+		{
+			name:          "no lines",
+			request:       &models.CreatePurchaseOrderRequest{SupplierID: 1},
+			expectedError: "at least one line is required",
+		},
+		{
+			name:          "zero quantity",
+			request:       &models.CreatePurchaseOrderRequest{SupplierID: 1, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 1}}},
+			expectedError: "quantity must be greater than zero",
+		},
+		{
+			name:          "negative cost",
+			request:       &models.CreatePurchaseOrderRequest{SupplierID: 1, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 1, Quantity: 10, UnitCostCents: -1}}},
+			expectedError: "unit cost cannot be negative",
+		},
+		{
+			name:          "ingredient listed twice",
+			request:       &models.CreatePurchaseOrderRequest{SupplierID: 1, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 1, Quantity: 10}, {IngredientID: 1, Quantity: 10}}},
+			expectedError: "ingredient 1 is listed more than once",
+		},
+		{
+			name:          "unknown ingredient",
+			request:       &models.CreatePurchaseOrderRequest{SupplierID: 1, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 999, Quantity: 10}}},
+			expectedError: "ingredient 999 not found",
+		},
+		{
+			name:          "total overflows",
+			request:       &models.CreatePurchaseOrderRequest{SupplierID: 1, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 1, Quantity: math.MaxInt64 / 2, UnitCostCents: 3}}},
+			expectedError: "amount is too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestProcurementService(t)
+
+			order, err := svc.CreatePurchaseOrder(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, models.PurchaseOrderOpen, order.Status)
+			require.Equal(t, tt.expectedTotal, order.TotalCents)
+		})
+	}
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	svc := newTestProcurementService(t)
+
+	order, err := svc.CreatePurchaseOrder(&models.CreatePurchaseOrderRequest{SupplierID: 1, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 1, Quantity: 5000, UnitCostCents: 1}}})
+	require.NoError(t, err)
+
+	received, err := svc.ReceivePurchaseOrder(order.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.PurchaseOrderReceived, received.Status)
+	require.NotNil(t, received.ReceivedAt)
+	require.True(t, received.ReceivedAt.Equal(svc.now()))
+
+	flour, err := svc.GetIngredient(1)
+	require.NoError(t, err)
+	require.Equal(t, 5000, flour.StockQuantity)
+
+	_, err = svc.ReceivePurchaseOrder(order.ID)
+	require.ErrorIs(t, err, ErrPurchaseOrderNotOpen)
+	_, err = svc.CancelPurchaseOrder(order.ID)
+	require.ErrorIs(t, err, ErrPurchaseOrderNotOpen)
+	flour, err = svc.GetIngredient(1)
+	require.NoError(t, err)
+	require.Equal(t, 5000, flour.StockQuantity, "an order is only received once")
+
+	_, err = svc.ReceivePurchaseOrder(999)
+	require.ErrorIs(t, err, ErrPurchaseOrderNotFound)
+}
+
+func TestCancelPurchaseOrder(t *testing.T) {
+	svc := newTestProcurementService(t)
+
+	order, err := svc.CreatePurchaseOrder(&models.CreatePurchaseOrderRequest{SupplierID: 1, Lines: []models.PurchaseOrderLineRequest{{IngredientID: 2, Quantity: 1000, UnitCostCents: 4}}})
+	require.NoError(t, err)
+
+	cancelled, err := svc.CancelPurchaseOrder(order.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.PurchaseOrderCancelled, cancelled.Status)
+
+	_, err = svc.ReceivePurchaseOrder(order.ID)
+	require.ErrorIs(t, err, ErrPurchaseOrderNotOpen)
+	butter, err := svc.GetIngredient(2)
+	require.NoError(t, err)
+	require.Zero(t, butter.StockQuantity)
+
+	open, err := svc.GetPurchaseOrders(models.PurchaseOrderOpen)
+	require.NoError(t, err)
+	require.Empty(t, open)
+	_, err = svc.GetPurchaseOrders("lost")
+	require.EqualError(t, err, "status must be open, received or cancelled")
+}
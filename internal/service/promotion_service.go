@@ -0,0 +1,142 @@
+package service
+
+import (
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/money"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+type PromotionService struct {
+	repo        repository.PromotionRepositoryInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+}
+
+var _ PromotionServiceInterface = (*PromotionService)(nil)
+
+func NewPromotionService(repo repository.PromotionRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface) *PromotionService {
+	return &PromotionService{repo: repo, cupcakeRepo: cupcakeRepo}
+}
+
+func (s *PromotionService) CreatePromotion(req *models.CreatePromotionRequest) (*models.Promotion, error) {
+	if err := validateDiscount(req.DiscountType, req.DiscountValue); err != nil {
+		return nil, err
+	}
+
+	if err := validatePromotionWindow(req.StartsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.cupcakeRepo.Exists(req.CupcakeID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, i18n.NewError(msgCupcakeNotFound, nil)
+	}
+
+	promotion := &models.Promotion{
+		CupcakeID:     req.CupcakeID,
+		DiscountType:  req.DiscountType,
+		DiscountValue: req.DiscountValue,
+		StartsAt:      req.StartsAt,
+		EndsAt:        req.EndsAt,
+	}
+
+	if err := s.repo.Create(promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *PromotionService) GetPromotion(id uint) (*models.Promotion, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *PromotionService) GetAllPromotions() ([]models.Promotion, error) {
+	return s.repo.FindAll()
+}
+
+func (s *PromotionService) UpdatePromotion(id uint, req *models.UpdatePromotionRequest) (*models.Promotion, error) {
+	promotion, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DiscountType != nil {
+		promotion.DiscountType = *req.DiscountType
+	}
+
+	if req.DiscountValue != nil {
+		promotion.DiscountValue = *req.DiscountValue
+	}
+
+	if req.StartsAt != nil {
+		promotion.StartsAt = *req.StartsAt
+	}
+
+	if req.EndsAt != nil {
+		promotion.EndsAt = *req.EndsAt
+	}
+
+	if err := validateDiscount(promotion.DiscountType, promotion.DiscountValue); err != nil {
+		return nil, err
+	}
+
+	if err := validatePromotionWindow(promotion.StartsAt, promotion.EndsAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *PromotionService) DeletePromotion(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func validatePromotionWindow(startsAt, endsAt time.Time) error {
+	if startsAt.IsZero() || endsAt.IsZero() {
+		return i18n.NewError(msgPromotionWindowRequired, nil)
+	}
+	if !endsAt.After(startsAt) {
+		return i18n.NewError(msgPromotionEndsBeforeStart, nil)
+	}
+	return nil
+}
+
+func applyPromotions(repo repository.PromotionRepositoryInterface, cupcakes []models.Cupcake, at time.Time) error {
+	ids := make([]uint, len(cupcakes))
+	index := make(map[uint]int, len(cupcakes))
+	for i, cupcake := range cupcakes {
+		ids[i] = cupcake.ID
+		index[cupcake.ID] = i
+	}
+
+	promotions, err := repo.FindActive(ids, at)
+	if err != nil {
+		return err
+	}
+
+	for _, promotion := range promotions {
+		applyPromotion(&cupcakes[index[promotion.CupcakeID]], promotion)
+	}
+
+	return nil
+}
+
+// applyPromotion lowers the cupcake's effective price when the promotion
+// beats the best one applied so far.
+func applyPromotion(cupcake *models.Cupcake, promotion models.Promotion) {
+	discount := calculateDiscount(promotion.DiscountType, promotion.DiscountValue, money.Cents(cupcake.PriceCents))
+	effective := cupcake.PriceCents - int(discount.Amount)
+	if cupcake.EffectivePriceCents == nil || effective < *cupcake.EffectivePriceCents {
+		cupcake.EffectivePriceCents = &effective
+	}
+}
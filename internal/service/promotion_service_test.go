@@ -0,0 +1,170 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPromotionServices(t *testing.T) (*PromotionService, *CupcakeService) {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	promotionRepo := repository.NewPromotionRepository(db)
+	return NewPromotionService(promotionRepo, cupcakeRepo), NewCupcakeService(cupcakeRepo, promotionRepo, nil, nil, nil, nil, nil)
+}
+
+func TestCreatePromotion(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		request       *models.CreatePromotionRequest
+		expectedError string
+	}{
+		{
+			name: "success",
+			request: &models.CreatePromotionRequest{
+				DiscountType:  models.DiscountTypePercentage,
+				DiscountValue: 20,
+				StartsAt:      now,
+				EndsAt:        now.Add(48 * time.Hour),
+			},
+		},
+		{
+			name: "validation error - window ends before start",
+			request: &models.CreatePromotionRequest{
+				DiscountType:  models.DiscountTypePercentage,
+				DiscountValue: 20,
+				StartsAt:      now,
+				EndsAt:        now.Add(-time.Hour),
+			},
+			expectedError: "promotion must end after it starts",
+		},
+		{
+			name: "validation error - missing window",
+			request: &models.CreatePromotionRequest{
+				DiscountType:  models.DiscountTypeFixed,
+				DiscountValue: 100,
+			},
+			expectedError: "promotion window is required",
+		},
+		{
+			name: "validation error - unknown cupcake",
+			request: &models.CreatePromotionRequest{
+				CupcakeID:     999,
+				DiscountType:  models.DiscountTypeFixed,
+				DiscountValue: 100,
+				StartsAt:      now,
+				EndsAt:        now.Add(time.Hour),
+			},
+			expectedError: "cupcake not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotionService, cupcakeService := newTestPromotionServices(t)
+
+			cupcake, err := cupcakeService.CreateCupcake(&models.CreateCupcakeRequest{
+				Name:       "Red Velvet",
+				Flavor:     "Cocoa",
+				PriceCents: 1000,
+			})
+			require.NoError(t, err)
+			if tt.request.CupcakeID == 0 {
+				tt.request.CupcakeID = cupcake.ID
+			}
+
+			promotion, err := promotionService.CreatePromotion(tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, promotion)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Greater(t, promotion.ID, uint(0))
+			}
+		})
+	}
+}
+
+func TestEffectivePrice(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name              string
+		promotions        []*models.CreatePromotionRequest
+		expectedEffective *int
+	}{
+		{
+			name:              "no promotion keeps effective price empty",
+			expectedEffective: nil,
+		},
+		{
+			name: "active percentage promotion",
+			promotions: []*models.CreatePromotionRequest{
+				{DiscountType: models.DiscountTypePercentage, DiscountValue: 20, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+			},
+			expectedEffective: intPtr(800),
+		},
+		{
+			name: "future promotion is ignored",
+			promotions: []*models.CreatePromotionRequest{
+				{DiscountType: models.DiscountTypePercentage, DiscountValue: 20, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+			},
+			expectedEffective: nil,
+		},
+		{
+			name: "overlapping promotions use the lowest price",
+			promotions: []*models.CreatePromotionRequest{
+				{DiscountType: models.DiscountTypePercentage, DiscountValue: 10, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+				{DiscountType: models.DiscountTypeFixed, DiscountValue: 300, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+			},
+			expectedEffective: intPtr(700),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotionService, cupcakeService := newTestPromotionServices(t)
+
+			cupcake, err := cupcakeService.CreateCupcake(&models.CreateCupcakeRequest{
+				Name:       "Red Velvet",
+				Flavor:     "Cocoa",
+				PriceCents: 1000,
+			})
+			require.NoError(t, err)
+
+			for _, request := range tt.promotions {
+				request.CupcakeID = cupcake.ID
+				_, err := promotionService.CreatePromotion(request)
+				require.NoError(t, err)
+			}
+
+			found, err := cupcakeService.GetCupcake(cupcake.ID)
+			require.NoError(t, err)
+			require.Equal(t, 1000, found.PriceCents)
+			require.Equal(t, tt.expectedEffective, found.EffectivePriceCents)
+
+			all, err := cupcakeService.GetAllCupcakes()
+			require.NoError(t, err)
+			require.Len(t, all, 1)
+			require.Equal(t, tt.expectedEffective, all[0].EffectivePriceCents)
+
+			var streamed []models.Cupcake
+			err = cupcakeService.StreamCupcakes("", func(c *models.Cupcake) error {
+				streamed = append(streamed, *c)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, streamed, 1)
+			require.Equal(t, tt.expectedEffective, streamed[0].EffectivePriceCents)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// PushSender sends push notifications through a provider such as Firebase
+// Cloud Messaging.
+type PushSender interface {
+	// Send delivers message to the device of token. It returns
+	// ErrPushTokenInvalid when the provider no longer knows the token.
+	Send(ctx context.Context, token string, message *models.PushMessage) error
+}
+
+// ErrPushTokenInvalid is returned by a PushSender for a token of an app
+// that was uninstalled or a token that expired.
+var ErrPushTokenInvalid = errors.New("push token is no longer valid")
+
+// pushSendTimeout bounds how long a notification waits on the provider,
+// for each device.
+const pushSendTimeout = 10 * time.Second
+
+// pushTexts writes the push notification of each notification event that
+// has one.
+var pushTexts = map[string]func(models.Notification) models.PushMessage{
+	models.EventPickupReady: func(n models.Notification) models.PushMessage {
+		event := n.(models.PickupReadyEvent)
+		return models.PushMessage{
+			Title: "Your order is ready",
+			Body:  fmt.Sprintf("Order #%d is ready for pickup at %s.", event.ReservationID, event.LocationName),
+			Data:  map[string]string{"event": models.EventPickupReady, "reservation_id": strconv.FormatUint(uint64(event.ReservationID), 10)},
+		}
+	},
+	models.EventPickupCancelled: func(n models.Notification) models.PushMessage {
+		event := n.(models.PickupCancelledEvent)
+		return models.PushMessage{
+			Title: "Your order was cancelled",
+			Body:  fmt.Sprintf("Order #%d for pickup at %s was cancelled.", event.ReservationID, event.LocationName),
+			Data:  map[string]string{"event": models.EventPickupCancelled, "reservation_id": strconv.FormatUint(uint64(event.ReservationID), 10)},
+		}
+	},
+	models.EventTicketStatusChanged: func(n models.Notification) models.PushMessage {
+		event := n.(models.TicketStatusEvent)
+		return models.PushMessage{
+			Title: "Your support ticket was updated",
+			Body:  fmt.Sprintf("Ticket #%d, %q, is now %s.", event.TicketID, event.Subject, event.Status),
+			Data:  map[string]string{"event": models.EventTicketStatusChanged, "ticket_id": strconv.FormatUint(uint64(event.TicketID), 10)},
+		}
+	},
+}
+
+// PushNotifier is the NotificationSender of the push channel. It sends to
+// every device of the recipient's account; customers without an account
+// or a device, and events with no push text, are skipped. Tokens the
+// provider rejects are unregistered.
+type PushNotifier struct {
+	push     PushSender
+	devices  repository.DeviceTokenRepositoryInterface
+	accounts repository.AccountRepositoryInterface
+}
+
+var _ NotificationSender = (*PushNotifier)(nil)
+
+func NewPushNotifier(push PushSender, devices repository.DeviceTokenRepositoryInterface, accounts repository.AccountRepositoryInterface) *PushNotifier {
+	return &PushNotifier{push: push, devices: devices, accounts: accounts}
+}
+
+func (n *PushNotifier) Send(event string, notification models.Notification) error {
+	text, ok := pushTexts[event]
+	if !ok {
+		return nil
+	}
+	account, err := n.accounts.FindByEmail(notification.NotificationRecipient())
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	devices, err := n.devices.FindByAccount(account.ID)
+	if err != nil {
+		return err
+	}
+
+	message := text(notification)
+	var errs []error
+	for _, device := range devices {
+		ctx, cancel := context.WithTimeout(context.Background(), pushSendTimeout)
+		err := n.push.Send(ctx, device.Token, &message)
+		cancel()
+		if errors.Is(err, ErrPushTokenInvalid) {
+			err = n.devices.DeleteToken(device.Token)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
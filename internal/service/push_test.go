@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+type sentPush struct {
+	token   string
+	message models.PushMessage
+}
+
+type recordingPush struct {
+	sent    []sentPush
+	invalid map[string]bool
+}
+
+func (p *recordingPush) Send(ctx context.Context, token string, message *models.PushMessage) error {
+	if p.invalid[token] {
+		return ErrPushTokenInvalid
+	}
+	p.sent = append(p.sent, sentPush{token: token, message: *message})
+	return nil
+}
+
+func TestPushNotifier(t *testing.T) {
+	db := setupTestDB(t)
+	account := &models.Account{Name: "Ana Lima", Email: "ana@example.com", PasswordHash: "x"}
+	require.NoError(t, db.Create(account).Error)
+	devices := repository.NewDeviceTokenRepository(db)
+	require.NoError(t, devices.Save(&models.DeviceToken{AccountID: account.ID, Token: "phone", Platform: models.PlatformAndroid}))
+	require.NoError(t, devices.Save(&models.DeviceToken{AccountID: account.ID, Token: "old-tablet", Platform: models.PlatformIOS}))
+
+	push := &recordingPush{invalid: map[string]bool{"old-tablet": true}}
+	notifier := NewPushNotifier(push, devices, repository.NewAccountRepository(db))
+
+	event := models.PickupReadyEvent{ReservationID: 12, CustomerName: "Ana", CustomerEmail: "ana@example.com", LocationName: "Centro"}
+	require.NoError(t, notifier.Send(models.EventPickupReady, event))
+	require.Equal(t, []sentPush{{token: "phone", message: models.PushMessage{
+		Title: "Your order is ready",
+		Body:  "Order #12 is ready for pickup at Centro.",
+		Data:  map[string]string{"event": models.EventPickupReady, "reservation_id": "12"},
+	}}}, push.sent)
+
+	// The token the provider rejected is unregistered.
+	registered, err := devices.FindByAccount(account.ID)
+	require.NoError(t, err)
+	require.Len(t, registered, 1)
+	require.Equal(t, "phone", registered[0].Token)
+
+	// Customers without an account, and events without a text, get nothing.
+	require.NoError(t, notifier.Send(models.EventPickupReady, models.PickupReadyEvent{CustomerEmail: "bruno@example.com"}))
+	require.NoError(t, notifier.Send(models.EventCupcakeCreated, models.TicketStatusEvent{CustomerEmail: "ana@example.com"}))
+	require.Len(t, push.sent, 1)
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/money"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var (
+	ErrRecipeCupcakeNotFound   = errors.New("cupcake not found")
+	ErrRecipeNotFound          = errors.New("recipe not found")
+	ErrInsufficientIngredients = errors.New("not enough ingredients in stock")
+)
+
+// RecipeService links cupcakes to the ingredients they are made of and
+// records production batches against ingredient stock.
+type RecipeService struct {
+	repo        repository.RecipeRepositoryInterface
+	ingredients repository.IngredientRepositoryInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+}
+
+var _ RecipeServiceInterface = (*RecipeService)(nil)
+
+func NewRecipeService(repo repository.RecipeRepositoryInterface, ingredients repository.IngredientRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface) *RecipeService {
+	return &RecipeService{repo: repo, ingredients: ingredients, cupcakeRepo: cupcakeRepo}
+}
+
+func (s *RecipeService) GetRecipe(cupcakeID uint) (*models.Recipe, error) {
+	if err := s.checkCupcake(cupcakeID); err != nil {
+		return nil, err
+	}
+
+	lines, err := s.repo.FindRecipe(cupcakeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, ErrRecipeNotFound
+	}
+
+	return s.recipe(cupcakeID, lines)
+}
+
+func (s *RecipeService) SetRecipe(cupcakeID uint, req *models.SetRecipeRequest) (*models.Recipe, error) {
+	if len(req.Ingredients) == 0 {
+		return nil, i18n.NewError(msgIngredientsRequired, nil)
+	}
+
+	if err := s.checkCupcake(cupcakeID); err != nil {
+		return nil, err
+	}
+
+	lines := make([]models.RecipeIngredient, 0, len(req.Ingredients))
+	ids := make([]uint, 0, len(req.Ingredients))
+	seen := make(map[uint]bool, len(req.Ingredients))
+	for _, item := range req.Ingredients {
+		if item.Quantity <= 0 {
+			return nil, i18n.NewError(msgQuantityNotPositive, nil)
+		}
+		if seen[item.IngredientID] {
+			return nil, i18n.NewError(msgIngredientRepeated, map[string]any{"ID": item.IngredientID})
+		}
+		seen[item.IngredientID] = true
+		ids = append(ids, item.IngredientID)
+		lines = append(lines, models.RecipeIngredient{IngredientID: item.IngredientID, Quantity: item.Quantity})
+	}
+
+	found, err := s.ingredients.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) != len(ids) {
+		known := make(map[uint]bool, len(found))
+		for _, ingredient := range found {
+			known[ingredient.ID] = true
+		}
+		for _, id := range ids {
+			if !known[id] {
+				return nil, i18n.NewError(msgIngredientIDNotFound, map[string]any{"ID": id})
+			}
+		}
+	}
+
+	if err := s.repo.SetRecipe(cupcakeID, lines); err != nil {
+		return nil, err
+	}
+
+	return s.GetRecipe(cupcakeID)
+}
+
+// RecordProduction takes the ingredients for a batch out of stock. The
+// whole batch is refused when any ingredient runs short.
+func (s *RecipeService) RecordProduction(cupcakeID uint, req *models.RecordProductionRequest) (*models.ProductionBatch, error) {
+	if req.Quantity <= 0 {
+		return nil, i18n.NewError(msgQuantityNotPositive, nil)
+	}
+
+	recipe, err := s.GetRecipe(cupcakeID)
+	if err != nil {
+		return nil, err
+	}
+	if recipe.MaxProducible < req.Quantity {
+		return nil, fmt.Errorf("%w: at most %d can be made", ErrInsufficientIngredients, recipe.MaxProducible)
+	}
+
+	cost, err := cents(money.Cents(recipe.CostCents).Mul(int64(req.Quantity)))
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &models.ProductionBatch{
+		CupcakeID: cupcakeID,
+		Quantity:  req.Quantity,
+		CostCents: cost,
+	}
+	if err := s.repo.RecordProduction(batch, recipe.Ingredients); err != nil {
+		if errors.Is(err, repository.ErrInsufficientIngredients) {
+			return nil, ErrInsufficientIngredients
+		}
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+func (s *RecipeService) GetProductionBatches(cupcakeID uint) ([]models.ProductionBatch, error) {
+	if err := s.checkCupcake(cupcakeID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindBatches(cupcakeID)
+}
+
+// recipe prices the recipe at the ingredients' latest costs and works out
+// how many cupcakes the current stock is enough for.
+func (s *RecipeService) recipe(cupcakeID uint, lines []models.RecipeIngredient) (*models.Recipe, error) {
+	ids := make([]uint, 0, len(lines))
+	for _, line := range lines {
+		ids = append(ids, line.IngredientID)
+	}
+	ingredients, err := s.ingredients.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.Ingredient, len(ingredients))
+	for _, ingredient := range ingredients {
+		byID[ingredient.ID] = ingredient
+	}
+
+	recipe := &models.Recipe{CupcakeID: cupcakeID, Ingredients: lines}
+	for i, line := range lines {
+		ingredient := byID[line.IngredientID]
+		cost, err := money.Cents(ingredient.UnitCostCents).Mul(int64(line.Quantity))
+		if err == nil {
+			cost, err = cost.Add(money.Cents(recipe.CostCents))
+		}
+		if recipe.CostCents, err = cents(cost, err); err != nil {
+			return nil, err
+		}
+
+		producible := ingredient.StockQuantity / line.Quantity
+		if i == 0 || producible < recipe.MaxProducible {
+			recipe.MaxProducible = producible
+		}
+	}
+	return recipe, nil
+}
+
+func (s *RecipeService) checkCupcake(cupcakeID uint) error {
+	exists, err := s.cupcakeRepo.Exists(cupcakeID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrRecipeCupcakeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRecipeService returns a service with the Vanilla cupcake (1) and
+// Flour (1, 1000 g at 1 cent/g), Butter (2, 200 g at 4 cents/g) and Sugar
+// (3, no stock) in the ingredient stock.
+func newTestRecipeService(t *testing.T) *RecipeService {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	vanilla := factory.Cupcake(factory.WithName("Vanilla"))
+	require.NoError(t, cupcakeRepo.Create(&vanilla))
+
+	ingredientRepo := repository.NewIngredientRepository(db)
+	for _, ingredient := range []models.Ingredient{
+		{Name: "Flour", Unit: "g", StockQuantity: 1000, UnitCostCents: 1},
+		{Name: "Butter", Unit: "g", StockQuantity: 200, UnitCostCents: 4},
+		{Name: "Sugar", Unit: "g"},
+	} {
+		require.NoError(t, ingredientRepo.Create(&ingredient))
+	}
+
+	return NewRecipeService(repository.NewRecipeRepository(db), ingredientRepo, cupcakeRepo)
+}
+
+func TestSetRecipe(t *testing.T) {
+	tests := []struct {
+		name                  string
+		cupcakeID             uint
+		ingredients           []models.RecipeIngredientRequest
+		expectedCost          int
+		expectedMaxProducible int
+		expectedError         string
+	}{
+		{
+			name:                  "cost and availability from ingredient stock",
+			cupcakeID:             1,
+			ingredients:           []models.RecipeIngredientRequest{{IngredientID: 1, Quantity: 50}, {IngredientID: 2, Quantity: 20}},
+			expectedCost:          50*1 + 20*4,
+			expectedMaxProducible: 10,
+		},
+		{
+			name:                  "ingredient out of stock",
+			cupcakeID:             1,
+			ingredients:           []models.RecipeIngredientRequest{{IngredientID: 1, Quantity: 50}, {IngredientID: 3, Quantity: 10}},
+			expectedCost:          50,
+			expectedMaxProducible: 0,
+		},
+		{
+			name:          "no ingredients",
+			cupcakeID:     1,
+			expectedError: "at least one ingredient is required",
+		},
+		{
+			name:          "zero quantity",
+			cupcakeID:     1,
+			ingredients:   []models.RecipeIngredientRequest{{IngredientID: 1}},
+			expectedError: "quantity must be greater than zero",
+		},
+		{
+			name:          "ingredient listed twice",
+			cupcakeID:     1,
+			ingredients:   []models.RecipeIngredientRequest{{IngredientID: 1, Quantity: 50}, {IngredientID: 1, Quantity: 10}},
+			expectedError: "ingredient 1 is listed more than once",
+		},
+		{
+			name:          "unknown ingredient",
+			cupcakeID:     1,
+			ingredients:   []models.RecipeIngredientRequest{{IngredientID: 1, Quantity: 50}, {IngredientID: 999, Quantity: 10}},
+			expectedError: "ingredient 999 not found",
+		},
+		{
+			name:          "unknown cupcake",
+			cupcakeID:     999,
+			ingredients:   []models.RecipeIngredientRequest{{IngredientID: 1, Quantity: 50}},
+			expectedError: ErrRecipeCupcakeNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestRecipeService(t)
+
+			recipe, err := svc.SetRecipe(tt.cupcakeID, &models.SetRecipeRequest{Ingredients: tt.ingredients})
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, recipe.Ingredients, len(tt.ingredients))
+			require.Equal(t, tt.expectedCost, recipe.CostCents)
+			require.Equal(t, tt.expectedMaxProducible, recipe.MaxProducible)
+		})
+	}
+}
+
+func TestRecordProduction(t *testing.T) {
+	svc := newTestRecipeService(t)
+
+	_, err := svc.RecordProduction(1, &models.RecordProductionRequest{Quantity: 1})
+	require.ErrorIs(t, err, ErrRecipeNotFound)
+
+	_, err = svc.SetRecipe(1, &models.SetRecipeRequest{Ingredients: []models.RecipeIngredientRequest{{IngredientID: 1, Quantity: 50}, {IngredientID: 2, Quantity: 20}}})
+	require.NoError(t, err)
+
+	batch, err := svc.RecordProduction(1, &models.RecordProductionRequest{Quantity: 6})
+	require.NoError(t, err)
+	require.Equal(t, 6*130, batch.CostCents)
+
+	recipe, err := svc.GetRecipe(1)
+	require.NoError(t, err)
+	require.Equal(t, 4, recipe.MaxProducible, "butter left for 4 more")
+
+	_, err = svc.RecordProduction(1, &models.RecordProductionRequest{Quantity: 5})
+	require.ErrorIs(t, err, ErrInsufficientIngredients)
+	require.EqualError(t, err, "not enough ingredients in stock: at most 4 can be made")
+
+	_, err = svc.RecordProduction(1, &models.RecordProductionRequest{Quantity: 0})
+	require.EqualError(t, err, "quantity must be greater than zero")
+
+	batches, err := svc.GetProductionBatches(1)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+}
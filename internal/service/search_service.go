@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	DefaultSearchLimit = 20
+	maxSearchLimit     = 50
+	searchTimeout      = 2 * time.Second
+)
+
+// SearchFacets are the facets a search can count.
+var SearchFacets = []string{"flavor"}
+
+var ErrSearchIndexDisabled = errors.New("search index is not configured")
+
+// SearchIndex is an external full-text index of the catalog, such as
+// Elasticsearch or OpenSearch.
+type SearchIndex interface {
+	Index(ctx context.Context, doc models.SearchDocument) error
+	Delete(ctx context.Context, id uint) error
+	Search(ctx context.Context, query models.SearchQuery) (*models.SearchHits, error)
+}
+
+type SearchService struct {
+	index         SearchIndex
+	cupcakeRepo   repository.CupcakeRepositoryInterface
+	promotionRepo repository.PromotionRepositoryInterface
+	now           func() time.Time
+}
+
+var _ SearchServiceInterface = (*SearchService)(nil)
+
+// NewSearchService builds the service; index may be nil, in which case every
+// search runs against the database.
+func NewSearchService(index SearchIndex, cupcakeRepo repository.CupcakeRepositoryInterface, promotionRepo repository.PromotionRepositoryInterface) *SearchService {
+	return &SearchService{index: index, cupcakeRepo: cupcakeRepo, promotionRepo: promotionRepo, now: time.Now}
+}
+
+// Search looks up available cupcakes. The index, when configured, tolerates
+// typos; if it fails the search falls back to a substring match in the
+// database so the storefront keeps working.
+func (s *SearchService) Search(query models.SearchQuery) (*models.SearchResult, error) {
+	if query.Limit < 1 || query.Limit > maxSearchLimit {
+		return nil, i18n.NewError(msgLimitOutOfRange, map[string]any{"Max": maxSearchLimit})
+	}
+	for _, facet := range query.Facets {
+		if !isSearchFacet(facet) {
+			return nil, i18n.NewError(msgUnsupportedFacet, map[string]any{"Facet": facet})
+		}
+	}
+
+	var result *models.SearchResult
+	if s.index != nil {
+		var err error
+		result, err = s.searchIndex(query)
+		if err != nil {
+			log.Printf("Search index unavailable, falling back to SQL: %v", err)
+			result = nil
+		}
+	}
+	if result == nil {
+		var err error
+		if result, err = s.searchDatabase(query); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	if err := applyPromotions(s.promotionRepo, result.Hits, now); err != nil {
+		return nil, err
+	}
+	for i := range result.Hits {
+		result.Hits[i].PreOrder = result.Hits[i].IsPreOrder(now)
+	}
+	return result, nil
+}
+
+func (s *SearchService) searchIndex(query models.SearchQuery) (*models.SearchResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), searchTimeout)
+	defer cancel()
+
+	hits, err := s.index.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// The index only supplies IDs; cupcakes are read from the database so
+	// prices and availability are current.
+	cupcakes := make([]models.Cupcake, 0, len(hits.IDs))
+	for _, id := range hits.IDs {
+		cupcake, err := s.cupcakeRepo.FindByID(id)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		cupcakes = append(cupcakes, *cupcake)
+	}
+	return &models.SearchResult{Backend: "index", Total: hits.Total, Hits: cupcakes, Facets: hits.Facets}, nil
+}
+
+func (s *SearchService) searchDatabase(query models.SearchQuery) (*models.SearchResult, error) {
+	cupcakes, err := s.cupcakeRepo.Search(query.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.SearchResult{Backend: "sql", Total: len(cupcakes), Hits: cupcakes}
+	if len(query.Facets) > 0 {
+		result.Facets = make(map[string][]models.FacetCount, len(query.Facets))
+		for _, facet := range query.Facets {
+			result.Facets[facet] = countFacet(cupcakes, facet)
+		}
+	}
+	if len(result.Hits) > query.Limit {
+		result.Hits = result.Hits[:query.Limit]
+	}
+	return result, nil
+}
+
+// Reindex writes every cupcake to the index, for the initial load or to
+// repair drift after index updates were lost. It returns how many were
+// indexed.
+func (s *SearchService) Reindex() (int, error) {
+	if s.index == nil {
+		return 0, ErrSearchIndexDisabled
+	}
+
+	indexed := 0
+	err := s.cupcakeRepo.Stream(func(cupcake *models.Cupcake) error {
+		ctx, cancel := context.WithTimeout(context.Background(), searchTimeout)
+		defer cancel()
+
+		response := models.NewCupcakeResponse(cupcake)
+		if err := s.index.Index(ctx, models.NewSearchDocument(&response)); err != nil {
+			return fmt.Errorf("indexing cupcake %d: %w", cupcake.ID, err)
+		}
+		indexed++
+		return nil
+	})
+	return indexed, err
+}
+
+func isSearchFacet(facet string) bool {
+	for _, f := range SearchFacets {
+		if f == facet {
+			return true
+		}
+	}
+	return false
+}
+
+// countFacet counts cupcakes per facet value, most common first.
+func countFacet(cupcakes []models.Cupcake, facet string) []models.FacetCount {
+	counts := make(map[string]int)
+	for _, cupcake := range cupcakes {
+		switch facet {
+		case "flavor":
+			counts[cupcake.Flavor]++
+		}
+	}
+
+	facets := make([]models.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, models.FacetCount{Value: value, Count: count})
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+	return facets
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func newSearchTestService(t *testing.T, index SearchIndex) *SearchService {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Chocolate Fudge"), factory.WithFlavor("Chocolate")),
+		factory.Cupcake(factory.WithName("Vanilla"), factory.WithFlavor("Vanilla")),
+		factory.Cupcake(factory.WithName("Mint Chocolate"), factory.WithFlavor("Mint")),
+		factory.Cupcake(factory.WithName("Chocolate Sold Out"), factory.WithFlavor("Chocolate"), factory.Unavailable()),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+	return NewSearchService(index, cupcakeRepo, repository.NewPromotionRepository(db))
+}
+
+func hitNames(result *models.SearchResult) []string {
+	names := []string{}
+	for _, cupcake := range result.Hits {
+		names = append(names, cupcake.Name)
+	}
+	return names
+}
+
+func TestSearch_Database(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          models.SearchQuery
+		expectedNames  []string
+		expectedTotal  int
+		expectedFacets map[string][]models.FacetCount
+		expectedError  string
+	}{
+		{
+			name:          "substring match on name and flavor",
+			query:         models.SearchQuery{Text: "CHOCO", Limit: 20},
+			expectedNames: []string{"Chocolate Fudge", "Mint Chocolate"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "limit keeps the total",
+			query:         models.SearchQuery{Text: "choco", Limit: 1},
+			expectedNames: []string{"Chocolate Fudge"},
+			expectedTotal: 2,
+		},
+		{
+			name:          "flavor facet over all matches",
+			query:         models.SearchQuery{Facets: []string{"flavor"}, Limit: 1},
+			expectedNames: []string{"Chocolate Fudge"},
+			expectedTotal: 3,
+			expectedFacets: map[string][]models.FacetCount{
+				"flavor": {{Value: "Chocolate", Count: 1}, {Value: "Mint", Count: 1}, {Value: "Vanilla", Count: 1}},
+			},
+		},
+		{name: "unsupported facet", query: models.SearchQuery{Facets: []string{"price"}, Limit: 20}, expectedError: "unsupported facet: price"},
+		{name: "limit out of range", query: models.SearchQuery{Limit: 51}, expectedError: "limit must be between 1 and 50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newSearchTestService(t, nil)
+
+			result, err := svc.Search(tt.query)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "sql", result.Backend)
+			require.Equal(t, tt.expectedNames, hitNames(result))
+			require.Equal(t, tt.expectedTotal, result.Total)
+			require.Equal(t, tt.expectedFacets, result.Facets)
+		})
+	}
+}
+
+func TestSearch_Index(t *testing.T) {
+	facets := map[string][]models.FacetCount{"flavor": {{Value: "Chocolate", Count: 2}}}
+	index := &mocks.SearchIndex{
+		SearchFunc: func(ctx context.Context, query models.SearchQuery) (*models.SearchHits, error) {
+			require.Equal(t, "choclate", query.Text)
+			// 99 was deleted after being indexed.
+			return &models.SearchHits{IDs: []uint{3, 99, 1}, Total: 3, Facets: facets}, nil
+		},
+	}
+	svc := newSearchTestService(t, index)
+
+	result, err := svc.Search(models.SearchQuery{Text: "choclate", Facets: []string{"flavor"}, Limit: 20})
+	require.NoError(t, err)
+	require.Equal(t, "index", result.Backend)
+	require.Equal(t, []string{"Mint Chocolate", "Chocolate Fudge"}, hitNames(result))
+	require.Equal(t, 3, result.Total)
+	require.Equal(t, facets, result.Facets)
+}
+
+func TestSearch_FallsBackWhenIndexFails(t *testing.T) {
+	index := &mocks.SearchIndex{
+		SearchFunc: func(ctx context.Context, query models.SearchQuery) (*models.SearchHits, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	svc := newSearchTestService(t, index)
+
+	result, err := svc.Search(models.SearchQuery{Text: "vanilla", Limit: 20})
+	require.NoError(t, err)
+	require.Equal(t, "sql", result.Backend)
+	require.Equal(t, []string{"Vanilla"}, hitNames(result))
+}
+
+func TestReindex(t *testing.T) {
+	_, err := newSearchTestService(t, nil).Reindex()
+	require.ErrorIs(t, err, ErrSearchIndexDisabled)
+
+	var indexed []models.SearchDocument
+	index := &mocks.SearchIndex{
+		IndexFunc: func(ctx context.Context, doc models.SearchDocument) error {
+			indexed = append(indexed, doc)
+			return nil
+		},
+	}
+	count, err := newSearchTestService(t, index).Reindex()
+	require.NoError(t, err)
+	require.Equal(t, 4, count)
+	require.Len(t, indexed, 4)
+	require.Equal(t, "Chocolate Fudge", indexed[0].Name)
+	require.False(t, indexed[3].IsAvailable)
+
+	index.IndexFunc = func(ctx context.Context, doc models.SearchDocument) error {
+		return errors.New("connection refused")
+	}
+	count, err = newSearchTestService(t, index).Reindex()
+	require.EqualError(t, err, "indexing cupcake 1: connection refused")
+	require.Zero(t, count)
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// DefaultSessionTTL is how long a web session lasts without being used
+// when no TTL is configured.
+const DefaultSessionTTL = 24 * time.Hour
+
+var ErrSessionInvalid = errors.New("session is invalid or has expired")
+
+// SessionService keeps the logins of the bundled web app on the server, so
+// the browser holds an HttpOnly cookie instead of a bearer token in
+// JavaScript. A request with the cookie is served with a fresh access
+// token of the account, so the endpoints that take bearer tokens take the
+// cookie too. Sessions expire after ttl without use.
+//
+// Requests that change something must also carry the session's CSRF
+// token, an HMAC of the session token, which another site cannot read.
+type SessionService struct {
+	repo     repository.SessionRepositoryInterface
+	accounts *AccountService
+	ttl      time.Duration
+}
+
+var _ SessionServiceInterface = (*SessionService)(nil)
+
+// NewSessionService checks logins and issues access tokens with accounts;
+// a zero ttl means DefaultSessionTTL.
+func NewSessionService(repo repository.SessionRepositoryInterface, accounts *AccountService, ttl time.Duration) *SessionService {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &SessionService{repo: repo, accounts: accounts, ttl: ttl}
+}
+
+// Login checks the credentials as AccountService.Login does and starts a
+// session.
+func (s *SessionService) Login(req *models.LoginRequest) (*models.WebSession, error) {
+	account, err := s.accounts.checkLogin(req)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	now := s.accounts.now()
+	session := &models.Session{TokenHash: hashSessionToken(token), AccountID: account.ID, ExpiresAt: now.Add(s.ttl)}
+	if err := s.repo.Create(session); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.DeleteExpired(now); err != nil {
+		log.Printf("Error deleting expired sessions: %v", err)
+	}
+	return s.webSession(token, session, account), nil
+}
+
+// Get returns the session of token, extending it.
+func (s *SessionService) Get(token string) (*models.WebSession, error) {
+	session, account, err := s.resume(token)
+	if err != nil {
+		return nil, err
+	}
+	return s.webSession(token, session, account), nil
+}
+
+// AccessToken issues an access token of the session's account, extending
+// the session.
+func (s *SessionService) AccessToken(token string) (*models.AccessToken, error) {
+	_, account, err := s.resume(token)
+	if err != nil {
+		return nil, err
+	}
+	return s.accounts.accessToken(account)
+}
+
+// ValidCSRF reports whether csrfToken belongs to the session of token.
+func (s *SessionService) ValidCSRF(token, csrfToken string) bool {
+	return token != "" && csrfToken != "" && s.accounts.signer.valid(csrfToken, "csrf", token)
+}
+
+// Logout ends the session of token. Ending one that is already gone is not
+// an error.
+func (s *SessionService) Logout(token string) error {
+	session, err := s.repo.FindByTokenHash(hashSessionToken(token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(session.ID)
+}
+
+func (s *SessionService) resume(token string) (*models.Session, *models.Account, error) {
+	if token == "" {
+		return nil, nil, ErrSessionInvalid
+	}
+	session, err := s.repo.FindByTokenHash(hashSessionToken(token))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, ErrSessionInvalid
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	now := s.accounts.now()
+	if !now.Before(session.ExpiresAt) {
+		return nil, nil, ErrSessionInvalid
+	}
+
+	account, err := s.accounts.repo.FindByID(session.AccountID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, ErrSessionInvalid
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Written at most once per minute, not on every request.
+	if expiresAt := now.Add(s.ttl); expiresAt.Sub(session.ExpiresAt) >= time.Minute {
+		if err := s.repo.Extend(session.ID, expiresAt); err != nil {
+			log.Printf("Error extending session %d: %v", session.ID, err)
+		} else {
+			session.ExpiresAt = expiresAt
+		}
+	}
+	return session, account, nil
+}
+
+func (s *SessionService) webSession(token string, session *models.Session, account *models.Account) *models.WebSession {
+	return &models.WebSession{
+		Token:     token,
+		CSRFToken: s.accounts.signer.sign("csrf", token),
+		ExpiresAt: session.ExpiresAt,
+		Account:   account,
+	}
+}
+
+// hashSessionToken is what sessions are stored and looked up by, so a
+// leaked database does not hand out logins.
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
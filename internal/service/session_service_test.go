@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionService(t *testing.T) {
+	db := setupTestDB(t)
+	accounts := NewAccountService(repository.NewAccountRepository(db), fastPasswordParams, "test-secret", time.Hour)
+	svc := NewSessionService(repository.NewSessionRepository(db), accounts, time.Hour)
+	account, err := accounts.Register(&models.RegisterRequest{Name: "Ana", Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	_, err = svc.Login(&models.LoginRequest{Email: "ana@example.com", Password: "wrong horse"})
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	session, err := svc.Login(&models.LoginRequest{Email: "ana@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.NotEmpty(t, session.Token)
+	require.Equal(t, account.ID, session.Account.ID)
+
+	// Only a hash of the token is stored.
+	var stored models.Session
+	require.NoError(t, db.First(&stored).Error)
+	require.NotEqual(t, session.Token, stored.TokenHash)
+
+	token, err := svc.AccessToken(session.Token)
+	require.NoError(t, err)
+	me, err := accounts.Authenticate(token.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, account.ID, me.ID)
+
+	require.True(t, svc.ValidCSRF(session.Token, session.CSRFToken))
+	require.False(t, svc.ValidCSRF(session.Token, ""))
+	require.False(t, svc.ValidCSRF("other-session", session.CSRFToken))
+
+	_, err = svc.Get("not-a-session")
+	require.ErrorIs(t, err, ErrSessionInvalid)
+
+	// Using the session pushes its expiry back.
+	accounts.now = func() time.Time { return time.Now().Add(50 * time.Minute) }
+	again, err := svc.Get(session.Token)
+	require.NoError(t, err)
+	require.Equal(t, session.CSRFToken, again.CSRFToken)
+	require.True(t, again.ExpiresAt.After(session.ExpiresAt))
+	accounts.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, err = svc.Get(session.Token)
+	require.ErrorIs(t, err, ErrSessionInvalid)
+	accounts.now = time.Now
+
+	require.NoError(t, svc.Logout(session.Token))
+	_, err = svc.AccessToken(session.Token)
+	require.ErrorIs(t, err, ErrSessionInvalid)
+	require.NoError(t, svc.Logout(session.Token))
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"maps"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/currency"
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var ErrSettingNotFound = errors.New("setting not found")
+
+const (
+	SettingMaintenanceMode       = "maintenance_mode"
+	SettingDefaultCurrency       = "default_currency"
+	SettingFreeDeliveryThreshold = "free_delivery_threshold_cents"
+
+	// FeatureFlagPrefix starts the key of every feature flag, e.g.
+	// feature.custom_cupcakes. Flags are created by writing them.
+	FeatureFlagPrefix = "feature."
+
+	// settingsCacheTTL bounds how long a change made through another
+	// instance takes to be seen here.
+	settingsCacheTTL = 30 * time.Second
+)
+
+var featureFlagPattern = regexp.MustCompile(`^feature\.[a-z0-9][a-z0-9_]{0,90}$`)
+
+// setting describes one key: its default, whether the storefront may read
+// it, how values are checked and what changes when one is written.
+type setting struct {
+	defaultValue json.RawMessage
+	description  string
+	public       bool
+	check        func(key string, value json.RawMessage) error
+	// apply, when set, pushes a checked value into the component it
+	// controls.
+	apply func(value json.RawMessage) error
+}
+
+// SettingsService keeps runtime settings in the database so they survive
+// restarts and are shared by every instance. Stored values are cached and
+// re-read after settingsCacheTTL; changes seen on re-read are applied like
+// local ones.
+type SettingsService struct {
+	repo     repository.SettingRepositoryInterface
+	settings map[string]setting
+	now      func() time.Time
+
+	mu       sync.Mutex
+	stored   map[string]models.Setting
+	loadedAt time.Time
+}
+
+// NewSettingsService offers maintenance_mode when maintenance is given and
+// default_currency when converter is, next to the free delivery threshold
+// and feature flags.
+func NewSettingsService(repo repository.SettingRepositoryInterface, maintenance *MaintenanceService, converter *currency.Converter) *SettingsService {
+	s := &SettingsService{
+		repo: repo,
+		now:  time.Now,
+		settings: map[string]setting{
+			SettingFreeDeliveryThreshold: {
+				defaultValue: json.RawMessage("0"),
+				description:  "Order total in cents from which delivery is free; 0 means never",
+				public:       true,
+				check:        checkNonNegativeInt,
+			},
+		},
+	}
+	if maintenance != nil {
+		s.settings[SettingMaintenanceMode] = setting{
+			defaultValue: json.RawMessage("false"),
+			description:  "Answer 503 on the public API",
+			check:        checkBool,
+			apply: func(value json.RawMessage) error {
+				var enabled bool
+				if err := json.Unmarshal(value, &enabled); err != nil {
+					return err
+				}
+				_, err := maintenance.SetStatus(&models.MaintenanceRequest{Enabled: &enabled})
+				return err
+			},
+		}
+	}
+	if converter != nil {
+		base, _ := json.Marshal(converter.Base())
+		s.settings[SettingDefaultCurrency] = setting{
+			defaultValue: base,
+			description:  "Currency prices are shown in when a request asks for none",
+			public:       true,
+			check: func(key string, value json.RawMessage) error {
+				var code string
+				if json.Unmarshal(value, &code) != nil {
+					return i18n.NewError(msgSettingNotString, map[string]any{"Key": key})
+				}
+				_, err := converter.Convert(0, code)
+				return err
+			},
+			apply: func(value json.RawMessage) error {
+				var code string
+				if err := json.Unmarshal(value, &code); err != nil {
+					return err
+				}
+				return converter.SetDefault(code)
+			},
+		}
+	}
+	return s
+}
+
+// Load reads the stored settings and applies them, so values written before
+// a restart take effect again.
+func (s *SettingsService) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refresh()
+}
+
+// List returns every known setting and every stored feature flag, sorted by
+// key.
+func (s *SettingsService) List() ([]models.SettingResponse, error) {
+	stored, err := s.storedSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(s.settings)+len(stored))
+	for key := range s.settings {
+		keys = append(keys, key)
+	}
+	for key := range stored {
+		if _, known := s.settings[key]; !known {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	responses := make([]models.SettingResponse, 0, len(keys))
+	for _, key := range keys {
+		def, ok := s.lookup(key)
+		if !ok {
+			continue
+		}
+		responses = append(responses, newSettingResponse(key, def, stored))
+	}
+	return responses, nil
+}
+
+func (s *SettingsService) Get(key string) (*models.SettingResponse, error) {
+	def, ok := s.lookup(key)
+	if !ok {
+		return nil, ErrSettingNotFound
+	}
+	stored, err := s.storedSettings()
+	if err != nil {
+		return nil, err
+	}
+	response := newSettingResponse(key, def, stored)
+	return &response, nil
+}
+
+// Set checks and stores a value for key, then applies it.
+func (s *SettingsService) Set(key string, req *models.UpdateSettingRequest) (*models.SettingResponse, error) {
+	def, ok := s.lookup(key)
+	if !ok {
+		return nil, ErrSettingNotFound
+	}
+	value := bytes.TrimSpace(req.Value)
+	if len(value) == 0 || bytes.Equal(value, []byte("null")) {
+		return nil, i18n.NewError(msgSettingValueRequired, nil)
+	}
+	if err := def.check(key, value); err != nil {
+		return nil, err
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, value); err != nil {
+		return nil, err
+	}
+	stored := models.Setting{Key: key, Value: compact.String()}
+	if err := s.repo.Set(&stored); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stored != nil {
+		s.stored[key] = stored
+	}
+	s.apply(key, def, json.RawMessage(stored.Value))
+
+	response := newSettingResponse(key, def, map[string]models.Setting{key: stored})
+	return &response, nil
+}
+
+// Reset drops the stored value of key, bringing back its default. Feature
+// flags are removed altogether.
+func (s *SettingsService) Reset(key string) error {
+	def, ok := s.lookup(key)
+	if !ok {
+		return ErrSettingNotFound
+	}
+	if err := s.repo.Delete(key); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stored != nil {
+		delete(s.stored, key)
+	}
+	s.apply(key, def, def.defaultValue)
+	return nil
+}
+
+// PublicValues returns the effective value of each setting the storefront
+// may read, keyed by setting.
+func (s *SettingsService) PublicValues() (map[string]json.RawMessage, error) {
+	settings, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]json.RawMessage)
+	for _, setting := range settings {
+		if setting.Public {
+			values[setting.Key] = setting.Value
+		}
+	}
+	return values, nil
+}
+
+// Enabled reports whether the feature flag named name (without the
+// feature. prefix) is on. Flags that cannot be read count as off.
+func (s *SettingsService) Enabled(name string) bool {
+	stored, err := s.storedSettings()
+	if err != nil {
+		log.Printf("Error reading feature flag %s: %v", name, err)
+		return false
+	}
+	setting, ok := stored[FeatureFlagPrefix+name]
+	return ok && setting.Value == "true"
+}
+
+func (s *SettingsService) lookup(key string) (setting, bool) {
+	if def, ok := s.settings[key]; ok {
+		return def, true
+	}
+	if featureFlagPattern.MatchString(key) {
+		return setting{
+			defaultValue: json.RawMessage("false"),
+			description:  "Feature flag",
+			public:       true,
+			check:        checkBool,
+		}, true
+	}
+	return setting{}, false
+}
+
+func (s *SettingsService) storedSettings() (map[string]models.Setting, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stored == nil || s.now().Sub(s.loadedAt) >= settingsCacheTTL {
+		if err := s.refresh(); err != nil {
+			return nil, err
+		}
+	}
+	// Set and Reset edit the cache in place, so callers get their own copy.
+	return maps.Clone(s.stored), nil
+}
+
+// refresh re-reads the stored settings and applies those that changed since
+// the last read, including ones reset elsewhere. Callers hold s.mu.
+func (s *SettingsService) refresh() error {
+	settings, err := s.repo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[string]models.Setting, len(settings))
+	for _, setting := range settings {
+		stored[setting.Key] = setting
+	}
+	for key, def := range s.settings {
+		previous, had := s.stored[key]
+		current, has := stored[key]
+		switch {
+		case has && (!had || previous.Value != current.Value):
+			s.apply(key, def, json.RawMessage(current.Value))
+		case had && !has:
+			s.apply(key, def, def.defaultValue)
+		}
+	}
+
+	s.stored = stored
+	s.loadedAt = s.now()
+	return nil
+}
+
+// apply logs instead of failing: the value is already stored, and a value
+// that no longer applies (say, a currency whose rate was removed) should
+// not keep the rest of the settings from loading.
+func (s *SettingsService) apply(key string, def setting, value json.RawMessage) {
+	if def.apply == nil {
+		return
+	}
+	if err := def.apply(value); err != nil {
+		log.Printf("Error applying setting %s: %v", key, err)
+	}
+}
+
+func newSettingResponse(key string, def setting, stored map[string]models.Setting) models.SettingResponse {
+	response := models.SettingResponse{
+		Key:         key,
+		Value:       def.defaultValue,
+		Default:     def.defaultValue,
+		Description: def.description,
+		Public:      def.public,
+	}
+	if setting, ok := stored[key]; ok {
+		updatedAt := setting.UpdatedAt
+		response.Value = json.RawMessage(setting.Value)
+		response.UpdatedAt = &updatedAt
+	}
+	return response
+}
+
+func checkBool(key string, value json.RawMessage) error {
+	var b bool
+	if json.Unmarshal(value, &b) != nil {
+		return i18n.NewError(msgSettingNotBool, map[string]any{"Key": key})
+	}
+	return nil
+}
+
+func checkNonNegativeInt(key string, value json.RawMessage) error {
+	var n int64
+	if json.Unmarshal(value, &n) != nil || n < 0 {
+		return i18n.NewError(msgSettingNotNonNegativeInt, map[string]any{"Key": key})
+	}
+	return nil
+}
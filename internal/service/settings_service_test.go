@@ -0,0 +1,143 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/currency"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSettingsService(t *testing.T) (*SettingsService, *MaintenanceService, *currency.Converter, repository.SettingRepositoryInterface) {
+	t.Helper()
+
+	rates, err := currency.ParseStaticRates("BRL", "USD=0.2")
+	require.NoError(t, err)
+	converter := currency.NewConverter("BRL", rates)
+	maintenance := NewMaintenanceService(false, false, time.Minute)
+	repo := repository.NewSettingRepository(setupTestDB(t))
+	return NewSettingsService(repo, maintenance, converter), maintenance, converter, repo
+}
+
+func TestSettingsService_Set(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		value         string
+		expectedValue string
+		expectedError string
+	}{
+		{name: "free delivery threshold", key: SettingFreeDeliveryThreshold, value: "5000", expectedValue: "5000"},
+		{name: "feature flag", key: "feature.gift_wrap", value: " true ", expectedValue: "true"},
+		{name: "default currency", key: SettingDefaultCurrency, value: `"usd"`, expectedValue: `"usd"`},
+		{name: "unknown key", key: "colour", value: "1", expectedError: "setting not found"},
+		{name: "invalid flag name", key: "feature.Gift Wrap", value: "true", expectedError: "setting not found"},
+		{name: "missing value", key: SettingFreeDeliveryThreshold, value: "", expectedError: "value is required"},
+		{name: "null value", key: SettingFreeDeliveryThreshold, value: "null", expectedError: "value is required"},
+		{name: "negative threshold", key: SettingFreeDeliveryThreshold, value: "-1", expectedError: "free_delivery_threshold_cents must be a whole number of zero or more"},
+		{name: "fractional threshold", key: SettingFreeDeliveryThreshold, value: "10.5", expectedError: "free_delivery_threshold_cents must be a whole number of zero or more"},
+		{name: "flag that is not a bool", key: "feature.gift_wrap", value: `"yes"`, expectedError: "feature.gift_wrap must be true or false"},
+		{name: "currency that is not a string", key: SettingDefaultCurrency, value: "1", expectedError: "default_currency must be a string"},
+		{name: "unsupported currency", key: SettingDefaultCurrency, value: `"JPY"`, expectedError: "unsupported currency: JPY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _, _ := newTestSettingsService(t)
+
+			setting, err := svc.Set(tt.key, &models.UpdateSettingRequest{Value: json.RawMessage(tt.value)})
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expectedValue, string(setting.Value))
+			require.NotNil(t, setting.UpdatedAt)
+
+			fetched, err := svc.Get(tt.key)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expectedValue, string(fetched.Value))
+		})
+	}
+}
+
+func TestSettingsService_Apply(t *testing.T) {
+	svc, maintenance, converter, _ := newTestSettingsService(t)
+
+	_, err := svc.Set(SettingMaintenanceMode, &models.UpdateSettingRequest{Value: json.RawMessage("true")})
+	require.NoError(t, err)
+	require.True(t, maintenance.Status().Enabled)
+
+	_, err = svc.Set(SettingDefaultCurrency, &models.UpdateSettingRequest{Value: json.RawMessage(`"USD"`)})
+	require.NoError(t, err)
+	require.Equal(t, "USD", converter.Default())
+
+	require.NoError(t, svc.Reset(SettingMaintenanceMode))
+	require.False(t, maintenance.Status().Enabled)
+	require.NoError(t, svc.Reset(SettingDefaultCurrency))
+	require.Equal(t, "BRL", converter.Default())
+
+	setting, err := svc.Get(SettingDefaultCurrency)
+	require.NoError(t, err)
+	require.JSONEq(t, `"BRL"`, string(setting.Value))
+	require.Nil(t, setting.UpdatedAt)
+}
+
+func TestSettingsService_Load(t *testing.T) {
+	_, _, _, repo := newTestSettingsService(t)
+	require.NoError(t, repo.Set(&models.Setting{Key: SettingMaintenanceMode, Value: "true"}))
+
+	// A new instance, as after a restart or on another server, picks up
+	// what was stored.
+	maintenance := NewMaintenanceService(false, false, time.Minute)
+	svc := NewSettingsService(repo, maintenance, nil)
+	require.NoError(t, svc.Load())
+	require.True(t, maintenance.Status().Enabled)
+
+	// Changes made elsewhere are applied once the cache expires.
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	require.NoError(t, svc.Load())
+	require.NoError(t, repo.Delete(SettingMaintenanceMode))
+
+	_, err := svc.List()
+	require.NoError(t, err)
+	require.True(t, maintenance.Status().Enabled)
+
+	now = now.Add(settingsCacheTTL)
+	_, err = svc.List()
+	require.NoError(t, err)
+	require.False(t, maintenance.Status().Enabled)
+}
+
+func TestSettingsService_ListAndPublicValues(t *testing.T) {
+	svc, _, _, _ := newTestSettingsService(t)
+
+	_, err := svc.Set("feature.gift_wrap", &models.UpdateSettingRequest{Value: json.RawMessage("true")})
+	require.NoError(t, err)
+
+	settings, err := svc.List()
+	require.NoError(t, err)
+	keys := make([]string, len(settings))
+	for i, setting := range settings {
+		keys[i] = setting.Key
+	}
+	require.Equal(t, []string{SettingDefaultCurrency, "feature.gift_wrap", SettingFreeDeliveryThreshold, SettingMaintenanceMode}, keys)
+
+	values, err := svc.PublicValues()
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+	require.NotContains(t, values, SettingMaintenanceMode)
+	require.JSONEq(t, "0", string(values[SettingFreeDeliveryThreshold]))
+
+	require.True(t, svc.Enabled("gift_wrap"))
+	require.False(t, svc.Enabled("express_delivery"))
+
+	require.NoError(t, svc.Reset("feature.gift_wrap"))
+	require.False(t, svc.Enabled("gift_wrap"))
+	require.ErrorIs(t, svc.Reset("colour"), ErrSettingNotFound)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+// SMSSender sends text messages through an SMS provider such as Twilio.
+type SMSSender interface {
+	// Send delivers body to the E.164 phone number to.
+	Send(ctx context.Context, to, body string) error
+}
+
+// smsSendTimeout bounds how long a notification waits on the provider.
+const smsSendTimeout = 10 * time.Second
+
+// smsTexts writes the SMS of each notification event that has one.
+var smsTexts = map[string]func(models.Notification) string{
+	models.EventPickupReady: func(n models.Notification) string {
+		event := n.(models.PickupReadyEvent)
+		return fmt.Sprintf("Hi %s, your order #%d is ready for pickup at %s.", event.CustomerName, event.ReservationID, event.LocationName)
+	},
+	models.EventPickupCancelled: func(n models.Notification) string {
+		event := n.(models.PickupCancelledEvent)
+		return fmt.Sprintf("Hi %s, your order #%d for pickup at %s was cancelled.", event.CustomerName, event.ReservationID, event.LocationName)
+	},
+}
+
+// SMSNotifier is the NotificationSender of the SMS channel. Notifications
+// without a phone number, or of events with no SMS text, are skipped.
+type SMSNotifier struct {
+	sms SMSSender
+}
+
+var _ NotificationSender = (*SMSNotifier)(nil)
+
+func NewSMSNotifier(sms SMSSender) *SMSNotifier {
+	return &SMSNotifier{sms: sms}
+}
+
+func (n *SMSNotifier) Send(event string, notification models.Notification) error {
+	text, ok := smsTexts[event]
+	phone, hasPhone := notification.(models.PhoneNotification)
+	if !ok || !hasPhone || phone.NotificationPhone() == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), smsSendTimeout)
+	defer cancel()
+	return n.sms.Send(ctx, phone.NotificationPhone(), text(notification))
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+type sentSMS struct {
+	to, body string
+}
+
+type recordingSMS struct {
+	sent []sentSMS
+}
+
+func (s *recordingSMS) Send(ctx context.Context, to, body string) error {
+	s.sent = append(s.sent, sentSMS{to: to, body: body})
+	return nil
+}
+
+func TestSMSNotifier(t *testing.T) {
+	sms := &recordingSMS{}
+	notifier := NewSMSNotifier(sms)
+
+	event := models.PickupReadyEvent{ReservationID: 12, CustomerName: "Ana", CustomerEmail: "ana@example.com", CustomerPhone: "+5511912345678", LocationName: "Centro"}
+	require.NoError(t, notifier.Send(models.EventPickupReady, event))
+	require.Equal(t, []sentSMS{{to: "+5511912345678", body: "Hi Ana, your order #12 is ready for pickup at Centro."}}, sms.sent)
+
+	// Without a phone, or without a text for the event, nothing is sent.
+	event.CustomerPhone = ""
+	require.NoError(t, notifier.Send(models.EventPickupReady, event))
+	require.NoError(t, notifier.Send(models.EventTicketStatusChanged, models.TicketStatusEvent{CustomerEmail: "ana@example.com"}))
+	require.Len(t, sms.sent, 1)
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	DefaultTopCupcakesDays  = 30
+	DefaultTopCupcakesLimit = 10
+	maxTopCupcakesDays      = 366
+	maxTopCupcakesLimit     = 50
+)
+
+// StatsService reports on sales. Pickup reservations are the store's only
+// orders, so sales are the cupcakes reserved for pickup.
+type StatsService struct {
+	pickups     repository.PickupRepositoryInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+}
+
+var _ StatsServiceInterface = (*StatsService)(nil)
+
+func NewStatsService(pickups repository.PickupRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface) *StatsService {
+	return &StatsService{pickups: pickups, cupcakeRepo: cupcakeRepo}
+}
+
+// TopCupcakes ranks the cupcakes reserved for pickup in slots on the days
+// from from to to, by units and then by ID, keeping the first limit. With
+// a flavor, only cupcakes of that flavor are ranked.
+func (s *StatsService) TopCupcakes(from, to time.Time, flavor string, limit int) (*models.TopCupcakes, error) {
+	start, _ := dayBounds(from)
+	last, end := dayBounds(to)
+	if last.Before(start) {
+		return nil, i18n.NewError(msgDateRangeInvalid, nil)
+	}
+	if start.AddDate(0, 0, maxTopCupcakesDays).Before(end) {
+		return nil, i18n.NewError(msgDateRangeTooLong, map[string]any{"Max": maxTopCupcakesDays})
+	}
+	if limit < 1 || limit > maxTopCupcakesLimit {
+		return nil, i18n.NewError(msgLimitOutOfRange, map[string]any{"Max": maxTopCupcakesLimit})
+	}
+
+	reservations, err := s.pickups.FindReservationsBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+	sales := make(map[uint]*models.CupcakeSales)
+	for _, reservation := range reservations {
+		for _, item := range reservation.Items {
+			line := sales[item.CupcakeID]
+			if line == nil {
+				line = &models.CupcakeSales{CupcakeID: item.CupcakeID}
+				sales[item.CupcakeID] = line
+			}
+			line.Quantity += item.Quantity
+			line.Orders++
+		}
+	}
+
+	flavor = strings.TrimSpace(flavor)
+	ranked := make([]models.CupcakeSales, 0, len(sales))
+	for cupcakeID, line := range sales {
+		cupcake, err := s.cupcakeRepo.FindByID(cupcakeID)
+		switch {
+		case err == nil:
+			line.Name = cupcake.Name
+			line.Flavor = cupcake.Flavor
+		case !errors.Is(err, repository.ErrNotFound):
+			return nil, err
+		}
+		if flavor != "" && !strings.EqualFold(line.Flavor, flavor) {
+			continue
+		}
+		ranked = append(ranked, *line)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Quantity != ranked[j].Quantity {
+			return ranked[i].Quantity > ranked[j].Quantity
+		}
+		return ranked[i].CupcakeID < ranked[j].CupcakeID
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return &models.TopCupcakes{
+		From:     start.Format(time.DateOnly),
+		To:       last.Format(time.DateOnly),
+		Cupcakes: ranked,
+	}, nil
+}
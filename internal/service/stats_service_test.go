@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopCupcakes(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla"), factory.WithFlavor("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate"), factory.WithFlavor("Chocolate")),
+		factory.Cupcake(factory.WithName("Double Chocolate"), factory.WithFlavor("Chocolate")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	pickupRepo := repository.NewPickupRepository(db)
+	first := &models.PickupSlot{LocationID: 1, StartsAt: day.Add(10 * time.Hour), EndsAt: day.Add(11 * time.Hour), Capacity: 5}
+	second := &models.PickupSlot{LocationID: 1, StartsAt: day.AddDate(0, 0, 1).Add(10 * time.Hour), EndsAt: day.AddDate(0, 0, 1).Add(11 * time.Hour), Capacity: 5}
+	later := &models.PickupSlot{LocationID: 1, StartsAt: day.AddDate(0, 0, 10).Add(10 * time.Hour), EndsAt: day.AddDate(0, 0, 10).Add(11 * time.Hour), Capacity: 5}
+	for _, slot := range []*models.PickupSlot{first, second, later} {
+		require.NoError(t, pickupRepo.CreateSlot(slot))
+	}
+	for _, reservation := range []models.PickupReservation{
+		{SlotID: first.ID, CustomerName: "Ana", CustomerEmail: "ana@example.com", Items: []models.PickupReservationItem{{CupcakeID: 1, Quantity: 2}, {CupcakeID: 2, Quantity: 3}}},
+		{SlotID: second.ID, CustomerName: "Bia", CustomerEmail: "bia@example.com", Items: []models.PickupReservationItem{{CupcakeID: 3, Quantity: 3}, {CupcakeID: 1, Quantity: 1}}},
+		{SlotID: later.ID, CustomerName: "Caio", CustomerEmail: "caio@example.com", Items: []models.PickupReservationItem{{CupcakeID: 1, Quantity: 12}}},
+	} {
+		require.NoError(t, pickupRepo.Reserve(&reservation))
+	}
+
+	svc := NewStatsService(pickupRepo, cupcakeRepo)
+
+	top, err := svc.TopCupcakes(day, day.AddDate(0, 0, 1), "", DefaultTopCupcakesLimit)
+	require.NoError(t, err)
+	require.Equal(t, &models.TopCupcakes{
+		From: "2026-10-01",
+		To:   "2026-10-02",
+		Cupcakes: []models.CupcakeSales{
+			{CupcakeID: 1, Name: "Vanilla", Flavor: "Vanilla", Quantity: 3, Orders: 2},
+			{CupcakeID: 2, Name: "Chocolate", Flavor: "Chocolate", Quantity: 3, Orders: 1},
+			{CupcakeID: 3, Name: "Double Chocolate", Flavor: "Chocolate", Quantity: 3, Orders: 1},
+		},
+	}, top)
+
+	top, err = svc.TopCupcakes(day, day.AddDate(0, 0, 30), "chocolate", 1)
+	require.NoError(t, err)
+	require.Equal(t, []models.CupcakeSales{{CupcakeID: 2, Name: "Chocolate", Flavor: "Chocolate", Quantity: 3, Orders: 1}}, top.Cupcakes)
+
+	top, err = svc.TopCupcakes(day.AddDate(0, 0, 20), day.AddDate(0, 0, 25), "", DefaultTopCupcakesLimit)
+	require.NoError(t, err)
+	require.Empty(t, top.Cupcakes)
+
+	_, err = svc.TopCupcakes(day.AddDate(0, 0, 1), day, "", DefaultTopCupcakesLimit)
+	require.EqualError(t, err, "to cannot be before from")
+	_, err = svc.TopCupcakes(day, day.AddDate(1, 0, 1), "", DefaultTopCupcakesLimit)
+	require.EqualError(t, err, "the date range must be at most 366 days")
+	_, err = svc.TopCupcakes(day, day, "", 0)
+	require.EqualError(t, err, "limit must be between 1 and 50")
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var ErrStockAlertNotFound = errors.New("alert not found")
+
+// StockAlertService raises an alert whenever a location's stock of a
+// cupcake falls to its reorder threshold, and publishes it as a stock.low
+// event for webhooks and the broker to pass on. Admins acknowledge alerts
+// and resolve them; an alert still unresolved when the stock is back above
+// the threshold is resolved by the next check.
+type StockAlertService struct {
+	repo      repository.StockAlertRepositoryInterface
+	locations repository.LocationRepositoryInterface
+	cupcakes  repository.CupcakeRepositoryInterface
+	events    EventPublisher
+	now       func() time.Time
+}
+
+var _ StockAlertServiceInterface = (*StockAlertService)(nil)
+
+func NewStockAlertService(repo repository.StockAlertRepositoryInterface, locations repository.LocationRepositoryInterface, cupcakes repository.CupcakeRepositoryInterface, events EventPublisher) *StockAlertService {
+	return &StockAlertService{repo: repo, locations: locations, cupcakes: cupcakes, events: events, now: time.Now}
+}
+
+// CheckStock compares every stock row with its threshold and returns how
+// many alerts it raised. A row gets a new alert when it has none, or when
+// its stock was set again since its last alert was resolved, so resolving
+// an alert by hand silences it until the next stock count.
+func (s *StockAlertService) CheckStock() (int, error) {
+	low, err := s.locations.FindLowStock()
+	if err != nil {
+		return 0, err
+	}
+	isLow := make(map[[2]uint]bool, len(low))
+	for _, stock := range low {
+		isLow[[2]uint{stock.LocationID, stock.CupcakeID}] = true
+	}
+
+	unresolved, err := s.repo.FindUnresolved()
+	if err != nil {
+		return 0, err
+	}
+	now := s.now()
+	for i := range unresolved {
+		alert := &unresolved[i]
+		if isLow[[2]uint{alert.LocationID, alert.CupcakeID}] {
+			continue
+		}
+		alert.Status = models.StockAlertResolved
+		alert.ResolvedAt = &now
+		if err := s.repo.Update(alert); err != nil {
+			return 0, err
+		}
+	}
+
+	raised := 0
+	for _, stock := range low {
+		latest, err := s.repo.FindLatest(stock.LocationID, stock.CupcakeID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return raised, err
+		}
+		if latest != nil && (latest.Status != models.StockAlertResolved || !latest.CreatedAt.Before(stock.UpdatedAt)) {
+			continue
+		}
+
+		// Stock left behind by a deleted cupcake is not worth restocking.
+		cupcake, err := s.cupcakes.FindByID(stock.CupcakeID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return raised, err
+		}
+		location, err := s.locations.FindByID(stock.LocationID)
+		if err != nil {
+			return raised, err
+		}
+
+		alert := &models.StockAlert{
+			LocationID: stock.LocationID,
+			CupcakeID:  stock.CupcakeID,
+			Quantity:   stock.Quantity,
+			Threshold:  stock.ReorderThreshold,
+			Status:     models.StockAlertOpen,
+		}
+		if err := s.repo.Create(alert); err != nil {
+			return raised, err
+		}
+		raised++
+
+		if s.events != nil {
+			s.events.Publish(models.EventStockLow, models.StockLowEvent{
+				AlertID:      alert.ID,
+				LocationID:   location.ID,
+				LocationName: location.Name,
+				CupcakeID:    cupcake.ID,
+				CupcakeName:  cupcake.Name,
+				Quantity:     alert.Quantity,
+				Threshold:    alert.Threshold,
+			})
+		}
+	}
+	return raised, nil
+}
+
+// GetAlerts lists the alerts with status, or all of them when it is
+// empty, newest first.
+func (s *StockAlertService) GetAlerts(status string) ([]models.StockAlert, error) {
+	if status != "" && !validStockAlertStatus(status) {
+		return nil, i18n.NewError(msgStockAlertStatusInvalid, nil)
+	}
+	return s.repo.FindAll(status)
+}
+
+// AcknowledgeAlert records that an admin has seen an open alert.
+// Acknowledging it again changes nothing.
+func (s *StockAlertService) AcknowledgeAlert(id uint) (*models.StockAlert, error) {
+	alert, err := s.findAlert(id)
+	if err != nil {
+		return nil, err
+	}
+	switch alert.Status {
+	case models.StockAlertAcknowledged:
+		return alert, nil
+	case models.StockAlertResolved:
+		return nil, i18n.NewError(msgStockAlertResolved, nil)
+	}
+
+	now := s.now()
+	alert.Status = models.StockAlertAcknowledged
+	alert.AcknowledgedAt = &now
+	if err := s.repo.Update(alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+// ResolveAlert closes an alert, for instance once a restock is ordered.
+// Resolving it again changes nothing.
+func (s *StockAlertService) ResolveAlert(id uint) (*models.StockAlert, error) {
+	alert, err := s.findAlert(id)
+	if err != nil {
+		return nil, err
+	}
+	if alert.Status == models.StockAlertResolved {
+		return alert, nil
+	}
+
+	now := s.now()
+	alert.Status = models.StockAlertResolved
+	alert.ResolvedAt = &now
+	if err := s.repo.Update(alert); err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (s *StockAlertService) findAlert(id uint) (*models.StockAlert, error) {
+	alert, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrStockAlertNotFound
+	}
+	return alert, err
+}
+
+func validStockAlertStatus(status string) bool {
+	switch status {
+	case models.StockAlertOpen, models.StockAlertAcknowledged, models.StockAlertResolved:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockAlertService_CheckStock(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakes := repository.NewCupcakeRepository(db)
+	locations := repository.NewLocationRepository(db)
+	vanilla := factory.Cupcake(factory.WithName("Vanilla"))
+	require.NoError(t, cupcakes.Create(&vanilla))
+	lemon := factory.Cupcake(factory.WithName("Lemon"))
+	require.NoError(t, cupcakes.Create(&lemon))
+	location := &models.Location{Name: "Centro", Address: "Rua Augusta, 100"}
+	require.NoError(t, locations.Create(location))
+
+	var published []models.StockLowEvent
+	events := &mocks.EventPublisher{PublishFunc: func(event string, data interface{}) {
+		require.Equal(t, models.EventStockLow, event)
+		published = append(published, data.(models.StockLowEvent))
+	}}
+	svc := NewStockAlertService(repository.NewStockAlertRepository(db), locations, cupcakes, events)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	setStock := func(cupcakeID uint, quantity, threshold int) {
+		require.NoError(t, locations.SetStock(&models.LocationStock{LocationID: location.ID, CupcakeID: cupcakeID, Quantity: quantity, ReorderThreshold: threshold}))
+	}
+
+	setStock(vanilla.ID, 3, 5)
+	setStock(lemon.ID, 20, 5)
+	raised, err := svc.CheckStock()
+	require.NoError(t, err)
+	require.Equal(t, 1, raised)
+	require.Equal(t, []models.StockLowEvent{{
+		AlertID: 1, LocationID: location.ID, LocationName: "Centro",
+		CupcakeID: vanilla.ID, CupcakeName: "Vanilla", Quantity: 3, Threshold: 5,
+	}}, published)
+
+	// An alert already raised is not raised again.
+	raised, err = svc.CheckStock()
+	require.NoError(t, err)
+	require.Zero(t, raised)
+
+	// Resolving it by hand silences it until the stock is set again.
+	_, err = svc.ResolveAlert(1)
+	require.NoError(t, err)
+	raised, err = svc.CheckStock()
+	require.NoError(t, err)
+	require.Zero(t, raised)
+	require.NoError(t, db.Model(&models.StockAlert{}).Where("id = ?", 1).Update("created_at", now.Add(-time.Hour)).Error)
+	setStock(vanilla.ID, 2, 5)
+	raised, err = svc.CheckStock()
+	require.NoError(t, err)
+	require.Equal(t, 1, raised)
+
+	// Restocking resolves the open alert on the next check.
+	setStock(vanilla.ID, 30, 5)
+	raised, err = svc.CheckStock()
+	require.NoError(t, err)
+	require.Zero(t, raised)
+	alerts, err := svc.GetAlerts(models.StockAlertResolved)
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	require.True(t, now.Equal(*alerts[0].ResolvedAt))
+
+	// A cupcake deleted from the catalog is not worth an alert.
+	setStock(lemon.ID, 1, 5)
+	require.NoError(t, cupcakes.Delete(lemon.ID))
+	raised, err = svc.CheckStock()
+	require.NoError(t, err)
+	require.Zero(t, raised)
+	require.Len(t, published, 2)
+}
+
+func TestStockAlertService_Acknowledge(t *testing.T) {
+	db := setupTestDB(t)
+	repo := repository.NewStockAlertRepository(db)
+	svc := NewStockAlertService(repo, repository.NewLocationRepository(db), repository.NewCupcakeRepository(db), nil)
+	alert := &models.StockAlert{LocationID: 1, CupcakeID: 1, Quantity: 2, Threshold: 5, Status: models.StockAlertOpen}
+	require.NoError(t, repo.Create(alert))
+
+	acknowledged, err := svc.AcknowledgeAlert(alert.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.StockAlertAcknowledged, acknowledged.Status)
+	require.NotNil(t, acknowledged.AcknowledgedAt)
+
+	open, err := svc.GetAlerts(models.StockAlertOpen)
+	require.NoError(t, err)
+	require.Empty(t, open)
+
+	resolved, err := svc.ResolveAlert(alert.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.StockAlertResolved, resolved.Status)
+
+	_, err = svc.AcknowledgeAlert(alert.ID)
+	require.EqualError(t, err, "the alert is already resolved")
+	_, err = svc.ResolveAlert(99)
+	require.ErrorIs(t, err, ErrStockAlertNotFound)
+	_, err = svc.GetAlerts("closed")
+	require.EqualError(t, err, "status must be open, acknowledged or resolved")
+}
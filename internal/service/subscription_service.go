@@ -0,0 +1,200 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+// ErrSubscriptionNotFound is returned for subscriptions that do not exist
+// and for those the caller does not own, so IDs cannot be probed.
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+type SubscriptionService struct {
+	repo        repository.SubscriptionRepositoryInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+	now         func() time.Time
+}
+
+var _ SubscriptionServiceInterface = (*SubscriptionService)(nil)
+
+func NewSubscriptionService(repo repository.SubscriptionRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface) *SubscriptionService {
+	return &SubscriptionService{repo: repo, cupcakeRepo: cupcakeRepo, now: time.Now}
+}
+
+// Subscribe returns the subscription with the token that manages it; it is
+// not shown again.
+func (s *SubscriptionService) Subscribe(req *models.CreateSubscriptionRequest) (*models.CreatedSubscription, error) {
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.cupcakeRepo.Exists(req.CupcakeID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, i18n.NewError(msgCupcakeNotFound, nil)
+	}
+
+	nextDelivery := nextDeliveryDate(s.now(), req.Frequency)
+	if req.FirstDeliveryAt != nil {
+		if !req.FirstDeliveryAt.After(s.now()) {
+			return nil, i18n.NewError(msgFirstDeliveryInPast, nil)
+		}
+		nextDelivery = *req.FirstDeliveryAt
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	subscription := &models.Subscription{
+		CustomerEmail:       strings.TrimSpace(req.CustomerEmail),
+		ManagementTokenHash: hashManagementToken(token),
+		CupcakeID:           req.CupcakeID,
+		Quantity:            req.Quantity,
+		Frequency:           req.Frequency,
+		Status:              models.SubscriptionActive,
+		NextDeliveryAt:      nextDelivery,
+	}
+
+	if err := s.repo.Create(subscription); err != nil {
+		return nil, err
+	}
+
+	return &models.CreatedSubscription{Subscription: *subscription, ManagementToken: token}, nil
+}
+
+// Authorize returns subscription id if the caller owns it: they hold its
+// management token, or account is signed in with the subscription's email,
+// confirmed. Anyone else gets ErrSubscriptionNotFound.
+func (s *SubscriptionService) Authorize(id uint, managementToken string, account *models.Account) (*models.Subscription, error) {
+	subscription, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case managementToken != "" && subtle.ConstantTimeCompare([]byte(hashManagementToken(managementToken)), []byte(subscription.ManagementTokenHash)) == 1:
+	case account != nil && account.EmailVerifiedAt != nil && strings.EqualFold(account.Email, subscription.CustomerEmail):
+	default:
+		return nil, ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+func (s *SubscriptionService) GetSubscription(id uint) (*models.Subscription, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *SubscriptionService) GetAllSubscriptions() ([]models.Subscription, error) {
+	return s.repo.FindAll()
+}
+
+func (s *SubscriptionService) Pause(id uint) (*models.Subscription, error) {
+	return s.transition(id, models.SubscriptionActive, models.SubscriptionPaused)
+}
+
+func (s *SubscriptionService) Resume(id uint) (*models.Subscription, error) {
+	subscription, err := s.transition(id, models.SubscriptionPaused, models.SubscriptionActive)
+	if err != nil {
+		return nil, err
+	}
+
+	for !subscription.NextDeliveryAt.After(s.now()) {
+		subscription.NextDeliveryAt = nextDeliveryDate(subscription.NextDeliveryAt, subscription.Frequency)
+	}
+
+	if err := s.repo.Update(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *SubscriptionService) Cancel(id uint) (*models.Subscription, error) {
+	subscription, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Status == models.SubscriptionCancelled {
+		return nil, i18n.NewError(msgSubscriptionCancelled, nil)
+	}
+
+	subscription.Status = models.SubscriptionCancelled
+	if err := s.repo.Update(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *SubscriptionService) transition(id uint, from, to string) (*models.Subscription, error) {
+	subscription, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Status != from {
+		return nil, i18n.NewError(msgSubscriptionNotInStatus, map[string]any{"Status": from})
+	}
+
+	subscription.Status = to
+	if err := s.repo.Update(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *SubscriptionService) validateCreateRequest(req *models.CreateSubscriptionRequest) error {
+	if strings.TrimSpace(req.CustomerEmail) == "" {
+		return i18n.NewError(msgCustomerEmailRequired, nil)
+	}
+
+	if _, err := mail.ParseAddress(req.CustomerEmail); err != nil {
+		return i18n.NewError(msgCustomerEmailInvalid, nil)
+	}
+
+	if req.Quantity <= 0 {
+		return i18n.NewError(msgQuantityNotPositive, nil)
+	}
+
+	switch req.Frequency {
+	case models.FrequencyWeekly, models.FrequencyBiweekly, models.FrequencyMonthly:
+	default:
+		return i18n.NewError(msgFrequencyInvalid, nil)
+	}
+
+	return nil
+}
+
+func hashManagementToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func nextDeliveryDate(from time.Time, frequency string) time.Time {
+	switch frequency {
+	case models.FrequencyBiweekly:
+		return from.AddDate(0, 0, 14)
+	case models.FrequencyMonthly:
+		return from.AddDate(0, 1, 0)
+	default:
+		return from.AddDate(0, 0, 7)
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubscriptionService(t *testing.T) (*SubscriptionService, uint) {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	cupcake := &models.Cupcake{Name: "Box Cupcake", Flavor: "Vanilla", PriceCents: 900, IsAvailable: true}
+	require.NoError(t, cupcakeRepo.Create(cupcake))
+
+	return NewSubscriptionService(repository.NewSubscriptionRepository(db), cupcakeRepo), cupcake.ID
+}
+
+func TestSubscribe(t *testing.T) {
+	now := time.Date(2025, time.March, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		request          *models.CreateSubscriptionRequest
+		expectedError    string
+		expectedDelivery time.Time
+	}{
+		{
+			name:             "weekly subscription starts next week",
+			request:          &models.CreateSubscriptionRequest{CustomerEmail: "ana@example.com", Quantity: 6, Frequency: models.FrequencyWeekly},
+			expectedDelivery: now.AddDate(0, 0, 7),
+		},
+		{
+			name:             "monthly subscription starts next month",
+			request:          &models.CreateSubscriptionRequest{CustomerEmail: "ana@example.com", Quantity: 6, Frequency: models.FrequencyMonthly},
+			expectedDelivery: now.AddDate(0, 1, 0),
+		},
+		{
+			name:          "validation error - invalid email",
+			request:       &models.CreateSubscriptionRequest{CustomerEmail: "not-an-email", Quantity: 6, Frequency: models.FrequencyWeekly},
+			expectedError: "customer email is invalid",
+		},
+		{
+			name:          "validation error - zero quantity",
+			request:       &models.CreateSubscriptionRequest{CustomerEmail: "ana@example.com", Quantity: 0, Frequency: models.FrequencyWeekly},
+			expectedError: "quantity must be greater than zero",
+		},
+		{
+			name:          "validation error - unknown frequency",
+			request:       &models.CreateSubscriptionRequest{CustomerEmail: "ana@example.com", Quantity: 6, Frequency: "daily"},
+			expectedError: "frequency must be weekly, biweekly or monthly",
+		},
+		{
+			name:          "validation error - unknown cupcake",
+			request:       &models.CreateSubscriptionRequest{CustomerEmail: "ana@example.com", CupcakeID: 999, Quantity: 6, Frequency: models.FrequencyWeekly},
+			expectedError: "cupcake not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, cupcakeID := newTestSubscriptionService(t)
+			service.now = func() time.Time { return now }
+
+			if tt.request.CupcakeID == 0 {
+				tt.request.CupcakeID = cupcakeID
+			}
+
+			subscription, err := service.Subscribe(tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, subscription)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, models.SubscriptionActive, subscription.Status)
+				require.True(t, tt.expectedDelivery.Equal(subscription.NextDeliveryAt))
+			}
+		})
+	}
+}
+
+func TestSubscriptionLifecycle(t *testing.T) {
+	service, cupcakeID := newTestSubscriptionService(t)
+
+	subscription, err := service.Subscribe(&models.CreateSubscriptionRequest{
+		CustomerEmail: "ana@example.com",
+		CupcakeID:     cupcakeID,
+		Quantity:      12,
+		Frequency:     models.FrequencyBiweekly,
+	})
+	require.NoError(t, err)
+
+	_, err = service.Resume(subscription.ID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "subscription is not paused")
+
+	paused, err := service.Pause(subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.SubscriptionPaused, paused.Status)
+
+	resumed, err := service.Resume(subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.SubscriptionActive, resumed.Status)
+
+	cancelled, err := service.Cancel(subscription.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.SubscriptionCancelled, cancelled.Status)
+
+	_, err = service.Cancel(subscription.ID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "subscription is already cancelled")
+}
+
+func TestSubscriptionAuthorize(t *testing.T) {
+	service, cupcakeID := newTestSubscriptionService(t)
+
+	created, err := service.Subscribe(&models.CreateSubscriptionRequest{CustomerEmail: "ana@example.com", CupcakeID: cupcakeID, Quantity: 6, Frequency: models.FrequencyWeekly})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ManagementToken)
+	require.NotEqual(t, created.ManagementToken, created.ManagementTokenHash)
+
+	subscription, err := service.Authorize(created.ID, created.ManagementToken, nil)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, subscription.ID)
+
+	_, err = service.Authorize(created.ID, "guess", nil)
+	require.ErrorIs(t, err, ErrSubscriptionNotFound)
+	_, err = service.Authorize(999, created.ManagementToken, nil)
+	require.ErrorIs(t, err, ErrSubscriptionNotFound)
+
+	// Accounts only count once their email is confirmed.
+	account := &models.Account{Email: "ANA@example.com"}
+	_, err = service.Authorize(created.ID, "", account)
+	require.ErrorIs(t, err, ErrSubscriptionNotFound)
+	verified := time.Now()
+	account.EmailVerifiedAt = &verified
+	_, err = service.Authorize(created.ID, "", account)
+	require.NoError(t, err)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	DefaultSyncLimit = 500
+	MaxSyncLimit     = 1000
+)
+
+// SyncService serves catalog deltas to offline clients such as a
+// point-of-sale terminal.
+type SyncService struct {
+	cupcakeRepo repository.CupcakeRepositoryInterface
+}
+
+var _ SyncServiceInterface = (*SyncService)(nil)
+
+func NewSyncService(cupcakeRepo repository.CupcakeRepositoryInterface) *SyncService {
+	return &SyncService{cupcakeRepo: cupcakeRepo}
+}
+
+// SyncCupcakes returns what changed after the cursor since. A zero cursor
+// asks for the whole catalog, which also covers cupcakes written before the
+// change log existed. Otherwise up to limit log entries are read and folded
+// into one upsert or tombstone per cupcake; clients keep calling with the
+// returned cursor while HasMore is set.
+func (s *SyncService) SyncCupcakes(since uint, limit int) (*models.CupcakeSync, error) {
+	if limit < 1 || limit > MaxSyncLimit {
+		return nil, i18n.NewError(msgLimitOutOfRange, map[string]any{"Max": MaxSyncLimit})
+	}
+
+	if since == 0 {
+		// Read the cursor first: a write racing with FindAll is then sent
+		// again on the next sync instead of being skipped.
+		cursor, err := s.cupcakeRepo.LastChangeID()
+		if err != nil {
+			return nil, err
+		}
+		cupcakes, err := s.cupcakeRepo.FindAll()
+		if err != nil {
+			return nil, err
+		}
+		return &models.CupcakeSync{Cursor: cursor, Upserted: cupcakes, Deleted: []uint{}}, nil
+	}
+
+	changes, err := s.cupcakeRepo.FindChanges(since, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	sync := &models.CupcakeSync{
+		Cursor:   since,
+		HasMore:  len(changes) == limit,
+		Upserted: []models.Cupcake{},
+		Deleted:  []uint{},
+	}
+
+	// Only the latest change of each cupcake matters.
+	latest := make(map[uint]bool, len(changes))
+	var order []uint
+	for _, change := range changes {
+		if _, seen := latest[change.CupcakeID]; !seen {
+			order = append(order, change.CupcakeID)
+		}
+		latest[change.CupcakeID] = change.Deleted
+		sync.Cursor = change.ID
+	}
+
+	for _, id := range order {
+		if latest[id] {
+			sync.Deleted = append(sync.Deleted, id)
+			continue
+		}
+		cupcake, err := s.cupcakeRepo.FindByID(id)
+		if err != nil {
+			// Deleted by a change past this page: a tombstone now is
+			// equivalent, and the later change repeats it.
+			if errors.Is(err, repository.ErrNotFound) {
+				sync.Deleted = append(sync.Deleted, id)
+				continue
+			}
+			return nil, err
+		}
+		sync.Upserted = append(sync.Upserted, *cupcake)
+	}
+
+	return sync, nil
+}
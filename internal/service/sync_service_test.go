@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCupcakes(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	svc := NewSyncService(cupcakeRepo)
+
+	vanilla := factory.Cupcake(factory.WithName("Vanilla"))
+	chocolate := factory.Cupcake(factory.WithName("Chocolate"))
+	require.NoError(t, cupcakeRepo.Create(&vanilla))
+	require.NoError(t, cupcakeRepo.Create(&chocolate))
+
+	full, err := svc.SyncCupcakes(0, DefaultSyncLimit)
+	require.NoError(t, err)
+	require.Len(t, full.Upserted, 2)
+	require.Empty(t, full.Deleted)
+	require.False(t, full.HasMore)
+
+	empty, err := svc.SyncCupcakes(full.Cursor, DefaultSyncLimit)
+	require.NoError(t, err)
+	require.Equal(t, full.Cursor, empty.Cursor)
+	require.Empty(t, empty.Upserted)
+	require.Empty(t, empty.Deleted)
+
+	vanilla.PriceCents = 900
+	require.NoError(t, cupcakeRepo.Update(&vanilla))
+	vanilla.PriceCents = 950
+	require.NoError(t, cupcakeRepo.Update(&vanilla))
+	require.NoError(t, cupcakeRepo.Delete(chocolate.ID))
+	strawberry := factory.Cupcake(factory.WithName("Strawberry"))
+	require.NoError(t, cupcakeRepo.Create(&strawberry))
+
+	delta, err := svc.SyncCupcakes(full.Cursor, DefaultSyncLimit)
+	require.NoError(t, err)
+	require.Len(t, delta.Upserted, 2)
+	require.Equal(t, "Vanilla", delta.Upserted[0].Name)
+	require.Equal(t, 950, delta.Upserted[0].PriceCents)
+	require.Equal(t, "Strawberry", delta.Upserted[1].Name)
+	require.Equal(t, []uint{chocolate.ID}, delta.Deleted)
+	require.Greater(t, delta.Cursor, full.Cursor)
+
+	// A page ending before the delete still reports the cupcake as gone.
+	require.NoError(t, cupcakeRepo.Update(&strawberry))
+	require.NoError(t, cupcakeRepo.Delete(strawberry.ID))
+	page, err := svc.SyncCupcakes(delta.Cursor, 1)
+	require.NoError(t, err)
+	require.True(t, page.HasMore)
+	require.Empty(t, page.Upserted)
+	require.Equal(t, []uint{strawberry.ID}, page.Deleted)
+
+	rest, err := svc.SyncCupcakes(page.Cursor, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint{strawberry.ID}, rest.Deleted)
+}
+
+func TestSyncCupcakesValidation(t *testing.T) {
+	svc := NewSyncService(repository.NewCupcakeRepository(setupTestDB(t)))
+
+	tests := []struct {
+		name  string
+		limit int
+	}{
+		{"zero limit", 0},
+		{"negative limit", -1},
+		{"limit too large", MaxSyncLimit + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SyncCupcakes(1, tt.limit)
+			require.EqualError(t, err, "limit must be between 1 and 1000")
+		})
+	}
+}
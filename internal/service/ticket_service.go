@@ -0,0 +1,200 @@
+package service
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/pii"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var (
+	ErrTicketNotFound = errors.New("ticket not found")
+	// ErrTicketOrderNotFound is returned both for orders that do not exist
+	// and for those placed with another email, so tickets cannot be used to
+	// find out whose orders are whose.
+	ErrTicketOrderNotFound = errors.New("order not found")
+)
+
+// TicketService handles customers' complaints and return requests about
+// their orders. Ordering does not need an account, so a ticket is opened
+// with the email the order was placed with. Admins assign tickets and move
+// them through open, investigating and resolved; each change of status is
+// published as a ticket.status_changed event, which tells the customer on
+// the channels they chose.
+type TicketService struct {
+	repo          repository.TicketRepositoryInterface
+	pickups       repository.PickupRepositoryInterface
+	subscriptions repository.SubscriptionRepositoryInterface
+	admins        repository.AdminRepositoryInterface
+	events        EventPublisher
+	now           func() time.Time
+}
+
+var _ TicketServiceInterface = (*TicketService)(nil)
+
+func NewTicketService(repo repository.TicketRepositoryInterface, pickups repository.PickupRepositoryInterface, subscriptions repository.SubscriptionRepositoryInterface, admins repository.AdminRepositoryInterface, events EventPublisher) *TicketService {
+	return &TicketService{repo: repo, pickups: pickups, subscriptions: subscriptions, admins: admins, events: events, now: time.Now}
+}
+
+// CreateTicket opens a ticket about an order placed with the request's
+// email.
+func (s *TicketService) CreateTicket(req *models.CreateTicketRequest) (*models.Ticket, error) {
+	if err := validateCreateTicketRequest(req); err != nil {
+		return nil, err
+	}
+
+	emailHash, err := s.orderEmailHash(req.OrderType, req.OrderID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrTicketOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if emailHash != pii.Hash(req.CustomerEmail) {
+		return nil, ErrTicketOrderNotFound
+	}
+
+	ticket := &models.Ticket{
+		OrderType:     req.OrderType,
+		OrderID:       req.OrderID,
+		CustomerEmail: strings.TrimSpace(req.CustomerEmail),
+		Subject:       strings.TrimSpace(req.Subject),
+		Message:       strings.TrimSpace(req.Message),
+		Status:        models.TicketOpen,
+	}
+	if err := s.repo.Create(ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+func (s *TicketService) GetTicket(id uint) (*models.Ticket, error) {
+	ticket, err := s.repo.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrTicketNotFound
+	}
+	return ticket, err
+}
+
+// GetTickets lists the tickets with status, or all of them when it is
+// empty.
+func (s *TicketService) GetTickets(status string) ([]models.Ticket, error) {
+	if status != "" && !validTicketStatus(status) {
+		return nil, i18n.NewError(msgTicketStatusInvalid, nil)
+	}
+	return s.repo.FindAll(status)
+}
+
+// UpdateTicket changes the ticket's status or assignee. The customer is
+// only notified when the status actually changes.
+func (s *TicketService) UpdateTicket(id uint, req *models.UpdateTicketRequest) (*models.Ticket, error) {
+	ticket, err := s.GetTicket(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AssigneeID != nil {
+		ticket.AssigneeID = nil
+		if *req.AssigneeID != 0 {
+			if _, err := s.admins.FindByID(*req.AssigneeID); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return nil, i18n.NewError(msgAssigneeNotFound, map[string]any{"ID": *req.AssigneeID})
+				}
+				return nil, err
+			}
+			ticket.AssigneeID = req.AssigneeID
+		}
+	}
+
+	previous := ticket.Status
+	if req.Status != nil {
+		if !validTicketStatus(*req.Status) {
+			return nil, i18n.NewError(msgTicketStatusInvalid, nil)
+		}
+		ticket.Status = *req.Status
+		if ticket.Status == models.TicketResolved && previous != models.TicketResolved {
+			now := s.now()
+			ticket.ResolvedAt = &now
+		} else if ticket.Status != models.TicketResolved {
+			ticket.ResolvedAt = nil
+		}
+	}
+
+	if err := s.repo.Update(ticket); err != nil {
+		return nil, err
+	}
+
+	if ticket.Status != previous && s.events != nil {
+		s.events.Publish(models.EventTicketStatusChanged, models.TicketStatusEvent{
+			TicketID:       ticket.ID,
+			CustomerEmail:  ticket.CustomerEmail,
+			OrderType:      ticket.OrderType,
+			OrderID:        ticket.OrderID,
+			Subject:        ticket.Subject,
+			Status:         ticket.Status,
+			PreviousStatus: previous,
+		})
+	}
+	return ticket, nil
+}
+
+func (s *TicketService) orderEmailHash(orderType string, orderID uint) (string, error) {
+	if orderType == models.TicketOrderSubscription {
+		subscription, err := s.subscriptions.FindByID(orderID)
+		if err != nil {
+			return "", err
+		}
+		return subscription.CustomerEmailHash, nil
+	}
+	reservation, err := s.pickups.FindReservation(orderID)
+	if err != nil {
+		return "", err
+	}
+	return reservation.CustomerEmailHash, nil
+}
+
+func validateCreateTicketRequest(req *models.CreateTicketRequest) error {
+	if strings.TrimSpace(req.CustomerEmail) == "" {
+		return i18n.NewError(msgCustomerEmailRequired, nil)
+	}
+	if _, err := mail.ParseAddress(req.CustomerEmail); err != nil {
+		return i18n.NewError(msgCustomerEmailInvalid, nil)
+	}
+
+	switch req.OrderType {
+	case models.TicketOrderPickup, models.TicketOrderSubscription:
+	default:
+		return i18n.NewError(msgOrderTypeInvalid, nil)
+	}
+
+	subject := strings.TrimSpace(req.Subject)
+	if subject == "" {
+		return i18n.NewError(msgSubjectRequired, nil)
+	}
+	if utf8.RuneCountInString(subject) > 200 {
+		return i18n.NewError(msgSubjectTooLong, nil)
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return i18n.NewError(msgMessageRequired, nil)
+	}
+	if utf8.RuneCountInString(message) > 5000 {
+		return i18n.NewError(msgMessageTooLong, nil)
+	}
+	return nil
+}
+
+func validTicketStatus(status string) bool {
+	switch status {
+	case models.TicketOpen, models.TicketInvestigating, models.TicketResolved:
+		return true
+	}
+	return false
+}
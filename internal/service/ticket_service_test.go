@@ -0,0 +1,145 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTicketService(t *testing.T, publisher EventPublisher) (*TicketService, *models.PickupReservation, *models.Admin) {
+	t.Helper()
+
+	db := setupTestDB(t)
+	reservation := &models.PickupReservation{SlotID: 1, CustomerName: "Ana Lima", CustomerEmail: "ana@example.com"}
+	require.NoError(t, db.Create(reservation).Error)
+	admin := &models.Admin{Name: "Bia", Email: "bia@example.com", PasswordHash: "x"}
+	require.NoError(t, db.Create(admin).Error)
+
+	svc := NewTicketService(repository.NewTicketRepository(db), repository.NewPickupRepository(db), repository.NewSubscriptionRepository(db), repository.NewAdminRepository(db), publisher)
+	return svc, reservation, admin
+}
+
+func TestTicketService_CreateTicket(t *testing.T) {
+	svc, reservation, _ := newTestTicketService(t, nil)
+	valid := func() *models.CreateTicketRequest {
+		return &models.CreateTicketRequest{
+			CustomerEmail: "ana@example.com",
+			OrderType:     models.TicketOrderPickup,
+			OrderID:       reservation.ID,
+			Subject:       "Wrong flavour",
+			Message:       "I got lemon instead of chocolate.",
+		}
+	}
+
+	tests := []struct {
+		name          string
+		modify        func(*models.CreateTicketRequest)
+		expectedError string
+	}{
+		{name: "invalid email", modify: func(r *models.CreateTicketRequest) { r.CustomerEmail = "ana" }, expectedError: "customer email is invalid"},
+		{name: "unknown order type", modify: func(r *models.CreateTicketRequest) { r.OrderType = "delivery" }, expectedError: "order type must be pickup_reservation or subscription"},
+		{name: "blank subject", modify: func(r *models.CreateTicketRequest) { r.Subject = "  " }, expectedError: "subject is required"},
+		{name: "blank message", modify: func(r *models.CreateTicketRequest) { r.Message = "" }, expectedError: "message is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+			_, err := svc.CreateTicket(req)
+			require.EqualError(t, err, tt.expectedError)
+		})
+	}
+
+	// An order placed with another email looks the same as a missing one.
+	req := valid()
+	req.CustomerEmail = "bruno@example.com"
+	_, err := svc.CreateTicket(req)
+	require.ErrorIs(t, err, ErrTicketOrderNotFound)
+	req = valid()
+	req.OrderID = 999
+	_, err = svc.CreateTicket(req)
+	require.ErrorIs(t, err, ErrTicketOrderNotFound)
+
+	req = valid()
+	req.CustomerEmail = " ANA@example.com "
+	ticket, err := svc.CreateTicket(req)
+	require.NoError(t, err)
+	require.Equal(t, models.TicketOpen, ticket.Status)
+	require.Equal(t, "ANA@example.com", ticket.CustomerEmail)
+	require.Nil(t, ticket.AssigneeID)
+}
+
+func TestTicketService_UpdateTicket(t *testing.T) {
+	var events []models.TicketStatusEvent
+	publisher := &mocks.EventPublisher{PublishFunc: func(event string, data interface{}) {
+		require.Equal(t, models.EventTicketStatusChanged, event)
+		events = append(events, data.(models.TicketStatusEvent))
+	}}
+	svc, reservation, admin := newTestTicketService(t, publisher)
+	now := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	ticket, err := svc.CreateTicket(&models.CreateTicketRequest{
+		CustomerEmail: "ana@example.com",
+		OrderType:     models.TicketOrderPickup,
+		OrderID:       reservation.ID,
+		Subject:       "Missing cupcake",
+		Message:       "One of the six was missing.",
+	})
+	require.NoError(t, err)
+
+	unknown := uint(999)
+	_, err = svc.UpdateTicket(ticket.ID, &models.UpdateTicketRequest{AssigneeID: &unknown})
+	require.EqualError(t, err, "admin 999 not found")
+	closed := "closed"
+	_, err = svc.UpdateTicket(ticket.ID, &models.UpdateTicketRequest{Status: &closed})
+	require.EqualError(t, err, "status must be open, investigating or resolved")
+	_, err = svc.UpdateTicket(999, &models.UpdateTicketRequest{})
+	require.ErrorIs(t, err, ErrTicketNotFound)
+
+	// Assigning alone does not notify the customer.
+	updated, err := svc.UpdateTicket(ticket.ID, &models.UpdateTicketRequest{AssigneeID: &admin.ID})
+	require.NoError(t, err)
+	require.Equal(t, admin.ID, *updated.AssigneeID)
+	require.Empty(t, events)
+
+	resolved := models.TicketResolved
+	updated, err = svc.UpdateTicket(ticket.ID, &models.UpdateTicketRequest{Status: &resolved})
+	require.NoError(t, err)
+	require.Equal(t, now, *updated.ResolvedAt)
+	require.Equal(t, []models.TicketStatusEvent{{
+		TicketID:       ticket.ID,
+		CustomerEmail:  "ana@example.com",
+		OrderType:      models.TicketOrderPickup,
+		OrderID:        reservation.ID,
+		Subject:        "Missing cupcake",
+		Status:         models.TicketResolved,
+		PreviousStatus: models.TicketOpen,
+	}}, events)
+
+	_, err = svc.UpdateTicket(ticket.ID, &models.UpdateTicketRequest{Status: &resolved})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	// Reopening clears the resolution; 0 unassigns.
+	investigating := models.TicketInvestigating
+	none := uint(0)
+	updated, err = svc.UpdateTicket(ticket.ID, &models.UpdateTicketRequest{Status: &investigating, AssigneeID: &none})
+	require.NoError(t, err)
+	require.Nil(t, updated.ResolvedAt)
+	require.Nil(t, updated.AssigneeID)
+	require.Len(t, events, 2)
+
+	open, err := svc.GetTickets(models.TicketOpen)
+	require.NoError(t, err)
+	require.Empty(t, open)
+	all, err := svc.GetTickets("")
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	_, err = svc.GetTickets("closed")
+	require.Error(t, err)
+}
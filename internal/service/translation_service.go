@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/locale"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var ErrTranslationNotFound = errors.New("translation not found")
+
+// TranslationService manages cupcake translations and applies them to read
+// responses. Cupcakes themselves hold the content in defaultLocale.
+type TranslationService struct {
+	repo          repository.TranslationRepositoryInterface
+	cupcakeRepo   repository.CupcakeRepositoryInterface
+	defaultLocale string
+}
+
+var _ TranslationServiceInterface = (*TranslationService)(nil)
+
+func NewTranslationService(repo repository.TranslationRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface, defaultLocale string) *TranslationService {
+	if normalized, ok := locale.Normalize(defaultLocale); ok {
+		defaultLocale = normalized
+	}
+	return &TranslationService{repo: repo, cupcakeRepo: cupcakeRepo, defaultLocale: defaultLocale}
+}
+
+func (s *TranslationService) GetTranslations(cupcakeID uint) ([]models.CupcakeTranslation, error) {
+	if err := s.checkCupcake(cupcakeID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByCupcake(cupcakeID)
+}
+
+func (s *TranslationService) SetTranslation(cupcakeID uint, tag string, req *models.SetTranslationRequest) (*models.CupcakeTranslation, error) {
+	tag, err := s.checkLocale(tag)
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(req.Name)) < 2 {
+		return nil, i18n.NewError(msgTranslationNameTooShort, nil)
+	}
+	if strings.TrimSpace(req.Flavor) == "" {
+		return nil, i18n.NewError(msgFlavorRequired, nil)
+	}
+	var errs fieldErrors
+	description := sanitizeDescription(&errs, req.Description)
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	if err := s.checkCupcake(cupcakeID); err != nil {
+		return nil, err
+	}
+
+	translation := &models.CupcakeTranslation{
+		CupcakeID:   cupcakeID,
+		Locale:      tag,
+		Name:        strings.TrimSpace(req.Name),
+		Flavor:      strings.TrimSpace(req.Flavor),
+		Description: description,
+	}
+	if err := s.repo.Set(translation); err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+func (s *TranslationService) DeleteTranslation(cupcakeID uint, tag string) error {
+	normalized, ok := locale.Normalize(tag)
+	if !ok {
+		return ErrTranslationNotFound
+	}
+	err := s.repo.Delete(cupcakeID, normalized)
+	if errors.Is(err, repository.ErrNotFound) {
+		return ErrTranslationNotFound
+	}
+	return err
+}
+
+// Localize replaces the name, flavor and description of each cupcake with
+// its translation in the most preferred locale of acceptLanguage that has
+// one, trying each tag and then its base language ("pt-BR", then "pt"). A
+// translation without a description keeps the original one, and a tag
+// matching the default locale keeps the original content. It returns the
+// locale of the most preferred match, for the Content-Language header.
+func (s *TranslationService) Localize(cupcakes []models.Cupcake, acceptLanguage string) (string, error) {
+	candidates := s.candidates(acceptLanguage)
+	for i := range cupcakes {
+		cupcakes[i].Locale = s.defaultLocale
+	}
+	if len(candidates) == 0 || len(cupcakes) == 0 {
+		return s.defaultLocale, nil
+	}
+
+	ids := make([]uint, len(cupcakes))
+	for i, cupcake := range cupcakes {
+		ids[i] = cupcake.ID
+	}
+	translations, err := s.repo.FindForCupcakes(ids, candidates)
+	if err != nil {
+		return "", err
+	}
+
+	byCupcake := make(map[uint]map[string]models.CupcakeTranslation)
+	for _, translation := range translations {
+		if byCupcake[translation.CupcakeID] == nil {
+			byCupcake[translation.CupcakeID] = make(map[string]models.CupcakeTranslation)
+		}
+		byCupcake[translation.CupcakeID][translation.Locale] = translation
+	}
+
+	contentLanguage := s.defaultLocale
+	best := len(candidates)
+	for i := range cupcakes {
+		for rank, tag := range candidates {
+			translation, ok := byCupcake[cupcakes[i].ID][tag]
+			if !ok {
+				continue
+			}
+			cupcakes[i].Name = translation.Name
+			cupcakes[i].Flavor = translation.Flavor
+			if translation.Description != "" {
+				cupcakes[i].Description = translation.Description
+			}
+			cupcakes[i].Locale = tag
+			if rank < best {
+				best, contentLanguage = rank, tag
+			}
+			break
+		}
+	}
+	return contentLanguage, nil
+}
+
+// candidates lists the locales worth looking up for acceptLanguage, in
+// order of preference. The list ends where the default content becomes an
+// acceptable answer: at the default locale or its language.
+func (s *TranslationService) candidates(acceptLanguage string) []string {
+	defaultBase := locale.Base(s.defaultLocale)
+	var candidates []string
+	add := func(tag string) {
+		for _, c := range candidates {
+			if c == tag {
+				return
+			}
+		}
+		candidates = append(candidates, tag)
+	}
+
+	for _, tag := range locale.ParseAcceptLanguage(acceptLanguage) {
+		if tag == s.defaultLocale || tag == defaultBase {
+			break
+		}
+		add(tag)
+		if locale.Base(tag) == defaultBase {
+			break
+		}
+		add(locale.Base(tag))
+	}
+	return candidates
+}
+
+func (s *TranslationService) checkLocale(tag string) (string, error) {
+	normalized, ok := locale.Normalize(tag)
+	if !ok {
+		return "", i18n.NewError(msgLocaleInvalid, nil)
+	}
+	if normalized == s.defaultLocale {
+		return "", i18n.NewError(msgDefaultLocaleNotTranslated, nil)
+	}
+	return normalized, nil
+}
+
+func (s *TranslationService) checkCupcake(id uint) error {
+	exists, err := s.cupcakeRepo.Exists(id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalize(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	svc := NewTranslationService(repository.NewTranslationRepository(db), cupcakeRepo, "pt-BR")
+
+	morango := factory.Cupcake(factory.WithName("Morango"), factory.WithFlavor("Morango"))
+	morango.Description = "<p>Cobertura de morango</p>"
+	limao := factory.Cupcake(factory.WithName("Limão"), factory.WithFlavor("Limão"))
+	limao.Description = "<p>Cobertura de limão</p>"
+	require.NoError(t, cupcakeRepo.Create(&morango))
+	require.NoError(t, cupcakeRepo.Create(&limao))
+
+	_, err := svc.SetTranslation(morango.ID, "en", &models.SetTranslationRequest{Name: "Strawberry", Flavor: "Strawberry", Description: "<p>Strawberry frosting</p>"})
+	require.NoError(t, err)
+	_, err = svc.SetTranslation(morango.ID, "es-ES", &models.SetTranslationRequest{Name: "Fresa", Flavor: "Fresa"})
+	require.NoError(t, err)
+	_, err = svc.SetTranslation(limao.ID, "es", &models.SetTranslationRequest{Name: "Limón", Flavor: "Limón"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		acceptLanguage   string
+		expectedNames    []string
+		expectedDescs    []string
+		expectedLocales  []string
+		expectedLanguage string
+	}{
+		{
+			name:             "no header keeps the default content",
+			expectedNames:    []string{"Morango", "Limão"},
+			expectedDescs:    []string{"<p>Cobertura de morango</p>", "<p>Cobertura de limão</p>"},
+			expectedLocales:  []string{"pt-BR", "pt-BR"},
+			expectedLanguage: "pt-BR",
+		},
+		{
+			name:             "regional tag falls back to its language",
+			acceptLanguage:   "en-US",
+			expectedNames:    []string{"Strawberry", "Limão"},
+			expectedDescs:    []string{"<p>Strawberry frosting</p>", "<p>Cobertura de limão</p>"},
+			expectedLocales:  []string{"en", "pt-BR"},
+			expectedLanguage: "en",
+		},
+		{
+			name:             "each cupcake takes its best match",
+			acceptLanguage:   "es-ES, en;q=0.5",
+			expectedNames:    []string{"Fresa", "Limón"},
+			expectedDescs:    []string{"<p>Cobertura de morango</p>", "<p>Cobertura de limão</p>"},
+			expectedLocales:  []string{"es-ES", "es"},
+			expectedLanguage: "es-ES",
+		},
+		{
+			name:             "default locale before others wins",
+			acceptLanguage:   "pt, en;q=0.8",
+			expectedNames:    []string{"Morango", "Limão"},
+			expectedLocales:  []string{"pt-BR", "pt-BR"},
+			expectedLanguage: "pt-BR",
+		},
+		{
+			name:             "unknown language keeps the default content",
+			acceptLanguage:   "fr-FR",
+			expectedNames:    []string{"Morango", "Limão"},
+			expectedLocales:  []string{"pt-BR", "pt-BR"},
+			expectedLanguage: "pt-BR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cupcakes := []models.Cupcake{morango, limao}
+			language, err := svc.Localize(cupcakes, tt.acceptLanguage)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedLanguage, language)
+			for i, cupcake := range cupcakes {
+				require.Equal(t, tt.expectedNames[i], cupcake.Name)
+				require.Equal(t, tt.expectedLocales[i], cupcake.Locale)
+				if tt.expectedDescs != nil {
+					require.Equal(t, tt.expectedDescs[i], cupcake.Description)
+				}
+			}
+		})
+	}
+}
+
+func TestSetTranslation(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	svc := NewTranslationService(repository.NewTranslationRepository(db), cupcakeRepo, "pt-BR")
+
+	cupcake := factory.Cupcake()
+	require.NoError(t, cupcakeRepo.Create(&cupcake))
+
+	tests := []struct {
+		name          string
+		cupcakeID     uint
+		locale        string
+		req           models.SetTranslationRequest
+		expectedError string
+		notFound      bool
+	}{
+		{
+			name:      "valid translation",
+			cupcakeID: cupcake.ID,
+			locale:    "en_us",
+			req:       models.SetTranslationRequest{Name: "Chocolate", Flavor: "Chocolate"},
+		},
+		{
+			name:          "description too long",
+			cupcakeID:     cupcake.ID,
+			locale:        "en",
+			req:           models.SetTranslationRequest{Name: "Chocolate", Flavor: "Chocolate", Description: strings.Repeat("a", maxDescriptionLength+1)},
+			expectedError: "description must be at most 2000 characters",
+		},
+		{
+			name:          "invalid locale",
+			cupcakeID:     cupcake.ID,
+			locale:        "english!",
+			req:           models.SetTranslationRequest{Name: "Chocolate", Flavor: "Chocolate"},
+			expectedError: "invalid locale",
+		},
+		{
+			name:          "default locale",
+			cupcakeID:     cupcake.ID,
+			locale:        "pt-br",
+			req:           models.SetTranslationRequest{Name: "Chocolate", Flavor: "Chocolate"},
+			expectedError: "the default locale is edited on the cupcake itself",
+		},
+		{
+			name:          "short name",
+			cupcakeID:     cupcake.ID,
+			locale:        "en",
+			req:           models.SetTranslationRequest{Name: "C", Flavor: "Chocolate"},
+			expectedError: "name must be at least 2 characters",
+		},
+		{
+			name:          "missing flavor",
+			cupcakeID:     cupcake.ID,
+			locale:        "en",
+			req:           models.SetTranslationRequest{Name: "Chocolate"},
+			expectedError: "flavor is required",
+		},
+		{
+			name:      "missing cupcake",
+			cupcakeID: 999,
+			locale:    "en",
+			req:       models.SetTranslationRequest{Name: "Chocolate", Flavor: "Chocolate"},
+			notFound:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translation, err := svc.SetTranslation(tt.cupcakeID, tt.locale, &tt.req)
+			switch {
+			case tt.notFound:
+				require.True(t, errors.Is(err, repository.ErrNotFound))
+			case tt.expectedError != "":
+				require.EqualError(t, err, tt.expectedError)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, "en-US", translation.Locale)
+			}
+		})
+	}
+
+	translations, err := svc.GetTranslations(cupcake.ID)
+	require.NoError(t, err)
+	require.Len(t, translations, 1)
+
+	translation, err := svc.SetTranslation(cupcake.ID, "en-US", &models.SetTranslationRequest{Name: "Chocolate", Flavor: "Chocolate", Description: `<p onclick="x()">Rich <b>cocoa</b></p><script>alert(1)</script>`})
+	require.NoError(t, err)
+	require.Equal(t, "<p>Rich <b>cocoa</b></p>", translation.Description)
+
+	require.NoError(t, svc.DeleteTranslation(cupcake.ID, "EN-us"))
+	require.True(t, errors.Is(svc.DeleteTranslation(cupcake.ID, "en-US"), ErrTranslationNotFound))
+}
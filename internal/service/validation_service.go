@@ -0,0 +1,78 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+// maxNameColumn is the size of the name column; the configured maximum
+// cannot go past it.
+const maxNameColumn = 100
+
+// ValidationService holds the process-local validation rules. Like the
+// maintenance switches they are not persisted, so each instance starts
+// from its own configuration and is edited separately.
+type ValidationService struct {
+	mu    sync.RWMutex
+	rules models.ValidationRules
+}
+
+// NewValidationService checks rules and returns a service holding them.
+func NewValidationService(rules models.ValidationRules) (*ValidationService, error) {
+	if err := checkValidationRules(rules); err != nil {
+		return nil, err
+	}
+	return &ValidationService{rules: rules}, nil
+}
+
+// DefaultValidationService holds models.DefaultValidationRules.
+func DefaultValidationService() *ValidationService {
+	return &ValidationService{rules: models.DefaultValidationRules()}
+}
+
+func (s *ValidationService) Rules() models.ValidationRules {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rules
+}
+
+// SetRules changes the rules given in req. The result is checked as a
+// whole, so a new minimum can be sent together with the maximum it needs.
+func (s *ValidationService) SetRules(req *models.UpdateValidationRulesRequest) (models.ValidationRules, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules := s.rules
+	if req.NameMinLength != nil {
+		rules.NameMinLength = *req.NameMinLength
+	}
+	if req.NameMaxLength != nil {
+		rules.NameMaxLength = *req.NameMaxLength
+	}
+	if req.MaxPriceCents != nil {
+		rules.MaxPriceCents = *req.MaxPriceCents
+	}
+
+	if err := checkValidationRules(rules); err != nil {
+		return models.ValidationRules{}, err
+	}
+	s.rules = rules
+	return rules, nil
+}
+
+func checkValidationRules(rules models.ValidationRules) error {
+	var errs fieldErrors
+	if rules.NameMinLength < 1 {
+		errs.add("name_min_length", RuleMin, msgNameMinLengthInvalid, nil)
+	}
+	if rules.NameMaxLength < rules.NameMinLength {
+		errs.add("name_max_length", RuleMin, msgNameMaxLengthInvalid, map[string]any{"Max": maxNameColumn})
+	} else if rules.NameMaxLength > maxNameColumn {
+		errs.add("name_max_length", RuleMax, msgNameMaxLengthInvalid, map[string]any{"Max": maxNameColumn})
+	}
+	if rules.MaxPriceCents < 0 {
+		errs.add("max_price_cents", RuleMin, msgMaxPriceNegative, nil)
+	}
+	return errs.err()
+}
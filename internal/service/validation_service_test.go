@@ -0,0 +1,128 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidationService(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         models.ValidationRules
+		expectedError string
+	}{
+		{
+			name:  "defaults",
+			rules: models.DefaultValidationRules(),
+		},
+		{
+			name:          "min length below one",
+			rules:         models.ValidationRules{NameMinLength: 0, NameMaxLength: 100},
+			expectedError: "name_min_length must be at least 1",
+		},
+		{
+			name:          "max length below min length",
+			rules:         models.ValidationRules{NameMinLength: 10, NameMaxLength: 5},
+			expectedError: "name_max_length must be between name_min_length and 100",
+		},
+		{
+			name:          "max length past the name column",
+			rules:         models.ValidationRules{NameMinLength: 2, NameMaxLength: 101},
+			expectedError: "name_max_length must be between name_min_length and 100",
+		},
+		{
+			name:          "negative max price",
+			rules:         models.ValidationRules{NameMinLength: 2, NameMaxLength: 100, MaxPriceCents: -1},
+			expectedError: "max_price_cents cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := NewValidationService(tt.rules)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.rules, service.Rules())
+		})
+	}
+}
+
+func TestValidationService_SetRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       models.UpdateValidationRulesRequest
+		expectedError string
+		expected      models.ValidationRules
+	}{
+		{
+			name:     "changes only the given fields",
+			request:  models.UpdateValidationRulesRequest{MaxPriceCents: intPtr(5000)},
+			expected: models.ValidationRules{NameMinLength: 2, NameMaxLength: 100, MaxPriceCents: 5000},
+		},
+		{
+			name:     "min and max checked together",
+			request:  models.UpdateValidationRulesRequest{NameMinLength: intPtr(5), NameMaxLength: intPtr(40)},
+			expected: models.ValidationRules{NameMinLength: 5, NameMaxLength: 40},
+		},
+		{
+			name:          "invalid rules are not applied",
+			request:       models.UpdateValidationRulesRequest{NameMaxLength: intPtr(1)},
+			expectedError: "name_max_length must be between name_min_length and 100",
+			expected:      models.DefaultValidationRules(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := DefaultValidationService()
+
+			rules, err := service.SetRules(&tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, rules)
+			}
+			require.Equal(t, tt.expected, service.Rules())
+		})
+	}
+}
+
+func TestCupcakeService_ValidationRules(t *testing.T) {
+	db := setupTestDB(t)
+	rules, err := NewValidationService(models.ValidationRules{NameMinLength: 4, NameMaxLength: 12, MaxPriceCents: 2000})
+	require.NoError(t, err)
+	svc := NewCupcakeService(repository.NewCupcakeRepository(db), repository.NewPromotionRepository(db), repository.NewLocationRepository(db), nil, nil, nil, rules)
+
+	_, err = svc.CreateCupcake(&models.CreateCupcakeRequest{Name: "Bob", Flavor: "Vanilla", PriceCents: 500})
+	require.EqualError(t, err, "name must have at least 4 characters")
+
+	_, err = svc.CreateCupcake(&models.CreateCupcakeRequest{Name: "Chocolate Supreme", Flavor: "Chocolate", PriceCents: 500})
+	require.EqualError(t, err, "name must be at most 12 characters")
+
+	_, err = svc.CreateCupcake(&models.CreateCupcakeRequest{Name: "Pão de mel", Flavor: "Honey", PriceCents: 2500})
+	require.EqualError(t, err, "price must be at most 2000 cents")
+
+	// Lengths count characters, so accented names are not penalized.
+	cupcake, err := svc.CreateCupcake(&models.CreateCupcakeRequest{Name: "Pão de mel", Flavor: "Honey", PriceCents: 2000})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateCupcake(cupcake.ID, &models.UpdateCupcakeRequest{PriceCents: intPtr(2001)})
+	require.EqualError(t, err, "price must be at most 2000 cents")
+
+	_, err = svc.BulkUpdatePrices(&models.BulkPriceUpdateRequest{AdjustmentType: models.PriceAdjustmentPercentage, Value: 10})
+	require.EqualError(t, err, "price for Pão de mel would exceed 2000 cents")
+
+	// Rules changed at runtime apply to the next request.
+	_, err = rules.SetRules(&models.UpdateValidationRulesRequest{MaxPriceCents: intPtr(0)})
+	require.NoError(t, err)
+	_, err = svc.UpdateCupcake(cupcake.ID, &models.UpdateCupcakeRequest{PriceCents: intPtr(9000)})
+	require.NoError(t, err)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const (
+	DefaultTrendingDays  = 7
+	DefaultTrendingLimit = 10
+	maxTrendingDays      = 90
+	maxTrendingLimit     = 50
+)
+
+// ViewCounter buffers cupcake page views in memory and writes them in
+// batches, so a popular product page costs one upsert per cupcake per flush
+// instead of a write per request. Views are counted on the day they are
+// flushed.
+type ViewCounter struct {
+	repo repository.ViewRepositoryInterface
+	now  func() time.Time
+
+	mu      sync.Mutex
+	pending map[uint]int
+}
+
+var _ ViewRecorder = (*ViewCounter)(nil)
+
+func NewViewCounter(repo repository.ViewRepositoryInterface) *ViewCounter {
+	return &ViewCounter{repo: repo, now: time.Now, pending: make(map[uint]int)}
+}
+
+func (c *ViewCounter) RecordView(cupcakeID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending[cupcakeID]++
+}
+
+// Flush writes the buffered views. On failure they are kept for the next
+// flush rather than dropped.
+func (c *ViewCounter) Flush() error {
+	c.mu.Lock()
+	counts := c.pending
+	c.pending = make(map[uint]int)
+	c.mu.Unlock()
+
+	if len(counts) == 0 {
+		return nil
+	}
+	if err := c.repo.AddViews(c.now().Format(time.DateOnly), counts); err != nil {
+		c.mu.Lock()
+		for cupcakeID, count := range counts {
+			c.pending[cupcakeID] += count
+		}
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Run flushes every interval until ctx is cancelled, then flushes once more
+// so no views are lost on shutdown.
+func (c *ViewCounter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := c.Flush(); err != nil {
+				log.Printf("Error flushing views: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := c.Flush(); err != nil {
+				log.Printf("Error flushing views: %v", err)
+			}
+		}
+	}
+}
+
+type TrendingService struct {
+	repo          repository.ViewRepositoryInterface
+	cupcakeRepo   repository.CupcakeRepositoryInterface
+	promotionRepo repository.PromotionRepositoryInterface
+	now           func() time.Time
+}
+
+var _ TrendingServiceInterface = (*TrendingService)(nil)
+
+func NewTrendingService(repo repository.ViewRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface, promotionRepo repository.PromotionRepositoryInterface) *TrendingService {
+	return &TrendingService{repo: repo, cupcakeRepo: cupcakeRepo, promotionRepo: promotionRepo, now: time.Now}
+}
+
+// GetTrending returns up to limit cupcakes by views over the last days days,
+// today included, most viewed first. Cupcakes deleted or made unavailable
+// since they were viewed are left out.
+func (s *TrendingService) GetTrending(days, limit int) ([]models.TrendingCupcake, error) {
+	if days < 1 || days > maxTrendingDays {
+		return nil, i18n.NewError(msgWindowOutOfRange, map[string]any{"Max": maxTrendingDays})
+	}
+	if limit < 1 || limit > maxTrendingLimit {
+		return nil, i18n.NewError(msgLimitOutOfRange, map[string]any{"Max": maxTrendingLimit})
+	}
+
+	now := s.now()
+	totals, err := s.repo.FindMostViewed(now.AddDate(0, 0, 1-days).Format(time.DateOnly), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	cupcakes := make([]models.Cupcake, 0, len(totals))
+	views := make([]int, 0, len(totals))
+	for _, total := range totals {
+		cupcake, err := s.cupcakeRepo.FindByID(total.CupcakeID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !cupcake.IsAvailable {
+			continue
+		}
+		cupcakes = append(cupcakes, *cupcake)
+		views = append(views, total.Views)
+	}
+
+	if err := applyPromotions(s.promotionRepo, cupcakes, now); err != nil {
+		return nil, err
+	}
+
+	trending := make([]models.TrendingCupcake, len(cupcakes))
+	for i, cupcake := range cupcakes {
+		cupcake.PreOrder = cupcake.IsPreOrder(now)
+		trending[i] = models.TrendingCupcake{Cupcake: cupcake, Views: views[i]}
+	}
+	return trending, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/mocks"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewCounter_Flush(t *testing.T) {
+	var writes []map[uint]int
+	fail := false
+	repo := &mocks.ViewRepository{
+		AddViewsFunc: func(day string, counts map[uint]int) error {
+			require.Equal(t, "2026-10-16", day)
+			if fail {
+				return errors.New("database is locked")
+			}
+			writes = append(writes, counts)
+			return nil
+		},
+	}
+	counter := NewViewCounter(repo)
+	counter.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local) }
+
+	require.NoError(t, counter.Flush())
+	require.Empty(t, writes, "nothing to write")
+
+	counter.RecordView(1)
+	counter.RecordView(1)
+	counter.RecordView(2)
+	require.NoError(t, counter.Flush())
+	require.Equal(t, []map[uint]int{{1: 2, 2: 1}}, writes)
+
+	counter.RecordView(1)
+	fail = true
+	require.Error(t, counter.Flush())
+	counter.RecordView(1)
+	fail = false
+	require.NoError(t, counter.Flush())
+	require.Equal(t, map[uint]int{1: 2}, writes[1], "failed batch is retried")
+}
+
+func TestViewCounter_RunFlushesOnStop(t *testing.T) {
+	repo := repository.NewViewRepository(setupTestDB(t))
+	counter := NewViewCounter(repo)
+	counter.RecordView(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	counter.Run(ctx, time.Hour)
+
+	totals, err := repo.FindMostViewed("2000-01-01", 10)
+	require.NoError(t, err)
+	require.Equal(t, []models.CupcakeViewTotal{{CupcakeID: 1, Views: 1}}, totals)
+}
+
+func TestGetTrending(t *testing.T) {
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla")),
+		factory.Cupcake(factory.WithName("Chocolate")),
+		factory.Cupcake(factory.WithName("Lemon"), factory.Unavailable()),
+		factory.Cupcake(factory.WithName("Carrot")),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+	require.NoError(t, cupcakeRepo.Delete(4))
+
+	viewRepo := repository.NewViewRepository(db)
+	require.NoError(t, viewRepo.AddViews("2026-10-01", map[uint]int{1: 50}))
+	require.NoError(t, viewRepo.AddViews("2026-10-10", map[uint]int{1: 2, 2: 5, 3: 9, 4: 9}))
+	require.NoError(t, viewRepo.AddViews("2026-10-16", map[uint]int{1: 1}))
+
+	svc := NewTrendingService(viewRepo, cupcakeRepo, repository.NewPromotionRepository(db))
+	svc.now = func() time.Time { return time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		name          string
+		days          int
+		limit         int
+		expected      map[string]int
+		expectedOrder []string
+		expectedError string
+	}{
+		{name: "last week", days: 7, limit: 10, expectedOrder: []string{"Chocolate", "Vanilla"}, expected: map[string]int{"Chocolate": 5, "Vanilla": 3}},
+		{name: "today only", days: 1, limit: 10, expectedOrder: []string{"Vanilla"}, expected: map[string]int{"Vanilla": 1}},
+		{name: "last month", days: 30, limit: 10, expectedOrder: []string{"Vanilla", "Chocolate"}, expected: map[string]int{"Vanilla": 53, "Chocolate": 5}},
+		{name: "window too short", days: 0, limit: 10, expectedError: "window must be between 1 and 90 days"},
+		{name: "window too long", days: 91, limit: 10, expectedError: "window must be between 1 and 90 days"},
+		{name: "limit out of range", days: 7, limit: 51, expectedError: "limit must be between 1 and 50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trending, err := svc.GetTrending(tt.days, tt.limit)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			names := []string{}
+			for _, item := range trending {
+				names = append(names, item.Cupcake.Name)
+				require.Equal(t, tt.expected[item.Cupcake.Name], item.Views)
+			}
+			require.Equal(t, tt.expectedOrder, names)
+		})
+	}
+}
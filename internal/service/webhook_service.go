@@ -0,0 +1,277 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+const webhookDeliveryJob = "webhook.deliver"
+
+var webhookEvents = map[string]bool{
+	models.EventCupcakeCreated:      true,
+	models.EventCupcakeUpdated:      true,
+	models.EventCupcakeDeleted:      true,
+	models.EventTicketStatusChanged: true,
+	models.EventPickupReady:         true,
+	models.EventPickupCancelled:     true,
+	models.EventStockLow:            true,
+}
+
+type webhookDeliveryPayload struct {
+	WebhookID uint            `json:"webhook_id"`
+	Event     string          `json:"event"`
+	Body      json.RawMessage `json:"body"`
+}
+
+type WebhookService struct {
+	repo   repository.WebhookRepositoryInterface
+	jobs   *JobService
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ WebhookServiceInterface = (*WebhookService)(nil)
+
+func NewWebhookService(repo repository.WebhookRepositoryInterface, jobs *JobService) *WebhookService {
+	s := &WebhookService{
+		repo:   repo,
+		jobs:   jobs,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+	}
+	jobs.Register(webhookDeliveryJob, s.deliver)
+	return s
+}
+
+// WithHTTPClient delivers webhooks through client instead of a plain one
+// with a ten second timeout.
+func (s *WebhookService) WithHTTPClient(client *http.Client) *WebhookService {
+	s.client = client
+	return s
+}
+
+func (s *WebhookService) CreateWebhook(req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	events, err := normalizeWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Secret) < 16 {
+		return nil, i18n.NewError(msgSecretTooShort, nil)
+	}
+
+	webhook := &models.Webhook{
+		URL:      strings.TrimSpace(req.URL),
+		Events:   events,
+		Secret:   req.Secret,
+		IsActive: true,
+	}
+
+	if err := s.repo.Create(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) GetWebhook(id uint) (*models.Webhook, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *WebhookService) GetAllWebhooks() ([]models.Webhook, error) {
+	return s.repo.FindAll()
+}
+
+func (s *WebhookService) UpdateWebhook(id uint, req *models.UpdateWebhookRequest) (*models.Webhook, error) {
+	webhook, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		webhook.URL = strings.TrimSpace(*req.URL)
+	}
+
+	if req.Events != nil {
+		events, err := normalizeWebhookEvents(*req.Events)
+		if err != nil {
+			return nil, err
+		}
+		webhook.Events = events
+	}
+
+	if req.Secret != nil {
+		if len(*req.Secret) < 16 {
+			return nil, i18n.NewError(msgSecretTooShort, nil)
+		}
+		webhook.Secret = *req.Secret
+	}
+
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Update(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) DeleteWebhook(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *WebhookService) GetDeliveries(id uint) ([]models.WebhookDelivery, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.FindDeliveries(id)
+}
+
+func (s *WebhookService) Publish(event string, data interface{}) {
+	webhooks, err := s.repo.FindActive()
+	if err != nil {
+		log.Printf("Error loading webhooks for %s: %v", event, err)
+		return
+	}
+
+	payload, err := json.Marshal(models.WebhookEvent{
+		Event:      event,
+		OccurredAt: s.now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		log.Printf("Error encoding webhook payload for %s: %v", event, err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !subscribesTo(webhook, event) {
+			continue
+		}
+		err := s.jobs.Enqueue(webhookDeliveryJob, webhookDeliveryPayload{
+			WebhookID: webhook.ID,
+			Event:     event,
+			Body:      payload,
+		})
+		if err != nil {
+			log.Printf("Error queueing webhook delivery %d: %v", webhook.ID, err)
+		}
+	}
+}
+
+func (s *WebhookService) deliver(job *models.Job) error {
+	var payload webhookDeliveryPayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return err
+	}
+
+	webhook, err := s.repo.FindByID(payload.WebhookID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	statusCode, err := s.send(*webhook, payload.Event, payload.Body)
+
+	delivery := &models.WebhookDelivery{
+		WebhookID:  webhook.ID,
+		Event:      payload.Event,
+		Payload:    string(payload.Body),
+		Attempt:    job.Attempts,
+		StatusCode: statusCode,
+		Succeeded:  err == nil,
+	}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	if recordErr := s.repo.CreateDelivery(delivery); recordErr != nil {
+		log.Printf("Error recording webhook delivery %d: %v", webhook.ID, recordErr)
+	}
+
+	return err
+}
+
+func (s *WebhookService) send(webhook models.Webhook, event string, payload []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Cupcake-Event", event)
+	req.Header.Set("X-Cupcake-Signature", "sha256="+SignWebhookPayload(webhook.Secret, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func subscribesTo(webhook models.Webhook, event string) bool {
+	for _, subscribed := range strings.Split(webhook.Events, ",") {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return i18n.NewError(msgWebhookURLInvalid, nil)
+	}
+	return nil
+}
+
+func normalizeWebhookEvents(events []string) (string, error) {
+	if len(events) == 0 {
+		return "", i18n.NewError(msgEventsRequired, nil)
+	}
+
+	normalized := make([]string, 0, len(events))
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !webhookEvents[event] {
+			return "", i18n.NewError(msgUnsupportedEvent, map[string]any{"Event": event})
+		}
+		normalized = append(normalized, event)
+	}
+	return strings.Join(normalized, ","), nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookService(t *testing.T) (*WebhookService, *CupcakeService, *JobService) {
+	t.Helper()
+
+	db := setupTestDB(t)
+	jobService := NewJobService(repository.NewJobRepository(db))
+	jobService.initialBackoff = 0
+
+	webhookService := NewWebhookService(repository.NewWebhookRepository(db), jobService)
+	cupcakeService := NewCupcakeService(repository.NewCupcakeRepository(db), repository.NewPromotionRepository(db), nil, webhookService, nil, nil, nil)
+	return webhookService, cupcakeService, jobService
+}
+
+func TestCreateWebhook(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateWebhookRequest
+		expectedError string
+	}{
+		{
+			name: "success",
+			request: &models.CreateWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Events: []string{models.EventCupcakeCreated, models.EventCupcakeDeleted},
+				Secret: "0123456789abcdef",
+			},
+		},
+		{
+			name: "validation error - relative URL",
+			request: &models.CreateWebhookRequest{
+				URL:    "/hooks",
+				Events: []string{models.EventCupcakeCreated},
+				Secret: "0123456789abcdef",
+			},
+			expectedError: "url must be an absolute http or https URL",
+		},
+		{
+			name: "validation error - unsupported event",
+			request: &models.CreateWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Events: []string{"order.paid"},
+				Secret: "0123456789abcdef",
+			},
+			expectedError: "unsupported event: order.paid",
+		},
+		{
+			name: "validation error - no events",
+			request: &models.CreateWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Secret: "0123456789abcdef",
+			},
+			expectedError: "at least one event is required",
+		},
+		{
+			name: "validation error - short secret",
+			request: &models.CreateWebhookRequest{
+				URL:    "https://example.com/hooks",
+				Events: []string{models.EventCupcakeCreated},
+				Secret: "short",
+			},
+			expectedError: "secret must have at least 16 characters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _ := newTestWebhookService(t)
+
+			webhook, err := service.CreateWebhook(tt.request)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Nil(t, webhook)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.True(t, webhook.IsActive)
+				require.Equal(t, "cupcake.created,cupcake.deleted", webhook.Events)
+			}
+		})
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	tests := []struct {
+		name               string
+		failures           int32
+		subscribedEvents   []string
+		expectedCalls      int32
+		expectedDeliveries int
+		expectedSucceeded  bool
+	}{
+		{
+			name:               "delivers signed event on first attempt",
+			subscribedEvents:   []string{models.EventCupcakeCreated},
+			expectedCalls:      1,
+			expectedDeliveries: 1,
+			expectedSucceeded:  true,
+		},
+		{
+			name:               "retries until the endpoint recovers",
+			failures:           2,
+			subscribedEvents:   []string{models.EventCupcakeCreated},
+			expectedCalls:      3,
+			expectedDeliveries: 3,
+			expectedSucceeded:  true,
+		},
+		{
+			name:               "gives up after max attempts",
+			failures:           jobMaxAttempts,
+			subscribedEvents:   []string{models.EventCupcakeCreated},
+			expectedCalls:      jobMaxAttempts,
+			expectedDeliveries: jobMaxAttempts,
+			expectedSucceeded:  false,
+		},
+		{
+			name:               "skips webhooks not subscribed to the event",
+			subscribedEvents:   []string{models.EventCupcakeDeleted},
+			expectedCalls:      0,
+			expectedDeliveries: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := "0123456789abcdef"
+			var calls int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, models.EventCupcakeCreated, r.Header.Get("X-Cupcake-Event"))
+				require.Equal(t, "sha256="+SignWebhookPayload(secret, body), r.Header.Get("X-Cupcake-Signature"))
+
+				if call <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			webhookService, cupcakeService, jobService := newTestWebhookService(t)
+
+			webhook, err := webhookService.CreateWebhook(&models.CreateWebhookRequest{
+				URL:    server.URL,
+				Events: tt.subscribedEvents,
+				Secret: secret,
+			})
+			require.NoError(t, err)
+
+			_, err = cupcakeService.CreateCupcake(&models.CreateCupcakeRequest{
+				Name:       "Pistachio",
+				Flavor:     "Nutty",
+				PriceCents: 1100,
+			})
+			require.NoError(t, err)
+
+			drainJobs(t, jobService)
+
+			require.Equal(t, tt.expectedCalls, atomic.LoadInt32(&calls))
+
+			deliveries, err := webhookService.GetDeliveries(webhook.ID)
+			require.NoError(t, err)
+			require.Len(t, deliveries, tt.expectedDeliveries)
+			if tt.expectedDeliveries > 0 {
+				require.Equal(t, tt.expectedSucceeded, deliveries[0].Succeeded)
+				require.Equal(t, tt.expectedDeliveries, deliveries[0].Attempt)
+			}
+		})
+	}
+}
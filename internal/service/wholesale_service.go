@@ -0,0 +1,250 @@
+package service
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+
+	"github.com/julimonteiro/cupcake-store/internal/i18n"
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/money"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+)
+
+var (
+	ErrWholesaleAccountNotFound = errors.New("wholesale account not found")
+	ErrWholesalePriceNotFound   = errors.New("wholesale price not found")
+)
+
+type WholesaleService struct {
+	repo        repository.WholesaleRepositoryInterface
+	cupcakeRepo repository.CupcakeRepositoryInterface
+}
+
+var _ WholesaleServiceInterface = (*WholesaleService)(nil)
+
+func NewWholesaleService(repo repository.WholesaleRepositoryInterface, cupcakeRepo repository.CupcakeRepositoryInterface) *WholesaleService {
+	return &WholesaleService{repo: repo, cupcakeRepo: cupcakeRepo}
+}
+
+func (s *WholesaleService) CreateAccount(req *models.CreateWholesaleAccountRequest) (*models.WholesaleAccount, error) {
+	account := &models.WholesaleAccount{
+		Name:             strings.TrimSpace(req.Name),
+		Email:            strings.ToLower(strings.TrimSpace(req.Email)),
+		MinOrderQuantity: req.MinOrderQuantity,
+	}
+	if err := s.validateAccount(account); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (s *WholesaleService) GetAccount(id uint) (*models.WholesaleAccount, error) {
+	account, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrWholesaleAccountNotFound
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+func (s *WholesaleService) GetAllAccounts() ([]models.WholesaleAccount, error) {
+	return s.repo.FindAll()
+}
+
+func (s *WholesaleService) UpdateAccount(id uint, req *models.UpdateWholesaleAccountRequest) (*models.WholesaleAccount, error) {
+	account, err := s.GetAccount(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		account.Name = strings.TrimSpace(*req.Name)
+	}
+
+	if req.Email != nil {
+		account.Email = strings.ToLower(strings.TrimSpace(*req.Email))
+	}
+
+	if req.MinOrderQuantity != nil {
+		account.MinOrderQuantity = *req.MinOrderQuantity
+	}
+
+	if err := s.validateAccount(account); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(account); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (s *WholesaleService) DeleteAccount(id uint) error {
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWholesaleAccountNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *WholesaleService) GetPrices(accountID uint) ([]models.WholesalePrice, error) {
+	if _, err := s.GetAccount(accountID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindPrices(accountID)
+}
+
+func (s *WholesaleService) SetPrice(accountID, cupcakeID uint, req *models.SetWholesalePriceRequest) (*models.WholesalePrice, error) {
+	if req.PriceCents == nil {
+		return nil, i18n.NewError(msgPriceRequired, nil)
+	}
+	if *req.PriceCents <= 0 {
+		return nil, i18n.NewError(msgPriceNotPositive, nil)
+	}
+
+	if _, err := s.GetAccount(accountID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.cupcakeRepo.Exists(cupcakeID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, i18n.NewError(msgCupcakeNotFound, nil)
+	}
+
+	price := &models.WholesalePrice{AccountID: accountID, CupcakeID: cupcakeID, PriceCents: *req.PriceCents}
+	if err := s.repo.SetPrice(price); err != nil {
+		return nil, err
+	}
+
+	return price, nil
+}
+
+func (s *WholesaleService) DeletePrice(accountID, cupcakeID uint) error {
+	if _, err := s.GetAccount(accountID); err != nil {
+		return err
+	}
+	if err := s.repo.DeletePrice(accountID, cupcakeID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrWholesalePriceNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// Quote prices a wholesale basket: negotiated prices where the account has
+// one, the catalog price otherwise. Retail promotions do not apply. The
+// basket must reach the account's minimum order quantity.
+func (s *WholesaleService) Quote(accountID uint, req *models.WholesaleQuoteRequest) (*models.WholesaleQuote, error) {
+	if len(req.Items) == 0 {
+		return nil, i18n.NewError(msgItemsRequired, nil)
+	}
+
+	account, err := s.GetAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same cupcake may be listed more than once; quote it as one line.
+	wanted := make(map[uint]int, len(req.Items))
+	var order []uint
+	units := 0
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, i18n.NewError(msgQuantityNotPositive, nil)
+		}
+		if _, seen := wanted[item.CupcakeID]; !seen {
+			order = append(order, item.CupcakeID)
+		}
+		wanted[item.CupcakeID] += item.Quantity
+		units += item.Quantity
+	}
+	if units < account.MinOrderQuantity {
+		return nil, i18n.NewError(msgBelowMinimumOrder, map[string]any{"Min": account.MinOrderQuantity})
+	}
+
+	prices, err := s.repo.FindPrices(accountID)
+	if err != nil {
+		return nil, err
+	}
+	negotiated := make(map[uint]int, len(prices))
+	for _, price := range prices {
+		negotiated[price.CupcakeID] = price.PriceCents
+	}
+
+	quote := &models.WholesaleQuote{AccountID: accountID, Lines: make([]models.WholesaleQuoteLine, 0, len(order))}
+	for _, cupcakeID := range order {
+		cupcake, err := s.cupcakeRepo.FindByID(cupcakeID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, i18n.NewError(msgCupcakeIDNotFound, map[string]any{"ID": cupcakeID})
+			}
+			return nil, err
+		}
+		if !cupcake.IsAvailable {
+			return nil, i18n.NewError(msgNotAvailable, map[string]any{"Name": cupcake.Name})
+		}
+
+		line := models.WholesaleQuoteLine{
+			CupcakeID:      cupcakeID,
+			Name:           cupcake.Name,
+			Quantity:       wanted[cupcakeID],
+			UnitPriceCents: cupcake.PriceCents,
+		}
+		if price, ok := negotiated[cupcakeID]; ok {
+			line.UnitPriceCents = price
+			line.Negotiated = true
+		}
+		subtotal, err := money.Cents(line.UnitPriceCents).Mul(int64(line.Quantity))
+		if line.SubtotalCents, err = cents(subtotal, err); err != nil {
+			return nil, err
+		}
+		quote.Lines = append(quote.Lines, line)
+		if quote.TotalCents, err = cents(money.Cents(quote.TotalCents).Add(subtotal)); err != nil {
+			return nil, err
+		}
+	}
+
+	return quote, nil
+}
+
+func (s *WholesaleService) validateAccount(account *models.WholesaleAccount) error {
+	if account.Name == "" {
+		return i18n.NewError(msgNameRequired, nil)
+	}
+	if len(account.Name) > 100 {
+		return i18n.NewError(msgNameTooLong, nil)
+	}
+	if account.Email == "" {
+		return i18n.NewError(msgEmailRequired, nil)
+	}
+	if _, err := mail.ParseAddress(account.Email); err != nil {
+		return i18n.NewError(msgEmailInvalid, nil)
+	}
+	if account.MinOrderQuantity < 0 {
+		return i18n.NewError(msgMinOrderQuantityNegative, nil)
+	}
+
+	existing, err := s.repo.FindByEmail(account.Email)
+	if err == nil && existing.ID != account.ID {
+		return i18n.NewError(msgWholesaleEmailTaken, nil)
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/repository"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestWholesaleService returns a service with Vanilla (1, 500),
+// Chocolate (2, 600) and Lemon (3, unavailable) in the catalog and a "Café
+// Central" account that pays 400 for Vanilla and orders at least 12 units.
+func newTestWholesaleService(t *testing.T) *WholesaleService {
+	t.Helper()
+
+	db := setupTestDB(t)
+	cupcakeRepo := repository.NewCupcakeRepository(db)
+	for _, cupcake := range []models.Cupcake{
+		factory.Cupcake(factory.WithName("Vanilla"), factory.WithPrice(500)),
+		factory.Cupcake(factory.WithName("Chocolate"), factory.WithPrice(600)),
+		factory.Cupcake(factory.WithName("Lemon"), factory.Unavailable()),
+	} {
+		require.NoError(t, cupcakeRepo.Create(&cupcake))
+	}
+
+	svc := NewWholesaleService(repository.NewWholesaleRepository(db), cupcakeRepo)
+	_, err := svc.CreateAccount(&models.CreateWholesaleAccountRequest{Name: "Café Central", Email: "compras@cafecentral.com", MinOrderQuantity: 12})
+	require.NoError(t, err)
+	_, err = svc.SetPrice(1, 1, &models.SetWholesalePriceRequest{PriceCents: intPtr(400)})
+	require.NoError(t, err)
+	return svc
+}
+
+func TestCreateWholesaleAccount(t *testing.T) {
+	tests := []struct {
+		name          string
+		request       *models.CreateWholesaleAccountRequest
+		expectedError string
+	}{
+		{name: "success", request: &models.CreateWholesaleAccountRequest{Name: " Hotel Sol ", Email: " Eventos@HotelSol.com ", MinOrderQuantity: 24}},
+		{name: "missing name", request: &models.CreateWholesaleAccountRequest{Email: "eventos@hotelsol.com"}, expectedError: "name is required"},
+		{name: "invalid email", request: &models.CreateWholesaleAccountRequest{Name: "Hotel Sol", Email: "hotel sol"}, expectedError: "email is invalid"},
+		{name: "negative minimum", request: &models.CreateWholesaleAccountRequest{Name: "Hotel Sol", Email: "eventos@hotelsol.com", MinOrderQuantity: -1}, expectedError: "minimum order quantity cannot be negative"},
+		{name: "email already used", request: &models.CreateWholesaleAccountRequest{Name: "Café Central 2", Email: "COMPRAS@cafecentral.com"}, expectedError: "a wholesale account with this email already exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestWholesaleService(t)
+
+			account, err := svc.CreateAccount(tt.request)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, account.ID)
+			require.Equal(t, "Hotel Sol", account.Name)
+			require.Equal(t, "eventos@hotelsol.com", account.Email)
+		})
+	}
+}
+
+func TestSetWholesalePrice(t *testing.T) {
+	svc := newTestWholesaleService(t)
+
+	price, err := svc.SetPrice(1, 1, &models.SetWholesalePriceRequest{PriceCents: intPtr(350)})
+	require.NoError(t, err)
+	require.Equal(t, 350, price.PriceCents)
+
+	prices, err := svc.GetPrices(1)
+	require.NoError(t, err)
+	require.Len(t, prices, 1, "setting a price again replaces it")
+	require.Equal(t, 350, prices[0].PriceCents)
+
+	_, err = svc.SetPrice(1, 1, &models.SetWholesalePriceRequest{PriceCents: intPtr(0)})
+	require.EqualError(t, err, "price must be greater than zero")
+	_, err = svc.SetPrice(1, 999, &models.SetWholesalePriceRequest{PriceCents: intPtr(100)})
+	require.EqualError(t, err, "cupcake not found")
+	_, err = svc.SetPrice(999, 1, &models.SetWholesalePriceRequest{PriceCents: intPtr(100)})
+	require.ErrorIs(t, err, ErrWholesaleAccountNotFound)
+
+	require.NoError(t, svc.DeletePrice(1, 1))
+	require.ErrorIs(t, svc.DeletePrice(1, 1), ErrWholesalePriceNotFound)
+}
+
+func TestWholesaleQuote(t *testing.T) {
+	tests := []struct {
+		name          string
+		accountID     uint
+		items         []models.PickupItem
+		expectedTotal int
+		expectedLines int
+		expectedError string
+	}{
+		{
+			name:          "negotiated and catalog prices",
+			accountID:     1,
+			items:         []models.PickupItem{{CupcakeID: 1, Quantity: 10}, {CupcakeID: 2, Quantity: 2}},
+			expectedTotal: 10*400 + 2*600,
+			expectedLines: 2,
+		},
+		{
+			name:          "repeated items quoted as one line",
+			accountID:     1,
+			items:         []models.PickupItem{{CupcakeID: 1, Quantity: 6}, {CupcakeID: 1, Quantity: 6}},
+			expectedTotal: 12 * 400,
+			expectedLines: 1,
+		},
+		{
+			name:          "below the minimum order",
+			accountID:     1,
+			items:         []models.PickupItem{{CupcakeID: 1, Quantity: 11}},
+			expectedError: "minimum order is 12 units",
+		},
+		{
+			name:          "unavailable cupcake",
+			accountID:     1,
+			items:         []models.PickupItem{{CupcakeID: 3, Quantity: 12}},
+			expectedError: "Lemon is not available",
+		},
+		{
+			name:          "unknown cupcake",
+			accountID:     1,
+			items:         []models.PickupItem{{CupcakeID: 999, Quantity: 12}},
+			expectedError: "cupcake 999 not found",
+		},
+		{
+			name:          "zero quantity",
+			accountID:     1,
+			items:         []models.PickupItem{{CupcakeID: 1, Quantity: 0}},
+			expectedError: "quantity must be greater than zero",
+		},
+		{
+			name:          "no items",
+			accountID:     1,
+			expectedError: "at least one item is required",
+		},
+		{
+			name:          "unknown account",
+			accountID:     999,
+			items:         []models.PickupItem{{CupcakeID: 1, Quantity: 12}},
+			expectedError: ErrWholesaleAccountNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestWholesaleService(t)
+
+			quote, err := svc.Quote(tt.accountID, &models.WholesaleQuoteRequest{Items: tt.items})
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedTotal, quote.TotalCents)
+			require.Len(t, quote.Lines, tt.expectedLines)
+			require.True(t, quote.Lines[0].Negotiated)
+		})
+	}
+}
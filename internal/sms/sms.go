@@ -0,0 +1,92 @@
+// Package sms sends text messages through an SMS provider. Twilio is the
+// only one supported.
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/httpclient"
+	"github.com/julimonteiro/cupcake-store/internal/service"
+)
+
+const twilioAPIURL = "https://api.twilio.com/2010-04-01"
+
+// New returns the sender of SMS_PROVIDER, or nil when it is none.
+func New(cfg *config.Config) (service.SMSSender, error) {
+	switch cfg.SMSProvider {
+	case "", "none":
+		return nil, nil
+	case "twilio":
+	default:
+		return nil, fmt.Errorf("unknown SMS_PROVIDER %q: must be none or twilio", cfg.SMSProvider)
+	}
+	if cfg.TwilioAccountSID == "" || cfg.TwilioAuthToken == "" || cfg.TwilioFrom == "" {
+		return nil, fmt.Errorf("TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN and TWILIO_FROM are required with SMS_PROVIDER=twilio")
+	}
+	settings, err := httpclient.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Twilio{
+		apiURL:     twilioAPIURL,
+		accountSID: cfg.TwilioAccountSID,
+		authToken:  cfg.TwilioAuthToken,
+		from:       cfg.TwilioFrom,
+		http:       httpclient.New("sms", 10*time.Second, settings),
+	}, nil
+}
+
+// Twilio sends messages with Twilio's Programmable Messaging API. From is
+// a Twilio number or, starting with MG, a messaging service.
+type Twilio struct {
+	apiURL     string
+	accountSID string
+	authToken  string
+	from       string
+	http       *http.Client
+}
+
+var _ service.SMSSender = (*Twilio)(nil)
+
+func (t *Twilio) Send(ctx context.Context, to, body string) error {
+	form := url.Values{"To": {to}, "Body": {body}}
+	if strings.HasPrefix(t.from, "MG") {
+		form.Set("MessagingServiceSid", t.from)
+	} else {
+		form.Set("From", t.from)
+	}
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", t.apiURL, url.PathEscape(t.accountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(t.accountSID, t.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("twilio: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusCreated {
+		return nil
+	}
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var failure struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &failure) == nil && failure.Message != "" {
+		return fmt.Errorf("twilio: %s: %d %s", resp.Status, failure.Code, failure.Message)
+	}
+	return fmt.Errorf("twilio: %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+}
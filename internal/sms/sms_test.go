@@ -0,0 +1,61 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	sender, err := New(&config.Config{SMSProvider: "none"})
+	require.NoError(t, err)
+	require.Nil(t, sender)
+
+	sender, err = New(&config.Config{SMSProvider: "twilio", TwilioAccountSID: "AC123", TwilioAuthToken: "token", TwilioFrom: "+15005550006"})
+	require.NoError(t, err)
+	require.Equal(t, "https://api.twilio.com/2010-04-01", sender.(*Twilio).apiURL)
+
+	_, err = New(&config.Config{SMSProvider: "twilio", TwilioAccountSID: "AC123"})
+	require.EqualError(t, err, "TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN and TWILIO_FROM are required with SMS_PROVIDER=twilio")
+	_, err = New(&config.Config{SMSProvider: "pigeon"})
+	require.ErrorContains(t, err, `unknown SMS_PROVIDER "pigeon"`)
+}
+
+func TestTwilio_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/Accounts/AC123/Messages.json", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		if user, password, _ := r.BasicAuth(); user != "AC123" || password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":20003,"message":"Authenticate","status":401}`))
+			return
+		}
+		if r.PostForm.Get("To") != "+5511912345678" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":21211,"message":"The 'To' number is not a valid phone number.","status":400}`))
+			return
+		}
+		require.Equal(t, "Your order is ready", r.PostForm.Get("Body"))
+		require.Equal(t, "+15005550006", r.PostForm.Get("From"))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid":"SM1","status":"queued"}`))
+	}))
+	defer server.Close()
+	sender, err := New(&config.Config{SMSProvider: "twilio", TwilioAccountSID: "AC123", TwilioAuthToken: "token", TwilioFrom: "+15005550006"})
+	require.NoError(t, err)
+	twilio := sender.(*Twilio)
+	twilio.apiURL = server.URL
+	ctx := context.Background()
+
+	require.NoError(t, sender.Send(ctx, "+5511912345678", "Your order is ready"))
+	err = sender.Send(ctx, "+55", "Your order is ready")
+	require.EqualError(t, err, "twilio: 400 Bad Request: 21211 The 'To' number is not a valid phone number.")
+
+	twilio.authToken = "wrong"
+	err = sender.Send(ctx, "+5511912345678", "Your order is ready")
+	require.EqualError(t, err, "twilio: 401 Unauthorized: 20003 Authenticate")
+}
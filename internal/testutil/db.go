@@ -0,0 +1,50 @@
+// Package testutil holds helpers shared by the test suites: a migrated
+// in-memory database and fixture loading. Entity factories live in the
+// factory subpackage.
+package testutil
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/database"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NewDB opens an in-memory SQLite database with every application table
+// migrated.
+func NewDB(tb testing.TB) *gorm.DB {
+	tb.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{TranslateError: true})
+	require.NoError(tb, err)
+	require.NoError(tb, database.Migrate(db))
+	return db
+}
+
+// Load inserts each fixture, e.g. Load(t, db, &cupcake, &promotion).
+// Fixtures must be pointers so generated IDs are written back.
+func Load(tb testing.TB, db *gorm.DB, fixtures ...interface{}) {
+	tb.Helper()
+
+	for _, fixture := range fixtures {
+		require.NoError(tb, db.Create(fixture).Error)
+	}
+}
+
+// LoadJSON decodes a JSON array from path into dest, a pointer to a slice
+// of models, and inserts every element.
+func LoadJSON(tb testing.TB, db *gorm.DB, path string, dest interface{}) {
+	tb.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(tb, err)
+	require.NoError(tb, json.Unmarshal(data, dest))
+	if reflect.ValueOf(dest).Elem().Len() > 0 {
+		require.NoError(tb, db.Create(dest).Error)
+	}
+}
@@ -0,0 +1,60 @@
+package testutil_test
+
+import (
+	"testing"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+	"github.com/julimonteiro/cupcake-store/internal/testutil"
+	"github.com/julimonteiro/cupcake-store/internal/testutil/factory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	db := testutil.NewDB(t)
+
+	cupcake := factory.Cupcake(factory.WithSKU("VAN-001"))
+	testutil.Load(t, db, &cupcake)
+	promotion := factory.Promotion(cupcake.ID)
+	coupon := factory.Coupon(factory.WithCode("SAVE5"))
+	testutil.Load(t, db, &promotion, &coupon)
+
+	require.NotZero(t, cupcake.ID)
+	require.NotZero(t, promotion.ID)
+
+	var stored models.Coupon
+	require.NoError(t, db.First(&stored, coupon.ID).Error)
+	require.Equal(t, "SAVE5", stored.Code)
+}
+
+func TestLoadJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedNames []string
+	}{
+		{
+			name:          "cupcake fixtures",
+			path:          "testdata/cupcakes.json",
+			expectedNames: []string{"Red Velvet", "Brigadeiro", "Limão"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testutil.NewDB(t)
+
+			var cupcakes []models.Cupcake
+			testutil.LoadJSON(t, db, tt.path, &cupcakes)
+
+			var stored []models.Cupcake
+			require.NoError(t, db.Order("id").Find(&stored).Error)
+			names := make([]string, len(stored))
+			for i, c := range stored {
+				names[i] = c.Name
+			}
+			require.Equal(t, tt.expectedNames, names)
+			require.Equal(t, "RV-001", *stored[0].SKU)
+			require.False(t, stored[2].IsAvailable)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package factory builds valid models for tests. Each factory starts from
+// sensible defaults and applies options in order, so a case only spells out
+// what it cares about:
+//
+//	cupcake := factory.Cupcake(factory.WithName("Red Velvet"), factory.Unavailable())
+package factory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/models"
+)
+
+type CupcakeOption func(*models.Cupcake)
+
+func Cupcake(opts ...CupcakeOption) models.Cupcake {
+	cupcake := models.Cupcake{
+		Name:        "Vanilla",
+		Flavor:      "Vanilla",
+		PriceCents:  1000,
+		IsAvailable: true,
+	}
+	for _, opt := range opts {
+		opt(&cupcake)
+	}
+	return cupcake
+}
+
+// Cupcakes builds n cupcakes named "Cupcake 1" to "Cupcake n"; opts apply
+// to every one of them.
+func Cupcakes(n int, opts ...CupcakeOption) []models.Cupcake {
+	cupcakes := make([]models.Cupcake, n)
+	for i := range cupcakes {
+		cupcakes[i] = Cupcake(append([]CupcakeOption{WithName(fmt.Sprintf("Cupcake %d", i+1))}, opts...)...)
+	}
+	return cupcakes
+}
+
+func WithName(name string) CupcakeOption {
+	return func(c *models.Cupcake) { c.Name = name }
+}
+
+func WithFlavor(flavor string) CupcakeOption {
+	return func(c *models.Cupcake) { c.Flavor = flavor }
+}
+
+func WithPrice(cents int) CupcakeOption {
+	return func(c *models.Cupcake) { c.PriceCents = cents }
+}
+
+func WithSKU(sku string) CupcakeOption {
+	return func(c *models.Cupcake) { c.SKU = &sku }
+}
+
+func Unavailable() CupcakeOption {
+	return func(c *models.Cupcake) { c.IsAvailable = false }
+}
+
+type CouponOption func(*models.Coupon)
+
+func Coupon(opts ...CouponOption) models.Coupon {
+	coupon := models.Coupon{
+		Code:          "WELCOME10",
+		DiscountType:  models.DiscountTypePercentage,
+		DiscountValue: 10,
+		IsActive:      true,
+	}
+	for _, opt := range opts {
+		opt(&coupon)
+	}
+	return coupon
+}
+
+func WithCode(code string) CouponOption {
+	return func(c *models.Coupon) { c.Code = code }
+}
+
+func ExpiresAt(at time.Time) CouponOption {
+	return func(c *models.Coupon) { c.ExpiresAt = &at }
+}
+
+type PromotionOption func(*models.Promotion)
+
+// Promotion builds a 10% promotion for cupcakeID that is active from an hour
+// ago until an hour from now.
+func Promotion(cupcakeID uint, opts ...PromotionOption) models.Promotion {
+	now := time.Now()
+	promotion := models.Promotion{
+		CupcakeID:     cupcakeID,
+		DiscountType:  models.DiscountTypePercentage,
+		DiscountValue: 10,
+		StartsAt:      now.Add(-time.Hour),
+		EndsAt:        now.Add(time.Hour),
+	}
+	for _, opt := range opts {
+		opt(&promotion)
+	}
+	return promotion
+}
+
+func Between(start, end time.Time) PromotionOption {
+	return func(p *models.Promotion) {
+		p.StartsAt = start
+		p.EndsAt = end
+	}
+}
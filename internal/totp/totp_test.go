@@ -0,0 +1,82 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238(t *testing.T) {
+	// The RFC lists eight digits; six-digit codes are their last six.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		code, err := Code(rfcSecret, time.Unix(unix, 0))
+		require.NoError(t, err)
+		require.Equal(t, want, code, unix)
+	}
+
+	_, err := Code("not base32!", time.Now())
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step, ok := Validate(rfcSecret, "050471", now)
+	require.True(t, ok)
+	require.Equal(t, Step(now), step)
+
+	// Codes of the neighbouring periods pass, for clock drift.
+	_, ok = Validate(rfcSecret, "050471", now.Add(Period))
+	require.True(t, ok)
+	_, ok = Validate(rfcSecret, "050471", now.Add(-Period))
+	require.True(t, ok)
+	_, ok = Validate(rfcSecret, "050471", now.Add(3*Period))
+	require.False(t, ok)
+
+	for _, bad := range []string{"", "05047", "0504710", "123456"} {
+		_, ok := Validate(rfcSecret, bad, now)
+		require.False(t, ok, bad)
+	}
+	_, ok = Validate("not base32!", "050471", now)
+	require.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	require.Len(t, secret, 32)
+
+	other, err := GenerateSecret()
+	require.NoError(t, err)
+	require.NotEqual(t, secret, other)
+
+	code, err := Code(secret, time.Now())
+	require.NoError(t, err)
+	_, ok := Validate(secret, code, time.Now())
+	require.True(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(ProvisioningURI("Cupcake Store", "ana@example.com", rfcSecret))
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", uri.Scheme)
+	require.Equal(t, "totp", uri.Host)
+	require.Equal(t, "/Cupcake Store:ana@example.com", uri.Path)
+	require.Equal(t, rfcSecret, uri.Query().Get("secret"))
+	require.Equal(t, "Cupcake Store", uri.Query().Get("issuer"))
+	require.Equal(t, "6", uri.Query().Get("digits"))
+	require.Equal(t, "30", uri.Query().Get("period"))
+}
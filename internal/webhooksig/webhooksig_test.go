@@ -0,0 +1,97 @@
+package webhooksig
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func signedRequest(secret string, sent time.Time, nonce, body string) *http.Request {
+	timestamp := strconv.FormatInt(sent.Unix(), 10)
+	req := httptest.NewRequest("POST", "/webhooks/payments", strings.NewReader(body))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(NonceHeader, nonce)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, nonce, []byte(body)))
+	return req
+}
+
+func TestVerifier_Require(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	verifier := New(map[string]string{"payments": "pay-secret", "carrier": "ship-secret"}, nil)
+	verifier.now = func() time.Time { return now }
+
+	var received string
+	handler := verifier.Require("payments")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(signedRequest("pay-secret", now, "n-1", `{"status":"paid"}`))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, `{"status":"paid"}`, received)
+
+	w = serve(signedRequest("pay-secret", now, "n-1", `{"status":"paid"}`))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Contains(t, w.Body.String(), ErrReplayed.Error())
+
+	// Another integration's secret does not sign for this one.
+	w = serve(signedRequest("ship-secret", now, "n-2", `{"status":"paid"}`))
+	require.Contains(t, w.Body.String(), ErrInvalidSignature.Error())
+
+	tampered := signedRequest("pay-secret", now, "n-3", `{"status":"paid"}`)
+	tampered.Body = io.NopCloser(strings.NewReader(`{"status":"refunded"}`))
+	w = serve(tampered)
+	require.Contains(t, w.Body.String(), ErrInvalidSignature.Error())
+
+	w = serve(signedRequest("pay-secret", now.Add(-6*time.Minute), "n-4", `{}`))
+	require.Contains(t, w.Body.String(), ErrStaleTimestamp.Error())
+	w = serve(signedRequest("pay-secret", now.Add(6*time.Minute), "n-5", `{}`))
+	require.Contains(t, w.Body.String(), ErrStaleTimestamp.Error())
+
+	unsigned := signedRequest("pay-secret", now, "n-6", `{}`)
+	unsigned.Header.Del(NonceHeader)
+	w = serve(unsigned)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Contains(t, w.Body.String(), ErrMissingSignature.Error())
+
+	// The forged and stale requests did not use up their nonces.
+	w = serve(signedRequest("pay-secret", now, "n-2", `{}`))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	w = serve(signedRequest("pay-secret", now, "n-4", `{}`))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	verifier.Require("refunds")(handler).ServeHTTP(w, signedRequest("", now, "n-7", `{}`))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestMemoryNonces(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	nonces := NewMemoryNonces()
+	nonces.now = func() time.Time { return now }
+
+	fresh, err := nonces.Claim("payments", "n-1", now.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, fresh)
+	fresh, _ = nonces.Claim("payments", "n-1", now.Add(time.Minute))
+	require.False(t, fresh)
+	fresh, _ = nonces.Claim("carrier", "n-1", now.Add(time.Minute))
+	require.True(t, fresh)
+
+	now = now.Add(time.Minute)
+	fresh, _ = nonces.Claim("payments", "n-2", now.Add(time.Minute))
+	require.True(t, fresh)
+	require.Len(t, nonces.nonces, 1)
+}
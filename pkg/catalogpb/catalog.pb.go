@@ -0,0 +1,726 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: pkg/catalogpb/catalog.proto
+
+package catalogpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Cupcake struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                  uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Flavor              string                 `protobuf:"bytes,3,opt,name=flavor,proto3" json:"flavor,omitempty"`
+	Sku                 string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	PriceCents          int32                  `protobuf:"varint,5,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	IsAvailable         bool                   `protobuf:"varint,6,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	EffectivePriceCents *int32                 `protobuf:"varint,7,opt,name=effective_price_cents,json=effectivePriceCents,proto3,oneof" json:"effective_price_cents,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Cupcake) Reset() {
+	*x = Cupcake{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cupcake) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cupcake) ProtoMessage() {}
+
+func (x *Cupcake) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cupcake.ProtoReflect.Descriptor instead.
+func (*Cupcake) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cupcake) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Cupcake) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Cupcake) GetFlavor() string {
+	if x != nil {
+		return x.Flavor
+	}
+	return ""
+}
+
+func (x *Cupcake) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Cupcake) GetPriceCents() int32 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *Cupcake) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *Cupcake) GetEffectivePriceCents() int32 {
+	if x != nil && x.EffectivePriceCents != nil {
+		return *x.EffectivePriceCents
+	}
+	return 0
+}
+
+func (x *Cupcake) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Cupcake) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetCupcakeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetCupcakeRequest) Reset() {
+	*x = GetCupcakeRequest{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCupcakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCupcakeRequest) ProtoMessage() {}
+
+func (x *GetCupcakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCupcakeRequest.ProtoReflect.Descriptor instead.
+func (*GetCupcakeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetCupcakeRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetCupcakeBySKURequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sku string `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+}
+
+func (x *GetCupcakeBySKURequest) Reset() {
+	*x = GetCupcakeBySKURequest{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCupcakeBySKURequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCupcakeBySKURequest) ProtoMessage() {}
+
+func (x *GetCupcakeBySKURequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCupcakeBySKURequest.ProtoReflect.Descriptor instead.
+func (*GetCupcakeBySKURequest) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetCupcakeBySKURequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+type ListCupcakesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListCupcakesRequest) Reset() {
+	*x = ListCupcakesRequest{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCupcakesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCupcakesRequest) ProtoMessage() {}
+
+func (x *ListCupcakesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCupcakesRequest.ProtoReflect.Descriptor instead.
+func (*ListCupcakesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{3}
+}
+
+type ListCupcakesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cupcakes []*Cupcake `protobuf:"bytes,1,rep,name=cupcakes,proto3" json:"cupcakes,omitempty"`
+}
+
+func (x *ListCupcakesResponse) Reset() {
+	*x = ListCupcakesResponse{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCupcakesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCupcakesResponse) ProtoMessage() {}
+
+func (x *ListCupcakesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCupcakesResponse.ProtoReflect.Descriptor instead.
+func (*ListCupcakesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCupcakesResponse) GetCupcakes() []*Cupcake {
+	if x != nil {
+		return x.Cupcakes
+	}
+	return nil
+}
+
+type CreateCupcakeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Flavor     string  `protobuf:"bytes,2,opt,name=flavor,proto3" json:"flavor,omitempty"`
+	PriceCents int32   `protobuf:"varint,3,opt,name=price_cents,json=priceCents,proto3" json:"price_cents,omitempty"`
+	Sku        *string `protobuf:"bytes,4,opt,name=sku,proto3,oneof" json:"sku,omitempty"`
+}
+
+func (x *CreateCupcakeRequest) Reset() {
+	*x = CreateCupcakeRequest{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCupcakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCupcakeRequest) ProtoMessage() {}
+
+func (x *CreateCupcakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCupcakeRequest.ProtoReflect.Descriptor instead.
+func (*CreateCupcakeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateCupcakeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCupcakeRequest) GetFlavor() string {
+	if x != nil {
+		return x.Flavor
+	}
+	return ""
+}
+
+func (x *CreateCupcakeRequest) GetPriceCents() int32 {
+	if x != nil {
+		return x.PriceCents
+	}
+	return 0
+}
+
+func (x *CreateCupcakeRequest) GetSku() string {
+	if x != nil && x.Sku != nil {
+		return *x.Sku
+	}
+	return ""
+}
+
+type UpdateCupcakeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          uint32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Flavor      *string `protobuf:"bytes,3,opt,name=flavor,proto3,oneof" json:"flavor,omitempty"`
+	PriceCents  *int32  `protobuf:"varint,4,opt,name=price_cents,json=priceCents,proto3,oneof" json:"price_cents,omitempty"`
+	IsAvailable *bool   `protobuf:"varint,5,opt,name=is_available,json=isAvailable,proto3,oneof" json:"is_available,omitempty"`
+	Sku         *string `protobuf:"bytes,6,opt,name=sku,proto3,oneof" json:"sku,omitempty"`
+}
+
+func (x *UpdateCupcakeRequest) Reset() {
+	*x = UpdateCupcakeRequest{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCupcakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCupcakeRequest) ProtoMessage() {}
+
+func (x *UpdateCupcakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCupcakeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCupcakeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateCupcakeRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCupcakeRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCupcakeRequest) GetFlavor() string {
+	if x != nil && x.Flavor != nil {
+		return *x.Flavor
+	}
+	return ""
+}
+
+func (x *UpdateCupcakeRequest) GetPriceCents() int32 {
+	if x != nil && x.PriceCents != nil {
+		return *x.PriceCents
+	}
+	return 0
+}
+
+func (x *UpdateCupcakeRequest) GetIsAvailable() bool {
+	if x != nil && x.IsAvailable != nil {
+		return *x.IsAvailable
+	}
+	return false
+}
+
+func (x *UpdateCupcakeRequest) GetSku() string {
+	if x != nil && x.Sku != nil {
+		return *x.Sku
+	}
+	return ""
+}
+
+type DeleteCupcakeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteCupcakeRequest) Reset() {
+	*x = DeleteCupcakeRequest{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCupcakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCupcakeRequest) ProtoMessage() {}
+
+func (x *DeleteCupcakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCupcakeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCupcakeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteCupcakeRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteCupcakeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteCupcakeResponse) Reset() {
+	*x = DeleteCupcakeResponse{}
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCupcakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCupcakeResponse) ProtoMessage() {}
+
+func (x *DeleteCupcakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_catalogpb_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCupcakeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCupcakeResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_catalogpb_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+var File_pkg_catalogpb_catalog_proto protoreflect.FileDescriptor
+
+var file_pkg_catalogpb_catalog_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x70, 0x62, 0x2f,
+	0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x63,
+	0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x76,
+	0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xe4, 0x02, 0x0a, 0x07, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x6b,
+	0x75, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x6b, 0x75, 0x12, 0x1f, 0x0a, 0x0b,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x69, 0x73, 0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x37, 0x0a, 0x15, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x00, 0x52, 0x13, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x42,
+	0x18, 0x0a, 0x16, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x23, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2a,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x42, 0x79, 0x53, 0x4b,
+	0x55, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x6b, 0x75, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x6b, 0x75, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x4f, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x63, 0x75, 0x70,
+	0x63, 0x61, 0x6b, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x75,
+	0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x52, 0x08, 0x63, 0x75, 0x70, 0x63, 0x61, 0x6b,
+	0x65, 0x73, 0x22, 0x82, 0x01, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x75, 0x70,
+	0x63, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x15, 0x0a, 0x03, 0x73, 0x6b, 0x75, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x73, 0x6b, 0x75, 0x88, 0x01, 0x01, 0x42,
+	0x06, 0x0a, 0x04, 0x5f, 0x73, 0x6b, 0x75, 0x22, 0xfe, 0x01, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x17, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x66, 0x6c, 0x61,
+	0x76, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x06, 0x66, 0x6c, 0x61,
+	0x76, 0x6f, 0x72, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f,
+	0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52, 0x0a, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c,
+	0x69, 0x73, 0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x03, 0x52, 0x0b, 0x69, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x73, 0x6b, 0x75, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x04, 0x52, 0x03, 0x73, 0x6b, 0x75, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x66, 0x6c, 0x61, 0x76, 0x6f, 0x72, 0x42,
+	0x0e, 0x0a, 0x0c, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x42,
+	0x0f, 0x0a, 0x0d, 0x5f, 0x69, 0x73, 0x5f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65,
+	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x73, 0x6b, 0x75, 0x22, 0x26, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x17, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xb7, 0x04, 0x0a, 0x0e, 0x43, 0x61,
+	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0a,
+	0x47, 0x65, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x25, 0x2e, 0x63, 0x75, 0x70,
+	0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61,
+	0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x5a,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x42, 0x79, 0x53, 0x4b,
+	0x55, 0x12, 0x2a, 0x2e, 0x63, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61,
+	0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b,
+	0x65, 0x42, 0x79, 0x53, 0x4b, 0x55, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x63, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x61, 0x0a, 0x0c, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x73, 0x12, 0x27, 0x2e, 0x63, 0x75, 0x70,
+	0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x63, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61,
+	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x75, 0x70,
+	0x63, 0x61, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a,
+	0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x28,
+	0x2e, 0x63, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x75, 0x70, 0x63, 0x61,
+	0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75,
+	0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x56, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
+	0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x28, 0x2e, 0x63, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65,
+	0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x63, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c,
+	0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x64, 0x0a,
+	0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x12, 0x28,
+	0x2e, 0x63, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x63, 0x75, 0x70, 0x63, 0x61,
+	0x6b, 0x65, 0x2e, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x43, 0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6a, 0x75, 0x6c, 0x69, 0x6d, 0x6f, 0x6e, 0x74, 0x65, 0x69, 0x72, 0x6f, 0x2f, 0x63,
+	0x75, 0x70, 0x63, 0x61, 0x6b, 0x65, 0x2d, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x70, 0x62, 0x3b, 0x63, 0x61, 0x74, 0x61, 0x6c,
+	0x6f, 0x67, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_catalogpb_catalog_proto_rawDescOnce sync.Once
+	file_pkg_catalogpb_catalog_proto_rawDescData = file_pkg_catalogpb_catalog_proto_rawDesc
+)
+
+func file_pkg_catalogpb_catalog_proto_rawDescGZIP() []byte {
+	file_pkg_catalogpb_catalog_proto_rawDescOnce.Do(func() {
+		file_pkg_catalogpb_catalog_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_catalogpb_catalog_proto_rawDescData)
+	})
+	return file_pkg_catalogpb_catalog_proto_rawDescData
+}
+
+var file_pkg_catalogpb_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pkg_catalogpb_catalog_proto_goTypes = []any{
+	(*Cupcake)(nil),                // 0: cupcake.catalog.v1.Cupcake
+	(*GetCupcakeRequest)(nil),      // 1: cupcake.catalog.v1.GetCupcakeRequest
+	(*GetCupcakeBySKURequest)(nil), // 2: cupcake.catalog.v1.GetCupcakeBySKURequest
+	(*ListCupcakesRequest)(nil),    // 3: cupcake.catalog.v1.ListCupcakesRequest
+	(*ListCupcakesResponse)(nil),   // 4: cupcake.catalog.v1.ListCupcakesResponse
+	(*CreateCupcakeRequest)(nil),   // 5: cupcake.catalog.v1.CreateCupcakeRequest
+	(*UpdateCupcakeRequest)(nil),   // 6: cupcake.catalog.v1.UpdateCupcakeRequest
+	(*DeleteCupcakeRequest)(nil),   // 7: cupcake.catalog.v1.DeleteCupcakeRequest
+	(*DeleteCupcakeResponse)(nil),  // 8: cupcake.catalog.v1.DeleteCupcakeResponse
+	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+}
+var file_pkg_catalogpb_catalog_proto_depIdxs = []int32{
+	9, // 0: cupcake.catalog.v1.Cupcake.created_at:type_name -> google.protobuf.Timestamp
+	9, // 1: cupcake.catalog.v1.Cupcake.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: cupcake.catalog.v1.ListCupcakesResponse.cupcakes:type_name -> cupcake.catalog.v1.Cupcake
+	1, // 3: cupcake.catalog.v1.CatalogService.GetCupcake:input_type -> cupcake.catalog.v1.GetCupcakeRequest
+	2, // 4: cupcake.catalog.v1.CatalogService.GetCupcakeBySKU:input_type -> cupcake.catalog.v1.GetCupcakeBySKURequest
+	3, // 5: cupcake.catalog.v1.CatalogService.ListCupcakes:input_type -> cupcake.catalog.v1.ListCupcakesRequest
+	5, // 6: cupcake.catalog.v1.CatalogService.CreateCupcake:input_type -> cupcake.catalog.v1.CreateCupcakeRequest
+	6, // 7: cupcake.catalog.v1.CatalogService.UpdateCupcake:input_type -> cupcake.catalog.v1.UpdateCupcakeRequest
+	7, // 8: cupcake.catalog.v1.CatalogService.DeleteCupcake:input_type -> cupcake.catalog.v1.DeleteCupcakeRequest
+	0, // 9: cupcake.catalog.v1.CatalogService.GetCupcake:output_type -> cupcake.catalog.v1.Cupcake
+	0, // 10: cupcake.catalog.v1.CatalogService.GetCupcakeBySKU:output_type -> cupcake.catalog.v1.Cupcake
+	4, // 11: cupcake.catalog.v1.CatalogService.ListCupcakes:output_type -> cupcake.catalog.v1.ListCupcakesResponse
+	0, // 12: cupcake.catalog.v1.CatalogService.CreateCupcake:output_type -> cupcake.catalog.v1.Cupcake
+	0, // 13: cupcake.catalog.v1.CatalogService.UpdateCupcake:output_type -> cupcake.catalog.v1.Cupcake
+	8, // 14: cupcake.catalog.v1.CatalogService.DeleteCupcake:output_type -> cupcake.catalog.v1.DeleteCupcakeResponse
+	9, // [9:15] is the sub-list for method output_type
+	3, // [3:9] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_pkg_catalogpb_catalog_proto_init() }
+func file_pkg_catalogpb_catalog_proto_init() {
+	if File_pkg_catalogpb_catalog_proto != nil {
+		return
+	}
+	file_pkg_catalogpb_catalog_proto_msgTypes[0].OneofWrappers = []any{}
+	file_pkg_catalogpb_catalog_proto_msgTypes[5].OneofWrappers = []any{}
+	file_pkg_catalogpb_catalog_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_catalogpb_catalog_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_catalogpb_catalog_proto_goTypes,
+		DependencyIndexes: file_pkg_catalogpb_catalog_proto_depIdxs,
+		MessageInfos:      file_pkg_catalogpb_catalog_proto_msgTypes,
+	}.Build()
+	File_pkg_catalogpb_catalog_proto = out.File
+	file_pkg_catalogpb_catalog_proto_rawDesc = nil
+	file_pkg_catalogpb_catalog_proto_goTypes = nil
+	file_pkg_catalogpb_catalog_proto_depIdxs = nil
+}
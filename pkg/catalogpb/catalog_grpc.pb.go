@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pkg/catalogpb/catalog.proto
+
+package catalogpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CatalogService_GetCupcake_FullMethodName      = "/cupcake.catalog.v1.CatalogService/GetCupcake"
+	CatalogService_GetCupcakeBySKU_FullMethodName = "/cupcake.catalog.v1.CatalogService/GetCupcakeBySKU"
+	CatalogService_ListCupcakes_FullMethodName    = "/cupcake.catalog.v1.CatalogService/ListCupcakes"
+	CatalogService_CreateCupcake_FullMethodName   = "/cupcake.catalog.v1.CatalogService/CreateCupcake"
+	CatalogService_UpdateCupcake_FullMethodName   = "/cupcake.catalog.v1.CatalogService/UpdateCupcake"
+	CatalogService_DeleteCupcake_FullMethodName   = "/cupcake.catalog.v1.CatalogService/DeleteCupcake"
+)
+
+// CatalogServiceClient is the client API for CatalogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CatalogServiceClient interface {
+	GetCupcake(ctx context.Context, in *GetCupcakeRequest, opts ...grpc.CallOption) (*Cupcake, error)
+	GetCupcakeBySKU(ctx context.Context, in *GetCupcakeBySKURequest, opts ...grpc.CallOption) (*Cupcake, error)
+	ListCupcakes(ctx context.Context, in *ListCupcakesRequest, opts ...grpc.CallOption) (*ListCupcakesResponse, error)
+	CreateCupcake(ctx context.Context, in *CreateCupcakeRequest, opts ...grpc.CallOption) (*Cupcake, error)
+	UpdateCupcake(ctx context.Context, in *UpdateCupcakeRequest, opts ...grpc.CallOption) (*Cupcake, error)
+	DeleteCupcake(ctx context.Context, in *DeleteCupcakeRequest, opts ...grpc.CallOption) (*DeleteCupcakeResponse, error)
+}
+
+type catalogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogServiceClient(cc grpc.ClientConnInterface) CatalogServiceClient {
+	return &catalogServiceClient{cc}
+}
+
+func (c *catalogServiceClient) GetCupcake(ctx context.Context, in *GetCupcakeRequest, opts ...grpc.CallOption) (*Cupcake, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cupcake)
+	err := c.cc.Invoke(ctx, CatalogService_GetCupcake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetCupcakeBySKU(ctx context.Context, in *GetCupcakeBySKURequest, opts ...grpc.CallOption) (*Cupcake, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cupcake)
+	err := c.cc.Invoke(ctx, CatalogService_GetCupcakeBySKU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListCupcakes(ctx context.Context, in *ListCupcakesRequest, opts ...grpc.CallOption) (*ListCupcakesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCupcakesResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListCupcakes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CreateCupcake(ctx context.Context, in *CreateCupcakeRequest, opts ...grpc.CallOption) (*Cupcake, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cupcake)
+	err := c.cc.Invoke(ctx, CatalogService_CreateCupcake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateCupcake(ctx context.Context, in *UpdateCupcakeRequest, opts ...grpc.CallOption) (*Cupcake, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Cupcake)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateCupcake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DeleteCupcake(ctx context.Context, in *DeleteCupcakeRequest, opts ...grpc.CallOption) (*DeleteCupcakeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCupcakeResponse)
+	err := c.cc.Invoke(ctx, CatalogService_DeleteCupcake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogServiceServer is the server API for CatalogService service.
+// All implementations must embed UnimplementedCatalogServiceServer
+// for forward compatibility.
+type CatalogServiceServer interface {
+	GetCupcake(context.Context, *GetCupcakeRequest) (*Cupcake, error)
+	GetCupcakeBySKU(context.Context, *GetCupcakeBySKURequest) (*Cupcake, error)
+	ListCupcakes(context.Context, *ListCupcakesRequest) (*ListCupcakesResponse, error)
+	CreateCupcake(context.Context, *CreateCupcakeRequest) (*Cupcake, error)
+	UpdateCupcake(context.Context, *UpdateCupcakeRequest) (*Cupcake, error)
+	DeleteCupcake(context.Context, *DeleteCupcakeRequest) (*DeleteCupcakeResponse, error)
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+// UnimplementedCatalogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatalogServiceServer struct{}
+
+func (UnimplementedCatalogServiceServer) GetCupcake(context.Context, *GetCupcakeRequest) (*Cupcake, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCupcake not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetCupcakeBySKU(context.Context, *GetCupcakeBySKURequest) (*Cupcake, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCupcakeBySKU not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListCupcakes(context.Context, *ListCupcakesRequest) (*ListCupcakesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCupcakes not implemented")
+}
+func (UnimplementedCatalogServiceServer) CreateCupcake(context.Context, *CreateCupcakeRequest) (*Cupcake, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCupcake not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateCupcake(context.Context, *UpdateCupcakeRequest) (*Cupcake, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCupcake not implemented")
+}
+func (UnimplementedCatalogServiceServer) DeleteCupcake(context.Context, *DeleteCupcakeRequest) (*DeleteCupcakeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCupcake not implemented")
+}
+func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
+func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogServiceServer will
+// result in compilation errors.
+type UnsafeCatalogServiceServer interface {
+	mustEmbedUnimplementedCatalogServiceServer()
+}
+
+func RegisterCatalogServiceServer(s grpc.ServiceRegistrar, srv CatalogServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCatalogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CatalogService_ServiceDesc, srv)
+}
+
+func _CatalogService_GetCupcake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCupcakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetCupcake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetCupcake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetCupcake(ctx, req.(*GetCupcakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetCupcakeBySKU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCupcakeBySKURequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetCupcakeBySKU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetCupcakeBySKU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetCupcakeBySKU(ctx, req.(*GetCupcakeBySKURequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListCupcakes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCupcakesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListCupcakes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListCupcakes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListCupcakes(ctx, req.(*ListCupcakesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CreateCupcake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCupcakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateCupcake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CreateCupcake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateCupcake(ctx, req.(*CreateCupcakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateCupcake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCupcakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateCupcake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateCupcake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateCupcake(ctx, req.(*UpdateCupcakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeleteCupcake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCupcakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteCupcake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeleteCupcake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteCupcake(ctx, req.(*DeleteCupcakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cupcake.catalog.v1.CatalogService",
+	HandlerType: (*CatalogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCupcake",
+			Handler:    _CatalogService_GetCupcake_Handler,
+		},
+		{
+			MethodName: "GetCupcakeBySKU",
+			Handler:    _CatalogService_GetCupcakeBySKU_Handler,
+		},
+		{
+			MethodName: "ListCupcakes",
+			Handler:    _CatalogService_ListCupcakes_Handler,
+		},
+		{
+			MethodName: "CreateCupcake",
+			Handler:    _CatalogService_CreateCupcake_Handler,
+		},
+		{
+			MethodName: "UpdateCupcake",
+			Handler:    _CatalogService_UpdateCupcake_Handler,
+		},
+		{
+			MethodName: "DeleteCupcake",
+			Handler:    _CatalogService_DeleteCupcake_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/catalogpb/catalog.proto",
+}
@@ -0,0 +1,254 @@
+// Package client is a typed Go client for the cupcake store HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	adminPrefix       = "/api/v1/admin"
+	defaultMaxRetries = 3
+	defaultBackoff    = 100 * time.Millisecond
+)
+
+type Cupcake struct {
+	ID                  uint       `json:"id"`
+	Name                string     `json:"name"`
+	Flavor              string     `json:"flavor"`
+	SKU                 *string    `json:"sku,omitempty"`
+	Slug                *string    `json:"slug,omitempty"`
+	Description         string     `json:"description,omitempty"`
+	DisplayOrder        int        `json:"display_order"`
+	PriceCents          int        `json:"price_cents"`
+	IsAvailable         bool       `json:"is_available"`
+	AvailableFrom       *time.Time `json:"available_from,omitempty"`
+	PreOrder            bool       `json:"pre_order,omitempty"`
+	EffectivePriceCents *int       `json:"effective_price_cents,omitempty"`
+	Currency            string     `json:"currency,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type CreateCupcakeRequest struct {
+	Name          string     `json:"name"`
+	Flavor        string     `json:"flavor"`
+	PriceCents    int        `json:"price_cents"`
+	SKU           *string    `json:"sku,omitempty"`
+	Slug          *string    `json:"slug,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	DisplayOrder  int        `json:"display_order,omitempty"`
+	AvailableFrom *time.Time `json:"available_from,omitempty"`
+}
+
+type UpdateCupcakeRequest struct {
+	Name          *string    `json:"name,omitempty"`
+	Flavor        *string    `json:"flavor,omitempty"`
+	PriceCents    *int       `json:"price_cents,omitempty"`
+	IsAvailable   *bool      `json:"is_available,omitempty"`
+	SKU           *string    `json:"sku,omitempty"`
+	Slug          *string    `json:"slug,omitempty"`
+	Description   *string    `json:"description,omitempty"`
+	DisplayOrder  *int       `json:"display_order,omitempty"`
+	AvailableFrom *time.Time `json:"available_from,omitempty"`
+}
+
+// APIError is returned for any non-2xx response. A 422 also lists the
+// invalid fields in Fields.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Fields     []FieldError
+}
+
+// FieldError is one validation rule a request field failed.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("cupcake store: %d %s", e.StatusCode, e.Message)
+}
+
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+type Client struct {
+	baseURL    string
+	adminURL   string
+	adminToken string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithAdminURL sends admin API requests to adminURL, for servers that
+// serve it on a separate port. By default they go to the base URL.
+func WithAdminURL(adminURL string) Option {
+	return func(c *Client) { c.adminURL = strings.TrimRight(adminURL, "/") }
+}
+
+// WithAdminToken sets the bearer token sent with admin API requests.
+func WithAdminToken(token string) Option {
+	return func(c *Client) { c.adminToken = token }
+}
+
+// WithRetries sets how many times idempotent requests are retried after a
+// network error or a 429/5xx response, and the initial backoff between them.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		adminURL:   strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) CreateCupcake(ctx context.Context, req *CreateCupcakeRequest) (*Cupcake, error) {
+	var cupcake Cupcake
+	if err := c.do(ctx, http.MethodPost, adminPrefix+"/cupcakes", req, &cupcake); err != nil {
+		return nil, err
+	}
+	return &cupcake, nil
+}
+
+func (c *Client) GetCupcake(ctx context.Context, id uint) (*Cupcake, error) {
+	var cupcake Cupcake
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/v1/cupcakes/%d", id), nil, &cupcake); err != nil {
+		return nil, err
+	}
+	return &cupcake, nil
+}
+
+func (c *Client) ListCupcakes(ctx context.Context) ([]Cupcake, error) {
+	var cupcakes []Cupcake
+	if err := c.do(ctx, http.MethodGet, "/api/v1/cupcakes", nil, &cupcakes); err != nil {
+		return nil, err
+	}
+	return cupcakes, nil
+}
+
+func (c *Client) UpdateCupcake(ctx context.Context, id uint, req *UpdateCupcakeRequest) (*Cupcake, error) {
+	var cupcake Cupcake
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("%s/cupcakes/%d", adminPrefix, id), req, &cupcake); err != nil {
+		return nil, err
+	}
+	return &cupcake, nil
+}
+
+func (c *Client) DeleteCupcake(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/cupcakes/%d", adminPrefix, id), nil, nil)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	retries := 0
+	if method != http.MethodPost {
+		retries = c.maxRetries
+	}
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, path, payload, out)
+		if err == nil || attempt >= retries || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, out interface{}) error {
+	baseURL := c.baseURL
+	admin := strings.HasPrefix(path, adminPrefix)
+	if admin {
+		baseURL = c.adminURL
+	}
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if admin && c.adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error  string       `json:"error"`
+			Errors []FieldError `json:"errors"`
+		}
+		data, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(data, &apiErr) != nil || apiErr.Error == "" {
+			apiErr.Error = strings.TrimSpace(string(data))
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: apiErr.Error, Fields: apiErr.Errors}
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/julimonteiro/cupcake-store/internal/config"
+	"github.com/julimonteiro/cupcake-store/internal/database"
+	"github.com/julimonteiro/cupcake-store/internal/router"
+	"github.com/stretchr/testify/require"
+)
+
+// testAdminToken is the admin token of the servers from newTestServer.
+const testAdminToken = "s3cret"
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	db, err := database.Init(&config.Config{DBDialect: "sqlite", DBDSN: ":memory:", LogLevel: "error"})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(router.Setup(db, router.Options{AdminToken: testAdminToken}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_CupcakeLifecycle(t *testing.T) {
+	server := newTestServer(t)
+	client := New(server.URL, WithAdminToken(testAdminToken))
+	ctx := context.Background()
+
+	sku := "CC-CHOC-01"
+	created, err := client.CreateCupcake(ctx, &CreateCupcakeRequest{
+		Name:       "Chocolate",
+		Flavor:     "Cocoa",
+		PriceCents: 1200,
+		SKU:        &sku,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+	require.True(t, created.IsAvailable)
+
+	fetched, err := client.GetCupcake(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Chocolate", fetched.Name)
+	require.Equal(t, &sku, fetched.SKU)
+
+	price := 1500
+	updated, err := client.UpdateCupcake(ctx, created.ID, &UpdateCupcakeRequest{PriceCents: &price})
+	require.NoError(t, err)
+	require.Equal(t, 1500, updated.PriceCents)
+
+	cupcakes, err := client.ListCupcakes(ctx)
+	require.NoError(t, err)
+	require.Len(t, cupcakes, 1)
+
+	require.NoError(t, client.DeleteCupcake(ctx, created.ID))
+
+	_, err = client.GetCupcake(ctx, created.ID)
+	require.True(t, IsNotFound(err))
+}
+
+func TestClient_SplitAdminAPI(t *testing.T) {
+	db, err := database.Init(&config.Config{DBDialect: "sqlite", DBDSN: ":memory:", LogLevel: "error"})
+	require.NoError(t, err)
+
+	public, admin := router.SetupSplit(db, router.Options{AdminToken: "s3cret"})
+	publicServer := httptest.NewServer(public)
+	t.Cleanup(publicServer.Close)
+	adminServer := httptest.NewServer(admin)
+	t.Cleanup(adminServer.Close)
+	ctx := context.Background()
+
+	_, err = New(publicServer.URL).CreateCupcake(ctx, &CreateCupcakeRequest{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1200})
+	require.True(t, IsNotFound(err))
+
+	_, err = New(publicServer.URL, WithAdminURL(adminServer.URL)).CreateCupcake(ctx, &CreateCupcakeRequest{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1200})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+
+	client := New(publicServer.URL, WithAdminURL(adminServer.URL), WithAdminToken("s3cret"))
+	created, err := client.CreateCupcake(ctx, &CreateCupcakeRequest{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 1200})
+	require.NoError(t, err)
+
+	fetched, err := client.GetCupcake(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Chocolate", fetched.Name)
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		call            func(c *Client) error
+		expectedStatus  int
+		expectedMessage string
+		expectedFields  []FieldError
+	}{
+		{
+			name: "validation error",
+			call: func(c *Client) error {
+				_, err := c.CreateCupcake(context.Background(), &CreateCupcakeRequest{Name: "A", Flavor: "Cocoa", PriceCents: 100})
+				return err
+			},
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedMessage: "validation failed",
+			expectedFields:  []FieldError{{Field: "name", Rule: "min_length", Message: "name must have at least 2 characters"}},
+		},
+		{
+			name: "not found",
+			call: func(c *Client) error {
+				_, err := c.GetCupcake(context.Background(), 999)
+				return err
+			},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "cupcake not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t)
+
+			err := tt.call(New(server.URL, WithAdminToken(testAdminToken)))
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			require.Equal(t, tt.expectedStatus, apiErr.StatusCode)
+			require.Equal(t, tt.expectedMessage, apiErr.Message)
+			require.Equal(t, tt.expectedFields, apiErr.Fields)
+		})
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        func(c *Client) error
+		failures      int32
+		status        int
+		expectedCalls int32
+		expectError   bool
+	}{
+		{
+			name:          "GET retries server errors until success",
+			method:        func(c *Client) error { _, err := c.ListCupcakes(context.Background()); return err },
+			failures:      2,
+			status:        http.StatusServiceUnavailable,
+			expectedCalls: 3,
+		},
+		{
+			name:          "GET gives up after max retries",
+			method:        func(c *Client) error { _, err := c.ListCupcakes(context.Background()); return err },
+			failures:      10,
+			status:        http.StatusInternalServerError,
+			expectedCalls: 4,
+			expectError:   true,
+		},
+		{
+			name:          "client errors are not retried",
+			method:        func(c *Client) error { _, err := c.GetCupcake(context.Background(), 1); return err },
+			failures:      10,
+			status:        http.StatusNotFound,
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name: "POST is never retried",
+			method: func(c *Client) error {
+				_, err := c.CreateCupcake(context.Background(), &CreateCupcakeRequest{Name: "Chocolate", Flavor: "Cocoa", PriceCents: 100})
+				return err
+			},
+			failures:      10,
+			status:        http.StatusServiceUnavailable,
+			expectedCalls: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			err := tt.method(New(server.URL, WithRetries(3, time.Millisecond)))
+
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestClient_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := New(server.URL, WithRetries(10, time.Second)).ListCupcakes(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,8 @@
+// Package web embeds the frontend so the binary serves it regardless of
+// the working directory it is started from.
+package web
+
+import "embed"
+
+//go:embed index.html
+var Assets embed.FS